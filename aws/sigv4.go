@@ -0,0 +1,136 @@
+package aws
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+)
+
+// maxPresignExpiry is the longest lifetime accepted for a SigV4 pre-signed URL
+const maxPresignExpiry = 7 * 24 * time.Hour
+
+// PresignOptions - parameters for SignV4URL
+type PresignOptions struct {
+	// Credentials to sign with - when nil, the default credential chain is used
+	Credentials *credentials.Credentials
+	// SignTime is the time the signature is valid from - defaults to now
+	SignTime time.Time
+	// Method is the HTTP method the URL will be used with - defaults to GET
+	Method string
+	// Service is the signing name of the service (e.g. "s3") - when empty, it's
+	// inferred from the URL's host
+	Service string
+	// Region is the region to sign for - when empty, it's inferred from the
+	// URL's host, or from the default AWS configuration
+	Region string
+	// Expires is how long the URL is valid for - defaults to 15 minutes
+	Expires time.Duration
+}
+
+// SignV4URL - returns a pre-signed copy of the given URL, with an AWS
+// Signature Version 4 added to its query string.
+func SignV4URL(rawURL string, opts PresignOptions) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse URL %q: %w", rawURL, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("URL %q must be absolute", rawURL)
+	}
+
+	if opts.Method == "" {
+		opts.Method = http.MethodGet
+	}
+	if opts.Expires == 0 {
+		opts.Expires = 15 * time.Minute
+	}
+	if opts.Expires < 0 || opts.Expires > maxPresignExpiry {
+		return "", fmt.Errorf("expiry %s out of range: must be between 1s and %s", opts.Expires, maxPresignExpiry)
+	}
+	if opts.SignTime.IsZero() {
+		opts.SignTime = time.Now()
+	}
+
+	service, region := endpointServiceRegion(u.Hostname())
+	if opts.Service == "" {
+		opts.Service = service
+	}
+	if opts.Service == "" {
+		return "", fmt.Errorf("could not determine the service to sign for from host %q, set the service explicitly", u.Host)
+	}
+
+	if opts.Region == "" {
+		opts.Region = region
+	}
+	if opts.Region == "" || opts.Credentials == nil {
+		sess := SDKSession()
+		if opts.Region == "" {
+			opts.Region = aws.StringValue(sess.Config.Region)
+		}
+		if opts.Credentials == nil {
+			opts.Credentials = sess.Config.Credentials
+		}
+	}
+	if opts.Region == "" {
+		return "", fmt.Errorf("could not determine the region to sign for, set the region explicitly")
+	}
+
+	req, err := http.NewRequest(strings.ToUpper(opts.Method), u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+
+	signer := v4.NewSigner(opts.Credentials)
+	_, err = signer.Presign(req, nil, opts.Service, opts.Region, opts.Expires, opts.SignTime)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign URL: %w", err)
+	}
+
+	return req.URL.String(), nil
+}
+
+// endpointServiceRegion infers the service signing name and region from an
+// AWS endpoint hostname, like "sqs.us-east-1.amazonaws.com" or
+// "bucket.s3.eu-west-1.amazonaws.com". Either may be empty when the host
+// doesn't contain them.
+func endpointServiceRegion(host string) (service, region string) {
+	labels := strings.Split(strings.ToLower(host), ".")
+	n := len(labels)
+	if n < 3 || labels[n-2] != "amazonaws" || labels[n-1] != "com" {
+		return "", ""
+	}
+
+	if isRegion(labels[n-3]) {
+		region = labels[n-3]
+		if n >= 4 {
+			service = labels[n-4]
+		}
+
+		return service, region
+	}
+
+	return labels[n-3], ""
+}
+
+// isRegion returns whether s looks like a region name (e.g. "us-west-2")
+func isRegion(s string) bool {
+	parts := strings.Split(s, "-")
+	if len(parts) < 3 {
+		return false
+	}
+
+	last := parts[len(parts)-1]
+	for _, r := range last {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return last != ""
+}
@@ -1,6 +1,9 @@
 package aws
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/sts"
 )
@@ -8,16 +11,36 @@ import (
 // STS -
 type STS struct {
 	identifier func() CallerIdentitifier
+	assumer    func() RoleAssumer
 	cache      map[string]interface{}
 }
 
-var identifierClient CallerIdentitifier
+var (
+	identifierClient CallerIdentitifier
+	assumerClient    RoleAssumer
+)
 
 // CallerIdentitifier - an interface to wrap GetCallerIdentity
 type CallerIdentitifier interface {
 	GetCallerIdentity(*sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error)
 }
 
+// RoleAssumer - an interface to wrap AssumeRole
+type RoleAssumer interface {
+	AssumeRole(*sts.AssumeRoleInput) (*sts.AssumeRoleOutput, error)
+}
+
+// AssumeRoleOptions - optional parameters for AssumeRole
+type AssumeRoleOptions struct {
+	// SessionName identifies the session in CloudTrail logs - defaults to
+	// "gomplate"
+	SessionName string
+	// ExternalID is required by some roles that are assumed by third parties
+	ExternalID string
+	// Duration of the session - when zero the role's default is used
+	Duration time.Duration
+}
+
 // NewSTS -
 func NewSTS(_ ClientOptions) *STS {
 	return &STS{
@@ -28,6 +51,13 @@ func NewSTS(_ ClientOptions) *STS {
 			}
 			return identifierClient
 		},
+		assumer: func() RoleAssumer {
+			if assumerClient == nil {
+				session := SDKSession()
+				assumerClient = sts.New(session)
+			}
+			return assumerClient
+		},
 		cache: make(map[string]interface{}),
 	}
 }
@@ -74,3 +104,41 @@ func (s *STS) Arn() (string, error) {
 	}
 	return aws.StringValue(cid.Arn), nil
 }
+
+// AssumeRole - assumes the given role, returning a set of temporary
+// credentials. Credentials are cached for each role and set of options, so
+// repeated calls don't result in repeated API calls.
+func (s *STS) AssumeRole(roleARN string, opts AssumeRoleOptions) (*sts.Credentials, error) {
+	if opts.SessionName == "" {
+		opts.SessionName = "gomplate"
+	}
+
+	key := fmt.Sprintf("AssumeRole|%s|%s|%s|%s", roleARN, opts.SessionName, opts.ExternalID, opts.Duration)
+	if val, ok := s.cache[key]; ok {
+		if c, ok := val.(*sts.Credentials); ok {
+			return c, nil
+		}
+	}
+
+	in := &sts.AssumeRoleInput{
+		RoleArn:         aws.String(roleARN),
+		RoleSessionName: aws.String(opts.SessionName),
+	}
+	if opts.ExternalID != "" {
+		in.ExternalId = aws.String(opts.ExternalID)
+	}
+	if opts.Duration != 0 {
+		in.DurationSeconds = aws.Int64(int64(opts.Duration / time.Second))
+	}
+
+	out, err := s.assumer().AssumeRole(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assume role %q: %w", roleARN, err)
+	}
+	if out.Credentials == nil {
+		return nil, fmt.Errorf("failed to assume role %q: no credentials returned", roleARN)
+	}
+
+	s.cache[key] = out.Credentials
+	return out.Credentials, nil
+}
@@ -3,6 +3,7 @@ package aws
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 
@@ -105,3 +106,62 @@ func (c *DummyCallerIdentifier) GetCallerIdentity(*sts.GetCallerIdentityInput) (
 	}
 	return out, nil
 }
+
+func TestAssumeRole(t *testing.T) {
+	s := NewSTS(ClientOptions{})
+	ra := &DummyRoleAssumer{}
+	s.assumer = func() RoleAssumer {
+		return ra
+	}
+
+	creds, err := s.AssumeRole("arn:aws:iam::123456789012:role/foo", AssumeRoleOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "AKID", aws.StringValue(creds.AccessKeyId))
+	assert.Equal(t, "arn:aws:iam::123456789012:role/foo", aws.StringValue(ra.in.RoleArn))
+	assert.Equal(t, "gomplate", aws.StringValue(ra.in.RoleSessionName))
+	assert.Nil(t, ra.in.ExternalId)
+	assert.Nil(t, ra.in.DurationSeconds)
+
+	// cached
+	_, err = s.AssumeRole("arn:aws:iam::123456789012:role/foo", AssumeRoleOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, ra.calls)
+
+	_, err = s.AssumeRole("arn:aws:iam::123456789012:role/bar", AssumeRoleOptions{
+		SessionName: "sess",
+		ExternalID:  "ext",
+		Duration:    2 * time.Hour,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, ra.calls)
+	assert.Equal(t, "sess", aws.StringValue(ra.in.RoleSessionName))
+	assert.Equal(t, "ext", aws.StringValue(ra.in.ExternalId))
+	assert.Equal(t, int64(7200), aws.Int64Value(ra.in.DurationSeconds))
+
+	ra.err = errors.New("ERRORED")
+	_, err = s.AssumeRole("arn:aws:iam::123456789012:role/baz", AssumeRoleOptions{})
+	require.EqualError(t, err, `failed to assume role "arn:aws:iam::123456789012:role/baz": ERRORED`)
+}
+
+type DummyRoleAssumer struct {
+	err   error
+	in    *sts.AssumeRoleInput
+	calls int
+}
+
+func (r *DummyRoleAssumer) AssumeRole(in *sts.AssumeRoleInput) (*sts.AssumeRoleOutput, error) {
+	r.calls++
+	r.in = in
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return &sts.AssumeRoleOutput{
+		Credentials: &sts.Credentials{
+			AccessKeyId:     aws.String("AKID"),
+			SecretAccessKey: aws.String("SECRET"),
+			SessionToken:    aws.String("TOKEN"),
+			Expiration:      aws.Time(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)),
+		},
+	}, nil
+}
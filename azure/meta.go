@@ -0,0 +1,270 @@
+// Package azure contains functions for interacting with the Azure Instance
+// Metadata Service (IMDS), including managed identity tokens.
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hairyhenderson/gomplate/v4/env"
+)
+
+// DefaultEndpoint is the address of the Azure Instance Metadata Service.
+var DefaultEndpoint = "http://169.254.169.254"
+
+const (
+	// instanceAPIVersion is the IMDS API version used for instance metadata
+	instanceAPIVersion = "2021-02-01"
+	// identityAPIVersion is the IMDS API version used for managed identity
+	identityAPIVersion = "2018-02-01"
+)
+
+// DefaultResource is the resource tokens are requested for when none is given.
+const DefaultResource = "https://management.azure.com/"
+
+var (
+	// co is a ClientOptions populated from the environment.
+	co ClientOptions
+	// coInit ensures that `co` is only set once.
+	coInit sync.Once
+)
+
+// ClientOptions contains various user-specifiable options for a MetaClient.
+type ClientOptions struct {
+	Timeout time.Duration
+}
+
+// GetClientOptions - Centralised reading of AZURE_TIMEOUT
+func GetClientOptions() ClientOptions {
+	coInit.Do(func() {
+		timeout := env.Getenv("AZURE_TIMEOUT")
+		if timeout == "" {
+			timeout = "500"
+		}
+
+		t, err := strconv.Atoi(timeout)
+		if err != nil {
+			panic(fmt.Errorf("invalid AZURE_TIMEOUT value '%s' - must be an integer: %w", timeout, err))
+		}
+
+		co.Timeout = time.Duration(t) * time.Millisecond
+	})
+	return co
+}
+
+// MetaClient is used to access the Azure Instance Metadata Service.
+type MetaClient struct {
+	ctx      context.Context
+	client   *http.Client
+	cache    map[string]string
+	tokens   map[string]*token
+	endpoint string
+	options  ClientOptions
+	mu       sync.Mutex
+}
+
+// token is a cached managed identity access token
+type token struct {
+	ExpiresOn   time.Time
+	AccessToken string
+}
+
+// NewMetaClient constructs a new MetaClient with the given ClientOptions. If the
+// environment contains a variable named `AZURE_IMDS_ENDPOINT`, the client will
+// address that, if not the value of `DefaultEndpoint` is used.
+func NewMetaClient(ctx context.Context, options ClientOptions) *MetaClient {
+	endpoint := env.Getenv("AZURE_IMDS_ENDPOINT")
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+
+	return &MetaClient{
+		ctx:      ctx,
+		cache:    make(map[string]string),
+		tokens:   make(map[string]*token),
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		options:  options,
+	}
+}
+
+// Meta retrieves a value from the instance metadata, returning the given default
+// if the service is unavailable or the requested key does not exist. Keys are
+// paths like `compute/location` or `network/interface/0/ipv4/ipAddress/0/privateIpAddress`.
+func (c *MetaClient) Meta(key string, def ...string) (string, error) {
+	u := c.endpoint + "/metadata/instance/" + strings.TrimPrefix(key, "/") +
+		"?api-version=" + instanceAPIVersion + "&format=text"
+
+	body, ok, err := c.retrieve(u)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return returnDefault(def), nil
+	}
+
+	return strings.TrimSpace(string(body)), nil
+}
+
+// SubscriptionID returns the ID of the subscription the instance belongs to.
+func (c *MetaClient) SubscriptionID(def ...string) (string, error) {
+	return c.Meta("compute/subscriptionId", def...)
+}
+
+// ResourceGroup returns the name of the resource group the instance belongs to.
+func (c *MetaClient) ResourceGroup(def ...string) (string, error) {
+	return c.Meta("compute/resourceGroupName", def...)
+}
+
+// Location returns the Azure region the instance is running in (e.g. `westus2`).
+func (c *MetaClient) Location(def ...string) (string, error) {
+	return c.Meta("compute/location", def...)
+}
+
+// TenantID returns the ID of the Microsoft Entra tenant the instance's managed
+// identity belongs to.
+func (c *MetaClient) TenantID(def ...string) (string, error) {
+	u := c.endpoint + "/metadata/identity/info?api-version=" + identityAPIVersion
+
+	body, ok, err := c.retrieve(u)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return returnDefault(def), nil
+	}
+
+	info := struct {
+		TenantID string `json:"tenantId"`
+	}{}
+	if err := json.Unmarshal(body, &info); err != nil {
+		return "", fmt.Errorf("failed to parse identity info: %w", err)
+	}
+
+	return info.TenantID, nil
+}
+
+// AccessToken returns a managed identity access token for the given resource
+// (or DefaultResource). When clientID is set, a user-assigned identity is
+// used. Tokens are cached until shortly before they expire.
+func (c *MetaClient) AccessToken(resource, clientID string) (string, error) {
+	if resource == "" {
+		resource = DefaultResource
+	}
+
+	key := resource + "|" + clientID
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if tok, ok := c.tokens[key]; ok && time.Until(tok.ExpiresOn) > 5*time.Minute {
+		return tok.AccessToken, nil
+	}
+
+	q := url.Values{}
+	q.Set("api-version", identityAPIVersion)
+	q.Set("resource", resource)
+	if clientID != "" {
+		q.Set("client_id", clientID)
+	}
+
+	u := c.endpoint + "/metadata/identity/oauth2/token?" + q.Encode()
+
+	body, ok, err := c.request(u)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", fmt.Errorf("failed to get managed identity token for %s: %s", resource, strings.TrimSpace(string(body)))
+	}
+
+	resp := struct {
+		AccessToken string `json:"access_token"`
+		ExpiresOn   string `json:"expires_on"`
+	}{}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", fmt.Errorf("failed to parse token response: %w", err)
+	}
+
+	tok := &token{AccessToken: resp.AccessToken}
+	if exp, err := strconv.ParseInt(resp.ExpiresOn, 10, 64); err == nil {
+		tok.ExpiresOn = time.Unix(exp, 0)
+	}
+	c.tokens[key] = tok
+
+	return tok.AccessToken, nil
+}
+
+// retrieve returns the (cached) body from the given IMDS URL. The boolean is
+// false when the service is unavailable or returned an error status.
+func (c *MetaClient) retrieve(u string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if value, ok := c.cache[u]; ok {
+		return []byte(value), true, nil
+	}
+
+	body, ok, err := c.request(u)
+	if err != nil || !ok {
+		// the service being unreachable isn't an error - defaults are used
+		return nil, false, err
+	}
+
+	c.cache[u] = string(body)
+
+	return body, true, nil
+}
+
+// request executes an HTTP request to the IMDS with the correct headers set,
+// and must be called with c.mu held. The boolean is false (with no error) when
+// the service can't be reached, or when it returns an error status, in which
+// case the body is returned for context.
+func (c *MetaClient) request(u string) ([]byte, bool, error) {
+	if c.client == nil {
+		timeout := c.options.Timeout
+		if timeout == 0 {
+			timeout = 500 * time.Millisecond
+		}
+		c.client = &http.Client{Timeout: timeout}
+	}
+
+	req, err := http.NewRequestWithContext(c.ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, false, nil
+	}
+	req.Header.Add("Metadata", "true")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return []byte(err.Error()), false, nil
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read response body from %s: %w", u, err)
+	}
+
+	if resp.StatusCode > 399 {
+		return body, false, nil
+	}
+
+	return body, true, nil
+}
+
+// returnDefault returns the first element of the given slice (often taken from varargs)
+// if there is one, or returns an empty string if the slice has no elements.
+func returnDefault(def []string) string {
+	if len(def) > 0 {
+		return def[0]
+	}
+	return ""
+}
@@ -0,0 +1,116 @@
+package azure
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMetaClient(t *testing.T, h http.HandlerFunc) *MetaClient {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata") != "true" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		h(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	return &MetaClient{
+		ctx:      context.Background(),
+		cache:    map[string]string{},
+		tokens:   map[string]*token{},
+		endpoint: srv.URL,
+	}
+}
+
+func TestMeta(t *testing.T) {
+	data := map[string]string{
+		"/metadata/instance/compute/location":          "westus2\n",
+		"/metadata/instance/compute/subscriptionId":    "8d10da13-8125-4ba9-a717-bf7490507b3d",
+		"/metadata/instance/compute/resourceGroupName": "my-rg",
+		"/metadata/identity/info":                      `{"tenantId":"72f988bf-86f1-41af-91ab-2d7cd011db47"}`,
+	}
+	calls := 0
+	c := newTestMetaClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		v, ok := data[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Path != "/metadata/identity/info" {
+			assert.Equal(t, "text", r.URL.Query().Get("format"))
+		}
+		assert.NotEmpty(t, r.URL.Query().Get("api-version"))
+
+		_, _ = w.Write([]byte(v))
+	})
+
+	assert.Equal(t, "westus2", must(c.Location()))
+	assert.Equal(t, "westus2", must(c.Meta("/compute/location")))
+	assert.Equal(t, 1, calls, "should be cached")
+	assert.Equal(t, "8d10da13-8125-4ba9-a717-bf7490507b3d", must(c.SubscriptionID()))
+	assert.Equal(t, "my-rg", must(c.ResourceGroup()))
+	assert.Equal(t, "72f988bf-86f1-41af-91ab-2d7cd011db47", must(c.TenantID()))
+	assert.Equal(t, "def", must(c.Meta("compute/missing", "def")))
+
+	c.endpoint = "http://127.0.0.1:1"
+	c.cache = map[string]string{}
+	assert.Equal(t, "unknown", must(c.Location("unknown")))
+	assert.Equal(t, "", must(c.TenantID()))
+}
+
+func TestAccessToken(t *testing.T) {
+	calls := 0
+	c := newTestMetaClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		q := r.URL.Query()
+		if q.Get("resource") == "https://bad.example.com" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_resource"}`))
+			return
+		}
+
+		exp := time.Now().Add(time.Hour).Unix()
+		_, _ = w.Write([]byte(`{"access_token":"tok-` + q.Get("resource") + q.Get("client_id") +
+			`","expires_on":"` + strconv.FormatInt(exp, 10) + `"}`))
+	})
+
+	tok, err := c.AccessToken("", "")
+	require.NoError(t, err)
+	assert.Equal(t, "tok-"+DefaultResource, tok)
+
+	tok, err = c.AccessToken("", "")
+	require.NoError(t, err)
+	assert.Equal(t, "tok-"+DefaultResource, tok)
+	assert.Equal(t, 1, calls, "should be cached")
+
+	tok, err = c.AccessToken("https://vault.azure.net", "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "tok-https://vault.azure.netabc", tok)
+
+	_, err = c.AccessToken("https://bad.example.com", "")
+	require.ErrorContains(t, err, "invalid_resource")
+
+	// expiring tokens are refreshed
+	c.tokens["|"] = &token{AccessToken: "old", ExpiresOn: time.Now().Add(time.Minute)}
+	tok, err = c.AccessToken("", "")
+	require.NoError(t, err)
+	assert.Equal(t, "tok-"+DefaultResource, tok)
+}
+
+func must(s string, err error) string {
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
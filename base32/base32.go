@@ -0,0 +1,62 @@
+// Package base32 contains Base32 encoding/decoding functions
+package base32
+
+import (
+	b32 "encoding/base32"
+	"fmt"
+	"strings"
+)
+
+// crockford is Douglas Crockford's Base32 alphabet, which excludes the
+// easily-confused letters I, L, O, and U
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// Encode - Encode data in base32 format, using the given encoding ("std" (the
+// default), "hex", or "crockford"). Crockford-encoded output is not padded.
+func Encode(encoding string, in []byte) (string, error) {
+	enc, err := lookup(encoding)
+	if err != nil {
+		return "", err
+	}
+
+	return enc.EncodeToString(in), nil
+}
+
+// Decode - Decode a base32-encoded string, using the given encoding ("std"
+// (the default), "hex", or "crockford"). Decoding is case-insensitive, and
+// whitespace, hyphens, and padding are optional.
+func Decode(encoding string, in string) ([]byte, error) {
+	enc, err := lookup(encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	s := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', '\r', '\n', '-', '=':
+			return -1
+		}
+
+		return r
+	}, strings.ToUpper(in))
+
+	if encoding == "crockford" {
+		// ambiguous characters are decoded as the digits they resemble
+		s = strings.NewReplacer("O", "0", "I", "1", "L", "1").Replace(s)
+	}
+
+	return enc.WithPadding(b32.NoPadding).DecodeString(s)
+}
+
+func lookup(encoding string) (*b32.Encoding, error) {
+	switch encoding {
+	case "std", "":
+		return b32.StdEncoding, nil
+	case "hex":
+		return b32.HexEncoding, nil
+	case "crockford":
+		return b32.NewEncoding(crockford).WithPadding(b32.NoPadding), nil
+	default:
+		return nil, fmt.Errorf("unknown encoding %q: must be one of std, hex, or crockford", encoding)
+	}
+}
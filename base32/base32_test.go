@@ -0,0 +1,61 @@
+package base32
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	testdata := []struct {
+		encoding string
+		in       string
+		expected string
+	}{
+		{"", "", ""},
+		{"", "f", "MY======"},
+		{"std", "foobar", "MZXW6YTBOI======"},
+		{"hex", "foobar", "CPNMUOJ1E8======"},
+		{"crockford", "foobar", "CSQPYRK1E8"},
+		{"crockford", "\x00\x01", "000G"},
+	}
+
+	for _, d := range testdata {
+		out, err := Encode(d.encoding, []byte(d.in))
+		require.NoError(t, err)
+		assert.Equal(t, d.expected, out)
+	}
+
+	_, err := Encode("bogus", nil)
+	require.Error(t, err)
+}
+
+func TestDecode(t *testing.T) {
+	testdata := []struct {
+		encoding string
+		in       string
+		expected string
+	}{
+		{"", "", ""},
+		{"", "MY======", "f"},
+		{"std", "MZXW6YTBOI======", "foobar"},
+		{"std", "mzxw 6ytb oi", "foobar"},
+		{"hex", "CPNMUOJ1E8======", "foobar"},
+		{"crockford", "CSQPYRK1E8", "foobar"},
+		{"crockford", "csqp-yrkie8", "foobar"},
+		{"crockford", "oOOg", "\x00\x01"},
+	}
+
+	for _, d := range testdata {
+		out, err := Decode(d.encoding, d.in)
+		require.NoError(t, err)
+		assert.Equal(t, d.expected, string(out), d.in)
+	}
+
+	_, err := Decode("", "MZXW1")
+	require.Error(t, err)
+
+	_, err = Decode("bogus", "")
+	require.Error(t, err)
+}
@@ -0,0 +1,34 @@
+// Package base85 contains Base85 (Ascii85) encoding/decoding functions
+package base85
+
+import (
+	"encoding/ascii85"
+	"fmt"
+	"strings"
+)
+
+// Encode - Encode data in Ascii85 format, as used by btoa, PostScript, and
+// PDF (without the "<~" and "~>" delimiters)
+func Encode(in []byte) (string, error) {
+	out := make([]byte, ascii85.MaxEncodedLen(len(in)))
+	n := ascii85.Encode(out, in)
+
+	return string(out[:n]), nil
+}
+
+// Decode - Decode an Ascii85-encoded string. Whitespace, and the optional
+// "<~" and "~>" delimiters, are ignored.
+func Decode(in string) ([]byte, error) {
+	s := strings.TrimSpace(in)
+	s = strings.TrimPrefix(s, "<~")
+	s = strings.TrimSuffix(s, "~>")
+
+	out := make([]byte, 4*len(s))
+
+	n, _, err := ascii85.Decode(out, []byte(s), true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode Ascii85 input: %w", err)
+	}
+
+	return out[:n], nil
+}
@@ -0,0 +1,50 @@
+package base85
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	testdata := []struct {
+		in       string
+		expected string
+	}{
+		{"", ""},
+		{"f", "Ac"},
+		{"foobar", "AoDTs@<)"},
+		{"hello world", "BOu!rD]j7BEbo7"},
+		{"\x00\x00\x00\x00", "z"},
+	}
+
+	for _, d := range testdata {
+		out, err := Encode([]byte(d.in))
+		require.NoError(t, err)
+		assert.Equal(t, d.expected, out)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	testdata := []struct {
+		in       string
+		expected string
+	}{
+		{"", ""},
+		{"Ac", "f"},
+		{"AoDTs@<)", "foobar"},
+		{"<~BOu!rD]j7BEbo7~>", "hello world"},
+		{"BOu!r D]j7\nBEbo7", "hello world"},
+		{"z", "\x00\x00\x00\x00"},
+	}
+
+	for _, d := range testdata {
+		out, err := Decode(d.in)
+		require.NoError(t, err)
+		assert.Equal(t, d.expected, string(out), d.in)
+	}
+
+	_, err := Decode("{bogus}")
+	require.Error(t, err)
+}
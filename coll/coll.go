@@ -156,160 +156,138 @@ func Reverse(list interface{}) ([]interface{}, error) {
 // Merge source maps (srcs) into dst. Precedence is in left-to-right order, with
 // the left-most values taking precedence over the right-most.
 func Merge(dst map[string]interface{}, srcs ...map[string]interface{}) (map[string]interface{}, error) {
-	for _, src := range srcs {
-		dst = mergeValues(src, dst)
-	}
-	return dst, nil
-}
-
-// returns whether or not a contains v
-func contains(v string, a []string) bool {
-	for _, n := range a {
-		if n == v {
-			return true
-		}
-	}
-	return false
+	return MergeWithOptions(MergeOptions{}, dst, srcs...)
 }
 
 // Omit returns a new map without any entries that have the
 // given keys (inverse of Pick).
+//
+// Keys can also be paths to nested entries, like "spec.template.metadata",
+// with keys containing dots given in brackets (like `labels["app.kubernetes.io/name"]`).
+// Keys matching a top-level entry exactly are never treated as paths.
 func Omit(in map[string]interface{}, keys ...string) map[string]interface{} {
-	out := map[string]interface{}{}
-	for k, v := range in {
-		if !contains(k, keys) {
-			out[k] = v
+	out := copyMap(in)
+	for _, k := range keys {
+		if _, ok := in[k]; ok {
+			delete(out, k)
+			continue
 		}
-	}
-	return out
-}
 
-// Pick returns a new map with any entries that have the
-// given keys (inverse of Omit).
-func Pick(in map[string]interface{}, keys ...string) map[string]interface{} {
-	out := map[string]interface{}{}
-	for k, v := range in {
-		if contains(k, keys) {
-			out[k] = v
+		path, err := splitKeyPath(k)
+		if err != nil {
+			continue
 		}
+
+		out, _ = omitPath(out, path)
 	}
 	return out
 }
 
-func copyMap(m map[string]interface{}) map[string]interface{} {
-	n := map[string]interface{}{}
-	for k, v := range m {
-		n[k] = v
+// omitPath returns a copy of m without the entry at the path, copying only
+// the maps along the path. If there's no entry at the path, m is returned
+// unmodified, along with false.
+func omitPath(m map[string]interface{}, path []string) (map[string]interface{}, bool) {
+	v, ok := m[path[0]]
+	if !ok {
+		return m, false
 	}
-	return n
+
+	n := copyMap(m)
+	if len(path) == 1 {
+		delete(n, path[0])
+		return n, true
+	}
+
+	child, ok := v.(map[string]interface{})
+	if !ok {
+		return m, false
+	}
+
+	child, ok = omitPath(child, path[1:])
+	if !ok {
+		return m, false
+	}
+
+	n[path[0]] = child
+	return n, true
 }
 
-// Merges a default and override map
-func mergeValues(d map[string]interface{}, o map[string]interface{}) map[string]interface{} {
-	def := copyMap(d)
-	over := copyMap(o)
-	for k, v := range over {
-		// If the key doesn't exist already, then just set the key to that value
-		if _, exists := def[k]; !exists {
-			def[k] = v
-			continue
-		}
-		nextMap, ok := v.(map[string]interface{})
-		// If it isn't another map, overwrite the value
-		if !ok {
-			def[k] = v
+// Pick returns a new map with any entries that have the
+// given keys (inverse of Omit).
+//
+// Keys can also be paths to nested entries, as with Omit. The parent maps of
+// nested entries are included, containing only the picked entries.
+func Pick(in map[string]interface{}, keys ...string) map[string]interface{} {
+	out := map[string]interface{}{}
+	for _, k := range keys {
+		if v, ok := in[k]; ok {
+			out[k] = copyMaps(v)
 			continue
 		}
-		// Edge case: If the key exists in the default, but isn't a map
-		defMap, isMap := def[k].(map[string]interface{})
-		// If the override map has a map for this key, prefer it
-		if !isMap {
-			def[k] = v
+
+		path, err := splitKeyPath(k)
+		if err != nil {
 			continue
 		}
-		// If we got to this point, it is a map in both, so merge them
-		def[k] = mergeValues(defMap, nextMap)
+
+		pickPath(out, in, path)
 	}
-	return def
+	return out
 }
 
-// Sort a given array or slice. Uses natural sort order if possible. If a
-// non-empty key is given and the list elements are maps, this will attempt to
-// sort by the values of those entries.
-//
-// Does not modify the input list.
-func Sort(key string, list interface{}) (out []interface{}, err error) {
-	if list == nil {
-		return nil, nil
+// pickPath copies the entry at the path in src to dst, creating any parent
+// maps needed in dst
+func pickPath(dst, src map[string]interface{}, path []string) {
+	v, ok := src[path[0]]
+	if !ok {
+		return
 	}
 
-	ia, err := iconv.InterfaceSlice(list)
-	if err != nil {
-		return nil, err
+	if len(path) == 1 {
+		dst[path[0]] = copyMaps(v)
+		return
 	}
-	// if the types are all the same, we can sort the slice
-	if sameTypes(ia) {
-		s := make([]interface{}, len(ia))
-		// make a copy so the original is unmodified
-		copy(s, ia)
-		sort.SliceStable(s, func(i, j int) bool {
-			return lessThan(key)(s[i], s[j])
-		})
-		return s, nil
+
+	child, ok := v.(map[string]interface{})
+	if !ok {
+		return
 	}
-	return ia, nil
-}
 
-// lessThan - compare two values of the same type
-func lessThan(key string) func(left, right interface{}) bool {
-	return func(left, right interface{}) bool {
-		val := reflect.Indirect(reflect.ValueOf(left))
-		rval := reflect.Indirect(reflect.ValueOf(right))
-		switch val.Kind() {
-		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
-			return val.Int() < rval.Int()
-		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint, reflect.Uint64:
-			return val.Uint() < rval.Uint()
-		case reflect.Float32, reflect.Float64:
-			return val.Float() < rval.Float()
-		case reflect.String:
-			return val.String() < rval.String()
-		case reflect.MapOf(
-			reflect.TypeOf(reflect.String),
-			reflect.TypeOf(reflect.Interface),
-		).Kind():
-			kval := reflect.ValueOf(key)
-			if !val.MapIndex(kval).IsValid() {
-				return false
-			}
-			newleft := val.MapIndex(kval).Interface()
-			newright := rval.MapIndex(kval).Interface()
-			return lessThan("")(newleft, newright)
-		case reflect.Struct:
-			if !val.FieldByName(key).IsValid() {
-				return false
-			}
-			newleft := val.FieldByName(key).Interface()
-			newright := rval.FieldByName(key).Interface()
-			return lessThan("")(newleft, newright)
-		default:
-			// it's not really comparable, so...
-			return false
+	dchild, ok := dst[path[0]].(map[string]interface{})
+	if !ok {
+		// don't create an empty parent map unless the entry exists
+		dchild = map[string]interface{}{}
+		pickPath(dchild, child, path[1:])
+		if len(dchild) > 0 {
+			dst[path[0]] = dchild
 		}
+		return
 	}
+
+	pickPath(dchild, child, path[1:])
 }
 
-func sameTypes(a []interface{}) bool {
-	var t reflect.Type
-	for _, v := range a {
-		if t == nil {
-			t = reflect.TypeOf(v)
-		}
-		if reflect.ValueOf(v).Kind() != t.Kind() {
-			return false
-		}
+// copyMaps returns a deep copy of v if it's a map, copying nested maps but not
+// other values, so picked maps can be safely modified by later picks
+func copyMaps(v interface{}) interface{} {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+
+	n := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		n[k] = copyMaps(v)
 	}
-	return true
+	return n
+}
+
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	n := map[string]interface{}{}
+	for k, v := range m {
+		n[k] = v
+	}
+	return n
 }
 
 // Flatten a nested array or slice to at most 'depth' levels. Use depth of -1
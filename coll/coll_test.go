@@ -344,7 +344,9 @@ func TestLessThan(t *testing.T) {
 
 	for _, d := range data {
 		t.Run(fmt.Sprintf(`LessThan("%s")(<%T>%#v,%#v)==%v`, d.key, d.left, d.left, d.right, d.out), func(t *testing.T) {
-			assert.Equal(t, d.out, lessThan(d.key)(d.left, d.right))
+			sk, err := parseSortKey(d.key)
+			require.NoError(t, err)
+			assert.Equal(t, d.out, sk.compare(d.left, d.right) < 0)
 		})
 	}
 }
@@ -621,3 +623,97 @@ func TestPick(t *testing.T) {
 
 	assert.EqualValues(t, in, Pick(in, "foo", "bar", ""))
 }
+
+func TestOmitPaths(t *testing.T) {
+	in := map[string]interface{}{
+		"a.b": 1,
+		"spec": map[string]interface{}{
+			"replicas": 3,
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{
+						"app":                    "web",
+						"app.kubernetes.io/name": "web",
+					},
+				},
+			},
+		},
+		"list": []interface{}{1, 2},
+	}
+
+	out := Omit(in, "a.b", "spec.template.metadata.labels[app.kubernetes.io/name]", "spec.nope", "list.0", "nope.nope", "[")
+	assert.EqualValues(t, map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": 3,
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{"app": "web"},
+				},
+			},
+		},
+		"list": []interface{}{1, 2},
+	}, out)
+
+	// the input isn't modified
+	assert.Len(t, in["spec"].(map[string]interface{})["template"].(map[string]interface{})["metadata"].(map[string]interface{})["labels"], 2)
+	assert.Contains(t, in, "a.b")
+
+	out = Omit(in, ".spec.replicas", `spec["template"]`)
+	assert.EqualValues(t, map[string]interface{}{
+		"a.b":  1,
+		"spec": map[string]interface{}{},
+		"list": []interface{}{1, 2},
+	}, out)
+}
+
+func TestPickPaths(t *testing.T) {
+	in := map[string]interface{}{
+		"a.b": 1,
+		"spec": map[string]interface{}{
+			"replicas": 3,
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"name": "web",
+					"labels": map[string]interface{}{
+						"app":                    "web",
+						"app.kubernetes.io/name": "web",
+					},
+				},
+			},
+		},
+	}
+
+	out := Pick(in, "a.b", "spec.template.metadata.labels", "spec.replicas", "spec.nope", "spec.replicas.nope", "[")
+	assert.EqualValues(t, map[string]interface{}{
+		"a.b": 1,
+		"spec": map[string]interface{}{
+			"replicas": 3,
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{
+						"app":                    "web",
+						"app.kubernetes.io/name": "web",
+					},
+				},
+			},
+		},
+	}, out)
+
+	// picking a map and then an entry within it doesn't modify the input
+	out = Pick(in, "spec", "spec.template.metadata.name")
+	assert.EqualValues(t, map[string]interface{}{"spec": in["spec"]}, out)
+
+	out["spec"].(map[string]interface{})["replicas"] = 5
+	assert.Equal(t, 3, in["spec"].(map[string]interface{})["replicas"])
+
+	out = Pick(in, `spec.template.metadata.labels["app.kubernetes.io/name"]`, "nope.nope")
+	assert.EqualValues(t, map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{"app.kubernetes.io/name": "web"},
+				},
+			},
+		},
+	}, out)
+}
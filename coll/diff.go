@@ -0,0 +1,119 @@
+package coll
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// Diff compares two data structures, returning the paths which were added,
+// removed, or changed between them. Maps and lists are compared recursively,
+// and paths are dot-separated map keys and list indexes (like
+// "servers.0.port"), or "." for the structure itself.
+//
+// The result is a map with three keys: "added" and "removed" map each path to
+// its value in the structure where it exists, and "changed" maps each path to
+// a map with the "from" and "to" values. Numbers are compared by value, so 1
+// and 1.0 are considered equal.
+func Diff(from, to interface{}) map[string]interface{} {
+	d := differ{
+		added:   map[string]interface{}{},
+		removed: map[string]interface{}{},
+		changed: map[string]interface{}{},
+	}
+
+	d.diff("", from, to)
+
+	return map[string]interface{}{
+		"added":   d.added,
+		"removed": d.removed,
+		"changed": d.changed,
+	}
+}
+
+type differ struct {
+	added, removed, changed map[string]interface{}
+}
+
+func (d *differ) diff(path string, from, to interface{}) {
+	fv := reflect.Indirect(reflect.ValueOf(from))
+	tv := reflect.Indirect(reflect.ValueOf(to))
+
+	switch {
+	case isStringMap(fv) && isStringMap(tv):
+		d.diffMaps(path, fv, tv)
+	case isList(fv) && isList(tv):
+		d.diffLists(path, fv, tv)
+	case !leafEqual(fv, tv):
+		if path == "" {
+			path = "."
+		}
+
+		d.changed[path] = map[string]interface{}{"from": from, "to": to}
+	}
+}
+
+func (d *differ) diffMaps(path string, from, to reflect.Value) {
+	for _, k := range from.MapKeys() {
+		fv := from.MapIndex(k)
+		p := joinPath(path, k.String())
+
+		tv := to.MapIndex(k)
+		if !tv.IsValid() {
+			d.removed[p] = fv.Interface()
+
+			continue
+		}
+
+		d.diff(p, fv.Interface(), tv.Interface())
+	}
+
+	for _, k := range to.MapKeys() {
+		if !from.MapIndex(k).IsValid() {
+			d.added[joinPath(path, k.String())] = to.MapIndex(k).Interface()
+		}
+	}
+}
+
+func (d *differ) diffLists(path string, from, to reflect.Value) {
+	for i := 0; i < from.Len() || i < to.Len(); i++ {
+		p := joinPath(path, strconv.Itoa(i))
+
+		switch {
+		case i >= to.Len():
+			d.removed[p] = from.Index(i).Interface()
+		case i >= from.Len():
+			d.added[p] = to.Index(i).Interface()
+		default:
+			d.diff(p, from.Index(i).Interface(), to.Index(i).Interface())
+		}
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func isStringMap(v reflect.Value) bool {
+	return v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String
+}
+
+func isList(v reflect.Value) bool {
+	return v.Kind() == reflect.Slice || v.Kind() == reflect.Array
+}
+
+// leafEqual compares two values which aren't both maps or lists
+func leafEqual(a, b reflect.Value) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+
+	if isNumber(a) && isNumber(b) {
+		return compareValues(a.Interface(), b.Interface()) == 0
+	}
+
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
@@ -0,0 +1,71 @@
+package coll
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	from := map[string]interface{}{
+		"name":    "app",
+		"replica": 2,
+		"image":   map[string]interface{}{"repo": "app", "tag": "1.0"},
+		"ports":   []interface{}{80, 443},
+		"debug":   true,
+		"env":     map[string]string{"A": "1", "B": "2"},
+	}
+	to := map[string]interface{}{
+		"name":    "app",
+		"replica": 3.0,
+		"image":   map[string]interface{}{"repo": "app", "tag": "1.1"},
+		"ports":   []interface{}{80, 8443, 9000},
+		"env":     map[string]string{"A": "1", "C": "3"},
+		"labels":  map[string]interface{}{"tier": "web"},
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"added": map[string]interface{}{
+			"ports.2": 9000,
+			"env.C":   "3",
+			"labels":  map[string]interface{}{"tier": "web"},
+		},
+		"removed": map[string]interface{}{
+			"debug": true,
+			"env.B": "2",
+		},
+		"changed": map[string]interface{}{
+			"replica":   map[string]interface{}{"from": 2, "to": 3.0},
+			"image.tag": map[string]interface{}{"from": "1.0", "to": "1.1"},
+			"ports.1":   map[string]interface{}{"from": 443, "to": 8443},
+		},
+	}, Diff(from, to))
+
+	empty := map[string]interface{}{
+		"added":   map[string]interface{}{},
+		"removed": map[string]interface{}{},
+		"changed": map[string]interface{}{},
+	}
+
+	assert.Equal(t, empty, Diff(from, from))
+	assert.Equal(t, empty, Diff(1, 1.0))
+	assert.Equal(t, empty, Diff(nil, nil))
+	assert.Equal(t, empty, Diff([]int{1, 2}, []interface{}{1, 2}))
+
+	// numbers are only equal to other numbers
+	assert.Equal(t, map[string]interface{}{"from": 1, "to": "1"}, Diff(1, "1")["changed"].(map[string]interface{})["."])
+
+	assert.Equal(t, map[string]interface{}{
+		".": map[string]interface{}{"from": []int{1}, "to": map[string]interface{}{}},
+	}, Diff([]int{1}, map[string]interface{}{})["changed"])
+
+	assert.Equal(t, map[string]interface{}{
+		"a": map[string]interface{}{"from": nil, "to": 1},
+	}, Diff(map[string]interface{}{"a": nil}, map[string]interface{}{"a": 1})["changed"])
+
+	assert.Equal(t, map[string]interface{}{
+		"1": "b",
+	}, Diff([]string{"a", "b"}, []string{"a"})["removed"])
+}
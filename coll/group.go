@@ -0,0 +1,147 @@
+package coll
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	iconv "github.com/hairyhenderson/gomplate/v4/internal/conv"
+)
+
+// GroupBy groups the items in the list by their values at the given key path
+// (like "metadata.name") or jq expression (like ".name | ascii_downcase"). The
+// result maps each value (as a string) to a list of the items with that value,
+// in their original order. Items without a value are grouped under the empty
+// string.
+func GroupBy(ctx context.Context, key string, list interface{}) (map[string]interface{}, error) {
+	l, err := iconv.InterfaceSlice(list)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := keyFunc(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	out := map[string]interface{}{}
+
+	for _, item := range l {
+		v, ok, err := f(item)
+		if err != nil {
+			return nil, err
+		}
+
+		group := ""
+		if ok {
+			group = conv.ToString(v)
+		}
+
+		g, _ := out[group].([]interface{})
+		out[group] = append(g, item)
+	}
+
+	return out, nil
+}
+
+// UniqBy returns the first item in the list for each distinct value at the
+// given key path or jq expression. Items without a value are considered to
+// share the same (missing) value.
+func UniqBy(ctx context.Context, key string, list interface{}) ([]interface{}, error) {
+	l, err := iconv.InterfaceSlice(list)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := keyFunc(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []interface{}{}
+	seen := []interface{}{}
+
+	for _, item := range l {
+		v, _, err := f(item)
+		if err != nil {
+			return nil, err
+		}
+
+		if Has(seen, v) {
+			continue
+		}
+
+		seen = append(seen, v)
+		out = append(out, item)
+	}
+
+	return out, nil
+}
+
+// Chunk splits the list into lists of the given size. The last list is
+// shorter when the list can't be split evenly.
+func Chunk(size int, list interface{}) ([]interface{}, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("chunk size must be at least 1, got %d", size)
+	}
+
+	l, err := iconv.InterfaceSlice(list)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]interface{}, 0, (len(l)+size-1)/size)
+
+	for i := 0; i < len(l); i += size {
+		end := min(i+size, len(l))
+
+		chunk := make([]interface{}, end-i)
+		copy(chunk, l[i:end])
+
+		out = append(out, chunk)
+	}
+
+	return out, nil
+}
+
+// Zip combines the lists into a list of lists, where the nth list contains
+// the nth item of each input list. The result is as long as the shortest
+// input list.
+func Zip(lists ...interface{}) ([]interface{}, error) {
+	if len(lists) == 0 {
+		return []interface{}{}, nil
+	}
+
+	ls := make([][]interface{}, len(lists))
+	n := -1
+
+	for i, list := range lists {
+		if list != nil && reflect.TypeOf(list).Kind() == reflect.Map {
+			return nil, fmt.Errorf("can't zip a map (argument %d)", i+1)
+		}
+
+		l, err := iconv.InterfaceSlice(list)
+		if err != nil {
+			return nil, err
+		}
+
+		ls[i] = l
+
+		if n < 0 || len(l) < n {
+			n = len(l)
+		}
+	}
+
+	out := make([]interface{}, n)
+	for i := range out {
+		tuple := make([]interface{}, len(ls))
+		for j, l := range ls {
+			tuple[j] = l[i]
+		}
+
+		out[i] = tuple
+	}
+
+	return out, nil
+}
@@ -0,0 +1,144 @@
+package coll
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupBy(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	in := []interface{}{
+		map[string]interface{}{"name": "a", "meta": map[string]interface{}{"env": "prod"}},
+		map[string]interface{}{"name": "b", "meta": map[string]interface{}{"env": "dev"}},
+		map[string]interface{}{"name": "C", "meta": map[string]interface{}{"env": "prod"}},
+		map[string]interface{}{"name": "d"},
+	}
+
+	out, err := GroupBy(ctx, "meta.env", in)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"prod": []interface{}{in[0], in[2]},
+		"dev":  []interface{}{in[1]},
+		"":     []interface{}{in[3]},
+	}, out)
+
+	out, err = GroupBy(ctx, ".meta.env", in)
+	require.NoError(t, err)
+	assert.Len(t, out["prod"], 2)
+
+	out, err = GroupBy(ctx, `.name | ascii_downcase | . < "c"`, in)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"true":  []interface{}{in[0], in[1]},
+		"false": []interface{}{in[2], in[3]},
+	}, out)
+
+	type item struct {
+		Kind string
+	}
+
+	out, err = GroupBy(ctx, "Kind", []item{{"x"}, {"y"}, {"x"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"x": []interface{}{item{"x"}, item{"x"}},
+		"y": []interface{}{item{"y"}},
+	}, out)
+
+	out, err = GroupBy(ctx, "1", [][]int{{1, 2}, {3, 2}, {4, 5}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"2": []interface{}{[]int{1, 2}, []int{3, 2}},
+		"5": []interface{}{[]int{4, 5}},
+	}, out)
+
+	_, err = GroupBy(ctx, "", in)
+	require.Error(t, err)
+
+	_, err = GroupBy(ctx, ".name |", in)
+	require.Error(t, err)
+
+	_, err = GroupBy(ctx, ".name | error", in)
+	require.Error(t, err)
+
+	_, err = GroupBy(ctx, "name", 42)
+	require.Error(t, err)
+}
+
+func TestUniqBy(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	in := []interface{}{
+		map[string]interface{}{"name": "a", "v": 1},
+		map[string]interface{}{"name": "b", "v": 2},
+		map[string]interface{}{"name": "A", "v": 3},
+		map[string]interface{}{"v": 4},
+		map[string]interface{}{"v": 5},
+	}
+
+	out, err := UniqBy(ctx, "name", in)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{in[0], in[1], in[2], in[3]}, out)
+
+	out, err = UniqBy(ctx, ".name | ascii_downcase?", in)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{in[0], in[1], in[3]}, out)
+
+	_, err = UniqBy(ctx, "", in)
+	require.Error(t, err)
+}
+
+func TestChunk(t *testing.T) {
+	t.Parallel()
+
+	out, err := Chunk(2, []int{1, 2, 3, 4, 5})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		[]interface{}{1, 2},
+		[]interface{}{3, 4},
+		[]interface{}{5},
+	}, out)
+
+	out, err = Chunk(10, []string{"a", "b"})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{[]interface{}{"a", "b"}}, out)
+
+	out, err = Chunk(3, []interface{}{})
+	require.NoError(t, err)
+	assert.Empty(t, out)
+
+	_, err = Chunk(0, []int{1})
+	require.Error(t, err)
+
+	_, err = Chunk(1, "foo")
+	require.Error(t, err)
+}
+
+func TestZip(t *testing.T) {
+	t.Parallel()
+
+	out, err := Zip([]string{"a", "b", "c"}, []int{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		[]interface{}{"a", 1},
+		[]interface{}{"b", 2},
+	}, out)
+
+	out, err = Zip([]string{"a", "b"})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{[]interface{}{"a"}, []interface{}{"b"}}, out)
+
+	out, err = Zip()
+	require.NoError(t, err)
+	assert.Empty(t, out)
+
+	_, err = Zip([]int{1}, map[string]interface{}{"a": 1})
+	require.Error(t, err)
+}
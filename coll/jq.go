@@ -5,24 +5,54 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/itchyny/gojq"
 )
 
 // JQ -
 func JQ(ctx context.Context, jqExpr string, in interface{}) (interface{}, error) {
+	return JQWithVariables(ctx, jqExpr, nil, in)
+}
+
+// JQWithVariables - like JQ, but the values in vars are available to the
+// expression as variables, named for their keys (with a "$" prefix)
+func JQWithVariables(ctx context.Context, jqExpr string, vars map[string]interface{}, in interface{}) (interface{}, error) {
 	query, err := gojq.Parse(jqExpr)
 	if err != nil {
 		return nil, fmt.Errorf("jq parsing expression %q: %w", jqExpr, err)
 	}
 
+	// variables are given in a consistent order
+	names := make([]string, 0, len(vars))
+	for k := range vars {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	values := make([]interface{}, len(names))
+	for i, k := range names {
+		values[i], err = jqConvertType(vars[k])
+		if err != nil {
+			return nil, fmt.Errorf("jq type conversion of variable %q: %w", k, err)
+		}
+
+		names[i] = "$" + strings.TrimPrefix(k, "$")
+	}
+
+	code, err := gojq.Compile(query, gojq.WithVariables(names))
+	if err != nil {
+		return nil, fmt.Errorf("jq compiling expression %q: %w", jqExpr, err)
+	}
+
 	// convert input to a supported type, if necessary
 	in, err = jqConvertType(in)
 	if err != nil {
 		return nil, fmt.Errorf("jq type conversion: %w", err)
 	}
 
-	iter := query.RunWithContext(ctx, in)
+	iter := code.RunWithContext(ctx, in, values...)
 	var out interface{}
 	a := []interface{}{}
 	for {
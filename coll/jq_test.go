@@ -122,6 +122,34 @@ func TestJQ(t *testing.T) {
 	assert.Contains(t, out, "baz")
 }
 
+func TestJQWithVariables(t *testing.T) {
+	ctx := context.Background()
+	in := []interface{}{
+		map[string]interface{}{"name": "web", "port": 80},
+		map[string]interface{}{"name": "api", "port": 8080},
+	}
+
+	out, err := JQWithVariables(ctx, `.[] | select(.name == $name) | .port`,
+		map[string]interface{}{"name": "api"}, in)
+	require.NoError(t, err)
+	assert.Equal(t, 8080, out)
+
+	// the "$" prefix is optional, and values are converted like the input
+	out, err = JQWithVariables(ctx, `[.[] | select(.port > $min) | .name + $suffix]`,
+		map[string]interface{}{"min": 100, "$suffix": "-svc"}, in)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"api-svc"}, out)
+
+	out, err = JQWithVariables(ctx, `$ports | map(. + 1)`,
+		map[string]interface{}{"ports": []interface{}{1, 2}}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{2, 3}, out)
+
+	// undefined variables are an error
+	_, err = JQWithVariables(ctx, `$nope`, map[string]interface{}{"name": "api"}, in)
+	require.ErrorContains(t, err, "jq compiling expression")
+}
+
 func TestJQ_typeConversions(t *testing.T) {
 	ctx := context.Background()
 
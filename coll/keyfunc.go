@@ -0,0 +1,107 @@
+package coll
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/itchyny/gojq"
+)
+
+// keyPathRE matches simple key paths, like "name" or "metadata.labels.app"
+// (with an optional leading "."). Anything else starting with "." is a jq
+// expression.
+var keyPathRE = regexp.MustCompile(`^\.?[\w-]+(\.[\w-]+)*$`)
+
+// keyFunc returns a function which extracts a value from an item, given
+// either a key path (like "metadata.name"), or a jq expression (like
+// ".name | ascii_downcase"). The function's second return value is false when
+// the item has no value at the key path, or the expression returns null.
+func keyFunc(ctx context.Context, key string) (func(item interface{}) (interface{}, bool, error), error) {
+	if key == "" {
+		return nil, fmt.Errorf("key must not be empty")
+	}
+
+	if keyPathRE.MatchString(key) || !strings.HasPrefix(key, ".") {
+		path := strings.Split(strings.TrimPrefix(key, "."), ".")
+
+		return func(item interface{}) (interface{}, bool, error) {
+			v, ok := valueAtPath(item, path)
+
+			return v, ok, nil
+		}, nil
+	}
+
+	query, err := gojq.Parse(key)
+	if err != nil {
+		return nil, fmt.Errorf("jq parsing expression %q: %w", key, err)
+	}
+
+	code, err := gojq.Compile(query)
+	if err != nil {
+		return nil, fmt.Errorf("jq compiling expression %q: %w", key, err)
+	}
+
+	return func(item interface{}) (interface{}, bool, error) {
+		in, err := jqConvertType(item)
+		if err != nil {
+			return nil, false, fmt.Errorf("jq type conversion: %w", err)
+		}
+
+		// only the first result is used
+		v, ok := code.RunWithContext(ctx, in).Next()
+		if !ok || v == nil {
+			return nil, false, nil
+		}
+
+		if err, ok := v.(error); ok {
+			return nil, false, fmt.Errorf("jq execution: %w", err)
+		}
+
+		return v, true, nil
+	}, nil
+}
+
+// valueAtPath returns the value at the path within v, following map keys,
+// struct fields, and list indexes
+func valueAtPath(v interface{}, path []string) (interface{}, bool) {
+	for _, k := range path {
+		rv := reflect.Indirect(reflect.ValueOf(v))
+
+		//nolint:exhaustive
+		switch rv.Kind() {
+		case reflect.Map:
+			if rv.Type().Key().Kind() != reflect.String {
+				return nil, false
+			}
+
+			mv := rv.MapIndex(reflect.ValueOf(k).Convert(rv.Type().Key()))
+			if !mv.IsValid() {
+				return nil, false
+			}
+
+			v = mv.Interface()
+		case reflect.Struct:
+			fv := rv.FieldByName(k)
+			if !fv.IsValid() || !fv.CanInterface() {
+				return nil, false
+			}
+
+			v = fv.Interface()
+		case reflect.Slice, reflect.Array:
+			i, err := strconv.Atoi(k)
+			if err != nil || i < 0 || i >= rv.Len() {
+				return nil, false
+			}
+
+			v = rv.Index(i).Interface()
+		default:
+			return nil, false
+		}
+	}
+
+	return v, true
+}
@@ -0,0 +1,89 @@
+package coll
+
+import (
+	"fmt"
+	"strings"
+)
+
+// splitKeyPath splits a path like `spec.template.metadata.labels` into its
+// keys. Keys containing dots can be given in brackets, optionally quoted, like
+// `metadata.labels["app.kubernetes.io/name"]`. A leading "." is allowed.
+func splitKeyPath(p string) ([]string, error) {
+	s := strings.TrimPrefix(p, ".")
+	if s == "" {
+		return nil, fmt.Errorf("invalid path %q: empty", p)
+	}
+
+	keys := []string{}
+
+	for i := 0; i < len(s); {
+		var key string
+
+		if s[i] == '[' {
+			k, n, err := bracketKey(s[i:])
+			if err != nil {
+				return nil, fmt.Errorf("invalid path %q: %w", p, err)
+			}
+
+			key = k
+			i += n
+
+			if i < len(s) && s[i] != '.' && s[i] != '[' {
+				return nil, fmt.Errorf("invalid path %q: expected '.' or '[' after ']'", p)
+			}
+		} else {
+			end := strings.IndexAny(s[i:], ".[")
+			if end < 0 {
+				end = len(s) - i
+			}
+
+			if end == 0 {
+				return nil, fmt.Errorf("invalid path %q: empty key", p)
+			}
+
+			key = s[i : i+end]
+			i += end
+		}
+
+		keys = append(keys, key)
+
+		if i < len(s) && s[i] == '.' {
+			i++
+
+			if i == len(s) {
+				return nil, fmt.Errorf("invalid path %q: empty key", p)
+			}
+		}
+	}
+
+	return keys, nil
+}
+
+// bracketKey parses a bracketed key at the start of s, like `[foo]` or
+// `["foo.bar"]`, returning the key and the number of bytes consumed
+func bracketKey(s string) (string, int, error) {
+	if len(s) > 1 && (s[1] == '"' || s[1] == '\'') {
+		end := strings.IndexByte(s[2:], s[1])
+		if end < 0 {
+			return "", 0, fmt.Errorf("unterminated quote")
+		}
+
+		end += 3
+		if end >= len(s) || s[end] != ']' {
+			return "", 0, fmt.Errorf("expected ']' after quoted key")
+		}
+
+		return s[2 : end-1], end + 1, nil
+	}
+
+	end := strings.IndexByte(s, ']')
+	if end < 0 {
+		return "", 0, fmt.Errorf("unterminated '['")
+	}
+
+	if end == 1 {
+		return "", 0, fmt.Errorf("empty key")
+	}
+
+	return s[1:end], end + 1, nil
+}
@@ -0,0 +1,39 @@
+package coll
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitKeyPath(t *testing.T) {
+	t.Parallel()
+
+	testdata := []struct {
+		in  string
+		out []string
+	}{
+		{"foo", []string{"foo"}},
+		{".foo", []string{"foo"}},
+		{"spec.template.metadata", []string{"spec", "template", "metadata"}},
+		{`labels["app.kubernetes.io/name"]`, []string{"labels", "app.kubernetes.io/name"}},
+		{`labels['a]b'].c`, []string{"labels", "a]b", "c"}},
+		{`[a.b][c]`, []string{"a.b", "c"}},
+		{`a.[b]`, []string{"a", "b"}},
+		{`a[""]`, []string{"a", ""}},
+	}
+
+	for _, d := range testdata {
+		out, err := splitKeyPath(d.in)
+		require.NoError(t, err, d.in)
+		assert.Equal(t, d.out, out, d.in)
+	}
+
+	for _, in := range []string{
+		"", ".", "a..b", "a.", "a[b", `a["b]`, `a["b"c]`, "a[]", "a[b]c",
+	} {
+		_, err := splitKeyPath(in)
+		require.Error(t, err, in)
+	}
+}
@@ -0,0 +1,416 @@
+package coll
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ArrayMergeStrategy controls how lists found at the same key are combined
+// when merging maps
+type ArrayMergeStrategy string
+
+const (
+	// ArraysReplace replaces lower-priority lists with higher-priority ones.
+	// This is the default.
+	ArraysReplace ArrayMergeStrategy = "replace"
+	// ArraysAppend appends higher-priority lists to lower-priority ones
+	ArraysAppend ArrayMergeStrategy = "append"
+	// ArraysUnique appends higher-priority lists to lower-priority ones, but
+	// omits items which are already present
+	ArraysUnique ArrayMergeStrategy = "unique"
+	// ArraysByIndex merges the items at each index, so that maps are merged
+	// and other values are replaced. Lower-priority items beyond the end of
+	// the higher-priority list are kept.
+	ArraysByIndex ArrayMergeStrategy = "byIndex"
+)
+
+// ParseArrayMergeStrategy returns the named array merge strategy. An empty name
+// is the default, [ArraysReplace].
+func ParseArrayMergeStrategy(name string) (ArrayMergeStrategy, error) {
+	switch s := ArrayMergeStrategy(name); s {
+	case "":
+		return ArraysReplace, nil
+	case ArraysReplace, ArraysAppend, ArraysUnique, ArraysByIndex:
+		return s, nil
+	default:
+		return "", fmt.Errorf("unknown array merge strategy %q: must be one of %s, %s, %s, or %s",
+			name, ArraysAppend, ArraysReplace, ArraysUnique, ArraysByIndex)
+	}
+}
+
+// MergeOptions control how maps are merged by [MergeWithOptions]. The zero
+// value merges the same way as [Merge].
+type MergeOptions struct {
+	// Arrays controls how lists found at the same key are combined
+	Arrays ArrayMergeStrategy
+	// ListKeys names the fields which identify the items in lists of maps,
+	// like Kubernetes' strategic merge patches. When every item in both lists
+	// is a map containing one of these keys (the first such key is used),
+	// items with the same value for that key are merged, and other items are
+	// appended. Other lists are combined according to Arrays.
+	ListKeys []string
+	// DeleteNulls removes keys which are null in higher-priority maps, rather
+	// than setting them to null, like JSON Merge Patch (RFC 7386). Nulls in
+	// the lowest-priority map are kept.
+	DeleteNulls bool
+	// Depth limits how many levels of nested maps and lists are merged. At the
+	// limit, maps and lists replace lower-priority values entirely. For
+	// example, 1 merges only the top-level keys. 0 is unlimited.
+	Depth int
+	// CoerceScalars converts higher-priority scalar values to the type of the
+	// lower-priority value they replace, when that can be done without loss
+	// (for example "1" replacing 1 becomes 1, and 2 replacing "1" becomes
+	// "2"). Values which can't be converted are used as-is.
+	CoerceScalars bool
+}
+
+// ParseMergeOptions reads merge options from URL query parameters, as used in
+// merge datasource URLs:
+//   - arrays - the array merge strategy (see [ParseArrayMergeStrategy])
+//   - listKey - comma-separated keys identifying items in lists of maps
+//   - nulls - keep (the default) or delete
+//   - depth - the maximum merge depth (0, the default, is unlimited)
+//   - coerce - whether to coerce scalars (true or false)
+//
+// Other parameters are ignored.
+func ParseMergeOptions(q url.Values) (MergeOptions, error) {
+	opts := MergeOptions{}
+
+	arrays, err := ParseArrayMergeStrategy(q.Get("arrays"))
+	if err != nil {
+		return opts, err
+	}
+
+	opts.Arrays = arrays
+
+	if keys := q.Get("listKey"); keys != "" {
+		for _, k := range strings.Split(keys, ",") {
+			if k = strings.TrimSpace(k); k != "" {
+				opts.ListKeys = append(opts.ListKeys, k)
+			}
+		}
+	}
+
+	switch nulls := q.Get("nulls"); nulls {
+	case "", "keep":
+	case "delete":
+		opts.DeleteNulls = true
+	default:
+		return opts, fmt.Errorf("invalid nulls option %q: must be keep or delete", nulls)
+	}
+
+	if depth := q.Get("depth"); depth != "" {
+		opts.Depth, err = strconv.Atoi(depth)
+		if err != nil || opts.Depth < 0 {
+			return opts, fmt.Errorf("invalid depth option %q: must be a non-negative integer", depth)
+		}
+	}
+
+	if coerce := q.Get("coerce"); coerce != "" {
+		opts.CoerceScalars, err = strconv.ParseBool(coerce)
+		if err != nil {
+			return opts, fmt.Errorf("invalid coerce option %q: must be true or false", coerce)
+		}
+	}
+
+	return opts, nil
+}
+
+// MergeWithOptions merges source maps (srcs) into dst, like [Merge], with the
+// given options. Precedence is in left-to-right order, with the left-most
+// values taking precedence over the right-most.
+func MergeWithOptions(opts MergeOptions, dst map[string]interface{}, srcs ...map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+
+	opts.Arrays, err = ParseArrayMergeStrategy(string(opts.Arrays))
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Depth < 0 {
+		return nil, fmt.Errorf("invalid merge depth %d: must not be negative", opts.Depth)
+	}
+
+	if opts.DeleteNulls && len(srcs) > 0 {
+		// nulls are replaced with a marker which overrides lower-priority
+		// values like any other, and is removed once everything's merged
+		srcs = append([]map[string]interface{}{}, srcs...)
+
+		dst = markNulls(dst)
+		for i := range srcs[:len(srcs)-1] {
+			srcs[i] = markNulls(srcs[i])
+		}
+	}
+
+	for _, src := range srcs {
+		dst = mergeValues(src, dst, opts, 1)
+	}
+
+	if opts.DeleteNulls {
+		dst = removeDeleted(dst)
+	}
+
+	return dst, nil
+}
+
+// deleted marks keys to be deleted when merging with DeleteNulls
+type deleted struct{}
+
+// markNulls returns a copy of the map with nulls (in it or in nested maps)
+// replaced by the deleted marker
+func markNulls(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+
+	for k, v := range m {
+		switch v := v.(type) {
+		case nil:
+			out[k] = deleted{}
+		case map[string]interface{}:
+			out[k] = markNulls(v)
+		default:
+			out[k] = v
+		}
+	}
+
+	return out
+}
+
+// removeDeleted returns a copy of the map without keys marked as deleted
+func removeDeleted(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+
+	for k, v := range m {
+		switch v := v.(type) {
+		case deleted:
+		case map[string]interface{}:
+			out[k] = removeDeleted(v)
+		default:
+			out[k] = v
+		}
+	}
+
+	return out
+}
+
+// Merges a default and override map, whose keys are at the given depth
+func mergeValues(d map[string]interface{}, o map[string]interface{}, opts MergeOptions, depth int) map[string]interface{} {
+	def := copyMap(d)
+	over := copyMap(o)
+	for k, v := range over {
+		// If the key doesn't exist already, then just set the key to that value
+		if _, exists := def[k]; !exists {
+			def[k] = v
+			continue
+		}
+
+		def[k] = mergeValue(def[k], v, opts, depth)
+	}
+	return def
+}
+
+// mergeValue merges a default and override value at the given depth - maps
+// are merged, lists are combined according to the array merge strategy, and
+// otherwise the override wins
+func mergeValue(d, o interface{}, opts MergeOptions, depth int) interface{} {
+	atLimit := opts.Depth > 0 && depth >= opts.Depth
+
+	switch over := o.(type) {
+	case map[string]interface{}:
+		if def, ok := d.(map[string]interface{}); ok && !atLimit {
+			return mergeValues(def, over, opts, depth+1)
+		}
+	case []interface{}:
+		if def, ok := d.([]interface{}); ok && !atLimit {
+			return mergeLists(def, over, opts, depth+1)
+		}
+	default:
+		if opts.CoerceScalars {
+			return coerceScalar(d, o)
+		}
+	}
+
+	return o
+}
+
+// coerceScalar converts the override value to the type of the default value,
+// if they're both scalars and the conversion is lossless. Otherwise, the
+// override value is returned unchanged.
+func coerceScalar(d, o interface{}) interface{} {
+	dv, ov := reflect.ValueOf(d), reflect.ValueOf(o)
+	if !dv.IsValid() || !ov.IsValid() || dv.Type() == ov.Type() || !isScalarKind(ov.Kind()) {
+		return o
+	}
+
+	s := fmt.Sprint(o)
+
+	var out reflect.Value
+
+	//nolint:exhaustive
+	switch dv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || dv.OverflowInt(n) {
+			return o
+		}
+
+		out = reflect.ValueOf(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil || dv.OverflowUint(n) {
+			return o
+		}
+
+		out = reflect.ValueOf(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, dv.Type().Bits())
+		if err != nil {
+			return o
+		}
+
+		out = reflect.ValueOf(f)
+	case reflect.Bool:
+		// only strings are converted to bools - 1 and 0 are too ambiguous
+		if ov.Kind() != reflect.String {
+			return o
+		}
+
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return o
+		}
+
+		out = reflect.ValueOf(b)
+	case reflect.String:
+		out = reflect.ValueOf(s)
+	default:
+		return o
+	}
+
+	return out.Convert(dv.Type()).Interface()
+}
+
+func isScalarKind(k reflect.Kind) bool {
+	//nolint:exhaustive
+	switch k {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// mergeLists combines a default and override list, whose items are at the given
+// depth, according to the array merge strategy
+func mergeLists(d, o []interface{}, opts MergeOptions, depth int) []interface{} {
+	if key, ok := commonListKey(opts.ListKeys, d, o); ok {
+		return mergeListsByKey(key, d, o, opts, depth)
+	}
+
+	switch opts.Arrays {
+	case ArraysAppend:
+		out := make([]interface{}, 0, len(d)+len(o))
+		out = append(out, d...)
+
+		return append(out, o...)
+	case ArraysUnique:
+		out := make([]interface{}, 0, len(d)+len(o))
+
+		for _, v := range append(append([]interface{}{}, d...), o...) {
+			if !containsValue(out, v) {
+				out = append(out, v)
+			}
+		}
+
+		return out
+	case ArraysByIndex:
+		out := make([]interface{}, max(len(d), len(o)))
+		copy(out, d)
+
+		for i, v := range o {
+			if i < len(d) {
+				out[i] = mergeValue(d[i], v, opts, depth)
+			} else {
+				out[i] = v
+			}
+		}
+
+		return out
+	default:
+		return o
+	}
+}
+
+// commonListKey returns the first of the keys which is present in every item
+// of the lists, if all items are maps
+func commonListKey(keys []string, lists ...[]interface{}) (string, bool) {
+	for _, key := range keys {
+		found := true
+
+		for _, list := range lists {
+			for _, item := range list {
+				m, ok := item.(map[string]interface{})
+				if !ok {
+					return "", false
+				}
+
+				if _, ok := m[key]; !ok {
+					found = false
+				}
+			}
+		}
+
+		if found {
+			return key, true
+		}
+	}
+
+	return "", false
+}
+
+// mergeListsByKey merges the items in the lists (all maps) which have the same
+// value for the key, and appends the override items which don't match any
+// default item
+func mergeListsByKey(key string, d, o []interface{}, opts MergeOptions, depth int) []interface{} {
+	out := make([]interface{}, len(d), len(d)+len(o))
+	copy(out, d)
+
+	for _, v := range o {
+		over := v.(map[string]interface{})
+
+		i := indexByKey(out[:len(d)], key, over[key])
+		if i < 0 {
+			out = append(out, over)
+			continue
+		}
+
+		out[i] = mergeValue(out[i], over, opts, depth)
+	}
+
+	return out
+}
+
+func indexByKey(list []interface{}, key string, value interface{}) int {
+	for i, item := range list {
+		if reflect.DeepEqual(item.(map[string]interface{})[key], value) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func containsValue(list []interface{}, v interface{}) bool {
+	for _, item := range list {
+		if reflect.DeepEqual(item, v) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,380 @@
+package coll
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeWithOptions_Arrays(t *testing.T) {
+	dst := map[string]interface{}{
+		"list": []interface{}{"b", "c"},
+		"m": map[string]interface{}{
+			"items": []interface{}{map[string]interface{}{"a": 1}},
+		},
+	}
+	src := map[string]interface{}{
+		"list": []interface{}{"a", "b"},
+		"m": map[string]interface{}{
+			"items": []interface{}{map[string]interface{}{"a": 2, "b": 2}, "x"},
+		},
+	}
+
+	testdata := []struct {
+		expected map[string]interface{}
+		arrays   ArrayMergeStrategy
+	}{
+		{
+			map[string]interface{}{
+				"list": []interface{}{"b", "c"},
+				"m": map[string]interface{}{
+					"items": []interface{}{map[string]interface{}{"a": 1}},
+				},
+			},
+			"",
+		},
+		{
+			map[string]interface{}{
+				"list": []interface{}{"b", "c"},
+				"m": map[string]interface{}{
+					"items": []interface{}{map[string]interface{}{"a": 1}},
+				},
+			},
+			ArraysReplace,
+		},
+		{
+			map[string]interface{}{
+				"list": []interface{}{"a", "b", "b", "c"},
+				"m": map[string]interface{}{
+					"items": []interface{}{
+						map[string]interface{}{"a": 2, "b": 2}, "x",
+						map[string]interface{}{"a": 1},
+					},
+				},
+			},
+			ArraysAppend,
+		},
+		{
+			map[string]interface{}{
+				"list": []interface{}{"a", "b", "c"},
+				"m": map[string]interface{}{
+					"items": []interface{}{
+						map[string]interface{}{"a": 2, "b": 2}, "x",
+						map[string]interface{}{"a": 1},
+					},
+				},
+			},
+			ArraysUnique,
+		},
+		{
+			map[string]interface{}{
+				"list": []interface{}{"b", "c"},
+				"m": map[string]interface{}{
+					"items": []interface{}{map[string]interface{}{"a": 1, "b": 2}, "x"},
+				},
+			},
+			ArraysByIndex,
+		},
+	}
+
+	for _, d := range testdata {
+		t.Run(string(d.arrays), func(t *testing.T) {
+			out, err := MergeWithOptions(MergeOptions{Arrays: d.arrays}, dst, src)
+			require.NoError(t, err)
+			assert.EqualValues(t, d.expected, out)
+		})
+	}
+
+	// the inputs aren't modified
+	assert.Equal(t, []interface{}{"b", "c"}, dst["list"])
+	assert.Equal(t, []interface{}{"a", "b"}, src["list"])
+
+	_, err := MergeWithOptions(MergeOptions{Arrays: "bogus"}, dst, src)
+	require.ErrorContains(t, err, "unknown array merge strategy")
+}
+
+func TestMergeWithOptions_ListKeys(t *testing.T) {
+	dst := map[string]interface{}{
+		"containers": []interface{}{
+			map[string]interface{}{"name": "app", "image": "app:2"},
+			map[string]interface{}{"name": "sidecar", "image": "proxy:1"},
+		},
+		"ports": []interface{}{
+			map[string]interface{}{"containerPort": 8080, "protocol": "TCP"},
+		},
+		"args": []interface{}{"--debug"},
+	}
+	src := map[string]interface{}{
+		"containers": []interface{}{
+			map[string]interface{}{
+				"name": "app", "image": "app:1",
+				"env": []interface{}{map[string]interface{}{"name": "A", "value": "1"}},
+			},
+			map[string]interface{}{"name": "init", "image": "busybox"},
+		},
+		"ports": []interface{}{
+			map[string]interface{}{"containerPort": 8080, "protocol": "UDP"},
+			map[string]interface{}{"containerPort": 9090},
+		},
+		"args": []interface{}{"--verbose"},
+	}
+
+	out, err := MergeWithOptions(MergeOptions{ListKeys: []string{"name", "containerPort"}}, dst, src)
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{
+		"containers": []interface{}{
+			map[string]interface{}{
+				"name": "app", "image": "app:2",
+				"env": []interface{}{map[string]interface{}{"name": "A", "value": "1"}},
+			},
+			map[string]interface{}{"name": "init", "image": "busybox"},
+			map[string]interface{}{"name": "sidecar", "image": "proxy:1"},
+		},
+		"ports": []interface{}{
+			map[string]interface{}{"containerPort": 8080, "protocol": "TCP"},
+			map[string]interface{}{"containerPort": 9090},
+		},
+		"args": []interface{}{"--debug"},
+	}, out)
+
+	// lists without the key in every item are combined by the array strategy
+	dst = map[string]interface{}{
+		"l": []interface{}{map[string]interface{}{"name": "a"}, map[string]interface{}{"x": 1}},
+	}
+	src = map[string]interface{}{
+		"l": []interface{}{map[string]interface{}{"name": "a", "y": 2}},
+	}
+
+	out, err = MergeWithOptions(MergeOptions{ListKeys: []string{"name"}, Arrays: ArraysAppend}, dst, src)
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{
+		"l": []interface{}{
+			map[string]interface{}{"name": "a", "y": 2},
+			map[string]interface{}{"name": "a"},
+			map[string]interface{}{"x": 1},
+		},
+	}, out)
+}
+
+func TestMergeWithOptions_DeleteNulls(t *testing.T) {
+	def := map[string]interface{}{
+		"a": 1,
+		"b": map[string]interface{}{"c": 1, "d": 1},
+		"e": nil,
+		"f": 1,
+	}
+	mid := map[string]interface{}{
+		"b": map[string]interface{}{"c": nil},
+		"f": nil,
+		"g": map[string]interface{}{"h": nil, "i": 1},
+	}
+	over := map[string]interface{}{
+		"a": nil,
+		"f": map[string]interface{}{"x": nil, "y": 1},
+		"l": []interface{}{nil, 1},
+	}
+
+	out, err := MergeWithOptions(MergeOptions{DeleteNulls: true}, over, mid, def)
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{
+		"b": map[string]interface{}{"d": 1},
+		"e": nil,
+		"f": map[string]interface{}{"y": 1},
+		"g": map[string]interface{}{"i": 1},
+		"l": []interface{}{nil, 1},
+	}, out)
+
+	// the inputs aren't modified
+	assert.Contains(t, over, "a")
+	assert.Contains(t, mid["b"], "c")
+
+	// nulls are kept otherwise
+	out, err = MergeWithOptions(MergeOptions{}, over, def)
+	require.NoError(t, err)
+	assert.Contains(t, out, "a")
+	assert.Nil(t, out["a"])
+
+	// a single map is unchanged
+	out, err = MergeWithOptions(MergeOptions{DeleteNulls: true}, def)
+	require.NoError(t, err)
+	assert.EqualValues(t, def, out)
+}
+
+func TestMergeWithOptions_Depth(t *testing.T) {
+	def := map[string]interface{}{
+		"a": map[string]interface{}{
+			"b": map[string]interface{}{"c": 1, "d": 1},
+			"e": 1,
+		},
+		"l": []interface{}{1},
+		"x": 1,
+	}
+	over := map[string]interface{}{
+		"a": map[string]interface{}{
+			"b": map[string]interface{}{"c": 2},
+		},
+		"l": []interface{}{2},
+	}
+
+	testdata := []struct {
+		expected map[string]interface{}
+		depth    int
+	}{
+		{
+			map[string]interface{}{
+				"a": map[string]interface{}{
+					"b": map[string]interface{}{"c": 2, "d": 1},
+					"e": 1,
+				},
+				"l": []interface{}{1, 2},
+				"x": 1,
+			},
+			0,
+		},
+		{
+			map[string]interface{}{
+				"a": map[string]interface{}{
+					"b": map[string]interface{}{"c": 2},
+				},
+				"l": []interface{}{2},
+				"x": 1,
+			},
+			1,
+		},
+		{
+			map[string]interface{}{
+				"a": map[string]interface{}{
+					"b": map[string]interface{}{"c": 2},
+					"e": 1,
+				},
+				"l": []interface{}{1, 2},
+				"x": 1,
+			},
+			2,
+		},
+		{
+			map[string]interface{}{
+				"a": map[string]interface{}{
+					"b": map[string]interface{}{"c": 2, "d": 1},
+					"e": 1,
+				},
+				"l": []interface{}{1, 2},
+				"x": 1,
+			},
+			3,
+		},
+	}
+
+	for _, d := range testdata {
+		out, err := MergeWithOptions(MergeOptions{Depth: d.depth, Arrays: ArraysAppend}, over, def)
+		require.NoError(t, err)
+		assert.EqualValues(t, d.expected, out, "depth %d", d.depth)
+	}
+
+	// items in lists are nested a level deeper
+	out, err := MergeWithOptions(MergeOptions{Depth: 2, ListKeys: []string{"name"}},
+		map[string]interface{}{"l": []interface{}{
+			map[string]interface{}{"name": "a", "m": map[string]interface{}{"y": 2}},
+		}},
+		map[string]interface{}{"l": []interface{}{
+			map[string]interface{}{"name": "a", "m": map[string]interface{}{"x": 1}, "z": 1},
+		}})
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{"l": []interface{}{
+		map[string]interface{}{"name": "a", "m": map[string]interface{}{"y": 2}},
+	}}, out)
+
+	_, err = MergeWithOptions(MergeOptions{Depth: -1}, over, def)
+	require.Error(t, err)
+}
+
+func TestMergeWithOptions_CoerceScalars(t *testing.T) {
+	def := map[string]interface{}{
+		"int":     8080,
+		"int64":   int64(1),
+		"uint":    uint8(1),
+		"float":   1.5,
+		"bool":    true,
+		"str":     "1",
+		"nothing": nil,
+		"nested":  map[string]interface{}{"port": 80},
+		"list":    []interface{}{1},
+	}
+	over := map[string]interface{}{
+		"int":     "9090",
+		"int64":   2.0,
+		"uint":    "300",
+		"float":   "2",
+		"bool":    "false",
+		"str":     2,
+		"nothing": "x",
+		"nested":  map[string]interface{}{"port": "443"},
+		"list":    "1",
+	}
+
+	out, err := MergeWithOptions(MergeOptions{CoerceScalars: true}, over, def)
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{
+		"int":   9090,
+		"int64": int64(2),
+		// too big for a uint8
+		"uint":    "300",
+		"float":   2.0,
+		"bool":    false,
+		"str":     "2",
+		"nothing": "x",
+		"nested":  map[string]interface{}{"port": 443},
+		// only scalars are coerced
+		"list": "1",
+	}, out)
+
+	// incompatible values are used as-is
+	out, err = MergeWithOptions(MergeOptions{CoerceScalars: true},
+		map[string]interface{}{"a": "x", "b": 1.5, "c": 1, "d": true},
+		map[string]interface{}{"a": 1, "b": 1, "c": false, "d": 1})
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{"a": "x", "b": 1.5, "c": 1, "d": true}, out)
+
+	// without the option, the types aren't changed
+	out, err = MergeWithOptions(MergeOptions{}, over, def)
+	require.NoError(t, err)
+	assert.Equal(t, "9090", out["int"])
+}
+
+func TestParseArrayMergeStrategy(t *testing.T) {
+	s, err := ParseArrayMergeStrategy("")
+	require.NoError(t, err)
+	assert.Equal(t, ArraysReplace, s)
+
+	s, err = ParseArrayMergeStrategy("byIndex")
+	require.NoError(t, err)
+	assert.Equal(t, ArraysByIndex, s)
+
+	_, err = ParseArrayMergeStrategy("byindex")
+	require.Error(t, err)
+}
+
+func TestParseMergeOptions(t *testing.T) {
+	opts, err := ParseMergeOptions(url.Values{})
+	require.NoError(t, err)
+	assert.Equal(t, MergeOptions{Arrays: ArraysReplace}, opts)
+
+	q, _ := url.ParseQuery("arrays=unique&listKey=name,+id&nulls=delete&depth=2&coerce=true&format=json")
+	opts, err = ParseMergeOptions(q)
+	require.NoError(t, err)
+	assert.Equal(t, MergeOptions{
+		Arrays:        ArraysUnique,
+		ListKeys:      []string{"name", "id"},
+		DeleteNulls:   true,
+		Depth:         2,
+		CoerceScalars: true,
+	}, opts)
+
+	for _, bad := range []string{"arrays=bogus", "nulls=bogus", "depth=-1", "depth=x", "coerce=maybe"} {
+		q, _ := url.ParseQuery(bad)
+		_, err = ParseMergeOptions(q)
+		require.Error(t, err, bad)
+	}
+}
@@ -0,0 +1,74 @@
+package coll
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// FlattenDepth flattens nested data to at most 'depth' levels. Use depth of
+// -1 to completely flatten the input.
+//
+// Lists are flattened as with [Flatten]. Maps are flattened into a single map,
+// with each nested entry's keys (and list indexes) joined with "." - so
+// {"a": {"b": [1, 2]}} flattens to {"a.b.0": 1, "a.b.1": 2}.
+//
+// Returns a new map or slice without modifying the input.
+func FlattenDepth(depth int, in interface{}) (interface{}, error) {
+	v := reflect.Indirect(reflect.ValueOf(in))
+	if !isStringMap(v) {
+		return Flatten(in, depth)
+	}
+
+	out := map[string]interface{}{}
+	for _, k := range v.MapKeys() {
+		flattenEntry(out, k.String(), v.MapIndex(k).Interface(), depth)
+	}
+
+	return out, nil
+}
+
+func flattenEntry(out map[string]interface{}, path string, in interface{}, depth int) {
+	v := reflect.Indirect(reflect.ValueOf(in))
+
+	switch {
+	case depth == 0 || !isContainer(v):
+		out[path] = in
+	case isStringMap(v):
+		for _, k := range v.MapKeys() {
+			flattenEntry(out, joinPath(path, k.String()), v.MapIndex(k).Interface(), depth-1)
+		}
+	default:
+		for i := 0; i < v.Len(); i++ {
+			flattenEntry(out, joinPath(path, strconv.Itoa(i)), v.Index(i).Interface(), depth-1)
+		}
+	}
+}
+
+// Paths returns the paths to all leaf values (values which aren't non-empty
+// maps or lists) in the given nested data, sorted. Paths are map keys and list
+// indexes joined with ".", like "servers.0.port".
+func Paths(in interface{}) []string {
+	v := reflect.Indirect(reflect.ValueOf(in))
+	if !isContainer(v) {
+		return []string{}
+	}
+
+	flat := map[string]interface{}{}
+	flattenEntry(flat, "", in, -1)
+
+	out := make([]string, 0, len(flat))
+	for k := range flat {
+		out = append(out, k)
+	}
+
+	sort.Strings(out)
+
+	return out
+}
+
+// isContainer reports whether v is a non-empty map with string keys, or a
+// non-empty list
+func isContainer(v reflect.Value) bool {
+	return (isStringMap(v) || isList(v)) && v.Len() > 0
+}
@@ -0,0 +1,78 @@
+package coll
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlattenDepth(t *testing.T) {
+	t.Parallel()
+
+	in := map[string]interface{}{
+		"name": "app",
+		"db": map[string]interface{}{
+			"host":  "localhost",
+			"ports": []interface{}{5432, 5433},
+			"opts":  map[string]interface{}{},
+		},
+		"tags": []string{},
+	}
+
+	out, err := FlattenDepth(-1, in)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"name":       "app",
+		"db.host":    "localhost",
+		"db.ports.0": 5432,
+		"db.ports.1": 5433,
+		"db.opts":    map[string]interface{}{},
+		"tags":       []string{},
+	}, out)
+
+	out, err = FlattenDepth(1, in)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"name":     "app",
+		"db.host":  "localhost",
+		"db.ports": []interface{}{5432, 5433},
+		"db.opts":  map[string]interface{}{},
+		"tags":     []string{},
+	}, out)
+
+	out, err = FlattenDepth(0, in)
+	require.NoError(t, err)
+	assert.Equal(t, in, out)
+
+	out, err = FlattenDepth(-1, map[string]map[string]int{"a": {"b": 1}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a.b": 1}, out)
+
+	// lists are flattened like Flatten
+	out, err = FlattenDepth(1, []interface{}{1, []interface{}{2, []int{3}}})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2, []int{3}}, out)
+
+	_, err = FlattenDepth(1, 42)
+	require.Error(t, err)
+}
+
+func TestPaths(t *testing.T) {
+	t.Parallel()
+
+	in := map[string]interface{}{
+		"name": "app",
+		"db": map[string]interface{}{
+			"host":  "localhost",
+			"ports": []interface{}{5432, map[string]interface{}{"tls": 5433}},
+		},
+		"tags": []string{},
+	}
+
+	assert.Equal(t, []string{"db.host", "db.ports.0", "db.ports.1.tls", "name", "tags"}, Paths(in))
+	assert.Equal(t, []string{"0", "1.a"}, Paths([]interface{}{1, map[string]int{"a": 2}}))
+	assert.Equal(t, []string{}, Paths(map[string]interface{}{}))
+	assert.Equal(t, []string{}, Paths(42))
+	assert.Equal(t, []string{}, Paths(nil))
+}
@@ -0,0 +1,126 @@
+package coll
+
+import (
+	iconv "github.com/hairyhenderson/gomplate/v4/internal/conv"
+)
+
+// The set operations below treat lists as sets: the results contain no
+// duplicates, and items are compared with deep equality (see [Has]), so lists
+// of maps or lists can be combined. Items keep the order in which they first
+// appear in the input lists.
+
+// Union returns the items in any of the given lists.
+func Union(lists ...interface{}) ([]interface{}, error) {
+	ls, err := interfaceSlices(lists)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []interface{}{}
+	for _, l := range ls {
+		for _, v := range l {
+			if !Has(out, v) {
+				out = append(out, v)
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// Intersection returns the items in all of the given lists.
+func Intersection(lists ...interface{}) ([]interface{}, error) {
+	ls, err := interfaceSlices(lists)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []interface{}{}
+	if len(ls) == 0 {
+		return out, nil
+	}
+
+	for _, v := range ls[0] {
+		if Has(out, v) || !inAll(ls[1:], v) {
+			continue
+		}
+
+		out = append(out, v)
+	}
+
+	return out, nil
+}
+
+// Difference returns the items in the list which aren't in any of the other
+// lists.
+func Difference(list interface{}, others ...interface{}) ([]interface{}, error) {
+	l, err := iconv.InterfaceSlice(list)
+	if err != nil {
+		return nil, err
+	}
+
+	ls, err := interfaceSlices(others)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []interface{}{}
+	for _, v := range l {
+		if Has(out, v) || inAny(ls, v) {
+			continue
+		}
+
+		out = append(out, v)
+	}
+
+	return out, nil
+}
+
+// SymmetricDifference returns the items in exactly one of the two lists.
+func SymmetricDifference(a, b interface{}) ([]interface{}, error) {
+	ab, err := Difference(a, b)
+	if err != nil {
+		return nil, err
+	}
+
+	ba, err := Difference(b, a)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(ab, ba...), nil
+}
+
+func interfaceSlices(lists []interface{}) ([][]interface{}, error) {
+	out := make([][]interface{}, len(lists))
+	for i, list := range lists {
+		l, err := iconv.InterfaceSlice(list)
+		if err != nil {
+			return nil, err
+		}
+
+		out[i] = l
+	}
+
+	return out, nil
+}
+
+func inAll(lists [][]interface{}, v interface{}) bool {
+	for _, l := range lists {
+		if !Has(l, v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func inAny(lists [][]interface{}, v interface{}) bool {
+	for _, l := range lists {
+		if Has(l, v) {
+			return true
+		}
+	}
+
+	return false
+}
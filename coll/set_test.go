@@ -0,0 +1,96 @@
+package coll
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnion(t *testing.T) {
+	t.Parallel()
+
+	out, err := Union([]int{1, 2, 2}, []interface{}{3, 1}, []int{4})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2, 3, 4}, out)
+
+	out, err = Union(
+		[]interface{}{map[string]interface{}{"a": 1}},
+		[]interface{}{map[string]interface{}{"a": 1}, map[string]interface{}{"a": 2}},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{map[string]interface{}{"a": 1}, map[string]interface{}{"a": 2}}, out)
+
+	out, err = Union()
+	require.NoError(t, err)
+	assert.Empty(t, out)
+
+	_, err = Union([]int{1}, 42)
+	require.Error(t, err)
+}
+
+func TestIntersection(t *testing.T) {
+	t.Parallel()
+
+	out, err := Intersection([]string{"a", "b", "c", "b"}, []string{"c", "b", "d"}, []string{"b", "c"})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"b", "c"}, out)
+
+	out, err = Intersection(
+		[]interface{}{map[string]interface{}{"a": []interface{}{1}}, map[string]interface{}{"a": 2}},
+		[]interface{}{map[string]interface{}{"a": []interface{}{1}}},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{map[string]interface{}{"a": []interface{}{1}}}, out)
+
+	out, err = Intersection([]int{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2}, out)
+
+	out, err = Intersection()
+	require.NoError(t, err)
+	assert.Empty(t, out)
+
+	_, err = Intersection("foo")
+	require.Error(t, err)
+}
+
+func TestDifference(t *testing.T) {
+	t.Parallel()
+
+	out, err := Difference([]int{1, 2, 3, 4, 1}, []int{2}, []int{4, 5})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 3}, out)
+
+	out, err = Difference(
+		[]interface{}{map[string]interface{}{"a": 1}, map[string]interface{}{"a": 2}},
+		[]interface{}{map[string]interface{}{"a": 2}},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{map[string]interface{}{"a": 1}}, out)
+
+	out, err = Difference([]int{1, 1})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1}, out)
+
+	_, err = Difference(42)
+	require.Error(t, err)
+
+	_, err = Difference([]int{1}, 42)
+	require.Error(t, err)
+}
+
+func TestSymmetricDifference(t *testing.T) {
+	t.Parallel()
+
+	out, err := SymmetricDifference([]int{1, 2, 3}, []int{3, 4, 4})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2, 4}, out)
+
+	out, err = SymmetricDifference([]int{1}, []int{1})
+	require.NoError(t, err)
+	assert.Empty(t, out)
+
+	_, err = SymmetricDifference([]int{1}, 42)
+	require.Error(t, err)
+}
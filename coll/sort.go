@@ -0,0 +1,296 @@
+package coll
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	iconv "github.com/hairyhenderson/gomplate/v4/internal/conv"
+	gompstrings "github.com/hairyhenderson/gomplate/v4/strings"
+)
+
+// Sort a given array or slice. Uses natural sort order if possible. If a
+// non-empty key is given and the list elements are maps, this will attempt to
+// sort by the values of those entries. See [SortBy] for the key syntax.
+//
+// Does not modify the input list.
+func Sort(key string, list interface{}) (out []interface{}, err error) {
+	var keys []string
+	if key != "" {
+		keys = []string{key}
+	}
+
+	return SortBy(keys, list)
+}
+
+// SortBy sorts a given array or slice by one or more keys, in order of
+// precedence. Each key is a dot-separated path to a value within the list's
+// elements (maps or structs), like "metadata.name". An empty path (or ".")
+// refers to the element itself.
+//
+// A key may be prefixed with "-" to sort in descending order (or "+" for the
+// default ascending order), and suffixed with ":natural" to compare values as
+// strings in natural order (so "a2" sorts before "a10"), or ":numeric" to
+// compare values as numbers (so "9" sorts before "10"). Keys that exist as-is
+// in the elements (like "a:b" or "-x") are used literally, without modifiers.
+// Elements without a value for a key sort after all others, regardless of the
+// order.
+//
+// Lists with elements of different types aren't sorted, and are returned
+// unmodified. Does not modify the input list.
+func SortBy(keys []string, list interface{}) ([]interface{}, error) {
+	if list == nil {
+		return nil, nil
+	}
+
+	ia, err := iconv.InterfaceSlice(list)
+	if err != nil {
+		return nil, err
+	}
+
+	sks := make([]sortKey, 0, len(keys))
+	for _, k := range keys {
+		if sk, ok := literalSortKey(k, ia); ok {
+			sks = append(sks, sk)
+
+			continue
+		}
+
+		sk, err := parseSortKey(k)
+		if err != nil {
+			return nil, err
+		}
+
+		sks = append(sks, sk)
+	}
+
+	if len(sks) == 0 {
+		sks = []sortKey{{}}
+	}
+
+	// if the types are all the same, we can sort the slice
+	if !sameTypes(ia) {
+		return ia, nil
+	}
+
+	s := make([]interface{}, len(ia))
+	// make a copy so the original is unmodified
+	copy(s, ia)
+
+	sort.SliceStable(s, func(i, j int) bool {
+		for _, sk := range sks {
+			if c := sk.compare(s[i], s[j]); c != 0 {
+				return c < 0
+			}
+		}
+
+		return false
+	})
+
+	return s, nil
+}
+
+// sortKey is a parsed key for SortBy
+type sortKey struct {
+	raw  string
+	mode string
+	path []string
+	desc bool
+}
+
+// literalSortKey returns a sort key for k as-is, with no modifiers, when any
+// element in the list has a value for it
+func literalSortKey(k string, list []interface{}) (sortKey, bool) {
+	if k == "" {
+		return sortKey{}, false
+	}
+
+	for _, v := range list {
+		if _, ok := valueAtPath(v, []string{k}); ok {
+			return sortKey{raw: k, path: []string{k}}, true
+		}
+	}
+
+	return sortKey{}, false
+}
+
+func parseSortKey(k string) (sortKey, error) {
+	sk := sortKey{}
+
+	switch {
+	case strings.HasPrefix(k, "-"):
+		sk.desc = true
+		k = k[1:]
+	case strings.HasPrefix(k, "+"):
+		k = k[1:]
+	}
+
+	if i := strings.LastIndex(k, ":"); i >= 0 {
+		sk.mode = k[i+1:]
+		if sk.mode != "natural" && sk.mode != "numeric" {
+			return sk, fmt.Errorf("invalid sort key %q: unknown comparison %q (must be natural or numeric)", k, sk.mode)
+		}
+
+		k = k[:i]
+	}
+
+	sk.raw = k
+
+	if k = strings.TrimPrefix(k, "."); k != "" {
+		sk.path = strings.Split(k, ".")
+	}
+
+	return sk, nil
+}
+
+// value returns the key's value in v, or false if there isn't one
+func (sk sortKey) value(v interface{}) (interface{}, bool) {
+	// keys containing dots are looked up directly first, for compatibility
+	if len(sk.path) > 1 {
+		if out, ok := valueAtPath(v, []string{sk.raw}); ok && out != nil {
+			return out, true
+		}
+	}
+
+	out, ok := valueAtPath(v, sk.path)
+	if !ok || out == nil {
+		return nil, false
+	}
+
+	if sk.mode == "numeric" {
+		f, err := conv.ToFloat64(out)
+		if err != nil {
+			return nil, false
+		}
+
+		return f, true
+	}
+
+	return out, true
+}
+
+// compare returns -1 if left sorts before right, +1 if it sorts after, and 0
+// if they're equal or can't be compared
+func (sk sortKey) compare(left, right interface{}) int {
+	lv, lok := sk.value(left)
+	rv, rok := sk.value(right)
+
+	switch {
+	case !lok && !rok:
+		return 0
+	case !lok:
+		return 1
+	case !rok:
+		return -1
+	}
+
+	var c int
+	if sk.mode == "natural" {
+		c = gompstrings.CompareNatural(conv.ToString(lv), conv.ToString(rv))
+	} else {
+		c = compareValues(lv, rv)
+	}
+
+	if sk.desc {
+		return -c
+	}
+
+	return c
+}
+
+// compareValues compares two values of the same basic type, returning 0 for
+// values which aren't comparable. Integers and floats can be compared with
+// each other.
+func compareValues(left, right interface{}) int {
+	val := reflect.Indirect(reflect.ValueOf(left))
+	rval := reflect.Indirect(reflect.ValueOf(right))
+
+	switch {
+	case isInt(val) && isInt(rval):
+		return cmpOrdered(val.Int(), rval.Int())
+	case isUint(val) && isUint(rval):
+		return cmpOrdered(val.Uint(), rval.Uint())
+	case isNumber(val) && isNumber(rval):
+		return cmpOrdered(toFloat(val), toFloat(rval))
+	case val.Kind() == reflect.String && rval.Kind() == reflect.String:
+		return strings.Compare(val.String(), rval.String())
+	case val.Kind() == reflect.Bool && rval.Kind() == reflect.Bool:
+		return cmpBool(val.Bool(), rval.Bool())
+	default:
+		// it's not really comparable, so...
+		return 0
+	}
+}
+
+func cmpOrdered[T int64 | uint64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// cmpBool orders false before true
+func cmpBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case b:
+		return -1
+	default:
+		return 1
+	}
+}
+
+func isInt(v reflect.Value) bool {
+	//nolint:exhaustive
+	switch v.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
+		return true
+	default:
+		return false
+	}
+}
+
+func isUint(v reflect.Value) bool {
+	//nolint:exhaustive
+	switch v.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isNumber(v reflect.Value) bool {
+	return isInt(v) || isUint(v) || v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64
+}
+
+func toFloat(v reflect.Value) float64 {
+	switch {
+	case isInt(v):
+		return float64(v.Int())
+	case isUint(v):
+		return float64(v.Uint())
+	default:
+		return v.Float()
+	}
+}
+
+func sameTypes(a []interface{}) bool {
+	var t reflect.Type
+	for _, v := range a {
+		if t == nil {
+			t = reflect.TypeOf(v)
+		}
+		if reflect.ValueOf(v).Kind() != t.Kind() {
+			return false
+		}
+	}
+	return true
+}
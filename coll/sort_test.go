@@ -0,0 +1,87 @@
+package coll
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortBy(t *testing.T) {
+	t.Parallel()
+
+	people := []interface{}{
+		map[string]interface{}{"name": "Bart", "age": 10, "meta": map[string]interface{}{"id": "u10"}},
+		map[string]interface{}{"name": "Lisa", "age": 8, "meta": map[string]interface{}{"id": "u9"}},
+		map[string]interface{}{"name": "Maggie", "age": 1, "meta": map[string]interface{}{"id": "u100"}},
+		map[string]interface{}{"name": "Abe", "age": 83.0},
+		map[string]interface{}{"name": "Homer", "age": 39, "meta": map[string]interface{}{"id": "u2"}},
+		map[string]interface{}{"name": "Marge", "age": 39, "meta": map[string]interface{}{"id": "u3"}},
+	}
+
+	names := func(l []interface{}) []string {
+		out := make([]string, len(l))
+		for i, v := range l {
+			out[i] = v.(map[string]interface{})["name"].(string)
+		}
+
+		return out
+	}
+
+	out, err := SortBy([]string{"-age", "name"}, people)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Abe", "Homer", "Marge", "Bart", "Lisa", "Maggie"}, names(out))
+
+	out, err = SortBy([]string{"-age", "-name"}, people)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Abe", "Marge", "Homer", "Bart", "Lisa", "Maggie"}, names(out))
+
+	// missing values sort last, in either order
+	out, err = SortBy([]string{"meta.id"}, people)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Bart", "Maggie", "Homer", "Marge", "Lisa", "Abe"}, names(out))
+
+	out, err = SortBy([]string{"-.meta.id:natural"}, people)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Maggie", "Bart", "Lisa", "Marge", "Homer", "Abe"}, names(out))
+
+	// the input isn't modified
+	assert.Equal(t, "Bart", people[0].(map[string]interface{})["name"])
+
+	out, err = SortBy([]string{":numeric"}, []string{"10", "9", "100", "-1", "x"})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"-1", "9", "10", "100", "x"}, out)
+
+	out, err = SortBy([]string{"-"}, []int{2, 3, 1})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{3, 2, 1}, out)
+
+	out, err = SortBy([]string{".:natural"}, []string{"img12.png", "img10.png", "img2.png", "img1.png"})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"img1.png", "img2.png", "img10.png", "img12.png"}, out)
+
+	out, err = SortBy([]string{"0"}, [][]interface{}{{"b", 1}, {"a", 2}})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{[]interface{}{"a", 2}, []interface{}{"b", 1}}, out)
+
+	// keys containing dots are looked up directly first
+	out, err = SortBy([]string{"a.b"}, []map[string]int{{"a.b": 2}, {"a.b": 1}})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{map[string]int{"a.b": 1}, map[string]int{"a.b": 2}}, out)
+
+	// keys that exist as-is aren't parsed for modifiers
+	out, err = SortBy([]string{"a:b"}, []map[string]int{{"a:b": 2}, {"a:b": 1}})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{map[string]int{"a:b": 1}, map[string]int{"a:b": 2}}, out)
+
+	out, err = SortBy([]string{"-x"}, []map[string]int{{"-x": 2, "x": 1}, {"-x": 1, "x": 2}})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{map[string]int{"-x": 1, "x": 2}, map[string]int{"-x": 2, "x": 1}}, out)
+
+	out, err = SortBy([]string{"age:numeric"}, []map[string]string{{"age:numeric": "b", "age": "9"}, {"age:numeric": "a", "age": "10"}})
+	require.NoError(t, err)
+	assert.Equal(t, "a", out[0].(map[string]string)["age:numeric"])
+
+	_, err = SortBy([]string{"name:bogus"}, people)
+	require.Error(t, err)
+}
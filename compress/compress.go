@@ -0,0 +1,124 @@
+// Package compress contains functions for compressing and decompressing data
+package compress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+// DefaultLevel selects the default compression level for the algorithm
+const DefaultLevel = -1
+
+// Gzip - compress data in gzip format, at the given level (1-9, or
+// DefaultLevel). The output is reproducible, since no modification time or
+// filename is recorded.
+func Gzip(level int, in []byte) ([]byte, error) {
+	if level != DefaultLevel && (level < gzip.BestSpeed || level > gzip.BestCompression) {
+		return nil, fmt.Errorf("invalid gzip level %d: must be between %d and %d", level, gzip.BestSpeed, gzip.BestCompression)
+	}
+
+	buf := &bytes.Buffer{}
+
+	w, err := gzip.NewWriterLevel(buf, level)
+	if err != nil {
+		return nil, err
+	}
+
+	return finish(w, buf, in)
+}
+
+// Gunzip - decompress gzip-compressed data
+func Gunzip(in []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(in))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gzip data: %w", err)
+	}
+	defer r.Close()
+
+	return readAll("gzip", r)
+}
+
+// Zstd - compress data in Zstandard format, at the given level (1-22, or
+// DefaultLevel). Levels are mapped to the closest of the four levels supported
+// by the encoder.
+func Zstd(level int, in []byte) ([]byte, error) {
+	zlevel := zstd.SpeedDefault
+	if level != DefaultLevel {
+		if level < 1 || level > 22 {
+			return nil, fmt.Errorf("invalid zstd level %d: must be between 1 and 22", level)
+		}
+
+		zlevel = zstd.EncoderLevelFromZstd(level)
+	}
+
+	buf := &bytes.Buffer{}
+
+	w, err := zstd.NewWriter(buf, zstd.WithEncoderLevel(zlevel))
+	if err != nil {
+		return nil, err
+	}
+
+	return finish(w, buf, in)
+}
+
+// Unzstd - decompress Zstandard-compressed data
+func Unzstd(in []byte) ([]byte, error) {
+	r, err := zstd.NewReader(bytes.NewReader(in))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read zstd data: %w", err)
+	}
+	defer r.Close()
+
+	return readAll("zstd", r)
+}
+
+// Brotli - compress data in Brotli format, at the given level (0-11, or
+// DefaultLevel)
+func Brotli(level int, in []byte) ([]byte, error) {
+	if level == DefaultLevel {
+		level = brotli.DefaultCompression
+	}
+
+	if level < brotli.BestSpeed || level > brotli.BestCompression {
+		return nil, fmt.Errorf("invalid brotli level %d: must be between %d and %d", level, brotli.BestSpeed, brotli.BestCompression)
+	}
+
+	buf := &bytes.Buffer{}
+
+	return finish(brotli.NewWriterLevel(buf, level), buf, in)
+}
+
+// Unbrotli - decompress Brotli-compressed data
+func Unbrotli(in []byte) ([]byte, error) {
+	return readAll("brotli", brotli.NewReader(bytes.NewReader(in)))
+}
+
+// finish writes the input to the compressing writer, and closes it to flush
+// the output to buf
+func finish(w io.WriteCloser, buf *bytes.Buffer, in []byte) ([]byte, error) {
+	_, err := w.Write(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compress data: %w", err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compress data: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func readAll(format string, r io.Reader) ([]byte, error) {
+	out, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s data: %w", format, err)
+	}
+
+	return out, nil
+}
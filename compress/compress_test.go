@@ -0,0 +1,80 @@
+package compress
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	in := bytes.Repeat([]byte("hello world "), 100)
+
+	testdata := []struct {
+		compress   func(int, []byte) ([]byte, error)
+		decompress func([]byte) ([]byte, error)
+		name       string
+		levels     []int
+	}{
+		{Gzip, Gunzip, "gzip", []int{DefaultLevel, 1, 9}},
+		{Zstd, Unzstd, "zstd", []int{DefaultLevel, 1, 3, 22}},
+		{Brotli, Unbrotli, "brotli", []int{DefaultLevel, 0, 11}},
+	}
+
+	for _, d := range testdata {
+		for _, level := range d.levels {
+			c, err := d.compress(level, in)
+			require.NoError(t, err, d.name)
+			assert.Less(t, len(c), len(in), d.name)
+
+			out, err := d.decompress(c)
+			require.NoError(t, err, d.name)
+			assert.Equal(t, in, out, d.name)
+		}
+
+		// empty input
+		c, err := d.compress(DefaultLevel, nil)
+		require.NoError(t, err, d.name)
+
+		out, err := d.decompress(c)
+		require.NoError(t, err, d.name)
+		assert.Empty(t, out, d.name)
+
+		_, err = d.decompress([]byte("not compressed"))
+		require.Error(t, err, d.name)
+	}
+}
+
+func TestGzipReproducible(t *testing.T) {
+	t.Parallel()
+
+	a, err := Gzip(DefaultLevel, []byte("hello"))
+	require.NoError(t, err)
+
+	b, err := Gzip(DefaultLevel, []byte("hello"))
+	require.NoError(t, err)
+
+	assert.Equal(t, a, b)
+}
+
+func TestInvalidLevels(t *testing.T) {
+	t.Parallel()
+
+	_, err := Gzip(0, nil)
+	require.Error(t, err)
+
+	_, err = Gzip(10, nil)
+	require.Error(t, err)
+
+	_, err = Zstd(0, nil)
+	require.Error(t, err)
+
+	_, err = Zstd(23, nil)
+	require.Error(t, err)
+
+	_, err = Brotli(12, nil)
+	require.Error(t, err)
+}
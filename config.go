@@ -68,12 +68,67 @@ type Config struct {
 	LDelim string `yaml:"leftDelim,omitempty"`
 	RDelim string `yaml:"rightDelim,omitempty"`
 
+	// FileDelims - delimiters for input files matching glob patterns, which
+	// override LDelim and RDelim. The first matching entry is used.
+	FileDelims []FileDelims `yaml:"fileDelims,omitempty"`
+
 	MissingKey string `yaml:"missingKey,omitempty"`
 
+	// Strict - when true, rendering fails when a template outputs a missing
+	// (nil) value, instead of printing "<no value>". Missing map keys are
+	// always errors in strict mode.
+	Strict bool `yaml:"strict,omitempty"`
+
 	PostExec []string `yaml:"postExec,omitempty,flow"`
 
 	PluginTimeout time.Duration `yaml:"pluginTimeout,omitempty"`
 
+	// RandomSeed - when set, the random functions are seeded with this value,
+	// so that they produce the same values on every run
+	RandomSeed string `yaml:"randomSeed,omitempty"`
+
+	// AllowWrite - when true, templates are allowed to write files with
+	// file.Write
+	AllowWrite bool `yaml:"allowWrite,omitempty"`
+
+	// MaxTemplateDepth - the maximum depth of nested template executions
+	// (with tmpl.Exec and similar functions). Defaults to 100.
+	MaxTemplateDepth int `yaml:"maxTemplateDepth,omitempty"`
+
+	// Policies - paths to Rego policy files, whose deny rules are evaluated
+	// against the template context before rendering
+	Policies []string `yaml:"policies,omitempty,flow"`
+
+	// Sprig - when true, Sprig-compatible functions are added, so templates
+	// written for Sprig (or Helm) can be rendered
+	Sprig bool `yaml:"sprig,omitempty"`
+
+	// FuncAliases - additional names for namespaces and functions, mapped to
+	// the existing names (like "strings" or "strings.ToUpper")
+	FuncAliases map[string]string `yaml:"funcAliases,omitempty"`
+	// AllowFuncs - when set, only these namespaces and top-level functions
+	// are available to templates
+	AllowFuncs []string `yaml:"allowFuncs,omitempty,flow"`
+	// DenyFuncs - namespaces and top-level functions to make unavailable to
+	// templates, such as "env" or "file". Denying a namespace also denies the
+	// top-level functions that call into it.
+	DenyFuncs []string `yaml:"denyFuncs,omitempty,flow"`
+
+	// DryRun - when true, output files are rendered but not written. The
+	// names of any files which would change are written to Stdout, and Run
+	// returns an error wrapping ErrOutputChanged.
+	DryRun bool `yaml:"dryRun,omitempty"`
+	// Diff - like DryRun, but a unified diff of the changes is written to
+	// Stdout instead of only the names of the files
+	Diff bool `yaml:"diff,omitempty"`
+
+	// Atomic - when true, output files are written to a temporary file, which
+	// is renamed into place once it's complete
+	Atomic bool `yaml:"atomic,omitempty"`
+	// Fsync - when true, output files are synced to disk before they're
+	// closed (or renamed into place)
+	Fsync bool `yaml:"fsync,omitempty"`
+
 	ExecPipe     bool `yaml:"execPipe,omitempty"`
 	Experimental bool `yaml:"experimental,omitempty"`
 }
@@ -99,12 +154,35 @@ type rawConfig struct {
 	LDelim string `yaml:"leftDelim,omitempty"`
 	RDelim string `yaml:"rightDelim,omitempty"`
 
+	FileDelims []FileDelims `yaml:"fileDelims,omitempty"`
+
 	MissingKey string `yaml:"missingKey,omitempty"`
+	Strict     bool   `yaml:"strict,omitempty"`
 
 	PostExec []string `yaml:"postExec,omitempty,flow"`
 
 	PluginTimeout time.Duration `yaml:"pluginTimeout,omitempty"`
 
+	RandomSeed string `yaml:"randomSeed,omitempty"`
+
+	AllowWrite bool `yaml:"allowWrite,omitempty"`
+
+	MaxTemplateDepth int `yaml:"maxTemplateDepth,omitempty"`
+
+	Policies []string `yaml:"policies,omitempty,flow"`
+
+	Sprig bool `yaml:"sprig,omitempty"`
+
+	FuncAliases map[string]string `yaml:"funcAliases,omitempty"`
+	AllowFuncs  []string          `yaml:"allowFuncs,omitempty,flow"`
+	DenyFuncs   []string          `yaml:"denyFuncs,omitempty,flow"`
+
+	DryRun bool `yaml:"dryRun,omitempty"`
+	Diff   bool `yaml:"diff,omitempty"`
+
+	Atomic bool `yaml:"atomic,omitempty"`
+	Fsync  bool `yaml:"fsync,omitempty"`
+
 	ExecPipe     bool `yaml:"execPipe,omitempty"`
 	Experimental bool `yaml:"experimental,omitempty"`
 }
@@ -135,9 +213,23 @@ func (c *Config) UnmarshalYAML(value *yaml.Node) error {
 		OutMode:               r.OutMode,
 		LDelim:                r.LDelim,
 		RDelim:                r.RDelim,
+		FileDelims:            r.FileDelims,
 		MissingKey:            r.MissingKey,
+		Strict:                r.Strict,
 		PostExec:              r.PostExec,
 		PluginTimeout:         r.PluginTimeout,
+		RandomSeed:            r.RandomSeed,
+		AllowWrite:            r.AllowWrite,
+		MaxTemplateDepth:      r.MaxTemplateDepth,
+		Policies:              r.Policies,
+		Sprig:                 r.Sprig,
+		FuncAliases:           r.FuncAliases,
+		AllowFuncs:            r.AllowFuncs,
+		DenyFuncs:             r.DenyFuncs,
+		DryRun:                r.DryRun,
+		Diff:                  r.Diff,
+		Atomic:                r.Atomic,
+		Fsync:                 r.Fsync,
 		ExecPipe:              r.ExecPipe,
 		Experimental:          r.Experimental,
 	}
@@ -165,9 +257,23 @@ func (c Config) MarshalYAML() (interface{}, error) {
 		OutMode:               c.OutMode,
 		LDelim:                c.LDelim,
 		RDelim:                c.RDelim,
+		FileDelims:            c.FileDelims,
 		MissingKey:            c.MissingKey,
+		Strict:                c.Strict,
 		PostExec:              c.PostExec,
 		PluginTimeout:         c.PluginTimeout,
+		RandomSeed:            c.RandomSeed,
+		AllowWrite:            c.AllowWrite,
+		MaxTemplateDepth:      c.MaxTemplateDepth,
+		Policies:              c.Policies,
+		Sprig:                 c.Sprig,
+		FuncAliases:           c.FuncAliases,
+		AllowFuncs:            c.AllowFuncs,
+		DenyFuncs:             c.DenyFuncs,
+		DryRun:                c.DryRun,
+		Diff:                  c.Diff,
+		Atomic:                c.Atomic,
+		Fsync:                 c.Fsync,
 		ExecPipe:              c.ExecPipe,
 		Experimental:          c.Experimental,
 	}
@@ -206,6 +312,14 @@ func mergeDataSources(left, right DataSource) DataSource {
 // DataSource - datasource configuration
 type DataSource = config.DataSource
 
+// FileDelims - the delimiters to use for input files matching any of the
+// Files glob patterns
+type FileDelims struct {
+	LDelim string   `yaml:"leftDelim"`
+	RDelim string   `yaml:"rightDelim"`
+	Files  []string `yaml:"files,flow"`
+}
+
 type PluginConfig struct {
 	Cmd     string
 	Args    []string      `yaml:"args,omitempty"`
@@ -309,6 +423,53 @@ func (c *Config) MergeFrom(o *Config) *Config {
 	if !isZero(o.RDelim) {
 		c.RDelim = o.RDelim
 	}
+	if !isZero(o.RandomSeed) {
+		c.RandomSeed = o.RandomSeed
+	}
+	if !isZero(o.AllowWrite) {
+		c.AllowWrite = o.AllowWrite
+	}
+	if !isZero(o.MaxTemplateDepth) {
+		c.MaxTemplateDepth = o.MaxTemplateDepth
+	}
+	if !isZero(o.Policies) {
+		c.Policies = o.Policies
+	}
+	if !isZero(o.Sprig) {
+		c.Sprig = o.Sprig
+	}
+	if !isZero(o.AllowFuncs) {
+		c.AllowFuncs = o.AllowFuncs
+	}
+	if !isZero(o.DenyFuncs) {
+		c.DenyFuncs = o.DenyFuncs
+	}
+	if !isZero(o.DryRun) {
+		c.DryRun = o.DryRun
+	}
+	if !isZero(o.Diff) {
+		c.Diff = o.Diff
+	}
+	if !isZero(o.Atomic) {
+		c.Atomic = o.Atomic
+	}
+	if !isZero(o.Fsync) {
+		c.Fsync = o.Fsync
+	}
+	if !isZero(o.Strict) {
+		c.Strict = o.Strict
+	}
+	if len(o.FileDelims) > 0 {
+		c.FileDelims = o.FileDelims
+	}
+	if len(o.FuncAliases) > 0 {
+		if c.FuncAliases == nil {
+			c.FuncAliases = map[string]string{}
+		}
+		for k, v := range o.FuncAliases {
+			c.FuncAliases[k] = v
+		}
+	}
 	if c.Templates == nil {
 		c.Templates = o.Templates
 	} else {
@@ -383,6 +544,12 @@ func (c Config) validate() (err error) {
 		}
 	}
 
+	if err == nil {
+		if c.ExecPipe && (c.DryRun || c.Diff) {
+			err = fmt.Errorf("execPipe can't be used with dryRun or diff")
+		}
+	}
+
 	if err == nil {
 		missingKeyValues := []string{"", "error", "zero", "default", "invalid"}
 		if !slices.Contains(missingKeyValues, c.MissingKey) {
@@ -390,6 +557,16 @@ func (c Config) validate() (err error) {
 		}
 	}
 
+	if err == nil {
+		err = validateFileDelims(c.FileDelims)
+	}
+
+	if err == nil {
+		if c.Strict && c.MissingKey != "" && c.MissingKey != "error" {
+			err = fmt.Errorf("missingKey must be 'error' (or unset) when using strict mode, not %q", c.MissingKey)
+		}
+	}
+
 	return err
 }
 
@@ -67,6 +67,14 @@ templates:
     url: file:///tmp/foo.t
 
 pluginTimeout: 2s
+randomSeed: build-42
+allowWrite: true
+maxTemplateDepth: 20
+policies: [policy/deploy.rego]
+sprig: true
+funcAliases:
+  up: strings.ToUpper
+denyFuncs: [env, file]
 `
 	expected = &Config{
 		Input:       "hello world",
@@ -93,6 +101,14 @@ pluginTimeout: 2s
 		},
 		Templates:     map[string]DataSource{"foo": {URL: mustURL("file:///tmp/foo.t")}},
 		PluginTimeout: 2 * time.Second,
+		RandomSeed:    "build-42",
+		AllowWrite:    true,
+
+		MaxTemplateDepth: 20,
+		Policies:         []string{"policy/deploy.rego"},
+		Sprig:            true,
+		FuncAliases:      map[string]string{"up": "strings.ToUpper"},
+		DenyFuncs:        []string{"env", "file"},
 	}
 
 	cf, err = Parse(strings.NewReader(in))
@@ -184,6 +200,30 @@ postExec: [echo]
 execPipe: true
 outputMap: foo
 postExec: [echo]
+`))
+
+	require.Error(t, validateConfig(`execPipe: true
+diff: true
+postExec: [echo]
+`))
+
+	require.NoError(t, validateConfig(`strict: true
+missingKey: error
+`))
+
+	require.Error(t, validateConfig(`strict: true
+missingKey: zero
+`))
+
+	require.NoError(t, validateConfig(`fileDelims:
+  - files: ['*.hbs', 'helm/**']
+    leftDelim: '[['
+    rightDelim: ']]'
+`))
+
+	require.Error(t, validateConfig(`fileDelims:
+  - files: ['*.hbs']
+    leftDelim: '[['
 `))
 }
 
@@ -442,6 +482,24 @@ func TestMergeFrom(t *testing.T) {
 	}
 
 	assert.EqualValues(t, expected, cfg.MergeFrom(other))
+
+	// function aliases are merged, and allow/deny lists are replaced
+	cfg = &Config{
+		FuncAliases: map[string]string{"up": "strings.ToUpper", "s": "strings"},
+		DenyFuncs:   []string{"env"},
+	}
+	other = &Config{
+		FuncAliases: map[string]string{"s": "strings.Split"},
+		DenyFuncs:   []string{"file"},
+		AllowFuncs:  []string{"strings"},
+	}
+	expected = &Config{
+		FuncAliases: map[string]string{"up": "strings.ToUpper", "s": "strings.Split"},
+		DenyFuncs:   []string{"file"},
+		AllowFuncs:  []string{"strings"},
+	}
+
+	assert.EqualValues(t, expected, cfg.MergeFrom(other))
 }
 
 func TestConfig_String(t *testing.T) {
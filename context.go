@@ -22,6 +22,19 @@ func (c *tmplctx) Env() map[string]string {
 	return env
 }
 
+// noEnvTmplctx - a template context without the Env method, for when the env
+// functions are denied
+type noEnvTmplctx tmplctx
+
+// withoutEnv - returns the template context without .Env, if it has it
+func withoutEnv(c interface{}) interface{} {
+	if tc, ok := c.(*tmplctx); ok {
+		return (*noEnvTmplctx)(tc)
+	}
+
+	return c
+}
+
 // createTmplContext reads the datasources for the given aliases
 func createTmplContext(
 	ctx context.Context, aliases []string,
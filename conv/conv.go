@@ -59,6 +59,45 @@ func ToBool(in interface{}) bool {
 	}
 }
 
+// StrictToBool - like ToBool, but returns an error for values that aren't
+// recognizably true or false, instead of treating them as false. Accepted
+// values are booleans, the numbers 1 and 0, and the strings accepted by
+// ToBool along with their false counterparts ("0", "f", "false", "no").
+func StrictToBool(in interface{}) (bool, error) {
+	if b, ok := in.(bool); ok {
+		return b, nil
+	}
+
+	if str, ok := in.(string); ok {
+		switch strings.ToLower(str) {
+		case "1", "t", "true", "yes":
+			return true, nil
+		case "0", "f", "false", "no":
+			return false, nil
+		}
+
+		f, err := strToFloat64(str)
+		if err != nil || (f != 0 && f != 1) {
+			return false, fmt.Errorf("could not convert %q to bool", str)
+		}
+
+		return f == 1, nil
+	}
+
+	val := reflect.Indirect(reflect.ValueOf(in))
+	switch val.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint,
+		reflect.Float32, reflect.Float64:
+		f, err := ToFloat64(val.Interface())
+		if err == nil && (f == 0 || f == 1) {
+			return f == 1, nil
+		}
+	}
+
+	return false, fmt.Errorf("could not convert %v to bool", in)
+}
+
 // ToBools -
 func ToBools(in ...interface{}) []bool {
 	out := make([]bool, len(in))
@@ -217,6 +256,65 @@ func ToInt64(v interface{}) (int64, error) {
 	}
 }
 
+// StrictToInt64 - like ToInt64, but returns an error instead of truncating
+// values with fractional parts (like 1.5 or "2.7"), and for booleans
+func StrictToInt64(v interface{}) (int64, error) {
+	if str, ok := v.(string); ok {
+		str = strings.ReplaceAll(str, ",", "")
+
+		if iv, err := strconv.ParseInt(str, 0, 64); err == nil {
+			return iv, nil
+		}
+
+		fv, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return 0, fmt.Errorf("could not convert %q to int64: %w", str, err)
+		}
+
+		return floatToInt64(fv, v)
+	}
+
+	val := reflect.Indirect(reflect.ValueOf(v))
+	switch val.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return floatToInt64(val.Float(), v)
+	case reflect.Bool:
+		return 0, fmt.Errorf("could not convert %v to int64: not a number", v)
+	default:
+		return ToInt64(v)
+	}
+}
+
+// floatToInt64 converts f to an int64, returning an error when it has a
+// fractional part or is out of range
+func floatToInt64(f float64, orig interface{}) (int64, error) {
+	if f != math.Trunc(f) {
+		return 0, fmt.Errorf("could not convert %v to int64: not an integer", orig)
+	}
+
+	// float64(math.MaxInt64) rounds up to 2^63, which is out of range
+	if f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, fmt.Errorf("could not convert %v to int64, would overflow", orig)
+	}
+
+	return int64(f), nil
+}
+
+// StrictToInt - like ToInt, but returns an error instead of truncating values
+// with fractional parts, and for booleans
+func StrictToInt(in interface{}) (int, error) {
+	i, err := StrictToInt64(in)
+	if err != nil {
+		return 0, err
+	}
+
+	if i >= math.MinInt && i <= math.MaxInt {
+		return int(i), nil
+	}
+
+	return 0, fmt.Errorf("could not convert %v to int", in)
+}
+
 // ToInt -
 func ToInt(in interface{}) (int, error) {
 	i, err := ToInt64(in)
@@ -502,3 +502,69 @@ func TestDict(t *testing.T) {
 		assert.Equal(t, d.expected, actual)
 	}
 }
+
+func TestStrictToBool(t *testing.T) {
+	for _, d := range []interface{}{
+		true, 1, uint8(1), float32(1), "1", "0x1", "1.0", "true", "T", "yes", "YES",
+	} {
+		out, err := StrictToBool(d)
+		require.NoError(t, err, d)
+		assert.True(t, out, d)
+	}
+
+	for _, d := range []interface{}{
+		false, 0, int64(0), 0.0, "0", "0.0", "false", "F", "no", "NO",
+	} {
+		out, err := StrictToBool(d)
+		require.NoError(t, err, d)
+		assert.False(t, out, d)
+	}
+
+	for _, d := range []interface{}{
+		nil, 42, -1, 0.5, "", "foo", "on", "2", []string{}, struct{}{},
+	} {
+		_, err := StrictToBool(d)
+		assert.Error(t, err, d)
+	}
+}
+
+func TestStrictToInt64(t *testing.T) {
+	testdata := []struct {
+		in       interface{}
+		expected int64
+	}{
+		{1, 1},
+		{int32(-5), -5},
+		{uint8(math.MaxUint8), 255},
+		{float64(42), 42},
+		{float32(-3), -3},
+		{"42", 42},
+		{"42.0", 42},
+		{"0xFF", 255},
+		{"4,096", 4096},
+		{"-1e3", -1000},
+	}
+
+	for _, d := range testdata {
+		out, err := StrictToInt64(d.in)
+		require.NoError(t, err, d.in)
+		assert.Equal(t, d.expected, out, d.in)
+	}
+
+	for _, d := range []interface{}{
+		nil, true, false, 1.5, float32(0.1), "3.5", "", "foo", 1e20, "1e20",
+		uint64(math.MaxUint64), math.NaN(), math.Inf(1),
+	} {
+		_, err := StrictToInt64(d)
+		assert.Error(t, err, d)
+	}
+}
+
+func TestStrictToInt(t *testing.T) {
+	out, err := StrictToInt("12")
+	require.NoError(t, err)
+	assert.Equal(t, 12, out)
+
+	_, err = StrictToInt(2.5)
+	require.Error(t, err)
+}
@@ -0,0 +1,184 @@
+package conv
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// quantityRE matches a number followed by an optional unit
+var quantityRE = regexp.MustCompile(`^\s*([+-]?(?:[0-9]+\.?[0-9]*|\.[0-9]+)(?:[eE][+-]?[0-9]+)?)\s*([a-zA-Zµ]*)\s*$`)
+
+// splitQuantity splits a quantity like "1.5GiB" into its number and unit
+func splitQuantity(s string) (float64, string, error) {
+	m := quantityRE.FindStringSubmatch(s)
+	if m == nil {
+		return 0, "", fmt.Errorf("invalid quantity %q", s)
+	}
+
+	n, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid quantity %q: %w", s, err)
+	}
+
+	return n, m[2], nil
+}
+
+// byteUnits maps lower-case byte units to their sizes. As with Kubernetes
+// quantities, single-letter units are decimal (SI), while units with an "i"
+// are binary (IEC).
+var byteUnits = map[string]float64{
+	"": 1, "b": 1, "byte": 1, "bytes": 1,
+	"k": 1e3, "kb": 1e3, "ki": 1 << 10, "kib": 1 << 10,
+	"m": 1e6, "mb": 1e6, "mi": 1 << 20, "mib": 1 << 20,
+	"g": 1e9, "gb": 1e9, "gi": 1 << 30, "gib": 1 << 30,
+	"t": 1e12, "tb": 1e12, "ti": 1 << 40, "tib": 1 << 40,
+	"p": 1e15, "pb": 1e15, "pi": 1 << 50, "pib": 1 << 50,
+	"e": 1e18, "eb": 1e18, "ei": 1 << 60, "eib": 1 << 60,
+}
+
+// ParseBytes parses a human-friendly byte size such as "1.5GiB", "512 MB",
+// or "2Gi" into a number of bytes. Units are case-insensitive. Decimal (SI)
+// units like "MB" are powers of 1000, and binary (IEC) units like "MiB" are
+// powers of 1024. A number with no unit is a number of bytes.
+func ParseBytes(s string) (int64, error) {
+	n, unit, err := splitQuantity(s)
+	if err != nil {
+		return 0, err
+	}
+
+	mult, ok := byteUnits[strings.ToLower(unit)]
+	if !ok {
+		return 0, fmt.Errorf("invalid byte size %q: unknown unit %q", s, unit)
+	}
+
+	b := math.Round(n * mult)
+	if b < 0 {
+		return 0, fmt.Errorf("invalid byte size %q: must not be negative", s)
+	}
+
+	if b >= math.MaxInt64 {
+		return 0, fmt.Errorf("invalid byte size %q: too large", s)
+	}
+
+	return int64(b), nil
+}
+
+// HumanBytes formats a number of bytes as a human-friendly size, such as
+// "1.5GiB". Binary (IEC) units are used by default, or decimal (SI) units
+// (such as "1.5GB") when si is true. Sizes are rounded to at most two
+// decimal places.
+func HumanBytes(n float64, si bool) string {
+	base := 1024.0
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	if si {
+		base = 1000
+		units = []string{"B", "kB", "MB", "GB", "TB", "PB", "EB"}
+	}
+
+	i := 0
+	for ; math.Abs(n) >= base && i < len(units)-1; i++ {
+		n /= base
+	}
+
+	return formatRounded(n) + units[i]
+}
+
+// siPrefixes maps SI prefixes (and binary prefixes like "Ki") to their
+// multipliers
+var siPrefixes = map[string]float64{
+	"n": 1e-9, "u": 1e-6, "µ": 1e-6, "m": 1e-3, "": 1,
+	"k": 1e3, "K": 1e3, "M": 1e6, "G": 1e9, "T": 1e12, "P": 1e15, "E": 1e18,
+	"Ki": 1 << 10, "Mi": 1 << 20, "Gi": 1 << 30, "Ti": 1 << 40, "Pi": 1 << 50, "Ei": 1 << 60,
+}
+
+// ParseSI parses a number with an optional SI prefix, such as "500m" (0.5),
+// "1.5k" (1500), or "2M" (2000000). Binary prefixes like "Ki" (1024) are
+// also accepted, so Kubernetes-style quantities can be parsed. Prefixes are
+// case-sensitive, since "m" (milli) and "M" (mega) differ.
+func ParseSI(s string) (float64, error) {
+	n, prefix, err := splitQuantity(s)
+	if err != nil {
+		return 0, err
+	}
+
+	mult, ok := siPrefixes[prefix]
+	if !ok {
+		return 0, fmt.Errorf("invalid quantity %q: unknown prefix %q", s, prefix)
+	}
+
+	return n * mult, nil
+}
+
+// HumanSI formats a number with an SI prefix, such as "1.5k" or "500m".
+// Numbers are rounded to at most two decimal places.
+func HumanSI(n float64) string {
+	if n == 0 || math.IsNaN(n) || math.IsInf(n, 0) {
+		return formatRounded(n)
+	}
+
+	prefixes := []string{"n", "µ", "m", "", "k", "M", "G", "T", "P", "E"}
+
+	// index of the empty prefix
+	i := 3
+	for ; math.Abs(n) >= 1000 && i < len(prefixes)-1; i++ {
+		n /= 1000
+	}
+
+	for ; math.Abs(n) < 1 && i > 0; i-- {
+		n *= 1000
+	}
+
+	return formatRounded(n) + prefixes[i]
+}
+
+// formatRounded formats n rounded to at most two decimal places
+func formatRounded(n float64) string {
+	return strconv.FormatFloat(math.Round(n*100)/100, 'f', -1, 64)
+}
+
+// durationDaysRE matches the day ("d") and week ("w") units which aren't
+// supported by time.ParseDuration
+var durationDaysRE = regexp.MustCompile(`([0-9]*\.?[0-9]+)([dw])`)
+
+// ParseDuration parses a duration string, like time.ParseDuration, but also
+// accepts days ("d") and weeks ("w") - e.g. "1w2d" or "1d12h". Days are
+// always 24 hours long, and weeks are 7 days. A number with no unit is
+// a number of seconds.
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return time.Duration(f * float64(time.Second)), nil
+	}
+
+	var convErr error
+	expanded := durationDaysRE.ReplaceAllStringFunc(s, func(m string) string {
+		n, err := strconv.ParseFloat(m[:len(m)-1], 64)
+		if err != nil {
+			convErr = err
+			return m
+		}
+
+		hours := 24.0
+		if m[len(m)-1] == 'w' {
+			hours *= 7
+		}
+
+		return strconv.FormatFloat(n*hours, 'f', -1, 64) + "h"
+	})
+
+	if convErr != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", s, convErr)
+	}
+
+	d, err := time.ParseDuration(expanded)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+
+	return d, nil
+}
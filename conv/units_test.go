@@ -0,0 +1,149 @@
+package conv
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBytes(t *testing.T) {
+	testdata := []struct {
+		in  string
+		out int64
+	}{
+		{"0", 0},
+		{"512", 512},
+		{"512B", 512},
+		{"1 byte", 1},
+		{"1.5GiB", 1610612736},
+		{"1.5gib", 1610612736},
+		{"2Gi", 2147483648},
+		{"10 MB", 10000000},
+		{"10M", 10000000},
+		{"1kB", 1000},
+		{"1KiB", 1024},
+		{" 1.5 TB ", 1500000000000},
+		{".5Ki", 512},
+		{"1e3", 1000},
+	}
+
+	for _, d := range testdata {
+		actual, err := ParseBytes(d.in)
+		require.NoError(t, err, d.in)
+		assert.Equal(t, d.out, actual, d.in)
+	}
+
+	for _, in := range []string{"", "foo", "1.5 GiBs", "1XB", "-1GB", "8EiB", "GiB", "1.2.3MB"} {
+		_, err := ParseBytes(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestHumanBytes(t *testing.T) {
+	testdata := []struct {
+		out string
+		in  float64
+		si  bool
+	}{
+		{"0B", 0, false},
+		{"512B", 512, false},
+		{"1KiB", 1024, false},
+		{"1000B", 1000, false},
+		{"1kB", 1000, true},
+		{"1.5GiB", 1610612736, false},
+		{"1.61GB", 1610612736, true},
+		{"1.33MiB", 1398101, false},
+		{"-2KiB", -2048, false},
+		{"8EiB", 8 * (1 << 60), false},
+		{"8000EB", 8e21, true},
+	}
+
+	for _, d := range testdata {
+		assert.Equal(t, d.out, HumanBytes(d.in, d.si), d.in)
+	}
+}
+
+func TestParseSI(t *testing.T) {
+	testdata := []struct {
+		in  string
+		out float64
+	}{
+		{"0", 0},
+		{"42", 42},
+		{"500m", 0.5},
+		{"1.5k", 1500},
+		{"1.5K", 1500},
+		{"2M", 2e6},
+		{"250u", 250e-6},
+		{"250µ", 250e-6},
+		{"10n", 10e-9},
+		{"1Gi", 1 << 30},
+		{"-3G", -3e9},
+	}
+
+	for _, d := range testdata {
+		actual, err := ParseSI(d.in)
+		require.NoError(t, err, d.in)
+		assert.InDelta(t, d.out, actual, math.Abs(d.out)*1e-12, d.in)
+	}
+
+	for _, in := range []string{"", "foo", "1x", "1mi", "1kB"} {
+		_, err := ParseSI(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestHumanSI(t *testing.T) {
+	testdata := []struct {
+		out string
+		in  float64
+	}{
+		{"0", 0},
+		{"42", 42},
+		{"999", 999},
+		{"1k", 1000},
+		{"1.5k", 1500},
+		{"2.35M", 2345678},
+		{"500m", 0.5},
+		{"250µ", 0.00025},
+		{"-1.5G", -1.5e9},
+		{"1.2n", 1.2e-9},
+		{"1000E", 1e21},
+	}
+
+	for _, d := range testdata {
+		assert.Equal(t, d.out, HumanSI(d.in), d.in)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	testdata := []struct {
+		in  string
+		out time.Duration
+	}{
+		{"0", 0},
+		{"90", 90 * time.Second},
+		{"1.5", 1500 * time.Millisecond},
+		{"1h30m", 90 * time.Minute},
+		{"1d", 24 * time.Hour},
+		{"1.5d", 36 * time.Hour},
+		{"1w2d3h", (7*24 + 2*24 + 3) * time.Hour},
+		{"-1d", -24 * time.Hour},
+		{" 2w ", 14 * 24 * time.Hour},
+		{"500ms", 500 * time.Millisecond},
+	}
+
+	for _, d := range testdata {
+		actual, err := ParseDuration(d.in)
+		require.NoError(t, err, d.in)
+		assert.Equal(t, d.out, actual, d.in)
+	}
+
+	for _, in := range []string{"", "foo", "1x", "d", "1y"} {
+		_, err := ParseDuration(in)
+		assert.Error(t, err, in)
+	}
+}
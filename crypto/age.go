@@ -0,0 +1,76 @@
+package crypto
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+)
+
+// AgeEncrypt - encrypt the input with age (https://age-encryption.org) for
+// the given recipients, which are parsed in the same format as age's
+// recipients files - one X25519 recipient ("age1...") per line, with blank
+// lines and "#" comments ignored. The output is ASCII-armored (PEM-like).
+func AgeEncrypt(recipients string, in []byte) ([]byte, error) {
+	rcpts, err := age.ParseRecipients(strings.NewReader(recipients))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse age recipients: %w", err)
+	}
+
+	out := &bytes.Buffer{}
+	aw := armor.NewWriter(out)
+
+	w, err := age.Encrypt(aw, rcpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt: %w", err)
+	}
+
+	_, err = w.Write(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt: %w", err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt: %w", err)
+	}
+
+	err = aw.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt: armoring failed: %w", err)
+	}
+
+	return out.Bytes(), nil
+}
+
+// AgeDecrypt - decrypt age-encrypted input (either ASCII-armored or binary)
+// with the given identities, which are parsed in the same format as age's
+// identity files - one X25519 identity ("AGE-SECRET-KEY-1...") per line, with
+// blank lines and "#" comments ignored.
+func AgeDecrypt(identities string, in []byte) ([]byte, error) {
+	ids, err := age.ParseIdentities(strings.NewReader(identities))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse age identities: %w", err)
+	}
+
+	var r io.Reader = bytes.NewReader(in)
+
+	if trimmed := bytes.TrimSpace(in); bytes.HasPrefix(trimmed, []byte(armor.Header)) {
+		r = armor.NewReader(bytes.NewReader(trimmed))
+	}
+
+	dr, err := age.Decrypt(r, ids...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+
+	out, err := io.ReadAll(dr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,57 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgeEncryptDecrypt(t *testing.T) {
+	id1, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	id2, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	recipients := "# first\n" + id1.Recipient().String() + "\n\n" + id2.Recipient().String() + "\n"
+
+	enc, err := AgeEncrypt(recipients, []byte("hello world"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(enc), armor.Header))
+
+	// either identity can decrypt
+	out, err := AgeDecrypt(id1.String(), enc)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(out))
+
+	out, err = AgeDecrypt("# created: today\n"+id2.String(), enc)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(out))
+
+	// surrounding whitespace is ignored
+	out, err = AgeDecrypt(id1.String(), []byte("\n  "+string(enc)+"\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(out))
+
+	other, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	_, err = AgeDecrypt(other.String(), enc)
+	require.Error(t, err)
+
+	_, err = AgeDecrypt("bogus", enc)
+	require.Error(t, err)
+
+	_, err = AgeDecrypt(id1.String(), []byte("not encrypted"))
+	require.Error(t, err)
+
+	_, err = AgeEncrypt("bogus", []byte("hello"))
+	require.Error(t, err)
+
+	_, err = AgeEncrypt("", []byte("hello"))
+	require.Error(t, err)
+}
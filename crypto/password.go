@@ -0,0 +1,234 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Argon2idOptions are the parameters for [Argon2id]
+type Argon2idOptions struct {
+	// Memory is the amount of memory used, in KiB
+	Memory uint32
+	// Iterations is the number of passes over the memory
+	Iterations uint32
+	// Parallelism is the number of threads used
+	Parallelism uint8
+	// KeyLength is the length of the hash, in bytes
+	KeyLength uint32
+}
+
+// DefaultArgon2idOptions are the parameters recommended by the Argon2 RFC
+// (RFC 9106) for memory-constrained environments
+//
+//nolint:gochecknoglobals
+var DefaultArgon2idOptions = Argon2idOptions{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 4,
+	KeyLength:   32,
+}
+
+// ScryptOptions are the parameters for [Scrypt]
+type ScryptOptions struct {
+	// Cost is the base-2 logarithm of the CPU/memory cost parameter (N)
+	Cost int
+	// BlockSize is the block size parameter (r)
+	BlockSize int
+	// Parallelism is the parallelization parameter (p)
+	Parallelism int
+	// KeyLength is the length of the hash, in bytes
+	KeyLength int
+}
+
+// DefaultScryptOptions are the parameters recommended for interactive logins
+//
+//nolint:gochecknoglobals
+var DefaultScryptOptions = ScryptOptions{
+	Cost:        15,
+	BlockSize:   8,
+	Parallelism: 1,
+	KeyLength:   32,
+}
+
+// passwordSaltLength is the length of the random salts, in bytes
+const passwordSaltLength = 16
+
+// Argon2id - hash the password with Argon2id and a random salt, returning the
+// hash in the PHC string format, as used by the reference implementation
+// ("$argon2id$v=19$m=65536,t=3,p=4$<salt>$<hash>").
+func Argon2id(password []byte, opts Argon2idOptions) (string, error) {
+	if err := opts.validate(); err != nil {
+		return "", err
+	}
+
+	salt, err := randomSalt()
+	if err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey(password, salt, opts.Iterations, opts.Memory, opts.Parallelism, opts.KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version,
+		opts.Memory, opts.Iterations, opts.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Argon2idVerify - check whether the password matches the Argon2id hash (in
+// PHC string format, as returned by [Argon2id])
+func Argon2idVerify(password []byte, hash string) (bool, error) {
+	var version int
+	var opts Argon2idOptions
+
+	params, salt, key, err := parsePHC(hash, "argon2id")
+	if err != nil {
+		return false, err
+	}
+
+	_, err = fmt.Sscanf(params, "v=%d$m=%d,t=%d,p=%d", &version, &opts.Memory, &opts.Iterations, &opts.Parallelism)
+	if err != nil {
+		return false, fmt.Errorf("invalid argon2id hash: malformed parameters %q", params)
+	}
+
+	if version != argon2.Version {
+		return false, fmt.Errorf("unsupported argon2id version %d", version)
+	}
+
+	//nolint:gosec // the key length is bounded by the hash's length
+	opts.KeyLength = uint32(len(key))
+
+	if err := opts.validate(); err != nil {
+		return false, err
+	}
+
+	actual := argon2.IDKey(password, salt, opts.Iterations, opts.Memory, opts.Parallelism, opts.KeyLength)
+
+	return subtle.ConstantTimeCompare(actual, key) == 1, nil
+}
+
+func (o Argon2idOptions) validate() error {
+	if o.Memory == 0 || o.Iterations == 0 || o.Parallelism == 0 || o.KeyLength == 0 {
+		return fmt.Errorf("invalid argon2id parameters: memory, iterations, parallelism, and key length must be positive")
+	}
+
+	return nil
+}
+
+// Scrypt - hash the password with scrypt and a random salt, returning the hash
+// in a PHC-like string format ("$scrypt$ln=15,r=8,p=1$<salt>$<hash>").
+func Scrypt(password []byte, opts ScryptOptions) (string, error) {
+	salt, err := randomSalt()
+	if err != nil {
+		return "", err
+	}
+
+	key, err := scryptKey(password, salt, opts)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("$scrypt$ln=%d,r=%d,p=%d$%s$%s",
+		opts.Cost, opts.BlockSize, opts.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// ScryptVerify - check whether the password matches the scrypt hash (as
+// returned by [Scrypt])
+func ScryptVerify(password []byte, hash string) (bool, error) {
+	opts := ScryptOptions{}
+
+	params, salt, key, err := parsePHC(hash, "scrypt")
+	if err != nil {
+		return false, err
+	}
+
+	_, err = fmt.Sscanf(params, "ln=%d,r=%d,p=%d", &opts.Cost, &opts.BlockSize, &opts.Parallelism)
+	if err != nil {
+		return false, fmt.Errorf("invalid scrypt hash: malformed parameters %q", params)
+	}
+
+	opts.KeyLength = len(key)
+
+	actual, err := scryptKey(password, salt, opts)
+	if err != nil {
+		return false, err
+	}
+
+	return subtle.ConstantTimeCompare(actual, key) == 1, nil
+}
+
+// BcryptVerify - check whether the password matches the bcrypt hash
+func BcryptVerify(password []byte, hash string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), password)
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("invalid bcrypt hash: %w", err)
+	}
+
+	return true, nil
+}
+
+func scryptKey(password, salt []byte, opts ScryptOptions) ([]byte, error) {
+	if opts.Cost < 1 || opts.Cost > 30 {
+		return nil, fmt.Errorf("invalid scrypt cost %d: must be between 1 and 30", opts.Cost)
+	}
+
+	if opts.BlockSize < 1 || opts.Parallelism < 1 || opts.KeyLength < 1 {
+		return nil, fmt.Errorf("invalid scrypt parameters: block size, parallelism, and key length must be positive")
+	}
+
+	key, err := scrypt.Key(password, salt, 1<<opts.Cost, opts.BlockSize, opts.Parallelism, opts.KeyLength)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scrypt parameters: %w", err)
+	}
+
+	return key, nil
+}
+
+// parsePHC splits a PHC-format hash ("$<id>$<params>$<salt>$<hash>") into its
+// parameters, salt, and hash. The parameters may include a version prefix
+// ("v=19$..."), which is returned as part of the parameters.
+func parsePHC(hash, id string) (params string, salt, key []byte, err error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) < 5 || parts[0] != "" || parts[1] != id {
+		return "", nil, nil, fmt.Errorf("invalid %s hash: must be in the format $%s$<params>$<salt>$<hash>", id, id)
+	}
+
+	n := len(parts)
+	params = strings.Join(parts[2:n-2], "$")
+
+	salt, err = base64.RawStdEncoding.DecodeString(parts[n-2])
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("invalid %s hash: malformed salt: %w", id, err)
+	}
+
+	key, err = base64.RawStdEncoding.DecodeString(parts[n-1])
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("invalid %s hash: malformed hash: %w", id, err)
+	}
+
+	return params, salt, key, nil
+}
+
+func randomSalt() ([]byte, error) {
+	salt := make([]byte, passwordSaltLength)
+
+	_, err := rand.Read(salt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	return salt, nil
+}
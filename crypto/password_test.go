@@ -0,0 +1,96 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestArgon2id(t *testing.T) {
+	opts := Argon2idOptions{Memory: 1024, Iterations: 1, Parallelism: 1, KeyLength: 16}
+
+	hash, err := Argon2id([]byte("hunter2"), opts)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"), hash)
+
+	// salts are random
+	hash2, err := Argon2id([]byte("hunter2"), opts)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, hash2)
+
+	ok, err := Argon2idVerify([]byte("hunter2"), hash)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = Argon2idVerify([]byte("hunter3"), hash)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// a hash from the reference implementation
+	ok, err = Argon2idVerify([]byte("password"),
+		"$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = Argon2id([]byte("hunter2"), Argon2idOptions{})
+	require.Error(t, err)
+
+	for _, bad := range []string{
+		"",
+		"$2a$10$abc",
+		"$argon2id$v=19$m=1024,t=1,p=1$!!!$abc",
+		"$argon2id$v=18$m=1024,t=1,p=1$c29tZXNhbHQ$c29tZXNhbHQ",
+		"$argon2id$v=19$m=1024,t=1,p=0$c29tZXNhbHQ$c29tZXNhbHQ",
+		"$argon2id$v=19$bogus$c29tZXNhbHQ$c29tZXNhbHQ",
+	} {
+		_, err = Argon2idVerify([]byte("hunter2"), bad)
+		require.Error(t, err, bad)
+	}
+}
+
+func TestScrypt(t *testing.T) {
+	opts := ScryptOptions{Cost: 10, BlockSize: 8, Parallelism: 1, KeyLength: 32}
+
+	hash, err := Scrypt([]byte("hunter2"), opts)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$scrypt$ln=10,r=8,p=1$"), hash)
+
+	ok, err := ScryptVerify([]byte("hunter2"), hash)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = ScryptVerify([]byte("hunter3"), hash)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = Scrypt([]byte("hunter2"), ScryptOptions{Cost: 31, BlockSize: 8, Parallelism: 1, KeyLength: 32})
+	require.Error(t, err)
+
+	_, err = Scrypt([]byte("hunter2"), ScryptOptions{Cost: 10})
+	require.Error(t, err)
+
+	_, err = ScryptVerify([]byte("hunter2"), "$scrypt$ln=10$c29tZXNhbHQ$c29tZXNhbHQ")
+	require.Error(t, err)
+
+	_, err = ScryptVerify([]byte("hunter2"), strings.Replace(hash, "$scrypt$", "$argon2id$", 1))
+	require.Error(t, err)
+}
+
+func TestBcryptVerify(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("hunter2"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	ok, err := BcryptVerify([]byte("hunter2"), string(hash))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = BcryptVerify([]byte("hunter3"), string(hash))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = BcryptVerify([]byte("hunter2"), "bogus")
+	require.Error(t, err)
+}
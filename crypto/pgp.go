@@ -0,0 +1,229 @@
+package crypto
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/clearsign"
+	pgperrors "github.com/ProtonMail/go-crypto/openpgp/errors"
+)
+
+// PGPEncrypt - encrypt the input for the given OpenPGP public keys (one or
+// more, ASCII-armored or binary). The output is an ASCII-armored OpenPGP
+// message.
+func PGPEncrypt(publicKeys, in []byte) ([]byte, error) {
+	keyring, err := readPGPKeyRing(publicKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &bytes.Buffer{}
+
+	aw, err := armor.Encode(out, "PGP MESSAGE", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt: armoring failed: %w", err)
+	}
+
+	w, err := openpgp.Encrypt(aw, keyring, nil, &openpgp.FileHints{IsBinary: true}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt: %w", err)
+	}
+
+	_, err = w.Write(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt: %w", err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt: %w", err)
+	}
+
+	err = aw.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt: armoring failed: %w", err)
+	}
+
+	// the armor encoder omits the final newline
+	out.WriteByte('\n')
+
+	return out.Bytes(), nil
+}
+
+// PGPDecrypt - decrypt an OpenPGP message (ASCII-armored or binary) with the
+// given private keys (ASCII-armored or binary). When the keys are protected,
+// the passphrase is used to decrypt them. If the message is signed by one of
+// the given keys, the signature must be valid.
+func PGPDecrypt(privateKeys, passphrase, in []byte) ([]byte, error) {
+	keyring, err := readPGPKeyRing(privateKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(passphrase) > 0 {
+		for _, e := range keyring {
+			err = e.DecryptPrivateKeys(passphrase)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decrypt private key: %w", err)
+			}
+		}
+	}
+
+	r, err := pgpDearmor(in, "PGP MESSAGE")
+	if err != nil {
+		return nil, err
+	}
+
+	prompt := func([]openpgp.Key, bool) ([]byte, error) {
+		return nil, fmt.Errorf("private key is encrypted, and no passphrase was given")
+	}
+
+	md, err := openpgp.ReadMessage(r, keyring, prompt, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+
+	out, err := io.ReadAll(md.UnverifiedBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+
+	// the signature can only be checked when signed by one of our keys
+	if md.IsSigned && md.SignedBy != nil && md.SignatureError != nil {
+		return nil, fmt.Errorf("failed to decrypt: invalid signature: %w", md.SignatureError)
+	}
+
+	return out, nil
+}
+
+// PGPVerify - verify that the message was signed by one of the given OpenPGP
+// public keys (ASCII-armored or binary). The signature is a detached
+// signature (ASCII-armored or binary), or if it's nil, the message must be
+// a cleartext-signed message.
+//
+// Returns false if the signature is not valid, was not made by one of the
+// keys, or was made by a revoked or expired key. An error is returned when
+// the inputs can't be read.
+func PGPVerify(publicKeys, signature, message []byte) (bool, error) {
+	keyring, err := readPGPKeyRing(publicKeys)
+	if err != nil {
+		return false, err
+	}
+
+	if signature == nil {
+		block, _ := clearsign.Decode(message)
+		if block == nil {
+			return false, fmt.Errorf("failed to read message: no cleartext-signed message found")
+		}
+
+		_, err = block.VerifySignature(keyring, nil)
+	} else {
+		var sig io.Reader
+
+		sig, err = pgpDearmor(signature, "PGP SIGNATURE")
+		if err != nil {
+			return false, err
+		}
+
+		_, err = openpgp.CheckDetachedSignature(keyring, bytes.NewReader(message), sig, nil)
+	}
+
+	var sigErr pgperrors.SignatureError
+
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.As(err, &sigErr),
+		errors.Is(err, pgperrors.ErrUnknownIssuer),
+		errors.Is(err, pgperrors.ErrSignatureExpired),
+		errors.Is(err, pgperrors.ErrKeyExpired),
+		errors.Is(err, pgperrors.ErrKeyRevoked):
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to verify signature: %w", err)
+	}
+}
+
+// readPGPKeyRing reads an OpenPGP key ring - either binary, or one or more
+// concatenated ASCII-armored blocks
+func readPGPKeyRing(keys []byte) (openpgp.EntityList, error) {
+	if !isArmored(keys) {
+		keyring, err := openpgp.ReadKeyRing(bytes.NewReader(keys))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read OpenPGP keys: %w", err)
+		}
+
+		return keyring, nil
+	}
+
+	var keyring openpgp.EntityList
+
+	for _, block := range splitArmoredBlocks(keys) {
+		el, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(block))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read OpenPGP keys: %w", err)
+		}
+
+		keyring = append(keyring, el...)
+	}
+
+	return keyring, nil
+}
+
+// splitArmoredBlocks splits the input into its ASCII-armored blocks
+func splitArmoredBlocks(in []byte) [][]byte {
+	begin, end := []byte("-----BEGIN "), []byte("-----END ")
+	blocks := [][]byte{}
+
+	for {
+		start := bytes.Index(in, begin)
+		if start < 0 {
+			return blocks
+		}
+
+		in = in[start:]
+
+		stop := bytes.Index(in, end)
+		if stop < 0 {
+			// let the armor decoder report the missing end line
+			return append(blocks, in)
+		}
+
+		// the end line finishes with a dash run, like "-----END X-----"
+		if dashes := bytes.Index(in[stop+len(end):], []byte("-----")); dashes >= 0 {
+			stop += len(end) + dashes + len("-----")
+		} else {
+			stop = len(in)
+		}
+
+		blocks = append(blocks, in[:stop])
+		in = in[stop:]
+	}
+}
+
+// pgpDearmor returns a reader for the body of the input, if it's ASCII-armored
+// with the given block type, or for the input itself, if it's binary
+func pgpDearmor(in []byte, blockType string) (io.Reader, error) {
+	if !isArmored(in) {
+		return bytes.NewReader(in), nil
+	}
+
+	block, err := armor.Decode(bytes.NewReader(in))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", blockType, err)
+	}
+
+	if block.Type != blockType {
+		return nil, fmt.Errorf("failed to read %s: unexpected block type %q", blockType, block.Type)
+	}
+
+	return block.Body, nil
+}
+
+func isArmored(in []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(in), []byte("-----BEGIN "))
+}
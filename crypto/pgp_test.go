@@ -0,0 +1,172 @@
+package crypto
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/clearsign"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func genPGPEntity(t *testing.T) *openpgp.Entity {
+	t.Helper()
+
+	e, err := openpgp.NewEntity("Test", "", "test@example.com", &packet.Config{
+		Algorithm: packet.PubKeyAlgoEdDSA,
+	})
+	require.NoError(t, err)
+
+	return e
+}
+
+func armorPGPKey(t *testing.T, e *openpgp.Entity, private bool) []byte {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+
+	blockType := openpgp.PublicKeyType
+	if private {
+		blockType = openpgp.PrivateKeyType
+	}
+
+	w, err := armor.Encode(buf, blockType, nil)
+	require.NoError(t, err)
+
+	if private {
+		require.NoError(t, e.SerializePrivateWithoutSigning(w, nil))
+	} else {
+		require.NoError(t, e.Serialize(w))
+	}
+
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+func TestPGPEncryptDecrypt(t *testing.T) {
+	e := genPGPEntity(t)
+	pub := armorPGPKey(t, e, false)
+	priv := armorPGPKey(t, e, true)
+
+	enc, err := PGPEncrypt(pub, []byte("hello world"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(enc), "-----BEGIN PGP MESSAGE-----"))
+
+	out, err := PGPDecrypt(priv, nil, enc)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(out))
+
+	// binary messages can be decrypted too
+	block, err := armor.Decode(bytes.NewReader(enc))
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	_, err = buf.ReadFrom(block.Body)
+	require.NoError(t, err)
+
+	out, err = PGPDecrypt(priv, nil, buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(out))
+
+	// a different key can't decrypt
+	_, err = PGPDecrypt(armorPGPKey(t, genPGPEntity(t), true), nil, enc)
+	require.Error(t, err)
+
+	_, err = PGPEncrypt([]byte("bogus"), []byte("hello"))
+	require.Error(t, err)
+
+	_, err = PGPDecrypt(priv, nil, []byte("-----BEGIN PGP SIGNATURE-----\n\n-----END PGP SIGNATURE-----\n"))
+	require.Error(t, err)
+}
+
+func TestPGPDecrypt_Passphrase(t *testing.T) {
+	e := genPGPEntity(t)
+	pub := armorPGPKey(t, e, false)
+
+	require.NoError(t, e.EncryptPrivateKeys([]byte("s3cr3t"), nil))
+	priv := armorPGPKey(t, e, true)
+
+	enc, err := PGPEncrypt(pub, []byte("hello"))
+	require.NoError(t, err)
+
+	_, err = PGPDecrypt(priv, nil, enc)
+	require.ErrorContains(t, err, "passphrase")
+
+	_, err = PGPDecrypt(priv, []byte("wrong"), enc)
+	require.Error(t, err)
+
+	out, err := PGPDecrypt(priv, []byte("s3cr3t"), enc)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(out))
+}
+
+func TestPGPVerify(t *testing.T) {
+	e := genPGPEntity(t)
+	pub := armorPGPKey(t, e, false)
+	other := armorPGPKey(t, genPGPEntity(t), false)
+
+	message := []byte("checksums go here\n")
+
+	sig := &bytes.Buffer{}
+	require.NoError(t, openpgp.ArmoredDetachSign(sig, e, bytes.NewReader(message), nil))
+
+	ok, err := PGPVerify(pub, sig.Bytes(), message)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// binary signatures
+	binSig := &bytes.Buffer{}
+	require.NoError(t, openpgp.DetachSign(binSig, e, bytes.NewReader(message), nil))
+
+	ok, err = PGPVerify(pub, binSig.Bytes(), message)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = PGPVerify(pub, sig.Bytes(), []byte("tampered\n"))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = PGPVerify(other, sig.Bytes(), message)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// keyrings with multiple keys
+	ok, err = PGPVerify(append(append([]byte{}, other...), pub...), sig.Bytes(), message)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = PGPVerify(pub, []byte("-----BEGIN PGP MESSAGE-----\n\n-----END PGP MESSAGE-----\n"), message)
+	require.Error(t, err)
+
+	_, err = PGPVerify([]byte("bogus"), sig.Bytes(), message)
+	require.Error(t, err)
+
+	// cleartext-signed messages
+	clear := &bytes.Buffer{}
+	w, err := clearsign.Encode(clear, e.PrivateKey, nil)
+	require.NoError(t, err)
+	_, err = w.Write(message)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	ok, err = PGPVerify(pub, nil, clear.Bytes())
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = PGPVerify(other, nil, clear.Bytes())
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	tampered := bytes.Replace(clear.Bytes(), []byte("checksums"), []byte("checksumz"), 1)
+	ok, err = PGPVerify(pub, nil, tampered)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = PGPVerify(pub, nil, message)
+	require.Error(t, err)
+}
@@ -0,0 +1,68 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// SSHKeys are a key pair in the formats used by SSH
+type SSHKeys struct {
+	// PrivateKey is the private key in OpenSSH format ("OPENSSH PRIVATE KEY")
+	PrivateKey []byte
+	// PublicKey is the public key in PEM-encoded PKIX form ("PUBLIC KEY")
+	PublicKey []byte
+	// AuthorizedKey is the public key as a line in OpenSSH's authorized_keys
+	// format (also used for ".pub" files), with the comment, if any
+	AuthorizedKey []byte
+	// Fingerprint is the public key's SHA-256 fingerprint, as shown by
+	// ssh-keygen ("SHA256:...")
+	Fingerprint string
+}
+
+// SSHKeyPair - convert the PEM-encoded RSA, ECDSA, or Ed25519 private key to
+// the formats used by SSH. When a passphrase is given, the OpenSSH private key
+// is encrypted with it.
+func SSHKeyPair(privateKey []byte, comment string, passphrase []byte) (*SSHKeys, error) {
+	key, err := ParsePrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var block *pem.Block
+	if len(passphrase) > 0 {
+		block, err = ssh.MarshalPrivateKeyWithPassphrase(key, comment, passphrase)
+	} else {
+		block, err = ssh.MarshalPrivateKey(key, comment)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal OpenSSH private key: %w", err)
+	}
+
+	pub, err := ssh.NewPublicKey(key.Public())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SSH public key: %w", err)
+	}
+
+	pkix, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal PKIX public key: %w", err)
+	}
+
+	// MarshalAuthorizedKey includes a trailing newline, but no comment
+	authorizedKey := ssh.MarshalAuthorizedKey(pub)
+	if comment != "" {
+		authorizedKey = append(bytes.TrimSuffix(authorizedKey, []byte("\n")), []byte(" "+comment+"\n")...)
+	}
+
+	return &SSHKeys{
+		PrivateKey:    pem.EncodeToMemory(block),
+		PublicKey:     pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pkix}),
+		AuthorizedKey: authorizedKey,
+		Fingerprint:   ssh.FingerprintSHA256(pub),
+	}, nil
+}
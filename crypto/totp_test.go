@@ -0,0 +1,78 @@
+package crypto
+
+import (
+	"crypto"
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTOTP(t *testing.T) {
+	// test vectors from RFC 6238, appendix B
+	secrets := map[crypto.Hash]string{
+		crypto.SHA1:   base32.StdEncoding.EncodeToString([]byte("12345678901234567890")),
+		crypto.SHA256: base32.StdEncoding.EncodeToString([]byte("12345678901234567890123456789012")),
+		crypto.SHA512: base32.StdEncoding.EncodeToString([]byte("1234567890123456789012345678901234567890123456789012345678901234")),
+	}
+
+	testdata := []struct {
+		hash     crypto.Hash
+		expected string
+		unix     int64
+	}{
+		{crypto.SHA1, "94287082", 59},
+		{crypto.SHA256, "46119246", 59},
+		{crypto.SHA512, "90693936", 59},
+		{crypto.SHA1, "07081804", 1111111109},
+		{crypto.SHA256, "68084774", 1111111109},
+		{crypto.SHA512, "25091201", 1111111109},
+		{crypto.SHA1, "14050471", 1111111111},
+		{crypto.SHA1, "89005924", 1234567890},
+		{crypto.SHA1, "69279037", 2000000000},
+		{crypto.SHA256, "77737706", 20000000000},
+		{crypto.SHA512, "47863826", 20000000000},
+	}
+
+	for _, d := range testdata {
+		opts := TOTPOptions{
+			Time:   time.Unix(d.unix, 0),
+			Period: 30 * time.Second,
+			Digits: 8,
+			Hash:   d.hash,
+		}
+
+		actual, err := TOTP(secrets[d.hash], opts)
+		require.NoError(t, err)
+		assert.Equal(t, d.expected, actual, "%v at %d", d.hash, d.unix)
+	}
+
+	// secrets are often shown in lower-case groups, without padding
+	opts := DefaultTOTPOptions()
+	opts.Time = time.Unix(59, 0)
+
+	secret := strings.ToLower(strings.TrimRight(secrets[crypto.SHA1], "="))
+	actual, err := TOTP(secret[:4]+" "+secret[4:8]+" "+secret[8:], opts)
+	require.NoError(t, err)
+	assert.Equal(t, "287082", actual)
+
+	_, err = TOTP("not base32!", opts)
+	require.Error(t, err)
+
+	opts.Digits = 0
+	_, err = TOTP(secrets[crypto.SHA1], opts)
+	require.Error(t, err)
+
+	opts = DefaultTOTPOptions()
+	opts.Period = 0
+	_, err = TOTP(secrets[crypto.SHA1], opts)
+	require.Error(t, err)
+
+	opts = DefaultTOTPOptions()
+	opts.Hash = crypto.MD5
+	_, err = TOTP(secrets[crypto.SHA1], opts)
+	require.Error(t, err)
+}
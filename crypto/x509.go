@@ -0,0 +1,369 @@
+package crypto
+
+import (
+	gcrypto "crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// CertOptions control the contents of generated certificates and certificate
+// signing requests
+type CertOptions struct {
+	// CommonName is the subject's common name (CN)
+	CommonName string
+	// Organization is the subject's organization (O)
+	Organization []string
+	// SANs are the subject alternative names. IP addresses, email addresses,
+	// and URIs (names containing "://") are recognized, and all other names
+	// are DNS names.
+	SANs []string
+	// NotBefore and NotAfter are the bounds of the certificate's validity
+	// period. They are ignored for certificate signing requests.
+	NotBefore, NotAfter time.Time
+	// IsCA marks the certificate as a certificate authority, able to sign
+	// other certificates. It is ignored for certificate signing requests.
+	IsCA bool
+}
+
+// ParsePrivateKey parses a PEM-encoded RSA, ECDSA, or Ed25519 private key, in
+// PKCS#1, SEC 1 ("EC PRIVATE KEY"), or PKCS#8 form.
+func ParsePrivateKey(privateKey []byte) (gcrypto.Signer, error) {
+	block, _ := pem.Decode(privateKey)
+	if block == nil {
+		return nil, fmt.Errorf("failed to read key: no key found")
+	}
+
+	var key interface{}
+	var err error
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	switch key := key.(type) {
+	case *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey:
+		return key.(gcrypto.Signer), nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+}
+
+// GenerateSelfSignedCert - create a PEM-encoded X.509 certificate, signed by
+// the given PEM-encoded private key.
+func GenerateSelfSignedCert(privateKey []byte, opts CertOptions) ([]byte, error) {
+	key, err := ParsePrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if !opts.NotAfter.After(opts.NotBefore) {
+		return nil, fmt.Errorf("invalid validity period: %s is not after %s", opts.NotAfter, opts.NotBefore)
+	}
+
+	// serial numbers must be positive, and at most 20 octets (RFC 5280)
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial.Add(serial, big.NewInt(1)),
+		Subject:               certSubject(opts),
+		NotBefore:             opts.NotBefore,
+		NotAfter:              opts.NotAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  opts.IsCA,
+	}
+
+	// RSA keys are used for key encipherment in TLS key exchange
+	if _, ok := key.(*rsa.PrivateKey); ok {
+		tmpl.KeyUsage |= x509.KeyUsageKeyEncipherment
+	}
+
+	if opts.IsCA {
+		tmpl.KeyUsage |= x509.KeyUsageCertSign
+	}
+
+	err = setSANs(opts.SANs, &tmpl.DNSNames, &tmpl.IPAddresses, &tmpl.EmailAddresses, &tmpl.URIs)
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), nil
+}
+
+// GenerateCSR - create a PEM-encoded PKCS#10 certificate signing request,
+// signed by the given PEM-encoded private key.
+func GenerateCSR(privateKey []byte, opts CertOptions) ([]byte, error) {
+	key, err := ParsePrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl := &x509.CertificateRequest{
+		Subject: certSubject(opts),
+	}
+
+	err = setSANs(opts.SANs, &tmpl.DNSNames, &tmpl.IPAddresses, &tmpl.EmailAddresses, &tmpl.URIs)
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, tmpl, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate signing request: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}), nil
+}
+
+// ParseCert - parse the first certificate in the PEM-encoded input (other PEM
+// blocks, such as keys, are skipped), and return its details as a map.
+func ParseCert(in []byte) (map[string]interface{}, error) {
+	certs, err := readCerts(in)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("failed to read certificate: no certificate found")
+	}
+
+	cert := certs[0]
+
+	ips := make([]interface{}, len(cert.IPAddresses))
+	for i, ip := range cert.IPAddresses {
+		ips[i] = ip.String()
+	}
+
+	uris := make([]interface{}, len(cert.URIs))
+	for i, u := range cert.URIs {
+		uris[i] = u.String()
+	}
+
+	fingerprint := sha256.Sum256(cert.Raw)
+
+	return map[string]interface{}{
+		"subject":            cert.Subject.String(),
+		"issuer":             cert.Issuer.String(),
+		"commonName":         cert.Subject.CommonName,
+		"organization":       toInterfaces(cert.Subject.Organization),
+		"serialNumber":       cert.SerialNumber.Text(16),
+		"notBefore":          cert.NotBefore,
+		"notAfter":           cert.NotAfter,
+		"dnsNames":           toInterfaces(cert.DNSNames),
+		"ipAddresses":        ips,
+		"emailAddresses":     toInterfaces(cert.EmailAddresses),
+		"uris":               uris,
+		"isCA":               cert.IsCA,
+		"publicKeyAlgorithm": cert.PublicKeyAlgorithm.String(),
+		"signatureAlgorithm": cert.SignatureAlgorithm.String(),
+		"fingerprint":        hex.EncodeToString(fingerprint[:]),
+	}, nil
+}
+
+// VerifyOptions control how certificate chains are verified by [VerifyChain]
+type VerifyOptions struct {
+	// Roots are the PEM-encoded trusted root certificates. When empty, the
+	// system's roots are used.
+	Roots []byte
+	// Intermediates are PEM-encoded intermediate certificates, in addition to
+	// any following the leaf certificate
+	Intermediates []byte
+	// Hostname is the name to check the leaf certificate against, if any
+	Hostname string
+	// Time is the time to verify the chain at - defaults to the current time
+	Time time.Time
+}
+
+// VerifyChain - verify the PEM-encoded leaf certificate (the first certificate
+// in the input - any following certificates are treated as intermediates)
+// against the trusted roots, and report the results as a map. Verification
+// failures are reported in the map rather than as errors - errors are only
+// returned when the certificates can't be read.
+func VerifyChain(in []byte, opts VerifyOptions) (map[string]interface{}, error) {
+	certs, err := readCerts(in)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("failed to read certificate: no certificate found")
+	}
+
+	leaf := certs[0]
+
+	intermediates := x509.NewCertPool()
+	for _, c := range certs[1:] {
+		intermediates.AddCert(c)
+	}
+
+	extra, err := readCerts(opts.Intermediates)
+	if err != nil {
+		return nil, fmt.Errorf("invalid intermediates: %w", err)
+	}
+
+	for _, c := range extra {
+		intermediates.AddCert(c)
+	}
+
+	var roots *x509.CertPool
+
+	if len(opts.Roots) > 0 {
+		rootCerts, err := readCerts(opts.Roots)
+		if err != nil {
+			return nil, fmt.Errorf("invalid roots: %w", err)
+		}
+
+		if len(rootCerts) == 0 {
+			return nil, fmt.Errorf("invalid roots: no certificate found")
+		}
+
+		roots = x509.NewCertPool()
+		for _, c := range rootCerts {
+			roots.AddCert(c)
+		}
+	}
+
+	now := opts.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	out := map[string]interface{}{
+		"notBefore":   leaf.NotBefore,
+		"notAfter":    leaf.NotAfter,
+		"expired":     now.After(leaf.NotAfter),
+		"notYetValid": now.Before(leaf.NotBefore),
+		"expiresIn":   leaf.NotAfter.Sub(now),
+		"chain":       []interface{}{},
+	}
+
+	var errs []string
+
+	// the hostname is checked separately, so it can be reported separately
+	chains, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		errs = append(errs, err.Error())
+	} else {
+		chain := make([]interface{}, len(chains[0]))
+		for i, c := range chains[0] {
+			chain[i] = c.Subject.String()
+		}
+
+		out["chain"] = chain
+	}
+
+	if opts.Hostname != "" {
+		err = leaf.VerifyHostname(opts.Hostname)
+		out["hostnameMatch"] = err == nil
+
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	out["valid"] = len(errs) == 0
+	out["error"] = strings.Join(errs, "; ")
+
+	return out, nil
+}
+
+// readCerts reads all certificates from the PEM-encoded input, skipping other
+// PEM blocks
+func readCerts(in []byte) ([]*x509.Certificate, error) {
+	certs := []*x509.Certificate{}
+
+	for {
+		var block *pem.Block
+
+		block, in = pem.Decode(in)
+		if block == nil {
+			return certs, nil
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate: %w", err)
+		}
+
+		certs = append(certs, cert)
+	}
+}
+
+func certSubject(opts CertOptions) pkix.Name {
+	return pkix.Name{
+		CommonName:   opts.CommonName,
+		Organization: opts.Organization,
+	}
+}
+
+// setSANs sorts the subject alternative names into the different kinds
+func setSANs(sans []string, dnsNames *[]string, ips *[]net.IP, emails *[]string, uris *[]*url.URL) error {
+	for _, san := range sans {
+		switch {
+		case net.ParseIP(san) != nil:
+			*ips = append(*ips, net.ParseIP(san))
+		case strings.Contains(san, "://"):
+			u, err := url.Parse(san)
+			if err != nil {
+				return fmt.Errorf("invalid URI SAN %q: %w", san, err)
+			}
+
+			*uris = append(*uris, u)
+		case strings.Contains(san, "@"):
+			*emails = append(*emails, san)
+		default:
+			*dnsNames = append(*dnsNames, san)
+		}
+	}
+
+	return nil
+}
+
+func toInterfaces(s []string) []interface{} {
+	out := make([]interface{}, len(s))
+	for i, v := range s {
+		out[i] = v
+	}
+
+	return out
+}
@@ -0,0 +1,99 @@
+package gomplate
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/gobwas/glob"
+)
+
+// delimsDirective matches a first line like "# gomplate:delims=[[ ]]" - any
+// comment syntax can surround the directive, as the whole line is removed
+// from the output
+var delimsDirective = regexp.MustCompile(`gomplate:delims=(\S+)\s+(\S+)`)
+
+// parseDelimsDirective - if the first line of text contains a delimiter
+// directive, return the delimiters and the text with the directive removed.
+// The first line is replaced by a comment spanning the line break, so that
+// line numbers in error messages are unchanged.
+func parseDelimsDirective(text string) (out, lDelim, rDelim string, ok bool) {
+	first, rest, found := strings.Cut(text, "\n")
+
+	m := delimsDirective.FindStringSubmatch(first)
+	if m == nil {
+		return text, "", "", false
+	}
+
+	lDelim, rDelim = m[1], m[2]
+	if !found {
+		return "", lDelim, rDelim, true
+	}
+
+	eol := "\n"
+	if strings.HasSuffix(first, "\r") {
+		eol = "\r\n"
+	}
+
+	return lDelim + "/*" + eol + "*/" + rDelim + rest, lDelim, rDelim, true
+}
+
+// matchFileDelims - find the first FileDelims entry with a pattern matching
+// the named file. Patterns without a slash also match the file's base name,
+// so "*.hbs" matches files in any directory.
+func matchFileDelims(fileDelims []FileDelims, name string) (FileDelims, bool) {
+	name = path.Clean(strings.TrimPrefix(filepath.ToSlash(name), "./"))
+
+	for _, fd := range fileDelims {
+		for _, p := range fd.Files {
+			g, err := glob.Compile(p, '/')
+			if err != nil {
+				// invalid patterns are rejected when the config is validated
+				continue
+			}
+
+			if g.Match(name) || (!strings.Contains(p, "/") && g.Match(path.Base(name))) {
+				return fd, true
+			}
+		}
+	}
+
+	return FileDelims{}, false
+}
+
+// applyDelims sets the template's delimiters from the config's FileDelims (by
+// the input file's name, relative to the input directory, if any), or from a
+// delimiter directive on the template's first line, which takes precedence.
+func applyDelims(cfg *Config, tpl *Template, name string) {
+	if name != "" {
+		if fd, ok := matchFileDelims(cfg.FileDelims, name); ok {
+			tpl.LDelim, tpl.RDelim = fd.LDelim, fd.RDelim
+		}
+	}
+
+	if text, l, r, ok := parseDelimsDirective(tpl.Text); ok {
+		tpl.Text, tpl.LDelim, tpl.RDelim = text, l, r
+	}
+}
+
+func validateFileDelims(fileDelims []FileDelims) error {
+	for i, fd := range fileDelims {
+		if fd.LDelim == "" || fd.RDelim == "" {
+			return fmt.Errorf("fileDelims[%d]: leftDelim and rightDelim must both be set", i)
+		}
+
+		if len(fd.Files) == 0 {
+			return fmt.Errorf("fileDelims[%d]: at least one files pattern must be set", i)
+		}
+
+		for _, p := range fd.Files {
+			if _, err := glob.Compile(p, '/'); err != nil {
+				return fmt.Errorf("fileDelims[%d]: invalid pattern %q: %w", i, p, err)
+			}
+		}
+	}
+
+	return nil
+}
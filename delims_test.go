@@ -0,0 +1,126 @@
+package gomplate
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDelimsDirective(t *testing.T) {
+	testdata := []struct {
+		in, out        string
+		lDelim, rDelim string
+		ok             bool
+	}{
+		{"hello", "hello", "", "", false},
+		{"hello\n# gomplate:delims=[[ ]]\n", "hello\n# gomplate:delims=[[ ]]\n", "", "", false},
+		{"# gomplate:delims=[[ ]]", "", "[[", "]]", true},
+		{"# gomplate:delims=[[ ]]\nhello [[ .x ]]\n", "[[/*\n*/]]hello [[ .x ]]\n", "[[", "]]", true},
+		{"<!-- gomplate:delims=<% %> -->\r\nhi\r\n", "<%/*\r\n*/%>hi\r\n", "<%", "%>", true},
+	}
+
+	for _, d := range testdata {
+		out, l, r, ok := parseDelimsDirective(d.in)
+		assert.Equal(t, d.out, out)
+		assert.Equal(t, d.lDelim, l)
+		assert.Equal(t, d.rDelim, r)
+		assert.Equal(t, d.ok, ok)
+	}
+}
+
+func TestMatchFileDelims(t *testing.T) {
+	fileDelims := []FileDelims{
+		{LDelim: "[[", RDelim: "]]", Files: []string{"*.hbs", "helm/**"}},
+		{LDelim: "<%", RDelim: "%>", Files: []string{"**/*.erb", "*.hbs"}},
+	}
+
+	testdata := []struct {
+		name   string
+		lDelim string
+		ok     bool
+	}{
+		{"foo.hbs", "[[", true},
+		{"sub/dir/foo.hbs", "[[", true},
+		{"./helm/templates/deploy.yaml", "[[", true},
+		{"other/helm/deploy.yaml", "", false},
+		{"sub/foo.erb", "<%", true},
+		{"foo.txt", "", false},
+	}
+
+	for _, d := range testdata {
+		fd, ok := matchFileDelims(fileDelims, d.name)
+		assert.Equal(t, d.ok, ok, d.name)
+		assert.Equal(t, d.lDelim, fd.LDelim, d.name)
+	}
+}
+
+func TestValidateFileDelims(t *testing.T) {
+	require.NoError(t, validateFileDelims(nil))
+	require.NoError(t, validateFileDelims([]FileDelims{
+		{LDelim: "[[", RDelim: "]]", Files: []string{"*.hbs"}},
+	}))
+
+	require.Error(t, validateFileDelims([]FileDelims{
+		{LDelim: "[[", Files: []string{"*.hbs"}},
+	}))
+	require.Error(t, validateFileDelims([]FileDelims{
+		{LDelim: "[[", RDelim: "]]"},
+	}))
+	require.Error(t, validateFileDelims([]FileDelims{
+		{LDelim: "[[", RDelim: "]]", Files: []string{"[.hbs"}},
+	}))
+}
+
+func TestRenderTemplate_Delims(t *testing.T) {
+	tr := newRenderer(RenderOptions{})
+
+	out := &bytes.Buffer{}
+	err := tr.RenderTemplates(context.Background(), []Template{
+		{Name: "a", Text: `[[ "hi" ]] {{ .x }}`, LDelim: "[[", RDelim: "]]", Writer: out},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "hi {{ .x }}", out.String())
+
+	// directives don't change line numbers in errors
+	err = tr.RenderTemplates(context.Background(), []Template{
+		{Name: "b", Text: "[[/*\n*/]]line 2\n[[ fail ]]", LDelim: "[[", RDelim: "]]", Writer: &bytes.Buffer{}},
+	})
+	require.ErrorContains(t, err, "b:3:")
+}
+
+func TestRun_FileDelims(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "in")
+	out := filepath.Join(dir, "out")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(in, "helm"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(in, "plain.txt"), []byte(`{{ "plain" }}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(in, "helm", "deploy.yaml"),
+		[]byte(`[[ "helm" ]] {{ .Values.x }}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(in, "page.html"),
+		[]byte("<!-- gomplate:delims=<% %> -->\n<% \"page\" %> [[ x ]]"), 0o600))
+
+	err := Run(context.Background(), &Config{
+		InputDir:  in,
+		OutputDir: out,
+		FileDelims: []FileDelims{
+			{LDelim: "[[", RDelim: "]]", Files: []string{"helm/**", "*.html"}},
+		},
+	})
+	require.NoError(t, err)
+
+	for name, expected := range map[string]string{
+		"plain.txt":        "plain",
+		"helm/deploy.yaml": "helm {{ .Values.x }}",
+		"page.html":        "page [[ x ]]",
+	} {
+		b, err := os.ReadFile(filepath.Join(out, filepath.FromSlash(name)))
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(b), name)
+	}
+}
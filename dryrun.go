@@ -0,0 +1,157 @@
+package gomplate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"sync"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// ErrOutputChanged is returned (wrapped) by Run in dry-run mode, when any
+// output files would be changed
+var ErrOutputChanged = errors.New("output files would change")
+
+type dryRunCtxKey struct{}
+
+// dryRun collects rendered outputs in memory instead of writing them, so they
+// can be compared with the existing output files
+type dryRun struct {
+	outputs []*dryRunFile
+	mu      sync.Mutex
+	diff    bool
+}
+
+func withDryRun(ctx context.Context, d *dryRun) context.Context {
+	return context.WithValue(ctx, dryRunCtxKey{}, d)
+}
+
+func dryRunFromContext(ctx context.Context) *dryRun {
+	d, _ := ctx.Value(dryRunCtxKey{}).(*dryRun)
+	return d
+}
+
+// open returns a writer for the named output file, which is only kept in
+// memory
+func (d *dryRun) open(filename string) io.WriteCloser {
+	f := &dryRunFile{name: filename}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.outputs = append(d.outputs, f)
+
+	return f
+}
+
+// report writes the names of (or diffs for) the files which would change,
+// and returns an error wrapping ErrOutputChanged if there are any
+func (d *dryRun) report(ctx context.Context, out io.Writer) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	changed := []string{}
+
+	for _, f := range d.outputs {
+		existing, exists, err := readExisting(ctx, f.name)
+		if err != nil {
+			return err
+		}
+
+		rendered := f.buf.String()
+		if exists && existing == rendered {
+			continue
+		}
+
+		changed = append(changed, f.name)
+
+		if !d.diff {
+			fmt.Fprintln(out, f.name)
+			continue
+		}
+
+		if err := writeDiff(out, f.name, existing, rendered, exists); err != nil {
+			return err
+		}
+	}
+
+	if len(changed) > 0 {
+		return fmt.Errorf("%w: %s", ErrOutputChanged, strings.Join(changed, ", "))
+	}
+
+	return nil
+}
+
+// readExisting reads the current content of an output file, if it exists
+func readExisting(ctx context.Context, filename string) (string, bool, error) {
+	fsys, err := datafs.FSysForPath(ctx, filename)
+	if err != nil {
+		return "", false, fmt.Errorf("fsysForPath: %w", err)
+	}
+
+	b, err := fs.ReadFile(fsys, filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", false, nil
+	}
+
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read output file %q: %w", filename, err)
+	}
+
+	return string(b), true, nil
+}
+
+// writeDiff writes a unified diff between the existing and rendered content
+func writeDiff(out io.Writer, name, existing, rendered string, exists bool) error {
+	from := name
+	if !exists {
+		from = "/dev/null"
+	}
+
+	if strings.ContainsRune(existing, 0) || strings.ContainsRune(rendered, 0) {
+		_, err := fmt.Fprintf(out, "Binary files %s and %s differ\n", from, name)
+		return err
+	}
+
+	return difflib.WriteUnifiedDiff(out, difflib.UnifiedDiff{
+		A:        diffLines(existing),
+		B:        diffLines(rendered),
+		FromFile: from,
+		ToFile:   name,
+		Context:  3,
+	})
+}
+
+// diffLines splits the content into lines, each ending with a newline (which
+// the diff's format requires)
+func diffLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+
+	lines[len(lines)-1] += "\n"
+
+	return lines
+}
+
+// dryRunFile is an output file which is only rendered into memory
+type dryRunFile struct {
+	name string
+	buf  bytes.Buffer
+}
+
+func (f *dryRunFile) Write(p []byte) (int, error) {
+	return f.buf.Write(p)
+}
+
+// Close - implements io.Closer
+func (f *dryRunFile) Close() error {
+	return nil
+}
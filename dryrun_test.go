@@ -0,0 +1,78 @@
+package gomplate
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunDryRun(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "in")
+	out := filepath.Join(dir, "out")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(in, "sub"), 0o755))
+	require.NoError(t, os.MkdirAll(out, 0o755))
+
+	require.NoError(t, os.WriteFile(filepath.Join(in, "same.txt"), []byte("same {{ 1 }}\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(in, "changed.txt"), []byte("a\nb={{ 2 }}\nc"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(in, "sub", "new.txt"), []byte("new\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(in, "empty.txt"), []byte("  \n"), 0o600))
+
+	require.NoError(t, os.WriteFile(filepath.Join(out, "same.txt"), []byte("same 1\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(out, "changed.txt"), []byte("a\nb=1\nc"), 0o600))
+
+	run := func(cfg *Config) (string, error) {
+		t.Helper()
+
+		stdout := &bytes.Buffer{}
+		cfg.InputDir = in
+		cfg.OutputDir = out
+		cfg.Stdout = stdout
+
+		err := Run(context.Background(), cfg)
+
+		return stdout.String(), err
+	}
+
+	stdout, err := run(&Config{DryRun: true})
+	require.ErrorIs(t, err, ErrOutputChanged)
+	assert.Equal(t, filepath.Join(out, "changed.txt")+"\n"+filepath.Join(out, "sub", "new.txt")+"\n", stdout)
+
+	// nothing was written
+	assert.NoDirExists(t, filepath.Join(out, "sub"))
+
+	b, err := os.ReadFile(filepath.Join(out, "changed.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "a\nb=1\nc", string(b))
+
+	stdout, err = run(&Config{Diff: true})
+	require.ErrorIs(t, err, ErrOutputChanged)
+
+	changed := filepath.Join(out, "changed.txt")
+	created := filepath.Join(out, "sub", "new.txt")
+	assert.Equal(t, "--- "+changed+"\n+++ "+changed+"\n"+
+		"@@ -1,3 +1,3 @@\n a\n-b=1\n+b=2\n c\n"+
+		"--- /dev/null\n+++ "+created+"\n"+
+		"@@ -0,0 +1 @@\n+new\n", stdout)
+
+	// after a real run, there are no differences
+	_, err = run(&Config{})
+	require.NoError(t, err)
+
+	stdout, err = run(&Config{Diff: true})
+	require.NoError(t, err)
+	assert.Empty(t, stdout)
+}
+
+func TestDiffLines(t *testing.T) {
+	assert.Equal(t, []string{}, diffLines(""))
+	assert.Equal(t, []string{"a\n"}, diffLines("a"))
+	assert.Equal(t, []string{"a\n", "b\n"}, diffLines("a\nb\n"))
+	assert.Equal(t, []string{"a\n", "\n"}, diffLines("a\n\n"))
+}
@@ -2,6 +2,11 @@
 package env
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
 	osfs "github.com/hack-pad/hackpadfs/os"
 	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
 )
@@ -30,3 +35,45 @@ func LookupEnv(key string) (string, bool) {
 	fsys := datafs.WrapWdFS(osfs.NewFS())
 	return datafs.LookupEnvFsys(fsys, key)
 }
+
+// Require - retrieves the value of the environment variable named by the key,
+// like Getenv, but returns an error when the variable is unset or empty. The
+// error message can be overridden with the optional message.
+func Require(key string, message ...string) (string, error) {
+	val, _ := LookupEnv(key)
+	if val == "" {
+		if len(message) > 0 && message[0] != "" {
+			return "", errors.New(message[0])
+		}
+
+		return "", fmt.Errorf("required environment variable %s is not set", key)
+	}
+
+	return val, nil
+}
+
+// WithPrefix - returns a map of all environment variables whose names start
+// with the given prefix. As with Getenv, variables ending in `_FILE` are
+// read from the referenced files, and appear in the map without the `_FILE`
+// suffix (unless the variable without the suffix is also set).
+func WithPrefix(prefix string) map[string]string {
+	out := map[string]string{}
+
+	for _, kv := range os.Environ() {
+		k, _, _ := strings.Cut(kv, "=")
+		if k == "" || !strings.HasPrefix(k, prefix) {
+			continue
+		}
+
+		k = strings.TrimSuffix(k, "_FILE")
+		if _, ok := out[k]; ok {
+			continue
+		}
+
+		if v, ok := LookupEnv(k); ok {
+			out[k] = v
+		}
+	}
+
+	return out
+}
@@ -2,9 +2,11 @@ package env
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetenv(t *testing.T) {
@@ -20,3 +22,40 @@ func TestExpandEnv(t *testing.T) {
 	assert.Equal(t, os.Getenv("USER")+": "+os.Getenv("HOME"),
 		ExpandEnv("$USER: ${HOME}"))
 }
+
+func TestRequire(t *testing.T) {
+	t.Setenv("REQUIRED_VAR", "hello")
+	t.Setenv("EMPTY_VAR", "")
+
+	v, err := Require("REQUIRED_VAR")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", v)
+
+	_, err = Require("EMPTY_VAR")
+	require.EqualError(t, err, "required environment variable EMPTY_VAR is not set")
+
+	_, err = Require("BLAHBLAHBLAH", "set BLAHBLAHBLAH to the API token")
+	require.EqualError(t, err, "set BLAHBLAHBLAH to the API token")
+}
+
+func TestWithPrefix(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "secret"), []byte("hunter2\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other"), []byte("ignored"), 0o600))
+
+	t.Setenv("WPTEST_HOST", "localhost")
+	t.Setenv("WPTEST_PORT", "8080")
+	t.Setenv("WPTEST_PASSWORD_FILE", filepath.Join(dir, "secret"))
+	t.Setenv("WPTEST_USER", "admin")
+	t.Setenv("WPTEST_USER_FILE", filepath.Join(dir, "other"))
+	t.Setenv("XWPTEST_HOST", "nope")
+
+	assert.Equal(t, map[string]string{
+		"WPTEST_HOST":     "localhost",
+		"WPTEST_PORT":     "8080",
+		"WPTEST_PASSWORD": "hunter2",
+		"WPTEST_USER":     "admin",
+	}, WithPrefix("WPTEST_"))
+
+	assert.Empty(t, WithPrefix("NO_SUCH_PREFIX_"))
+}
@@ -0,0 +1,158 @@
+package env
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExpandSafe - expands references to environment variables in s, like
+// ExpandEnv, but with support for shell-style defaults and required
+// variables, and without mangling text that isn't a reference:
+//
+//   - $VAR and ${VAR} are replaced by the variable's value
+//   - ${VAR:-default} uses the default when VAR is unset or empty
+//   - ${VAR-default} uses the default only when VAR is unset
+//   - ${VAR:?message} and ${VAR?message} fail with the message (or a standard
+//     one) when VAR is unset (or empty, with the colon)
+//   - $$ is replaced by a single $
+//
+// References to unset variables without defaults are left unchanged, as is
+// a $ that isn't followed by a variable name (like "$5" or "$(cmd)").
+func ExpandSafe(s string) (string, error) {
+	return expandSafe(s, LookupEnv)
+}
+
+func expandSafe(s string, lookup func(string) (string, bool)) (string, error) {
+	var sb strings.Builder
+	sb.Grow(len(s))
+
+	for i := 0; i < len(s); {
+		if s[i] != '$' || i+1 == len(s) {
+			sb.WriteByte(s[i])
+			i++
+
+			continue
+		}
+
+		switch c := s[i+1]; {
+		case c == '$':
+			sb.WriteByte('$')
+			i += 2
+		case c == '{':
+			end := closingBrace(s[i+2:])
+			if end < 0 {
+				return "", fmt.Errorf("unterminated variable reference at %q", s[i:])
+			}
+
+			out, ok, err := expandBraced(s[i+2:i+2+end], lookup)
+			if err != nil {
+				return "", err
+			}
+
+			if ok {
+				sb.WriteString(out)
+			} else {
+				sb.WriteString(s[i : i+3+end])
+			}
+
+			i += 3 + end
+		case isNameStart(c):
+			n := 2
+			for i+n < len(s) && isNameChar(s[i+n]) {
+				n++
+			}
+
+			if v, ok := lookup(s[i+1 : i+n]); ok {
+				sb.WriteString(v)
+			} else {
+				sb.WriteString(s[i : i+n])
+			}
+
+			i += n
+		default:
+			sb.WriteByte('$')
+			i++
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// expandBraced expands the contents of a ${...} reference. The second return
+// value is false when the reference should be left unchanged.
+func expandBraced(ref string, lookup func(string) (string, bool)) (string, bool, error) {
+	n := 0
+	for n < len(ref) && isNameChar(ref[n]) {
+		n++
+	}
+
+	name, rest := ref[:n], ref[n:]
+	if name == "" || !isNameStart(name[0]) {
+		return "", false, fmt.Errorf("invalid variable reference ${%s}", ref)
+	}
+
+	val, set := lookup(name)
+
+	colon := strings.HasPrefix(rest, ":")
+	op := strings.TrimPrefix(rest, ":")
+
+	// with a colon, empty variables are treated as unset
+	missing := !set || (colon && val == "")
+
+	switch {
+	case rest == "":
+		return val, set, nil
+	case strings.HasPrefix(op, "-"):
+		if missing {
+			// defaults can contain references too
+			def, err := expandSafe(op[1:], lookup)
+			return def, true, err
+		}
+
+		return val, true, nil
+	case strings.HasPrefix(op, "?"):
+		if !missing {
+			return val, true, nil
+		}
+
+		if msg := op[1:]; msg != "" {
+			return "", false, fmt.Errorf("%s: %s", name, msg)
+		}
+
+		if colon {
+			return "", false, fmt.Errorf("required environment variable %s is not set or empty", name)
+		}
+
+		return "", false, fmt.Errorf("required environment variable %s is not set", name)
+	default:
+		return "", false, fmt.Errorf("invalid variable reference ${%s}", ref)
+	}
+}
+
+// closingBrace returns the index of the } that closes a ${ reference, allowing
+// for nested references, or -1 when there's none
+func closingBrace(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '$' && i+1 < len(s) && s[i+1] == '{':
+			depth++
+			i++
+		case s[i] == '}':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+
+	return -1
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func isNameChar(c byte) bool {
+	return isNameStart(c) || ('0' <= c && c <= '9')
+}
@@ -0,0 +1,65 @@
+package env
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandSafe(t *testing.T) {
+	vars := map[string]string{
+		"FOO":   "foo",
+		"EMPTY": "",
+		"B_2":   "bar",
+	}
+	lookup := func(k string) (string, bool) {
+		v, ok := vars[k]
+		return v, ok
+	}
+
+	testdata := []struct {
+		in, expected string
+	}{
+		{"", ""},
+		{"plain text", "plain text"},
+		{"$FOO ${FOO}", "foo foo"},
+		{"$FOO.txt ${B_2}x $B_2", "foo.txt barx bar"},
+		{"$UNSET ${UNSET}", "$UNSET ${UNSET}"},
+		{"${UNSET:-def} ${EMPTY:-def} ${FOO:-def}", "def def foo"},
+		{"${UNSET-def} [${EMPTY-def}] ${FOO-def}", "def [] foo"},
+		{"${UNSET:-}", ""},
+		{"${UNSET:-a b, c}", "a b, c"},
+		{"${UNSET:-$FOO}", "foo"},
+		{"${UNSET:-${B_2}}!", "bar!"},
+		{"${UNSET:-${ALSO_UNSET:-deep}}", "deep"},
+		{"${FOO:?oops} ${EMPTY?oops}", "foo "},
+		{"$$FOO costs $5 $(date) $", "$FOO costs $5 $(date) $"},
+		{"a$", "a$"},
+		{"100%$", "100%$"},
+	}
+
+	for _, d := range testdata {
+		out, err := expandSafe(d.in, lookup)
+		require.NoError(t, err, d.in)
+		assert.Equal(t, d.expected, out, d.in)
+	}
+
+	testerrs := []struct {
+		in, err string
+	}{
+		{"${UNSET:?must set UNSET}", "UNSET: must set UNSET"},
+		{"${EMPTY:?}", "required environment variable EMPTY is not set or empty"},
+		{"${UNSET?}", "required environment variable UNSET is not set"},
+		{"${FOO", "unterminated"},
+		{"${}", "invalid variable reference"},
+		{"${1A}", "invalid variable reference"},
+		{"${FOO:+alt}", "invalid variable reference"},
+		{"${UNSET:-${X:?nested}}", "X: nested"},
+	}
+
+	for _, d := range testerrs {
+		_, err := expandSafe(d.in, lookup)
+		assert.ErrorContains(t, err, d.err, d.in)
+	}
+}
@@ -0,0 +1,86 @@
+package fake
+
+//nolint:gochecknoglobals
+var (
+	firstNames = []string{
+		"Aaliyah", "Aarav", "Alejandro", "Amara", "Amelia", "Andrei", "Anna",
+		"Ava", "Benjamin", "Camila", "Carlos", "Charlotte", "Chen", "Chloe",
+		"Daniel", "David", "Elena", "Eli", "Emily", "Emma", "Ethan", "Fatima",
+		"Freya", "Gabriel", "Grace", "Hana", "Harper", "Henry", "Isabella",
+		"Ivan", "Jack", "James", "Javier", "Jin", "Kai", "Kenji", "Layla",
+		"Leila", "Liam", "Lucas", "Lucia", "Maria", "Mateo", "Maya", "Mia",
+		"Mohammed", "Nadia", "Noah", "Nora", "Olivia", "Omar", "Priya", "Rafael",
+		"Ravi", "Sakura", "Samuel", "Sofia", "Thomas", "Yara", "Zoe",
+	}
+
+	lastNames = []string{
+		"Adams", "Ahmed", "Alvarez", "Anderson", "Baker", "Brown", "Campbell",
+		"Chen", "Clark", "Cohen", "Davis", "Diaz", "Evans", "Fischer", "Garcia",
+		"Gonzalez", "Gupta", "Hall", "Hernandez", "Hill", "Ivanov", "Jackson",
+		"Johnson", "Jones", "Kim", "Kowalski", "Lee", "Lewis", "Lopez",
+		"Martin", "Martinez", "Miller", "Moore", "Murphy", "Nakamura", "Nguyen",
+		"Okafor", "Patel", "Perez", "Petrov", "Robinson", "Rodriguez", "Rossi",
+		"Sanchez", "Schmidt", "Silva", "Singh", "Smith", "Suzuki", "Taylor",
+		"Thomas", "Thompson", "Walker", "White", "Williams", "Wilson", "Wright",
+		"Young", "Zhang",
+	}
+
+	companyPrefixes = []string{
+		"Acme", "Apex", "Blue Sky", "Bright", "Cascade", "Cobalt", "Crescent",
+		"Evergreen", "Globex", "Granite", "Harbor", "Initech", "Keystone",
+		"Lighthouse", "Maple", "Meridian", "Nimbus", "Northwind", "Pinnacle",
+		"Quantum", "Redwood", "Silverline", "Summit", "Umbrella", "Vertex",
+	}
+
+	companySuffixes = []string{
+		"Inc", "LLC", "Ltd", "Group", "Systems", "Labs", "Industries",
+		"Technologies", "Solutions", "Partners",
+	}
+
+	cities = []string{
+		"Amsterdam", "Auckland", "Austin", "Bangalore", "Barcelona", "Berlin",
+		"Bogotá", "Boston", "Buenos Aires", "Cairo", "Cape Town", "Chicago",
+		"Denver", "Dublin", "Helsinki", "Istanbul", "Jakarta", "Lagos", "Lima",
+		"Lisbon", "London", "Madrid", "Melbourne", "Mexico City", "Montréal",
+		"Mumbai", "Nairobi", "Osaka", "Oslo", "Paris", "Portland", "Prague",
+		"Seattle", "Seoul", "Singapore", "Stockholm", "São Paulo", "Tokyo",
+		"Toronto", "Vancouver", "Vienna", "Warsaw", "Zürich",
+	}
+
+	countries = []string{
+		"Argentina", "Australia", "Austria", "Brazil", "Canada", "Chile",
+		"China", "Colombia", "Denmark", "Egypt", "Finland", "France", "Germany",
+		"India", "Indonesia", "Ireland", "Italy", "Japan", "Kenya", "Mexico",
+		"Netherlands", "New Zealand", "Nigeria", "Norway", "Peru", "Poland",
+		"Portugal", "South Africa", "South Korea", "Spain", "Sweden",
+		"Switzerland", "Turkey", "United Kingdom", "United States",
+	}
+
+	streetNames = []string{
+		"Cedar", "Elm", "Hill", "Lake", "Maple", "Market", "Mill", "Oak",
+		"Park", "Pine", "River", "Spring", "Sunset", "Valley", "Washington",
+		"Willow",
+	}
+
+	streetSuffixes = []string{
+		"Street", "Avenue", "Road", "Lane", "Drive", "Way", "Boulevard", "Court",
+	}
+
+	tlds = []string{"com", "net", "org", "io", "dev"}
+
+	// emails use the domains reserved for documentation (RFC 2606), so
+	// they can never reach a real mailbox
+	emailDomains = []string{"example.com", "example.net", "example.org"}
+
+	loremWords = []string{
+		"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing",
+		"elit", "sed", "do", "eiusmod", "tempor", "incididunt", "ut", "labore",
+		"et", "dolore", "magna", "aliqua", "enim", "ad", "minim", "veniam",
+		"quis", "nostrud", "exercitation", "ullamco", "laboris", "nisi",
+		"aliquip", "ex", "ea", "commodo", "consequat", "duis", "aute", "irure",
+		"in", "reprehenderit", "voluptate", "velit", "esse", "cillum", "fugiat",
+		"nulla", "pariatur", "excepteur", "sint", "occaecat", "cupidatat",
+		"non", "proident", "sunt", "culpa", "qui", "officia", "deserunt",
+		"mollit", "anim", "id", "est", "laborum",
+	}
+)
@@ -0,0 +1,253 @@
+// Package fake generates fake (but realistic-looking) data, such as names,
+// email addresses, and IP addresses, for fixtures and demo configurations.
+package fake
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"math/rand/v2"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Faker generates fake data. A Faker created with a seed produces the same
+// sequence of values for that seed, on every platform. A Faker is safe for
+// concurrent use.
+type Faker struct {
+	r  *rand.Rand
+	mu sync.Mutex
+}
+
+// New returns a Faker seeded with the given seed. Any string can be used as a
+// seed. When the seed is empty, a random seed is used.
+func New(seed string) *Faker {
+	f := &Faker{}
+	f.Seed(seed)
+
+	return f
+}
+
+// Seed resets the Faker with the given seed, so that it produces the same
+// sequence of values as a new Faker with the same seed. When the seed is
+// empty, a random seed is used.
+func (f *Faker) Seed(seed string) {
+	var s1, s2 uint64
+	if seed == "" {
+		//nolint:gosec
+		s1, s2 = rand.Uint64(), rand.Uint64()
+	} else {
+		h := sha256.Sum256([]byte(seed))
+		s1, s2 = binary.BigEndian.Uint64(h[:8]), binary.BigEndian.Uint64(h[8:16])
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	//nolint:gosec
+	f.r = rand.New(rand.NewPCG(s1, s2))
+}
+
+func (f *Faker) intN(n int) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.r.IntN(n)
+}
+
+func (f *Faker) pick(list []string) string {
+	return list[f.intN(len(list))]
+}
+
+// FirstName returns a first name
+func (f *Faker) FirstName() string {
+	return f.pick(firstNames)
+}
+
+// LastName returns a last name
+func (f *Faker) LastName() string {
+	return f.pick(lastNames)
+}
+
+// Name returns a full name
+func (f *Faker) Name() string {
+	return f.FirstName() + " " + f.LastName()
+}
+
+// Username returns a username, like "emma.garcia42"
+func (f *Faker) Username() string {
+	return usernameFor(f.FirstName(), f.LastName(), f.intN(100))
+}
+
+func usernameFor(first, last string, n int) string {
+	return asciiLower(first) + "." + asciiLower(last) + strconv.Itoa(n)
+}
+
+// asciiLower lowercases s, removing anything other than ASCII letters and
+// digits
+func asciiLower(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			return unicode.ToLower(r)
+		default:
+			return -1
+		}
+	}, s)
+}
+
+// Email returns an email address, in one of the example.com, example.net,
+// or example.org domains, which are reserved for documentation
+func (f *Faker) Email() string {
+	return f.Username() + "@" + f.pick(emailDomains)
+}
+
+// Company returns a company name
+func (f *Faker) Company() string {
+	return f.pick(companyPrefixes) + " " + f.pick(companySuffixes)
+}
+
+// DomainName returns a domain name, like "bright-systems.io"
+func (f *Faker) DomainName() string {
+	prefix := strings.ReplaceAll(strings.ToLower(f.pick(companyPrefixes)), " ", "-")
+	return prefix + "-" + strings.ToLower(f.pick(companySuffixes)) + "." + f.pick(tlds)
+}
+
+// URL returns an HTTPS URL, with a random domain and path
+func (f *Faker) URL() string {
+	return "https://" + f.DomainName() + "/" + f.Word() + "/" + f.Word()
+}
+
+// Phone returns a North American phone number in the 555-0100 to 555-0199
+// range, which is reserved for fictional use
+func (f *Faker) Phone() string {
+	return fmt.Sprintf("+1-%d-555-01%02d", 200+f.intN(800), f.intN(100))
+}
+
+// StreetAddress returns a street address, like "123 Maple Street"
+func (f *Faker) StreetAddress() string {
+	return strconv.Itoa(1+f.intN(9999)) + " " + f.pick(streetNames) + " " + f.pick(streetSuffixes)
+}
+
+// City returns a city name
+func (f *Faker) City() string {
+	return f.pick(cities)
+}
+
+// Country returns a country name
+func (f *Faker) Country() string {
+	return f.pick(countries)
+}
+
+// Word returns a lorem ipsum word
+func (f *Faker) Word() string {
+	return f.pick(loremWords)
+}
+
+// Words returns n lorem ipsum words
+func (f *Faker) Words(n int) []string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = f.Word()
+	}
+
+	return out
+}
+
+// Sentence returns a lorem ipsum sentence of n words, capitalized and ending
+// with a period
+func (f *Faker) Sentence(n int) string {
+	if n <= 0 {
+		return ""
+	}
+
+	s := strings.Join(f.Words(n), " ")
+	return strings.ToUpper(s[:1]) + s[1:] + "."
+}
+
+// Paragraph returns a lorem ipsum paragraph of n sentences, each with between
+// 4 and 12 words
+func (f *Faker) Paragraph(n int) string {
+	sentences := make([]string, n)
+	for i := range sentences {
+		sentences[i] = f.Sentence(4 + f.intN(9))
+	}
+
+	return strings.Join(sentences, " ")
+}
+
+// UUID returns a (version 4) UUID
+func (f *Faker) UUID() string {
+	b := make([]byte, 16)
+	for i := range b {
+		b[i] = byte(f.intN(256))
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// IPAddress returns a random address in the given network, like
+// "192.168.0.0/16" or "fd00::/8". The network and broadcast addresses of
+// IPv4 networks are never returned, unless the network is too small to
+// exclude them.
+func (f *Faker) IPAddress(cidr string) (netip.Addr, error) {
+	p, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("invalid network %q: %w", cidr, err)
+	}
+	p = p.Masked()
+
+	base := new(big.Int).SetBytes(p.Addr().AsSlice())
+	hostBits := p.Addr().BitLen() - p.Bits()
+	size := new(big.Int).Lsh(big.NewInt(1), uint(hostBits))
+
+	offset := f.bigN(size)
+	if p.Addr().Is4() && hostBits > 1 {
+		// avoid the network and broadcast addresses
+		offset = f.bigN(new(big.Int).Sub(size, big.NewInt(2)))
+		offset.Add(offset, big.NewInt(1))
+	}
+
+	b := base.Add(base, offset).FillBytes(make([]byte, p.Addr().BitLen()/8))
+	addr, _ := netip.AddrFromSlice(b)
+
+	return addr, nil
+}
+
+// bigN returns a random number in [0, n)
+func (f *Faker) bigN(n *big.Int) *big.Int {
+	// a few extra bytes keep the modulo bias negligible
+	b := make([]byte, len(n.Bytes())+8)
+	for i := range b {
+		b[i] = byte(f.intN(256))
+	}
+
+	out := new(big.Int).SetBytes(b)
+
+	return out.Mod(out, n)
+}
+
+// MACAddress returns a locally-administered unicast MAC address
+func (f *Faker) MACAddress() string {
+	b := make([]byte, 6)
+	for i := range b {
+		b[i] = byte(f.intN(256))
+	}
+
+	// set the locally-administered bit, and clear the multicast bit
+	b[0] = (b[0] | 0x02) &^ 0x01
+
+	parts := make([]string, len(b))
+	for i, v := range b {
+		parts[i] = fmt.Sprintf("%02x", v)
+	}
+
+	return strings.Join(parts, ":")
+}
@@ -0,0 +1,111 @@
+package fake
+
+import (
+	"net/netip"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeeded(t *testing.T) {
+	gen := func(f *Faker) []string {
+		return []string{
+			f.Name(), f.Email(), f.Company(), f.URL(), f.Phone(),
+			f.StreetAddress(), f.City(), f.Country(), f.Paragraph(2),
+			f.UUID(), f.MACAddress(),
+		}
+	}
+
+	a := gen(New("seed"))
+	assert.Equal(t, a, gen(New("seed")))
+	assert.NotEqual(t, a, gen(New("other seed")))
+
+	f := New("seed")
+	_ = gen(f)
+	f.Seed("seed")
+	assert.Equal(t, a, gen(f))
+}
+
+func TestNames(t *testing.T) {
+	f := New("")
+
+	for range 100 {
+		assert.Contains(t, firstNames, f.FirstName())
+		assert.Contains(t, lastNames, f.LastName())
+		assert.Regexp(t, `^\S+ \S+$`, f.Name())
+		assert.Regexp(t, `^[a-z]+\.[a-z]+\d{1,2}$`, f.Username())
+		assert.Regexp(t, `^[a-z]+\.[a-z]+\d{1,2}@example\.(com|net|org)$`, f.Email())
+		assert.Regexp(t, `^[a-z-]+\.[a-z]+$`, f.DomainName())
+		assert.Regexp(t, `^https://[a-z.-]+/[a-z]+/[a-z]+$`, f.URL())
+		assert.Regexp(t, `^\+1-[2-9]\d\d-555-01\d\d$`, f.Phone())
+		assert.Regexp(t, `^\d{1,4} [A-Z][a-z]+ [A-Z][a-z]+$`, f.StreetAddress())
+	}
+
+	assert.Equal(t, "emma.obrien7", usernameFor("Emma", "O'Brien", 7))
+}
+
+func TestLorem(t *testing.T) {
+	f := New("")
+
+	assert.Empty(t, f.Words(0))
+	assert.Len(t, f.Words(5), 5)
+
+	assert.Empty(t, f.Sentence(0))
+	s := f.Sentence(6)
+	assert.Len(t, strings.Fields(s), 6)
+	assert.Regexp(t, `^[A-Z][a-z ]+\.$`, s)
+
+	p := f.Paragraph(3)
+	assert.Equal(t, 3, strings.Count(p, "."))
+}
+
+func TestUUID(t *testing.T) {
+	f := New("")
+	re := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	for range 100 {
+		assert.Regexp(t, re, f.UUID())
+	}
+}
+
+func TestIPAddress(t *testing.T) {
+	f := New("")
+
+	for _, cidr := range []string{"10.0.0.0/8", "192.168.1.0/24", "172.16.5.4/12", "fd00::/8", "2001:db8::/32"} {
+		p := netip.MustParsePrefix(cidr).Masked()
+
+		for range 100 {
+			addr, err := f.IPAddress(cidr)
+			require.NoError(t, err)
+			assert.True(t, p.Contains(addr), "%s not in %s", addr, cidr)
+		}
+	}
+
+	// network and broadcast addresses are avoided
+	for range 100 {
+		addr, err := f.IPAddress("192.168.0.0/30")
+		require.NoError(t, err)
+		assert.Contains(t, []string{"192.168.0.1", "192.168.0.2"}, addr.String())
+	}
+
+	addr, err := f.IPAddress("192.168.0.7/32")
+	require.NoError(t, err)
+	assert.Equal(t, "192.168.0.7", addr.String())
+
+	_, err = f.IPAddress("192.168.0.0")
+	require.Error(t, err)
+}
+
+func TestMACAddress(t *testing.T) {
+	f := New("")
+
+	for range 100 {
+		mac := f.MACAddress()
+		assert.Regexp(t, `^[0-9a-f]{2}(:[0-9a-f]{2}){5}$`, mac)
+		// locally-administered, unicast
+		assert.Contains(t, "2367abef", mac[1:2])
+	}
+}
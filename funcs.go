@@ -2,6 +2,10 @@ package gomplate
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
 	"text/template"
 
 	"github.com/hairyhenderson/gomplate/v4/internal/config"
@@ -14,14 +18,25 @@ func CreateFuncs(ctx context.Context) template.FuncMap {
 	addToMap(f, funcs.CreateDataFuncs(ctx))
 	addToMap(f, funcs.CreateAWSFuncs(ctx))
 	addToMap(f, funcs.CreateGCPFuncs(ctx))
+	addToMap(f, funcs.CreateAzureFuncs(ctx))
 	addToMap(f, funcs.CreateBase64Funcs(ctx))
+	addToMap(f, funcs.CreateBase32Funcs(ctx))
+	addToMap(f, funcs.CreateBase85Funcs(ctx))
+	addToMap(f, funcs.CreateHexFuncs(ctx))
+	addToMap(f, funcs.CreateCompressFuncs(ctx))
+	addToMap(f, funcs.CreateCodecFuncs(ctx))
 	addToMap(f, funcs.CreateNetFuncs(ctx))
 	addToMap(f, funcs.CreateReFuncs(ctx))
 	addToMap(f, funcs.CreateStringFuncs(ctx))
+	addToMap(f, funcs.CreateColorFuncs(ctx))
 	addToMap(f, funcs.CreateEnvFuncs(ctx))
+	addToMap(f, funcs.CreateOSFuncs(ctx))
+	addToMap(f, funcs.CreateSysInfoFuncs(ctx))
+	addToMap(f, funcs.CreateGitInfoFuncs(ctx))
 	addToMap(f, funcs.CreateConvFuncs(ctx))
 	addToMap(f, funcs.CreateTimeFuncs(ctx))
 	addToMap(f, funcs.CreateMathFuncs(ctx))
+	addToMap(f, funcs.CreateBigNumFuncs(ctx))
 	addToMap(f, funcs.CreateCryptoFuncs(ctx))
 	addToMap(f, funcs.CreateFileFuncs(ctx))
 	addToMap(f, funcs.CreateFilePathFuncs(ctx))
@@ -31,7 +46,20 @@ func CreateFuncs(ctx context.Context) template.FuncMap {
 	addToMap(f, funcs.CreateCollFuncs(ctx))
 	addToMap(f, funcs.CreateUUIDFuncs(ctx))
 	addToMap(f, funcs.CreateRandomFuncs(ctx))
+	addToMap(f, funcs.CreateFakeFuncs(ctx))
 	addToMap(f, funcs.CreateSemverFuncs(ctx))
+	addToMap(f, funcs.CreateGeoIPFuncs(ctx))
+	addToMap(f, funcs.CreateCUEFuncs(ctx))
+	addToMap(f, funcs.CreateRegoFuncs(ctx))
+	addToMap(f, funcs.CreateXMLFuncs(ctx))
+	addToMap(f, funcs.CreateHTMLFuncs(ctx))
+	addToMap(f, funcs.CreateK8sFuncs(ctx))
+
+	// Sprig-compatible funcs are added last, as some override gomplate's
+	// aliases
+	if config.SprigEnabled(ctx) {
+		addToMap(f, funcs.CreateSprigFuncs(ctx))
+	}
 	return f
 }
 
@@ -42,6 +70,173 @@ func addToMap(dst, src map[string]interface{}) {
 	}
 }
 
+// nsAliases - the top-level functions which call into each namespace, and so
+// are denied along with it. Sprig's env and semver functions share their
+// namespace's name, so are denied with it anyway.
+var nsAliases = map[string][]string{
+	"aws":  {"ec2dynamic", "ec2meta", "ec2region", "ec2tag", "ec2tags"},
+	"coll": {"append", "dict", "flatten", "has", "jq", "jsonpath", "keys", "merge", "prepend", "reverse", "set", "slice", "sort", "uniq", "unset", "values"},
+	"conv": {"bool", "default", "join", "urlParse"},
+	"data": {
+		"csv", "csvByColumn", "csvByRow", "hcl", "ini", "json", "json5", "jsonArray", "jsonnet", "msgpack", "ndjson",
+		"toCSV", "toCUE", "toDotEnv", "toHCL", "toINI", "toJSON", "toJSONPretty", "toMsgPack", "toTOML", "toXML", "toYAML",
+		"toml", "yaml", "yamlArray",
+		// datasource functions
+		"_datasource", "datasource", "datasourceExists", "datasourceReachable", "datasourceStream", "defineDatasource",
+		"ds", "include", "listDatasources",
+	},
+	"env":     {"getenv"},
+	"math":    {"add", "div", "mul", "pow", "rem", "seq", "sub"},
+	"strings": {"contains", "hasPrefix", "hasSuffix", "indent", "nindent", "quote", "replaceAll", "shellQuote", "split", "splitN", "squote", "title", "toLower", "toUpper", "trim", "trimSpace"},
+	"test":    {"assert", "fail", "isKind", "kind", "required", "ternary"},
+	"tmpl":    {"tpl"},
+}
+
+// sprigNSAliases - the Sprig functions which correspond to each namespace,
+// when Sprig compatibility is enabled. These take the place of gomplate's own
+// aliases with the same names (like include, which is Helm's tmpl.Exec). The
+// few Sprig functions with no corresponding namespace (like empty and
+// coalesce) can only be denied by name.
+var sprigNSAliases = map[string][]string{
+	"base32": {"b32dec", "b32enc"},
+	"base64": {"b64dec", "b64enc"},
+	"coll": {
+		"append", "compact", "concat", "deepCopy", "dig", "first", "get", "has", "hasKey", "initial", "last", "list",
+		"mergeOverwrite", "omit", "pick", "pluck", "prepend", "push", "rest", "set", "slice", "tuple", "unset", "without",
+	},
+	"conv":   {"atoi", "float64", "int", "int64", "toString"},
+	"crypto": {"adler32sum", "sha1sum", "sha256sum"},
+	"data":   {"fromJson", "fromJsonArray", "fromYaml", "fromYamlArray", "toJson", "toPrettyJson", "toRawJson", "toYaml"},
+	"env":    {"expandenv"},
+	"math": {
+		"add", "add1", "addf", "ceil", "div", "divf", "floor", "max", "maxf", "min", "minf", "mod", "mul", "mulf",
+		"round", "sub", "subf", "until", "untilStep",
+	},
+	"path":   {"base", "clean", "dir", "ext", "isAbs"},
+	"random": {"randAlpha", "randAlphaNum", "randAscii", "randNumeric"},
+	"regexp": {"regexFind", "regexFindAll", "regexMatch", "regexQuoteMeta", "regexReplaceAll", "regexReplaceAllLiteral", "regexSplit"},
+	"semver": {"semverCompare"},
+	"strings": {
+		"abbrev", "camelcase", "cat", "contains", "hasPrefix", "hasSuffix", "initials", "join", "kebabcase", "lower",
+		"nospace", "plural", "quote", "repeat", "replace", "snakecase", "sortAlpha", "split", "splitList", "splitn",
+		"squote", "substr", "swapcase", "toStrings", "trim", "trimAll", "trimPrefix", "trimSuffix", "trunc", "untitle",
+		"upper", "wrap", "wrapWith",
+	},
+	"test": {"kindIs", "kindOf", "typeIs", "typeOf"},
+	"time": {"date", "dateInZone", "dateModify", "duration", "now", "toDate", "unixEpoch"},
+	"tmpl": {"include"},
+	"uuid": {"uuidv4"},
+}
+
+// aliasNamespaces - maps each top-level alias to the namespace it belongs to
+func aliasNamespaces(sprig bool) map[string]string {
+	owners := map[string]string{}
+	for ns, aliases := range nsAliases {
+		for _, alias := range aliases {
+			owners[alias] = ns
+		}
+	}
+
+	if sprig {
+		for ns, aliases := range sprigNSAliases {
+			for _, alias := range aliases {
+				owners[alias] = ns
+			}
+		}
+	}
+
+	return owners
+}
+
+// funcDenied - whether the namespace or top-level function isn't permitted by
+// the allow and deny lists
+func funcDenied(name string, allow, deny []string) bool {
+	return slices.Contains(deny, name) || (len(allow) > 0 && !slices.Contains(allow, name))
+}
+
+// applyFuncRules - removes the namespaces and functions not permitted by the
+// allow and deny lists from f, and then adds the aliases. Aliases can refer
+// to namespaces, top-level functions, or functions in namespaces (like
+// "strings.ToUpper"), but only if they're still permitted.
+//
+// Denying a namespace also denies its top-level aliases (see nsAliases and
+// sprigNSAliases), so that denying "env" also denies "getenv". Individual
+// functions in a namespace can't be allowed or denied, since templates call
+// them directly on the namespace.
+func applyFuncRules(ctx context.Context, f template.FuncMap, aliases map[string]string, allow, deny []string) error {
+	for _, name := range append(slices.Clone(allow), deny...) {
+		if _, ok := f[name]; ok {
+			continue
+		}
+
+		if ns, _, ok := strings.Cut(name, "."); ok {
+			return fmt.Errorf("can't allow or deny %q: individual functions in a namespace can't be allowed or denied, only whole namespaces (like %q) and top-level functions", name, ns)
+		}
+
+		return fmt.Errorf("can't allow or deny %q: no such namespace or function", name)
+	}
+
+	if len(allow) > 0 {
+		for name := range f {
+			if !slices.Contains(allow, name) {
+				delete(f, name)
+			}
+		}
+	}
+
+	owners := aliasNamespaces(config.SprigEnabled(ctx))
+	for _, name := range deny {
+		delete(f, name)
+
+		for alias, ns := range owners {
+			if ns == name {
+				delete(f, alias)
+			}
+		}
+	}
+
+	// resolve all aliases before adding any, so aliases can't refer to other
+	// aliases
+	resolved := make(map[string]interface{}, len(aliases))
+	for alias, name := range aliases {
+		fn, err := lookupFunc(f, name)
+		if err != nil {
+			return fmt.Errorf("can't alias %q: %w", alias, err)
+		}
+
+		resolved[alias] = fn
+	}
+
+	addToMap(f, resolved)
+
+	return nil
+}
+
+// lookupFunc - finds the namespace or function with the given name, which
+// may be a function in a namespace, like "strings.ToUpper"
+func lookupFunc(f template.FuncMap, name string) (interface{}, error) {
+	if fn, ok := f[name]; ok {
+		return fn, nil
+	}
+
+	nsName, funcName, ok := strings.Cut(name, ".")
+	if !ok {
+		return nil, fmt.Errorf("no such namespace or function %q (it may be denied)", name)
+	}
+
+	nsFunc, ok := f[nsName].(func() interface{})
+	if !ok {
+		return nil, fmt.Errorf("no such namespace %q (it may be denied)", nsName)
+	}
+
+	m := reflect.ValueOf(nsFunc()).MethodByName(funcName)
+	if !m.IsValid() {
+		return nil, fmt.Errorf("no such function %q", name)
+	}
+
+	return m.Interface(), nil
+}
+
 // SetExperimental enables experimental functions and features in the given
 // context. This must be done before creating functions. The set of experimental
 // features enabled by this is not fixed and will change over time.
@@ -50,3 +245,10 @@ func SetExperimental(ctx context.Context) context.Context {
 	// functions available to external packages.
 	return config.SetExperimental(ctx)
 }
+
+// SetWriteAllowed allows templates to write files (with file.Write) in the
+// given context. This must be done before creating functions. When rendering
+// with a [Renderer], set [RenderOptions.AllowWrite] instead.
+func SetWriteAllowed(ctx context.Context) context.Context {
+	return config.WithWriteAllowed(ctx)
+}
@@ -0,0 +1,146 @@
+package gomplate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+	"github.com/hairyhenderson/gomplate/v4/internal/funcs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyFuncRules(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	f := CreateFuncs(ctx)
+	err := applyFuncRules(ctx, f, nil, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, len(CreateFuncs(ctx)), len(f))
+
+	f = CreateFuncs(ctx)
+	err = applyFuncRules(ctx, f, nil, nil, []string{"env", "getenv", "file"})
+	require.NoError(t, err)
+	assert.NotContains(t, f, "env")
+	assert.NotContains(t, f, "getenv")
+	assert.NotContains(t, f, "file")
+	assert.Contains(t, f, "strings")
+
+	// denying a namespace also denies its aliases
+	f = CreateFuncs(ctx)
+	addToMap(f, funcs.CreateDataSourceFuncs(ctx, nil))
+	err = applyFuncRules(ctx, f, nil, nil, []string{"env", "data"})
+	require.NoError(t, err)
+	assert.NotContains(t, f, "getenv")
+	assert.NotContains(t, f, "json")
+	assert.NotContains(t, f, "include")
+	assert.NotContains(t, f, "ds")
+	assert.Contains(t, f, "toUpper")
+
+	f = CreateFuncs(ctx)
+	err = applyFuncRules(ctx, f, nil, []string{"strings", "conv", "toUpper"}, []string{"conv"})
+	require.NoError(t, err)
+	assert.Len(t, f, 2)
+	assert.Contains(t, f, "strings")
+	assert.Contains(t, f, "toUpper")
+
+	f = CreateFuncs(ctx)
+	err = applyFuncRules(ctx, f, map[string]string{
+		"s":        "strings",
+		"up":       "strings.ToUpper",
+		"contains": "strings.Contains",
+	}, nil, nil)
+	require.NoError(t, err)
+	assert.Contains(t, f, "s")
+
+	tmpl, err := template.New("t").Funcs(f).Parse(`{{ up "hi" }} {{ s.Title "hi" }} {{ contains "b" "abc" }}`)
+	require.NoError(t, err)
+
+	out := &strings.Builder{}
+	err = tmpl.Execute(out, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "HI Hi true", out.String())
+
+	testdata := []struct {
+		aliases     map[string]string
+		allow, deny []string
+		err         string
+	}{
+		{deny: []string{"bogus"}, err: `no such namespace or function`},
+		{allow: []string{"file.Write"}, err: `only whole namespaces (like "file")`},
+		{aliases: map[string]string{"x": "bogus"}, err: `can't alias "x": no such namespace or function "bogus"`},
+		{aliases: map[string]string{"x": "bogus.Foo"}, err: `no such namespace "bogus"`},
+		{aliases: map[string]string{"x": "strings.Bogus"}, err: `no such function "strings.Bogus"`},
+		{aliases: map[string]string{"e": "env.Getenv"}, deny: []string{"env"}, err: `no such namespace "env"`},
+	}
+
+	for _, d := range testdata {
+		err = applyFuncRules(ctx, CreateFuncs(ctx), d.aliases, d.allow, d.deny)
+		require.ErrorContains(t, err, d.err)
+	}
+}
+
+func TestNSAliases(t *testing.T) {
+	t.Parallel()
+
+	// some aliases are only available with Sprig
+	ctx := config.WithSprig(context.Background())
+
+	f := CreateFuncs(ctx)
+	addToMap(f, funcs.CreateDataSourceFuncs(ctx, nil))
+	addTmplFuncs(ctx, f, template.New("t"), nil, "", nil)
+
+	for _, m := range []map[string][]string{nsAliases, sprigNSAliases} {
+		for ns, aliases := range m {
+			assert.Contains(t, f, ns)
+
+			for _, alias := range aliases {
+				assert.Contains(t, f, alias, "alias of %s", ns)
+			}
+		}
+	}
+
+	// Sprig aliases are denied with their namespace
+	err := applyFuncRules(ctx, f, nil, nil, []string{"crypto", "data", "env"})
+	require.NoError(t, err)
+	assert.NotContains(t, f, "sha256sum")
+	assert.NotContains(t, f, "toJson")
+	assert.NotContains(t, f, "expandenv")
+
+	// include is Helm's tmpl.Exec in Sprig mode
+	assert.Contains(t, f, "include")
+
+	err = applyFuncRules(ctx, f, nil, nil, []string{"tmpl"})
+	require.NoError(t, err)
+	assert.NotContains(t, f, "include")
+}
+
+func TestSetWriteAllowed(t *testing.T) {
+	// files can only be written in the working directory
+	wd, _ := os.Getwd()
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+	})
+	dir := t.TempDir()
+	_ = os.Chdir(dir)
+
+	fname := filepath.Join(dir, "out.txt")
+	in := `{{ "hello" | file.Write "out.txt" }}`
+
+	f := CreateFuncs(context.Background())
+	tmpl := template.Must(template.New("t").Funcs(f).Parse(in))
+	err := tmpl.Execute(&strings.Builder{}, nil)
+	require.ErrorContains(t, err, "file.Write is disabled")
+
+	f = CreateFuncs(SetWriteAllowed(context.Background()))
+	tmpl = template.Must(template.New("t").Funcs(f).Parse(in))
+	err = tmpl.Execute(&strings.Builder{}, nil)
+	require.NoError(t, err)
+	assert.FileExists(t, fname)
+}
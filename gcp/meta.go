@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"path"
 	"strconv"
 	"strings"
 	"sync"
@@ -81,6 +82,52 @@ func (c *MetaClient) Meta(key string, def ...string) (string, error) {
 	return c.retrieveMetadata(c.ctx, url, def...)
 }
 
+// ProjectMeta retrieves a project-level value from the GCP Instance Metadata Service,
+// returning the given default if the service is unavailable or the requested URL does
+// not exist.
+func (c *MetaClient) ProjectMeta(key string, def ...string) (string, error) {
+	url := c.endpoint + "/computeMetadata/v1/project/" + key
+	return c.retrieveMetadata(c.ctx, url, def...)
+}
+
+// ProjectID returns the ID of the project the instance belongs to, or the given
+// default if it can't be determined.
+func (c *MetaClient) ProjectID(def ...string) (string, error) {
+	return c.ProjectMeta("project-id", def...)
+}
+
+// Zone returns the zone the instance is running in (e.g. `us-central1-a`), or the
+// given default if it can't be determined.
+func (c *MetaClient) Zone(def ...string) (string, error) {
+	// the metadata service returns the full zone name, like
+	// "projects/123456789/zones/us-central1-a"
+	zone, err := c.Meta("zone")
+	if err != nil {
+		return "", err
+	}
+	if zone == "" {
+		return returnDefault(def), nil
+	}
+
+	return path.Base(zone), nil
+}
+
+// Region returns the region the instance is running in (e.g. `us-central1`), or
+// the given default if it can't be determined.
+func (c *MetaClient) Region(def ...string) (string, error) {
+	zone, err := c.Zone()
+	if err != nil {
+		return "", err
+	}
+
+	i := strings.LastIndex(zone, "-")
+	if i <= 0 {
+		return returnDefault(def), nil
+	}
+
+	return zone[:i], nil
+}
+
 // retrieveMetadata executes an HTTP request to the GCP Instance Metadata Service with the
 // correct headers set, and extracts the returned value.
 func (c *MetaClient) retrieveMetadata(ctx context.Context, url string, def ...string) (string, error) {
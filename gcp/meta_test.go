@@ -0,0 +1,66 @@
+package gcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMetaClient(t *testing.T, data map[string]string) *MetaClient {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata-Flavor") != "Google" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		v, ok := data[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(v))
+	}))
+	t.Cleanup(srv.Close)
+
+	return &MetaClient{
+		ctx:      context.Background(),
+		cache:    map[string]string{},
+		endpoint: srv.URL,
+	}
+}
+
+func TestMeta(t *testing.T) {
+	c := newTestMetaClient(t, map[string]string{
+		"/computeMetadata/v1/instance/id":          "1334999446930701104",
+		"/computeMetadata/v1/instance/zone":        "projects/123456789/zones/us-central1-a",
+		"/computeMetadata/v1/project/project-id":   "my-project",
+		"/computeMetadata/v1/project/attributes/a": "b\n",
+	})
+
+	assert.Equal(t, "1334999446930701104", must(c.Meta("id")))
+	assert.Equal(t, "def", must(c.Meta("missing", "def")))
+	assert.Equal(t, "my-project", must(c.ProjectID()))
+	assert.Equal(t, "b", must(c.ProjectMeta("attributes/a")))
+	assert.Equal(t, "", must(c.ProjectMeta("attributes/missing")))
+	assert.Equal(t, "us-central1-a", must(c.Zone()))
+	assert.Equal(t, "us-central1", must(c.Region()))
+
+	c = newTestMetaClient(t, map[string]string{})
+	assert.Equal(t, "unknown", must(c.Zone("unknown")))
+	assert.Equal(t, "unknown", must(c.Region("unknown")))
+	assert.Equal(t, "", must(c.Region()))
+	assert.Equal(t, "def", must(c.ProjectID("def")))
+}
+
+func must(s string, err error) string {
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
@@ -0,0 +1,76 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// DefaultScopes are the OAuth2 scopes requested when none are given.
+var DefaultScopes = []string{"https://www.googleapis.com/auth/cloud-platform"}
+
+// findDefaultCredentials is swappable for testing
+var findDefaultCredentials = google.FindDefaultCredentials
+
+// TokenClient is used to get OAuth2 access tokens from Google's Application
+// Default Credentials (ADC).
+type TokenClient struct {
+	ctx     context.Context
+	sources map[string]oauth2.TokenSource
+	mu      sync.Mutex
+}
+
+// NewTokenClient constructs a new TokenClient.
+func NewTokenClient(ctx context.Context) *TokenClient {
+	return &TokenClient{
+		ctx:     ctx,
+		sources: map[string]oauth2.TokenSource{},
+	}
+}
+
+// AccessToken returns an access token for the given scopes (or DefaultScopes),
+// using Application Default Credentials. Tokens are reused until they expire.
+func (c *TokenClient) AccessToken(scopes ...string) (string, error) {
+	if len(scopes) == 0 {
+		scopes = DefaultScopes
+	}
+
+	ts, err := c.tokenSource(scopes)
+	if err != nil {
+		return "", err
+	}
+
+	tok, err := ts.Token()
+	if err != nil {
+		return "", fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	return tok.AccessToken, nil
+}
+
+func (c *TokenClient) tokenSource(scopes []string) (oauth2.TokenSource, error) {
+	sorted := make([]string, len(scopes))
+	copy(sorted, scopes)
+	sort.Strings(sorted)
+	key := strings.Join(sorted, " ")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ts, ok := c.sources[key]; ok {
+		return ts, nil
+	}
+
+	creds, err := findDefaultCredentials(c.ctx, scopes...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find default credentials: %w", err)
+	}
+
+	c.sources[key] = creds.TokenSource
+	return creds.TokenSource, nil
+}
@@ -0,0 +1,46 @@
+package gcp
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+func TestAccessToken(t *testing.T) {
+	calls := 0
+	var gotScopes []string
+	findDefaultCredentials = func(_ context.Context, scopes ...string) (*google.Credentials, error) {
+		calls++
+		gotScopes = scopes
+		return &google.Credentials{
+			TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "ya29.token"}),
+		}, nil
+	}
+	defer func() { findDefaultCredentials = google.FindDefaultCredentials }()
+
+	c := NewTokenClient(context.Background())
+
+	tok, err := c.AccessToken()
+	require.NoError(t, err)
+	assert.Equal(t, "ya29.token", tok)
+	assert.Equal(t, DefaultScopes, gotScopes)
+
+	// token source is reused for the same set of scopes, in any order
+	_, err = c.AccessToken("b", "a")
+	require.NoError(t, err)
+	_, err = c.AccessToken("a", "b")
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, []string{"b", "a"}, gotScopes)
+
+	findDefaultCredentials = func(context.Context, ...string) (*google.Credentials, error) {
+		return nil, errors.New("no creds")
+	}
+	_, err = c.AccessToken("c")
+	require.EqualError(t, err, "failed to find default credentials: no creds")
+}
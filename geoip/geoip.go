@@ -0,0 +1,77 @@
+// Package geoip contains functions for looking up IP addresses in MaxMind DB
+// (MMDB) files, such as the GeoIP2 and GeoLite2 databases
+package geoip
+
+import (
+	"fmt"
+	"io/fs"
+	"net"
+
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// Open reads the MMDB file at the given path from fsys.
+func Open(fsys fs.FS, name string) (*maxminddb.Reader, error) {
+	b, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", name, err)
+	}
+
+	db, err := maxminddb.FromBytes(b)
+	if err != nil {
+		return nil, fmt.Errorf("open MMDB %s: %w", name, err)
+	}
+
+	return db, nil
+}
+
+// Lookup returns the full record for the given IP address. An empty map is
+// returned when the database has no record for the address.
+func Lookup(db *maxminddb.Reader, ip string) (map[string]interface{}, error) {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return nil, fmt.Errorf("invalid IP address %q", ip)
+	}
+
+	record := map[string]interface{}{}
+
+	err := db.Lookup(addr, &record)
+	if err != nil {
+		return nil, fmt.Errorf("lookup %s: %w", ip, err)
+	}
+
+	return record, nil
+}
+
+// Country returns the ISO 3166-1 country code for the given IP address, or an
+// empty string if no country is known. The registered country is used when
+// the record has no physical country (as for anycast addresses).
+func Country(db *maxminddb.Reader, ip string) (string, error) {
+	record, err := Lookup(db, ip)
+	if err != nil {
+		return "", err
+	}
+
+	for _, k := range []string{"country", "registered_country"} {
+		if c, ok := record[k].(map[string]interface{}); ok {
+			if code, ok := c["iso_code"].(string); ok {
+				return code, nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// ASN returns the autonomous system number for the given IP address, or 0 if
+// no ASN is known.
+func ASN(db *maxminddb.Reader, ip string) (uint64, error) {
+	record, err := Lookup(db, ip)
+	if err != nil {
+		return 0, err
+	}
+
+	asn, _ := record["autonomous_system_number"].(uint64)
+
+	return asn, nil
+}
@@ -0,0 +1,65 @@
+package geoip
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	db, err := Open(os.DirFS("testdata"), "test.mmdb")
+	require.NoError(t, err)
+
+	record, err := Lookup(db, "81.2.69.142")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"country": map[string]interface{}{
+			"iso_code": "GB",
+			"names":    map[string]interface{}{"en": "United Kingdom"},
+		},
+		"autonomous_system_number":       uint64(20712),
+		"autonomous_system_organization": "Andrews & Arnold Ltd",
+	}, record)
+
+	record, err = Lookup(db, "10.0.0.1")
+	require.NoError(t, err)
+	assert.Empty(t, record)
+
+	_, err = Lookup(db, "not an IP")
+	require.Error(t, err)
+
+	_, err = Open(os.DirFS("testdata"), "bogus.mmdb")
+	require.Error(t, err)
+}
+
+func TestCountry(t *testing.T) {
+	db, err := Open(os.DirFS("testdata"), "test.mmdb")
+	require.NoError(t, err)
+
+	c, err := Country(db, "216.160.83.58")
+	require.NoError(t, err)
+	assert.Equal(t, "US", c)
+
+	c, err = Country(db, "127.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, "", c)
+}
+
+func TestASN(t *testing.T) {
+	db, err := Open(os.DirFS("testdata"), "test.mmdb")
+	require.NoError(t, err)
+
+	asn, err := ASN(db, "81.2.69.1")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(20712), asn)
+
+	asn, err = ASN(db, "127.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), asn)
+
+	// the test database is IPv4-only
+	_, err = ASN(db, "::1")
+	require.Error(t, err)
+}
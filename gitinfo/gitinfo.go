@@ -0,0 +1,130 @@
+// Package gitinfo reads metadata, such as the current commit and branch, from
+// git repositories.
+package gitinfo
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hairyhenderson/go-git/v5"
+	"github.com/hairyhenderson/go-git/v5/plumbing"
+)
+
+// Repo is a git repository
+type Repo struct {
+	repo *git.Repository
+}
+
+// Open opens the git repository containing the given directory (which may be
+// the repository's root, or any directory within it)
+func Open(dir string) (*Repo, error) {
+	r, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{
+		DetectDotGit:          true,
+		EnableDotGitCommonDir: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open git repository at %q: %w", dir, err)
+	}
+
+	return &Repo{repo: r}, nil
+}
+
+// Commit returns the full SHA of the commit HEAD points to
+func (r *Repo) Commit() (string, error) {
+	head, err := r.repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+
+	return head.Hash().String(), nil
+}
+
+// Branch returns the name of the checked-out branch, or an empty string when
+// HEAD is detached
+func (r *Repo) Branch() (string, error) {
+	head, err := r.repo.Reference(plumbing.HEAD, false)
+	if err != nil {
+		return "", fmt.Errorf("failed to read HEAD: %w", err)
+	}
+
+	if head.Type() != plumbing.SymbolicReference || !head.Target().IsBranch() {
+		return "", nil
+	}
+
+	return head.Target().Short(), nil
+}
+
+// Tags returns the (sorted) names of the tags that point to the commit HEAD
+// points to. Both lightweight and annotated tags are included.
+func (r *Repo) Tags() ([]string, error) {
+	head, err := r.repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+
+	iter, err := r.repo.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	tags := []string{}
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		hash := ref.Hash()
+
+		// annotated tags point to tag objects, which point to commits
+		tag, err := r.repo.TagObject(hash)
+		switch {
+		case err == nil:
+			c, err := tag.Commit()
+			if err != nil {
+				// tags of other objects (like trees) can't match
+				return nil
+			}
+
+			hash = c.Hash
+		case !errors.Is(err, plumbing.ErrObjectNotFound):
+			return err
+		}
+
+		if hash == head.Hash() {
+			tags = append(tags, ref.Name().Short())
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	sort.Strings(tags)
+
+	return tags, nil
+}
+
+// Dirty returns true when the working tree has uncommitted changes to
+// tracked files, either staged or unstaged. Untracked files are ignored,
+// as with git describe --dirty.
+func (r *Repo) Dirty() (bool, error) {
+	wt, err := r.repo.Worktree()
+	if err != nil {
+		return false, fmt.Errorf("failed to open worktree: %w", err)
+	}
+
+	status, err := wt.Status()
+	if err != nil {
+		return false, fmt.Errorf("failed to get worktree status: %w", err)
+	}
+
+	for _, s := range status {
+		if s.Worktree == git.Untracked {
+			continue
+		}
+
+		if s.Staging != git.Unmodified || s.Worktree != git.Unmodified {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,106 @@
+package gitinfo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hairyhenderson/go-git/v5"
+	"github.com/hairyhenderson/go-git/v5/plumbing"
+	"github.com/hairyhenderson/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRepo(t *testing.T) (string, *git.Repository, plumbing.Hash) {
+	t.Helper()
+
+	dir := t.TempDir()
+	r, err := git.PlainInitWithOptions(dir, &git.PlainInitOptions{
+		InitOptions: git.InitOptions{DefaultBranch: plumbing.NewBranchReferenceName("main")},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("hello"), 0o600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0o755))
+
+	wt, err := r.Worktree()
+	require.NoError(t, err)
+
+	_, err = wt.Add("a.txt")
+	require.NoError(t, err)
+
+	sig := &object.Signature{Name: "me", Email: "me@example.com", When: time.Unix(0, 0)}
+	hash, err := wt.Commit("initial commit", &git.CommitOptions{Author: sig})
+	require.NoError(t, err)
+
+	return dir, r, hash
+}
+
+func TestRepo(t *testing.T) {
+	dir, r, hash := setupRepo(t)
+
+	// open from a subdirectory
+	repo, err := Open(filepath.Join(dir, "sub"))
+	require.NoError(t, err)
+
+	c, err := repo.Commit()
+	require.NoError(t, err)
+	assert.Equal(t, hash.String(), c)
+
+	b, err := repo.Branch()
+	require.NoError(t, err)
+	assert.Equal(t, "main", b)
+
+	tags, err := repo.Tags()
+	require.NoError(t, err)
+	assert.Empty(t, tags)
+
+	_, err = r.CreateTag("v1.0.0", hash, nil)
+	require.NoError(t, err)
+	_, err = r.CreateTag("v1.0.0-annotated", hash, &git.CreateTagOptions{
+		Tagger:  &object.Signature{Name: "me", Email: "me@example.com", When: time.Unix(0, 0)},
+		Message: "release",
+	})
+	require.NoError(t, err)
+
+	tags, err = repo.Tags()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1.0.0", "v1.0.0-annotated"}, tags)
+
+	dirty, err := repo.Dirty()
+	require.NoError(t, err)
+	assert.False(t, dirty)
+
+	// untracked files don't count
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "new.txt"), []byte("new"), 0o600))
+
+	dirty, err = repo.Dirty()
+	require.NoError(t, err)
+	assert.False(t, dirty)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("changed"), 0o600))
+
+	dirty, err = repo.Dirty()
+	require.NoError(t, err)
+	assert.True(t, dirty)
+
+	// detached HEAD
+	wt, err := r.Worktree()
+	require.NoError(t, err)
+	require.NoError(t, wt.Checkout(&git.CheckoutOptions{Hash: hash, Force: true}))
+
+	b, err = repo.Branch()
+	require.NoError(t, err)
+	assert.Empty(t, b)
+
+	c, err = repo.Commit()
+	require.NoError(t, err)
+	assert.Equal(t, hash.String(), c)
+}
+
+func TestOpen_NotARepo(t *testing.T) {
+	_, err := Open(t.TempDir())
+	require.Error(t, err)
+}
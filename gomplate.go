@@ -12,6 +12,7 @@ import (
 	"text/template"
 	"time"
 
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
 	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
 )
 
@@ -34,6 +35,22 @@ func Run(ctx context.Context, cfg *Config) error {
 		ctx = SetExperimental(ctx)
 	}
 
+	if cfg.RandomSeed != "" {
+		ctx = config.WithRandomSeed(ctx, cfg.RandomSeed)
+	}
+
+	if cfg.AllowWrite {
+		ctx = config.WithWriteAllowed(ctx)
+	}
+
+	if cfg.MaxTemplateDepth > 0 {
+		ctx = config.WithMaxTemplateDepth(ctx, cfg.MaxTemplateDepth)
+	}
+
+	if cfg.Sprig {
+		ctx = config.WithSprig(ctx)
+	}
+
 	// bind plugins from the configuration to the funcMap
 	funcMap := template.FuncMap{}
 	err = bindPlugins(ctx, cfg, funcMap)
@@ -51,9 +68,22 @@ func Run(ctx context.Context, cfg *Config) error {
 		ctx = datafs.ContextWithFSProvider(ctx, DefaultFSProvider)
 	}
 
+	ctx = withWriteOptions(ctx, writeOptions{atomic: cfg.Atomic, fsync: cfg.Fsync})
+
+	var dr *dryRun
+	if cfg.DryRun || cfg.Diff {
+		dr = &dryRun{diff: cfg.Diff}
+		ctx = withDryRun(ctx, dr)
+	}
+
 	// extract the rendering options from the config
 	opts := optionsFromConfig(cfg)
 	opts.Funcs = funcMap
+
+	opts.Policies, err = readPolicies(ctx, cfg.Policies)
+	if err != nil {
+		return err
+	}
 	tr := newRenderer(opts)
 
 	start := time.Now()
@@ -76,6 +106,10 @@ func Run(ctx context.Context, cfg *Config) error {
 		return err
 	}
 
+	if dr != nil {
+		return dr.report(ctx, cfg.Stdout)
+	}
+
 	return nil
 }
 
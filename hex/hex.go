@@ -0,0 +1,49 @@
+// Package hex contains hexadecimal encoding/decoding functions
+package hex
+
+import (
+	"encoding/hex"
+	"strings"
+)
+
+// Encode - Encode data as lower-case hexadecimal, with the given separator
+// (which may be empty) between each byte, as in "de:ad:be:ef"
+func Encode(sep string, in []byte) string {
+	if sep == "" || len(in) == 0 {
+		return hex.EncodeToString(in)
+	}
+
+	var sb strings.Builder
+
+	sb.Grow(len(in)*(2+len(sep)) - len(sep))
+
+	for i, b := range in {
+		if i > 0 {
+			sb.WriteString(sep)
+		}
+
+		sb.WriteString(hex.EncodeToString([]byte{b}))
+	}
+
+	return sb.String()
+}
+
+// Decode - Decode a hexadecimal string. Decoding is case-insensitive, and an
+// optional "0x" prefix, whitespace, and ':' or '-' separators are ignored.
+func Decode(in string) ([]byte, error) {
+	s := strings.TrimSpace(in)
+	if len(s) > 1 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		s = s[2:]
+	}
+
+	s = strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', '\r', '\n', ':', '-':
+			return -1
+		}
+
+		return r
+	}, s)
+
+	return hex.DecodeString(s)
+}
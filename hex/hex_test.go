@@ -0,0 +1,42 @@
+package hex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	assert.Equal(t, "", Encode("", nil))
+	assert.Equal(t, "", Encode(":", nil))
+	assert.Equal(t, "deadbeef", Encode("", []byte{0xde, 0xad, 0xbe, 0xef}))
+	assert.Equal(t, "de:ad:be:ef", Encode(":", []byte{0xde, 0xad, 0xbe, 0xef}))
+	assert.Equal(t, "00 0f", Encode(" ", []byte{0x00, 0x0f}))
+	assert.Equal(t, "66", Encode(":", []byte("f")))
+}
+
+func TestDecode(t *testing.T) {
+	testdata := []struct {
+		in       string
+		expected []byte
+	}{
+		{"", []byte{}},
+		{"deadbeef", []byte{0xde, 0xad, 0xbe, 0xef}},
+		{"DE:AD:BE:EF", []byte{0xde, 0xad, 0xbe, 0xef}},
+		{"0xDEADbeef", []byte{0xde, 0xad, 0xbe, 0xef}},
+		{" de-ad be\nef ", []byte{0xde, 0xad, 0xbe, 0xef}},
+	}
+
+	for _, d := range testdata {
+		out, err := Decode(d.in)
+		require.NoError(t, err)
+		assert.Equal(t, d.expected, out, d.in)
+	}
+
+	_, err := Decode("abc")
+	require.Error(t, err)
+
+	_, err = Decode("zz")
+	require.Error(t, err)
+}
@@ -0,0 +1,171 @@
+// Package archive contains functions for reading files from tar and zip
+// archives.
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/bzip2"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/hairyhenderson/gomplate/v4/compress"
+)
+
+// List returns the names of the regular files in the archive, in the order
+// they're stored. The archive can be a zip file, or a tar file that is either
+// uncompressed or compressed with gzip, bzip2, or zstd.
+func List(b []byte) ([]string, error) {
+	names := []string{}
+
+	err := walk(b, func(name string, _ func() ([]byte, error)) (bool, error) {
+		names = append(names, name)
+
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// Read returns the content of the named file in the archive
+func Read(b []byte, name string) ([]byte, error) {
+	name = cleanName(name)
+
+	var out []byte
+
+	found := false
+
+	err := walk(b, func(n string, read func() ([]byte, error)) (bool, error) {
+		if n != name {
+			return false, nil
+		}
+
+		found = true
+
+		var err error
+		out, err = read()
+
+		return true, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, fmt.Errorf("file %q not found in archive: %w", name, fs.ErrNotExist)
+	}
+
+	return out, nil
+}
+
+// walkFunc is called for each regular file in an archive, with a function to
+// read its content. Returning true stops the walk.
+type walkFunc func(name string, read func() ([]byte, error)) (bool, error)
+
+func walk(b []byte, f walkFunc) error {
+	switch {
+	case bytes.HasPrefix(b, []byte("PK\x03\x04")), bytes.HasPrefix(b, []byte("PK\x05\x06")):
+		return walkZip(b, f)
+	case bytes.HasPrefix(b, []byte{0x1f, 0x8b}):
+		tb, err := compress.Gunzip(b)
+		if err != nil {
+			return err
+		}
+
+		return walkTar(tb, f)
+	case bytes.HasPrefix(b, []byte{0x28, 0xb5, 0x2f, 0xfd}):
+		tb, err := compress.Unzstd(b)
+		if err != nil {
+			return err
+		}
+
+		return walkTar(tb, f)
+	case bytes.HasPrefix(b, []byte("BZh")):
+		tb, err := io.ReadAll(bzip2.NewReader(bytes.NewReader(b)))
+		if err != nil {
+			return fmt.Errorf("failed to read bzip2 data: %w", err)
+		}
+
+		return walkTar(tb, f)
+	default:
+		return walkTar(b, f)
+	}
+}
+
+func walkZip(b []byte, f walkFunc) error {
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return fmt.Errorf("failed to read zip archive: %w", err)
+	}
+
+	for _, zf := range zr.File {
+		if !zf.Mode().IsRegular() {
+			continue
+		}
+
+		done, err := f(cleanName(zf.Name), func() ([]byte, error) {
+			rc, err := zf.Open()
+			if err != nil {
+				return nil, err
+			}
+			defer rc.Close()
+
+			return io.ReadAll(rc)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to read %q from zip archive: %w", zf.Name, err)
+		}
+
+		if done {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func walkTar(b []byte, f walkFunc) error {
+	tr := tar.NewReader(bytes.NewReader(b))
+
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to read tar archive: %w", err)
+		}
+
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		done, err := f(cleanName(hdr.Name), func() ([]byte, error) {
+			return io.ReadAll(tr)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to read %q from tar archive: %w", hdr.Name, err)
+		}
+
+		if done {
+			return nil
+		}
+	}
+}
+
+// cleanName normalizes a file name, so that "./foo//bar" and "foo/bar" refer
+// to the same file
+func cleanName(name string) string {
+	name = path.Clean("/" + strings.TrimPrefix(name, "./"))
+
+	return strings.TrimPrefix(name, "/")
+}
@@ -0,0 +1,118 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"io/fs"
+	"testing"
+
+	"github.com/hairyhenderson/gomplate/v4/compress"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testFiles = []struct {
+	name    string
+	content string
+}{
+	{"./README.md", "# hello"},
+	{"bin/", ""},
+	{"bin/app", "binary"},
+}
+
+func makeTar(t *testing.T) []byte {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+
+	for _, f := range testFiles {
+		hdr := &tar.Header{Name: f.name, Mode: 0o644, Size: int64(len(f.content))}
+		if f.name[len(f.name)-1] == '/' {
+			hdr.Typeflag = tar.TypeDir
+			hdr.Mode = 0o755
+		}
+
+		require.NoError(t, tw.WriteHeader(hdr))
+		_, err := tw.Write([]byte(f.content))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, tw.Close())
+
+	return buf.Bytes()
+}
+
+func makeZip(t *testing.T) []byte {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+
+	for _, f := range testFiles {
+		w, err := zw.Create(f.name)
+		require.NoError(t, err)
+
+		_, err = w.Write([]byte(f.content))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, zw.Close())
+
+	return buf.Bytes()
+}
+
+func TestListAndRead(t *testing.T) {
+	t.Parallel()
+
+	tb := makeTar(t)
+
+	tgz, err := compress.Gzip(compress.DefaultLevel, tb)
+	require.NoError(t, err)
+
+	tzst, err := compress.Zstd(compress.DefaultLevel, tb)
+	require.NoError(t, err)
+
+	archives := map[string][]byte{
+		"tar":     tb,
+		"tar.gz":  tgz,
+		"tar.zst": tzst,
+		"zip":     makeZip(t),
+	}
+
+	for format, b := range archives {
+		names, err := List(b)
+		require.NoError(t, err, format)
+		assert.Equal(t, []string{"README.md", "bin/app"}, names, format)
+
+		out, err := Read(b, "README.md")
+		require.NoError(t, err, format)
+		assert.Equal(t, "# hello", string(out), format)
+
+		out, err = Read(b, "./bin//app")
+		require.NoError(t, err, format)
+		assert.Equal(t, "binary", string(out), format)
+
+		_, err = Read(b, "bin")
+		require.ErrorIs(t, err, fs.ErrNotExist, format)
+	}
+}
+
+func TestInvalidArchives(t *testing.T) {
+	t.Parallel()
+
+	_, err := List([]byte("this is not an archive, but it is long enough to not be mistaken for an empty one"))
+	require.Error(t, err)
+
+	_, err = List([]byte("PK\x03\x04 truncated"))
+	require.Error(t, err)
+
+	_, err = List([]byte{0x1f, 0x8b, 0x00})
+	require.Error(t, err)
+
+	// an empty tar archive
+	names, err := List(nil)
+	require.NoError(t, err)
+	assert.Empty(t, names)
+}
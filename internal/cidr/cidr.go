@@ -141,3 +141,32 @@ func NextSubnet(network netip.Prefix, prefixLen int) (netip.Prefix, bool) {
 	}
 	return next, false
 }
+
+// RangeSize returns the number of addresses in the range
+func RangeSize(r netipx.IPRange) *big.Int {
+	from, _ := ipToInt(r.From())
+	to, _ := ipToInt(r.To())
+
+	return to.Sub(to, from).Add(to, big.NewInt(1))
+}
+
+// RangeAddr returns the address at the given (0-based) offset within the
+// range. Negative offsets count back from the end of the range, so -1 is the
+// last address. The second return value is false when the offset is outside
+// the range.
+func RangeAddr(r netipx.IPRange, offset *big.Int) (netip.Addr, bool) {
+	size := RangeSize(r)
+
+	n := new(big.Int).Set(offset)
+	if n.Sign() < 0 {
+		n.Add(n, size)
+	}
+
+	if n.Sign() < 0 || n.Cmp(size) >= 0 {
+		return netip.Addr{}, false
+	}
+
+	from, bits := ipToInt(r.From())
+
+	return intToIP(from.Add(from, n), bits), true
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go4.org/netipx"
 )
 
 func TestSubnetBig(t *testing.T) {
@@ -202,3 +203,40 @@ func TestPreviousNextSubnet(t *testing.T) {
 		assert.Equal(t, c1, n1)
 	}
 }
+
+func TestRangeSizeAndAddr(t *testing.T) {
+	r := netipx.MustParseIPRange("10.0.0.250-10.0.1.5")
+	assert.Equal(t, big.NewInt(12), RangeSize(r))
+
+	testCases := []struct {
+		offset int64
+		out    string
+	}{
+		{0, "10.0.0.250"},
+		{6, "10.0.1.0"},
+		{11, "10.0.1.5"},
+		{-1, "10.0.1.5"},
+		{-12, "10.0.0.250"},
+		{12, ""},
+		{-13, ""},
+	}
+
+	for _, tc := range testCases {
+		addr, ok := RangeAddr(r, big.NewInt(tc.offset))
+		if tc.out == "" {
+			assert.False(t, ok, tc.offset)
+			continue
+		}
+
+		assert.True(t, ok, tc.offset)
+		assert.Equal(t, tc.out, addr.String())
+	}
+
+	r6 := netipx.RangeOfPrefix(netip.MustParsePrefix("2001:db8::/32"))
+	size, _ := new(big.Int).SetString("79228162514264337593543950336", 10)
+	assert.Equal(t, size, RangeSize(r6))
+
+	addr, ok := RangeAddr(r6, big.NewInt(-1))
+	assert.True(t, ok)
+	assert.Equal(t, "2001:db8:ffff:ffff:ffff:ffff:ffff:ffff", addr.String())
+}
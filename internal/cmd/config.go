@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"os"
 	"path"
+	"strconv"
 	"strings"
 	"time"
 
@@ -167,6 +168,22 @@ func cobraConfig(cmd *cobra.Command, args []string) (cfg *gomplate.Config, err e
 	if err != nil {
 		return nil, err
 	}
+	cfg.DryRun, err = getBool(cmd, "dry-run")
+	if err != nil {
+		return nil, err
+	}
+	cfg.Diff, err = getBool(cmd, "diff")
+	if err != nil {
+		return nil, err
+	}
+	cfg.Atomic, err = getBool(cmd, "atomic")
+	if err != nil {
+		return nil, err
+	}
+	cfg.Fsync, err = getBool(cmd, "fsync")
+	if err != nil {
+		return nil, err
+	}
 	cfg.Experimental, err = getBool(cmd, "experimental")
 	if err != nil {
 		return nil, err
@@ -186,6 +203,36 @@ func cobraConfig(cmd *cobra.Command, args []string) (cfg *gomplate.Config, err e
 		return nil, err
 	}
 
+	cfg.Strict, err = getBool(cmd, "strict")
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.RandomSeed, err = getString(cmd, "random-seed")
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.AllowWrite, err = getBool(cmd, "allow-write")
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Sprig, err = getBool(cmd, "sprig")
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.MaxTemplateDepth, err = getInt(cmd, "max-template-depth")
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Policies, err = getStringSlice(cmd, "policy")
+	if err != nil {
+		return nil, err
+	}
+
 	ds, err := getStringSlice(cmd, "datasource")
 	if err != nil {
 		return nil, err
@@ -215,6 +262,25 @@ func cobraConfig(cmd *cobra.Command, args []string) (cfg *gomplate.Config, err e
 	if err != nil {
 		return nil, err
 	}
+
+	fa, err := getStringSlice(cmd, "func-alias")
+	if err != nil {
+		return nil, err
+	}
+	err = ParseFuncAliasFlags(cfg, fa)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.AllowFuncs, err = getStringSlice(cmd, "allow-func")
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.DenyFuncs, err = getStringSlice(cmd, "deny-func")
+	if err != nil {
+		return nil, err
+	}
 	return cfg, nil
 }
 
@@ -239,6 +305,13 @@ func getBool(cmd *cobra.Command, flag string) (b bool, err error) {
 	return b, err
 }
 
+func getInt(cmd *cobra.Command, flag string) (i int, err error) {
+	if cmd.Flag(flag) != nil && cmd.Flag(flag).Changed {
+		i, err = cmd.Flags().GetInt(flag)
+	}
+	return i, err
+}
+
 // process --include flags - these are analogous to specifying --exclude '*',
 // then the inverse of the --include options.
 func processIncludes(includes, excludes []string) []string {
@@ -273,6 +346,26 @@ func applyEnvVars(_ context.Context, cfg *gomplate.Config) (*gomplate.Config, er
 		cfg.Experimental = true
 	}
 
+	if cfg.RandomSeed == "" {
+		cfg.RandomSeed = env.Getenv("GOMPLATE_RANDOM_SEED")
+	}
+
+	if !cfg.AllowWrite && conv.ToBool(env.Getenv("GOMPLATE_ALLOW_WRITE", "false")) {
+		cfg.AllowWrite = true
+	}
+
+	if !cfg.Sprig && conv.ToBool(env.Getenv("GOMPLATE_SPRIG", "false")) {
+		cfg.Sprig = true
+	}
+
+	if d := env.Getenv("GOMPLATE_MAX_TEMPLATE_DEPTH"); cfg.MaxTemplateDepth == 0 && d != "" {
+		depth, err := strconv.Atoi(d)
+		if err != nil {
+			return nil, fmt.Errorf("GOMPLATE_MAX_TEMPLATE_DEPTH set to invalid value %q: %w", d, err)
+		}
+		cfg.MaxTemplateDepth = depth
+	}
+
 	if cfg.LDelim == "" {
 		cfg.LDelim = env.Getenv("GOMPLATE_LEFT_DELIM")
 	}
@@ -304,6 +397,22 @@ func postExecInput(cfg *gomplate.Config) io.Reader {
 	return os.Stdin
 }
 
+// ParseFuncAliasFlags - sets the FuncAliases field from the alias=name format
+// flags as provided at the command-line
+func ParseFuncAliasFlags(c *gomplate.Config, aliases []string) error {
+	for _, a := range aliases {
+		alias, name, ok := strings.Cut(a, "=")
+		if !ok || alias == "" || name == "" {
+			return fmt.Errorf("function alias %q must be in alias=name form", a)
+		}
+		if c.FuncAliases == nil {
+			c.FuncAliases = map[string]string{}
+		}
+		c.FuncAliases[alias] = name
+	}
+	return nil
+}
+
 // ParsePluginFlags - sets the Plugins field from the
 // key=value format flags as provided at the command-line
 func ParsePluginFlags(c *gomplate.Config, plugins []string) error {
@@ -87,6 +87,7 @@ func TestLoadConfig(t *testing.T) {
 	cmd.Flags().String("left-delim", "{{", "...")
 	cmd.Flags().String("right-delim", "}}", "...")
 	cmd.Flags().Bool("exec-pipe", false, "...")
+	cmd.Flags().StringSlice("policy", nil, "...")
 	cmd.ParseFlags(nil)
 
 	out, err := loadConfig(ctx, cmd, cmd.Flags().Args())
@@ -121,6 +122,18 @@ func TestLoadConfig(t *testing.T) {
 	}
 	require.NoError(t, err)
 	assert.EqualValues(t, expected, out)
+
+	cmd.ParseFlags([]string{"--in", "foo", "--exec-pipe=false", "--policy", "a.rego", "--policy", "b.rego"})
+	out, err = loadConfig(ctx, cmd, cmd.Flags().Args())
+	expected = &gomplate.Config{
+		Input:    "foo",
+		Policies: []string{"a.rego", "b.rego"},
+		Stdin:    stdin,
+		Stdout:   out.Stdout,
+		Stderr:   stderr,
+	}
+	require.NoError(t, err)
+	assert.EqualValues(t, expected, out)
 }
 
 func TestPostExecInput(t *testing.T) {
@@ -240,6 +253,12 @@ func TestApplyEnvVars(t *testing.T) {
 		require.Error(t, err)
 	})
 
+	t.Run("invalid GOMPLATE_MAX_TEMPLATE_DEPTH", func(t *testing.T) {
+		t.Setenv("GOMPLATE_MAX_TEMPLATE_DEPTH", "bogus")
+		_, err := applyEnvVars(context.Background(), &gomplate.Config{})
+		require.Error(t, err)
+	})
+
 	data := []struct {
 		input, expected *gomplate.Config
 		env             string
@@ -275,6 +294,46 @@ func TestApplyEnvVars(t *testing.T) {
 			&gomplate.Config{Experimental: true},
 			"GOMPLATE_EXPERIMENTAL", "false",
 		},
+		{
+			&gomplate.Config{},
+			&gomplate.Config{RandomSeed: "abc"},
+			"GOMPLATE_RANDOM_SEED", "abc",
+		},
+		{
+			&gomplate.Config{RandomSeed: "def"},
+			&gomplate.Config{RandomSeed: "def"},
+			"GOMPLATE_RANDOM_SEED", "abc",
+		},
+		{
+			&gomplate.Config{},
+			&gomplate.Config{AllowWrite: true},
+			"GOMPLATE_ALLOW_WRITE", "true",
+		},
+		{
+			&gomplate.Config{AllowWrite: true},
+			&gomplate.Config{AllowWrite: true},
+			"GOMPLATE_ALLOW_WRITE", "false",
+		},
+		{
+			&gomplate.Config{},
+			&gomplate.Config{Sprig: true},
+			"GOMPLATE_SPRIG", "true",
+		},
+		{
+			&gomplate.Config{Sprig: true},
+			&gomplate.Config{Sprig: true},
+			"GOMPLATE_SPRIG", "false",
+		},
+		{
+			&gomplate.Config{},
+			&gomplate.Config{MaxTemplateDepth: 20},
+			"GOMPLATE_MAX_TEMPLATE_DEPTH", "20",
+		},
+		{
+			&gomplate.Config{MaxTemplateDepth: 5},
+			&gomplate.Config{MaxTemplateDepth: 5},
+			"GOMPLATE_MAX_TEMPLATE_DEPTH", "20",
+		},
 		{
 			&gomplate.Config{},
 			&gomplate.Config{LDelim: "--"},
@@ -424,6 +483,23 @@ func TestParsePluginFlags(t *testing.T) {
 	assert.EqualValues(t, &gomplate.Config{Plugins: map[string]gomplate.PluginConfig{"foo": {Cmd: "bar"}}}, cfg)
 }
 
+func TestParseFuncAliasFlags(t *testing.T) {
+	t.Parallel()
+	cfg := &gomplate.Config{}
+	err := ParseFuncAliasFlags(cfg, nil)
+	require.NoError(t, err)
+	assert.Nil(t, cfg.FuncAliases)
+
+	err = ParseFuncAliasFlags(cfg, []string{"up=strings.ToUpper", "s=strings"})
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]string{"up": "strings.ToUpper", "s": "strings"}, cfg.FuncAliases)
+
+	for _, in := range []string{"up", "=strings", "up="} {
+		err = ParseFuncAliasFlags(&gomplate.Config{}, []string{in})
+		require.Error(t, err, in)
+	}
+}
+
 func TestParseDatasourceArgNoAlias(t *testing.T) {
 	alias, ds, err := parseDatasourceArg("foo.json")
 	require.NoError(t, err)
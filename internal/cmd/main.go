@@ -59,6 +59,23 @@ func postRunExec(ctx context.Context, args []string, stdin io.Reader, stdout, st
 	return nil
 }
 
+// runWatch - renders the templates, and re-renders them whenever they (or
+// their datasources) change, until the context is cancelled
+func runWatch(ctx context.Context, cfg *gomplate.Config, stderr io.Writer) error {
+	if len(cfg.PostExec) > 0 || cfg.ExecPipe {
+		return fmt.Errorf("--watch can't be used with a post-run command")
+	}
+
+	t, err := watchTargetsForConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	return watch(ctx, t, func(ctx context.Context) error {
+		return gomplate.Run(ctx, cfg)
+	}, stderr)
+}
+
 // optionalExecArgs - implements cobra.PositionalArgs. Allows extra args following
 // a '--', but not otherwise.
 func optionalExecArgs(cmd *cobra.Command, args []string) error {
@@ -97,6 +114,13 @@ func NewGomplateCmd(stderr io.Writer) *cobra.Command {
 				slog.String("build", version.GitCommit),
 			)
 
+			if w, _ := cmd.Flags().GetBool("watch"); w {
+				cmd.SilenceErrors = true
+				cmd.SilenceUsage = true
+
+				return runWatch(ctx, cfg, stderr)
+			}
+
 			// run the main command
 			err = gomplate.Run(ctx, cfg)
 			cmd.SilenceErrors = true
@@ -111,6 +135,11 @@ func NewGomplateCmd(stderr io.Writer) *cobra.Command {
 				return err
 			}
 
+			if cfg.DryRun || cfg.Diff {
+				// nothing was written, so there's nothing for the command to use
+				return nil
+			}
+
 			return postRunExec(ctx, cfg.PostExec, postExecReader, cmd.OutOrStdout(), cmd.ErrOrStderr())
 		},
 		Args: optionalExecArgs,
@@ -146,8 +175,16 @@ func InitFlags(command *cobra.Command) {
 	command.Flags().String("output-map", "", "Template `string` to map the input file to an output path")
 	command.Flags().String("chmod", "", "set the mode for output file(s). Omit to inherit from input file(s)")
 
+	command.Flags().Bool("atomic", false, "write output files to a temporary file, and rename it into place when complete, so partly-written files are never seen")
+	command.Flags().Bool("fsync", false, "sync output files to disk before closing them")
+
 	command.Flags().Bool("exec-pipe", false, "pipe the output to the post-run exec command")
 
+	command.Flags().Bool("dry-run", false, "render the templates without writing the output files, and list the files which would change (exits with an error when there are changes)")
+	command.Flags().Bool("diff", false, "like --dry-run, but print a unified diff of the changes to the output files")
+
+	command.Flags().Bool("watch", false, "watch the input templates and file-based datasources, and re-render when they change")
+
 	// these are only set for the help output - these defaults aren't actually used
 	ldDefault := env.Getenv("GOMPLATE_LEFT_DELIM", "{{")
 	rdDefault := env.Getenv("GOMPLATE_RIGHT_DELIM", "}}")
@@ -155,6 +192,21 @@ func InitFlags(command *cobra.Command) {
 	command.Flags().String("right-delim", rdDefault, "override the default right-`delimiter` [$GOMPLATE_RIGHT_DELIM]")
 
 	command.Flags().String("missing-key", "error", "Control the behavior during execution if a map is indexed with a key that is not present in the map. error (default) - return an error, zero - fallback to zero value, default/invalid - print <no value>")
+	command.Flags().Bool("strict", false, "fail when a template outputs a missing value (such as a nil datasource field or a missing key) instead of printing <no value>")
+
+	command.Flags().String("random-seed", "", "seed the random functions with this `value`, so they produce the same values on every run [$GOMPLATE_RANDOM_SEED]")
+
+	command.Flags().StringSlice("policy", nil, "Rego policy `file` whose deny rules are evaluated against the context before rendering. Can be specified multiple times")
+
+	command.Flags().Bool("sprig", false, "add Sprig-compatible functions, for rendering templates written for Sprig or Helm [$GOMPLATE_SPRIG]")
+
+	command.Flags().StringSlice("func-alias", nil, "add an alias for a function or namespace, in alias=name form (e.g. up=strings.ToUpper). Can be specified multiple times")
+	command.Flags().StringSlice("allow-func", nil, "only allow these namespaces and top-level `functions` to be used. Can be specified multiple times")
+	command.Flags().StringSlice("deny-func", nil, "deny these namespaces (with their aliases) and top-level `functions` (e.g. env, file). Can be specified multiple times")
+
+	command.Flags().Int("max-template-depth", 0, "maximum `depth` of nested template executions with tmpl.Exec and similar functions (default 100) [$GOMPLATE_MAX_TEMPLATE_DEPTH]")
+
+	command.Flags().Bool("allow-write", false, "allow templates to write files with file.Write [$GOMPLATE_ALLOW_WRITE]")
 
 	command.Flags().Bool("experimental", false, "enable experimental features [$GOMPLATE_EXPERIMENTAL]")
 
@@ -173,6 +225,8 @@ func Main(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io
 
 	command := NewGomplateCmd(stderr)
 	InitFlags(command)
+	command.AddCommand(NewServeCmd(stderr))
+	command.CompletionOptions.DisableDefaultCmd = true
 	command.SetArgs(args)
 	command.SetIn(stdin)
 	command.SetOut(stdout)
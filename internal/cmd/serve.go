@@ -0,0 +1,359 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hairyhenderson/gomplate/v4"
+	"github.com/hairyhenderson/xignore"
+	"github.com/spf13/cobra"
+)
+
+// NewServeCmd - the serve subcommand, which serves rendered templates over
+// HTTP
+func NewServeCmd(stderr io.Writer) *cobra.Command {
+	serveCmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve rendered templates over HTTP",
+		Long: `Serve the templates in the input directory (the current directory by default)
+over HTTP, rendering them when they're requested. Request paths are mapped to
+files in the input directory, and directories are served by their index.html.
+
+With --watch, the whole input directory is rendered up-front, and re-rendered
+whenever the templates or their datasources change.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			level := slog.LevelWarn
+			if v, _ := cmd.Flags().GetBool("verbose"); v {
+				level = slog.LevelDebug
+			}
+			initLogger(stderr, level)
+
+			ctx := cmd.Context()
+
+			cfg, err := loadConfig(ctx, cmd, args)
+			if err != nil {
+				return err
+			}
+
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+
+			addr, _ := cmd.Flags().GetString("addr")
+
+			ln, err := net.Listen("tcp", addr)
+			if err != nil {
+				return fmt.Errorf("couldn't listen on %s: %w", addr, err)
+			}
+
+			w, _ := cmd.Flags().GetBool("watch")
+
+			return serve(ctx, cfg, ln, w, stderr)
+		},
+	}
+
+	serveCmd.Flags().String("addr", "localhost:8080", "`address` to listen on")
+
+	InitFlags(serveCmd)
+
+	// these don't make sense when serving
+	for _, name := range []string{"file", "in", "out", "output-dir", "chmod", "exec-pipe", "dry-run", "diff", "atomic", "fsync"} {
+		_ = serveCmd.Flags().MarkHidden(name)
+	}
+
+	return serveCmd
+}
+
+// serve - serves the config's input directory on the listener until the
+// context is cancelled. Templates are rendered on each request, or (when
+// watching) whenever they change.
+func serve(ctx context.Context, cfg *gomplate.Config, ln net.Listener, watchChanges bool, stderr io.Writer) error {
+	defer ln.Close()
+
+	if len(cfg.PostExec) > 0 || cfg.ExecPipe {
+		return fmt.Errorf("serve can't be used with a post-run command")
+	}
+
+	if cfg.InputDir == "" {
+		cfg.InputDir = "."
+	}
+
+	cfg.Input = ""
+	cfg.InputFiles = nil
+	cfg.OutputFiles = nil
+	cfg.DryRun = false
+	cfg.Diff = false
+
+	var handler http.Handler
+
+	errs := make(chan error, 2)
+
+	if watchChanges {
+		outDir, err := os.MkdirTemp("", "gomplate-serve")
+		if err != nil {
+			return fmt.Errorf("couldn't create output directory: %w", err)
+		}
+		defer os.RemoveAll(outDir)
+
+		cfg.OutputDir = outDir
+
+		t, err := watchTargetsForConfig(cfg)
+		if err != nil {
+			return err
+		}
+
+		h := newRenderedDirHandler(outDir)
+		handler = h
+
+		go func() {
+			errs <- watch(ctx, t, func(ctx context.Context) error {
+				return h.render(ctx, cfg)
+			}, stderr)
+		}()
+	} else {
+		handler = newTemplateHandler(cfg)
+	}
+
+	srv := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	go func() {
+		err := srv.Serve(ln)
+		if errors.Is(err, http.ErrServerClosed) {
+			err = nil
+		}
+
+		errs <- err
+	}()
+
+	fmt.Fprintf(stderr, "serving %s at http://%s\n", cfg.InputDir, ln.Addr())
+
+	var err error
+
+	select {
+	case <-ctx.Done():
+	case err = <-errs:
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+
+	if serr := srv.Shutdown(shutdownCtx); serr != nil && err == nil {
+		err = fmt.Errorf("couldn't stop the server: %w", serr)
+	}
+
+	return err
+}
+
+// templateHandler renders the requested template from the input directory
+// for each request
+type templateHandler struct {
+	cfg  *gomplate.Config
+	fsys fs.FS
+
+	// gomplate.Run can't be called concurrently
+	mu sync.Mutex
+}
+
+func newTemplateHandler(cfg *gomplate.Config) *templateHandler {
+	return &templateHandler{cfg: cfg, fsys: os.DirFS(cfg.InputDir)}
+}
+
+func (h *templateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	if name == "" {
+		name = "."
+	}
+
+	fi, err := fs.Stat(h.fsys, name)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if fi.IsDir() {
+		if !strings.HasSuffix(r.URL.Path, "/") {
+			http.Redirect(w, r, r.URL.Path+"/", http.StatusMovedPermanently)
+			return
+		}
+
+		name = path.Join(name, "index.html")
+		if _, err = fs.Stat(h.fsys, name); err != nil {
+			http.NotFound(w, r)
+			return
+		}
+	}
+
+	excluded, passthrough, err := h.exclusions()
+	if err != nil {
+		slog.ErrorContext(r.Context(), "", slog.Any("err", err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	switch {
+	case excluded[name]:
+		http.NotFound(w, r)
+		return
+	case passthrough[name]:
+		http.ServeFileFS(w, r, h.fsys, name)
+		return
+	}
+
+	out, err := h.render(r.Context(), name)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "", slog.Any("err", err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	// the content type is chosen from the name, or sniffed from the content
+	http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(out))
+}
+
+// exclusions - finds the files which are excluded (with --exclude or a
+// .gomplateignore file), and the files which are served without rendering
+// (with --exclude-processing)
+func (h *templateHandler) exclusions() (excluded, passthrough map[string]bool, err error) {
+	matcher := xignore.NewMatcher(h.fsys)
+
+	excludes, err := matcher.Matches(".", &xignore.MatchesOptions{
+		Ignorefile:    ".gomplateignore",
+		Nested:        true,
+		AfterPatterns: h.cfg.ExcludeGlob,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("ignore matching failed for %s: %w", h.cfg.InputDir, err)
+	}
+
+	passthroughs, err := matcher.Matches(".", &xignore.MatchesOptions{
+		Ignorefile:    ".gomplateignore",
+		Nested:        true,
+		AfterPatterns: h.cfg.ExcludeProcessingGlob,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("passthough matching failed for %s: %w", h.cfg.InputDir, err)
+	}
+
+	excluded = make(map[string]bool, len(excludes.MatchedFiles))
+	for _, f := range excludes.MatchedFiles {
+		excluded[filepath.ToSlash(f)] = true
+	}
+
+	passthrough = make(map[string]bool, len(passthroughs.MatchedFiles))
+	for _, f := range passthroughs.MatchedFiles {
+		passthrough[filepath.ToSlash(f)] = true
+	}
+
+	return excluded, passthrough, nil
+}
+
+// render - renders the named template from the input directory
+func (h *templateHandler) render(ctx context.Context, name string) ([]byte, error) {
+	buf := &bytes.Buffer{}
+
+	cfg := *h.cfg
+	cfg.InputDir = ""
+	cfg.OutputDir = ""
+	cfg.OutputMap = ""
+	cfg.InputFiles = []string{filepath.Join(h.cfg.InputDir, filepath.FromSlash(name))}
+	cfg.OutputFiles = []string{"-"}
+	cfg.Stdout = buf
+	cfg.FileDelims = fileDelimsInDir(h.cfg.InputDir, h.cfg.FileDelims)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err := gomplate.Run(ctx, &cfg); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// fileDelimsInDir - fileDelims patterns with a slash are relative to the
+// input directory, but input files are matched by their full path, so the
+// patterns need to be prefixed with the directory
+func fileDelimsInDir(dir string, fileDelims []gomplate.FileDelims) []gomplate.FileDelims {
+	if len(fileDelims) == 0 {
+		return fileDelims
+	}
+
+	dir = filepath.ToSlash(dir)
+	out := make([]gomplate.FileDelims, len(fileDelims))
+
+	for i, fd := range fileDelims {
+		files := make([]string, len(fd.Files))
+		for j, p := range fd.Files {
+			if strings.Contains(p, "/") {
+				p = path.Join(dir, p)
+			}
+
+			files[j] = p
+		}
+
+		fd.Files = files
+		out[i] = fd
+	}
+
+	return out
+}
+
+// renderedDirHandler serves the files rendered into an output directory,
+// which is re-rendered whenever the templates change
+type renderedDirHandler struct {
+	files http.Handler
+	dir   string
+
+	// held for writing while rendering, so partly-rendered files aren't served
+	mu sync.RWMutex
+}
+
+func newRenderedDirHandler(dir string) *renderedDirHandler {
+	return &renderedDirHandler{dir: dir, files: http.FileServer(http.Dir(dir))}
+}
+
+func (h *renderedDirHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	h.files.ServeHTTP(w, r)
+}
+
+// render - renders the config's templates into the output directory,
+// removing anything rendered previously (so deleted templates aren't served)
+func (h *renderedDirHandler) render(ctx context.Context, cfg *gomplate.Config) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	entries, err := os.ReadDir(h.dir)
+	if err != nil {
+		return fmt.Errorf("couldn't clean output directory: %w", err)
+	}
+
+	for _, e := range entries {
+		if err := os.RemoveAll(filepath.Join(h.dir, e.Name())); err != nil {
+			return fmt.Errorf("couldn't clean output directory: %w", err)
+		}
+	}
+
+	return gomplate.Run(ctx, cfg)
+}
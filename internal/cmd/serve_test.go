@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hairyhenderson/gomplate/v4"
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+	"github.com/hairyhenderson/gomplate/v4/internal/urlhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupServeDir(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"index.html":      "<h1>{{ (ds \"data\").title }}</h1>",
+		"data.json":       `{"title": "hello"}`,
+		"sub/index.html":  "sub {{ add 1 2 }}",
+		"sub/page.txt":    "page",
+		"static/raw.txt":  "{{ raw }}",
+		"broken.txt":      "{{ 1",
+		".gomplateignore": "ignored.txt\n",
+		"ignored.txt":     "ignored",
+		"nested/a.txt":    "no index",
+	}
+
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+	}
+
+	return dir
+}
+
+func TestTemplateHandler(t *testing.T) {
+	dir := setupServeDir(t)
+
+	ctx := datafs.ContextWithFSProvider(context.Background(), gomplate.DefaultFSProvider)
+
+	cfg := &gomplate.Config{
+		InputDir:              dir,
+		ExcludeGlob:           []string{"data.json"},
+		ExcludeProcessingGlob: []string{"static/*"},
+		DataSources: map[string]gomplate.DataSource{
+			"data": {URL: mustSourceURL(t, filepath.Join(dir, "data.json"))},
+		},
+	}
+
+	h := newTemplateHandler(cfg)
+
+	get := func(p string) *http.Response {
+		t.Helper()
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, p, nil).WithContext(ctx))
+
+		return w.Result()
+	}
+
+	body := func(resp *http.Response) string {
+		t.Helper()
+		defer resp.Body.Close()
+
+		b, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		return string(b)
+	}
+
+	resp := get("/")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/html; charset=utf-8", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "<h1>hello</h1>", body(resp))
+
+	resp = get("/sub")
+	assert.Equal(t, http.StatusMovedPermanently, resp.StatusCode)
+	assert.Equal(t, "/sub/", resp.Header.Get("Location"))
+
+	resp = get("/sub/")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "sub 3", body(resp))
+
+	resp = get("/sub/page.txt")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "page", body(resp))
+
+	resp = get("/static/raw.txt")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "{{ raw }}", body(resp))
+
+	resp = get("/broken.txt")
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Contains(t, body(resp), "unclosed action")
+
+	for _, p := range []string{"/data.json", "/ignored.txt", "/nested/", "/missing.txt", "/../data.json"} {
+		resp = get(p)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, p)
+		resp.Body.Close()
+	}
+}
+
+func TestRenderedDirHandler(t *testing.T) {
+	dir := setupServeDir(t)
+	outDir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(outDir, "stale.txt"), []byte("stale"), 0o600))
+
+	ctx := datafs.ContextWithFSProvider(context.Background(), gomplate.DefaultFSProvider)
+
+	cfg := &gomplate.Config{
+		InputDir:              dir,
+		OutputDir:             outDir,
+		ExcludeGlob:           []string{"data.json", "broken.txt"},
+		ExcludeProcessingGlob: []string{"static/*"},
+		DataSources: map[string]gomplate.DataSource{
+			"data": {URL: mustSourceURL(t, filepath.Join(dir, "data.json"))},
+		},
+	}
+
+	h := newRenderedDirHandler(outDir)
+	require.NoError(t, h.render(ctx, cfg))
+
+	get := func(p string) (int, string) {
+		t.Helper()
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, p, nil))
+
+		return w.Code, w.Body.String()
+	}
+
+	code, out := get("/")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "<h1>hello</h1>", out)
+
+	code, out = get("/static/raw.txt")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "{{ raw }}", out)
+
+	code, _ = get("/stale.txt")
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestServe(t *testing.T) {
+	dir := setupServeDir(t)
+
+	ctx, cancel := context.WithCancel(datafs.ContextWithFSProvider(context.Background(), gomplate.DefaultFSProvider))
+	defer cancel()
+
+	err := serve(ctx, &gomplate.Config{PostExec: []string{"echo"}}, newTestListener(t), false, &bytes.Buffer{})
+	require.ErrorContains(t, err, "post-run command")
+
+	ln := newTestListener(t)
+	addr := "http://" + ln.Addr().String()
+
+	done := make(chan error)
+
+	go func() {
+		done <- serve(ctx, &gomplate.Config{InputDir: dir}, ln, false, io.Discard)
+	}()
+
+	var resp *http.Response
+
+	require.Eventually(t, func() bool {
+		//nolint:noctx
+		resp, err = http.Get(addr + "/sub/page.txt")
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	b, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "page", string(b))
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func mustSourceURL(t *testing.T, p string) *url.URL {
+	t.Helper()
+
+	u, err := urlhelpers.ParseSourceURL(p)
+	require.NoError(t, err)
+
+	return u
+}
+
+func newTestListener(t *testing.T) net.Listener {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	return ln
+}
+
+func TestFileDelimsInDir(t *testing.T) {
+	assert.Nil(t, fileDelimsInDir("in", nil))
+
+	fileDelims := []gomplate.FileDelims{
+		{LDelim: "[[", RDelim: "]]", Files: []string{"*.hbs", "helm/**"}},
+	}
+
+	assert.Equal(t, []gomplate.FileDelims{
+		{LDelim: "[[", RDelim: "]]", Files: []string{"*.hbs", "in/helm/**"}},
+	}, fileDelimsInDir("in", fileDelims))
+
+	assert.Equal(t, fileDelims, fileDelimsInDir(".", fileDelims))
+
+	// the original isn't modified
+	assert.Equal(t, "helm/**", fileDelims[0].Files[1])
+}
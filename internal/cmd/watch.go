@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/hairyhenderson/gomplate/v4"
+)
+
+// watchDebounce is how long to wait after a change before re-rendering, so
+// that bursts of changes (like an editor's save, or a git checkout) only
+// cause one render
+//
+//nolint:gochecknoglobals
+var watchDebounce = 100 * time.Millisecond
+
+// watchTargets are the paths that templates are read from, which need to be
+// watched for changes
+type watchTargets struct {
+	// files are watched individually (by watching their parent directories,
+	// so files that are replaced rather than written are still noticed)
+	files map[string]bool
+	// dirs are watched recursively
+	dirs []string
+	// outDir is the output directory, changes to which are ignored, in case
+	// it's inside a watched directory
+	outDir string
+}
+
+// watchTargetsForConfig - finds the input files and directories, templates,
+// file-based datasources, and policies used by the config
+func watchTargetsForConfig(cfg *gomplate.Config) (*watchTargets, error) {
+	if cfg.Input == "" && cfg.InputDir == "" && (len(cfg.InputFiles) == 0 || slices.Contains(cfg.InputFiles, "-")) {
+		return nil, fmt.Errorf("--watch can't be used with templates read from standard input")
+	}
+
+	t := &watchTargets{files: map[string]bool{}}
+
+	add := func(p string) error {
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			return fmt.Errorf("couldn't watch %q: %w", p, err)
+		}
+
+		if fi, err := os.Stat(abs); err == nil && fi.IsDir() {
+			t.dirs = append(t.dirs, abs)
+		} else {
+			t.files[abs] = true
+		}
+
+		return nil
+	}
+
+	for _, f := range cfg.InputFiles {
+		if f == "-" {
+			continue
+		}
+
+		if err := add(f); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.InputDir != "" {
+		if err := add(cfg.InputDir); err != nil {
+			return nil, err
+		}
+
+		if cfg.OutputDir != "" {
+			outDir, err := filepath.Abs(cfg.OutputDir)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't find output directory %q: %w", cfg.OutputDir, err)
+			}
+
+			t.outDir = outDir
+		}
+	}
+
+	for _, m := range []map[string]gomplate.DataSource{cfg.Templates, cfg.DataSources, cfg.Context} {
+		for _, ds := range m {
+			if ds.URL == nil || (ds.URL.Scheme != "" && ds.URL.Scheme != "file") || ds.URL.Path == "" {
+				continue
+			}
+
+			if err := add(filepath.FromSlash(ds.URL.Path)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, p := range cfg.Policies {
+		if err := add(p); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(t.files) == 0 && len(t.dirs) == 0 {
+		return nil, fmt.Errorf("nothing to watch: --watch needs template files, or file-based datasources")
+	}
+
+	return t, nil
+}
+
+// relevant - whether a change to the path should cause a re-render
+func (t *watchTargets) relevant(p string) bool {
+	if t.files[p] {
+		return true
+	}
+
+	if t.outDir != "" && isWithin(t.outDir, p) {
+		return false
+	}
+
+	for _, d := range t.dirs {
+		if isWithin(d, p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isWithin - whether the path is the directory, or inside it
+func isWithin(dir, p string) bool {
+	return p == dir || strings.HasPrefix(p, dir+string(filepath.Separator))
+}
+
+// addWatches - adds the watched files' parent directories, and the watched
+// directories (recursively) to the watcher
+func (t *watchTargets) addWatches(w *fsnotify.Watcher) error {
+	for f := range t.files {
+		if err := w.Add(filepath.Dir(f)); err != nil {
+			return fmt.Errorf("couldn't watch %q: %w", f, err)
+		}
+	}
+
+	for _, d := range t.dirs {
+		if err := addDirWatches(w, d); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func addDirWatches(w *fsnotify.Watcher, dir string) error {
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if err := w.Add(p); err != nil {
+				return fmt.Errorf("couldn't watch %q: %w", p, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// watch - renders once, and then again whenever a watched path changes, until
+// the context is cancelled. Rendering errors are logged, and don't stop the
+// watching.
+func watch(ctx context.Context, t *watchTargets, render func(context.Context) error, stderr io.Writer) error {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("couldn't start watching: %w", err)
+	}
+	defer w.Close()
+
+	if err := t.addWatches(w); err != nil {
+		return err
+	}
+
+	doRender := func() {
+		start := time.Now()
+		if err := render(ctx); err != nil {
+			slog.ErrorContext(ctx, "", slog.Any("err", err))
+			fmt.Fprintln(stderr, "watching for changes...")
+
+			return
+		}
+
+		fmt.Fprintf(stderr, "rendered in %s - watching for changes...\n", time.Since(start).Round(time.Millisecond))
+	}
+
+	doRender()
+
+	timer := time.NewTimer(watchDebounce)
+	timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-w.Events:
+			if !ok {
+				return nil
+			}
+
+			if !t.relevant(ev.Name) {
+				continue
+			}
+
+			// new directories need to be watched too
+			if ev.Has(fsnotify.Create) {
+				if fi, err := os.Stat(ev.Name); err == nil && fi.IsDir() {
+					if err := addDirWatches(w, ev.Name); err != nil {
+						slog.WarnContext(ctx, "", slog.Any("err", err))
+					}
+				}
+			}
+
+			slog.DebugContext(ctx, "change detected", slog.String("path", ev.Name), slog.String("op", ev.Op.String()))
+
+			timer.Reset(watchDebounce)
+		case err, ok := <-w.Errors:
+			if !ok {
+				return nil
+			}
+
+			slog.WarnContext(ctx, "error watching for changes", slog.Any("err", err))
+		case <-timer.C:
+			doRender()
+		}
+	}
+}
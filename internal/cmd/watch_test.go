@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hairyhenderson/gomplate/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchTargetsForConfig(t *testing.T) {
+	dir := t.TempDir()
+	tmplDir := filepath.Join(dir, "partials")
+	require.NoError(t, os.Mkdir(tmplDir, 0o755))
+
+	_, err := watchTargetsForConfig(&gomplate.Config{})
+	require.ErrorContains(t, err, "standard input")
+
+	_, err = watchTargetsForConfig(&gomplate.Config{InputFiles: []string{"-"}})
+	require.ErrorContains(t, err, "standard input")
+
+	_, err = watchTargetsForConfig(&gomplate.Config{Input: "hello"})
+	require.ErrorContains(t, err, "nothing to watch")
+
+	_, err = watchTargetsForConfig(&gomplate.Config{
+		Input: "hello",
+		DataSources: map[string]gomplate.DataSource{
+			"web": {URL: &url.URL{Scheme: "https", Host: "example.com", Path: "/data.json"}},
+			"env": {URL: &url.URL{Scheme: "env", Opaque: "FOO"}},
+		},
+	})
+	require.ErrorContains(t, err, "nothing to watch")
+
+	cfg := &gomplate.Config{
+		InputFiles: []string{filepath.Join(dir, "in.tmpl")},
+		Templates: map[string]gomplate.DataSource{
+			"partials": {URL: &url.URL{Path: filepath.ToSlash(tmplDir) + "/"}},
+		},
+		DataSources: map[string]gomplate.DataSource{
+			"config": {URL: &url.URL{Scheme: "file", Path: filepath.ToSlash(filepath.Join(dir, "config.yaml"))}},
+			"web":    {URL: &url.URL{Scheme: "https", Host: "example.com", Path: "/data.json"}},
+		},
+		Policies: []string{filepath.Join(dir, "policy.rego")},
+	}
+
+	wt, err := watchTargetsForConfig(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{
+		filepath.Join(dir, "in.tmpl"):     true,
+		filepath.Join(dir, "config.yaml"): true,
+		filepath.Join(dir, "policy.rego"): true,
+	}, wt.files)
+	assert.Equal(t, []string{tmplDir}, wt.dirs)
+
+	assert.True(t, wt.relevant(filepath.Join(dir, "in.tmpl")))
+	assert.True(t, wt.relevant(filepath.Join(tmplDir, "header.tmpl")))
+	assert.False(t, wt.relevant(filepath.Join(dir, "out.txt")))
+	assert.False(t, wt.relevant(filepath.Join(dir, "partials-old", "header.tmpl")))
+
+	wt, err = watchTargetsForConfig(&gomplate.Config{
+		InputDir:  dir,
+		OutputDir: filepath.Join(dir, "out"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{dir}, wt.dirs)
+	assert.True(t, wt.relevant(filepath.Join(dir, "in.tmpl")))
+	assert.False(t, wt.relevant(filepath.Join(dir, "out", "in.tmpl")))
+}
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "in.tmpl")
+	require.NoError(t, os.WriteFile(in, []byte("hello"), 0o600))
+
+	orig := watchDebounce
+	watchDebounce = 10 * time.Millisecond
+
+	t.Cleanup(func() { watchDebounce = orig })
+
+	wt, err := watchTargetsForConfig(&gomplate.Config{InputFiles: []string{in}})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	renders := atomic.Int32{}
+	render := func(context.Context) error {
+		renders.Add(1)
+		return nil
+	}
+
+	stderr := &bytes.Buffer{}
+	done := make(chan error)
+
+	go func() {
+		done <- watch(ctx, wt, render, stderr)
+	}()
+
+	require.Eventually(t, func() bool { return renders.Load() == 1 }, time.Second, 5*time.Millisecond)
+
+	// unrelated files don't cause a render
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "out.txt"), []byte("hello"), 0o600))
+
+	for i := range 3 {
+		require.NoError(t, os.WriteFile(in, []byte{'a' + byte(i)}, 0o600))
+	}
+
+	require.Eventually(t, func() bool { return renders.Load() == 2 }, time.Second, 5*time.Millisecond)
+
+	time.Sleep(5 * watchDebounce)
+	assert.EqualValues(t, 2, renders.Load())
+
+	cancel()
+	require.NoError(t, <-done)
+	assert.Contains(t, stderr.String(), "watching for changes...")
+}
+
+func TestRunWatch(t *testing.T) {
+	err := runWatch(context.Background(), &gomplate.Config{
+		Input:    "hello",
+		PostExec: []string{"echo"},
+	}, &bytes.Buffer{})
+	require.ErrorContains(t, err, "post-run command")
+}
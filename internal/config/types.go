@@ -100,6 +100,63 @@ func ExperimentalEnabled(ctx context.Context) bool {
 	return ok && v
 }
 
+type randomSeedCtxKey struct{}
+
+// WithRandomSeed returns a context in which random functions are seeded with
+// the given seed, so that they produce the same values each time
+func WithRandomSeed(ctx context.Context, seed string) context.Context {
+	return context.WithValue(ctx, randomSeedCtxKey{}, seed)
+}
+
+// RandomSeed returns the seed set with WithRandomSeed, if any
+func RandomSeed(ctx context.Context) (string, bool) {
+	v, ok := ctx.Value(randomSeedCtxKey{}).(string)
+	return v, ok
+}
+
+type allowWriteCtxKey struct{}
+
+// WithWriteAllowed returns a context in which templates are allowed to write
+// files (with file.Write)
+func WithWriteAllowed(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allowWriteCtxKey{}, true)
+}
+
+// WriteAllowed reports whether templates are allowed to write files
+func WriteAllowed(ctx context.Context) bool {
+	v, ok := ctx.Value(allowWriteCtxKey{}).(bool)
+	return ok && v
+}
+
+type sprigCtxKey struct{}
+
+// WithSprig returns a context in which Sprig-compatible functions are added
+// to the template functions
+func WithSprig(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sprigCtxKey{}, true)
+}
+
+// SprigEnabled reports whether Sprig-compatible functions are enabled
+func SprigEnabled(ctx context.Context) bool {
+	v, ok := ctx.Value(sprigCtxKey{}).(bool)
+	return ok && v
+}
+
+type maxTemplateDepthCtxKey struct{}
+
+// WithMaxTemplateDepth returns a context in which nested template executions
+// (with tmpl.Exec and similar functions) are limited to the given depth
+func WithMaxTemplateDepth(ctx context.Context, depth int) context.Context {
+	return context.WithValue(ctx, maxTemplateDepthCtxKey{}, depth)
+}
+
+// MaxTemplateDepth returns the depth set with WithMaxTemplateDepth, or 0 if
+// none was set
+func MaxTemplateDepth(ctx context.Context) int {
+	v, _ := ctx.Value(maxTemplateDepthCtxKey{}).(int)
+	return v
+}
+
 // DataSource - datasource configuration
 //
 // defined in this package to avoid cyclic dependencies
@@ -0,0 +1,114 @@
+package datafs
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/parsers"
+)
+
+const (
+	// avroSchemaParam is the query parameter naming the schema used to decode
+	// Avro-encoded datasources
+	avroSchemaParam = "avroSchema"
+	// avroRegistryParam is the query parameter naming the Confluent Schema
+	// Registry used to look up the schemas of Avro-encoded datasources
+	avroRegistryParam = "avroRegistry"
+)
+
+// decodeAvro decodes a single Avro-encoded value as JSON.
+//
+// When a schema registry is given, the data must be in the Confluent wire
+// format (a zero byte and a 4-byte schema ID, followed by the value), and the
+// schema is fetched from the registry by ID. Otherwise, the data is the bare
+// value, and the schema is read from the schema datasource. Both may be
+// aliases, URLs, or relative paths.
+func (d *dsReader) decodeAvro(ctx context.Context, data []byte, schemaRef, registry string) ([]byte, error) {
+	var schema []byte
+
+	var err error
+
+	if registry != "" {
+		var id uint32
+
+		id, data, err = splitAvroWireFormat(data)
+		if err != nil {
+			return nil, err
+		}
+
+		schema, err = d.readRegistrySchema(ctx, registry, id)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		schemaURL, hdr, lerr := d.lookupSource(schemaRef)
+		if lerr != nil {
+			return nil, fmt.Errorf("invalid Avro schema %q: %w", schemaRef, lerr)
+		}
+
+		sc, rerr := d.readFileContent(ctx, schemaURL, hdr)
+		if rerr != nil {
+			return nil, fmt.Errorf("read Avro schema %q: %w", schemaRef, rerr)
+		}
+
+		schema = sc.b
+	}
+
+	v, err := parsers.AvroDatum(string(schema), data)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("marshal Avro value as JSON: %w", err)
+	}
+
+	return b, nil
+}
+
+// splitAvroWireFormat splits a message in the Confluent wire format into its
+// schema ID and the encoded value
+func splitAvroWireFormat(data []byte) (uint32, []byte, error) {
+	if len(data) < 5 || data[0] != 0 {
+		return 0, nil, fmt.Errorf("message is not in the Confluent wire format: expected a zero byte followed by a 4-byte schema ID")
+	}
+
+	return binary.BigEndian.Uint32(data[1:5]), data[5:], nil
+}
+
+// readRegistrySchema fetches the schema with the given ID from a Confluent
+// Schema Registry
+func (d *dsReader) readRegistrySchema(ctx context.Context, registry string, id uint32) ([]byte, error) {
+	regURL, hdr, err := d.lookupSource(registry)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema registry %q: %w", registry, err)
+	}
+
+	schemaURL := regURL.JoinPath("schemas", "ids", strconv.FormatUint(uint64(id), 10))
+
+	sc, err := d.readFileContent(ctx, schemaURL, hdr)
+	if err != nil {
+		return nil, fmt.Errorf("read Avro schema %d from registry %q: %w", id, registry, err)
+	}
+
+	resp := struct {
+		Schema     string `json:"schema"`
+		SchemaType string `json:"schemaType"`
+	}{}
+
+	err = json.Unmarshal(sc.b, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("parse schema registry response for schema %d: %w", id, err)
+	}
+
+	// the schema type is omitted for Avro schemas
+	if resp.SchemaType != "" && resp.SchemaType != "AVRO" {
+		return nil, fmt.Errorf("schema %d in registry %q is a %s schema, not Avro", id, registry, resp.SchemaType)
+	}
+
+	return []byte(resp.Schema), nil
+}
@@ -0,0 +1,140 @@
+package datafs
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hairyhenderson/go-fsimpl"
+	"github.com/hairyhenderson/go-fsimpl/httpfs"
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
+	"github.com/hamba/avro/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAvroSchema = `{
+	"type": "record",
+	"name": "ServiceConfig",
+	"namespace": "config.v1",
+	"fields": [
+		{"name": "service_name", "type": "string"},
+		{"name": "port", "type": "int"},
+		{"name": "hosts", "type": {"type": "array", "items": "string"}},
+		{"name": "owner", "type": ["null", "string"], "default": null}
+	]
+}`
+
+func TestReadFileContent_Avro(t *testing.T) {
+	wd, _ := os.Getwd()
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+	})
+	_ = os.Chdir("/")
+
+	value, err := avro.Marshal(avro.MustParse(testAvroSchema), map[string]interface{}{
+		"service_name": "web",
+		"port":         8080,
+		"hosts":        []interface{}{"a.example.com", "b.example.com"},
+		"owner":        nil,
+	})
+	require.NoError(t, err)
+
+	// the Confluent wire format prefixes the value with a zero byte and the
+	// schema ID
+	message := append([]byte{0, 0, 0, 0, 42}, value...)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/registry/schemas/ids/42", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+		_ = json.NewEncoder(w).Encode(map[string]string{"schema": testAvroSchema})
+	})
+	mux.HandleFunc("/registry/schemas/ids/43", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+		_ = json.NewEncoder(w).Encode(map[string]string{"schema": "syntax = \"proto3\";", "schemaType": "PROTOBUF"})
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	fsys := WrapWdFS(fstest.MapFS{
+		"schema/config.avsc": &fstest.MapFile{Data: []byte(testAvroSchema)},
+		"data/config.bin":    &fstest.MapFile{Data: value},
+		"data/message.bin":   &fstest.MapFile{Data: message},
+		"data/message43.bin": &fstest.MapFile{Data: append([]byte{0, 0, 0, 0, 43}, value...)},
+	})
+
+	fsp := fsimpl.NewMux()
+	fsp.Add(httpfs.FS)
+	fsp.Add(WrappedFSProvider(fsys, "file", ""))
+
+	ctx := ContextWithFSProvider(context.Background(), fsp)
+
+	reg := NewRegistry()
+	sr := &dsReader{Registry: reg}
+
+	expected := `{
+		"service_name": "web",
+		"port": 8080,
+		"hosts": ["a.example.com", "b.example.com"],
+		"owner": null
+	}`
+
+	fc, err := sr.readFileContent(ctx, mustParseURL("file:///data/config.bin?avroSchema=schema/config.avsc"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, iohelpers.JSONMimetype, fc.contentType)
+	assert.JSONEq(t, expected, string(fc.b))
+
+	// the schema can be another datasource
+	reg.Register("schema", config.DataSource{URL: mustParseURL("file:///schema/config.avsc")})
+
+	fc, err = sr.readFileContent(ctx, mustParseURL("file:///data/config.bin?avroSchema=schema"), nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, expected, string(fc.b))
+
+	_, err = sr.readFileContent(ctx, mustParseURL("file:///data/config.bin?avroSchema=missing.avsc"), nil)
+	require.Error(t, err)
+
+	// the schema is looked up in the registry by ID
+	fc, err = sr.readFileContent(ctx,
+		mustParseURL("file:///data/message.bin?avroRegistry="+srv.URL+"/registry"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, iohelpers.JSONMimetype, fc.contentType)
+	assert.JSONEq(t, expected, string(fc.b))
+
+	// the registry can be another datasource
+	reg.Register("registry", config.DataSource{URL: mustParseURL(srv.URL + "/registry/")})
+
+	fc, err = sr.readFileContent(ctx, mustParseURL("file:///data/message.bin?avroRegistry=registry"), nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, expected, string(fc.b))
+
+	// the value isn't in the wire format
+	_, err = sr.readFileContent(ctx, mustParseURL("file:///data/config.bin?avroRegistry=registry"), nil)
+	require.ErrorContains(t, err, "wire format")
+
+	_, err = sr.readFileContent(ctx, mustParseURL("file:///data/message43.bin?avroRegistry=registry"), nil)
+	require.ErrorContains(t, err, "PROTOBUF")
+}
+
+func TestSplitAvroWireFormat(t *testing.T) {
+	msg := binary.BigEndian.AppendUint32([]byte{0}, 1234)
+	msg = append(msg, 'x')
+
+	id, data, err := splitAvroWireFormat(msg)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1234), id)
+	assert.Equal(t, []byte("x"), data)
+
+	_, _, err = splitAvroWireFormat([]byte{1, 0, 0, 0, 1, 'x'})
+	require.Error(t, err)
+
+	_, _, err = splitAvroWireFormat([]byte{0, 0})
+	require.Error(t, err)
+}
@@ -28,6 +28,21 @@ func WithDataSourceRegistryFS(registry Registry, fsys fs.FS) fs.FS {
 	return fsys
 }
 
+type withMergeCacher interface {
+	WithMergeCache(cache *mergeCache) fs.FS
+}
+
+// withMergeCacheFS injects a cache of parsed sub-sources into the filesystem
+// fs, if the filesystem supports it (i.e. has a WithMergeCache method). This is
+// used for the mergefs filesystem.
+func withMergeCacheFS(cache *mergeCache, fsys fs.FS) fs.FS {
+	if fsys, ok := fsys.(withMergeCacher); ok {
+		return fsys.WithMergeCache(cache)
+	}
+
+	return fsys
+}
+
 type stdinCtxKey struct{}
 
 // ContextWithStdin injects an [io.Reader] into the context, which can be used
@@ -0,0 +1,318 @@
+package datafs
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	osfs "github.com/hack-pad/hackpadfs/os"
+	"github.com/hairyhenderson/go-fsimpl"
+	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
+)
+
+// NewFeatureFlagFS returns a filesystem (an fs.FS) that can be used to read
+// feature flag state from LaunchDarkly or Unleash. The URL's scheme must be
+// one of launchdarkly+http, launchdarkly+https, unleash+http, or unleash+https,
+// and the rest of the URL locates the flag service's API.
+//
+// Flags are always presented as a JSON object keyed by flag name.
+func NewFeatureFlagFS(u *url.URL) (fs.FS, error) {
+	provider, scheme, _ := strings.Cut(u.Scheme, "+")
+	if (provider != "launchdarkly" && provider != "unleash") ||
+		(scheme != "http" && scheme != "https") {
+		return nil, fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+
+	base := *u
+	base.Scheme = scheme
+
+	return &featureFlagFS{
+		ctx:      context.Background(),
+		client:   http.DefaultClient,
+		base:     &base,
+		provider: provider,
+		headers:  http.Header{},
+		envfs:    WrapWdFS(osfs.NewFS()),
+	}, nil
+}
+
+type featureFlagFS struct {
+	ctx      context.Context
+	client   *http.Client
+	base     *url.URL
+	headers  http.Header
+	envfs    fs.FS
+	provider string
+}
+
+//nolint:gochecknoglobals
+var FeatureFlagFS = fsimpl.FSProviderFunc(NewFeatureFlagFS,
+	"launchdarkly+http", "launchdarkly+https", "unleash+http", "unleash+https")
+
+var (
+	_ fs.FS         = (*featureFlagFS)(nil)
+	_ withContexter = (*featureFlagFS)(nil)
+)
+
+func (f *featureFlagFS) WithContext(ctx context.Context) fs.FS {
+	if ctx == nil {
+		return f
+	}
+
+	fsys := *f
+	fsys.ctx = ctx
+
+	return &fsys
+}
+
+func (f *featureFlagFS) WithHeader(headers http.Header) fs.FS {
+	if headers == nil {
+		return f
+	}
+
+	fsys := *f
+	fsys.headers = f.headers.Clone()
+	for k, vs := range headers {
+		for _, v := range vs {
+			fsys.headers.Add(k, v)
+		}
+	}
+
+	return &fsys
+}
+
+func (f *featureFlagFS) WithHTTPClient(client *http.Client) fs.FS {
+	if client == nil {
+		return f
+	}
+
+	fsys := *f
+	fsys.client = client
+
+	return &fsys
+}
+
+func (f *featureFlagFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{
+			Op:   "open",
+			Path: name,
+			Err:  fs.ErrInvalid,
+		}
+	}
+
+	u := *f.base
+	u.Path = path.Join("/", f.base.Path, name)
+
+	hdr := f.headers.Clone()
+	if hdr.Get("Authorization") == "" {
+		if token := GetenvFsys(f.envfs, f.tokenEnvVar()); token != "" {
+			hdr.Set("Authorization", token)
+		}
+	}
+
+	return &featureFlagFile{
+		ctx:      f.ctx,
+		client:   f.client,
+		u:        &u,
+		hdr:      hdr,
+		name:     name,
+		provider: f.provider,
+	}, nil
+}
+
+// tokenEnvVar returns the name of the environment variable holding the API
+// token (or SDK key) used when no Authorization header has been provided
+func (f *featureFlagFS) tokenEnvVar() string {
+	if f.provider == "unleash" {
+		return "UNLEASH_API_TOKEN"
+	}
+
+	return "LAUNCHDARKLY_SDK_KEY"
+}
+
+type featureFlagFile struct {
+	ctx      context.Context
+	client   *http.Client
+	u        *url.URL
+	hdr      http.Header
+	body     io.Reader
+	fi       fs.FileInfo
+	name     string
+	provider string
+}
+
+var _ fs.File = (*featureFlagFile)(nil)
+
+func (f *featureFlagFile) Close() error {
+	f.body = nil
+	return nil
+}
+
+func (f *featureFlagFile) Stat() (fs.FileInfo, error) {
+	if f.fi == nil {
+		if err := f.fetch(); err != nil {
+			return nil, err
+		}
+	}
+
+	return f.fi, nil
+}
+
+func (f *featureFlagFile) Read(p []byte) (int, error) {
+	if f.body == nil {
+		if err := f.fetch(); err != nil {
+			return 0, err
+		}
+	}
+
+	return f.body.Read(p)
+}
+
+// fetch requests the flags from the service and normalizes them into a JSON
+// object keyed by flag name
+func (f *featureFlagFile) fetch() error {
+	u, err := f.requestURL()
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(f.ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header = f.hdr
+	req.Header.Set("Accept", iohelpers.JSONMimetype)
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("%s flags request failed with status %d", f.provider, resp.StatusCode)
+	}
+
+	var raw interface{}
+	if err = json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return fmt.Errorf("decoding %s response: %w", f.provider, err)
+	}
+
+	var flags map[string]interface{}
+	if f.provider == "unleash" {
+		flags, err = unleashFlags(raw)
+	} else {
+		flags, err = launchDarklyFlags(raw)
+	}
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(flags)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	modTime := time.Time{}
+	if mod := resp.Header.Get("Last-Modified"); mod != "" {
+		// best-effort - if it can't be parsed, just ignore it...
+		modTime, _ = http.ParseTime(mod)
+	}
+
+	f.body = bytes.NewReader(b)
+	f.fi = FileInfo(f.name, int64(len(b)), 0o444, modTime, iohelpers.JSONMimetype)
+
+	return nil
+}
+
+// requestURL returns the URL to request. For LaunchDarkly's client-side
+// evaluation endpoint (a path ending in /contexts), the query parameters
+// describe the evaluation context and are encoded into the path.
+func (f *featureFlagFile) requestURL() (*url.URL, error) {
+	u := *f.u
+	if f.provider != "launchdarkly" || path.Base(u.Path) != "contexts" {
+		return &u, nil
+	}
+
+	q := u.Query()
+	ldctx := map[string]interface{}{"kind": "user"}
+	for k := range q {
+		ldctx[k] = q.Get(k)
+	}
+
+	if _, ok := ldctx["key"]; !ok {
+		return nil, fmt.Errorf("launchdarkly flag evaluation requires a 'key' query parameter")
+	}
+
+	b, err := json.Marshal(ldctx)
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	u.Path = path.Join(u.Path, base64.URLEncoding.EncodeToString(b))
+	u.RawQuery = ""
+
+	return &u, nil
+}
+
+// unleashFlags normalizes responses from both Unleash's client API (a list of
+// "features") and its frontend API (a list of evaluated "toggles")
+func unleashFlags(raw interface{}) (map[string]interface{}, error) {
+	resp, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected unleash response of type %T", raw)
+	}
+
+	list, ok := resp["features"].([]interface{})
+	if !ok {
+		list, ok = resp["toggles"].([]interface{})
+	}
+	if !ok {
+		return nil, fmt.Errorf("unexpected unleash response: no features or toggles found")
+	}
+
+	flags := make(map[string]interface{}, len(list))
+	for _, item := range list {
+		flag, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		name, ok := flag["name"].(string)
+		if !ok {
+			continue
+		}
+
+		delete(flag, "name")
+		flags[name] = flag
+	}
+
+	return flags, nil
+}
+
+// launchDarklyFlags normalizes responses from both LaunchDarkly's server-side
+// SDK endpoint (flag configurations under a "flags" key) and its client-side
+// evaluation endpoint (evaluated flags keyed by name)
+func launchDarklyFlags(raw interface{}) (map[string]interface{}, error) {
+	resp, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected launchdarkly response of type %T", raw)
+	}
+
+	if flags, ok := resp["flags"].(map[string]interface{}); ok {
+		return flags, nil
+	}
+
+	return resp, nil
+}
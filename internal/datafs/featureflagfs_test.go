@@ -0,0 +1,133 @@
+package datafs
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/hairyhenderson/go-fsimpl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFeatureFlagFS(t *testing.T) {
+	for _, s := range []string{"launchdarkly+https", "unleash+http"} {
+		fsys, err := NewFeatureFlagFS(&url.URL{Scheme: s, Host: "example.com"})
+		require.NoError(t, err)
+		assert.IsType(t, &featureFlagFS{}, fsys)
+	}
+
+	for _, s := range []string{"launchdarkly", "unleash+ftp", "flags+https"} {
+		_, err := NewFeatureFlagFS(&url.URL{Scheme: s, Host: "example.com"})
+		require.Error(t, err)
+	}
+}
+
+func TestFeatureFlagFS_Unleash(t *testing.T) {
+	t.Setenv("UNLEASH_API_TOKEN", "default:production.secret")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "default:production.secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/api/client/features":
+			w.Write([]byte(`{"version":2,"features":[
+				{"name":"new-ui","enabled":true,"strategies":[{"name":"default"}]},
+				{"name":"beta","enabled":false,"strategies":[]}]}`))
+		case "/api/frontend":
+			assert.Equal(t, "user1", r.URL.Query().Get("userId"))
+			w.Write([]byte(`{"toggles":[
+				{"name":"new-ui","enabled":true,"variant":{"name":"blue","enabled":true}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	u, _ := url.Parse(strings.Replace(srv.URL, "http", "unleash+http", 1))
+
+	fsys, err := NewFeatureFlagFS(u)
+	require.NoError(t, err)
+
+	b, err := fs.ReadFile(fsys, "api/client/features")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"new-ui": {"enabled": true, "strategies": [{"name": "default"}]},
+		"beta": {"enabled": false, "strategies": []}
+	}`, string(b))
+
+	fi, err := fs.Stat(fsys, "api/client/features")
+	require.NoError(t, err)
+	assert.Equal(t, "application/json", fsimpl.ContentType(fi))
+
+	u.RawQuery = "userId=user1"
+	fsys, err = NewFeatureFlagFS(u)
+	require.NoError(t, err)
+
+	b, err = fs.ReadFile(fsys, "api/frontend")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"new-ui": {"enabled": true, "variant": {"name": "blue", "enabled": true}}}`, string(b))
+
+	_, err = fs.ReadFile(fsys, "api/bogus")
+	require.Error(t, err)
+
+	// an explicit header takes precedence over the environment variable
+	fsys = fsys.(*featureFlagFS).WithHeader(http.Header{"Authorization": {"wrong"}})
+	_, err = fs.ReadFile(fsys, "api/frontend")
+	require.Error(t, err)
+}
+
+func TestFeatureFlagFS_LaunchDarkly(t *testing.T) {
+	t.Setenv("LAUNCHDARKLY_SDK_KEY", "sdk-123")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/sdk/latest-all":
+			assert.Equal(t, "sdk-123", r.Header.Get("Authorization"))
+			w.Write([]byte(`{"flags":{"new-ui":{"key":"new-ui","on":true}},"segments":{}}`))
+		case strings.HasPrefix(r.URL.Path, "/sdk/evalx/env-1/contexts/"):
+			b, err := base64.URLEncoding.DecodeString(path.Base(r.URL.Path))
+			require.NoError(t, err)
+
+			ldctx := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal(b, &ldctx))
+			assert.Equal(t, map[string]interface{}{"kind": "user", "key": "u1", "plan": "pro"}, ldctx)
+			assert.Empty(t, r.URL.RawQuery)
+
+			w.Write([]byte(`{"new-ui":{"value":true,"variation":0,"version":4}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	u, _ := url.Parse(strings.Replace(srv.URL, "http", "launchdarkly+http", 1))
+
+	fsys, err := NewFeatureFlagFS(u)
+	require.NoError(t, err)
+
+	b, err := fs.ReadFile(fsys, "sdk/latest-all")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"new-ui": {"key": "new-ui", "on": true}}`, string(b))
+
+	// evaluation requires a context key
+	_, err = fs.ReadFile(fsys, "sdk/evalx/env-1/contexts")
+	require.Error(t, err)
+
+	u.RawQuery = "key=u1&plan=pro"
+	fsys, err = NewFeatureFlagFS(u)
+	require.NoError(t, err)
+
+	b, err = fs.ReadFile(fsys, "sdk/evalx/env-1/contexts")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"new-ui": {"value": true, "variation": 0, "version": 4}}`, string(b))
+}
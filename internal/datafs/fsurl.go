@@ -30,6 +30,15 @@ func SplitFSMuxURL(in *url.URL) (*url.URL, string) {
 		} else {
 			return &url.URL{Scheme: u.Scheme, Path: "/"}, strings.TrimLeft(u.Path, "/")
 		}
+	case "geoip":
+		// An opaque geoip URL references a file relative to the working
+		// directory, so the filesystem must not be rooted at "/"
+		if u.Opaque != "" {
+			base := u.Opaque
+			u.Opaque = ""
+
+			return &u, base
+		}
 	}
 
 	// trim leading and trailing slashes - they are not part of a valid path
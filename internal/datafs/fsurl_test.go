@@ -44,6 +44,16 @@ func TestSplitFSMuxURL(t *testing.T) {
 			"vault:///",
 			"foo/bar",
 		},
+		{
+			"geoip:///usr/share/GeoIP/GeoLite2-ASN.mmdb?ip=1.2.3.4",
+			"geoip:///?ip=1.2.3.4",
+			"usr/share/GeoIP/GeoLite2-ASN.mmdb",
+		},
+		{
+			"geoip:GeoLite2-ASN.mmdb?ip=1.2.3.4",
+			"geoip:?ip=1.2.3.4",
+			"GeoLite2-ASN.mmdb",
+		},
 		{
 			"consul://myhost/foo/bar/baz?q=1",
 			"consul://myhost/?q=1",
@@ -57,6 +57,9 @@ func FSysForPath(ctx context.Context, path string) (fs.FS, error) {
 		// no-op, these are handled
 	case "aws+sm":
 		// An aws+sm URL can be opaque, best not disturb it
+	case "geoip":
+		// An opaque geoip URL references a file relative to the working
+		// directory, so it must not be rooted
 	case "", "file", "git+file":
 		// default to "/" so we have a rooted filesystem for all schemes, but also
 		// support volumes on Windows
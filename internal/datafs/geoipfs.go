@@ -0,0 +1,113 @@
+package datafs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"path"
+	"time"
+
+	osfs "github.com/hack-pad/hackpadfs/os"
+	"github.com/hairyhenderson/go-fsimpl"
+	"github.com/hairyhenderson/gomplate/v4/geoip"
+	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
+)
+
+// NewGeoIPFS returns a filesystem (an fs.FS) that can be used to look up IP
+// addresses in local MaxMind DB (MMDB) files. The address to look up is given
+// in the URL's "ip" query parameter - when it is omitted, the database's
+// metadata is returned instead.
+//
+// An opaque URL (like "geoip:GeoLite2-Country.mmdb") references a file
+// relative to the working directory.
+func NewGeoIPFS(u *url.URL) (fs.FS, error) {
+	if u.Scheme != "geoip" {
+		return nil, fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+
+	return &geoipFS{
+		root: u.Path,
+		ip:   u.Query().Get("ip"),
+		fsys: WrapWdFS(osfs.NewFS()),
+	}, nil
+}
+
+type geoipFS struct {
+	fsys fs.FS
+	root string
+	ip   string
+}
+
+//nolint:gochecknoglobals
+var GeoIPFS = fsimpl.FSProviderFunc(NewGeoIPFS, "geoip")
+
+var _ fs.FS = (*geoipFS)(nil)
+
+func (f *geoipFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{
+			Op:   "open",
+			Path: name,
+			Err:  fs.ErrInvalid,
+		}
+	}
+
+	db, err := geoip.Open(f.fsys, path.Join(f.root, name))
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	md := db.Metadata
+
+	var out interface{} = map[string]interface{}{
+		"database_type":               md.DatabaseType,
+		"description":                 md.Description,
+		"languages":                   md.Languages,
+		"ip_version":                  md.IPVersion,
+		"build_epoch":                 md.BuildEpoch,
+		"node_count":                  md.NodeCount,
+		"record_size":                 md.RecordSize,
+		"binary_format_major_version": md.BinaryFormatMajorVersion,
+		"binary_format_minor_version": md.BinaryFormatMinorVersion,
+	}
+	if f.ip != "" {
+		out, err = geoip.Lookup(db, f.ip)
+		if err != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+	}
+
+	b, err := json.Marshal(out)
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	modTime := time.Unix(int64(md.BuildEpoch), 0) //nolint:gosec
+
+	return &geoipFile{
+		body: bytes.NewReader(b),
+		fi:   FileInfo(name, int64(len(b)), 0o444, modTime, iohelpers.JSONMimetype),
+	}, nil
+}
+
+type geoipFile struct {
+	body io.Reader
+	fi   fs.FileInfo
+}
+
+var _ fs.File = (*geoipFile)(nil)
+
+func (f *geoipFile) Close() error {
+	return nil
+}
+
+func (f *geoipFile) Stat() (fs.FileInfo, error) {
+	return f.fi, nil
+}
+
+func (f *geoipFile) Read(p []byte) (int, error) {
+	return f.body.Read(p)
+}
@@ -0,0 +1,50 @@
+package datafs
+
+import (
+	"io/fs"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeoIPFS(t *testing.T) {
+	dbPath, err := filepath.Abs("../../geoip/testdata/test.mmdb")
+	require.NoError(t, err)
+
+	dbPath = strings.TrimPrefix(filepath.ToSlash(dbPath), "/")
+
+	u, _ := url.Parse("geoip:///?ip=81.2.69.142")
+	fsys, err := NewGeoIPFS(u)
+	require.NoError(t, err)
+
+	b, err := fs.ReadFile(fsys, dbPath)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"country": {"iso_code": "GB", "names": {"en": "United Kingdom"}},
+		"autonomous_system_number": 20712,
+		"autonomous_system_organization": "Andrews & Arnold Ltd"
+	}`, string(b))
+
+	fi, err := fs.Stat(fsys, dbPath)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1700000000), fi.ModTime().Unix())
+
+	// without an IP, the metadata is returned
+	u, _ = url.Parse("geoip:///")
+	fsys, err = NewGeoIPFS(u)
+	require.NoError(t, err)
+
+	b, err = fs.ReadFile(fsys, dbPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"database_type":"Gomplate-Test"`)
+
+	_, err = fsys.Open("bogus.mmdb")
+	require.Error(t, err)
+
+	_, err = NewGeoIPFS(&url.URL{Scheme: "file"})
+	require.Error(t, err)
+}
@@ -0,0 +1,218 @@
+package datafs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	osfs "github.com/hack-pad/hackpadfs/os"
+	"github.com/hairyhenderson/go-fsimpl"
+	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
+)
+
+// NewJWTEnvFS returns a filesystem (an fs.FS) that can be used to decode JSON
+// Web Tokens (JWTs) held in environment variables, presenting their claims as
+// a JSON object.
+//
+// The variable can be named by the URL's host (as in "jwt+env://TOKEN") or
+// path (as in "jwt+env:TOKEN"). By default the token's signature is not
+// verified - set the "jwks" query parameter to the URL of a JSON Web Key Set
+// to verify the signature and the token's time-based claims.
+func NewJWTEnvFS(u *url.URL) (fs.FS, error) {
+	if u.Scheme != "jwt+env" {
+		return nil, fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+
+	return &jwtEnvFS{
+		ctx:     context.Background(),
+		client:  http.DefaultClient,
+		envfs:   WrapWdFS(osfs.NewFS()),
+		varName: u.Host,
+		jwksURL: u.Query().Get("jwks"),
+	}, nil
+}
+
+type jwtEnvFS struct {
+	ctx     context.Context
+	client  *http.Client
+	envfs   fs.FS
+	varName string
+	jwksURL string
+}
+
+//nolint:gochecknoglobals
+var JWTEnvFS = fsimpl.FSProviderFunc(NewJWTEnvFS, "jwt+env")
+
+var (
+	_ fs.FS         = (*jwtEnvFS)(nil)
+	_ withContexter = (*jwtEnvFS)(nil)
+)
+
+// jwtAlgorithms are the signature algorithms accepted when parsing tokens
+//
+//nolint:gochecknoglobals
+var jwtAlgorithms = []jose.SignatureAlgorithm{
+	jose.EdDSA,
+	jose.HS256, jose.HS384, jose.HS512,
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.PS256, jose.PS384, jose.PS512,
+}
+
+func (f *jwtEnvFS) WithContext(ctx context.Context) fs.FS {
+	if ctx == nil {
+		return f
+	}
+
+	fsys := *f
+	fsys.ctx = ctx
+
+	return &fsys
+}
+
+func (f *jwtEnvFS) WithHTTPClient(client *http.Client) fs.FS {
+	if client == nil {
+		return f
+	}
+
+	fsys := *f
+	fsys.client = client
+
+	return &fsys
+}
+
+func (f *jwtEnvFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{
+			Op:   "open",
+			Path: name,
+			Err:  fs.ErrInvalid,
+		}
+	}
+
+	varName := f.varName
+	if varName == "" {
+		varName = name
+	}
+
+	if varName == "." {
+		return nil, &fs.PathError{
+			Op:   "open",
+			Path: name,
+			Err:  fmt.Errorf("environment variable name required"),
+		}
+	}
+
+	token, ok := LookupEnvFsys(f.envfs, varName)
+	if !ok || token == "" {
+		return nil, &fs.PathError{Op: "open", Path: varName, Err: fs.ErrNotExist}
+	}
+
+	claims, err := f.claims(strings.TrimSpace(token))
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: varName, Err: err}
+	}
+
+	b, err := json.Marshal(claims)
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	return &jwtEnvFile{
+		body: bytes.NewReader(b),
+		fi:   FileInfo(varName, int64(len(b)), 0o444, time.Time{}, iohelpers.JSONMimetype),
+	}, nil
+}
+
+// claims decodes the token's claims, verifying the token first if a JWKS URL
+// has been configured
+func (f *jwtEnvFS) claims(token string) (map[string]interface{}, error) {
+	tok, err := jwt.ParseSigned(token, jwtAlgorithms)
+	if err != nil {
+		return nil, fmt.Errorf("parse JWT: %w", err)
+	}
+
+	claims := map[string]interface{}{}
+
+	if f.jwksURL == "" {
+		err = tok.UnsafeClaimsWithoutVerification(&claims)
+		if err != nil {
+			return nil, fmt.Errorf("decode JWT claims: %w", err)
+		}
+
+		return claims, nil
+	}
+
+	jwks, err := f.fetchJWKS()
+	if err != nil {
+		return nil, err
+	}
+
+	registered := jwt.Claims{}
+
+	err = tok.Claims(jwks, &claims, &registered)
+	if err != nil {
+		return nil, fmt.Errorf("verify JWT: %w", err)
+	}
+
+	err = registered.ValidateWithLeeway(jwt.Expected{Time: time.Now()}, jwt.DefaultLeeway)
+	if err != nil {
+		return nil, fmt.Errorf("validate JWT: %w", err)
+	}
+
+	return claims, nil
+}
+
+func (f *jwtEnvFS) fetchJWKS() (*jose.JSONWebKeySet, error) {
+	req, err := http.NewRequestWithContext(f.ctx, http.MethodGet, f.jwksURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("JWKS request: %w", err)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("fetch JWKS: http GET failed with status %d", resp.StatusCode)
+	}
+
+	jwks := &jose.JSONWebKeySet{}
+
+	err = json.NewDecoder(resp.Body).Decode(jwks)
+	if err != nil {
+		return nil, fmt.Errorf("decode JWKS: %w", err)
+	}
+
+	return jwks, nil
+}
+
+type jwtEnvFile struct {
+	body io.Reader
+	fi   fs.FileInfo
+}
+
+var _ fs.File = (*jwtEnvFile)(nil)
+
+func (f *jwtEnvFile) Close() error {
+	return nil
+}
+
+func (f *jwtEnvFile) Stat() (fs.FileInfo, error) {
+	return f.fi, nil
+}
+
+func (f *jwtEnvFile) Read(p []byte) (int, error) {
+	return f.body.Read(p)
+}
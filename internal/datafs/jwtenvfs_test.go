@@ -0,0 +1,110 @@
+package datafs
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signTestJWT(t *testing.T, key *rsa.PrivateKey, claims interface{}) string {
+	t.Helper()
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", "test"))
+	require.NoError(t, err)
+
+	token, err := jwt.Signed(signer).Claims(claims).Serialize()
+	require.NoError(t, err)
+
+	return token
+}
+
+func TestJWTEnvFS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	token := signTestJWT(t, key, map[string]interface{}{
+		"sub": "repo:example/app:ref:refs/heads/main",
+		"ref": "refs/heads/main",
+	})
+	t.Setenv("CI_ID_TOKEN", token)
+
+	u, _ := url.Parse("jwt+env://CI_ID_TOKEN")
+	fsys, err := NewJWTEnvFS(u)
+	require.NoError(t, err)
+
+	b, err := fs.ReadFile(fsys, ".")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"sub":"repo:example/app:ref:refs/heads/main","ref":"refs/heads/main"}`, string(b))
+
+	// the variable may also be named in the path
+	u, _ = url.Parse("jwt+env:///")
+	fsys, err = NewJWTEnvFS(u)
+	require.NoError(t, err)
+
+	b, err = fs.ReadFile(fsys, "CI_ID_TOKEN")
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"ref":"refs/heads/main"`)
+
+	_, err = fs.ReadFile(fsys, "MISSING_TOKEN")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	t.Setenv("NOT_A_TOKEN", "foo")
+	_, err = fs.ReadFile(fsys, "NOT_A_TOKEN")
+	require.Error(t, err)
+
+	_, err = NewJWTEnvFS(&url.URL{Scheme: "env"})
+	require.Error(t, err)
+}
+
+func TestJWTEnvFS_Verify(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		jwks := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: key.Public(), KeyID: "test", Algorithm: string(jose.RS256), Use: "sig"},
+		}}
+		_ = json.NewEncoder(w).Encode(jwks)
+	}))
+	t.Cleanup(srv.Close)
+
+	u, _ := url.Parse("jwt+env://CI_ID_TOKEN?jwks=" + url.QueryEscape(srv.URL))
+	fsys, err := NewJWTEnvFS(u)
+	require.NoError(t, err)
+
+	now := time.Now()
+
+	t.Setenv("CI_ID_TOKEN", signTestJWT(t, key, map[string]interface{}{
+		"sub": "deployer", "exp": now.Add(time.Hour).Unix(),
+	}))
+	b, err := fs.ReadFile(fsys, ".")
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"sub":"deployer"`)
+
+	// expired
+	t.Setenv("CI_ID_TOKEN", signTestJWT(t, key, map[string]interface{}{
+		"sub": "deployer", "exp": now.Add(-time.Hour).Unix(),
+	}))
+	_, err = fs.ReadFile(fsys, ".")
+	require.Error(t, err)
+
+	// signed with the wrong key
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	t.Setenv("CI_ID_TOKEN", signTestJWT(t, otherKey, map[string]interface{}{"sub": "deployer"}))
+	_, err = fs.ReadFile(fsys, ".")
+	require.Error(t, err)
+}
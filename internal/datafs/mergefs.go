@@ -3,6 +3,7 @@ package datafs
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -10,24 +11,46 @@ import (
 	"net/http"
 	"net/url"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/evanphx/json-patch/v5"
 	"github.com/hairyhenderson/go-fsimpl"
 	"github.com/hairyhenderson/gomplate/v4/coll"
+	"github.com/hairyhenderson/gomplate/v4/conv"
 	"github.com/hairyhenderson/gomplate/v4/internal/config"
 	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
 	"github.com/hairyhenderson/gomplate/v4/internal/parsers"
 	"github.com/hairyhenderson/gomplate/v4/internal/urlhelpers"
+	"github.com/hairyhenderson/yaml"
 )
 
 // NewMergeFS returns a new filesystem that merges the contents of multiple
 // paths. Only a URL like "merge:" or "merge:///" makes sense here - the
 // piped-separated lists of sub-sources to merge must be given to Open.
 //
+// Merge options can be given in the URL's query:
+//   - arrays - how lists are combined (append, replace, unique, or byIndex)
+//   - listKey - comma-separated keys identifying items in lists of maps, which
+//     are merged by key rather than combined
+//   - nulls - whether null values are kept (keep), or delete keys from
+//     lower-priority sources (delete), like JSON Merge Patch
+//   - depth - how many levels of nested maps and lists are merged (0, the
+//     default, is unlimited)
+//   - coerce - whether scalar values are converted to the type of the
+//     lower-priority values they replace, where possible (true or false)
+//   - format - the format of the merged output (json, toml, or yaml - the
+//     default)
+//   - preserve - when true, YAML output keeps the comments, anchors and
+//     aliases, and key order of the lowest-priority sub-source (when it's
+//     YAML), wherever its values are unchanged
+//
 // You can use WithDataSourceRegistryFS to provide the datasource registry,
-// otherwise, an empty registry will be used.
+// otherwise, an empty registry will be used. Parsed sub-sources can be shared
+// between merges with withMergeCacheFS, otherwise they're read every time. A
+// custom MergeFunc can be provided with a context using ContextWithMergeFunc.
 //
 // An FSProvider will also be needed, which can be provided with a context
 // using ContextWithFSProvider. Provide that context with fsimpl.WithContextFS.
@@ -36,16 +59,89 @@ func NewMergeFS(u *url.URL) (fs.FS, error) {
 		return nil, fmt.Errorf("unsupported scheme %q", u.Scheme)
 	}
 
+	opts, err := coll.ParseMergeOptions(u.Query())
+	if err != nil {
+		return nil, err
+	}
+
+	format, err := mergeFormat(u.Query().Get("format"))
+	if err != nil {
+		return nil, err
+	}
+
+	preserve := false
+	if p := u.Query().Get("preserve"); p != "" {
+		preserve, err = strconv.ParseBool(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid preserve option %q: must be true or false", p)
+		}
+	}
+
 	return &mergeFS{
 		ctx:      context.Background(),
 		registry: NewRegistry(),
+		opts:     opts,
+		format:   format,
+		preserve: preserve,
 	}, nil
 }
 
+// MergeFunc merges the parsed documents read from a merge datasource's
+// sub-sources, in order of precedence (the left-most first). Documents can be
+// of any type, including nil.
+//
+// JSON Patch sub-sources are not given to the function - instead, it's called
+// for the documents between each patch, with the patched result of the
+// documents to their right as the last document.
+type MergeFunc func(docs []interface{}) (interface{}, error)
+
+type mergeFuncCtxKey struct{}
+
+// ContextWithMergeFunc injects a MergeFunc into the context, which merge
+// datasources will use instead of the default merge behaviour.
+func ContextWithMergeFunc(ctx context.Context, merge MergeFunc) context.Context {
+	return context.WithValue(ctx, mergeFuncCtxKey{}, merge)
+}
+
+// MergeFuncFromContext returns the MergeFunc injected by ContextWithMergeFunc,
+// or nil if there isn't one.
+func MergeFuncFromContext(ctx context.Context) MergeFunc {
+	if merge, ok := ctx.Value(mergeFuncCtxKey{}).(MergeFunc); ok {
+		return merge
+	}
+
+	return nil
+}
+
+// docMerger returns a MergeFunc which merges documents with mergeDocs
+func docMerger(opts coll.MergeOptions) MergeFunc {
+	return func(docs []interface{}) (interface{}, error) {
+		return mergeDocs(docs, opts)
+	}
+}
+
+// mergeFormat returns the content type of the merged output, given its name
+func mergeFormat(name string) (string, error) {
+	switch strings.ToLower(name) {
+	case "", "yaml":
+		return iohelpers.YAMLMimetype, nil
+	case "json":
+		return iohelpers.JSONMimetype, nil
+	case "toml":
+		return iohelpers.TOMLMimetype, nil
+	default:
+		return "", fmt.Errorf("invalid format option %q: must be json, toml, or yaml", name)
+	}
+}
+
 type mergeFS struct {
 	ctx        context.Context
 	httpClient *http.Client
 	registry   Registry
+	cache      *mergeCache
+	opts       coll.MergeOptions
+	format     string
+	preserve   bool
 }
 
 //nolint:gochecknoglobals
@@ -55,6 +151,7 @@ var (
 	_ fs.FS                    = (*mergeFS)(nil)
 	_ withContexter            = (*mergeFS)(nil)
 	_ withDataSourceRegistryer = (*mergeFS)(nil)
+	_ withMergeCacher          = (*mergeFS)(nil)
 )
 
 func (f *mergeFS) WithContext(ctx context.Context) fs.FS {
@@ -90,6 +187,17 @@ func (f *mergeFS) WithDataSourceRegistry(registry Registry) fs.FS {
 	return &fsys
 }
 
+func (f *mergeFS) WithMergeCache(cache *mergeCache) fs.FS {
+	if cache == nil {
+		return f
+	}
+
+	fsys := *f
+	fsys.cache = cache
+
+	return &fsys
+}
+
 func (f *mergeFS) Open(name string) (fs.File, error) {
 	parts := strings.Split(name, "|")
 	if len(parts) < 2 {
@@ -100,11 +208,14 @@ func (f *mergeFS) Open(name string) (fs.File, error) {
 	}
 
 	// now open each of the sub-files
-	subFiles := make([]subFile, len(parts))
+	subFiles := make([]subFile, 0, len(parts))
 
 	modTime := time.Time{}
 
-	for i, part := range parts {
+	for _, part := range parts {
+		// sources flagged as optional are skipped when they don't exist
+		part, optional := cutOptional(part)
+
 		// if this is a datasource, look it up
 		subSource, ok := f.registry.Lookup(part)
 		if !ok {
@@ -116,7 +227,9 @@ func (f *mergeFS) Open(name string) (fs.File, error) {
 			subSource = config.DataSource{URL: u}
 		}
 
-		u := subSource.URL
+		// copy the URL, so the registered datasource isn't modified
+		su := *subSource.URL
+		u := &su
 
 		// possible type hint in the type query param. Contrary to spec, we allow
 		// unescaped '+' characters to make it simpler to provide types like
@@ -151,27 +264,175 @@ func (f *mergeFS) Open(name string) (fs.File, error) {
 
 		fsys = fsimpl.WithHTTPClientFS(f.httpClient, fsys)
 
-		f, err := fsys.Open(base)
-		if err != nil {
-			return nil, &fs.PathError{
-				Op: "open", Path: name,
-				Err: fmt.Errorf("opening merge part %q: %w", part, err),
+		// glob patterns expand to all matching files, in lexical order
+		names := []string{base}
+		if hasGlobMeta(base) {
+			names, err = fs.Glob(fsys, base)
+			if err != nil {
+				return nil, &fs.PathError{
+					Op: "open", Path: name,
+					Err: fmt.Errorf("expanding merge part %q: %w", part, err),
+				}
 			}
 		}
 
-		subFiles[i] = subFile{f, mimeTypeHint}
+		for _, n := range names {
+			key := mergeCacheKey(fsURL, n, mimeTypeHint, subSource.Header)
+
+			// sub-sources already read in this render don't need to be opened
+			if cached, ok := f.cache.get(key); ok {
+				subFiles = append(subFiles, subFile{cacheKey: key, cached: &cached})
+				continue
+			}
+
+			sf, err := fsys.Open(n)
+			if err != nil && optional && errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			if err != nil {
+				return nil, &fs.PathError{
+					Op: "open", Path: name,
+					Err: fmt.Errorf("opening merge part %q: %w", part, err),
+				}
+			}
+
+			subFiles = append(subFiles, subFile{
+				File: sf, contentType: mimeTypeHint, cacheKey: key, optional: optional,
+			})
+		}
+	}
+
+	if len(subFiles) == 0 {
+		return nil, &fs.PathError{
+			Op: "open", Path: name,
+			Err: fmt.Errorf("no datasources to merge: %w", fs.ErrNotExist),
+		}
 	}
 
 	return &mergeFile{
 		name:     name,
 		subFiles: subFiles,
 		modTime:  modTime,
+		cache:    f.cache,
+		opts:     f.opts,
+		merge:    MergeFuncFromContext(f.ctx),
+		format:   f.format,
+		preserve: f.preserve,
 	}, nil
 }
 
+// cutOptional removes the "optional" flag from the merge part's query string,
+// returning the part without it, and whether the part is optional. The flag can
+// be given alone (as in "foo.yaml?optional") or with a boolean value. The rest
+// of the part is left untouched.
+func cutOptional(part string) (string, bool) {
+	base, query, ok := strings.Cut(part, "?")
+	if !ok {
+		return part, false
+	}
+
+	optional := false
+	params := []string{}
+
+	for _, p := range strings.Split(query, "&") {
+		k, v, hasValue := strings.Cut(p, "=")
+		if k != "optional" {
+			params = append(params, p)
+			continue
+		}
+
+		optional = !hasValue || conv.ToBool(v)
+	}
+
+	if len(params) == 0 {
+		return base, optional
+	}
+
+	return base + "?" + strings.Join(params, "&"), optional
+}
+
+// splitMergeQuery moves the query strings of the datasources listed in a merge
+// URL back into its opaque part, since only the query after the last one
+// belongs to the merge URL itself. Other URLs are returned unchanged.
+func splitMergeQuery(u *url.URL) *url.URL {
+	if u.Scheme != "merge" || u.Opaque == "" {
+		return u
+	}
+
+	i := strings.LastIndex(u.RawQuery, "|")
+	if i < 0 {
+		return u
+	}
+
+	out := *u
+	last, q, _ := strings.Cut(u.RawQuery[i+1:], "?")
+	out.Opaque += "?" + u.RawQuery[:i+1] + last
+	out.RawQuery = q
+
+	return &out
+}
+
+// hasGlobMeta returns true if the path contains any glob pattern characters.
+// "?" isn't included, since it always starts the query string in a URL.
+func hasGlobMeta(p string) bool {
+	return strings.ContainsAny(p, `*[`)
+}
+
 type subFile struct {
 	fs.File
 	contentType string
+	cacheKey    string
+	optional    bool
+	// the sub-file's content, when it's already been read - File is nil
+	cached *cachedPart
+}
+
+// mergeCache holds the parsed content of merge sub-sources, so that sources
+// used in several merges within a render are only read and parsed once. A nil
+// cache caches nothing.
+type mergeCache struct {
+	parts map[string]cachedPart
+	mu    sync.Mutex
+}
+
+type cachedPart struct {
+	modTime time.Time
+	part    mergePart
+}
+
+func newMergeCache() *mergeCache {
+	return &mergeCache{parts: map[string]cachedPart{}}
+}
+
+func (c *mergeCache) get(key string) (cachedPart, bool) {
+	if c == nil {
+		return cachedPart{}, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p, ok := c.parts[key]
+
+	return p, ok
+}
+
+func (c *mergeCache) put(key string, p cachedPart) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.parts[key] = p
+}
+
+// mergeCacheKey identifies a sub-source by everything that affects how it's
+// read and parsed
+func mergeCacheKey(fsURL *url.URL, name, contentType string, header http.Header) string {
+	return fmt.Sprintf("%s\x00%s\x00%s\x00%v", fsURL, name, contentType, header)
 }
 
 type mergeFile struct {
@@ -180,6 +441,11 @@ type mergeFile struct {
 	fi       fs.FileInfo
 	modTime  time.Time // the modTime of the most recently modified sub-file
 	subFiles []subFile
+	cache    *mergeCache
+	opts     coll.MergeOptions
+	merge    MergeFunc // a custom merge function, overriding opts
+	format   string    // the content type of the merged output
+	preserve bool      // whether YAML output preserves the base sub-source's formatting
 	readMux  sync.Mutex
 }
 
@@ -187,7 +453,9 @@ var _ fs.File = (*mergeFile)(nil)
 
 func (f *mergeFile) Close() error {
 	for _, f := range f.subFiles {
-		f.Close()
+		if f.File != nil {
+			f.Close()
+		}
 	}
 	return nil
 }
@@ -211,88 +479,267 @@ func (f *mergeFile) Read(p []byte) (int, error) {
 		defer f.readMux.Unlock()
 
 		// read from all and merge
-		data := make([]map[string]any, len(f.subFiles))
-		for i, sf := range f.subFiles {
-			d, err := f.readSubFile(sf)
+		parts := make([]mergePart, 0, len(f.subFiles))
+		for _, sf := range f.subFiles {
+			part, err := f.readSubFile(sf)
+			if err != nil && sf.optional && errors.Is(err, fs.ErrNotExist) {
+				// some filesystems don't fail until the file is read
+				continue
+			}
+
 			if err != nil {
 				return 0, fmt.Errorf("readSubFile: %w", err)
 			}
 
-			data[i] = d
+			parts = append(parts, part)
+		}
+
+		if f.format == "" {
+			f.format = iohelpers.YAMLMimetype
 		}
 
-		md, err := mergeData(data)
+		merge := f.merge
+		if merge == nil {
+			merge = docMerger(f.opts)
+		}
+
+		md, err := mergeData(parts, merge, f.format, f.preserve)
 		if err != nil {
 			return 0, fmt.Errorf("mergeData: %w", err)
 		}
 
 		f.merged = bytes.NewReader(md)
 
-		f.fi = FileInfo(f.name, int64(len(md)), 0o400, f.modTime, iohelpers.YAMLMimetype)
+		f.fi = FileInfo(f.name, int64(len(md)), 0o400, f.modTime, f.format)
 	}
 
 	return f.merged.Read(p)
 }
 
-func (f *mergeFile) readSubFile(sf subFile) (map[string]any, error) {
-	// stat for content type and modTime
-	fi, err := sf.Stat()
-	if err != nil {
-		return nil, fmt.Errorf("stat merge part %q: %w", f.name, err)
+// mergePart is the parsed content of a sub-file - either data to merge, or a
+// JSON Patch to apply to the data merged from the sub-files to its right
+type mergePart struct {
+	data    interface{}
+	patch   jsonpatch.Patch
+	isPatch bool
+	yaml    string // the unparsed document, for YAML sub-files
+}
+
+func (f *mergeFile) readSubFile(sf subFile) (mergePart, error) {
+	if sf.cached == nil {
+		part, modTime, err := f.parseSubFile(sf)
+		if err != nil {
+			return mergePart{}, err
+		}
+
+		sf.cached = &cachedPart{part: part, modTime: modTime}
+		f.cache.put(sf.cacheKey, *sf.cached)
 	}
 
 	// the merged file's modTime is the most recent of all the sub-files
-	if fi.ModTime().After(f.modTime) {
-		f.modTime = fi.ModTime()
+	if sf.cached.modTime.After(f.modTime) {
+		f.modTime = sf.cached.modTime
+	}
+
+	return sf.cached.part, nil
+}
+
+// parseSubFile reads and parses the sub-file, returning its content and modTime
+func (f *mergeFile) parseSubFile(sf subFile) (mergePart, time.Time, error) {
+	// stat for content type and modTime
+	fi, err := sf.Stat()
+	if err != nil {
+		return mergePart{}, time.Time{}, fmt.Errorf("stat merge part %q: %w", f.name, err)
 	}
 
 	// if we haven't been given a content type hint, guess the normal way
 	if sf.contentType == "" {
-		sf.contentType = fsimpl.ContentType(fi)
+		sf.contentType = contentType(fi)
 	}
 
 	b, err := io.ReadAll(sf)
 	if err != nil && !errors.Is(err, io.EOF) {
-		return nil, fmt.Errorf("readAll: %w", err)
+		return mergePart{}, time.Time{}, fmt.Errorf("readAll: %w", err)
 	}
 
-	sfData, err := parseMap(sf.contentType, string(b))
+	if iohelpers.MimeAlias(sf.contentType) == iohelpers.JSONPatchMimetype {
+		patch, perr := jsonpatch.DecodePatch(b)
+		if perr != nil {
+			return mergePart{}, time.Time{}, fmt.Errorf("parsing JSON Patch: %w", perr)
+		}
+
+		return mergePart{patch: patch, isPatch: true}, fi.ModTime(), nil
+	}
+
+	sfData, err := parsers.ParseData(sf.contentType, string(b))
 	if err != nil {
-		return nil, fmt.Errorf("parsing map with content type %s: %w", sf.contentType, err)
+		return mergePart{}, time.Time{}, fmt.Errorf("parsing data with content type %s: %w", sf.contentType, err)
 	}
 
-	return sfData, nil
-}
+	part := mergePart{data: sfData}
+	if iohelpers.MimeAlias(sf.contentType) == iohelpers.YAMLMimetype {
+		part.yaml = string(b)
+	}
 
-func mergeData(data []map[string]interface{}) ([]byte, error) {
-	dst := data[0]
-	data = data[1:]
+	return part, fi.ModTime(), nil
+}
 
-	dst, err := coll.Merge(dst, data...)
+// mergeData merges the parts, and marshals the result with the given content
+// type (YAML by default). When preserve is set, YAML output is based on the
+// last (lowest-priority) part, if it's YAML.
+func mergeData(parts []mergePart, merge MergeFunc, format string, preserve bool) ([]byte, error) {
+	dst, err := mergeParts(parts, merge)
 	if err != nil {
 		return nil, err
 	}
 
-	s, err := parsers.ToYAML(dst)
+	var s string
+
+	switch format {
+	case iohelpers.JSONMimetype:
+		s, err = parsers.ToJSONPretty("  ", dst)
+		s += "\n"
+	case iohelpers.TOMLMimetype:
+		s, err = parsers.ToTOML(dst)
+	default:
+		if preserve && len(parts) > 0 && parts[len(parts)-1].yaml != "" {
+			s, err = parsers.ToYAMLWithOptions(dst, parsers.YAMLOptions{Source: parts[len(parts)-1].yaml})
+		} else {
+			s, err = parsers.ToYAML(dst)
+		}
+	}
+
 	if err != nil {
 		return nil, err
 	}
 	return []byte(s), nil
 }
 
-func parseMap(mimeType, data string) (map[string]any, error) {
-	datum, err := parsers.ParseData(mimeType, data)
-	if err != nil {
-		return nil, fmt.Errorf("parseData: %w", err)
+// mergeParts merges the parts from right to left - each JSON Patch is applied
+// to the data merged from the parts to its right
+func mergeParts(parts []mergePart, merge MergeFunc) (interface{}, error) {
+	var merged interface{}
+
+	// whether there's any data merged yet
+	haveData := false
+
+	end := len(parts)
+
+	for i := len(parts) - 1; i >= -1; i-- {
+		if i >= 0 && !parts[i].isPatch {
+			continue
+		}
+
+		// merge the data between this patch and the previous one
+		docs := make([]interface{}, 0, end-i)
+		for _, p := range parts[i+1 : end] {
+			docs = append(docs, p.data)
+		}
+
+		if haveData {
+			docs = append(docs, merged)
+		}
+
+		if len(docs) > 0 {
+			var err error
+
+			merged, err = merge(docs)
+			if err != nil {
+				return nil, err
+			}
+
+			haveData = true
+		}
+
+		if i < 0 {
+			break
+		}
+
+		if !haveData {
+			return nil, fmt.Errorf("JSON Patch must be followed by data to apply it to")
+		}
+
+		var err error
+
+		merged, err = applyJSONPatch(parts[i].patch, merged)
+		if err != nil {
+			return nil, err
+		}
+
+		end = i
+	}
+
+	return merged, nil
+}
+
+// mergeDocs merges top-level documents, in order of precedence. Maps are
+// merged, lists are combined according to the array merge strategy, and for
+// other values the first non-null value is used.
+func mergeDocs(docs []interface{}, opts coll.MergeOptions) (interface{}, error) {
+	var maps []map[string]interface{}
+
+	var lists []map[string]interface{}
+
+	var first interface{}
+
+	for _, doc := range docs {
+		switch doc := doc.(type) {
+		case nil:
+			continue
+		case map[string]interface{}:
+			maps = append(maps, doc)
+		case []interface{}:
+			// lists are wrapped in maps, so they're combined the same way as
+			// nested lists
+			lists = append(lists, map[string]interface{}{"": doc})
+		default:
+			if first == nil {
+				first = doc
+			}
+		}
+
+		if (len(maps) > 0 && len(lists) > 0) || ((len(maps) > 0 || len(lists) > 0) && first != nil) {
+			return nil, fmt.Errorf("unexpected data type '%T' for datasource; merge: can only merge documents of the same type (maps, arrays, or other values)", doc)
+		}
 	}
 
-	var m map[string]any
-	switch datum := datum.(type) {
-	case map[string]any:
-		m = datum
+	switch {
+	case len(maps) > 0:
+		return coll.MergeWithOptions(opts, maps[0], maps[1:]...)
+	case len(lists) > 0:
+		// the wrapping map adds a level
+		if opts.Depth > 0 {
+			opts.Depth++
+		}
+
+		merged, err := coll.MergeWithOptions(opts, lists[0], lists[1:]...)
+		if err != nil {
+			return nil, err
+		}
+
+		return merged[""], nil
 	default:
-		return nil, fmt.Errorf("unexpected data type '%T' for datasource (type %s); merge: can only merge maps", datum, mimeType)
+		return first, nil
+	}
+}
+
+func applyJSONPatch(patch jsonpatch.Patch, data interface{}) (interface{}, error) {
+	doc, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	doc, err = patch.Apply(doc)
+	if err != nil {
+		return nil, fmt.Errorf("applying JSON Patch: %w", err)
+	}
+
+	var out interface{}
+
+	err = yaml.Unmarshal(doc, &out)
+	if err != nil {
+		return nil, fmt.Errorf("applying JSON Patch: %w", err)
 	}
 
-	return m, nil
+	return out, nil
 }
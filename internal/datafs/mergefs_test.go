@@ -13,7 +13,9 @@ import (
 	"testing"
 	"testing/fstest"
 
+	"github.com/evanphx/json-patch/v5"
 	"github.com/hairyhenderson/go-fsimpl"
+	"github.com/hairyhenderson/gomplate/v4/coll"
 	"github.com/hairyhenderson/gomplate/v4/internal/config"
 	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
 	"github.com/stretchr/testify/assert"
@@ -40,6 +42,7 @@ func setupMergeFsys(ctx context.Context, t *testing.T) fs.FS {
 		"tmp/array.json":                   {Data: []byte(arrayContent)},
 		"tmp/yamlfile.yaml":                {Data: []byte(yamlContent)},
 		"tmp/textfile.txt":                 {Data: []byte(`plain text...`)},
+		"tmp/patch.json":                   {Data: []byte(`[{"op": "remove", "path": "/goodnight"}]`)},
 		path.Join(wd, "jsonfile.json"):     {Data: []byte(jsonContent)},
 		path.Join(wd, "array.json"):        {Data: []byte(arrayContent)},
 		path.Join(wd, "yamlfile.yaml"):     {Data: []byte(yamlContent)},
@@ -60,6 +63,9 @@ func setupMergeFsys(ctx context.Context, t *testing.T) fs.FS {
 	reg.Register("badscheme", config.DataSource{URL: mustParseURL("bad:///scheme.json")})
 	// mime type overridden by URL query, should fail to parse
 	reg.Register("badtype", config.DataSource{URL: mustParseURL("file:///tmp/textfile.txt?type=foo/bar")})
+	reg.Register("patch", config.DataSource{
+		URL: mustParseURL("file:///tmp/patch.json?type=" + iohelpers.JSONPatchMimetype),
+	})
 	reg.Register("array", config.DataSource{
 		URL: mustParseURL("file:///tmp/array.json?type=" + url.QueryEscape(iohelpers.JSONArrayMimetype)),
 	})
@@ -96,13 +102,22 @@ func wdForTest(t *testing.T) string {
 	return wd
 }
 
+func dataParts(data ...map[string]interface{}) []mergePart {
+	parts := make([]mergePart, len(data))
+	for i, d := range data {
+		parts[i] = mergePart{data: d}
+	}
+
+	return parts
+}
+
 func TestMergeData(t *testing.T) {
 	def := map[string]interface{}{
 		"f": true,
 		"t": false,
 		"z": "def",
 	}
-	out, err := mergeData([]map[string]interface{}{def})
+	out, err := mergeData(dataParts(def), docMerger(coll.MergeOptions{}), "", false)
 	require.NoError(t, err)
 	assert.Equal(t, "f: true\nt: false\nz: def\n", string(out))
 
@@ -111,7 +126,7 @@ func TestMergeData(t *testing.T) {
 		"t": true,
 		"z": "over",
 	}
-	out, err = mergeData([]map[string]interface{}{over, def})
+	out, err = mergeData(dataParts(over, def), docMerger(coll.MergeOptions{}), "", false)
 	require.NoError(t, err)
 	assert.Equal(t, "f: false\nt: true\nz: over\n", string(out))
 
@@ -123,14 +138,14 @@ func TestMergeData(t *testing.T) {
 			"a": "aaa",
 		},
 	}
-	out, err = mergeData([]map[string]interface{}{over, def})
+	out, err = mergeData(dataParts(over, def), docMerger(coll.MergeOptions{}), "", false)
 	require.NoError(t, err)
 	assert.Equal(t, "f: false\nm:\n  a: aaa\nt: true\nz: over\n", string(out))
 
 	uber := map[string]interface{}{
 		"z": "über",
 	}
-	out, err = mergeData([]map[string]interface{}{uber, over, def})
+	out, err = mergeData(dataParts(uber, over, def), docMerger(coll.MergeOptions{}), "", false)
 	require.NoError(t, err)
 	assert.Equal(t, "f: false\nm:\n  a: aaa\nt: true\nz: über\n", string(out))
 
@@ -140,7 +155,7 @@ func TestMergeData(t *testing.T) {
 			"b": "bbb",
 		},
 	}
-	out, err = mergeData([]map[string]interface{}{uber, over, def})
+	out, err = mergeData(dataParts(uber, over, def), docMerger(coll.MergeOptions{}), "", false)
 	require.NoError(t, err)
 	assert.Equal(t, "f: false\nm: notamap\nt: true\nz:\n  b: bbb\n", string(out))
 
@@ -149,11 +164,113 @@ func TestMergeData(t *testing.T) {
 			"b": "bbb",
 		},
 	}
-	out, err = mergeData([]map[string]interface{}{uber, over, def})
+	out, err = mergeData(dataParts(uber, over, def), docMerger(coll.MergeOptions{}), "", false)
 	require.NoError(t, err)
 	assert.Equal(t, "f: false\nm:\n  a: aaa\n  b: bbb\nt: true\nz: over\n", string(out))
 }
 
+func TestMergeParts(t *testing.T) {
+	patch := func(s string) mergePart {
+		p, err := jsonpatch.DecodePatch([]byte(s))
+		require.NoError(t, err)
+
+		return mergePart{patch: p, isPatch: true}
+	}
+
+	listeners := map[string]interface{}{
+		"listeners": []interface{}{"a", "b", "c"},
+		"port":      80,
+	}
+
+	out, err := mergeParts([]mergePart{
+		{data: map[string]interface{}{"port": 8080}},
+		patch(`[{"op": "remove", "path": "/listeners/2"}, {"op": "add", "path": "/port", "value": 443}]`),
+		{data: map[string]interface{}{"name": "web"}},
+		{data: listeners},
+	}, docMerger(coll.MergeOptions{}))
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{
+		"listeners": []interface{}{"a", "b"},
+		"name":      "web",
+		"port":      8080,
+	}, out)
+
+	// consecutive patches are applied in turn
+	out, err = mergeParts([]mergePart{
+		patch(`[{"op": "replace", "path": "/port", "value": 9090}]`),
+		patch(`[{"op": "test", "path": "/port", "value": 80}, {"op": "remove", "path": "/listeners"}]`),
+		{data: listeners},
+	}, docMerger(coll.MergeOptions{}))
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{"port": 9090}, out)
+
+	_, err = mergeParts([]mergePart{
+		patch(`[{"op": "test", "path": "/port", "value": 81}]`),
+		{data: listeners},
+	}, docMerger(coll.MergeOptions{}))
+	require.ErrorContains(t, err, "applying JSON Patch")
+
+	// the result can be any type of document
+	out, err = mergeParts([]mergePart{
+		patch(`[{"op": "replace", "path": "", "value": [1, {"a": "b"}]}]`),
+		{data: listeners},
+	}, docMerger(coll.MergeOptions{}))
+	require.NoError(t, err)
+	assert.EqualValues(t, []interface{}{1, map[string]interface{}{"a": "b"}}, out)
+
+	// but it must be the same type as the documents merged on top
+	_, err = mergeParts([]mergePart{
+		{data: map[string]interface{}{"port": 8080}},
+		patch(`[{"op": "replace", "path": "", "value": [1, 2]}]`),
+		{data: listeners},
+	}, docMerger(coll.MergeOptions{}))
+	require.ErrorContains(t, err, "can only merge documents of the same type")
+}
+
+func TestMergeDocs(t *testing.T) {
+	testdata := []struct {
+		expected interface{}
+		opts     coll.MergeOptions
+		docs     []interface{}
+	}{
+		{
+			map[string]interface{}{"a": 1, "b": 2},
+			coll.MergeOptions{},
+			[]interface{}{map[string]interface{}{"a": 1}, nil, map[string]interface{}{"a": 2, "b": 2}},
+		},
+		{
+			[]interface{}{"c", "d"},
+			coll.MergeOptions{},
+			[]interface{}{[]interface{}{"c", "d"}, []interface{}{"a", "c"}},
+		},
+		{
+			[]interface{}{"a", "c", "c", "d"},
+			coll.MergeOptions{Arrays: coll.ArraysAppend},
+			[]interface{}{[]interface{}{"c", "d"}, []interface{}{"a", "c"}},
+		},
+		{
+			[]interface{}{"a", "c", "d"},
+			coll.MergeOptions{Arrays: coll.ArraysUnique},
+			[]interface{}{[]interface{}{"c", "d"}, nil, []interface{}{"a", "c"}},
+		},
+		{"first", coll.MergeOptions{}, []interface{}{nil, "first", 2, true}},
+		{false, coll.MergeOptions{}, []interface{}{false, "second"}},
+		{nil, coll.MergeOptions{}, []interface{}{nil, nil}},
+	}
+
+	for _, d := range testdata {
+		out, err := mergeDocs(d.docs, d.opts)
+		require.NoError(t, err)
+		assert.EqualValues(t, d.expected, out)
+	}
+
+	_, err := mergeDocs([]interface{}{map[string]interface{}{}, []interface{}{}}, coll.MergeOptions{})
+	require.ErrorContains(t, err, "can only merge documents of the same type")
+
+	_, err = mergeDocs([]interface{}{"a", []interface{}{}}, coll.MergeOptions{})
+	require.ErrorContains(t, err, "can only merge documents of the same type")
+}
+
 func TestMergeFS_Open(t *testing.T) {
 	fsys := setupMergeFsys(context.Background(), t)
 	assert.IsType(t, &mergeFS{}, fsys)
@@ -193,7 +310,7 @@ func TestMergeFile_Read(t *testing.T) {
 
 		ct := mime.TypeByExtension(filepath.Ext(fn))
 
-		files[i] = subFile{f, ct}
+		files[i] = subFile{File: f, contentType: ct}
 	}
 
 	mf := &mergeFile{name: "one.yml|two.json|three.toml", subFiles: files}
@@ -209,7 +326,7 @@ func TestMergeFile_Read(t *testing.T) {
 
 		ct := mime.TypeByExtension(filepath.Ext(fn))
 
-		files[i] = subFile{f, ct}
+		files[i] = subFile{File: f, contentType: ct}
 	}
 
 	mf = &mergeFile{name: "one.yml|two.json|three.toml", subFiles: files}
@@ -259,13 +376,19 @@ func TestMergeFS_ReadFile(t *testing.T) {
 		})
 	}
 
+	// JSON Patches are applied to the data merged to their right
+	b, err := fs.ReadFile(fsys, "patch|bar|baz")
+	require.NoError(t, err)
+	assert.Equal(t, "hello: world\n", string(b))
+
 	// read errors
 	errortests := []struct {
 		in            string
 		expectedError string
 	}{
 		{"file:///tmp/jsonfile.json|badtype", "data of type \"foo/bar\" not yet supported"},
-		{"file:///tmp/jsonfile.json|array", "can only merge maps"},
+		{"file:///tmp/jsonfile.json|array", "can only merge documents of the same type"},
+		{"bar|patch", "must be followed by data"},
 	}
 
 	for _, td := range errortests {
@@ -281,6 +404,224 @@ func TestMergeFS_ReadFile(t *testing.T) {
 	}
 }
 
+func TestMergeFS_Options(t *testing.T) {
+	wd := wdForTest(t)
+
+	fsys := WrapWdFS(fstest.MapFS{
+		path.Join(wd, "over.yaml"):  {Data: []byte("list: [b, c]\n")},
+		path.Join(wd, "def.yaml"):   {Data: []byte("list: [a, b]\n")},
+		path.Join(wd, "env.yaml"):   {Data: []byte("env: [{name: A, value: '2'}, {name: C, value: '3'}]\n")},
+		path.Join(wd, "base.yaml"):  {Data: []byte("env: [{name: A, value: '1'}, {name: B, value: '1'}]\n")},
+		path.Join(wd, "patch.json"): {Data: []byte(`{"list": null, "a": {"b": null}}`)},
+		path.Join(wd, "orig.yaml"):  {Data: []byte("list: [a]\na: {b: 1, c: 2}\n")},
+		path.Join(wd, "a.json"):     {Data: []byte(`["a", "b"]`)},
+		path.Join(wd, "b.json"):     {Data: []byte(`["b", "c"]`)},
+		path.Join(wd, "app.json"):   {Data: []byte(`{"server": {"port": "9090"}}`)},
+		path.Join(wd, "app.yaml"):   {Data: []byte("server: {port: 8080, host: localhost}\n")},
+		path.Join(wd, "config.yaml"): {Data: []byte("# the server\nserver:\n  port: 8080 # default\n  host: &h localhost\n" +
+			"proxy:\n  host: *h\n")},
+	})
+
+	mux := fsimpl.NewMux()
+	mux.Add(MergeFS)
+	mux.Add(WrappedFSProvider(fsys, "file", ""))
+
+	ctx := ContextWithFSProvider(context.Background(), mux)
+
+	testdata := []struct {
+		query    string
+		name     string
+		expected string
+	}{
+		{"", "over.yaml|def.yaml", "list:\n  - b\n  - c\n"},
+		{"arrays=append", "over.yaml|def.yaml", "list:\n  - a\n  - b\n  - b\n  - c\n"},
+		{"arrays=unique", "over.yaml|def.yaml", "list:\n  - a\n  - b\n  - c\n"},
+		{
+			"listKey=name", "env.yaml|base.yaml",
+			"env:\n  - name: A\n    value: \"2\"\n  - name: B\n    value: \"1\"\n  - name: C\n    value: \"3\"\n",
+		},
+		{"nulls=keep", "patch.json|orig.yaml", "a:\n  b: null\n  c: 2\nlist: null\n"},
+		{"nulls=delete", "patch.json|orig.yaml", "a:\n  c: 2\n"},
+		{"format=json", "over.yaml|def.yaml", "{\n  \"list\": [\n    \"b\",\n    \"c\"\n  ]\n}\n"},
+		{"format=toml", "over.yaml|def.yaml", "list = [\"b\", \"c\"]\n"},
+		{"format=yaml", "over.yaml|def.yaml", "list:\n  - b\n  - c\n"},
+		{"arrays=unique&format=json", "a.json|b.json", "[\n  \"b\",\n  \"c\",\n  \"a\"\n]\n"},
+		{"depth=0", "app.json|app.yaml", "server:\n  host: localhost\n  port: \"9090\"\n"},
+		{"depth=1", "app.json|app.yaml", "server:\n  port: \"9090\"\n"},
+		{"arrays=append&depth=1&format=json", "a.json|b.json", "[\n  \"b\",\n  \"c\",\n  \"a\",\n  \"b\"\n]\n"},
+		{"coerce=true", "app.json|app.yaml", "server:\n  host: localhost\n  port: 9090\n"},
+		{
+			"preserve=true", "app.json|config.yaml",
+			"# the server\nserver:\n  port: \"9090\" # default\n  host: &h localhost\nproxy:\n  host: *h\n",
+		},
+		{"preserve=true&format=json", "app.json|config.yaml", "{\n  \"proxy\": {\n    \"host\": \"localhost\"\n  },\n  \"server\": {\n    \"host\": \"localhost\",\n    \"port\": \"9090\"\n  }\n}\n"},
+	}
+
+	for _, d := range testdata {
+		t.Run(d.query, func(t *testing.T) {
+			mfs, err := NewMergeFS(mustParseURL("merge:///?" + d.query))
+			require.NoError(t, err)
+
+			mfs = fsimpl.WithContextFS(ctx, mfs)
+
+			b, err := fs.ReadFile(mfs, d.name)
+			require.NoError(t, err)
+			assert.Equal(t, d.expected, string(b))
+		})
+	}
+
+	_, err := NewMergeFS(mustParseURL("merge:///?arrays=bogus"))
+	require.ErrorContains(t, err, "unknown array merge strategy")
+
+	_, err = NewMergeFS(mustParseURL("merge:///?nulls=bogus"))
+	require.ErrorContains(t, err, "invalid nulls option")
+
+	_, err = NewMergeFS(mustParseURL("merge:///?format=xml"))
+	require.ErrorContains(t, err, "invalid format option")
+
+	_, err = NewMergeFS(mustParseURL("merge:///?depth=-1"))
+	require.ErrorContains(t, err, "invalid depth option")
+
+	_, err = NewMergeFS(mustParseURL("merge:///?coerce=maybe"))
+	require.ErrorContains(t, err, "invalid coerce option")
+
+	_, err = NewMergeFS(mustParseURL("merge:///?preserve=maybe"))
+	require.ErrorContains(t, err, "invalid preserve option")
+}
+
+func TestMergeFS_Glob(t *testing.T) {
+	wd := wdForTest(t)
+
+	fsys := WrapWdFS(fstest.MapFS{
+		path.Join(wd, "conf.d/10-a.yaml"): {Data: []byte("a: 1\n")},
+		path.Join(wd, "conf.d/20-b.yaml"): {Data: []byte("a: 2\nb: 2\n")},
+		path.Join(wd, "conf.d/README"):    {Data: []byte("not yaml")},
+		path.Join(wd, "defaults.yaml"):    {Data: []byte("a: 0\nb: 0\nc: 0\n")},
+	})
+
+	mux := fsimpl.NewMux()
+	mux.Add(MergeFS)
+	mux.Add(WrappedFSProvider(fsys, "file", ""))
+
+	ctx := ContextWithFSProvider(context.Background(), mux)
+
+	mfs, err := NewMergeFS(mustParseURL("merge:///"))
+	require.NoError(t, err)
+
+	mfs = fsimpl.WithContextFS(ctx, mfs)
+
+	// matches are merged in lexical order, so earlier files take precedence
+	b, err := fs.ReadFile(mfs, "conf.d/*.yaml|defaults.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "a: 1\nb: 2\nc: 0\n", string(b))
+
+	b, err = fs.ReadFile(mfs, "conf.d/[2]0-*.yaml|defaults.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "a: 2\nb: 2\nc: 0\n", string(b))
+
+	// patterns with no matches are skipped
+	b, err = fs.ReadFile(mfs, "conf.d/*.json|defaults.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "a: 0\nb: 0\nc: 0\n", string(b))
+
+	_, err = fs.ReadFile(mfs, "conf.d/*.json|none/*.yaml")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestMergeFS_Optional(t *testing.T) {
+	wd := wdForTest(t)
+
+	fsys := WrapWdFS(fstest.MapFS{
+		path.Join(wd, "override.yaml"): {Data: []byte("a: 1\n")},
+		path.Join(wd, "defaults.json"): {Data: []byte(`{"a": 0, "b": 0}`)},
+		path.Join(wd, "defaults.txt"):  {Data: []byte(`{"a": 0, "b": 0}`)},
+	})
+
+	mux := fsimpl.NewMux()
+	mux.Add(MergeFS)
+	mux.Add(WrappedFSProvider(fsys, "file", ""))
+
+	ctx := ContextWithFSProvider(context.Background(), mux)
+
+	reg := NewRegistry()
+	reg.Register("missing", config.DataSource{URL: mustParseURL("missing.yaml")})
+
+	mfs, err := NewMergeFS(mustParseURL("merge:///"))
+	require.NoError(t, err)
+
+	mfs = WithDataSourceRegistryFS(reg, mfs)
+	mfs = fsimpl.WithContextFS(ctx, mfs)
+
+	b, err := fs.ReadFile(mfs, "override.yaml?optional|defaults.json")
+	require.NoError(t, err)
+	assert.Equal(t, "a: 1\nb: 0\n", string(b))
+
+	b, err = fs.ReadFile(mfs, "nope.yaml?optional|missing?optional=true|defaults.json")
+	require.NoError(t, err)
+	assert.Equal(t, "a: 0\nb: 0\n", string(b))
+
+	// other query parameters are kept
+	b, err = fs.ReadFile(mfs, "nope.yaml?optional|defaults.txt?type=application/json&optional")
+	require.NoError(t, err)
+	assert.Equal(t, "a: 0\nb: 0\n", string(b))
+
+	_, err = fs.ReadFile(mfs, "nope.yaml?optional=false|defaults.json")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	_, err = fs.ReadFile(mfs, "missing|defaults.json")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
+	_, err = fs.ReadFile(mfs, "nope.yaml?optional|missing?optional")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestCutOptional(t *testing.T) {
+	testdata := []struct {
+		in, part string
+		optional bool
+	}{
+		{"foo.yaml", "foo.yaml", false},
+		{"foo.yaml?optional", "foo.yaml", true},
+		{"foo.yaml?optional=true", "foo.yaml", true},
+		{"foo.yaml?optional=false", "foo.yaml", false},
+		{"foo?type=application/array+json&optional", "foo?type=application/array+json", true},
+		{"https://example.com/a?b=c&optional&d=e", "https://example.com/a?b=c&d=e", true},
+		{"foo?optionally=1", "foo?optionally=1", false},
+	}
+
+	for _, d := range testdata {
+		part, optional := cutOptional(d.in)
+		assert.Equal(t, d.part, part, d.in)
+		assert.Equal(t, d.optional, optional, d.in)
+	}
+}
+
+func TestSplitMergeQuery(t *testing.T) {
+	testdata := []struct {
+		in       string
+		expected *url.URL
+	}{
+		{
+			"merge:foo.yaml?optional|bar?type=application/json|baz.yaml?arrays=append",
+			&url.URL{Scheme: "merge", Opaque: "foo.yaml?optional|bar?type=application/json|baz.yaml", RawQuery: "arrays=append"},
+		},
+		{"merge:foo|bar?arrays=append", &url.URL{Scheme: "merge", Opaque: "foo|bar", RawQuery: "arrays=append"}},
+		{"merge:foo?optional|bar", &url.URL{Scheme: "merge", Opaque: "foo?optional|bar"}},
+		{"file:///foo?a=b|c", &url.URL{Scheme: "file", Path: "/foo", RawQuery: "a=b|c"}},
+	}
+
+	for _, d := range testdata {
+		u, err := url.Parse(d.in)
+		require.NoError(t, err)
+
+		out := splitMergeQuery(u)
+		assert.Equal(t, d.expected, out, d.in)
+
+		// splitting is idempotent
+		assert.Equal(t, d.expected, splitMergeQuery(out), d.in)
+	}
+}
+
 func TestMergeFS_ReadsSubFilesOnce(t *testing.T) {
 	mergedContent := "goodnight: moon\nhello: world\n"
 
@@ -313,6 +654,49 @@ func TestMergeFS_ReadsSubFilesOnce(t *testing.T) {
 	assert.Equal(t, mergedContent, string(b))
 }
 
+func TestMergeFS_Cache(t *testing.T) {
+	wd := wdForTest(t)
+
+	fsys := WrapWdFS(
+		openOnce(&fstest.MapFS{
+			path.Join(wd, "tmp/jsonfile.json"): {Data: []byte(`{"hello": "world"}`)},
+			path.Join(wd, "tmp/yamlfile.yaml"): {Data: []byte("hello: earth\ngoodnight: moon\n")},
+			path.Join(wd, "tmp/other.yaml"):    {Data: []byte("hello: mars\nfoo: bar\n")},
+		}))
+
+	mux := fsimpl.NewMux()
+	mux.Add(MergeFS)
+	mux.Add(WrappedFSProvider(fsys, "file", ""))
+
+	ctx := ContextWithFSProvider(context.Background(), mux)
+
+	reg := NewRegistry()
+	reg.Register("jsonfile", config.DataSource{URL: mustParseURL("tmp/jsonfile.json")})
+	reg.Register("yamlfile", config.DataSource{URL: mustParseURL("tmp/yamlfile.yaml")})
+	reg.Register("other", config.DataSource{URL: mustParseURL("tmp/other.yaml")})
+
+	fsys, err := NewMergeFS(mustParseURL("merge:///"))
+	require.NoError(t, err)
+
+	fsys = WithDataSourceRegistryFS(reg, fsys)
+	fsys = withMergeCacheFS(newMergeCache(), fsys)
+	fsys = fsimpl.WithContextFS(ctx, fsys)
+
+	b, err := fs.ReadFile(fsys, "jsonfile|yamlfile")
+	require.NoError(t, err)
+	assert.Equal(t, "goodnight: moon\nhello: world\n", string(b))
+
+	// the sub-sources are only opened once, so this would fail without the
+	// cache
+	b, err = fs.ReadFile(fsys, "other|yamlfile|jsonfile")
+	require.NoError(t, err)
+	assert.Equal(t, "foo: bar\ngoodnight: moon\nhello: mars\n", string(b))
+
+	b, err = fs.ReadFile(fsys, "yamlfile|jsonfile")
+	require.NoError(t, err)
+	assert.Equal(t, "goodnight: moon\nhello: earth\n", string(b))
+}
+
 type openOnceFS struct {
 	fs     *fstest.MapFS
 	opened map[string]struct{}
@@ -0,0 +1,108 @@
+package datafs
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const (
+	// protobufDescriptorParam is the query parameter naming the
+	// FileDescriptorSet used to decode binary protobuf datasources
+	protobufDescriptorParam = "protobufDescriptor"
+	// protobufMessageParam is the query parameter naming the message type
+	protobufMessageParam = "protobufMessage"
+)
+
+// decodeProtobuf decodes a binary protobuf message as JSON. The message type is
+// found in the FileDescriptorSet read from the descriptor datasource, which may
+// be an alias, a URL, or a relative path. The message name may be omitted when
+// the set contains only one message type.
+func (d *dsReader) decodeProtobuf(ctx context.Context, data []byte, descriptor, message string) ([]byte, error) {
+	descURL, hdr, err := d.lookupSource(descriptor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid protobuf descriptor %q: %w", descriptor, err)
+	}
+
+	dc, err := d.readFileContent(ctx, descURL, hdr)
+	if err != nil {
+		return nil, fmt.Errorf("read protobuf descriptor %q: %w", descriptor, err)
+	}
+
+	fds := &descriptorpb.FileDescriptorSet{}
+
+	err = proto.Unmarshal(dc.b, fds)
+	if err != nil {
+		return nil, fmt.Errorf("parse protobuf descriptor %q: %w", descriptor, err)
+	}
+
+	files, err := protodesc.NewFiles(fds)
+	if err != nil {
+		return nil, fmt.Errorf("parse protobuf descriptor %q: %w", descriptor, err)
+	}
+
+	md, err := findProtobufMessage(files, message)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := dynamicpb.NewMessage(md)
+
+	err = proto.Unmarshal(data, msg)
+	if err != nil {
+		return nil, fmt.Errorf("decode protobuf message %s: %w", md.FullName(), err)
+	}
+
+	b, err := protojson.MarshalOptions{
+		UseProtoNames:   true,
+		EmitUnpopulated: true,
+		Resolver:        dynamicpb.NewTypes(files),
+	}.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("marshal protobuf message %s as JSON: %w", md.FullName(), err)
+	}
+
+	return b, nil
+}
+
+// findProtobufMessage finds the named message type. When no name is given,
+// the files must contain exactly one message type.
+func findProtobufMessage(files *protoregistry.Files, name string) (protoreflect.MessageDescriptor, error) {
+	if name != "" {
+		desc, err := files.FindDescriptorByName(protoreflect.FullName(name))
+		if err != nil {
+			return nil, fmt.Errorf("protobuf message %q: %w", name, err)
+		}
+
+		md, ok := desc.(protoreflect.MessageDescriptor)
+		if !ok {
+			return nil, fmt.Errorf("protobuf descriptor %q is not a message", name)
+		}
+
+		return md, nil
+	}
+
+	var found []protoreflect.MessageDescriptor
+
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		msgs := fd.Messages()
+		for i := 0; i < msgs.Len(); i++ {
+			found = append(found, msgs.Get(i))
+		}
+
+		return true
+	})
+
+	if len(found) != 1 {
+		return nil, fmt.Errorf("protobuf descriptor contains %d message types, so the %q parameter is required", len(found), protobufMessageParam)
+	}
+
+	return found[0], nil
+}
@@ -0,0 +1,142 @@
+package datafs
+
+import (
+	"context"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// testProtobufFixtures returns a serialized FileDescriptorSet describing a
+// config.v1.ServiceConfig message, and a serialized message of that type
+func testProtobufFixtures(t *testing.T) (descriptor, message []byte) {
+	t.Helper()
+
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("config.proto"),
+		Package: proto.String("config.v1"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("ServiceConfig"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{
+					Name:     proto.String("service_name"),
+					JsonName: proto.String("serviceName"),
+					Number:   proto.Int32(1),
+					Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+					Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				},
+				{
+					Name:     proto.String("port"),
+					JsonName: proto.String("port"),
+					Number:   proto.Int32(2),
+					Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+					Type:     descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum(),
+				},
+				{
+					Name:     proto.String("hosts"),
+					JsonName: proto.String("hosts"),
+					Number:   proto.Int32(3),
+					Label:    descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+					Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				},
+				{
+					Name:     proto.String("debug"),
+					JsonName: proto.String("debug"),
+					Number:   proto.Int32(4),
+					Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+					Type:     descriptorpb.FieldDescriptorProto_TYPE_BOOL.Enum(),
+				},
+			},
+		}},
+	}
+
+	descriptor, err := proto.Marshal(&descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{fdp},
+	})
+	require.NoError(t, err)
+
+	fd, err := protodesc.NewFile(fdp, nil)
+	require.NoError(t, err)
+
+	md := fd.Messages().Get(0)
+	msg := dynamicpb.NewMessage(md)
+	msg.Set(md.Fields().ByName("service_name"), protoreflect.ValueOfString("web"))
+	msg.Set(md.Fields().ByName("port"), protoreflect.ValueOfInt32(8080))
+
+	hosts := msg.NewField(md.Fields().ByName("hosts")).List()
+	hosts.Append(protoreflect.ValueOfString("a.example.com"))
+	hosts.Append(protoreflect.ValueOfString("b.example.com"))
+	msg.Set(md.Fields().ByName("hosts"), protoreflect.ValueOfList(hosts))
+
+	message, err = proto.Marshal(msg)
+	require.NoError(t, err)
+
+	return descriptor, message
+}
+
+func TestReadFileContent_Protobuf(t *testing.T) {
+	wd, _ := os.Getwd()
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+	})
+	_ = os.Chdir("/")
+
+	descriptor, message := testProtobufFixtures(t)
+
+	fsys := WrapWdFS(fstest.MapFS{
+		"schema/config.pb": &fstest.MapFile{Data: descriptor},
+		"data/config.bin":  &fstest.MapFile{Data: message},
+	})
+
+	ctx := ContextWithFSProvider(context.Background(), WrappedFSProvider(fsys, "file", ""))
+
+	reg := NewRegistry()
+	sr := &dsReader{Registry: reg}
+
+	expected := `{
+		"service_name": "web",
+		"port": 8080,
+		"hosts": ["a.example.com", "b.example.com"],
+		"debug": false
+	}`
+
+	fc, err := sr.readFileContent(ctx,
+		mustParseURL("file:///data/config.bin?protobufDescriptor=schema/config.pb&protobufMessage=config.v1.ServiceConfig"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, iohelpers.JSONMimetype, fc.contentType)
+	assert.JSONEq(t, expected, string(fc.b))
+
+	// the message name is optional when there's only one message type
+	fc, err = sr.readFileContent(ctx, mustParseURL("file:///data/config.bin?protobufDescriptor=schema/config.pb"), nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, expected, string(fc.b))
+
+	// the descriptor can be another datasource
+	reg.Register("schema", config.DataSource{URL: mustParseURL("file:///schema/config.pb")})
+
+	fc, err = sr.readFileContent(ctx, mustParseURL("file:///data/config.bin?protobufDescriptor=schema"), nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, expected, string(fc.b))
+
+	_, err = sr.readFileContent(ctx,
+		mustParseURL("file:///data/config.bin?protobufDescriptor=schema&protobufMessage=config.v1.Bogus"), nil)
+	require.ErrorContains(t, err, "config.v1.Bogus")
+
+	_, err = sr.readFileContent(ctx, mustParseURL("file:///data/config.bin?protobufDescriptor=missing.pb"), nil)
+	require.Error(t, err)
+
+	// the message isn't a valid descriptor
+	_, err = sr.readFileContent(ctx, mustParseURL("file:///data/config.bin?protobufDescriptor=data/config.bin"), nil)
+	require.Error(t, err)
+}
@@ -6,16 +6,21 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"mime"
 	"net/http"
 	"net/url"
 	"os"
 	"path"
 	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/hairyhenderson/go-fsimpl"
+	"github.com/hairyhenderson/gomplate/v4/conv"
 	"github.com/hairyhenderson/gomplate/v4/internal/config"
 	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
+	"github.com/hairyhenderson/gomplate/v4/internal/parsers"
+	"github.com/hairyhenderson/gomplate/v4/internal/urlhelpers"
 )
 
 // typeOverrideParam gets the query parameter used to override the content type
@@ -29,6 +34,86 @@ func typeOverrideParam() string {
 	return "type"
 }
 
+// frontMatterParam is the query parameter which enables (or disables) splitting
+// front matter from the body of a document
+const frontMatterParam = "frontmatter"
+
+// jsonnetExtVarPrefix is the prefix of the query parameters which set
+// external variables for Jsonnet datasources, like "ext.env=prod"
+const jsonnetExtVarPrefix = "ext."
+
+// isJsonnet returns true if the type, or the extension of the path when
+// there's no type, is Jsonnet
+func isJsonnet(mimeType, p string) bool {
+	switch iohelpers.MimeAlias(mimeType) {
+	case iohelpers.JsonnetMimetype:
+		return true
+	case "":
+		ext := strings.ToLower(path.Ext(p))
+		return ext == ".jsonnet" || ext == ".libsonnet"
+	default:
+		return false
+	}
+}
+
+// splitJsonnetExtVars returns the URL without the Jsonnet external variable
+// query parameters, and the variables they set (without the prefix)
+func splitJsonnetExtVars(u *url.URL) (*url.URL, url.Values) {
+	extVars := url.Values{}
+	for k, v := range u.Query() {
+		if name, ok := strings.CutPrefix(k, jsonnetExtVarPrefix); ok && name != "" {
+			extVars[name] = v
+			u = removeQueryParam(u, k)
+		}
+	}
+
+	return u, extVars
+}
+
+// isCSV returns true if the type, or the extension of the path when there's
+// no type, is CSV (or TSV)
+func isCSV(mimeType, p string) bool {
+	switch iohelpers.MimeAlias(mimeType) {
+	case iohelpers.CSVMimetype, iohelpers.TSVMimetype:
+		return true
+	case "":
+		ext := strings.ToLower(path.Ext(p))
+		return ext == ".csv" || ext == ".tsv"
+	default:
+		return false
+	}
+}
+
+// registerMimeTypes registers file extensions for supported formats which
+// aren't otherwise known
+//
+//nolint:gochecknoglobals
+var registerMimeTypes = sync.OnceFunc(func() {
+	_ = mime.AddExtensionType(".hcl", iohelpers.HCLMimetype)
+	_ = mime.AddExtensionType(".ini", iohelpers.INIMimetype)
+	_ = mime.AddExtensionType(".tsv", iohelpers.TSVMimetype)
+	_ = mime.AddExtensionType(".tf", iohelpers.HCLMimetype)
+	_ = mime.AddExtensionType(".json5", iohelpers.JSON5Mimetype)
+	_ = mime.AddExtensionType(".jsonc", iohelpers.JSON5Mimetype)
+	_ = mime.AddExtensionType(".jsonnet", iohelpers.JsonnetMimetype)
+	_ = mime.AddExtensionType(".libsonnet", iohelpers.JsonnetMimetype)
+	_ = mime.AddExtensionType(".msgpack", iohelpers.MsgPackMimetype)
+	_ = mime.AddExtensionType(".avro", iohelpers.AvroMimetype)
+	_ = mime.AddExtensionType(".parquet", iohelpers.ParquetMimetype)
+	_ = mime.AddExtensionType(".ndjson", iohelpers.NDJSONMimetype)
+	_ = mime.AddExtensionType(".jsonl", iohelpers.NDJSONMimetype)
+	_ = mime.AddExtensionType(".bson", iohelpers.BSONMimetype)
+})
+
+// contentType returns the content type of the file, like
+// [fsimpl.ContentType], but also recognizes extensions for all supported
+// formats
+func contentType(fi fs.FileInfo) string {
+	registerMimeTypes()
+
+	return fsimpl.ContentType(fi)
+}
+
 // DataSourceReader reads content from a datasource
 type DataSourceReader interface {
 	// ReadSource reads the content of a datasource, given an alias and optional
@@ -39,6 +124,11 @@ type DataSourceReader interface {
 	// arguments will return the same content.
 	ReadSource(ctx context.Context, alias string, args ...string) (string, []byte, error)
 
+	// OpenSource opens a datasource for reading, given an alias and optional
+	// arguments, like ReadSource. The content is not cached, so it can be read
+	// as a stream. The caller must close the returned reader.
+	OpenSource(ctx context.Context, alias string, args ...string) (string, io.ReadCloser, error)
+
 	// contains registry
 	Registry
 }
@@ -46,6 +136,9 @@ type DataSourceReader interface {
 type dsReader struct {
 	cache map[string]*content
 
+	// parsed sub-sources of merge datasources, shared by all merges
+	mergeCache *mergeCache
+
 	Registry
 }
 
@@ -59,12 +152,14 @@ func NewSourceReader(reg Registry) DataSourceReader {
 	return &dsReader{Registry: reg}
 }
 
-func (d *dsReader) ReadSource(ctx context.Context, alias string, args ...string) (string, []byte, error) {
+// lookupAlias finds the named datasource, registering it first if the alias
+// is a URL
+func (d *dsReader) lookupAlias(alias string) (config.DataSource, error) {
 	source, ok := d.Lookup(alias)
 	if !ok {
 		srcURL, err := url.Parse(alias)
 		if err != nil || !srcURL.IsAbs() {
-			return "", nil, fmt.Errorf("undefined datasource '%s': %w", alias, err)
+			return source, fmt.Errorf("undefined datasource '%s': %w", alias, err)
 		}
 
 		d.Register(alias, config.DataSource{URL: srcURL})
@@ -74,6 +169,19 @@ func (d *dsReader) ReadSource(ctx context.Context, alias string, args ...string)
 		source, _ = d.Lookup(alias)
 	}
 
+	if source.URL != nil {
+		source.URL = splitMergeQuery(source.URL)
+	}
+
+	return source, nil
+}
+
+func (d *dsReader) ReadSource(ctx context.Context, alias string, args ...string) (string, []byte, error) {
+	source, err := d.lookupAlias(alias)
+	if err != nil {
+		return "", nil, err
+	}
+
 	if d.cache == nil {
 		d.cache = make(map[string]*content)
 	}
@@ -104,6 +212,21 @@ func (d *dsReader) ReadSource(ctx context.Context, alias string, args ...string)
 	return fc.contentType, fc.b, nil
 }
 
+// lookupSource resolves a reference to a supporting source (such as a schema),
+// which may be a datasource alias, a URL, or a relative path
+func (d *dsReader) lookupSource(ref string) (*url.URL, http.Header, error) {
+	if ds, ok := d.Lookup(ref); ok {
+		return ds.URL, ds.Header, nil
+	}
+
+	u, err := urlhelpers.ParseSourceURL(ref)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return u, nil, nil
+}
+
 func removeQueryParam(u *url.URL, key string) *url.URL {
 	q := u.Query()
 	q.Del(key)
@@ -111,7 +234,9 @@ func removeQueryParam(u *url.URL, key string) *url.URL {
 	return u
 }
 
-func (d *dsReader) readFileContent(ctx context.Context, u *url.URL, hdr http.Header) (*content, error) {
+// splitTypeOverride returns the URL without the type override query
+// parameter, and the type it contained, if any
+func splitTypeOverride(u *url.URL) (*url.URL, string) {
 	// possible type hint in the type query param. Contrary to spec, we allow
 	// unescaped '+' characters to make it simpler to provide types like
 	// "application/array+json"
@@ -121,13 +246,17 @@ func (d *dsReader) readFileContent(ctx context.Context, u *url.URL, hdr http.Hea
 
 	// now that we have the hint, remove it from the URL - we can't have it
 	// leaking into the filesystem layer
-	u = removeQueryParam(u, overrideType)
+	return removeQueryParam(u, overrideType), mimeType
+}
 
+// openFile opens the file at the given URL, returning it along with the
+// filesystem it was opened from and its name in that filesystem
+func (d *dsReader) openFile(ctx context.Context, u *url.URL, hdr http.Header) (fs.FS, string, fs.File, error) {
 	u, fname := SplitFSMuxURL(u)
 
 	fsys, err := FSysForPath(ctx, u.String())
 	if err != nil {
-		return nil, fmt.Errorf("fsys for path %v: %w", u, err)
+		return nil, "", nil, fmt.Errorf("fsys for path %v: %w", u, err)
 	}
 
 	// need to support absolute paths on local filesystem too
@@ -140,9 +269,123 @@ func (d *dsReader) readFileContent(ctx context.Context, u *url.URL, hdr http.Hea
 	fsys = fsimpl.WithHeaderFS(hdr, fsys)
 	fsys = WithDataSourceRegistryFS(d.Registry, fsys)
 
+	if d.mergeCache == nil {
+		d.mergeCache = newMergeCache()
+	}
+	fsys = withMergeCacheFS(d.mergeCache, fsys)
+
 	f, err := fsys.Open(fname)
 	if err != nil {
-		return nil, fmt.Errorf("open (url: %q, name: %q): %w", u, fname, err)
+		return nil, "", nil, fmt.Errorf("open (url: %q, name: %q): %w", u, fname, err)
+	}
+
+	return fsys, fname, f, nil
+}
+
+func (d *dsReader) OpenSource(ctx context.Context, alias string, args ...string) (string, io.ReadCloser, error) {
+	source, err := d.lookupAlias(alias)
+	if err != nil {
+		return "", nil, err
+	}
+
+	arg := ""
+	if len(args) > 0 {
+		arg = args[0]
+	}
+
+	u, err := resolveURL(*source.URL, arg)
+	if err != nil {
+		return "", nil, err
+	}
+
+	u, mimeType := splitTypeOverride(u)
+
+	_, _, f, err := d.openFile(ctx, u, source.Header)
+	if err != nil {
+		return "", nil, fmt.Errorf("couldn't open datasource '%s' (%s): %w", alias, u, err)
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return "", nil, fmt.Errorf("couldn't open datasource '%s' (%s): stat: %w", alias, u, err)
+	}
+
+	if fi.IsDir() {
+		f.Close()
+		return "", nil, fmt.Errorf("couldn't open datasource '%s' (%s): can't stream a directory", alias, u)
+	}
+
+	if mimeType == "" {
+		mimeType = contentType(fi)
+	}
+
+	if mimeType == "" {
+		mimeType = iohelpers.TextMimetype
+	}
+
+	return mimeType, f, nil
+}
+
+func (d *dsReader) readFileContent(ctx context.Context, u *url.URL, hdr http.Header) (*content, error) {
+	u, mimeType := splitTypeOverride(u)
+
+	// binary protobuf messages are decoded with a descriptor
+	pbDescriptor := u.Query().Get(protobufDescriptorParam)
+	pbMessage := u.Query().Get(protobufMessageParam)
+
+	if pbDescriptor != "" {
+		u = removeQueryParam(u, protobufDescriptorParam)
+		u = removeQueryParam(u, protobufMessageParam)
+	}
+
+	// Avro-encoded values are decoded with a schema, or a schema registry
+	avroSchema := u.Query().Get(avroSchemaParam)
+	avroRegistry := u.Query().Get(avroRegistryParam)
+
+	if avroSchema != "" || avroRegistry != "" {
+		u = removeQueryParam(u, avroSchemaParam)
+		u = removeQueryParam(u, avroRegistryParam)
+	}
+
+	// Parquet files can be read with only some columns
+	var parquetColumns string
+
+	if iohelpers.MimeAlias(mimeType) == iohelpers.ParquetMimetype || path.Ext(u.Path) == ".parquet" {
+		parquetColumns = u.Query().Get(parsers.ParquetColumnsParam)
+		u = removeQueryParam(u, parsers.ParquetColumnsParam)
+	}
+
+	// CSV parsing options are given in query parameters
+	var csvQuery url.Values
+
+	if isCSV(mimeType, u.Path) {
+		csvQuery = u.Query()
+		for _, p := range parsers.CSVQueryParams() {
+			u = removeQueryParam(u, p)
+		}
+	}
+
+	// Jsonnet external variables are given in prefixed query parameters
+	var jsonnetExtVars url.Values
+
+	if isJsonnet(mimeType, u.Path) {
+		u, jsonnetExtVars = splitJsonnetExtVars(u)
+	}
+
+	// front matter is split from Markdown documents, or any other document
+	// when requested
+	frontMatter := u.Query().Has(frontMatterParam)
+	splitFrontMatter := frontMatter && (u.Query().Get(frontMatterParam) == "" ||
+		conv.ToBool(u.Query().Get(frontMatterParam)))
+
+	if frontMatter {
+		u = removeQueryParam(u, frontMatterParam)
+	}
+
+	fsys, fname, f, err := d.openFile(ctx, u, hdr)
+	if err != nil {
+		return nil, err
 	}
 	defer f.Close()
 
@@ -152,7 +395,7 @@ func (d *dsReader) readFileContent(ctx context.Context, u *url.URL, hdr http.Hea
 	}
 
 	if mimeType == "" {
-		mimeType = fsimpl.ContentType(fi)
+		mimeType = contentType(fi)
 	}
 
 	var data []byte
@@ -179,6 +422,24 @@ func (d *dsReader) readFileContent(ctx context.Context, u *url.URL, hdr http.Hea
 		if err != nil {
 			return nil, fmt.Errorf("read (url: %q, name: %s): %w", u, fname, err)
 		}
+
+		if pbDescriptor != "" {
+			data, err = d.decodeProtobuf(ctx, data, pbDescriptor, pbMessage)
+			if err != nil {
+				return nil, fmt.Errorf("decode (url: %q, name: %s): %w", u, fname, err)
+			}
+
+			mimeType = iohelpers.JSONMimetype
+		}
+
+		if avroSchema != "" || avroRegistry != "" {
+			data, err = d.decodeAvro(ctx, data, avroSchema, avroRegistry)
+			if err != nil {
+				return nil, fmt.Errorf("decode (url: %q, name: %s): %w", u, fname, err)
+			}
+
+			mimeType = iohelpers.JSONMimetype
+		}
 	}
 
 	if mimeType == "" {
@@ -186,6 +447,25 @@ func (d *dsReader) readFileContent(ctx context.Context, u *url.URL, hdr http.Hea
 		mimeType = iohelpers.TextMimetype
 	}
 
+	if iohelpers.MimeAlias(mimeType) == iohelpers.JsonnetMimetype {
+		mimeType = parsers.JsonnetMediaType(jsonnetExtVars)
+	}
+
+	if iohelpers.MimeAlias(mimeType) == iohelpers.ParquetMimetype {
+		mimeType = parsers.ParquetMediaType(mimeType, parquetColumns)
+	}
+
+	if csvQuery != nil && isCSV(mimeType, "") {
+		mimeType = parsers.CSVMediaType(mimeType, csvQuery)
+	}
+
+	switch {
+	case splitFrontMatter:
+		mimeType = iohelpers.MarkdownMimetype
+	case frontMatter && iohelpers.MimeAlias(mimeType) == iohelpers.MarkdownMimetype:
+		mimeType = iohelpers.TextMimetype
+	}
+
 	return &content{contentType: mimeType, b: data}, nil
 }
 
@@ -238,8 +518,20 @@ func resolveURL(base url.URL, rel string) (*url.URL, error) {
 	}
 
 	// if there's still an opaque part, there's no resolving to do - just return
-	// the base URL
+	// the base URL, with any query parameters from a query-only rel
 	if base.Opaque != "" {
+		if strings.HasPrefix(rel, "?") {
+			bq := base.Query()
+			rq, err := url.ParseQuery(rel[1:])
+			if err != nil {
+				return nil, err
+			}
+			for k := range rq {
+				bq.Set(k, rq.Get(k))
+			}
+			base.RawQuery = bq.Encode()
+		}
+
 		return &base, nil
 	}
 
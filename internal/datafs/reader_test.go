@@ -3,6 +3,7 @@ package datafs
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -10,6 +11,7 @@ import (
 	"runtime"
 	"testing"
 	"testing/fstest"
+	"time"
 
 	"github.com/hairyhenderson/go-fsimpl"
 	"github.com/hairyhenderson/go-fsimpl/httpfs"
@@ -62,6 +64,11 @@ func TestResolveURL(t *testing.T) {
 	_, err = resolveURL(*mustParseURL("git+ssh://git@example.com/foo//bar"), "baz//myfile")
 	require.Error(t, err)
 
+	// query parameters can be added to opaque URLs
+	out, err = resolveURL(*mustParseURL("geoip:db.mmdb?a=1"), "?ip=1.2.3.4")
+	require.NoError(t, err)
+	assert.Equal(t, "geoip:db.mmdb?a=1&ip=1.2.3.4", out.String())
+
 	// relative base URLs must remain relative
 	out, err = resolveURL(*mustParseURL("tmp/foo.json"), "")
 	require.NoError(t, err)
@@ -146,6 +153,11 @@ func TestReadFileContent(t *testing.T) {
 		w.Header().Set("Content-Type", iohelpers.JSONMimetype)
 		w.Write([]byte(`{"foo": "bar"}`))
 	})
+	mux.HandleFunc("/app.jsonnet", func(w http.ResponseWriter, r *http.Request) {
+		// the external variables shouldn't be sent along
+		w.Header().Set("Content-Type", iohelpers.JsonnetMimetype)
+		w.Write([]byte(`{q: '` + r.URL.RawQuery + `'}`))
+	})
 
 	srv := httptest.NewServer(mux)
 	t.Cleanup(srv.Close)
@@ -155,6 +167,11 @@ func TestReadFileContent(t *testing.T) {
 		"dir/1.yaml":        &fstest.MapFile{Data: []byte(`foo: bar`)},
 		"dir/2.yaml":        &fstest.MapFile{Data: []byte(`baz: qux`)},
 		"dir/sub/sub1.yaml": &fstest.MapFile{Data: []byte(`quux: corge`)},
+		"app.jsonnet":       &fstest.MapFile{Data: []byte(`{env: std.extVar('env')}`)},
+		"rows.parquet":      &fstest.MapFile{Data: []byte("PAR1")},
+		"rows.csv":          &fstest.MapFile{Data: []byte("a;b\n1;2\n")},
+		"post.html":         &fstest.MapFile{Data: []byte("---\ntitle: hi\n---\n<p>hi</p>\n")},
+		"post.md":           &fstest.MapFile{Data: []byte("---\ntitle: hi\n---\n# hi\n")},
 	})
 
 	fsp := fsimpl.NewMux()
@@ -177,6 +194,45 @@ func TestReadFileContent(t *testing.T) {
 	fc, err = sr.readFileContent(ctx, mustParseURL(srv.URL+"/foo.json"), nil)
 	require.NoError(t, err)
 	assert.JSONEq(t, `{"foo": "bar"}`, string(fc.b))
+
+	// Jsonnet external variables are passed along in the content type
+	fc, err = sr.readFileContent(ctx, mustParseURL("file:///app.jsonnet?ext.env=prod"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, `application/jsonnet; ext="env=prod"`, fc.contentType)
+
+	// other query parameters are left for the filesystem
+	fc, err = sr.readFileContent(ctx, mustParseURL(srv.URL+"/app.jsonnet?ext.env=prod&token=abc"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, `application/jsonnet; ext="env=prod"`, fc.contentType)
+	assert.Equal(t, `{q: 'token=abc'}`, string(fc.b))
+
+	// Parquet columns are passed along in the content type
+	fc, err = sr.readFileContent(ctx, mustParseURL("file:///rows.parquet?columns=name,port"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, `application/vnd.apache.parquet; columns="name,port"`, fc.contentType)
+
+	// CSV options are passed along in the content type
+	fc, err = sr.readFileContent(ctx, mustParseURL("file:///rows.csv?delimiter=%3B&inferTypes=true"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, `text/csv; charset=utf-8; delimiter=";"; infertypes=true`, fc.contentType)
+
+	fc, err = sr.readFileContent(ctx, mustParseURL("file:///rows.csv"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "text/csv; charset=utf-8", fc.contentType)
+
+	// front matter is split from any document on request
+	fc, err = sr.readFileContent(ctx, mustParseURL("file:///post.html?frontmatter=true"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, iohelpers.MarkdownMimetype, fc.contentType)
+
+	fc, err = sr.readFileContent(ctx, mustParseURL("file:///post.md?frontmatter"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, iohelpers.MarkdownMimetype, fc.contentType)
+
+	// and Markdown can be read as plain text
+	fc, err = sr.readFileContent(ctx, mustParseURL("file:///post.md?frontmatter=false"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, iohelpers.TextMimetype, fc.contentType)
 }
 
 func TestDatasource(t *testing.T) {
@@ -227,3 +283,69 @@ func TestDatasource(t *testing.T) {
 	_, _, err = d.ReadSource(ctx, "bar")
 	require.Error(t, err)
 }
+
+func TestOpenSource(t *testing.T) {
+	fsys := WrapWdFS(fstest.MapFS{
+		"tmp/events.ndjson": &fstest.MapFile{Data: []byte("{\"n\": 1}\n{\"n\": 2}\n")},
+		"tmp/data.txt":      &fstest.MapFile{Data: []byte("hello")},
+	})
+	ctx := ContextWithFSProvider(context.Background(), WrappedFSProvider(fsys, "file", ""))
+
+	reg := NewRegistry()
+	reg.Register("tmp", config.DataSource{URL: &url.URL{Scheme: "file", Path: "/tmp/"}})
+
+	d := &dsReader{Registry: reg}
+
+	ct, r, err := d.OpenSource(ctx, "tmp", "events.ndjson")
+	require.NoError(t, err)
+	assert.Equal(t, iohelpers.NDJSONMimetype, iohelpers.MimeAlias(ct))
+
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, "{\"n\": 1}\n{\"n\": 2}\n", string(b))
+
+	ct, r, err = d.OpenSource(ctx, "tmp", "data.txt?type=application/x-ndjson")
+	require.NoError(t, err)
+	assert.Equal(t, iohelpers.NDJSONMimetype, ct)
+	require.NoError(t, r.Close())
+
+	// directories can't be streamed
+	_, _, err = d.OpenSource(ctx, "tmp")
+	require.Error(t, err)
+
+	_, _, err = d.OpenSource(ctx, "tmp", "missing.ndjson")
+	require.Error(t, err)
+
+	_, _, err = d.OpenSource(ctx, "bogus")
+	require.Error(t, err)
+}
+
+func TestContentType(t *testing.T) {
+	testdata := []struct {
+		name     string
+		expected string
+	}{
+		{"foo.json", iohelpers.JSONMimetype},
+		{"foo.yaml", iohelpers.YAMLMimetype},
+		{"foo.hcl", iohelpers.HCLMimetype},
+		{"main.tf", iohelpers.HCLMimetype},
+		{"php.ini", iohelpers.INIMimetype},
+		{"foo.xml", iohelpers.XMLMimetype},
+		{"events.msgpack", iohelpers.MsgPackMimetype},
+		{"events.avro", iohelpers.AvroMimetype},
+		{"events.parquet", iohelpers.ParquetMimetype},
+		{"rows.tsv", iohelpers.TSVMimetype},
+		{"tsconfig.jsonc", iohelpers.JSON5Mimetype},
+		{"README.md", iohelpers.MarkdownMimetype},
+		{"config.json5", iohelpers.JSON5Mimetype},
+		{"events.ndjson", iohelpers.NDJSONMimetype},
+		{"events.jsonl", iohelpers.NDJSONMimetype},
+		{"users.bson", iohelpers.BSONMimetype},
+	}
+
+	for _, d := range testdata {
+		fi := FileInfo(d.name, 0, 0o644, time.Time{}, "")
+		assert.Equal(t, d.expected, iohelpers.MimeAlias(contentType(fi)), d.name)
+	}
+}
@@ -352,10 +352,15 @@ func (w *wdFS) Sub(name string) (fs.FS, error) {
 	return fs.Sub(w.fsys, name)
 }
 
-func (w *wdFS) Glob(_ string) ([]string, error) {
-	// I'm not sure how to handle this, so I'm just going to error for now -
-	// I have no need of Glob anyway.
-	return nil, fmt.Errorf("glob not supported by wdFS: %w", fs.ErrInvalid)
+func (w *wdFS) Glob(pattern string) ([]string, error) {
+	// fs.Glob falls back to ReadDir for filesystems which don't implement
+	// Glob, which resolves paths the same way as Open
+	return fs.Glob(readDirFS{w}, pattern)
+}
+
+// readDirFS hides all methods of a filesystem other than Open and ReadDir
+type readDirFS struct {
+	fs.ReadDirFS
 }
 
 func (w *wdFS) Create(name string) (fs.File, error) {
@@ -422,6 +427,25 @@ func (w *wdFS) Remove(name string) error {
 	return hackpadfs.Remove(fsys, resolved)
 }
 
+func (w *wdFS) Rename(oldname, newname string) error {
+	oldRoot, oldResolved, err := resolveLocalPath(w.vol, oldname)
+	if err != nil {
+		return fmt.Errorf("resolve: %w", err)
+	}
+	newRoot, newResolved, err := resolveLocalPath(w.vol, newname)
+	if err != nil {
+		return fmt.Errorf("resolve: %w", err)
+	}
+	if oldRoot != newRoot {
+		return fmt.Errorf("rename %q to %q: can't rename across volumes", oldname, newname)
+	}
+	fsys, err := w.fsysFor(oldRoot)
+	if err != nil {
+		return err
+	}
+	return hackpadfs.Rename(fsys, oldResolved, newResolved)
+}
+
 func (w *wdFS) Chmod(name string, mode fs.FileMode) error {
 	root, resolved, err := resolveLocalPath(w.vol, name)
 	if err != nil {
@@ -62,6 +62,19 @@ func TestWDFS_ReadOps(t *testing.T) {
 	b, err = fs.ReadFile(subfs, "bar")
 	require.NoError(t, err)
 	assert.Equal(t, "goodnight moon", string(b))
+
+	matches, err := fs.Glob(fsys, "/tmp/*.txt")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/tmp/one.txt", "/tmp/three.txt", "/tmp/two.txt"}, matches)
+
+	// relative to the working directory
+	matches, err = fs.Glob(fsys, "tmp/t*.txt")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tmp/three.txt", "tmp/two.txt"}, matches)
+
+	matches, err = fs.Glob(fsys, "/tmp/*.json")
+	require.NoError(t, err)
+	assert.Empty(t, matches)
 }
 
 func TestWDFS_WriteOps(t *testing.T) {
@@ -133,6 +146,16 @@ func TestWDFS_WriteOps(t *testing.T) {
 	assert.True(t, fi.Mode().IsRegular())
 	assert.Equal(t, "0444", fmt.Sprintf("%#o", fi.Mode().Perm()))
 
+	err = fsys.Rename("/tmp/one.txt", "/tmp/sub/renamed.txt")
+	require.NoError(t, err)
+
+	b, err = fs.ReadFile(fsys, "/tmp/sub/renamed.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "one", string(b))
+
+	_, err = fsys.Stat("/tmp/one.txt")
+	require.ErrorIs(t, err, fs.ErrNotExist)
+
 	// now delete it
 	err = fsys.Remove("/tmp/foo")
 	require.NoError(t, err)
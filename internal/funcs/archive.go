@@ -0,0 +1,59 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/internal/archive"
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+)
+
+// CreateArchiveFuncs -
+func CreateArchiveFuncs(ctx context.Context, sr datafs.DataSourceReader) map[string]interface{} {
+	ns := &ArchiveFuncs{ctx: ctx, sr: sr}
+
+	return map[string]interface{}{
+		"archive": func() interface{} { return ns },
+	}
+}
+
+// ArchiveFuncs -
+type ArchiveFuncs struct {
+	ctx context.Context
+	sr  datafs.DataSourceReader
+}
+
+func (f *ArchiveFuncs) read(alias string) ([]byte, error) {
+	_, b, err := f.sr.ReadSource(f.ctx, alias)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive %q: %w", alias, err)
+	}
+
+	return b, nil
+}
+
+// List - lists the files in the archive read from the named datasource
+func (f *ArchiveFuncs) List(alias string) ([]string, error) {
+	b, err := f.read(alias)
+	if err != nil {
+		return nil, err
+	}
+
+	return archive.List(b)
+}
+
+// Read - reads the named file from the archive read from the named datasource
+func (f *ArchiveFuncs) Read(alias string, name interface{}) (string, error) {
+	b, err := f.read(alias)
+	if err != nil {
+		return "", err
+	}
+
+	out, err := archive.Read(b, conv.ToString(name))
+	if err != nil {
+		return "", fmt.Errorf("failed to read from archive %q: %w", alias, err)
+	}
+
+	return string(out), nil
+}
@@ -0,0 +1,71 @@
+package funcs
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/url"
+	"runtime"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateArchiveFuncs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fmap := CreateArchiveFuncs(ctx, nil)
+	actual := fmap["archive"].(func() interface{})
+
+	assert.Equal(t, ctx, actual().(*ArchiveFuncs).ctx)
+}
+
+func TestArchive(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	w, err := zw.Create("docs/README.md")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("# hello"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	root := "/tmp/"
+	if runtime.GOOS == osWindows {
+		root = "C:/tmp/"
+	}
+
+	fsys := datafs.WrapWdFS(fstest.MapFS{
+		"tmp/release.zip": &fstest.MapFile{Data: buf.Bytes()},
+	})
+	ctx := datafs.ContextWithFSProvider(context.Background(), datafs.WrappedFSProvider(fsys, "file", ""))
+
+	reg := datafs.NewRegistry()
+	reg.Register("release", config.DataSource{URL: &url.URL{Scheme: "file", Path: root + "release.zip"}})
+
+	af := &ArchiveFuncs{ctx: ctx, sr: datafs.NewSourceReader(reg)}
+
+	names, err := af.List("release")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs/README.md"}, names)
+
+	out, err := af.Read("release", "docs/README.md")
+	require.NoError(t, err)
+	assert.Equal(t, "# hello", out)
+
+	_, err = af.Read("release", "bogus")
+	require.Error(t, err)
+
+	_, err = af.List("bogus")
+	require.Error(t, err)
+
+	_, err = af.Read("bogus", "docs/README.md")
+	require.Error(t, err)
+}
@@ -2,8 +2,12 @@ package funcs
 
 import (
 	"context"
+	"fmt"
 	"sync"
+	"time"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/hairyhenderson/gomplate/v4/aws"
 	"github.com/hairyhenderson/gomplate/v4/conv"
 )
@@ -108,6 +112,87 @@ func (a *Funcs) ARN() (string, error) {
 	return a.sts.Arn()
 }
 
+// AccountID - an alias for Account
+func (a *Funcs) AccountID() (string, error) {
+	return a.Account()
+}
+
+// AssumeRole - Assumes the given IAM role, returning a map of temporary
+// credentials (AccessKeyId, SecretAccessKey, SessionToken, and Expiration),
+// suitable for use with SignV4URL.
+func (a *Funcs) AssumeRole(args ...interface{}) (map[string]interface{}, error) {
+	m, role, err := optionsAndInput(args)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := aws.AssumeRoleOptions{}
+	for k, v := range m {
+		switch k {
+		case "sessionName":
+			opts.SessionName = conv.ToString(v)
+		case "externalID":
+			opts.ExternalID = conv.ToString(v)
+		case "duration":
+			opts.Duration, err = durationArg(v)
+		default:
+			err = fmt.Errorf("unknown option %q: must be one of sessionName, externalID, or duration", k)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid option %q: %w", k, err)
+		}
+	}
+
+	a.stsInit.Do(a.initSTS)
+	creds, err := a.sts.AssumeRole(conv.ToString(role), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	out := map[string]interface{}{
+		"AccessKeyId":     awssdk.StringValue(creds.AccessKeyId),
+		"SecretAccessKey": awssdk.StringValue(creds.SecretAccessKey),
+		"SessionToken":    awssdk.StringValue(creds.SessionToken),
+		"Expiration":      awssdk.TimeValue(creds.Expiration),
+	}
+
+	return out, nil
+}
+
+// SignV4URL - Returns a pre-signed copy of the given URL, using AWS Signature
+// Version 4.
+func (a *Funcs) SignV4URL(args ...interface{}) (string, error) {
+	m, u, err := optionsAndInput(args)
+	if err != nil {
+		return "", err
+	}
+
+	opts := aws.PresignOptions{}
+	for k, v := range m {
+		switch k {
+		case "method":
+			opts.Method = conv.ToString(v)
+		case "service":
+			opts.Service = conv.ToString(v)
+		case "region":
+			opts.Region = conv.ToString(v)
+		case "expires":
+			opts.Expires, err = durationArg(v)
+		case "credentials":
+			opts.Credentials, err = credentialsArg(v)
+		default:
+			err = fmt.Errorf("unknown option %q: must be one of method, service, region, expires, or credentials", k)
+		}
+
+		if err != nil {
+			return "", fmt.Errorf("invalid option %q: %w", k, err)
+		}
+	}
+
+	return aws.SignV4URL(conv.ToString(u), opts)
+}
+
 func (a *Funcs) initMeta() {
 	if a.meta == nil {
 		a.meta = aws.NewEc2Meta(a.awsopts)
@@ -131,3 +216,36 @@ func (a *Funcs) initSTS() {
 		a.sts = aws.NewSTS(a.awsopts)
 	}
 }
+
+// durationArg converts a duration, a number of seconds, or a duration string
+// to a duration
+func durationArg(v interface{}) (time.Duration, error) {
+	if d, ok := v.(time.Duration); ok {
+		return d, nil
+	}
+
+	return conv.ParseDuration(conv.ToString(v))
+}
+
+// credentialsArg converts a map of credentials, like the one returned by
+// AssumeRole, to static credentials
+func credentialsArg(v interface{}) (*credentials.Credentials, error) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("must be a map, not %T", v)
+	}
+
+	str := func(k string) string {
+		if v, ok := m[k]; ok && v != nil {
+			return conv.ToString(v)
+		}
+		return ""
+	}
+
+	id, secret := str("AccessKeyId"), str("SecretAccessKey")
+	if id == "" || secret == "" {
+		return nil, fmt.Errorf("AccessKeyId and SecretAccessKey are required")
+	}
+
+	return credentials.NewStaticCredentials(id, secret, str("SessionToken")), nil
+}
@@ -2,11 +2,14 @@ package funcs
 
 import (
 	"context"
+	"net/url"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/hairyhenderson/gomplate/v4/aws"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateAWSFuncs(t *testing.T) {
@@ -38,6 +41,59 @@ func TestAWSFuncs(t *testing.T) {
 	assert.Equal(t, "unknown", must(af.EC2Region()))
 }
 
+func TestSignV4URL(t *testing.T) {
+	t.Parallel()
+
+	af := &Funcs{}
+	creds := map[string]interface{}{
+		"AccessKeyId":     "AKID",
+		"SecretAccessKey": "SECRET",
+		"SessionToken":    "TOKEN",
+		"Expiration":      time.Now().Add(time.Hour),
+	}
+
+	out, err := af.SignV4URL(map[string]interface{}{
+		"credentials": creds,
+		"region":      "us-west-2",
+		"expires":     "1h",
+		"method":      "put",
+	}, "https://bucket.s3.amazonaws.com/key")
+	require.NoError(t, err)
+
+	u, err := url.Parse(out)
+	require.NoError(t, err)
+	q := u.Query()
+	assert.Contains(t, q.Get("X-Amz-Credential"), "/us-west-2/s3/aws4_request")
+	assert.Equal(t, "3600", q.Get("X-Amz-Expires"))
+	assert.Equal(t, "TOKEN", q.Get("X-Amz-Security-Token"))
+	assert.NotEmpty(t, q.Get("X-Amz-Signature"))
+
+	_, err = af.SignV4URL(map[string]interface{}{"foo": "bar"}, "https://s3.amazonaws.com")
+	require.ErrorContains(t, err, `unknown option "foo"`)
+
+	_, err = af.SignV4URL(map[string]interface{}{
+		"credentials": map[string]interface{}{"AccessKeyId": "AKID"},
+	}, "https://s3.amazonaws.com")
+	require.ErrorContains(t, err, "AccessKeyId and SecretAccessKey are required")
+
+	_, err = af.SignV4URL(map[string]interface{}{"credentials": "foo"}, "https://s3.amazonaws.com")
+	require.ErrorContains(t, err, "must be a map")
+
+	_, err = af.SignV4URL()
+	require.Error(t, err)
+}
+
+func TestAssumeRoleOptions(t *testing.T) {
+	t.Parallel()
+
+	af := &Funcs{}
+	_, err := af.AssumeRole(map[string]interface{}{"foo": "bar"}, "arn:aws:iam::123456789012:role/foo")
+	require.ErrorContains(t, err, `unknown option "foo"`)
+
+	_, err = af.AssumeRole(map[string]interface{}{"duration": "forever"}, "arn:aws:iam::123456789012:role/foo")
+	require.ErrorContains(t, err, `invalid option "duration"`)
+}
+
 func must(r interface{}, err error) interface{} {
 	if err != nil {
 		panic(err)
@@ -0,0 +1,84 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hairyhenderson/gomplate/v4/azure"
+	"github.com/hairyhenderson/gomplate/v4/conv"
+)
+
+// CreateAzureFuncs -
+func CreateAzureFuncs(ctx context.Context) map[string]interface{} {
+	ns := &AzureFuncs{
+		ctx:       ctx,
+		azureopts: azure.GetClientOptions(),
+	}
+	return map[string]interface{}{
+		"azure": func() interface{} { return ns },
+	}
+}
+
+// AzureFuncs -
+type AzureFuncs struct {
+	ctx context.Context
+
+	meta      *azure.MetaClient
+	metaInit  sync.Once
+	azureopts azure.ClientOptions
+}
+
+// Meta -
+func (a *AzureFuncs) Meta(key string, def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.Meta(key, def...)
+}
+
+// SubscriptionID -
+func (a *AzureFuncs) SubscriptionID(def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.SubscriptionID(def...)
+}
+
+// TenantID -
+func (a *AzureFuncs) TenantID(def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.TenantID(def...)
+}
+
+// ResourceGroup -
+func (a *AzureFuncs) ResourceGroup(def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.ResourceGroup(def...)
+}
+
+// Location -
+func (a *AzureFuncs) Location(def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.Location(def...)
+}
+
+// AccessToken - returns a managed identity token for the given resource, and
+// optionally the client ID of a user-assigned identity
+func (a *AzureFuncs) AccessToken(args ...interface{}) (string, error) {
+	resource, clientID := "", ""
+	switch len(args) {
+	case 0:
+	case 1:
+		resource = conv.ToString(args[0])
+	case 2:
+		resource, clientID = conv.ToString(args[0]), conv.ToString(args[1])
+	default:
+		return "", fmt.Errorf("wrong number of args: want 0, 1, or 2, got %d", len(args))
+	}
+
+	a.metaInit.Do(a.initMeta)
+	return a.meta.AccessToken(resource, clientID)
+}
+
+func (a *AzureFuncs) initMeta() {
+	if a.meta == nil {
+		a.meta = azure.NewMetaClient(a.ctx, a.azureopts)
+	}
+}
@@ -0,0 +1,34 @@
+package funcs
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAzureFuncs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateAzureFuncs(ctx)
+			actual := fmap["azure"].(func() interface{})
+
+			assert.Equal(t, ctx, actual().(*AzureFuncs).ctx)
+		})
+	}
+}
+
+func TestAzureAccessTokenArgs(t *testing.T) {
+	t.Parallel()
+
+	a := &AzureFuncs{}
+	_, err := a.AccessToken("a", "b", "c")
+	assert.EqualError(t, err, "wrong number of args: want 0, 1, or 2, got 3")
+}
@@ -0,0 +1,63 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hairyhenderson/gomplate/v4/base32"
+	"github.com/hairyhenderson/gomplate/v4/conv"
+)
+
+// CreateBase32Funcs -
+func CreateBase32Funcs(ctx context.Context) map[string]interface{} {
+	f := map[string]interface{}{}
+
+	ns := &Base32Funcs{ctx}
+	f["base32"] = func() interface{} { return ns }
+
+	return f
+}
+
+// Base32Funcs -
+type Base32Funcs struct {
+	ctx context.Context
+}
+
+// Encode -
+func (Base32Funcs) Encode(args ...interface{}) (string, error) {
+	encoding, in, err := stringArgAndInput(args)
+	if err != nil {
+		return "", err
+	}
+
+	return base32.Encode(encoding, toBytes(in))
+}
+
+// Decode -
+func (f Base32Funcs) Decode(args ...interface{}) (string, error) {
+	out, err := f.DecodeBytes(args...)
+	return string(out), err
+}
+
+// DecodeBytes -
+func (Base32Funcs) DecodeBytes(args ...interface{}) ([]byte, error) {
+	encoding, in, err := stringArgAndInput(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return base32.Decode(encoding, conv.ToString(in))
+}
+
+// stringArgAndInput splits the arguments of a function that takes an optional
+// leading string argument (such as an encoding name) before its input
+func stringArgAndInput(args []interface{}) (string, interface{}, error) {
+	switch len(args) {
+	case 1:
+		return "", args[0], nil
+	case 2:
+		return conv.ToString(args[0]), args[1], nil
+	default:
+		return "", nil, fmt.Errorf("wrong number of args: want 1 or 2, got %d", len(args))
+	}
+}
@@ -0,0 +1,56 @@
+package funcs
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateBase32Funcs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateBase32Funcs(ctx)
+			actual := fmap["base32"].(func() interface{})
+
+			assert.Equal(t, ctx, actual().(*Base32Funcs).ctx)
+		})
+	}
+}
+
+func TestBase32Encode(t *testing.T) {
+	t.Parallel()
+
+	bf := &Base32Funcs{}
+	assert.Equal(t, "MZXW6YTBOI======", must(bf.Encode("foobar")))
+	assert.Equal(t, "CSQPYRK1E8", must(bf.Encode("crockford", []byte("foobar"))))
+
+	_, err := bf.Encode("bogus", "foobar")
+	require.Error(t, err)
+
+	_, err = bf.Encode()
+	require.Error(t, err)
+}
+
+func TestBase32Decode(t *testing.T) {
+	t.Parallel()
+
+	bf := &Base32Funcs{}
+	assert.Equal(t, "foobar", must(bf.Decode("mzxw6ytboi")))
+	assert.Equal(t, "foobar", must(bf.Decode("hex", "CPNMUOJ1E8======")))
+
+	out, err := bf.DecodeBytes("crockford", "CSQP-YRK1-E8")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("foobar"), out)
+
+	_, err = bf.Decode("a", "b", "c")
+	require.Error(t, err)
+}
@@ -0,0 +1,39 @@
+package funcs
+
+import (
+	"context"
+
+	"github.com/hairyhenderson/gomplate/v4/base85"
+	"github.com/hairyhenderson/gomplate/v4/conv"
+)
+
+// CreateBase85Funcs -
+func CreateBase85Funcs(ctx context.Context) map[string]interface{} {
+	f := map[string]interface{}{}
+
+	ns := &Base85Funcs{ctx}
+	f["base85"] = func() interface{} { return ns }
+
+	return f
+}
+
+// Base85Funcs -
+type Base85Funcs struct {
+	ctx context.Context
+}
+
+// Encode -
+func (Base85Funcs) Encode(in interface{}) (string, error) {
+	return base85.Encode(toBytes(in))
+}
+
+// Decode -
+func (Base85Funcs) Decode(in interface{}) (string, error) {
+	out, err := base85.Decode(conv.ToString(in))
+	return string(out), err
+}
+
+// DecodeBytes -
+func (Base85Funcs) DecodeBytes(in interface{}) ([]byte, error) {
+	return base85.Decode(conv.ToString(in))
+}
@@ -0,0 +1,42 @@
+package funcs
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateBase85Funcs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateBase85Funcs(ctx)
+			actual := fmap["base85"].(func() interface{})
+
+			assert.Equal(t, ctx, actual().(*Base85Funcs).ctx)
+		})
+	}
+}
+
+func TestBase85(t *testing.T) {
+	t.Parallel()
+
+	bf := &Base85Funcs{}
+	assert.Equal(t, "BOu!rD]j7BEbo7", must(bf.Encode("hello world")))
+	assert.Equal(t, "hello world", must(bf.Decode("<~BOu!rD]j7BEbo7~>")))
+
+	out, err := bf.DecodeBytes("z")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 0}, out)
+
+	_, err = bf.Decode("{")
+	require.Error(t, err)
+}
@@ -0,0 +1,124 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/math"
+)
+
+// CreateBigNumFuncs -
+func CreateBigNumFuncs(ctx context.Context) map[string]interface{} {
+	f := map[string]interface{}{}
+
+	ns := &BigNumFuncs{ctx}
+	f["bignum"] = func() interface{} { return ns }
+
+	return f
+}
+
+// BigNumFuncs -
+type BigNumFuncs struct {
+	ctx context.Context
+}
+
+// parseDecimals parses each of the inputs as arbitrary-precision decimals
+func parseDecimals(n []interface{}) ([]*big.Rat, []int, error) {
+	nums := make([]*big.Rat, len(n))
+	scales := make([]int, len(n))
+
+	for i, v := range n {
+		r, scale, err := math.ParseDecimal(conv.ToString(v))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		nums[i] = r
+		scales[i] = scale
+	}
+
+	return nums, scales, nil
+}
+
+// Add -
+func (BigNumFuncs) Add(n ...interface{}) (string, error) {
+	nums, scales, err := parseDecimals(n)
+	if err != nil {
+		return "", err
+	}
+
+	x := new(big.Rat)
+	scale := 0
+	for i, v := range nums {
+		x.Add(x, v)
+		scale = max(scale, scales[i])
+	}
+
+	return math.FormatDecimal(x, scale), nil
+}
+
+// Sub -
+func (BigNumFuncs) Sub(a, b interface{}) (string, error) {
+	nums, scales, err := parseDecimals([]interface{}{a, b})
+	if err != nil {
+		return "", err
+	}
+
+	x := new(big.Rat).Sub(nums[0], nums[1])
+
+	return math.FormatDecimal(x, max(scales[0], scales[1])), nil
+}
+
+// Mul -
+func (BigNumFuncs) Mul(n ...interface{}) (string, error) {
+	nums, scales, err := parseDecimals(n)
+	if err != nil {
+		return "", err
+	}
+
+	x := big.NewRat(1, 1)
+	scale := 0
+	for i, v := range nums {
+		x.Mul(x, v)
+		scale += scales[i]
+	}
+
+	return math.FormatDecimal(x, scale), nil
+}
+
+// Div -
+func (BigNumFuncs) Div(scale, a, b interface{}) (string, error) {
+	s, err := conv.ToInt(scale)
+	if err != nil {
+		return "", fmt.Errorf("scale must be an integer: %w", err)
+	}
+
+	if s < 0 {
+		return "", fmt.Errorf("scale must not be negative, got %d", s)
+	}
+
+	nums, _, err := parseDecimals([]interface{}{a, b})
+	if err != nil {
+		return "", err
+	}
+
+	if nums[1].Sign() == 0 {
+		return "", fmt.Errorf("division by 0")
+	}
+
+	x := new(big.Rat).Quo(nums[0], nums[1])
+
+	return math.FormatDecimal(x, s), nil
+}
+
+// Cmp -
+func (BigNumFuncs) Cmp(a, b interface{}) (int, error) {
+	nums, _, err := parseDecimals([]interface{}{a, b})
+	if err != nil {
+		return 0, err
+	}
+
+	return nums[0].Cmp(nums[1]), nil
+}
@@ -0,0 +1,138 @@
+package funcs
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateBigNumFuncs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateBigNumFuncs(ctx)
+			actual := fmap["bignum"].(func() interface{})
+
+			assert.Equal(t, ctx, actual().(*BigNumFuncs).ctx)
+		})
+	}
+}
+
+func TestBigNumAdd(t *testing.T) {
+	t.Parallel()
+
+	b := BigNumFuncs{}
+
+	actual, err := b.Add("0.1", "0.2")
+	require.NoError(t, err)
+	assert.Equal(t, "0.3", actual)
+
+	actual, err = b.Add(0.1, 0.2, "1.005")
+	require.NoError(t, err)
+	assert.Equal(t, "1.305", actual)
+
+	actual, err = b.Add("9223372036854775807", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "9223372036854775808", actual)
+
+	actual, err = b.Add()
+	require.NoError(t, err)
+	assert.Equal(t, "0", actual)
+
+	_, err = b.Add(1, "foo")
+	require.Error(t, err)
+}
+
+func TestBigNumSub(t *testing.T) {
+	t.Parallel()
+
+	b := BigNumFuncs{}
+
+	actual, err := b.Sub("1.00", "0.01")
+	require.NoError(t, err)
+	assert.Equal(t, "0.99", actual)
+
+	actual, err = b.Sub(0, "123456789012345678901234567890")
+	require.NoError(t, err)
+	assert.Equal(t, "-123456789012345678901234567890", actual)
+
+	_, err = b.Sub("", 1)
+	require.Error(t, err)
+}
+
+func TestBigNumMul(t *testing.T) {
+	t.Parallel()
+
+	b := BigNumFuncs{}
+
+	actual, err := b.Mul("19.99", 3)
+	require.NoError(t, err)
+	assert.Equal(t, "59.97", actual)
+
+	actual, err = b.Mul("1.5", "1.5")
+	require.NoError(t, err)
+	assert.Equal(t, "2.25", actual)
+
+	actual, err = b.Mul("0xffffffffffffffff", "0xffffffffffffffff")
+	require.NoError(t, err)
+	assert.Equal(t, "340282366920938463426481119284349108225", actual)
+
+	_, err = b.Mul(2, "1/2")
+	require.Error(t, err)
+}
+
+func TestBigNumDiv(t *testing.T) {
+	t.Parallel()
+
+	b := BigNumFuncs{}
+
+	actual, err := b.Div(2, 10, 3)
+	require.NoError(t, err)
+	assert.Equal(t, "3.33", actual)
+
+	actual, err = b.Div("4", "2", "3")
+	require.NoError(t, err)
+	assert.Equal(t, "0.6667", actual)
+
+	actual, err = b.Div(0, 5, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "3", actual)
+
+	_, err = b.Div(2, 1, 0)
+	require.Error(t, err)
+
+	_, err = b.Div(-1, 1, 2)
+	require.Error(t, err)
+
+	_, err = b.Div("foo", 1, 2)
+	require.Error(t, err)
+}
+
+func TestBigNumCmp(t *testing.T) {
+	t.Parallel()
+
+	b := BigNumFuncs{}
+
+	actual, err := b.Cmp("1.10", 1.1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, actual)
+
+	actual, err = b.Cmp("100000000000000000001", "100000000000000000000")
+	require.NoError(t, err)
+	assert.Equal(t, 1, actual)
+
+	actual, err = b.Cmp("-0.5", 0)
+	require.NoError(t, err)
+	assert.Equal(t, -1, actual)
+
+	_, err = b.Cmp("foo", 0)
+	require.Error(t, err)
+}
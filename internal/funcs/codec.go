@@ -0,0 +1,116 @@
+package funcs
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// CreateCodecFuncs -
+func CreateCodecFuncs(ctx context.Context) map[string]interface{} {
+	f := map[string]interface{}{}
+
+	ns := &CodecFuncs{ctx}
+	f["codec"] = func() interface{} { return ns }
+
+	return f
+}
+
+// CodecFuncs -
+type CodecFuncs struct {
+	ctx context.Context
+}
+
+type qrOpts struct {
+	format string
+	size   int
+	level  qrcode.RecoveryLevel
+	invert bool
+}
+
+// QRCode - encodes the input as a QR code, returned as a base64-encoded PNG
+// image, or as ASCII art (with the "format" option set to "ascii")
+func (CodecFuncs) QRCode(args ...interface{}) (string, error) {
+	opts := qrOpts{format: "png", size: 256, level: qrcode.Medium}
+
+	var in interface{}
+	switch len(args) {
+	case 1:
+		in = args[0]
+	case 2:
+		m, ok := args[0].(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("options must be a map, not %T", args[0])
+		}
+
+		if err := parseQROpts(m, &opts); err != nil {
+			return "", err
+		}
+
+		in = args[1]
+	default:
+		return "", fmt.Errorf("wrong number of args: want 1 or 2, got %d", len(args))
+	}
+
+	q, err := qrcode.New(conv.ToString(in), opts.level)
+	if err != nil {
+		return "", fmt.Errorf("failed to create QR code: %w", err)
+	}
+
+	if opts.format == "ascii" {
+		return q.ToSmallString(opts.invert), nil
+	}
+
+	b, err := q.PNG(opts.size)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode QR code as PNG: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+func parseQROpts(m map[string]interface{}, opts *qrOpts) error {
+	for k, v := range m {
+		switch k {
+		case "format":
+			opts.format = strings.ToLower(conv.ToString(v))
+			if opts.format != "png" && opts.format != "ascii" {
+				return fmt.Errorf("unknown format %q: must be png or ascii", opts.format)
+			}
+		case "size":
+			n, err := conv.ToInt(v)
+			if err != nil {
+				return fmt.Errorf("size must be an integer: %w", err)
+			}
+
+			if n <= 0 {
+				return fmt.Errorf("size must be greater than 0, got %d", n)
+			}
+
+			opts.size = n
+		case "level":
+			switch strings.ToLower(conv.ToString(v)) {
+			case "low", "l":
+				opts.level = qrcode.Low
+			case "medium", "m":
+				opts.level = qrcode.Medium
+			case "high", "q":
+				opts.level = qrcode.High
+			case "highest", "h":
+				opts.level = qrcode.Highest
+			default:
+				return fmt.Errorf("unknown level %q: must be low, medium, high, or highest", v)
+			}
+		case "invert":
+			opts.invert = conv.ToBool(v)
+		default:
+			return fmt.Errorf("unknown option %q: must be one of format, size, level, or invert", k)
+		}
+	}
+
+	return nil
+}
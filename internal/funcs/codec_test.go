@@ -0,0 +1,87 @@
+package funcs
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"image/png"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCodecFuncs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateCodecFuncs(ctx)
+			actual := fmap["codec"].(func() interface{})
+
+			assert.Equal(t, ctx, actual().(*CodecFuncs).ctx)
+		})
+	}
+}
+
+func TestQRCode(t *testing.T) {
+	t.Parallel()
+
+	c := CodecFuncs{}
+
+	out, err := c.QRCode("https://example.com")
+	require.NoError(t, err)
+
+	b, err := base64.StdEncoding.DecodeString(out)
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(b))
+	require.NoError(t, err)
+	assert.Equal(t, 256, img.Bounds().Dx())
+
+	out, err = c.QRCode(map[string]interface{}{"size": 64, "level": "highest"}, "https://example.com")
+	require.NoError(t, err)
+
+	b, err = base64.StdEncoding.DecodeString(out)
+	require.NoError(t, err)
+
+	img, err = png.Decode(bytes.NewReader(b))
+	require.NoError(t, err)
+	assert.Equal(t, 64, img.Bounds().Dx())
+
+	out, err = c.QRCode(map[string]interface{}{"format": "ascii"}, "WIFI:T:WPA;S:example;P:secret;;")
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	assert.Greater(t, len(lines), 10)
+	assert.Contains(t, out, "█")
+
+	inverted, err := c.QRCode(map[string]interface{}{"format": "ASCII", "invert": true}, "WIFI:T:WPA;S:example;P:secret;;")
+	require.NoError(t, err)
+	assert.NotEqual(t, out, inverted)
+
+	_, err = c.QRCode()
+	require.Error(t, err)
+
+	_, err = c.QRCode("foo", "bar")
+	require.Error(t, err)
+
+	for _, opts := range []map[string]interface{}{
+		{"format": "gif"},
+		{"size": 0},
+		{"size": "big"},
+		{"level": "extreme"},
+		{"colour": "red"},
+	} {
+		_, err = c.QRCode(opts, "foo")
+		require.Error(t, err, opts)
+	}
+
+	_, err = c.QRCode(strings.Repeat("x", 5000))
+	require.Error(t, err)
+}
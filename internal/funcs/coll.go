@@ -3,6 +3,7 @@ package funcs
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"reflect"
 
 	"github.com/hairyhenderson/gomplate/v4/conv"
@@ -116,28 +117,109 @@ func (CollFuncs) Reverse(in interface{}) ([]interface{}, error) {
 	return coll.Reverse(in)
 }
 
-// Merge -
-func (CollFuncs) Merge(dst map[string]interface{}, src ...map[string]interface{}) (map[string]interface{}, error) {
-	return coll.Merge(dst, src...)
+// Merge - merges maps, optionally with merge options (like "arrays=append")
+// given as leading string arguments
+func (CollFuncs) Merge(args ...interface{}) (map[string]interface{}, error) {
+	opts, maps, err := mergeArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return coll.MergeWithOptions(opts, maps[0], maps[1:]...)
 }
 
-// Sort -
-func (CollFuncs) Sort(args ...interface{}) ([]interface{}, error) {
-	var (
-		key  string
-		list interface{}
-	)
-	if len(args) == 0 || len(args) > 2 {
-		return nil, fmt.Errorf("wrong number of args: wanted 1 or 2, got %d", len(args))
+// toStringMap converts any map with string keys (including named map types
+// like the template context, and pointers to them) to a map[string]interface{}
+func toStringMap(in interface{}) (map[string]interface{}, bool) {
+	if m, ok := in.(map[string]interface{}); ok {
+		return m, true
 	}
-	if len(args) == 1 {
-		list = args[0]
+
+	v := reflect.ValueOf(in)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
 	}
-	if len(args) == 2 {
-		key = conv.ToString(args[0])
-		list = args[1]
+
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+
+	mapType := reflect.TypeOf(map[string]interface{}{})
+	if v.Type().ConvertibleTo(mapType) {
+		return v.Convert(mapType).Interface().(map[string]interface{}), true
+	}
+
+	m := make(map[string]interface{}, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		m[iter.Key().String()] = iter.Value().Interface()
+	}
+
+	return m, true
+}
+
+// mergeArgs splits the arguments to Merge into the merge options (in the same
+// query-string form as merge datasource URLs) and the maps to merge
+func mergeArgs(args []interface{}) (coll.MergeOptions, []map[string]interface{}, error) {
+	q := url.Values{}
+
+	i := 0
+	for ; i < len(args); i++ {
+		s, ok := args[i].(string)
+		if !ok {
+			break
+		}
+
+		opt, err := url.ParseQuery(s)
+		if err != nil {
+			return coll.MergeOptions{}, nil, fmt.Errorf("invalid merge option %q: %w", s, err)
+		}
+
+		for k, v := range opt {
+			switch k {
+			case "arrays", "listKey", "nulls", "depth", "coerce":
+				q[k] = v
+			default:
+				return coll.MergeOptions{}, nil, fmt.Errorf("unknown merge option %q", k)
+			}
+		}
+	}
+
+	if i == len(args) {
+		return coll.MergeOptions{}, nil, fmt.Errorf("wrong number of args: need at least 1 map to merge")
+	}
+
+	maps := make([]map[string]interface{}, 0, len(args)-i)
+	for _, arg := range args[i:] {
+		m, ok := toStringMap(arg)
+		if !ok {
+			return coll.MergeOptions{}, nil, fmt.Errorf("expected a map to merge, got %T", arg)
+		}
+
+		maps = append(maps, m)
+	}
+
+	opts, err := coll.ParseMergeOptions(q)
+
+	return opts, maps, err
+}
+
+// Sort - sort a list, optionally by one or more keys given as leading
+// arguments
+func (CollFuncs) Sort(args ...interface{}) ([]interface{}, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("wrong number of args: wanted at least 1, got 0")
+	}
+
+	keys := make([]string, len(args)-1)
+	for i, k := range args[:len(args)-1] {
+		keys[i] = conv.ToString(k)
 	}
-	return coll.Sort(key, list)
+
+	return coll.SortBy(keys, args[len(args)-1])
 }
 
 // JSONPath -
@@ -145,9 +227,22 @@ func (CollFuncs) JSONPath(p string, in interface{}) (interface{}, error) {
 	return coll.JSONPath(p, in)
 }
 
-// JQ -
-func (f *CollFuncs) JQ(jqExpr string, in interface{}) (interface{}, error) {
-	return coll.JQ(f.ctx, jqExpr, in)
+// JQ - filters the input with a jq expression, optionally with a map of
+// variables before the input
+func (f *CollFuncs) JQ(jqExpr string, args ...interface{}) (interface{}, error) {
+	switch len(args) {
+	case 1:
+		return coll.JQ(f.ctx, jqExpr, args[0])
+	case 2:
+		vars, ok := args[0].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected a map of variables, got %T", args[0])
+		}
+
+		return coll.JQWithVariables(f.ctx, jqExpr, vars, args[1])
+	default:
+		return nil, fmt.Errorf("wrong number of args: want 2 or 3, got %d", len(args)+1)
+	}
 }
 
 // Flatten -
@@ -172,6 +267,75 @@ func (CollFuncs) Flatten(args ...interface{}) ([]interface{}, error) {
 	return coll.Flatten(list, depth)
 }
 
+// FlattenDepth -
+func (CollFuncs) FlattenDepth(depth interface{}, in interface{}) (interface{}, error) {
+	d, err := conv.ToInt(depth)
+	if err != nil {
+		return nil, fmt.Errorf("wrong depth type: must be int, got %T (%+v)", depth, depth)
+	}
+
+	return coll.FlattenDepth(d, in)
+}
+
+// Paths -
+func (CollFuncs) Paths(in interface{}) []string {
+	return coll.Paths(in)
+}
+
+// GroupBy -
+func (f *CollFuncs) GroupBy(key string, list interface{}) (map[string]interface{}, error) {
+	return coll.GroupBy(f.ctx, key, list)
+}
+
+// UniqBy -
+func (f *CollFuncs) UniqBy(key string, list interface{}) ([]interface{}, error) {
+	return coll.UniqBy(f.ctx, key, list)
+}
+
+// Chunk -
+func (CollFuncs) Chunk(size interface{}, list interface{}) ([]interface{}, error) {
+	n, err := conv.ToInt(size)
+	if err != nil {
+		return nil, fmt.Errorf("wrong size type: must be int, got %T (%+v)", size, size)
+	}
+
+	return coll.Chunk(n, list)
+}
+
+// Zip -
+func (CollFuncs) Zip(lists ...interface{}) ([]interface{}, error) {
+	return coll.Zip(lists...)
+}
+
+// Union -
+func (CollFuncs) Union(lists ...interface{}) ([]interface{}, error) {
+	return coll.Union(lists...)
+}
+
+// Intersection -
+func (CollFuncs) Intersection(lists ...interface{}) ([]interface{}, error) {
+	return coll.Intersection(lists...)
+}
+
+// Difference -
+func (CollFuncs) Difference(lists ...interface{}) ([]interface{}, error) {
+	if len(lists) == 0 {
+		return nil, fmt.Errorf("wrong number of args: wanted at least 1, got 0")
+	}
+
+	return coll.Difference(lists[0], lists[1:]...)
+}
+
+// SymmetricDifference -
+func (CollFuncs) SymmetricDifference(a, b interface{}) ([]interface{}, error) {
+	return coll.SymmetricDifference(a, b)
+}
+
+// Diff -
+func (CollFuncs) Diff(from, to interface{}) map[string]interface{} {
+	return coll.Diff(from, to)
+}
+
 func pickOmitArgs(args ...interface{}) (map[string]interface{}, []string, error) {
 	if len(args) <= 1 {
 		return nil, nil, fmt.Errorf("wrong number of args: wanted 2 or more, got %d", len(args))
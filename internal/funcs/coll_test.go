@@ -47,6 +47,197 @@ func TestFlatten(t *testing.T) {
 	assert.EqualValues(t, []interface{}{1, []int{2}, 3}, out)
 }
 
+func TestGroupByUniqBy(t *testing.T) {
+	t.Parallel()
+
+	c := &CollFuncs{ctx: context.Background()}
+
+	in := []map[string]interface{}{
+		{"name": "a", "env": "prod"},
+		{"name": "b", "env": "dev"},
+		{"name": "c", "env": "prod"},
+	}
+
+	groups, err := c.GroupBy("env", in)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"prod": []interface{}{in[0], in[2]},
+		"dev":  []interface{}{in[1]},
+	}, groups)
+
+	out, err := c.UniqBy(".env", in)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{in[0], in[1]}, out)
+}
+
+func TestChunkZip(t *testing.T) {
+	t.Parallel()
+
+	c := CollFuncs{}
+
+	out, err := c.Chunk("2", []int{1, 2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{[]interface{}{1, 2}, []interface{}{3}}, out)
+
+	_, err = c.Chunk("foo", []int{1, 2, 3})
+	require.Error(t, err)
+
+	out, err = c.Zip([]string{"a", "b"}, []int{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{[]interface{}{"a", 1}, []interface{}{"b", 2}}, out)
+}
+
+func TestCollSort(t *testing.T) {
+	t.Parallel()
+
+	c := CollFuncs{}
+
+	_, err := c.Sort()
+	require.Error(t, err)
+
+	out, err := c.Sort([]int{3, 1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2, 3}, out)
+
+	in := []map[string]interface{}{
+		{"name": "b", "n": 1},
+		{"name": "a", "n": 2},
+		{"name": "c", "n": 1},
+	}
+
+	out, err = c.Sort("n", "-name", in)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{in[2], in[0], in[1]}, out)
+}
+
+func TestSetOps(t *testing.T) {
+	t.Parallel()
+
+	c := CollFuncs{}
+
+	out, err := c.Union([]int{1, 2}, []int{2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2, 3}, out)
+
+	out, err = c.Intersection([]int{1, 2}, []int{2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{2}, out)
+
+	_, err = c.Difference()
+	require.Error(t, err)
+
+	out, err = c.Difference([]int{1, 2}, []int{2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1}, out)
+
+	out, err = c.SymmetricDifference([]int{1, 2}, []int{2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 3}, out)
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	c := CollFuncs{}
+
+	out := c.Diff(map[string]interface{}{"a": 1, "b": 2}, map[string]interface{}{"a": 2, "c": 3})
+	assert.Equal(t, map[string]interface{}{
+		"added":   map[string]interface{}{"c": 3},
+		"removed": map[string]interface{}{"b": 2},
+		"changed": map[string]interface{}{"a": map[string]interface{}{"from": 1, "to": 2}},
+	}, out)
+}
+
+func TestFlattenDepthPaths(t *testing.T) {
+	t.Parallel()
+
+	c := CollFuncs{}
+
+	in := map[string]interface{}{"a": map[string]interface{}{"b": []interface{}{1, 2}}}
+
+	out, err := c.FlattenDepth("-1", in)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a.b.0": 1, "a.b.1": 2}, out)
+
+	_, err = c.FlattenDepth("foo", in)
+	require.Error(t, err)
+
+	assert.Equal(t, []string{"a.b.0", "a.b.1"}, c.Paths(in))
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	c := CollFuncs{}
+
+	def := map[string]interface{}{"list": []interface{}{"a"}, "x": 1, "y": 1}
+	over := map[string]interface{}{"list": []interface{}{"b"}, "x": nil}
+
+	out, err := c.Merge(over, def)
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{"list": []interface{}{"b"}, "x": nil, "y": 1}, out)
+
+	out, err = c.Merge("arrays=append", "nulls=delete", over, def)
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{"list": []interface{}{"a", "b"}, "y": 1}, out)
+
+	// options can also be combined in one string
+	out, err = c.Merge("arrays=append&nulls=delete", over, def)
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{"list": []interface{}{"a", "b"}, "y": 1}, out)
+
+	out, err = c.Merge(over)
+	require.NoError(t, err)
+	assert.EqualValues(t, over, out)
+
+	_, err = c.Merge()
+	require.Error(t, err)
+
+	_, err = c.Merge("arrays=append")
+	require.Error(t, err)
+
+	_, err = c.Merge("format=json", over, def)
+	require.ErrorContains(t, err, "unknown merge option")
+
+	_, err = c.Merge("arrays=bogus", over, def)
+	require.ErrorContains(t, err, "unknown array merge strategy")
+
+	_, err = c.Merge(over, "arrays=append", def)
+	require.ErrorContains(t, err, "expected a map")
+
+	// named map types (and pointers to them) are accepted too
+	type namedMap map[string]interface{}
+	named := namedMap{"x": 2, "z": 3}
+	out, err = c.Merge(&named, def)
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{"list": []interface{}{"a"}, "x": 2, "y": 1, "z": 3}, out)
+
+	out, err = c.Merge(map[string]string{"x": "s"}, def)
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{"list": []interface{}{"a"}, "x": "s", "y": 1}, out)
+}
+
+func TestCollFuncs_JQ(t *testing.T) {
+	t.Parallel()
+
+	c := &CollFuncs{ctx: context.Background()}
+	in := map[string]interface{}{"a": 1, "b": 2}
+
+	out, err := c.JQ(".a", in)
+	require.NoError(t, err)
+	assert.Equal(t, 1, out)
+
+	out, err = c.JQ(".[$k]", map[string]interface{}{"k": "b"}, in)
+	require.NoError(t, err)
+	assert.Equal(t, 2, out)
+
+	_, err = c.JQ(".a")
+	require.Error(t, err)
+
+	_, err = c.JQ(".[$k]", "k", in)
+	require.ErrorContains(t, err, "expected a map of variables")
+}
+
 func TestPick(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,230 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/env"
+	"golang.org/x/term"
+)
+
+// CreateColorFuncs -
+func CreateColorFuncs(ctx context.Context) map[string]interface{} {
+	ns := &ColorFuncs{ctx: ctx, enabled: colorEnabled(nil)}
+
+	return map[string]interface{}{
+		"color": func() interface{} { return ns },
+	}
+}
+
+// SetColorOutput enables the color functions in f when out is a terminal (or
+// when FORCE_COLOR is set). Without this, styling is only output when forced.
+func SetColorOutput(f map[string]interface{}, out io.Writer) {
+	nsf, ok := f["color"].(func() interface{})
+	if !ok {
+		return
+	}
+
+	ns, ok := nsf().(*ColorFuncs)
+	if !ok {
+		return
+	}
+
+	withOut := &ColorFuncs{ctx: ns.ctx, enabled: colorEnabled(out)}
+	f["color"] = func() interface{} { return withOut }
+}
+
+// colorEnabled - whether ANSI styling should be output to out. NO_COLOR (see
+// https://no-color.org) always disables it, and FORCE_COLOR enables it even
+// when the output isn't a terminal (for example, when rendering a MOTD file).
+func colorEnabled(out io.Writer) bool {
+	if env.Getenv("NO_COLOR") != "" {
+		return false
+	}
+
+	if force := env.Getenv("FORCE_COLOR"); force != "" {
+		return force != "0" && force != "false"
+	}
+
+	f, ok := out.(*os.File)
+
+	return ok && f != nil && term.IsTerminal(int(f.Fd())) && runtime.GOOS != "windows"
+}
+
+// ColorFuncs -
+type ColorFuncs struct {
+	ctx     context.Context
+	enabled bool
+}
+
+// ansiStyle is a pair of SGR codes which start and end a style
+type ansiStyle struct {
+	open, close int
+}
+
+//nolint:gochecknoglobals
+var ansiStyles = map[string]ansiStyle{
+	"bold":      {1, 22},
+	"dim":       {2, 22},
+	"italic":    {3, 23},
+	"underline": {4, 24},
+	"reverse":   {7, 27},
+
+	"black":   {30, 39},
+	"red":     {31, 39},
+	"green":   {32, 39},
+	"yellow":  {33, 39},
+	"blue":    {34, 39},
+	"magenta": {35, 39},
+	"cyan":    {36, 39},
+	"white":   {37, 39},
+	"gray":    {90, 39},
+
+	"bgBlack":   {40, 49},
+	"bgRed":     {41, 49},
+	"bgGreen":   {42, 49},
+	"bgYellow":  {43, 49},
+	"bgBlue":    {44, 49},
+	"bgMagenta": {45, 49},
+	"bgCyan":    {46, 49},
+	"bgWhite":   {47, 49},
+	"bgGray":    {100, 49},
+}
+
+// ansiPattern matches SGR escape sequences
+//
+//nolint:gochecknoglobals
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// apply wraps the input in the style's escape sequences. Nested styles which
+// end the same attribute (like a red word in a blue sentence) are re-opened
+// after they end, so the rest of the input keeps this style.
+func (s ansiStyle) apply(in string) string {
+	open := fmt.Sprintf("\x1b[%dm", s.open)
+	end := fmt.Sprintf("\x1b[%dm", s.close)
+
+	return open + strings.ReplaceAll(in, end, end+open) + end
+}
+
+func (f *ColorFuncs) style(name string, in interface{}) string {
+	s := conv.ToString(in)
+	if !f.enabled || s == "" {
+		return s
+	}
+
+	return ansiStyles[name].apply(s)
+}
+
+// Enabled -
+func (f *ColorFuncs) Enabled() bool {
+	return f.enabled
+}
+
+// Style -
+func (f *ColorFuncs) Style(args ...interface{}) (string, error) {
+	if len(args) < 2 {
+		return "", fmt.Errorf("wrong number of args: want at least 2, got %d", len(args))
+	}
+
+	styles := make([]ansiStyle, 0, len(args)-1)
+
+	for _, arg := range args[:len(args)-1] {
+		for _, name := range strings.FieldsFunc(conv.ToString(arg), func(r rune) bool {
+			return r == ',' || r == ' '
+		}) {
+			st, ok := ansiStyles[name]
+			if !ok {
+				return "", fmt.Errorf("unknown style %q", name)
+			}
+
+			styles = append(styles, st)
+		}
+	}
+
+	s := conv.ToString(args[len(args)-1])
+	if !f.enabled || s == "" {
+		return s, nil
+	}
+
+	for i := len(styles) - 1; i >= 0; i-- {
+		s = styles[i].apply(s)
+	}
+
+	return s, nil
+}
+
+// Strip -
+func (ColorFuncs) Strip(in interface{}) string {
+	return ansiPattern.ReplaceAllString(conv.ToString(in), "")
+}
+
+// Bold -
+func (f *ColorFuncs) Bold(in interface{}) string {
+	return f.style("bold", in)
+}
+
+// Dim -
+func (f *ColorFuncs) Dim(in interface{}) string {
+	return f.style("dim", in)
+}
+
+// Italic -
+func (f *ColorFuncs) Italic(in interface{}) string {
+	return f.style("italic", in)
+}
+
+// Underline -
+func (f *ColorFuncs) Underline(in interface{}) string {
+	return f.style("underline", in)
+}
+
+// Black -
+func (f *ColorFuncs) Black(in interface{}) string {
+	return f.style("black", in)
+}
+
+// Red -
+func (f *ColorFuncs) Red(in interface{}) string {
+	return f.style("red", in)
+}
+
+// Green -
+func (f *ColorFuncs) Green(in interface{}) string {
+	return f.style("green", in)
+}
+
+// Yellow -
+func (f *ColorFuncs) Yellow(in interface{}) string {
+	return f.style("yellow", in)
+}
+
+// Blue -
+func (f *ColorFuncs) Blue(in interface{}) string {
+	return f.style("blue", in)
+}
+
+// Magenta -
+func (f *ColorFuncs) Magenta(in interface{}) string {
+	return f.style("magenta", in)
+}
+
+// Cyan -
+func (f *ColorFuncs) Cyan(in interface{}) string {
+	return f.style("cyan", in)
+}
+
+// White -
+func (f *ColorFuncs) White(in interface{}) string {
+	return f.style("white", in)
+}
+
+// Gray -
+func (f *ColorFuncs) Gray(in interface{}) string {
+	return f.style("gray", in)
+}
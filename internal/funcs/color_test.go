@@ -0,0 +1,118 @@
+package funcs
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateColorFuncs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateColorFuncs(ctx)
+			actual := fmap["color"].(func() interface{})
+
+			assert.Equal(t, ctx, actual().(*ColorFuncs).ctx)
+		})
+	}
+}
+
+func TestColorEnabled(t *testing.T) {
+	// not a terminal
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "")
+	assert.False(t, colorEnabled(nil))
+
+	t.Setenv("FORCE_COLOR", "1")
+	assert.True(t, colorEnabled(nil))
+
+	t.Setenv("FORCE_COLOR", "0")
+	assert.False(t, colorEnabled(nil))
+
+	t.Setenv("FORCE_COLOR", "1")
+	t.Setenv("NO_COLOR", "1")
+	assert.False(t, colorEnabled(nil))
+
+	// regular files aren't terminals
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "")
+	f, err := os.Create(filepath.Join(t.TempDir(), "out"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+	assert.False(t, colorEnabled(f))
+	assert.False(t, colorEnabled(&bytes.Buffer{}))
+}
+
+func TestSetColorOutput(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "1")
+
+	ctx := context.Background()
+	fmap := CreateColorFuncs(ctx)
+	SetColorOutput(fmap, &bytes.Buffer{})
+
+	ns := fmap["color"].(func() interface{})().(*ColorFuncs)
+	assert.True(t, ns.enabled)
+	assert.Equal(t, ctx, ns.ctx)
+
+	t.Setenv("FORCE_COLOR", "")
+	SetColorOutput(fmap, &bytes.Buffer{})
+	assert.False(t, fmap["color"].(func() interface{})().(*ColorFuncs).enabled)
+
+	// no color namespace - nothing to do
+	fmap = map[string]interface{}{}
+	SetColorOutput(fmap, nil)
+	assert.Empty(t, fmap)
+}
+
+func TestColor(t *testing.T) {
+	t.Parallel()
+
+	c := &ColorFuncs{enabled: true}
+
+	assert.Equal(t, "\x1b[31mhi\x1b[39m", c.Red("hi"))
+	assert.Equal(t, "\x1b[1m42\x1b[22m", c.Bold(42))
+	assert.Equal(t, "\x1b[90mx\x1b[39m", c.Gray("x"))
+	assert.Equal(t, "", c.Green(""))
+
+	// nested colors re-open the outer color
+	assert.Equal(t, "\x1b[34ma \x1b[31mb\x1b[39m\x1b[34m c\x1b[39m",
+		c.Blue("a "+c.Red("b")+" c"))
+
+	out, err := c.Style("bold", "red,bgWhite", "hi")
+	require.NoError(t, err)
+	assert.Equal(t, "\x1b[1m\x1b[31m\x1b[47mhi\x1b[49m\x1b[39m\x1b[22m", out)
+	assert.Equal(t, "hi", c.Strip(out))
+
+	_, err = c.Style("sparkly", "hi")
+	require.ErrorContains(t, err, `unknown style "sparkly"`)
+
+	_, err = c.Style("hi")
+	require.Error(t, err)
+
+	assert.True(t, c.Enabled())
+
+	c = &ColorFuncs{}
+	assert.False(t, c.Enabled())
+	assert.Equal(t, "hi", c.Red("hi"))
+	assert.Equal(t, "hi", c.Underline("hi"))
+
+	out, err = c.Style("bold red", "hi")
+	require.NoError(t, err)
+	assert.Equal(t, "hi", out)
+
+	_, err = c.Style("sparkly", "hi")
+	require.Error(t, err)
+}
@@ -0,0 +1,85 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hairyhenderson/gomplate/v4/compress"
+	"github.com/hairyhenderson/gomplate/v4/conv"
+)
+
+// CreateCompressFuncs -
+func CreateCompressFuncs(ctx context.Context) map[string]interface{} {
+	f := map[string]interface{}{}
+
+	ns := &CompressFuncs{ctx}
+	f["compress"] = func() interface{} { return ns }
+
+	return f
+}
+
+// CompressFuncs -
+type CompressFuncs struct {
+	ctx context.Context
+}
+
+// Gzip -
+func (CompressFuncs) Gzip(args ...interface{}) (string, error) {
+	return compressWith(compress.Gzip, args)
+}
+
+// Gunzip -
+func (CompressFuncs) Gunzip(in interface{}) (string, error) {
+	out, err := compress.Gunzip(toBytes(in))
+	return string(out), err
+}
+
+// Zstd -
+func (CompressFuncs) Zstd(args ...interface{}) (string, error) {
+	return compressWith(compress.Zstd, args)
+}
+
+// Unzstd -
+func (CompressFuncs) Unzstd(in interface{}) (string, error) {
+	out, err := compress.Unzstd(toBytes(in))
+	return string(out), err
+}
+
+// Brotli -
+func (CompressFuncs) Brotli(args ...interface{}) (string, error) {
+	return compressWith(compress.Brotli, args)
+}
+
+// Unbrotli -
+func (CompressFuncs) Unbrotli(in interface{}) (string, error) {
+	out, err := compress.Unbrotli(toBytes(in))
+	return string(out), err
+}
+
+// compressWith compresses the input (the last argument) with the given
+// function, at the level given by the optional first argument
+func compressWith(f func(int, []byte) ([]byte, error), args []interface{}) (string, error) {
+	level := compress.DefaultLevel
+
+	var in interface{}
+
+	switch len(args) {
+	case 1:
+		in = args[0]
+	case 2:
+		var err error
+
+		level, err = conv.ToInt(args[0])
+		if err != nil {
+			return "", fmt.Errorf("expected level to be a number: %w", err)
+		}
+
+		in = args[1]
+	default:
+		return "", fmt.Errorf("wrong number of args: want 1 or 2, got %d", len(args))
+	}
+
+	out, err := f(level, toBytes(in))
+
+	return string(out), err
+}
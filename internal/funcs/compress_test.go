@@ -0,0 +1,65 @@
+package funcs
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCompressFuncs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateCompressFuncs(ctx)
+			actual := fmap["compress"].(func() interface{})
+
+			assert.Equal(t, ctx, actual().(*CompressFuncs).ctx)
+		})
+	}
+}
+
+func TestCompress(t *testing.T) {
+	t.Parallel()
+
+	cf := &CompressFuncs{}
+
+	testdata := []struct {
+		compress   func(...interface{}) (string, error)
+		decompress func(interface{}) (string, error)
+	}{
+		{cf.Gzip, cf.Gunzip},
+		{cf.Zstd, cf.Unzstd},
+		{cf.Brotli, cf.Unbrotli},
+	}
+
+	for _, d := range testdata {
+		c, err := d.compress("hello world")
+		require.NoError(t, err)
+
+		out, err := d.decompress(c)
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", out)
+
+		// compressed data can also be given as bytes
+		c, err = d.compress("9", []byte("hello world"))
+		require.NoError(t, err)
+
+		out, err = d.decompress([]byte(c))
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", out)
+
+		_, err = d.compress("fast", "hello world")
+		require.Error(t, err)
+
+		_, err = d.compress()
+		require.Error(t, err)
+	}
+}
@@ -2,9 +2,11 @@ package funcs
 
 import (
 	"context"
+	"fmt"
 	"net/url"
 	"strconv"
 	"text/template"
+	"time"
 
 	"github.com/hairyhenderson/gomplate/v4/conv"
 	"github.com/hairyhenderson/gomplate/v4/internal/deprecated"
@@ -42,6 +44,11 @@ func (ConvFuncs) ToBool(in interface{}) bool {
 	return conv.ToBool(in)
 }
 
+// MustToBool -
+func (ConvFuncs) MustToBool(in interface{}) (bool, error) {
+	return conv.StrictToBool(in)
+}
+
 // ToBools -
 func (ConvFuncs) ToBools(in ...interface{}) []bool {
 	return conv.ToBools(in...)
@@ -87,6 +94,16 @@ func (ConvFuncs) ToInt(in interface{}) (int, error) {
 	return conv.ToInt(in)
 }
 
+// MustToInt64 -
+func (ConvFuncs) MustToInt64(in interface{}) (int64, error) {
+	return conv.StrictToInt64(in)
+}
+
+// MustToInt -
+func (ConvFuncs) MustToInt(in interface{}) (int, error) {
+	return conv.StrictToInt(in)
+}
+
 // ToInt64s -
 func (ConvFuncs) ToInt64s(in ...interface{}) ([]int64, error) {
 	return conv.ToInt64s(in...)
@@ -125,3 +142,52 @@ func (ConvFuncs) Default(def, in interface{}) interface{} {
 
 	return def
 }
+
+// ParseBytes -
+func (ConvFuncs) ParseBytes(in interface{}) (int64, error) {
+	return conv.ParseBytes(conv.ToString(in))
+}
+
+// HumanBytes -
+func (ConvFuncs) HumanBytes(args ...interface{}) (string, error) {
+	units, in, err := stringArgAndInput(args)
+	if err != nil {
+		return "", err
+	}
+
+	si := false
+	switch units {
+	case "", "iec":
+	case "si":
+		si = true
+	default:
+		return "", fmt.Errorf("unknown units %q: must be one of iec or si", units)
+	}
+
+	n, err := conv.ToFloat64(in)
+	if err != nil {
+		return "", fmt.Errorf("expected a number: %w", err)
+	}
+
+	return conv.HumanBytes(n, si), nil
+}
+
+// ParseSI -
+func (ConvFuncs) ParseSI(in interface{}) (float64, error) {
+	return conv.ParseSI(conv.ToString(in))
+}
+
+// HumanSI -
+func (ConvFuncs) HumanSI(in interface{}) (string, error) {
+	n, err := conv.ToFloat64(in)
+	if err != nil {
+		return "", fmt.Errorf("expected a number: %w", err)
+	}
+
+	return conv.HumanSI(n), nil
+}
+
+// ParseDuration -
+func (ConvFuncs) ParseDuration(in interface{}) (time.Duration, error) {
+	return conv.ParseDuration(conv.ToString(in))
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateConvFuncs(t *testing.T) {
@@ -60,3 +62,83 @@ func TestDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestMustConv(t *testing.T) {
+	t.Parallel()
+
+	c := &ConvFuncs{}
+
+	b, err := c.MustToBool("yes")
+	require.NoError(t, err)
+	assert.True(t, b)
+
+	b, err = c.MustToBool("false")
+	require.NoError(t, err)
+	assert.False(t, b)
+
+	_, err = c.MustToBool("foo")
+	require.Error(t, err)
+
+	i, err := c.MustToInt("42")
+	require.NoError(t, err)
+	assert.Equal(t, 42, i)
+
+	_, err = c.MustToInt("1.5")
+	require.Error(t, err)
+
+	i64, err := c.MustToInt64(3.0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), i64)
+
+	_, err = c.MustToInt64(true)
+	require.Error(t, err)
+}
+
+func TestUnits(t *testing.T) {
+	t.Parallel()
+
+	c := &ConvFuncs{}
+
+	b, err := c.ParseBytes("1.5GiB")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1610612736), b)
+
+	_, err = c.ParseBytes("1.5 parsecs")
+	require.Error(t, err)
+
+	s, err := c.HumanBytes(1610612736)
+	require.NoError(t, err)
+	assert.Equal(t, "1.5GiB", s)
+
+	s, err = c.HumanBytes("si", "1500000")
+	require.NoError(t, err)
+	assert.Equal(t, "1.5MB", s)
+
+	_, err = c.HumanBytes("foo", 1)
+	require.Error(t, err)
+
+	_, err = c.HumanBytes("foo")
+	require.Error(t, err)
+
+	_, err = c.HumanBytes()
+	require.Error(t, err)
+
+	f, err := c.ParseSI("500m")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, f, 1e-12)
+
+	s, err = c.HumanSI(1500)
+	require.NoError(t, err)
+	assert.Equal(t, "1.5k", s)
+
+	_, err = c.HumanSI("foo")
+	require.Error(t, err)
+
+	d, err := c.ParseDuration("1d12h")
+	require.NoError(t, err)
+	assert.Equal(t, 36*time.Hour, d)
+
+	d, err = c.ParseDuration(30)
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, d)
+}
@@ -10,13 +10,16 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"math"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/hairyhenderson/gomplate/v4/conv"
 	"github.com/hairyhenderson/gomplate/v4/crypto"
+	"github.com/hairyhenderson/gomplate/v4/env"
 )
 
 // CreateCryptoFuncs -
@@ -197,6 +200,172 @@ func (CryptoFuncs) Bcrypt(args ...interface{}) (string, error) {
 	return string(hash), err
 }
 
+// BcryptVerify -
+func (CryptoFuncs) BcryptVerify(hash, input interface{}) (bool, error) {
+	return crypto.BcryptVerify(toBytes(input), conv.ToString(hash))
+}
+
+// Argon2id -
+func (CryptoFuncs) Argon2id(args ...interface{}) (string, error) {
+	m, input, err := optionsAndInput(args)
+	if err != nil {
+		return "", err
+	}
+
+	opts := crypto.DefaultArgon2idOptions
+
+	for k, v := range m {
+		n, err := conv.ToInt64(v)
+		if err != nil || n < 1 || n > math.MaxUint32 {
+			return "", fmt.Errorf("argon2id option %s must be a positive integer, not %v", k, v)
+		}
+
+		switch k {
+		case "memory":
+			opts.Memory = uint32(n)
+		case "iterations":
+			opts.Iterations = uint32(n)
+		case "parallelism":
+			if n > math.MaxUint8 {
+				return "", fmt.Errorf("argon2id parallelism must be at most %d", math.MaxUint8)
+			}
+
+			opts.Parallelism = uint8(n)
+		case "keyLength":
+			opts.KeyLength = uint32(n)
+		default:
+			return "", fmt.Errorf("unknown argon2id option %q", k)
+		}
+	}
+
+	return crypto.Argon2id(toBytes(input), opts)
+}
+
+// Argon2idVerify -
+func (CryptoFuncs) Argon2idVerify(hash, input interface{}) (bool, error) {
+	return crypto.Argon2idVerify(toBytes(input), conv.ToString(hash))
+}
+
+// Scrypt -
+func (CryptoFuncs) Scrypt(args ...interface{}) (string, error) {
+	m, input, err := optionsAndInput(args)
+	if err != nil {
+		return "", err
+	}
+
+	opts := crypto.DefaultScryptOptions
+
+	for k, v := range m {
+		n, err := conv.ToInt(v)
+		if err != nil || n < 1 {
+			return "", fmt.Errorf("scrypt option %s must be a positive integer, not %v", k, v)
+		}
+
+		switch k {
+		case "cost":
+			opts.Cost = n
+		case "blockSize":
+			opts.BlockSize = n
+		case "parallelism":
+			opts.Parallelism = n
+		case "keyLength":
+			opts.KeyLength = n
+		default:
+			return "", fmt.Errorf("unknown scrypt option %q", k)
+		}
+	}
+
+	return crypto.Scrypt(toBytes(input), opts)
+}
+
+// ScryptVerify -
+func (CryptoFuncs) ScryptVerify(hash, input interface{}) (bool, error) {
+	return crypto.ScryptVerify(toBytes(input), conv.ToString(hash))
+}
+
+// optionsAndInput returns the optional options map, and the input, from one
+// or two args
+func optionsAndInput(args []interface{}) (map[string]interface{}, interface{}, error) {
+	switch len(args) {
+	case 1:
+		return nil, args[0], nil
+	case 2:
+		m, ok := args[0].(map[string]interface{})
+		if !ok {
+			return nil, nil, fmt.Errorf("options must be a map, not %T", args[0])
+		}
+
+		return m, args[1], nil
+	default:
+		return nil, nil, fmt.Errorf("wrong number of args: want 1 or 2, got %d", len(args))
+	}
+}
+
+// TOTP -
+func (CryptoFuncs) TOTP(args ...interface{}) (string, error) {
+	m, secret, err := optionsAndInput(args)
+	if err != nil {
+		return "", err
+	}
+
+	opts := crypto.DefaultTOTPOptions()
+
+	for k, v := range m {
+		switch k {
+		case "time":
+			opts.Time, err = timeArg(v)
+		case "period":
+			opts.Period, err = totpPeriod(v)
+		case "digits":
+			opts.Digits, err = conv.ToInt(v)
+		case "algorithm":
+			opts.Hash, err = crypto.StrToHash(strings.ToUpper(conv.ToString(v)))
+		default:
+			return "", fmt.Errorf("unknown TOTP option %q", k)
+		}
+
+		if err != nil {
+			return "", fmt.Errorf("invalid TOTP %s %q: %w", k, v, err)
+		}
+	}
+
+	return crypto.TOTP(conv.ToString(secret), opts)
+}
+
+// timeArg converts a time, or a number of seconds since the Unix epoch, or an
+// RFC 3339 timestamp, to a time
+func timeArg(v interface{}) (time.Time, error) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t, nil
+		}
+	}
+
+	n, err := conv.ToInt64(v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("must be a time, a Unix timestamp, or an RFC 3339 timestamp")
+	}
+
+	return time.Unix(n, 0), nil
+}
+
+// totpPeriod converts a duration, or a number of seconds, to a duration
+func totpPeriod(v interface{}) (time.Duration, error) {
+	if d, ok := v.(time.Duration); ok {
+		return d, nil
+	}
+
+	n, err := conv.ToInt64(v)
+	if err == nil {
+		return time.Duration(n) * time.Second, nil
+	}
+
+	return time.ParseDuration(conv.ToString(v))
+}
+
 // RSAEncrypt -
 // Experimental!
 func (f *CryptoFuncs) RSAEncrypt(key string, in interface{}) ([]byte, error) {
@@ -406,3 +575,393 @@ func parseAESArgs(key string, args ...interface{}) ([]byte, []byte, error) {
 
 	return k, msg, nil
 }
+
+// AgeEncrypt -
+// Experimental!
+func (f *CryptoFuncs) AgeEncrypt(args ...interface{}) (string, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return "", err
+	}
+
+	recipients, in, err := parseAgeArgs("GOMPLATE_AGE_RECIPIENTS", args)
+	if err != nil {
+		return "", err
+	}
+
+	if recipients == "" {
+		return "", fmt.Errorf("no age recipients given, and GOMPLATE_AGE_RECIPIENTS is not set")
+	}
+
+	out, err := crypto.AgeEncrypt(recipients, in)
+	return string(out), err
+}
+
+// AgeDecrypt -
+// Experimental!
+func (f *CryptoFuncs) AgeDecrypt(args ...interface{}) (string, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return "", err
+	}
+
+	identities, in, err := parseAgeArgs("GOMPLATE_AGE_IDENTITY", args)
+	if err != nil {
+		return "", err
+	}
+
+	if identities == "" {
+		return "", fmt.Errorf("no age identities given, and GOMPLATE_AGE_IDENTITY is not set")
+	}
+
+	out, err := crypto.AgeDecrypt(identities, in)
+	return string(out), err
+}
+
+// parseAgeArgs returns the recipients or identities (one per line), either
+// from the first of two args, or from the environment variable, and the input
+func parseAgeArgs(envKey string, args []interface{}) (string, []byte, error) {
+	switch len(args) {
+	case 1:
+		return env.Getenv(envKey), toBytes(args[0]), nil
+	case 2:
+		return strings.Join(toStringList(args[0]), "\n"), toBytes(args[1]), nil
+	default:
+		return "", nil, fmt.Errorf("wrong number of args: want 1 or 2, got %d", len(args))
+	}
+}
+
+// PGPEncrypt -
+// Experimental!
+func (f *CryptoFuncs) PGPEncrypt(publicKeys, in interface{}) (string, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return "", err
+	}
+
+	out, err := crypto.PGPEncrypt(toBytes(publicKeys), toBytes(in))
+	return string(out), err
+}
+
+// PGPDecrypt -
+// Experimental!
+func (f *CryptoFuncs) PGPDecrypt(privateKeys interface{}, args ...interface{}) (string, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return "", err
+	}
+
+	var passphrase, in []byte
+
+	switch len(args) {
+	case 1:
+		in = toBytes(args[0])
+	case 2:
+		passphrase = toBytes(args[0])
+		in = toBytes(args[1])
+	default:
+		return "", fmt.Errorf("wrong number of args: want 2 or 3, got %d", len(args)+1)
+	}
+
+	out, err := crypto.PGPDecrypt(toBytes(privateKeys), passphrase, in)
+	return string(out), err
+}
+
+// PGPVerify -
+// Experimental!
+func (f *CryptoFuncs) PGPVerify(publicKeys interface{}, args ...interface{}) (bool, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return false, err
+	}
+
+	var signature, message []byte
+
+	switch len(args) {
+	case 1:
+		message = toBytes(args[0])
+	case 2:
+		signature = toBytes(args[0])
+		message = toBytes(args[1])
+	default:
+		return false, fmt.Errorf("wrong number of args: want 2 or 3, got %d", len(args)+1)
+	}
+
+	return crypto.PGPVerify(toBytes(publicKeys), signature, message)
+}
+
+// ParseCert -
+func (CryptoFuncs) ParseCert(cert interface{}) (map[string]interface{}, error) {
+	return crypto.ParseCert(toBytes(cert))
+}
+
+// GenerateSelfSigned -
+// Experimental!
+func (f *CryptoFuncs) GenerateSelfSigned(args ...interface{}) (map[string]interface{}, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return nil, err
+	}
+
+	m, err := optionsArg(args)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := parseCertOptions(m)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := opts.privateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := crypto.GenerateSelfSignedCert(key, opts.CertOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"cert": string(cert), "key": string(key)}, nil
+}
+
+// CSR -
+// Experimental!
+func (f *CryptoFuncs) CSR(args ...interface{}) (string, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return "", err
+	}
+
+	m, key, err := optionsAndInput(args)
+	if err != nil {
+		return "", err
+	}
+
+	opts, err := parseCertOptions(m)
+	if err != nil {
+		return "", err
+	}
+
+	out, err := crypto.GenerateCSR(toBytes(key), opts.CertOptions)
+	return string(out), err
+}
+
+// SSHKeyPair -
+// Experimental!
+func (f *CryptoFuncs) SSHKeyPair(args ...interface{}) (map[string]interface{}, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return nil, err
+	}
+
+	m, err := optionsArg(args)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := newKeyOptions("ed25519")
+
+	var comment string
+	var passphrase []byte
+
+	for k, v := range m {
+		switch k {
+		case "comment":
+			comment = conv.ToString(v)
+		case "passphrase":
+			passphrase = toBytes(v)
+		default:
+			ok, err := opts.set(k, v)
+			if err != nil {
+				return nil, err
+			}
+
+			if !ok {
+				return nil, fmt.Errorf("unknown SSH key option %q", k)
+			}
+		}
+	}
+
+	key, err := opts.privateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := crypto.SSHKeyPair(key, comment, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"private":       string(keys.PrivateKey),
+		"public":        string(keys.PublicKey),
+		"authorizedKey": string(keys.AuthorizedKey),
+		"fingerprint":   keys.Fingerprint,
+	}, nil
+}
+
+// VerifyChain -
+func (CryptoFuncs) VerifyChain(args ...interface{}) (map[string]interface{}, error) {
+	m, cert, err := optionsAndInput(args)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := crypto.VerifyOptions{}
+
+	for k, v := range m {
+		switch k {
+		case "roots":
+			opts.Roots = toBytes(v)
+		case "intermediates":
+			opts.Intermediates = toBytes(v)
+		case "hostname":
+			opts.Hostname = conv.ToString(v)
+		case "time":
+			t, err := timeArg(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid time %q: %w", v, err)
+			}
+
+			opts.Time = t
+		default:
+			return nil, fmt.Errorf("unknown verify option %q", k)
+		}
+	}
+
+	return crypto.VerifyChain(toBytes(cert), opts)
+}
+
+// certOptions are the options for GenerateSelfSigned and CSR
+type certOptions struct {
+	crypto.CertOptions
+	keyOptions
+}
+
+func parseCertOptions(m map[string]interface{}) (certOptions, error) {
+	validity := 365 * 24 * time.Hour
+	opts := certOptions{
+		CertOptions: crypto.CertOptions{NotBefore: time.Now()},
+		keyOptions:  newKeyOptions("ecdsa"),
+	}
+
+	var err error
+
+	for k, v := range m {
+		switch k {
+		case "commonName":
+			opts.CommonName = conv.ToString(v)
+		case "organization":
+			opts.Organization = toStringList(v)
+		case "sans":
+			opts.SANs = toStringList(v)
+		case "validity":
+			validity, err = time.ParseDuration(conv.ToString(v))
+			if err != nil {
+				return opts, fmt.Errorf("invalid validity %q: %w", v, err)
+			}
+		case "isCA":
+			opts.IsCA = conv.ToBool(v)
+		default:
+			ok, err := opts.set(k, v)
+			if err != nil {
+				return opts, err
+			}
+
+			if !ok {
+				return opts, fmt.Errorf("unknown certificate option %q", k)
+			}
+		}
+	}
+
+	opts.NotAfter = opts.NotBefore.Add(validity)
+
+	return opts, nil
+}
+
+// keyOptions are the options for functions which generate private keys, or
+// accept existing ones
+type keyOptions struct {
+	// key is the PEM-encoded private key to use, if given
+	key []byte
+	// keyType is the type of key to generate - rsa, ecdsa, or ed25519
+	keyType string
+	// keyBits is the size of RSA keys to generate
+	keyBits int
+	// curve is the curve of ECDSA keys to generate
+	curve string
+}
+
+func newKeyOptions(keyType string) keyOptions {
+	return keyOptions{keyType: keyType, keyBits: 4096, curve: "P256"}
+}
+
+// set sets the named key option, returning false if it's not a key option
+func (o *keyOptions) set(k string, v interface{}) (bool, error) {
+	var err error
+
+	switch k {
+	case "key":
+		o.key = toBytes(v)
+	case "keyType":
+		o.keyType = strings.ToLower(conv.ToString(v))
+	case "keyBits":
+		o.keyBits, err = conv.ToInt(v)
+		if err != nil {
+			return true, fmt.Errorf("keyBits must be an integer: %w", err)
+		}
+	case "curve":
+		o.curve = strings.ReplaceAll(strings.ToUpper(conv.ToString(v)), "-", "")
+	default:
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// privateKey returns the given PEM-encoded private key, or generates one of
+// the configured type
+func (o keyOptions) privateKey() ([]byte, error) {
+	if o.key != nil {
+		return o.key, nil
+	}
+
+	switch o.keyType {
+	case "rsa":
+		return crypto.RSAGenerateKey(o.keyBits)
+	case "ecdsa":
+		curve, ok := crypto.Curves(o.curve)
+		if !ok {
+			return nil, fmt.Errorf("unknown curve: %s", o.curve)
+		}
+
+		return crypto.ECDSAGenerateKey(curve)
+	case "ed25519":
+		return crypto.Ed25519GenerateKey()
+	default:
+		return nil, fmt.Errorf("unknown key type %q: must be one of rsa, ecdsa, or ed25519", o.keyType)
+	}
+}
+
+// optionsArg returns the options map from optional args (at most one)
+func optionsArg(args []interface{}) (map[string]interface{}, error) {
+	switch len(args) {
+	case 0:
+		return nil, nil
+	case 1:
+		m, ok := args[0].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("options must be a map, not %T", args[0])
+		}
+
+		return m, nil
+	default:
+		return nil, fmt.Errorf("wrong number of args: want 0 or 1, got %d", len(args))
+	}
+}
+
+// toStringList converts a list (or a single value) to a list of strings
+func toStringList(v interface{}) []string {
+	switch v := v.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		return conv.ToStrings(v...)
+	default:
+		return []string{conv.ToString(v)}
+	}
+}
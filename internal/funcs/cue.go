@@ -0,0 +1,44 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/internal/parsers"
+)
+
+// CreateCUEFuncs -
+func CreateCUEFuncs(ctx context.Context) map[string]interface{} {
+	ns := &CUEFuncs{ctx}
+
+	return map[string]interface{}{
+		// cue is both the namespace and an alias for data.CUE - called with no
+		// arguments (as in "cue.Validate") it returns the namespace
+		"cue": func(args ...interface{}) (interface{}, error) {
+			switch len(args) {
+			case 0:
+				return ns, nil
+			case 1:
+				return parsers.CUE(conv.ToString(args[0]))
+			default:
+				return nil, fmt.Errorf("wrong number of args: want 0 or 1, got %d", len(args))
+			}
+		},
+	}
+}
+
+// CUEFuncs -
+type CUEFuncs struct {
+	ctx context.Context
+}
+
+// Validate -
+func (CUEFuncs) Validate(schema interface{}, in interface{}) (interface{}, error) {
+	err := parsers.CUEValidate(conv.ToString(schema), in)
+	if err != nil {
+		return nil, err
+	}
+
+	return in, nil
+}
@@ -2,40 +2,61 @@ package funcs
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
 	"github.com/hairyhenderson/gomplate/v4/internal/parsers"
 )
 
 // CreateDataFuncs -
 func CreateDataFuncs(ctx context.Context) map[string]interface{} {
+	return CreateDataFuncsWithReader(ctx, nil)
+}
+
+// CreateDataFuncsWithReader - like CreateDataFuncs, but functions that refer
+// to datasources (like data.ValidateJSONSchema) can read them with sr
+func CreateDataFuncsWithReader(ctx context.Context, sr datafs.DataSourceReader) map[string]interface{} {
 	f := map[string]interface{}{}
 
-	ns := &DataFuncs{ctx}
+	ns := &DataFuncs{ctx: ctx, sr: sr}
 
 	f["data"] = func() interface{} { return ns }
 
 	f["json"] = ns.JSON
 	f["jsonArray"] = ns.JSONArray
+	f["json5"] = ns.JSON5
 	f["yaml"] = ns.YAML
 	f["yamlArray"] = ns.YAMLArray
 	f["toml"] = ns.TOML
 	f["csv"] = ns.CSV
 	f["csvByRow"] = ns.CSVByRow
 	f["csvByColumn"] = ns.CSVByColumn
-	f["cue"] = ns.CUE
+	// the cue alias is in CreateCUEFuncs, since it's also the cue namespace
+	f["hcl"] = ns.HCL
+	f["ini"] = ns.INI
+	f["jsonnet"] = ns.Jsonnet
+	f["msgpack"] = ns.MsgPack
+	f["ndjson"] = ns.NDJSON
 	f["toJSON"] = ns.ToJSON
 	f["toJSONPretty"] = ns.ToJSONPretty
+	f["toMsgPack"] = ns.ToMsgPack
 	f["toYAML"] = ns.ToYAML
 	f["toTOML"] = ns.ToTOML
 	f["toCSV"] = ns.ToCSV
 	f["toCUE"] = ns.ToCUE
+	f["toDotEnv"] = ns.ToDotEnv
+	f["toHCL"] = ns.ToHCL
+	f["toINI"] = ns.ToINI
+	f["toXML"] = ns.ToXML
 	return f
 }
 
 // DataFuncs -
 type DataFuncs struct {
 	ctx context.Context
+	sr  datafs.DataSourceReader
 }
 
 // JSON -
@@ -48,6 +69,11 @@ func (f *DataFuncs) JSONArray(in interface{}) ([]interface{}, error) {
 	return parsers.JSONArray(conv.ToString(in))
 }
 
+// JSON5 -
+func (f *DataFuncs) JSON5(in interface{}) (interface{}, error) {
+	return parsers.JSON5(conv.ToString(in))
+}
+
 // YAML -
 func (f *DataFuncs) YAML(in interface{}) (map[string]interface{}, error) {
 	return parsers.YAML(conv.ToString(in))
@@ -63,6 +89,74 @@ func (f *DataFuncs) TOML(in interface{}) (interface{}, error) {
 	return parsers.TOML(conv.ToString(in))
 }
 
+// MustJSON - like JSON, but fails on empty or null input instead of
+// returning an empty map
+func (f *DataFuncs) MustJSON(in interface{}) (map[string]interface{}, error) {
+	s, err := requireDocument("JSON", in)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsers.JSON(s)
+}
+
+// MustJSONArray - like JSONArray, but fails on empty or null input
+func (f *DataFuncs) MustJSONArray(in interface{}) ([]interface{}, error) {
+	s, err := requireDocument("JSON", in)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsers.JSONArray(s)
+}
+
+// MustYAML - like YAML, but fails on empty or null input instead of
+// returning an empty map
+func (f *DataFuncs) MustYAML(in interface{}) (map[string]interface{}, error) {
+	s, err := requireDocument("YAML", in)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsers.YAML(s)
+}
+
+// MustYAMLArray - like YAMLArray, but fails on empty or null input
+func (f *DataFuncs) MustYAMLArray(in interface{}) ([]interface{}, error) {
+	s, err := requireDocument("YAML", in)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsers.YAMLArray(s)
+}
+
+// MustTOML - like TOML, but fails when the input has no keys instead of
+// returning an empty map
+func (f *DataFuncs) MustTOML(in interface{}) (interface{}, error) {
+	out, err := parsers.TOML(conv.ToString(in))
+	if err != nil {
+		return nil, err
+	}
+
+	if m, ok := out.(map[string]interface{}); ok && len(m) == 0 {
+		return nil, fmt.Errorf("TOML input is empty")
+	}
+
+	return out, nil
+}
+
+// requireDocument returns the input as a string, or an error when it's
+// empty or null
+func requireDocument(format string, in interface{}) (string, error) {
+	s := conv.ToString(in)
+	if in == nil || parsers.EmptyYAML(s) {
+		return "", fmt.Errorf("%s input is empty or null", format)
+	}
+
+	return s, nil
+}
+
 // CSV -
 func (f *DataFuncs) CSV(args ...string) ([][]string, error) {
 	return parsers.CSV(args...)
@@ -83,6 +177,165 @@ func (f *DataFuncs) CUE(in interface{}) (interface{}, error) {
 	return parsers.CUE(conv.ToString(in))
 }
 
+// HCL -
+func (f *DataFuncs) HCL(in interface{}) (map[string]interface{}, error) {
+	return parsers.HCL(conv.ToString(in))
+}
+
+// INI -
+func (f *DataFuncs) INI(in interface{}) (map[string]interface{}, error) {
+	return parsers.INI(conv.ToString(in))
+}
+
+// Jsonnet - evaluates a Jsonnet document, optionally with a map of external
+// variables as the first argument
+func (f *DataFuncs) Jsonnet(args ...interface{}) (interface{}, error) {
+	var in interface{}
+	extVars := map[string]string{}
+
+	switch len(args) {
+	case 1:
+		in = args[0]
+	case 2:
+		m, ok := args[0].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected a map of external variables, got %T", args[0])
+		}
+
+		for k, v := range m {
+			extVars[k] = conv.ToString(v)
+		}
+
+		in = args[1]
+	default:
+		return nil, fmt.Errorf("wrong number of args: want 1 or 2, got %d", len(args))
+	}
+
+	return parsers.Jsonnet(conv.ToString(in), extVars)
+}
+
+// ValidateJSONSchema - validates the input against a JSON Schema, read from
+// the named datasource (or given as a map). By default the render fails when
+// the input is invalid, and the input is returned otherwise. With the "fail"
+// option set to false, a list of errors (each with a path and a message) is
+// returned instead.
+func (f *DataFuncs) ValidateJSONSchema(args ...interface{}) (interface{}, error) {
+	fail := true
+
+	var schemaArg, in interface{}
+	switch len(args) {
+	case 2:
+		schemaArg, in = args[0], args[1]
+	case 3:
+		opts, ok := args[0].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("options must be a map, not %T", args[0])
+		}
+
+		for k, v := range opts {
+			switch k {
+			case "fail":
+				fail = conv.ToBool(v)
+			default:
+				return nil, fmt.Errorf("unknown option %q: must be fail", k)
+			}
+		}
+
+		schemaArg, in = args[1], args[2]
+	default:
+		return nil, fmt.Errorf("wrong number of args: want 2 or 3, got %d", len(args))
+	}
+
+	schema, err := f.readSchema(schemaArg)
+	if err != nil {
+		return nil, err
+	}
+
+	errs, err := parsers.JSONSchemaValidate(schema, in)
+	if err != nil {
+		return nil, err
+	}
+
+	if !fail {
+		out := make([]interface{}, len(errs))
+		for i, e := range errs {
+			out[i] = map[string]interface{}{"path": e.Path, "message": e.Message}
+		}
+
+		return out, nil
+	}
+
+	if len(errs) > 0 {
+		msgs := make([]string, len(errs))
+		for i, e := range errs {
+			msgs[i] = e.Error()
+		}
+
+		return nil, fmt.Errorf("JSON Schema validation failed:\n  %s", strings.Join(msgs, "\n  "))
+	}
+
+	return in, nil
+}
+
+// readSchema returns the schema, either given directly as a map, or read from
+// the named datasource
+func (f *DataFuncs) readSchema(schema interface{}) (interface{}, error) {
+	if m, ok := schema.(map[string]interface{}); ok {
+		return m, nil
+	}
+
+	alias := conv.ToString(schema)
+	if f.sr == nil {
+		return nil, fmt.Errorf("can't read schema from datasource %q: datasources are not available", alias)
+	}
+
+	ct, b, err := f.sr.ReadSource(f.ctx, alias)
+	if err != nil {
+		return nil, fmt.Errorf("can't read schema: %w", err)
+	}
+
+	return parsers.ParseData(ct, string(b))
+}
+
+// MsgPack -
+func (f *DataFuncs) MsgPack(in interface{}) (interface{}, error) {
+	return parsers.MsgPack(conv.ToString(in))
+}
+
+// NDJSON -
+func (f *DataFuncs) NDJSON(in interface{}) ([]interface{}, error) {
+	return parsers.NDJSON(conv.ToString(in))
+}
+
+// XML - parses an XML document, optionally with a map of options as the first
+// argument
+func (f *DataFuncs) XML(args ...interface{}) (map[string]interface{}, error) {
+	opts, in, err := xmlArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsers.XML(conv.ToString(in), opts)
+}
+
+func xmlArgs(args []interface{}) (opts parsers.XMLOptions, in interface{}, err error) {
+	switch len(args) {
+	case 1:
+		return opts, args[0], nil
+	case 2:
+		m, ok := args[0].(map[string]interface{})
+		if !ok {
+			return opts, nil, fmt.Errorf("expected a map of options, got %T", args[0])
+		}
+
+		opts, err = parsers.XMLOptionsFromMap(m)
+
+		return opts, args[1], err
+	default:
+		return opts, nil, fmt.Errorf("wrong number of args: want 1 or 2, got %d", len(args))
+	}
+}
+
 // ToCSV -
 func (f *DataFuncs) ToCSV(args ...interface{}) (string, error) {
 	return parsers.ToCSV(args...)
@@ -93,19 +346,79 @@ func (f *DataFuncs) ToCUE(in interface{}) (string, error) {
 	return parsers.ToCUE(in)
 }
 
+// ToDotEnv -
+func (f *DataFuncs) ToDotEnv(in interface{}) (string, error) {
+	return parsers.ToDotEnv(in)
+}
+
+// ToHCL -
+func (f *DataFuncs) ToHCL(in interface{}) (string, error) {
+	return parsers.ToHCL(in)
+}
+
+// ToINI -
+func (f *DataFuncs) ToINI(in interface{}) (string, error) {
+	return parsers.ToINI(in)
+}
+
 // ToJSON -
 func (f *DataFuncs) ToJSON(in interface{}) (string, error) {
 	return parsers.ToJSON(in)
 }
 
-// ToJSONPretty -
-func (f *DataFuncs) ToJSONPretty(indent string, in interface{}) (string, error) {
-	return parsers.ToJSONPretty(indent, in)
+// ToJSONPretty - marshals an object as indented JSON. The first argument is
+// either the indent string, or a map of formatting options.
+func (f *DataFuncs) ToJSONPretty(indent interface{}, in interface{}) (string, error) {
+	m, ok := indent.(map[string]interface{})
+	if !ok {
+		return parsers.ToJSONPretty(conv.ToString(indent), in)
+	}
+
+	opts, err := parsers.JSONOptionsFromMap(m)
+	if err != nil {
+		return "", err
+	}
+
+	return parsers.ToJSONPrettyWithOptions(in, opts)
 }
 
-// ToYAML -
-func (f *DataFuncs) ToYAML(in interface{}) (string, error) {
-	return parsers.ToYAML(in)
+// ToMsgPack -
+func (f *DataFuncs) ToMsgPack(in interface{}) (string, error) {
+	return parsers.ToMsgPack(in)
+}
+
+// ToXML - marshals an object as an XML document, optionally with a map of
+// options as the first argument
+func (f *DataFuncs) ToXML(args ...interface{}) (string, error) {
+	opts, in, err := xmlArgs(args)
+	if err != nil {
+		return "", err
+	}
+
+	return parsers.ToXML(in, opts)
+}
+
+// ToYAML - marshals an object as YAML, optionally with a map of formatting
+// options as the first argument
+func (f *DataFuncs) ToYAML(args ...interface{}) (string, error) {
+	switch len(args) {
+	case 1:
+		return parsers.ToYAML(args[0])
+	case 2:
+		m, ok := args[0].(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("expected a map of options, got %T", args[0])
+		}
+
+		opts, err := parsers.YAMLOptionsFromMap(m)
+		if err != nil {
+			return "", err
+		}
+
+		return parsers.ToYAMLWithOptions(args[1], opts)
+	default:
+		return "", fmt.Errorf("wrong number of args: want 1 or 2, got %d", len(args))
+	}
 }
 
 // ToTOML -
@@ -2,10 +2,15 @@ package funcs
 
 import (
 	"context"
+	"net/url"
 	"strconv"
 	"testing"
+	"testing/fstest"
 
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateDataFuncs(t *testing.T) {
@@ -24,3 +29,167 @@ func TestCreateDataFuncs(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	fsys := datafs.WrapWdFS(fstest.MapFS{
+		"schema.json": &fstest.MapFile{Data: []byte(`{
+			"type": "object",
+			"required": ["name"],
+			"properties": {"name": {"type": "string"}, "port": {"type": "integer"}}
+		}`)},
+		"schema.yaml": &fstest.MapFile{Data: []byte("type: string\n")},
+	})
+	ctx := datafs.ContextWithFSProvider(context.Background(), datafs.WrappedFSProvider(fsys, "file", ""))
+
+	reg := datafs.NewRegistry()
+	reg.Register("schema", config.DataSource{URL: &url.URL{Scheme: "file", Path: "/schema.json"}})
+	reg.Register("yamlschema", config.DataSource{URL: &url.URL{Scheme: "file", Path: "/schema.yaml"}})
+
+	f := &DataFuncs{ctx: ctx, sr: datafs.NewSourceReader(reg)}
+
+	valid := map[string]interface{}{"name": "foo", "port": 80}
+	out, err := f.ValidateJSONSchema("schema", valid)
+	require.NoError(t, err)
+	assert.Equal(t, valid, out)
+
+	_, err = f.ValidateJSONSchema("schema", map[string]interface{}{"name": "foo", "port": "80"})
+	require.ErrorContains(t, err, "JSON Schema validation failed:")
+	require.ErrorContains(t, err, "/port: ")
+
+	out, err = f.ValidateJSONSchema(map[string]interface{}{"fail": false}, "schema", map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"path": "/name", "message": "field is required but not present"},
+	}, out)
+
+	out, err = f.ValidateJSONSchema(map[string]interface{}{"fail": false}, "schema", valid)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{}, out)
+
+	out, err = f.ValidateJSONSchema("yamlschema", "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", out)
+
+	// inline schema
+	_, err = f.ValidateJSONSchema(map[string]interface{}{"type": "string"}, 42)
+	require.ErrorContains(t, err, "JSON Schema validation failed:")
+
+	_, err = f.ValidateJSONSchema("bogus", "foo")
+	require.ErrorContains(t, err, "can't read schema")
+
+	_, err = f.ValidateJSONSchema(map[string]interface{}{"foo": true}, "schema", "foo")
+	require.ErrorContains(t, err, `unknown option "foo"`)
+
+	_, err = f.ValidateJSONSchema("foo")
+	require.ErrorContains(t, err, "wrong number of args")
+
+	f = &DataFuncs{ctx: ctx}
+	_, err = f.ValidateJSONSchema("schema", valid)
+	require.ErrorContains(t, err, "datasources are not available")
+}
+
+func TestMustParse(t *testing.T) {
+	t.Parallel()
+
+	f := &DataFuncs{}
+
+	m, err := f.MustJSON(`{"a": 1}`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": 1}, m)
+
+	m, err = f.MustJSON(`{}`)
+	require.NoError(t, err)
+	assert.Empty(t, m)
+
+	a, err := f.MustJSONArray(`[1, 2]`)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2}, a)
+
+	m, err = f.MustYAML("a: b")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": "b"}, m)
+
+	a, err = f.MustYAMLArray("- a")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"a"}, a)
+
+	tm, err := f.MustTOML(`a = "b"`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": "b"}, tm)
+
+	for _, in := range []interface{}{nil, "", "  ", "null"} {
+		_, err = f.MustJSON(in)
+		require.ErrorContains(t, err, "JSON input is empty or null")
+
+		_, err = f.MustJSONArray(in)
+		require.ErrorContains(t, err, "JSON input is empty or null")
+
+		_, err = f.MustYAML(in)
+		require.ErrorContains(t, err, "YAML input is empty or null")
+
+		_, err = f.MustYAMLArray(in)
+		require.ErrorContains(t, err, "YAML input is empty or null")
+	}
+
+	_, err = f.MustYAML("# just a comment\n---\n~\n")
+	require.Error(t, err)
+
+	_, err = f.MustJSON(`[1]`)
+	require.Error(t, err)
+
+	_, err = f.MustTOML("# nothing here\n")
+	require.ErrorContains(t, err, "TOML input is empty")
+}
+
+func TestToYAMLOptions(t *testing.T) {
+	t.Parallel()
+
+	f := &DataFuncs{}
+	in := map[string]interface{}{"a": map[string]interface{}{"b": "c"}}
+
+	out, err := f.ToYAML(in)
+	require.NoError(t, err)
+	assert.Equal(t, "a:\n  b: c\n", out)
+
+	out, err = f.ToYAML(map[string]interface{}{"indent": 4, "quote": "double"}, in)
+	require.NoError(t, err)
+	assert.Equal(t, "a:\n    b: \"c\"\n", out)
+
+	out, err = f.ToYAML(map[string]interface{}{"flowMaxKeys": 1}, in)
+	require.NoError(t, err)
+	assert.Equal(t, "a: {b: c}\n", out)
+
+	src := "# comment\na:\n  b: d # old\n"
+	out, err = f.ToYAML(map[string]interface{}{"source": src}, in)
+	require.NoError(t, err)
+	assert.Equal(t, "# comment\na:\n  b: c # old\n", out)
+
+	_, err = f.ToYAML("foo", in)
+	require.Error(t, err)
+
+	_, err = f.ToYAML(map[string]interface{}{"bogus": 1}, in)
+	require.Error(t, err)
+
+	_, err = f.ToYAML()
+	require.Error(t, err)
+}
+
+func TestToJSONPrettyOptions(t *testing.T) {
+	t.Parallel()
+
+	f := &DataFuncs{}
+	in := map[string]interface{}{"a": map[string]interface{}{"b": "c"}}
+
+	out, err := f.ToJSONPretty("  ", in)
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"a\": {\n    \"b\": \"c\"\n  }\n}", out)
+
+	out, err = f.ToJSONPretty(map[string]interface{}{"indent": 4, "flowMaxKeys": 1}, in)
+	require.NoError(t, err)
+	assert.Equal(t, "{\n    \"a\": {\"b\": \"c\"}\n}", out)
+
+	_, err = f.ToJSONPretty(map[string]interface{}{"bogus": 1}, in)
+	require.Error(t, err)
+}
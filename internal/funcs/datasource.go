@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sync"
 
 	"github.com/hairyhenderson/gomplate/v4/internal/config"
 	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
 	"github.com/hairyhenderson/gomplate/v4/internal/parsers"
 	"github.com/hairyhenderson/gomplate/v4/internal/urlhelpers"
 )
@@ -27,6 +29,7 @@ func CreateDataSourceFuncs(ctx context.Context, sr datafs.DataSourceReader) map[
 	f["ds"] = ns.Datasource
 	f["datasourceExists"] = ns.DatasourceExists
 	f["datasourceReachable"] = ns.DatasourceReachable
+	f["datasourceStream"] = ns.DatasourceStream
 	f["defineDatasource"] = ns.DefineDatasource
 	f["include"] = ns.Include
 	f["listDatasources"] = ns.ListDatasources
@@ -34,10 +37,89 @@ func CreateDataSourceFuncs(ctx context.Context, sr datafs.DataSourceReader) map[
 	return f
 }
 
+// CloseDataSourceStreams - stops any streams opened with datasourceStream by
+// the funcs in f (as created by [CreateDataSourceFuncs]), and returns the
+// first error encountered while reading them. This should be called when each
+// template finishes rendering.
+func CloseDataSourceStreams(f map[string]interface{}) error {
+	nsf, ok := f["_datasource"].(func() interface{})
+	if !ok {
+		return nil
+	}
+
+	if ns, ok := nsf().(*dataSourceFuncs); ok {
+		return ns.closeStreams()
+	}
+
+	return nil
+}
+
 // dataSourceFuncs - datasource reading functions
 type dataSourceFuncs struct {
 	ctx context.Context
 	sr  datafs.DataSourceReader
+
+	streamMu sync.Mutex
+	streams  *streamGroup
+}
+
+// streamGroup - the streams opened while rendering a template, which are all
+// cancelled together when it's done
+type streamGroup struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu  sync.Mutex
+	err error
+}
+
+// setErr records the first error from a stream in the group
+func (g *streamGroup) setErr(err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.err == nil {
+		g.err = err
+	}
+}
+
+// currentStreams - returns the current stream group, starting a new one if needed
+func (d *dataSourceFuncs) currentStreams() *streamGroup {
+	d.streamMu.Lock()
+	defer d.streamMu.Unlock()
+
+	if d.streams == nil {
+		ctx := d.ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		g := &streamGroup{}
+		g.ctx, g.cancel = context.WithCancel(ctx)
+		d.streams = g
+	}
+
+	return d.streams
+}
+
+// closeStreams - cancels all open streams, and returns the first error
+// encountered while reading them
+func (d *dataSourceFuncs) closeStreams() error {
+	d.streamMu.Lock()
+	g := d.streams
+	d.streams = nil
+	d.streamMu.Unlock()
+
+	if g == nil {
+		return nil
+	}
+
+	g.cancel()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.err
 }
 
 // Include - Reads from the named datasource, without parsing the data, which
@@ -61,6 +143,52 @@ func (d *dataSourceFuncs) Datasource(alias string, args ...string) (interface{},
 	return parsers.ParseData(ct, string(b))
 }
 
+// DatasourceStream - Reads from the named datasource as a stream, returning a
+// channel which delivers each parsed record in turn, so that large datasources
+// don't need to be read into memory all at once. Only NDJSON datasources can
+// be streamed.
+//
+// Because the channel can't deliver errors, an error while reading the stream
+// ends the stream, and is returned by [CloseDataSourceStreams] so that the
+// render fails. Streams which aren't read to the end are stopped then too.
+func (d *dataSourceFuncs) DatasourceStream(alias string, args ...string) (<-chan interface{}, error) {
+	g := d.currentStreams()
+
+	ct, r, err := d.sr.OpenSource(g.ctx, alias, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if iohelpers.MimeAlias(ct) != iohelpers.NDJSONMimetype {
+		r.Close()
+
+		return nil, fmt.Errorf("datasource '%s' can't be streamed: type %q is not supported (only %q)", alias, ct, iohelpers.NDJSONMimetype)
+	}
+
+	ch := make(chan interface{})
+
+	go func() {
+		defer close(ch)
+		defer r.Close()
+
+		err := parsers.NDJSONEach(r, func(v interface{}) error {
+			select {
+			case ch <- v:
+				return nil
+			case <-g.ctx.Done():
+				return g.ctx.Err()
+			}
+		})
+
+		// errors from stopping the stream aren't interesting
+		if err != nil && g.ctx.Err() == nil {
+			g.setErr(fmt.Errorf("datasourceStream %q: %w", alias, err))
+		}
+	}()
+
+	return ch, nil
+}
+
 // DefineDatasource -
 func (d *dataSourceFuncs) DefineDatasource(alias, value string) (string, error) {
 	if alias == "" {
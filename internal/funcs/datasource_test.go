@@ -149,6 +149,76 @@ func TestInclude(t *testing.T) {
 	assert.Equal(t, contents, actual)
 }
 
+func TestDatasourceStream(t *testing.T) {
+	fsys := datafs.WrapWdFS(fstest.MapFS{
+		"tmp/events.ndjson": &fstest.MapFile{Data: []byte("{\"n\": 1}\n{\"n\": 2}\n\n{\"n\": 3}\n")},
+		"tmp/bad.ndjson":    &fstest.MapFile{Data: []byte("{\"n\": 1}\n{\"n\": \n{\"n\": 3}\n")},
+		"tmp/events.json":   &fstest.MapFile{Data: []byte(`{"n": 1}`)},
+	})
+	ctx := datafs.ContextWithFSProvider(context.Background(), datafs.WrappedFSProvider(fsys, "file", ""))
+
+	reg := datafs.NewRegistry()
+	reg.Register("tmp", config.DataSource{URL: &url.URL{Scheme: "file", Path: "/tmp/"}})
+
+	data := &dataSourceFuncs{sr: datafs.NewSourceReader(reg), ctx: ctx}
+
+	ch, err := data.DatasourceStream("tmp", "events.ndjson")
+	require.NoError(t, err)
+
+	actual := []interface{}{}
+	for v := range ch {
+		actual = append(actual, v)
+	}
+
+	assert.EqualValues(t, []interface{}{
+		map[string]interface{}{"n": 1},
+		map[string]interface{}{"n": 2},
+		map[string]interface{}{"n": 3},
+	}, actual)
+
+	// the stream ends at the first error
+	ch, err = data.DatasourceStream("tmp", "bad.ndjson")
+	require.NoError(t, err)
+
+	actual = []interface{}{}
+	for v := range ch {
+		actual = append(actual, v)
+	}
+
+	assert.EqualValues(t, []interface{}{map[string]interface{}{"n": 1}}, actual)
+
+	// and the error is returned when the streams are closed
+	require.ErrorContains(t, data.closeStreams(), `datasourceStream "tmp"`)
+	require.NoError(t, data.closeStreams())
+
+	// streams which aren't read to the end are stopped when closed
+	ch, err = data.DatasourceStream("tmp", "events.ndjson")
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{"n": 1}, <-ch)
+	require.NoError(t, data.closeStreams())
+
+	for range ch {
+		// drain any record that was already in flight
+	}
+
+	// the type can be overridden
+	ch, err = data.DatasourceStream("tmp", "events.json?type=application/x-ndjson")
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{"n": 1}, <-ch)
+
+	_, err = data.DatasourceStream("tmp", "events.json")
+	require.ErrorContains(t, err, "can't be streamed")
+
+	_, err = data.DatasourceStream("tmp", "missing.ndjson")
+	require.Error(t, err)
+
+	_, err = data.DatasourceStream("tmp")
+	require.Error(t, err)
+
+	_, err = data.DatasourceStream("bogus")
+	require.Error(t, err)
+}
+
 func TestDefineDatasource(t *testing.T) {
 	reg := datafs.NewRegistry()
 	d := &dataSourceFuncs{sr: datafs.NewSourceReader(reg)}
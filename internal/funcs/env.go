@@ -31,3 +31,25 @@ func (EnvFuncs) Getenv(key interface{}, def ...string) string {
 func (EnvFuncs) ExpandEnv(s interface{}) string {
 	return env.ExpandEnv(conv.ToString(s))
 }
+
+// Require -
+func (EnvFuncs) Require(key interface{}, message ...string) (string, error) {
+	return env.Require(conv.ToString(key), message...)
+}
+
+// ExpandSafe -
+func (EnvFuncs) ExpandSafe(s interface{}) (string, error) {
+	return env.ExpandSafe(conv.ToString(s))
+}
+
+// WithPrefix -
+func (EnvFuncs) WithPrefix(prefix interface{}) map[string]interface{} {
+	vars := env.WithPrefix(conv.ToString(prefix))
+
+	out := make(map[string]interface{}, len(vars))
+	for k, v := range vars {
+		out[k] = v
+	}
+
+	return out
+}
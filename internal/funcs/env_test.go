@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateEnvFuncs(t *testing.T) {
@@ -35,3 +36,43 @@ func TestEnvGetenv(t *testing.T) {
 
 	assert.Equal(t, "foo", ef.Getenv("bogusenvvar", "foo"))
 }
+
+func TestEnvRequire(t *testing.T) {
+	t.Parallel()
+
+	ef := &EnvFuncs{}
+	if expected := os.Getenv("PATH"); expected != "" {
+		out, err := ef.Require("PATH")
+		require.NoError(t, err)
+		assert.Equal(t, expected, out)
+	}
+
+	_, err := ef.Require("bogusenvvar")
+	require.ErrorContains(t, err, "bogusenvvar is not set")
+
+	_, err = ef.Require("bogusenvvar", "bogusenvvar is needed")
+	require.EqualError(t, err, "bogusenvvar is needed")
+}
+
+func TestEnvExpandSafe(t *testing.T) {
+	t.Parallel()
+
+	ef := &EnvFuncs{}
+	out, err := ef.ExpandSafe("${bogusenvvar:-fallback} $bogusenvvar $$")
+	require.NoError(t, err)
+	assert.Equal(t, "fallback $bogusenvvar $", out)
+
+	_, err = ef.ExpandSafe("${bogusenvvar:?}")
+	require.Error(t, err)
+}
+
+func TestEnvWithPrefix(t *testing.T) {
+	t.Parallel()
+
+	ef := &EnvFuncs{}
+	assert.Empty(t, ef.WithPrefix("bogusenvvar"))
+
+	if expected := os.Getenv("PATH"); expected != "" {
+		assert.Equal(t, expected, ef.WithPrefix("PAT")["PATH"])
+	}
+}
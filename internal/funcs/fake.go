@@ -0,0 +1,191 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/fake"
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+)
+
+// CreateFakeFuncs -
+func CreateFakeFuncs(ctx context.Context) map[string]interface{} {
+	// with a seed (from --random-seed), the same data is generated on every run
+	seed, _ := config.RandomSeed(ctx)
+	ns := &FakeFuncs{ctx: ctx, f: fake.New(seed)}
+
+	return map[string]interface{}{
+		"fake": func() interface{} { return ns },
+	}
+}
+
+// FakeFuncs -
+type FakeFuncs struct {
+	ctx context.Context
+	f   *fake.Faker
+}
+
+// Seed - reseeds the generator, so that the values that follow are
+// deterministic
+func (f *FakeFuncs) Seed(seed interface{}) string {
+	f.f.Seed(conv.ToString(seed))
+	return ""
+}
+
+// FirstName -
+func (f *FakeFuncs) FirstName() string {
+	return f.f.FirstName()
+}
+
+// LastName -
+func (f *FakeFuncs) LastName() string {
+	return f.f.LastName()
+}
+
+// Name -
+func (f *FakeFuncs) Name() string {
+	return f.f.Name()
+}
+
+// Username -
+func (f *FakeFuncs) Username() string {
+	return f.f.Username()
+}
+
+// Email -
+func (f *FakeFuncs) Email() string {
+	return f.f.Email()
+}
+
+// Company -
+func (f *FakeFuncs) Company() string {
+	return f.f.Company()
+}
+
+// DomainName -
+func (f *FakeFuncs) DomainName() string {
+	return f.f.DomainName()
+}
+
+// URL -
+func (f *FakeFuncs) URL() string {
+	return f.f.URL()
+}
+
+// Phone -
+func (f *FakeFuncs) Phone() string {
+	return f.f.Phone()
+}
+
+// StreetAddress -
+func (f *FakeFuncs) StreetAddress() string {
+	return f.f.StreetAddress()
+}
+
+// City -
+func (f *FakeFuncs) City() string {
+	return f.f.City()
+}
+
+// Country -
+func (f *FakeFuncs) Country() string {
+	return f.f.Country()
+}
+
+// Word -
+func (f *FakeFuncs) Word() string {
+	return f.f.Word()
+}
+
+// Words -
+func (f *FakeFuncs) Words(count interface{}) ([]string, error) {
+	n, err := conv.ToInt(count)
+	if err != nil {
+		return nil, fmt.Errorf("count must be an integer: %w", err)
+	}
+
+	if n < 0 {
+		return nil, fmt.Errorf("count must not be negative, got %d", n)
+	}
+
+	return f.f.Words(n), nil
+}
+
+// Sentence -
+func (f *FakeFuncs) Sentence(args ...interface{}) (string, error) {
+	n, err := optionalCount("words", 8, args)
+	if err != nil {
+		return "", err
+	}
+
+	return f.f.Sentence(n), nil
+}
+
+// Paragraph -
+func (f *FakeFuncs) Paragraph(args ...interface{}) (string, error) {
+	n, err := optionalCount("sentences", 4, args)
+	if err != nil {
+		return "", err
+	}
+
+	return f.f.Paragraph(n), nil
+}
+
+func optionalCount(name string, def int, args []interface{}) (int, error) {
+	switch len(args) {
+	case 0:
+		return def, nil
+	case 1:
+		n, err := conv.ToInt(args[0])
+		if err != nil {
+			return 0, fmt.Errorf("number of %s must be an integer: %w", name, err)
+		}
+
+		if n < 0 {
+			return 0, fmt.Errorf("number of %s must not be negative, got %d", name, n)
+		}
+
+		return n, nil
+	default:
+		return 0, fmt.Errorf("wrong number of args: want 0 or 1, got %d", len(args))
+	}
+}
+
+// UUID -
+func (f *FakeFuncs) UUID() string {
+	return f.f.UUID()
+}
+
+// IPv4 - returns an address in the given network, or in 10.0.0.0/8
+func (f *FakeFuncs) IPv4(args ...interface{}) (string, error) {
+	return f.ip("10.0.0.0/8", args)
+}
+
+// IPv6 - returns an address in the given network, or in fd00::/8
+func (f *FakeFuncs) IPv6(args ...interface{}) (string, error) {
+	return f.ip("fd00::/8", args)
+}
+
+func (f *FakeFuncs) ip(def string, args []interface{}) (string, error) {
+	cidr := def
+	switch len(args) {
+	case 0:
+	case 1:
+		cidr = conv.ToString(args[0])
+	default:
+		return "", fmt.Errorf("wrong number of args: want 0 or 1, got %d", len(args))
+	}
+
+	addr, err := f.f.IPAddress(cidr)
+	if err != nil {
+		return "", err
+	}
+
+	return addr.String(), nil
+}
+
+// MACAddress -
+func (f *FakeFuncs) MACAddress() string {
+	return f.f.MACAddress()
+}
@@ -0,0 +1,106 @@
+package funcs
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateFakeFuncs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateFakeFuncs(ctx)
+			actual := fmap["fake"].(func() interface{})
+
+			assert.Equal(t, ctx, actual().(*FakeFuncs).ctx)
+		})
+	}
+}
+
+func TestSeededFake(t *testing.T) {
+	t.Parallel()
+
+	gen := func(f *FakeFuncs) []string {
+		p, _ := f.Paragraph()
+		ip, _ := f.IPv4()
+		return []string{f.Name(), f.Email(), f.Company(), p, ip}
+	}
+
+	ctx := config.WithRandomSeed(context.Background(), "abc")
+	a := CreateFakeFuncs(ctx)["fake"].(func() interface{})().(*FakeFuncs)
+	b := CreateFakeFuncs(ctx)["fake"].(func() interface{})().(*FakeFuncs)
+
+	expected := gen(a)
+	assert.Equal(t, expected, gen(b))
+
+	// reseeding from the template
+	assert.Empty(t, a.Seed("abc"))
+	assert.Equal(t, expected, gen(a))
+}
+
+func TestFakeLorem(t *testing.T) {
+	t.Parallel()
+
+	f := CreateFakeFuncs(context.Background())["fake"].(func() interface{})().(*FakeFuncs)
+
+	w, err := f.Words("3")
+	require.NoError(t, err)
+	assert.Len(t, w, 3)
+
+	_, err = f.Words(-1)
+	require.Error(t, err)
+
+	s, err := f.Sentence()
+	require.NoError(t, err)
+	assert.Len(t, strings.Fields(s), 8)
+
+	s, err = f.Sentence(3)
+	require.NoError(t, err)
+	assert.Len(t, strings.Fields(s), 3)
+
+	_, err = f.Sentence("foo")
+	require.Error(t, err)
+
+	_, err = f.Sentence(1, 2)
+	require.Error(t, err)
+
+	p, err := f.Paragraph(2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(p, "."))
+}
+
+func TestFakeIP(t *testing.T) {
+	t.Parallel()
+
+	f := CreateFakeFuncs(context.Background())["fake"].(func() interface{})().(*FakeFuncs)
+
+	ip, err := f.IPv4()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(ip, "10."), ip)
+
+	ip, err = f.IPv4("192.168.10.0/24")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(ip, "192.168.10."), ip)
+
+	ip, err = f.IPv6()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(ip, "fd"), ip)
+
+	_, err = f.IPv4("bogus")
+	require.Error(t, err)
+
+	_, err = f.IPv4("a", "b")
+	require.Error(t, err)
+}
@@ -2,11 +2,16 @@ package funcs
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
 	"io/fs"
 	"path/filepath"
 
 	osfs "github.com/hack-pad/hackpadfs/os"
 	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
 	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
 	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
 )
@@ -40,6 +45,22 @@ func (f *FileFuncs) Read(path interface{}) (string, error) {
 	return string(b), err
 }
 
+// SHA256 -
+func (f *FileFuncs) SHA256(path interface{}) (string, error) {
+	file, err := f.fs.Open(conv.ToString(path))
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", fmt.Errorf("read %s: %w", path, err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 // Stat -
 func (f *FileFuncs) Stat(path interface{}) (fs.FileInfo, error) {
 	return fs.Stat(f.fs, conv.ToString(path))
@@ -93,6 +114,10 @@ func (f *FileFuncs) Walk(path interface{}) ([]string, error) {
 
 // Write -
 func (f *FileFuncs) Write(path interface{}, data interface{}) (s string, err error) {
+	if !config.WriteAllowed(f.ctx) {
+		return "", fmt.Errorf("file.Write is disabled: use --allow-write (or set allowWrite in the config file) to enable it")
+	}
+
 	type byteser interface{ Bytes() []byte }
 
 	var content []byte
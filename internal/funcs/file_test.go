@@ -12,6 +12,7 @@ import (
 
 	"github.com/hack-pad/hackpadfs"
 	osfs "github.com/hack-pad/hackpadfs/os"
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
 	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -123,7 +124,7 @@ func TestWrite(t *testing.T) {
 	fsys := datafs.WrapWdFS(osfs.NewFS())
 
 	f := &FileFuncs{
-		ctx: context.Background(),
+		ctx: config.WithWriteAllowed(context.Background()),
 		fs:  fsys,
 	}
 
@@ -175,3 +176,45 @@ func TestWrite(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "Hello from a byte buffer!", string(out))
 }
+
+func TestWriteNotAllowed(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	f := &FileFuncs{
+		ctx: context.Background(),
+		fs:  datafs.WrapWdFS(osfs.NewFS()),
+	}
+
+	foopath := filepath.Join(dir, "foo")
+	_, err := f.Write(foopath, "Hello world")
+	require.ErrorContains(t, err, "--allow-write")
+
+	_, err = os.Stat(foopath)
+	require.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestSHA256(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"tmp/foo": {Data: []byte("hello world")},
+		"tmp/bar": {Data: []byte{}},
+	}
+
+	ff := &FileFuncs{
+		ctx: context.Background(),
+		fs:  fsys,
+	}
+
+	actual, err := ff.SHA256("tmp/foo")
+	require.NoError(t, err)
+	assert.Equal(t, "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9", actual)
+
+	actual, err = ff.SHA256("tmp/bar")
+	require.NoError(t, err)
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", actual)
+
+	_, err = ff.SHA256("tmp/missing")
+	require.Error(t, err)
+}
@@ -2,9 +2,15 @@ package funcs
 
 import (
 	"context"
+	"fmt"
+	"io/fs"
+	"net/url"
 	"path/filepath"
+	"strings"
 
+	osfs "github.com/hack-pad/hackpadfs/os"
 	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
 )
 
 // CreateFilePathFuncs -
@@ -46,6 +52,70 @@ func (f *FilePathFuncs) FromSlash(in interface{}) string {
 	return filepath.FromSlash(conv.ToString(in))
 }
 
+// Glob -
+func (f *FilePathFuncs) Glob(pattern interface{}) ([]string, error) {
+	p := conv.ToString(pattern)
+	if !strings.Contains(p, "://") {
+		fsys, err := datafs.FSysForPath(f.ctx, "/")
+		if err != nil {
+			fsys = datafs.WrapWdFS(osfs.NewFS())
+		}
+
+		return fs.Glob(fsys, p)
+	}
+
+	return f.globURL(p)
+}
+
+// globURL matches a pattern in the path of a URL, such as
+// s3://bucket/dir/*.yaml, against the filesystem for that URL. Because '?'
+// starts the query string in a URL, it can't be used as a wildcard here.
+func (f *FilePathFuncs) globURL(pattern string) ([]string, error) {
+	base, query, _ := strings.Cut(pattern, "?")
+
+	u, err := url.Parse(base)
+	if err != nil {
+		return nil, fmt.Errorf("parse pattern %q: %w", pattern, err)
+	}
+
+	u.RawQuery = query
+
+	fsURL, name := datafs.SplitFSMuxURL(u)
+
+	fsys, err := datafs.FSysForPath(f.ctx, fsURL.String())
+	if err != nil {
+		return nil, fmt.Errorf("glob %q: %w", pattern, err)
+	}
+
+	// local paths must be absolute, as they are when reading datasources
+	if fsURL.Scheme == "file" {
+		name = "/" + name
+	}
+
+	matches, err := fs.Glob(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("glob %q: %w", pattern, err)
+	}
+
+	out := make([]string, len(matches))
+	for i, m := range matches {
+		mu := *u
+
+		switch u.Scheme {
+		case "git", "git+file", "git+http", "git+https", "git+ssh":
+			mu.Path = fsURL.Path + "//" + m
+		case "file":
+			mu.Path = m
+		default:
+			mu.Path = "/" + m
+		}
+
+		out[i] = mu.String()
+	}
+
+	return out, nil
+}
+
 // IsAbs -
 func (f *FilePathFuncs) IsAbs(in interface{}) bool {
 	return filepath.IsAbs(conv.ToString(in))
@@ -2,10 +2,15 @@ package funcs
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
+	"testing/fstest"
 
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateFilePathFuncs(t *testing.T) {
@@ -24,3 +29,64 @@ func TestCreateFilePathFuncs(t *testing.T) {
 		})
 	}
 }
+
+func TestGlob(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, name := range []string{"a.yaml", "b.yaml", "c.json"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0o600))
+	}
+
+	f := &FilePathFuncs{ctx: context.Background()}
+
+	matches, err := f.Glob(filepath.Join(dir, "*.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "a.yaml"),
+		filepath.Join(dir, "b.yaml"),
+	}, matches)
+
+	matches, err = f.Glob(filepath.Join(dir, "*.txt"))
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+
+	_, err = f.Glob(filepath.Join(dir, "[a-"))
+	require.Error(t, err)
+}
+
+func TestGlobURL(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"dir/a.yaml":     {Data: []byte("a")},
+		"dir/b.yaml":     {Data: []byte("b")},
+		"dir/c.json":     {Data: []byte("c")},
+		"dir/sub/d.yaml": {Data: []byte("d")},
+	}
+
+	ctx := datafs.ContextWithFSProvider(context.Background(),
+		datafs.WrappedFSProvider(fsys, "s3", "git+https"))
+	f := &FilePathFuncs{ctx: ctx}
+
+	matches, err := f.Glob("s3://bucket/dir/*.yaml?region=us-east-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"s3://bucket/dir/a.yaml?region=us-east-1",
+		"s3://bucket/dir/b.yaml?region=us-east-1",
+	}, matches)
+
+	matches, err = f.Glob("s3://bucket/dir/*/*.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"s3://bucket/dir/sub/d.yaml"}, matches)
+
+	matches, err = f.Glob("git+https://example.com/repo//dir/*.json")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"git+https://example.com/repo//dir/c.json"}, matches)
+
+	_, err = f.Glob("s3://bucket/dir/[a-")
+	require.Error(t, err)
+
+	_, err = (&FilePathFuncs{ctx: context.Background()}).Glob("s3://bucket/*.yaml")
+	require.Error(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/hairyhenderson/gomplate/v4/conv"
 	"github.com/hairyhenderson/gomplate/v4/gcp"
 )
 
@@ -22,15 +23,58 @@ func CreateGCPFuncs(ctx context.Context) map[string]interface{} {
 type GcpFuncs struct {
 	ctx context.Context
 
-	meta    *gcp.MetaClient
-	gcpopts gcp.ClientOptions
+	meta      *gcp.MetaClient
+	token     *gcp.TokenClient
+	metaInit  sync.Once
+	tokenInit sync.Once
+	gcpopts   gcp.ClientOptions
 }
 
 // Meta -
 func (a *GcpFuncs) Meta(key string, def ...string) (string, error) {
-	a.meta = sync.OnceValue[*gcp.MetaClient](func() *gcp.MetaClient {
-		return gcp.NewMetaClient(a.ctx, a.gcpopts)
-	})()
-
+	a.metaInit.Do(a.initMeta)
 	return a.meta.Meta(key, def...)
 }
+
+// ProjectMeta -
+func (a *GcpFuncs) ProjectMeta(key string, def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.ProjectMeta(key, def...)
+}
+
+// ProjectID -
+func (a *GcpFuncs) ProjectID(def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.ProjectID(def...)
+}
+
+// Zone -
+func (a *GcpFuncs) Zone(def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.Zone(def...)
+}
+
+// Region -
+func (a *GcpFuncs) Region(def ...string) (string, error) {
+	a.metaInit.Do(a.initMeta)
+	return a.meta.Region(def...)
+}
+
+// AccessToken - returns an OAuth2 access token from Application Default
+// Credentials, for the given scopes (or the cloud-platform scope)
+func (a *GcpFuncs) AccessToken(scopes ...interface{}) (string, error) {
+	a.tokenInit.Do(a.initToken)
+	return a.token.AccessToken(conv.ToStrings(scopes...)...)
+}
+
+func (a *GcpFuncs) initMeta() {
+	if a.meta == nil {
+		a.meta = gcp.NewMetaClient(a.ctx, a.gcpopts)
+	}
+}
+
+func (a *GcpFuncs) initToken() {
+	if a.token == nil {
+		a.token = gcp.NewTokenClient(a.ctx)
+	}
+}
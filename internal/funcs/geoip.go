@@ -0,0 +1,91 @@
+package funcs
+
+import (
+	"context"
+	"io/fs"
+	"sync"
+
+	osfs "github.com/hack-pad/hackpadfs/os"
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/geoip"
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// CreateGeoIPFuncs -
+func CreateGeoIPFuncs(ctx context.Context) map[string]interface{} {
+	fsys, err := datafs.FSysForPath(ctx, "/")
+	if err != nil {
+		fsys = datafs.WrapWdFS(osfs.NewFS())
+	}
+
+	ns := &GeoIPFuncs{
+		ctx: ctx,
+		fs:  fsys,
+		dbs: map[string]*maxminddb.Reader{},
+	}
+
+	return map[string]interface{}{
+		"geoip": func() interface{} { return ns },
+	}
+}
+
+// GeoIPFuncs -
+type GeoIPFuncs struct {
+	ctx context.Context
+	fs  fs.FS
+
+	// opened databases, keyed by path, so each is only read once per render
+	dbs map[string]*maxminddb.Reader
+	mu  sync.Mutex
+}
+
+func (f *GeoIPFuncs) open(path interface{}) (*maxminddb.Reader, error) {
+	name := conv.ToString(path)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if db, ok := f.dbs[name]; ok {
+		return db, nil
+	}
+
+	db, err := geoip.Open(f.fs, name)
+	if err != nil {
+		return nil, err
+	}
+
+	f.dbs[name] = db
+
+	return db, nil
+}
+
+// Lookup -
+func (f *GeoIPFuncs) Lookup(db, ip interface{}) (map[string]interface{}, error) {
+	r, err := f.open(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return geoip.Lookup(r, conv.ToString(ip))
+}
+
+// Country -
+func (f *GeoIPFuncs) Country(db, ip interface{}) (string, error) {
+	r, err := f.open(db)
+	if err != nil {
+		return "", err
+	}
+
+	return geoip.Country(r, conv.ToString(ip))
+}
+
+// ASN -
+func (f *GeoIPFuncs) ASN(db, ip interface{}) (uint64, error) {
+	r, err := f.open(db)
+	if err != nil {
+		return 0, err
+	}
+
+	return geoip.ASN(r, conv.ToString(ip))
+}
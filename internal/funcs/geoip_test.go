@@ -0,0 +1,62 @@
+package funcs
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+	"github.com/oschwald/maxminddb-golang"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateGeoIPFuncs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateGeoIPFuncs(ctx)
+			actual := fmap["geoip"].(func() interface{})
+
+			assert.Equal(t, ctx, actual().(*GeoIPFuncs).ctx)
+		})
+	}
+}
+
+func TestGeoIPFuncs(t *testing.T) {
+	t.Parallel()
+
+	b, err := os.ReadFile("../../geoip/testdata/test.mmdb")
+	require.NoError(t, err)
+
+	fsys := fstest.MapFS{"db/test.mmdb": &fstest.MapFile{Data: b}}
+	f := &GeoIPFuncs{
+		fs:  datafs.WrapWdFS(fsys),
+		dbs: map[string]*maxminddb.Reader{},
+	}
+
+	c, err := f.Country("/db/test.mmdb", "81.2.69.142")
+	require.NoError(t, err)
+	assert.Equal(t, "GB", c)
+
+	asn, err := f.ASN("/db/test.mmdb", "216.160.83.56")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(209), asn)
+
+	rec, err := f.Lookup("/db/test.mmdb", "216.160.83.56")
+	require.NoError(t, err)
+	assert.Equal(t, "CenturyLink", rec["autonomous_system_organization"])
+
+	// the database is only opened once
+	assert.Len(t, f.dbs, 1)
+
+	_, err = f.Lookup("/db/missing.mmdb", "216.160.83.56")
+	require.Error(t, err)
+}
@@ -0,0 +1,142 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/gitinfo"
+)
+
+// CreateGitInfoFuncs -
+func CreateGitInfoFuncs(ctx context.Context) map[string]interface{} {
+	ns := &GitInfoFuncs{ctx: ctx, dir: ".", repos: &gitRepos{repos: map[string]*gitinfo.Repo{}}}
+
+	return map[string]interface{}{
+		"gitinfo": func() interface{} { return ns },
+	}
+}
+
+// SetGitInfoDir - replaces the gitinfo namespace in f (as created by
+// [CreateGitInfoFuncs]) with one which defaults to the repository containing
+// dir, rather than the working directory. This is used so that each template
+// defaults to its own repository. Repositories are still only opened once.
+func SetGitInfoDir(f map[string]interface{}, dir string) {
+	nsf, ok := f["gitinfo"].(func() interface{})
+	if !ok {
+		return
+	}
+
+	ns, ok := nsf().(*GitInfoFuncs)
+	if !ok {
+		return
+	}
+
+	withDir := &GitInfoFuncs{ctx: ns.ctx, dir: dir, repos: ns.repos}
+	f["gitinfo"] = func() interface{} { return withDir }
+}
+
+// GitInfoFuncs -
+type GitInfoFuncs struct {
+	ctx   context.Context
+	repos *gitRepos
+	dir   string
+}
+
+// gitRepos - the repositories opened so far, by directory
+type gitRepos struct {
+	repos map[string]*gitinfo.Repo
+	mu    sync.Mutex
+}
+
+// repo opens the repository containing the directory given as the optional
+// argument, or else the directory containing the template (or the working
+// directory). Repositories are only opened once.
+func (f *GitInfoFuncs) repo(args []interface{}) (*gitinfo.Repo, error) {
+	dir := f.dir
+	switch len(args) {
+	case 0:
+	case 1:
+		dir = conv.ToString(args[0])
+	default:
+		return nil, fmt.Errorf("wrong number of args: want 0 or 1, got %d", len(args))
+	}
+
+	f.repos.mu.Lock()
+	defer f.repos.mu.Unlock()
+
+	if r, ok := f.repos.repos[dir]; ok {
+		return r, nil
+	}
+
+	r, err := gitinfo.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	f.repos.repos[dir] = r
+
+	return r, nil
+}
+
+// Commit -
+func (f *GitInfoFuncs) Commit(args ...interface{}) (string, error) {
+	r, err := f.repo(args)
+	if err != nil {
+		return "", err
+	}
+
+	return r.Commit()
+}
+
+// ShortCommit -
+func (f *GitInfoFuncs) ShortCommit(args ...interface{}) (string, error) {
+	c, err := f.Commit(args...)
+	if err != nil {
+		return "", err
+	}
+
+	return c[:7], nil
+}
+
+// Branch -
+func (f *GitInfoFuncs) Branch(args ...interface{}) (string, error) {
+	r, err := f.repo(args)
+	if err != nil {
+		return "", err
+	}
+
+	return r.Branch()
+}
+
+// Tag - the first tag pointing at HEAD, or an empty string when there are
+// none
+func (f *GitInfoFuncs) Tag(args ...interface{}) (string, error) {
+	tags, err := f.Tags(args...)
+	if err != nil || len(tags) == 0 {
+		return "", err
+	}
+
+	return tags[0], nil
+}
+
+// Tags -
+func (f *GitInfoFuncs) Tags(args ...interface{}) ([]string, error) {
+	r, err := f.repo(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Tags()
+}
+
+// Dirty -
+func (f *GitInfoFuncs) Dirty(args ...interface{}) (bool, error) {
+	r, err := f.repo(args)
+	if err != nil {
+		return false, err
+	}
+
+	return r.Dirty()
+}
@@ -0,0 +1,109 @@
+package funcs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hairyhenderson/go-git/v5"
+	"github.com/hairyhenderson/go-git/v5/plumbing"
+	"github.com/hairyhenderson/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateGitInfoFuncs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateGitInfoFuncs(ctx)
+			actual := fmap["gitinfo"].(func() interface{})
+
+			assert.Equal(t, ctx, actual().(*GitInfoFuncs).ctx)
+		})
+	}
+}
+
+func TestGitInfo(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	r, err := git.PlainInitWithOptions(dir, &git.PlainInitOptions{
+		InitOptions: git.InitOptions{DefaultBranch: plumbing.NewBranchReferenceName("release")},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("hello"), 0o600))
+
+	wt, err := r.Worktree()
+	require.NoError(t, err)
+
+	_, err = wt.Add("a.txt")
+	require.NoError(t, err)
+
+	hash, err := wt.Commit("initial commit", &git.CommitOptions{
+		Author: &object.Signature{Name: "me", Email: "me@example.com", When: time.Unix(0, 0)},
+	})
+	require.NoError(t, err)
+
+	g := CreateGitInfoFuncs(context.Background())["gitinfo"].(func() interface{})().(*GitInfoFuncs)
+
+	c, err := g.Commit(dir)
+	require.NoError(t, err)
+	assert.Equal(t, hash.String(), c)
+
+	c, err = g.ShortCommit(dir)
+	require.NoError(t, err)
+	assert.Equal(t, hash.String()[:7], c)
+
+	b, err := g.Branch(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "release", b)
+
+	tag, err := g.Tag(dir)
+	require.NoError(t, err)
+	assert.Empty(t, tag)
+
+	_, err = r.CreateTag("v2.0.0", hash, nil)
+	require.NoError(t, err)
+	_, err = r.CreateTag("v1.0.0", hash, nil)
+	require.NoError(t, err)
+
+	tag, err = g.Tag(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0.0", tag)
+
+	tags, err := g.Tags(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1.0.0", "v2.0.0"}, tags)
+
+	dirty, err := g.Dirty(dir)
+	require.NoError(t, err)
+	assert.False(t, dirty)
+
+	assert.Len(t, g.repos.repos, 1)
+
+	// the default directory can be set, and opened repositories are shared
+	f := map[string]interface{}{"gitinfo": func() interface{} { return g }}
+	SetGitInfoDir(f, dir)
+	gd := f["gitinfo"].(func() interface{})().(*GitInfoFuncs)
+
+	c, err = gd.Commit()
+	require.NoError(t, err)
+	assert.Equal(t, hash.String(), c)
+	assert.Same(t, g.repos, gd.repos)
+
+	_, err = g.Commit(dir, "extra")
+	require.Error(t, err)
+
+	_, err = g.Branch(t.TempDir())
+	require.Error(t, err)
+}
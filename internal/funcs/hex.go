@@ -0,0 +1,44 @@
+package funcs
+
+import (
+	"context"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/hex"
+)
+
+// CreateHexFuncs -
+func CreateHexFuncs(ctx context.Context) map[string]interface{} {
+	f := map[string]interface{}{}
+
+	ns := &HexFuncs{ctx}
+	f["hex"] = func() interface{} { return ns }
+
+	return f
+}
+
+// HexFuncs -
+type HexFuncs struct {
+	ctx context.Context
+}
+
+// Encode -
+func (HexFuncs) Encode(args ...interface{}) (string, error) {
+	sep, in, err := stringArgAndInput(args)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.Encode(sep, toBytes(in)), nil
+}
+
+// Decode -
+func (HexFuncs) Decode(in interface{}) (string, error) {
+	out, err := hex.Decode(conv.ToString(in))
+	return string(out), err
+}
+
+// DecodeBytes -
+func (HexFuncs) DecodeBytes(in interface{}) ([]byte, error) {
+	return hex.Decode(conv.ToString(in))
+}
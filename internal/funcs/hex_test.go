@@ -0,0 +1,46 @@
+package funcs
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateHexFuncs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateHexFuncs(ctx)
+			actual := fmap["hex"].(func() interface{})
+
+			assert.Equal(t, ctx, actual().(*HexFuncs).ctx)
+		})
+	}
+}
+
+func TestHex(t *testing.T) {
+	t.Parallel()
+
+	hf := &HexFuncs{}
+	assert.Equal(t, "68656c6c6f", must(hf.Encode("hello")))
+	assert.Equal(t, "de:ad:be:ef", must(hf.Encode(":", []byte{0xde, 0xad, 0xbe, 0xef})))
+	assert.Equal(t, "hello", must(hf.Decode("68:65:6C:6C:6F")))
+
+	out, err := hf.DecodeBytes("0xDEADBEEF")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, out)
+
+	_, err = hf.Encode()
+	require.Error(t, err)
+
+	_, err = hf.Decode("xyz")
+	require.Error(t, err)
+}
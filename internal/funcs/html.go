@@ -0,0 +1,70 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"text/template"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/internal/parsers"
+)
+
+// CreateHTMLFuncs -
+func CreateHTMLFuncs(ctx context.Context) map[string]interface{} {
+	ns := &HTMLFuncs{ctx}
+
+	return map[string]interface{}{
+		// html is both the namespace and text/template's built-in html
+		// function - called with no arguments (as in "html.Query") it returns
+		// the namespace, otherwise it escapes its arguments as HTML
+		"html": func(args ...interface{}) interface{} {
+			if len(args) == 0 {
+				return ns
+			}
+
+			return template.HTMLEscaper(args...)
+		},
+	}
+}
+
+// HTMLFuncs -
+type HTMLFuncs struct {
+	ctx context.Context
+}
+
+// Parse -
+func (HTMLFuncs) Parse(in interface{}) (*parsers.HTMLNode, error) {
+	return parsers.HTML(conv.ToString(in))
+}
+
+// Query - finds the elements matching the CSS selector in an HTML document, or
+// within an element returned by Parse or Query
+func (HTMLFuncs) Query(selector string, in interface{}) ([]interface{}, error) {
+	var n *parsers.HTMLNode
+
+	switch in := in.(type) {
+	case *parsers.HTMLNode:
+		n = in
+	case string, []byte:
+		var err error
+
+		n, err = parsers.HTML(conv.ToString(in))
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("expected an HTML document or element, got %T", in)
+	}
+
+	nodes, err := parsers.HTMLQuery(selector, n)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]interface{}, len(nodes))
+	for i, node := range nodes {
+		out[i] = node
+	}
+
+	return out, nil
+}
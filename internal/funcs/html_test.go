@@ -0,0 +1,73 @@
+package funcs
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/parsers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateHTMLFuncs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateHTMLFuncs(ctx)
+			actual := fmap["html"].(func(...interface{}) interface{})
+
+			assert.Equal(t, ctx, actual().(*HTMLFuncs).ctx)
+		})
+	}
+}
+
+func TestHTMLEscaping(t *testing.T) {
+	t.Parallel()
+
+	html := CreateHTMLFuncs(context.Background())["html"].(func(...interface{}) interface{})
+
+	assert.Equal(t, "&lt;b&gt;Tom &amp; Jerry&lt;/b&gt;", html("<b>Tom & Jerry</b>"))
+	assert.Equal(t, "a1", html("a", 1))
+}
+
+func TestHTMLQuery(t *testing.T) {
+	t.Parallel()
+
+	h := HTMLFuncs{}
+
+	in := `<ul><li class="up">web</li><li class="down">api</li></ul>`
+
+	out, err := h.Query("li.down", in)
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+	assert.Equal(t, "api", out[0].(*parsers.HTMLNode).Text)
+
+	out, err = h.Query("li", []byte(in))
+	require.NoError(t, err)
+	assert.Len(t, out, 2)
+
+	doc, err := h.Parse(in)
+	require.NoError(t, err)
+
+	out, err = h.Query("li.up", doc)
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+	assert.Equal(t, map[string]string{"class": "up"}, out[0].(*parsers.HTMLNode).Attrs)
+
+	// elements can be queried
+	ul, err := h.Query("ul", doc)
+	require.NoError(t, err)
+
+	out, err = h.Query(".down", ul[0])
+	require.NoError(t, err)
+	assert.Len(t, out, 1)
+
+	_, err = h.Query("li", 42)
+	require.ErrorContains(t, err, "expected an HTML document")
+}
@@ -0,0 +1,126 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+	"github.com/hairyhenderson/gomplate/v4/internal/i18n"
+)
+
+// CreateI18nFuncs -
+func CreateI18nFuncs(ctx context.Context, sr datafs.DataSourceReader) map[string]interface{} {
+	ns := &I18nFuncs{
+		ctx:      ctx,
+		sr:       sr,
+		catalogs: map[string]*i18n.Catalog{},
+	}
+
+	return map[string]interface{}{
+		"i18n": func() interface{} { return ns },
+	}
+}
+
+// I18nFuncs -
+type I18nFuncs struct {
+	ctx      context.Context
+	sr       datafs.DataSourceReader
+	catalogs map[string]*i18n.Catalog
+	locale   string
+	mu       sync.RWMutex
+}
+
+// Load - loads a message catalog for the given locale from a datasource. The
+// first catalog loaded sets the current locale.
+func (f *I18nFuncs) Load(locale string, alias string, args ...string) (string, error) {
+	_, b, err := f.sr.ReadSource(f.ctx, alias, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to read catalog %q: %w", alias, err)
+	}
+
+	c, err := i18n.Parse(locale, b)
+	if err != nil {
+		return "", fmt.Errorf("failed to load catalog %q: %w", alias, err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.catalogs[c.Locale] = c
+	if f.locale == "" {
+		f.locale = c.Locale
+	}
+
+	return "", nil
+}
+
+// SetLocale - sets the locale used for translations
+func (f *I18nFuncs) SetLocale(locale string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.locale = i18n.NormalizeLocale(locale)
+
+	return ""
+}
+
+// Locale - returns the locale used for translations
+func (f *I18nFuncs) Locale() string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return f.locale
+}
+
+// Locales - returns the locales of all loaded catalogs, sorted
+func (f *I18nFuncs) Locales() []string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	locales := make([]string, 0, len(f.catalogs))
+	for l := range f.catalogs {
+		locales = append(locales, l)
+	}
+
+	sort.Strings(locales)
+
+	return locales
+}
+
+func (f *I18nFuncs) catalog() *i18n.Catalog {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return f.catalogs[f.locale]
+}
+
+// T - translates a message into the current locale. When arguments are given,
+// the translation is used as a format string.
+func (f *I18nFuncs) T(msgid interface{}, args ...interface{}) string {
+	s := f.catalog().T(conv.ToString(msgid))
+	if len(args) == 0 {
+		return s
+	}
+
+	return fmt.Sprintf(s, args...)
+}
+
+// TN - translates a message into the current locale, choosing the plural
+// form appropriate for the count n. When arguments are given, the translation
+// is used as a format string.
+func (f *I18nFuncs) TN(msgid, msgidPlural, n interface{}, args ...interface{}) (string, error) {
+	count, err := conv.ToInt(n)
+	if err != nil {
+		return "", fmt.Errorf("expected a number for the count: %w", err)
+	}
+
+	s := f.catalog().TN(conv.ToString(msgid), conv.ToString(msgidPlural), count)
+	if len(args) == 0 {
+		return s, nil
+	}
+
+	return fmt.Sprintf(s, args...), nil
+}
@@ -0,0 +1,103 @@
+package funcs
+
+import (
+	"context"
+	"net/url"
+	"runtime"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateI18nFuncs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fmap := CreateI18nFuncs(ctx, nil)
+	actual := fmap["i18n"].(func() interface{})
+
+	assert.Equal(t, ctx, actual().(*I18nFuncs).ctx)
+}
+
+func TestI18n(t *testing.T) {
+	t.Parallel()
+
+	root := "/tmp/"
+	if runtime.GOOS == osWindows {
+		root = "C:/tmp/"
+	}
+
+	fsys := datafs.WrapWdFS(fstest.MapFS{
+		"tmp/de.po": &fstest.MapFile{Data: []byte(`msgid ""
+msgstr ""
+"Language: de\n"
+
+msgid "Hello, %s!"
+msgstr "Hallo, %s!"
+
+msgid "%d file"
+msgid_plural "%d files"
+msgstr[0] "%d Datei"
+msgstr[1] "%d Dateien"
+`)},
+		"tmp/fr.json": &fstest.MapFile{Data: []byte(`{"Hello, %s!": "Bonjour, %s !", "%d file": ["%d fichier", "%d fichiers"]}`)},
+	})
+	ctx := datafs.ContextWithFSProvider(context.Background(), datafs.WrappedFSProvider(fsys, "file", ""))
+
+	reg := datafs.NewRegistry()
+	reg.Register("de", config.DataSource{URL: &url.URL{Scheme: "file", Path: root + "de.po"}})
+	reg.Register("fr", config.DataSource{URL: &url.URL{Scheme: "file", Path: root + "fr.json"}})
+
+	f := CreateI18nFuncs(ctx, datafs.NewSourceReader(reg))["i18n"].(func() interface{})().(*I18nFuncs)
+
+	// nothing loaded yet
+	assert.Equal(t, "", f.Locale())
+	assert.Equal(t, "Hello, world!", f.T("Hello, %s!", "world"))
+
+	out, err := f.Load("", "de")
+	require.NoError(t, err)
+	assert.Equal(t, "", out)
+
+	_, err = f.Load("fr-FR", "fr")
+	require.NoError(t, err)
+
+	// the first catalog loaded sets the locale
+	assert.Equal(t, "de", f.Locale())
+	assert.Equal(t, []string{"de", "fr_FR"}, f.Locales())
+
+	assert.Equal(t, "Hallo, %s!", f.T("Hello, %s!"))
+	assert.Equal(t, "Hallo, Welt!", f.T("Hello, %s!", "Welt"))
+
+	s, err := f.TN("%d file", "%d files", 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "1 Datei", s)
+
+	s, err = f.TN("%d file", "%d files", "0", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "0 Dateien", s)
+
+	assert.Equal(t, "", f.SetLocale("fr-FR"))
+	assert.Equal(t, "fr_FR", f.Locale())
+	assert.Equal(t, "Bonjour, monde !", f.T("Hello, %s!", "monde"))
+
+	s, err = f.TN("%d file", "%d files", 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "0 fichier", s)
+
+	// unknown locales fall back to the message IDs
+	f.SetLocale("es")
+	s, err = f.TN("%d file", "%d files", 2)
+	require.NoError(t, err)
+	assert.Equal(t, "%d files", s)
+
+	_, err = f.TN("%d file", "%d files", "many")
+	require.Error(t, err)
+
+	_, err = f.Load("", "bogus")
+	require.Error(t, err)
+}
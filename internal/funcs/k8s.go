@@ -0,0 +1,178 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/k8s"
+)
+
+// CreateK8sFuncs -
+func CreateK8sFuncs(ctx context.Context) map[string]interface{} {
+	f := map[string]interface{}{}
+
+	ns := &K8sFuncs{ctx}
+	f["k8s"] = func() interface{} { return ns }
+
+	return f
+}
+
+// K8sFuncs -
+type K8sFuncs struct {
+	ctx context.Context
+}
+
+// ParseQuantity - parses a resource quantity like "512Mi" or "100m", returning
+// an int64 for whole numbers, and a float64 otherwise
+func (K8sFuncs) ParseQuantity(q interface{}) (interface{}, error) {
+	r, _, err := k8s.ParseQuantity(conv.ToString(q))
+	if err != nil {
+		return nil, err
+	}
+
+	if r.IsInt() && r.Num().IsInt64() {
+		return r.Num().Int64(), nil
+	}
+
+	f, _ := r.Float64()
+	return f, nil
+}
+
+// FormatQuantity - formats a number (or quantity) as a canonical quantity,
+// optionally in the given format (BinarySI, DecimalSI, or DecimalExponent)
+func (K8sFuncs) FormatQuantity(args ...interface{}) (string, error) {
+	var format k8s.QuantityFormat
+	var in interface{}
+
+	switch len(args) {
+	case 1:
+		in = args[0]
+	case 2:
+		f, err := quantityFormat(conv.ToString(args[0]))
+		if err != nil {
+			return "", err
+		}
+
+		format, in = f, args[1]
+	default:
+		return "", fmt.Errorf("wrong number of args: want 1 or 2, got %d", len(args))
+	}
+
+	r, f, err := quantityArg(in)
+	if err != nil {
+		return "", err
+	}
+
+	// like Kubernetes, preserve the input's format when none is given
+	if format == "" {
+		format = f
+	}
+
+	return k8s.FormatQuantity(r, format)
+}
+
+func quantityFormat(s string) (k8s.QuantityFormat, error) {
+	for _, f := range []k8s.QuantityFormat{k8s.BinarySI, k8s.DecimalSI, k8s.DecimalExponent} {
+		if strings.EqualFold(s, string(f)) {
+			return f, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown format %q: must be one of %s, %s, or %s", s, k8s.BinarySI, k8s.DecimalSI, k8s.DecimalExponent)
+}
+
+// quantityArg converts a number or quantity string to a rational. Numbers are
+// always treated as DecimalSI.
+func quantityArg(in interface{}) (*big.Rat, k8s.QuantityFormat, error) {
+	if s, ok := in.(string); ok {
+		return k8s.ParseQuantity(s)
+	}
+
+	r, _, err := k8s.ParseQuantity(conv.ToString(in))
+	if err != nil {
+		return nil, "", fmt.Errorf("expected a number or quantity: %w", err)
+	}
+
+	return r, k8s.DecimalSI, nil
+}
+
+// MatchSelector - returns whether the labels match the given label selector,
+// which may be a string (like "app=web,tier in (a,b)") or a map of labels
+func (K8sFuncs) MatchSelector(selector, labels interface{}) (bool, error) {
+	var sel k8s.Selector
+
+	switch s := selector.(type) {
+	case string:
+		var err error
+		sel, err = k8s.ParseSelector(s)
+		if err != nil {
+			return false, err
+		}
+	default:
+		m, err := stringMap(selector)
+		if err != nil {
+			return false, fmt.Errorf("selector must be a string or map: %w", err)
+		}
+
+		sel = k8s.SelectorFromMap(m)
+	}
+
+	l, err := stringMap(labels)
+	if err != nil {
+		return false, fmt.Errorf("labels must be a map: %w", err)
+	}
+
+	return sel.Matches(l), nil
+}
+
+// stringMap converts a map to a map of strings
+func stringMap(in interface{}) (map[string]string, error) {
+	switch m := in.(type) {
+	case nil:
+		return map[string]string{}, nil
+	case map[string]string:
+		return m, nil
+	case map[string]interface{}:
+		out := make(map[string]string, len(m))
+		for k, v := range m {
+			out[k] = conv.ToString(v)
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("unexpected type %T", in)
+	}
+}
+
+// IsDNS1123Label -
+func (K8sFuncs) IsDNS1123Label(name interface{}) bool {
+	return k8s.IsDNS1123Label(conv.ToString(name))
+}
+
+// IsDNS1123Subdomain -
+func (K8sFuncs) IsDNS1123Subdomain(name interface{}) bool {
+	return k8s.IsDNS1123Subdomain(conv.ToString(name))
+}
+
+// SanitizeName - converts the input into a valid DNS-1123 label, optionally
+// limited to the given length
+func (K8sFuncs) SanitizeName(args ...interface{}) (string, error) {
+	switch len(args) {
+	case 1:
+		return k8s.SanitizeName(conv.ToString(args[0]), 0), nil
+	case 2:
+		n, err := conv.ToInt(args[0])
+		if err != nil {
+			return "", fmt.Errorf("expected a number: %w", err)
+		}
+		if n < 1 || n > k8s.DNS1123LabelMaxLength {
+			return "", fmt.Errorf("length %d out of range: must be between 1 and %d", n, k8s.DNS1123LabelMaxLength)
+		}
+
+		return k8s.SanitizeName(conv.ToString(args[1]), n), nil
+	default:
+		return "", fmt.Errorf("wrong number of args: want 1 or 2, got %d", len(args))
+	}
+}
@@ -0,0 +1,149 @@
+package funcs
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateK8sFuncs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateK8sFuncs(ctx)
+			actual := fmap["k8s"].(func() interface{})
+
+			assert.Equal(t, ctx, actual().(*K8sFuncs).ctx)
+		})
+	}
+}
+
+func TestK8sParseQuantity(t *testing.T) {
+	t.Parallel()
+
+	k := K8sFuncs{}
+
+	out, err := k.ParseQuantity("512Mi")
+	require.NoError(t, err)
+	assert.Equal(t, int64(536870912), out)
+
+	out, err = k.ParseQuantity("250m")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.25, out, 1e-9)
+
+	out, err = k.ParseQuantity(2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), out)
+
+	_, err = k.ParseQuantity("2KB")
+	require.Error(t, err)
+}
+
+func TestK8sFormatQuantity(t *testing.T) {
+	t.Parallel()
+
+	k := K8sFuncs{}
+
+	testdata := []struct {
+		expected string
+		args     []interface{}
+	}{
+		{"1536Mi", []interface{}{"1.5Gi"}},
+		{"1500M", []interface{}{"1.5G"}},
+		{"1k", []interface{}{1000}},
+		{"1Gi", []interface{}{"BinarySI", 1073741824}},
+		{"1Gi", []interface{}{"binarysi", "1024Mi"}},
+		{"1073741824", []interface{}{"DecimalSI", "1Gi"}},
+		{"500m", []interface{}{0.5}},
+		{"1e3", []interface{}{"DecimalExponent", 1000}},
+		{"1000E", []interface{}{1e21}},
+	}
+
+	for _, d := range testdata {
+		out, err := k.FormatQuantity(d.args...)
+		require.NoError(t, err)
+		assert.Equal(t, d.expected, out, d.args)
+	}
+
+	_, err := k.FormatQuantity("Bogus", 1)
+	require.ErrorContains(t, err, "unknown format")
+
+	_, err = k.FormatQuantity(math.NaN())
+	require.ErrorContains(t, err, "expected a number or quantity")
+
+	_, err = k.FormatQuantity()
+	require.Error(t, err)
+}
+
+func TestK8sMatchSelector(t *testing.T) {
+	t.Parallel()
+
+	k := K8sFuncs{}
+	labels := map[string]interface{}{"app": "web", "tier": "frontend"}
+
+	ok, err := k.MatchSelector("app=web,tier in (frontend,backend)", labels)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = k.MatchSelector("app=web,!tier", labels)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = k.MatchSelector(map[string]interface{}{"app": "web"}, labels)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = k.MatchSelector(map[string]string{"app": "api"}, labels)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = k.MatchSelector("!app", nil)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = k.MatchSelector("app in (web", labels)
+	require.Error(t, err)
+
+	_, err = k.MatchSelector(42, labels)
+	require.ErrorContains(t, err, "selector must be a string or map")
+
+	_, err = k.MatchSelector("app", []string{"app"})
+	require.ErrorContains(t, err, "labels must be a map")
+}
+
+func TestK8sNames(t *testing.T) {
+	t.Parallel()
+
+	k := K8sFuncs{}
+
+	assert.True(t, k.IsDNS1123Label("my-app"))
+	assert.False(t, k.IsDNS1123Label("my.app"))
+	assert.True(t, k.IsDNS1123Subdomain("my.app"))
+	assert.False(t, k.IsDNS1123Subdomain("My.App"))
+
+	out, err := k.SanitizeName("Feature/JIRA-123")
+	require.NoError(t, err)
+	assert.Equal(t, "feature-jira-123", out)
+
+	out, err = k.SanitizeName(7, "Feature/JIRA-123")
+	require.NoError(t, err)
+	assert.Equal(t, "feature", out)
+
+	_, err = k.SanitizeName(64, "foo")
+	require.ErrorContains(t, err, "out of range")
+
+	_, err = k.SanitizeName("a", "foo")
+	require.ErrorContains(t, err, "expected a number")
+
+	_, err = k.SanitizeName()
+	require.Error(t, err)
+}
@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/hairyhenderson/gomplate/v4/conv"
+	iconv "github.com/hairyhenderson/gomplate/v4/internal/conv"
 
 	"github.com/hairyhenderson/gomplate/v4/math"
 )
@@ -283,6 +284,11 @@ func (f MathFuncs) Seq(n ...interface{}) ([]int64, error) {
 
 // Max -
 func (f MathFuncs) Max(a interface{}, b ...interface{}) (interface{}, error) {
+	a, b, err := f.firstAndRest(a, b)
+	if err != nil {
+		return nil, err
+	}
+
 	if f.IsFloat(a) || f.containsFloat(b...) {
 		m, err := conv.ToFloat64(a)
 		if err != nil {
@@ -322,6 +328,11 @@ func (f MathFuncs) Max(a interface{}, b ...interface{}) (interface{}, error) {
 
 // Min -
 func (f MathFuncs) Min(a interface{}, b ...interface{}) (interface{}, error) {
+	a, b, err := f.firstAndRest(a, b)
+	if err != nil {
+		return nil, err
+	}
+
 	if f.IsFloat(a) || f.containsFloat(b...) {
 		m, err := conv.ToFloat64(a)
 		if err != nil {
@@ -357,6 +368,104 @@ func (f MathFuncs) Min(a interface{}, b ...interface{}) (interface{}, error) {
 	return m, nil
 }
 
+// firstAndRest flattens the arguments to Max and Min, so that they can be
+// given either as separate numbers or as arrays.
+func (f MathFuncs) firstAndRest(a interface{}, b []interface{}) (interface{}, []interface{}, error) {
+	nums := flattenNums(append([]interface{}{a}, b...))
+	if len(nums) == 0 {
+		return nil, nil, fmt.Errorf("expected at least one number")
+	}
+
+	return nums[0], nums[1:], nil
+}
+
+// flattenNums expands any arrays or slices in the arguments, so that
+// functions can be given numbers either as separate arguments or as a list
+func flattenNums(n []interface{}) []interface{} {
+	out := make([]interface{}, 0, len(n))
+	for _, v := range n {
+		if _, ok := v.(string); !ok {
+			if s, err := iconv.InterfaceSlice(v); err == nil {
+				out = append(out, flattenNums(s)...)
+				continue
+			}
+		}
+
+		out = append(out, v)
+	}
+
+	return out
+}
+
+// statsInput converts the arguments to a non-empty list of floats, for the
+// statistics functions
+func statsInput(n []interface{}) ([]float64, error) {
+	nums, err := conv.ToFloat64s(flattenNums(n)...)
+	if err != nil {
+		return nil, fmt.Errorf("expected number inputs: %w", err)
+	}
+
+	if len(nums) == 0 {
+		return nil, fmt.Errorf("expected at least one number")
+	}
+
+	return nums, nil
+}
+
+// Sum -
+func (f MathFuncs) Sum(n ...interface{}) (interface{}, error) {
+	return f.Add(flattenNums(n)...)
+}
+
+// Mean -
+func (f MathFuncs) Mean(n ...interface{}) (float64, error) {
+	nums, err := statsInput(n)
+	if err != nil {
+		return 0, err
+	}
+
+	return math.Mean(nums), nil
+}
+
+// Median -
+func (f MathFuncs) Median(n ...interface{}) (float64, error) {
+	nums, err := statsInput(n)
+	if err != nil {
+		return 0, err
+	}
+
+	return math.Median(nums), nil
+}
+
+// StdDev -
+func (f MathFuncs) StdDev(n ...interface{}) (float64, error) {
+	nums, err := statsInput(n)
+	if err != nil {
+		return 0, err
+	}
+
+	return math.StdDev(nums), nil
+}
+
+// Percentile -
+func (f MathFuncs) Percentile(p interface{}, n ...interface{}) (float64, error) {
+	pf, err := conv.ToFloat64(p)
+	if err != nil {
+		return 0, fmt.Errorf("percentile must be a number: %w", err)
+	}
+
+	if pf < 0 || pf > 100 {
+		return 0, fmt.Errorf("percentile must be between 0 and 100, got %v", p)
+	}
+
+	nums, err := statsInput(n)
+	if err != nil {
+		return 0, err
+	}
+
+	return math.Percentile(pf, nums), nil
+}
+
 // Ceil -
 func (f MathFuncs) Ceil(n interface{}) (interface{}, error) {
 	in, err := conv.ToFloat64(n)
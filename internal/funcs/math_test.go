@@ -524,3 +524,94 @@ func TestAbs(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestMaxMinSlices(t *testing.T) {
+	t.Parallel()
+
+	m := MathFuncs{}
+
+	actual, err := m.Max([]interface{}{1, 5, 3})
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), actual)
+
+	actual, err = m.Max([]int{1, 5}, 7.5)
+	require.NoError(t, err)
+	assert.InDelta(t, 7.5, actual, 1e-12)
+
+	actual, err = m.Min([]string{"4", "2", "8"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), actual)
+
+	_, err = m.Max([]interface{}{})
+	require.Error(t, err)
+
+	_, err = m.Min([]interface{}{})
+	require.Error(t, err)
+}
+
+func TestSum(t *testing.T) {
+	t.Parallel()
+
+	m := MathFuncs{}
+
+	actual, err := m.Sum([]interface{}{1, 2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), actual)
+
+	actual, err = m.Sum([]float64{1.5, 2.5}, 1)
+	require.NoError(t, err)
+	assert.InDelta(t, 5.0, actual, 1e-12)
+
+	actual, err = m.Sum([]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), actual)
+
+	_, err = m.Sum([]interface{}{1, "foo"})
+	require.Error(t, err)
+}
+
+func TestStats(t *testing.T) {
+	t.Parallel()
+
+	m := MathFuncs{}
+	nums := []interface{}{2, 4, "4", 4, 5, 5, 7, 9.0}
+
+	actual, err := m.Mean(nums)
+	require.NoError(t, err)
+	assert.InDelta(t, 5.0, actual, 1e-12)
+
+	actual, err = m.Mean(1, 2)
+	require.NoError(t, err)
+	assert.InDelta(t, 1.5, actual, 1e-12)
+
+	actual, err = m.Median(nums)
+	require.NoError(t, err)
+	assert.InDelta(t, 4.5, actual, 1e-12)
+
+	actual, err = m.StdDev(nums)
+	require.NoError(t, err)
+	assert.InDelta(t, 2.0, actual, 1e-12)
+
+	actual, err = m.Percentile(95, []int{15, 20, 35, 40, 50})
+	require.NoError(t, err)
+	assert.InDelta(t, 48.0, actual, 1e-12)
+
+	actual, err = m.Percentile("50", []int{15, 20, 35, 40, 50})
+	require.NoError(t, err)
+	assert.InDelta(t, 35.0, actual, 1e-12)
+
+	_, err = m.Mean([]interface{}{})
+	require.Error(t, err)
+
+	_, err = m.Median()
+	require.Error(t, err)
+
+	_, err = m.StdDev([]interface{}{"foo"})
+	require.Error(t, err)
+
+	_, err = m.Percentile(101, nums)
+	require.Error(t, err)
+
+	_, err = m.Percentile("foo", nums)
+	require.Error(t, err)
+}
@@ -6,8 +6,11 @@ import (
 	"math/big"
 	stdnet "net"
 	"net/netip"
+	"strings"
+	"sync"
 
 	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/env"
 	"github.com/hairyhenderson/gomplate/v4/internal/cidr"
 	"github.com/hairyhenderson/gomplate/v4/internal/deprecated"
 	"github.com/hairyhenderson/gomplate/v4/net"
@@ -17,7 +20,7 @@ import (
 
 // CreateNetFuncs -
 func CreateNetFuncs(ctx context.Context) map[string]interface{} {
-	ns := &NetFuncs{ctx}
+	ns := &NetFuncs{ctx: ctx, resolvers: &resolverCache{}}
 	return map[string]interface{}{
 		"net": func() interface{} { return ns },
 	}
@@ -26,36 +29,141 @@ func CreateNetFuncs(ctx context.Context) map[string]interface{} {
 // NetFuncs -
 type NetFuncs struct {
 	ctx context.Context
+
+	// resolvers caches DNS lookups for the duration of the render
+	resolvers *resolverCache
+}
+
+// resolverCache holds a resolver (and so a cache of lookup results) for each
+// DNS server
+type resolverCache struct {
+	m  map[string]*net.Resolver
+	mu sync.Mutex
 }
 
 // LookupIP -
-func (f NetFuncs) LookupIP(name interface{}) (string, error) {
-	return net.LookupIP(conv.ToString(name))
+func (f NetFuncs) LookupIP(args ...interface{}) (string, error) {
+	ips, err := f.LookupIPs(args...)
+	if err != nil || len(ips) == 0 {
+		return "", err
+	}
+
+	return ips[0], nil
 }
 
 // LookupIPs -
-func (f NetFuncs) LookupIPs(name interface{}) ([]string, error) {
-	return net.LookupIPs(conv.ToString(name))
+func (f NetFuncs) LookupIPs(args ...interface{}) ([]string, error) {
+	r, name, err := f.lookupArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.LookupIPs(f.ctx, name)
 }
 
 // LookupCNAME -
-func (f NetFuncs) LookupCNAME(name interface{}) (string, error) {
-	return net.LookupCNAME(conv.ToString(name))
+func (f NetFuncs) LookupCNAME(args ...interface{}) (string, error) {
+	r, name, err := f.lookupArgs(args)
+	if err != nil {
+		return "", err
+	}
+
+	return r.LookupCNAME(f.ctx, name)
 }
 
 // LookupSRV -
-func (f NetFuncs) LookupSRV(name interface{}) (*stdnet.SRV, error) {
-	return net.LookupSRV(conv.ToString(name))
+func (f NetFuncs) LookupSRV(args ...interface{}) (*stdnet.SRV, error) {
+	srvs, err := f.LookupSRVs(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(srvs) == 0 {
+		return nil, fmt.Errorf("no SRV records found")
+	}
+
+	return srvs[0], nil
 }
 
 // LookupSRVs -
-func (f NetFuncs) LookupSRVs(name interface{}) ([]*stdnet.SRV, error) {
-	return net.LookupSRVs(conv.ToString(name))
+func (f NetFuncs) LookupSRVs(args ...interface{}) ([]*stdnet.SRV, error) {
+	r, name, err := f.lookupArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.LookupSRVs(f.ctx, name)
 }
 
 // LookupTXT -
-func (f NetFuncs) LookupTXT(name interface{}) ([]string, error) {
-	return net.LookupTXT(conv.ToString(name))
+func (f NetFuncs) LookupTXT(args ...interface{}) ([]string, error) {
+	r, name, err := f.lookupArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.LookupTXT(f.ctx, name)
+}
+
+// LookupMX -
+func (f NetFuncs) LookupMX(args ...interface{}) ([]*stdnet.MX, error) {
+	r, name, err := f.lookupArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.LookupMX(f.ctx, name)
+}
+
+// lookupArgs parses the arguments to the Lookup functions - an optional DNS
+// server, and the name to look up. The DNS server defaults to the value of
+// $GOMPLATE_DNS_SERVER, or the system's configured servers.
+func (f NetFuncs) lookupArgs(args []interface{}) (*net.Resolver, string, error) {
+	var server string
+
+	switch len(args) {
+	case 1:
+		server = env.Getenv("GOMPLATE_DNS_SERVER")
+	case 2:
+		server = conv.ToString(args[0])
+	default:
+		return nil, "", fmt.Errorf("wrong number of args: want 1 or 2, got %d", len(args))
+	}
+
+	r, err := f.resolver(server)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return r, conv.ToString(args[len(args)-1]), nil
+}
+
+// resolver returns the resolver for the DNS server, creating it if necessary
+func (f NetFuncs) resolver(server string) (*net.Resolver, error) {
+	if f.resolvers == nil {
+		// no caching
+		return net.NewResolver(server)
+	}
+
+	f.resolvers.mu.Lock()
+	defer f.resolvers.mu.Unlock()
+
+	if r, ok := f.resolvers.m[server]; ok {
+		return r, nil
+	}
+
+	r, err := net.NewResolver(server)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.resolvers.m == nil {
+		f.resolvers.m = map[string]*net.Resolver{}
+	}
+
+	f.resolvers.m[server] = r
+
+	return r, nil
 }
 
 // ParseIP -
@@ -271,3 +379,215 @@ func (f *NetFuncs) CIDRSubnetSizes(args ...interface{}) ([]netip.Prefix, error)
 
 	return retValues, nil
 }
+
+// maxNetAddrs limits the number of addresses or subnets returned by Hosts and
+// Subnets, to avoid accidentally exhausting memory
+const maxNetAddrs = 1 << 16
+
+// Subnets -
+// Experimental!
+func (f *NetFuncs) Subnets(prefixLen interface{}, prefix interface{}) ([]netip.Prefix, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return nil, err
+	}
+
+	network, err := f.parseNetipPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	network = network.Masked()
+
+	bits, err := conv.ToInt(strings.TrimPrefix(conv.ToString(prefixLen), "/"))
+	if err != nil {
+		return nil, fmt.Errorf("prefix length must be a number: %w", err)
+	}
+
+	if bits < network.Bits() || bits > network.Addr().BitLen() {
+		return nil, fmt.Errorf("prefix length must be between %d and %d, got %d",
+			network.Bits(), network.Addr().BitLen(), bits)
+	}
+
+	newBits := bits - network.Bits()
+	if newBits > 16 {
+		return nil, fmt.Errorf("too many subnets: splitting %s into /%d subnets would produce more than %d", network, bits, maxNetAddrs)
+	}
+
+	out := make([]netip.Prefix, 1<<newBits)
+	for i := range out {
+		out[i], err = cidr.SubnetBig(network, newBits, big.NewInt(int64(i)))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// Hosts -
+// Experimental!
+func (f *NetFuncs) Hosts(in interface{}) ([]netip.Addr, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return nil, err
+	}
+
+	r, err := f.parseHostRange(in)
+	if err != nil {
+		return nil, err
+	}
+
+	size := cidr.RangeSize(r)
+	if size.Cmp(big.NewInt(maxNetAddrs)) > 0 {
+		return nil, fmt.Errorf("too many addresses: %s contains %s, more than %d", r, size, maxNetAddrs)
+	}
+
+	out := make([]netip.Addr, 0, size.Int64())
+	for addr := r.From(); addr.IsValid() && addr.Compare(r.To()) <= 0; addr = addr.Next() {
+		out = append(out, addr)
+	}
+
+	return out, nil
+}
+
+// NthHost -
+// Experimental!
+func (f *NetFuncs) NthHost(n interface{}, in interface{}) (netip.Addr, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return netip.Addr{}, err
+	}
+
+	r, err := f.parseHostRange(in)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+
+	num, err := conv.ToInt64(n)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("expected a number: %w", err)
+	}
+
+	if num == 0 {
+		return netip.Addr{}, fmt.Errorf("host number must not be 0: the first host is 1, and the last is -1")
+	}
+
+	// hosts are numbered from 1, and negative numbers count back from -1
+	offset := big.NewInt(num)
+	if num > 0 {
+		offset.Sub(offset, big.NewInt(1))
+	}
+
+	addr, ok := cidr.RangeAddr(r, offset)
+	if !ok {
+		return netip.Addr{}, fmt.Errorf("%s does not contain a host numbered %d", r, num)
+	}
+
+	return addr, nil
+}
+
+// Contains -
+// Experimental!
+func (f *NetFuncs) Contains(network interface{}, in interface{}) (bool, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return false, err
+	}
+
+	outer, err := f.parseNetipRange(network)
+	if err != nil {
+		return false, err
+	}
+
+	inner, err := f.parseNetipRange(in)
+	if err != nil {
+		return false, err
+	}
+
+	if outer.From().Is4() != inner.From().Is4() {
+		return false, nil
+	}
+
+	return outer.From().Compare(inner.From()) <= 0 && outer.To().Compare(inner.To()) >= 0, nil
+}
+
+// Overlaps -
+// Experimental!
+func (f *NetFuncs) Overlaps(a interface{}, b interface{}) (bool, error) {
+	if err := checkExperimental(f.ctx); err != nil {
+		return false, err
+	}
+
+	ra, err := f.parseNetipRange(a)
+	if err != nil {
+		return false, err
+	}
+
+	rb, err := f.parseNetipRange(b)
+	if err != nil {
+		return false, err
+	}
+
+	return ra.Overlaps(rb), nil
+}
+
+// parseNetipRange parses an address, a prefix, or a range into a range
+func (f *NetFuncs) parseNetipRange(in interface{}) (netipx.IPRange, error) {
+	r, _, err := f.parseNetipRangeOrPrefix(in)
+
+	return r, err
+}
+
+// parseNetipRangeOrPrefix parses an address, a prefix, or a range into a
+// range, also returning the prefix when the input was one
+func (f *NetFuncs) parseNetipRangeOrPrefix(in interface{}) (netipx.IPRange, netip.Prefix, error) {
+	switch v := in.(type) {
+	case netipx.IPRange:
+		return v, netip.Prefix{}, nil
+	case netip.Addr:
+		return netipx.IPRangeFrom(v, v), netip.Prefix{}, nil
+	case netip.Prefix, *stdnet.IPNet, netaddr.IPPrefix:
+		p, err := f.parseNetipPrefix(v)
+		if err != nil {
+			return netipx.IPRange{}, netip.Prefix{}, err
+		}
+
+		return netipx.RangeOfPrefix(p), p, nil
+	}
+
+	s := conv.ToString(in)
+
+	switch {
+	case strings.Contains(s, "-"):
+		r, err := netipx.ParseIPRange(s)
+
+		return r, netip.Prefix{}, err
+	case strings.Contains(s, "/"):
+		p, err := netip.ParsePrefix(s)
+		if err != nil {
+			return netipx.IPRange{}, netip.Prefix{}, err
+		}
+
+		return netipx.RangeOfPrefix(p), p, nil
+	default:
+		addr, err := netip.ParseAddr(s)
+		if err != nil {
+			return netipx.IPRange{}, netip.Prefix{}, err
+		}
+
+		return netipx.IPRangeFrom(addr, addr), netip.Prefix{}, nil
+	}
+}
+
+// parseHostRange parses a prefix or a range into the range of its usable host
+// addresses - for IPv4 prefixes of /30 or larger, the network and broadcast
+// addresses are excluded
+func (f *NetFuncs) parseHostRange(in interface{}) (netipx.IPRange, error) {
+	r, p, err := f.parseNetipRangeOrPrefix(in)
+	if err != nil {
+		return netipx.IPRange{}, err
+	}
+
+	if p.IsValid() && p.Addr().Is4() && p.Bits() <= 30 {
+		r = netipx.IPRangeFrom(r.From().Next(), r.To().Prev())
+	}
+
+	return r, nil
+}
@@ -33,7 +33,7 @@ func TestCreateNetFuncs(t *testing.T) {
 func TestNetLookupIP(t *testing.T) {
 	t.Parallel()
 
-	n := NetFuncs{}
+	n := NetFuncs{ctx: context.Background()}
 	assert.Equal(t, "127.0.0.1", must(n.LookupIP("localhost")))
 }
 
@@ -265,3 +265,179 @@ func TestCIDRSubnetSizes(t *testing.T) {
 	assert.Equal(t, "2016:1234:5678:9abc:c800::/72", subnets[6].String())
 	assert.Equal(t, "2016:1234:5678:9abc:c900::/74", subnets[7].String())
 }
+
+func TestSubnets(t *testing.T) {
+	n := testNetNS()
+
+	subnets, err := n.Subnets(18, "10.0.0.0/16")
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/18"),
+		netip.MustParsePrefix("10.0.64.0/18"),
+		netip.MustParsePrefix("10.0.128.0/18"),
+		netip.MustParsePrefix("10.0.192.0/18"),
+	}, subnets)
+
+	// the prefix is masked, and the length can be given like "/17"
+	subnets, err = n.Subnets("/17", netip.MustParsePrefix("10.0.1.2/16"))
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/17"),
+		netip.MustParsePrefix("10.0.128.0/17"),
+	}, subnets)
+
+	subnets, err = n.Subnets(16, "10.0.0.0/16")
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Prefix{netip.MustParsePrefix("10.0.0.0/16")}, subnets)
+
+	subnets, err = n.Subnets(64, "2001:db8::/56")
+	require.NoError(t, err)
+	assert.Len(t, subnets, 256)
+	assert.Equal(t, "2001:db8:0:ff::/64", subnets[255].String())
+
+	_, err = n.Subnets(8, "10.0.0.0/16")
+	require.Error(t, err)
+
+	_, err = n.Subnets(33, "10.0.0.0/16")
+	require.Error(t, err)
+
+	_, err = n.Subnets(32, "10.0.0.0/8")
+	require.Error(t, err)
+
+	_, err = n.Subnets("x", "10.0.0.0/8")
+	require.Error(t, err)
+}
+
+func TestHosts(t *testing.T) {
+	n := testNetNS()
+
+	hosts, err := n.Hosts("192.168.0.0/29")
+	require.NoError(t, err)
+	assert.Len(t, hosts, 6)
+	assert.Equal(t, "192.168.0.1", hosts[0].String())
+	assert.Equal(t, "192.168.0.6", hosts[5].String())
+
+	hosts, err = n.Hosts("192.168.0.0/31")
+	require.NoError(t, err)
+	assert.Len(t, hosts, 2)
+
+	// ranges include all addresses, even when they're equivalent to a prefix
+	hosts, err = n.Hosts("192.168.0.0-192.168.0.7")
+	require.NoError(t, err)
+	assert.Len(t, hosts, 8)
+
+	hosts, err = n.Hosts("2001:db8::/126")
+	require.NoError(t, err)
+	assert.Len(t, hosts, 4)
+	assert.Equal(t, "2001:db8::", hosts[0].String())
+
+	hosts, err = n.Hosts("255.255.255.254-255.255.255.255")
+	require.NoError(t, err)
+	assert.Len(t, hosts, 2)
+
+	_, err = n.Hosts("10.0.0.0/8")
+	require.Error(t, err)
+
+	_, err = n.Hosts("bogus")
+	require.Error(t, err)
+}
+
+func TestNthHost(t *testing.T) {
+	n := testNetNS()
+
+	testdata := []struct {
+		n    int
+		in   interface{}
+		want string
+	}{
+		{1, "10.0.0.0/24", "10.0.0.1"},
+		{10, "10.0.0.0/24", "10.0.0.10"},
+		{-1, "10.0.0.0/24", "10.0.0.254"},
+		{254, "10.0.0.0/24", "10.0.0.254"},
+		{1, netip.MustParsePrefix("10.0.0.0/24"), "10.0.0.1"},
+		{1, "10.0.0.10-10.0.0.20", "10.0.0.10"},
+		{-1, "10.0.0.10-10.0.0.20", "10.0.0.20"},
+		{-1, "2001:db8::/32", "2001:db8:ffff:ffff:ffff:ffff:ffff:ffff"},
+	}
+
+	for _, d := range testdata {
+		addr, err := n.NthHost(d.n, d.in)
+		require.NoError(t, err)
+		assert.Equal(t, d.want, addr.String())
+	}
+
+	_, err := n.NthHost(0, "10.0.0.0/24")
+	require.Error(t, err)
+
+	_, err = n.NthHost(255, "10.0.0.0/24")
+	require.Error(t, err)
+
+	_, err = n.NthHost(-255, "10.0.0.0/24")
+	require.Error(t, err)
+}
+
+func TestContainsOverlaps(t *testing.T) {
+	n := testNetNS()
+
+	testdata := []struct {
+		a, b               interface{}
+		contains, overlaps bool
+	}{
+		{"10.0.0.0/8", "10.1.2.3", true, true},
+		{"10.0.0.0/8", netip.MustParseAddr("11.0.0.0"), false, false},
+		{"10.0.0.0/8", "10.1.0.0/16", true, true},
+		{"10.1.0.0/16", "10.0.0.0/8", false, true},
+		{"10.0.0.0/16", "10.0.255.0-10.1.0.10", false, true},
+		{"10.0.0.0/16", "10.1.0.0/16", false, false},
+		{"10.0.0.0/8", "::ffff:10.0.0.1", false, false},
+		{"2001:db8::/32", "2001:db8:1::/48", true, true},
+	}
+
+	for _, d := range testdata {
+		contains, err := n.Contains(d.a, d.b)
+		require.NoError(t, err)
+		assert.Equal(t, d.contains, contains, "%v contains %v", d.a, d.b)
+
+		overlaps, err := n.Overlaps(d.a, d.b)
+		require.NoError(t, err)
+		assert.Equal(t, d.overlaps, overlaps, "%v overlaps %v", d.a, d.b)
+	}
+
+	_, err := n.Contains("bogus", "10.0.0.1")
+	require.Error(t, err)
+
+	_, err = n.Overlaps("10.0.0.0/8", "bogus")
+	require.Error(t, err)
+}
+
+func TestNetLookupResolver(t *testing.T) {
+	t.Parallel()
+
+	n := CreateNetFuncs(context.Background())["net"].(func() interface{})().(*NetFuncs)
+
+	// the hosts file is consulted before the DNS server
+	ip, err := n.LookupIP("127.0.0.1:5353", "localhost")
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1", ip)
+
+	// resolvers (and their caches) are reused for the same server
+	r1, err := n.resolver("127.0.0.1:5353")
+	require.NoError(t, err)
+
+	r2, err := n.resolver("127.0.0.1:5353")
+	require.NoError(t, err)
+	assert.Same(t, r1, r2)
+
+	r3, err := n.resolver("")
+	require.NoError(t, err)
+	assert.NotSame(t, r1, r3)
+
+	_, err = n.LookupTXT("[::1", "example.com")
+	require.Error(t, err)
+
+	_, err = n.LookupMX()
+	require.Error(t, err)
+
+	_, err = n.LookupMX("a", "b", "c")
+	require.Error(t, err)
+}
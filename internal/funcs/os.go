@@ -0,0 +1,82 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"os/user"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+)
+
+// CreateOSFuncs -
+func CreateOSFuncs(ctx context.Context) map[string]interface{} {
+	ns := &OSFuncs{ctx}
+
+	return map[string]interface{}{
+		"os": func() interface{} { return ns },
+	}
+}
+
+// OSFuncs -
+type OSFuncs struct {
+	ctx context.Context
+}
+
+// User - the current user
+func (OSFuncs) User() (*user.User, error) {
+	return user.Current()
+}
+
+// LookupUser -
+func (OSFuncs) LookupUser(username interface{}) (*user.User, error) {
+	return user.Lookup(conv.ToString(username))
+}
+
+// LookupUID -
+func (OSFuncs) LookupUID(uid interface{}) (*user.User, error) {
+	return user.LookupId(conv.ToString(uid))
+}
+
+// LookupGroup -
+func (OSFuncs) LookupGroup(name interface{}) (*user.Group, error) {
+	return user.LookupGroup(conv.ToString(name))
+}
+
+// LookupGID -
+func (OSFuncs) LookupGID(gid interface{}) (*user.Group, error) {
+	return user.LookupGroupId(conv.ToString(gid))
+}
+
+// Groups - the groups the given user (or the current user) is a member of
+func (OSFuncs) Groups(args ...interface{}) ([]*user.Group, error) {
+	var u *user.User
+	var err error
+
+	switch len(args) {
+	case 0:
+		u, err = user.Current()
+	case 1:
+		u, err = user.Lookup(conv.ToString(args[0]))
+	default:
+		return nil, fmt.Errorf("wrong number of args: want 0 or 1, got %d", len(args))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	gids, err := u.GroupIds()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups for %s: %w", u.Username, err)
+	}
+
+	groups := make([]*user.Group, len(gids))
+	for i, gid := range gids {
+		groups[i], err = user.LookupGroupId(gid)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return groups, nil
+}
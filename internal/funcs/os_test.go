@@ -0,0 +1,88 @@
+package funcs
+
+import (
+	"context"
+	"os/user"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateOSFuncs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateOSFuncs(ctx)
+			actual := fmap["os"].(func() interface{})
+
+			assert.Equal(t, ctx, actual().(*OSFuncs).ctx)
+		})
+	}
+}
+
+func TestOSUsers(t *testing.T) {
+	t.Parallel()
+
+	expected, err := user.Current()
+	require.NoError(t, err)
+
+	o := OSFuncs{}
+
+	u, err := o.User()
+	require.NoError(t, err)
+	assert.Equal(t, expected, u)
+
+	u, err = o.LookupUser(expected.Username)
+	require.NoError(t, err)
+	assert.Equal(t, expected.Uid, u.Uid)
+
+	u, err = o.LookupUID(expected.Uid)
+	require.NoError(t, err)
+	assert.Equal(t, expected.Username, u.Username)
+
+	_, err = o.LookupUser("no-such-user-hopefully")
+	require.Error(t, err)
+}
+
+func TestOSGroups(t *testing.T) {
+	t.Parallel()
+
+	current, err := user.Current()
+	require.NoError(t, err)
+
+	expected, err := user.LookupGroupId(current.Gid)
+	if err != nil {
+		t.Skipf("primary group not found: %v", err)
+	}
+
+	o := OSFuncs{}
+
+	g, err := o.LookupGID(current.Gid)
+	require.NoError(t, err)
+	assert.Equal(t, expected, g)
+
+	g, err = o.LookupGroup(expected.Name)
+	require.NoError(t, err)
+	assert.Equal(t, expected.Gid, g.Gid)
+
+	groups, err := o.Groups()
+	require.NoError(t, err)
+	assert.Contains(t, groups, expected)
+
+	groups, err = o.Groups(current.Username)
+	require.NoError(t, err)
+	assert.Contains(t, groups, expected)
+
+	_, err = o.Groups("a", "b")
+	require.Error(t, err)
+
+	_, err = o.LookupGroup("no-such-group-hopefully")
+	require.Error(t, err)
+}
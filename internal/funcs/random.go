@@ -3,17 +3,25 @@ package funcs
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"unicode/utf8"
 
 	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
 	iconv "github.com/hairyhenderson/gomplate/v4/internal/conv"
 	"github.com/hairyhenderson/gomplate/v4/random"
 )
 
 // CreateRandomFuncs -
 func CreateRandomFuncs(ctx context.Context) map[string]interface{} {
-	ns := &RandomFuncs{ctx}
+	ns := &RandomFuncs{ctx: ctx}
+
+	// with a seed, the same values are generated on every run
+	if seed, ok := config.RandomSeed(ctx); ok {
+		ns.gen = random.NewSeededGenerator(seed)
+	}
+
 	return map[string]interface{}{
 		"random": func() interface{} { return ns },
 	}
@@ -22,40 +30,41 @@ func CreateRandomFuncs(ctx context.Context) map[string]interface{} {
 // RandomFuncs -
 type RandomFuncs struct {
 	ctx context.Context
+	gen *random.Generator
 }
 
 // ASCII -
-func (RandomFuncs) ASCII(count interface{}) (string, error) {
+func (f RandomFuncs) ASCII(count interface{}) (string, error) {
 	n, err := conv.ToInt(count)
 	if err != nil {
 		return "", fmt.Errorf("count must be an integer: %w", err)
 	}
 
-	return random.StringBounds(n, ' ', '~')
+	return f.gen.StringBounds(n, ' ', '~')
 }
 
 // Alpha -
-func (RandomFuncs) Alpha(count interface{}) (string, error) {
+func (f RandomFuncs) Alpha(count interface{}) (string, error) {
 	n, err := conv.ToInt(count)
 	if err != nil {
 		return "", fmt.Errorf("count must be an integer: %w", err)
 	}
 
-	return random.StringRE(n, "[[:alpha:]]")
+	return f.gen.StringRE(n, "[[:alpha:]]")
 }
 
 // AlphaNum -
-func (RandomFuncs) AlphaNum(count interface{}) (string, error) {
+func (f RandomFuncs) AlphaNum(count interface{}) (string, error) {
 	n, err := conv.ToInt(count)
 	if err != nil {
 		return "", fmt.Errorf("count must be an integer: %w", err)
 	}
 
-	return random.StringRE(n, "[[:alnum:]]")
+	return f.gen.StringRE(n, "[[:alnum:]]")
 }
 
 // String -
-func (RandomFuncs) String(count interface{}, args ...interface{}) (string, error) {
+func (f RandomFuncs) String(count interface{}, args ...interface{}) (string, error) {
 	c, err := conv.ToInt(count)
 	if err != nil {
 		return "", fmt.Errorf("count must be an integer: %w", err)
@@ -92,10 +101,10 @@ func (RandomFuncs) String(count interface{}, args ...interface{}) (string, error
 			l, u = rune(nl), rune(nu)
 		}
 
-		return random.StringBounds(c, l, u)
+		return f.gen.StringBounds(c, l, u)
 	}
 
-	return random.StringRE(c, m)
+	return f.gen.StringRE(c, m)
 }
 
 func isString(s interface{}) bool {
@@ -131,16 +140,16 @@ func toCodePoints(l, u string) (rune, rune, error) {
 }
 
 // Item -
-func (RandomFuncs) Item(items interface{}) (interface{}, error) {
+func (f RandomFuncs) Item(items interface{}) (interface{}, error) {
 	i, err := iconv.InterfaceSlice(items)
 	if err != nil {
 		return nil, err
 	}
-	return random.Item(i)
+	return f.gen.Item(i)
 }
 
 // Number -
-func (RandomFuncs) Number(args ...interface{}) (int64, error) {
+func (f RandomFuncs) Number(args ...interface{}) (int64, error) {
 	var nMin, nMax int64
 	nMin, nMax = 0, 100
 
@@ -165,11 +174,11 @@ func (RandomFuncs) Number(args ...interface{}) (int64, error) {
 		}
 	}
 
-	return random.Number(nMin, nMax)
+	return f.gen.Number(nMin, nMax)
 }
 
 // Float -
-func (RandomFuncs) Float(args ...interface{}) (float64, error) {
+func (f RandomFuncs) Float(args ...interface{}) (float64, error) {
 	var nMin, nMax float64
 	nMin, nMax = 0, 1.0
 
@@ -194,5 +203,169 @@ func (RandomFuncs) Float(args ...interface{}) (float64, error) {
 		}
 	}
 
-	return random.Float(nMin, nMax)
+	return f.gen.Float(nMin, nMax)
+}
+
+// WeightedChoice -
+func (f RandomFuncs) WeightedChoice(args ...interface{}) (interface{}, error) {
+	var items []interface{}
+	var weights []float64
+
+	switch len(args) {
+	case 1:
+		m, ok := args[0].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected a map of items to weights, got %T", args[0])
+		}
+
+		// sort the keys so that seeded choices are reproducible
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			w, err := conv.ToFloat64(m[k])
+			if err != nil {
+				return nil, fmt.Errorf("weight for %q must be a number: %w", k, err)
+			}
+
+			items = append(items, k)
+			weights = append(weights, w)
+		}
+	case 2:
+		var err error
+
+		items, err = iconv.InterfaceSlice(args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		ws, err := iconv.InterfaceSlice(args[1])
+		if err != nil {
+			return nil, err
+		}
+
+		for i, w := range ws {
+			fw, err := conv.ToFloat64(w)
+			if err != nil {
+				return nil, fmt.Errorf("weight %d must be a number: %w", i, err)
+			}
+
+			weights = append(weights, fw)
+		}
+	default:
+		return nil, fmt.Errorf("wrong number of args: want 1 or 2, got %d", len(args))
+	}
+
+	return f.gen.WeightedChoice(items, weights)
+}
+
+// Shuffle -
+func (f RandomFuncs) Shuffle(items interface{}) ([]interface{}, error) {
+	i, err := iconv.InterfaceSlice(items)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.gen.Shuffle(i), nil
+}
+
+// NanoID -
+func (RandomFuncs) NanoID(args ...interface{}) (string, error) {
+	size := 21
+	alphabet := ""
+
+	var err error
+
+	switch len(args) {
+	case 0:
+	case 1, 2:
+		size, err = conv.ToInt(args[0])
+		if err != nil {
+			return "", fmt.Errorf("size must be an integer: %w", err)
+		}
+
+		if len(args) == 2 {
+			alphabet = conv.ToString(args[1])
+		}
+	default:
+		return "", fmt.Errorf("wrong number of args: want 0, 1, or 2, got %d", len(args))
+	}
+
+	return random.NanoID(size, alphabet)
+}
+
+// Password -
+func (RandomFuncs) Password(length interface{}, args ...interface{}) (string, error) {
+	n, err := conv.ToInt(length)
+	if err != nil {
+		return "", fmt.Errorf("length must be an integer: %w", err)
+	}
+
+	policy := random.PasswordPolicy{}
+
+	switch len(args) {
+	case 0:
+	case 1:
+		m, ok := args[0].(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("expected a map of policy options, got %T", args[0])
+		}
+
+		policy, err = passwordPolicy(m)
+		if err != nil {
+			return "", err
+		}
+	default:
+		return "", fmt.Errorf("wrong number of args: want 1 or 2, got %d", len(args)+1)
+	}
+
+	return random.Password(n, policy)
+}
+
+// passwordPolicy converts a map of options to a PasswordPolicy. The character
+// classes all default to a minimum of 1 - they can be set to a different
+// minimum, or to false to exclude them.
+func passwordPolicy(m map[string]interface{}) (random.PasswordPolicy, error) {
+	policy := random.PasswordPolicy{
+		Min: map[string]int{"lower": 1, "upper": 1, "digits": 1, "symbols": 1},
+	}
+
+	for k, v := range m {
+		switch k {
+		case "lower", "upper", "digits", "symbols":
+			if b, ok := v.(bool); ok {
+				if b {
+					policy.Min[k] = 1
+				} else {
+					delete(policy.Min, k)
+				}
+
+				continue
+			}
+
+			n, err := conv.ToInt(v)
+			if err != nil {
+				return policy, fmt.Errorf("%s must be a minimum number of characters, or false: %w", k, err)
+			}
+
+			policy.Min[k] = n
+		case "symbolChars":
+			policy.Symbols = conv.ToString(v)
+		case "exclude":
+			policy.Exclude = conv.ToString(v)
+		case "words":
+			policy.Words = conv.ToBool(v)
+		case "separator":
+			policy.Separator = conv.ToString(v)
+		case "wordList":
+			policy.WordList = conv.ToString(v)
+		default:
+			return policy, fmt.Errorf("unknown password policy option %q", k)
+		}
+	}
+
+	return policy, nil
 }
@@ -6,6 +6,8 @@ import (
 	"testing"
 	"unicode/utf8"
 
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -221,3 +223,127 @@ func TestFloat(t *testing.T) {
 	require.NoError(t, err)
 	assert.InDelta(t, 0, n, 500)
 }
+
+func TestNanoID(t *testing.T) {
+	t.Parallel()
+
+	f := RandomFuncs{}
+
+	id, err := f.NanoID()
+	require.NoError(t, err)
+	assert.Regexp(t, "^[A-Za-z0-9_-]{21}$", id)
+
+	id, err = f.NanoID("10")
+	require.NoError(t, err)
+	assert.Len(t, id, 10)
+
+	id, err = f.NanoID(6, "0123456789")
+	require.NoError(t, err)
+	assert.Regexp(t, "^[0-9]{6}$", id)
+
+	_, err = f.NanoID("ten")
+	require.Error(t, err)
+
+	_, err = f.NanoID(1, 2, 3)
+	require.Error(t, err)
+}
+
+func TestSeededRandom(t *testing.T) {
+	t.Parallel()
+
+	ctx := config.WithRandomSeed(context.Background(), "abc")
+
+	a := CreateRandomFuncs(ctx)["random"].(func() interface{})().(*RandomFuncs)
+	b := CreateRandomFuncs(ctx)["random"].(func() interface{})().(*RandomFuncs)
+
+	sa, err := a.AlphaNum(20)
+	require.NoError(t, err)
+	sb, err := b.AlphaNum(20)
+	require.NoError(t, err)
+	assert.Equal(t, sa, sb)
+
+	ia, err := a.Item([]string{"a", "b", "c", "d"})
+	require.NoError(t, err)
+	ib, err := b.Item([]string{"a", "b", "c", "d"})
+	require.NoError(t, err)
+	assert.Equal(t, ia, ib)
+}
+
+func TestWeightedChoice(t *testing.T) {
+	t.Parallel()
+
+	f := RandomFuncs{}
+
+	out, err := f.WeightedChoice(map[string]interface{}{"a": 0, "b": "1", "c": 0.0})
+	require.NoError(t, err)
+	assert.Equal(t, "b", out)
+
+	out, err = f.WeightedChoice([]interface{}{1, 2, 3}, []interface{}{0, 0, 5})
+	require.NoError(t, err)
+	assert.Equal(t, 3, out)
+
+	_, err = f.WeightedChoice(map[string]interface{}{"a": "heavy"})
+	require.Error(t, err)
+
+	_, err = f.WeightedChoice([]interface{}{1}, []interface{}{"heavy"})
+	require.Error(t, err)
+
+	_, err = f.WeightedChoice([]interface{}{1, 2}, []interface{}{1})
+	require.Error(t, err)
+
+	_, err = f.WeightedChoice("a")
+	require.Error(t, err)
+
+	_, err = f.WeightedChoice()
+	require.Error(t, err)
+}
+
+func TestShuffle(t *testing.T) {
+	t.Parallel()
+
+	f := RandomFuncs{}
+
+	out, err := f.Shuffle([]string{"a", "b", "c"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []interface{}{"a", "b", "c"}, out)
+
+	_, err = f.Shuffle("abc")
+	require.Error(t, err)
+}
+
+func TestPassword(t *testing.T) {
+	t.Parallel()
+
+	f := RandomFuncs{}
+
+	p, err := f.Password(16)
+	require.NoError(t, err)
+	assert.Len(t, p, 16)
+
+	p, err = f.Password("10", map[string]interface{}{
+		"lower": false, "upper": false, "symbols": false, "digits": 10,
+	})
+	require.NoError(t, err)
+	assert.Regexp(t, "^[0-9]{10}$", p)
+
+	p, err = f.Password(5, map[string]interface{}{
+		"words": true, "separator": ".",
+	})
+	require.NoError(t, err)
+	assert.Regexp(t, `^[a-z-]+(\.[a-z-]+){4}$`, p)
+
+	_, err = f.Password(8, map[string]interface{}{"symbols": "lots"})
+	require.Error(t, err)
+
+	_, err = f.Password(8, map[string]interface{}{"strength": "high"})
+	require.ErrorContains(t, err, "unknown password policy option")
+
+	_, err = f.Password(8, "strong")
+	require.Error(t, err)
+
+	_, err = f.Password(8, nil, nil)
+	require.Error(t, err)
+
+	_, err = f.Password("eight")
+	require.Error(t, err)
+}
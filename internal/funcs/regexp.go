@@ -54,6 +54,38 @@ func (ReFuncs) FindAll(args ...interface{}) ([]string, error) {
 	return regexp.FindAll(re, n, input)
 }
 
+// FindNamed -
+func (ReFuncs) FindNamed(re, input interface{}) (map[string]interface{}, error) {
+	return regexp.FindNamed(conv.ToString(re), conv.ToString(input))
+}
+
+// FindAllNamed -
+func (ReFuncs) FindAllNamed(args ...interface{}) ([]map[string]interface{}, error) {
+	re := ""
+	n := -1
+	input := ""
+
+	switch len(args) {
+	case 2:
+		re = conv.ToString(args[0])
+		input = conv.ToString(args[1])
+	case 3:
+		re = conv.ToString(args[0])
+
+		var err error
+		n, err = conv.ToInt(args[1])
+		if err != nil {
+			return nil, fmt.Errorf("n must be an integer: %w", err)
+		}
+
+		input = conv.ToString(args[2])
+	default:
+		return nil, fmt.Errorf("wrong number of args: want 2 or 3, got %d", len(args))
+	}
+
+	return regexp.FindAllNamed(re, n, input)
+}
+
 // Match -
 func (ReFuncs) Match(re, input interface{}) (bool, error) {
 	return regexp.Match(conv.ToString(re), conv.ToString(input))
@@ -64,6 +96,11 @@ func (ReFuncs) QuoteMeta(in interface{}) string {
 	return regexp.QuoteMeta(conv.ToString(in))
 }
 
+// QuoteReplacement -
+func (ReFuncs) QuoteReplacement(in interface{}) string {
+	return regexp.QuoteReplacement(conv.ToString(in))
+}
+
 // Replace -
 func (ReFuncs) Replace(re, replacement, input interface{}) (string, error) {
 	return regexp.Replace(conv.ToString(re),
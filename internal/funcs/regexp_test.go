@@ -150,3 +150,42 @@ func TestReplaceLiteral(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "hello$1 world", r)
 }
+
+func TestFindNamed(t *testing.T) {
+	t.Parallel()
+
+	re := &ReFuncs{}
+	m, err := re.FindNamed(`(?P<user>\w+)@(?P<domain>[\w.]+)`, "mail jo@example.com now")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"user": "jo", "domain": "example.com"}, m)
+
+	_, err = re.FindNamed(`[a-`, "")
+	require.Error(t, err)
+}
+
+func TestFindAllNamed(t *testing.T) {
+	t.Parallel()
+
+	re := &ReFuncs{}
+	m, err := re.FindAllNamed(`(?P<k>\w)=(?P<v>\d)`, "a=1 b=2 c=3")
+	require.NoError(t, err)
+	assert.Len(t, m, 3)
+	assert.Equal(t, map[string]interface{}{"k": "c", "v": "3"}, m[2])
+
+	m, err = re.FindAllNamed(`(?P<k>\w)=(?P<v>\d)`, "2", "a=1 b=2 c=3")
+	require.NoError(t, err)
+	assert.Len(t, m, 2)
+
+	_, err = re.FindAllNamed(`(?P<k>\w)`, "foo", "a")
+	require.Error(t, err)
+
+	_, err = re.FindAllNamed("")
+	require.Error(t, err)
+}
+
+func TestQuoteReplacement(t *testing.T) {
+	t.Parallel()
+
+	re := &ReFuncs{}
+	assert.Equal(t, "$$5", re.QuoteReplacement("$5"))
+}
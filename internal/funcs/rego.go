@@ -0,0 +1,88 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/internal/policy"
+)
+
+// CreateRegoFuncs -
+func CreateRegoFuncs(ctx context.Context) map[string]interface{} {
+	f := map[string]interface{}{}
+
+	ns := &RegoFuncs{ctx: ctx, eval: policy.NewEvaluator()}
+	f["rego"] = func() interface{} { return ns }
+
+	return f
+}
+
+// RegoFuncs -
+type RegoFuncs struct {
+	ctx  context.Context
+	eval *policy.Evaluator
+}
+
+// Eval - evaluates a Rego query against the input, optionally with the given
+// policy module(s)
+func (f *RegoFuncs) Eval(query string, args ...interface{}) (interface{}, error) {
+	var modules map[string]string
+	var input interface{}
+
+	switch len(args) {
+	case 1:
+		input = args[0]
+	case 2:
+		m, err := regoModules(args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		modules, input = m, args[1]
+	default:
+		return nil, fmt.Errorf("wrong number of args: want 2 or 3, got %d", len(args)+1)
+	}
+
+	return f.eval.Eval(f.ctx, query, modules, input)
+}
+
+// regoModules converts the module argument to a map of file names to Rego
+// source. A single module or a list of modules can be given, or a map when
+// the names matter (for example in error messages).
+func regoModules(in interface{}) (map[string]string, error) {
+	switch m := in.(type) {
+	case map[string]string:
+		return m, nil
+	case map[string]interface{}:
+		out := make(map[string]string, len(m))
+		for k, v := range m {
+			out[k] = conv.ToString(v)
+		}
+
+		return out, nil
+	case []string:
+		return regoModuleList(m), nil
+	case []interface{}:
+		return regoModuleList(conv.ToStrings(m...)), nil
+	case nil:
+		return nil, fmt.Errorf("module must not be nil")
+	default:
+		return map[string]string{"policy.rego": conv.ToString(m)}, nil
+	}
+}
+
+func regoModuleList(mods []string) map[string]string {
+	out := make(map[string]string, len(mods))
+	for i, m := range mods {
+		name := "policy.rego"
+		if i > 0 {
+			name = "policy" + strconv.Itoa(i) + ".rego"
+		}
+
+		out[name] = m
+	}
+
+	return out
+}
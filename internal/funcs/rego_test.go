@@ -0,0 +1,77 @@
+package funcs
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateRegoFuncs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateRegoFuncs(ctx)
+			actual := fmap["rego"].(func() interface{})
+
+			assert.Equal(t, ctx, actual().(*RegoFuncs).ctx)
+		})
+	}
+}
+
+func TestRegoEval(t *testing.T) {
+	t.Parallel()
+
+	r := CreateRegoFuncs(context.Background())["rego"].(func() interface{})().(*RegoFuncs)
+
+	mod := `package app
+
+default allow := false
+
+allow if input.env == "prod"
+`
+
+	out, err := r.Eval("data.app.allow", mod, map[string]interface{}{"env": "prod"})
+	require.NoError(t, err)
+	assert.Equal(t, true, out)
+
+	out, err = r.Eval("data.app.allow", []interface{}{mod}, map[string]interface{}{"env": "dev"})
+	require.NoError(t, err)
+	assert.Equal(t, false, out)
+
+	out, err = r.Eval("data.app.allow", map[string]interface{}{"app.rego": mod}, map[string]interface{}{"env": "prod"})
+	require.NoError(t, err)
+	assert.Equal(t, true, out)
+
+	out, err = r.Eval("input.a * 2", map[string]interface{}{"a": 21})
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), out)
+
+	_, err = r.Eval("data.app.allow")
+	require.Error(t, err)
+
+	_, err = r.Eval("data.app.allow", nil, nil)
+	require.Error(t, err)
+
+	_, err = r.Eval("data.app.allow", map[string]interface{}{"bad.rego": "package app\nallow if {"}, nil)
+	require.ErrorContains(t, err, "bad.rego")
+}
+
+func TestRegoModules(t *testing.T) {
+	t.Parallel()
+
+	out, err := regoModules([]string{"a", "b"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"policy.rego": "a", "policy1.rego": "b"}, out)
+
+	out, err = regoModules(map[string]string{"x.rego": "x"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"x.rego": "x"}, out)
+}
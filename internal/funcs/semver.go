@@ -2,8 +2,14 @@ package funcs
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/Masterminds/semver/v3"
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	iconv "github.com/hairyhenderson/gomplate/v4/internal/conv"
 )
 
 // CreateSemverFuncs -
@@ -38,3 +44,153 @@ func (SemverFuncs) CheckConstraint(constraint, in string) (bool, error) {
 
 	return c.Check(v), nil
 }
+
+// Parse -
+func (SemverFuncs) Parse(version interface{}) (map[string]interface{}, error) {
+	v, err := toSemver(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"major":      v.Major(),
+		"minor":      v.Minor(),
+		"patch":      v.Patch(),
+		"prerelease": v.Prerelease(),
+		"metadata":   v.Metadata(),
+		"original":   v.Original(),
+		"version":    v.String(),
+	}, nil
+}
+
+// Compare -
+func (SemverFuncs) Compare(a, b interface{}) (int, error) {
+	va, err := toSemver(a)
+	if err != nil {
+		return 0, err
+	}
+
+	vb, err := toSemver(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return va.Compare(vb), nil
+}
+
+// Satisfies -
+func (f SemverFuncs) Satisfies(constraint string, version interface{}) (bool, error) {
+	v, err := toSemver(version)
+	if err != nil {
+		return false, err
+	}
+
+	return f.CheckConstraint(constraint, v.Original())
+}
+
+// Bump -
+func (SemverFuncs) Bump(part string, version interface{}) (string, error) {
+	v, err := toSemver(version)
+	if err != nil {
+		return "", err
+	}
+
+	var out semver.Version
+
+	switch strings.ToLower(part) {
+	case "major":
+		out = v.IncMajor()
+	case "minor":
+		out = v.IncMinor()
+	case "patch":
+		out = v.IncPatch()
+	case "prerelease":
+		pre, err := bumpPrerelease(v.Prerelease())
+		if err != nil {
+			return "", err
+		}
+
+		out, err = v.SetPrerelease(pre)
+		if err != nil {
+			return "", err
+		}
+
+		out, err = out.SetMetadata("")
+		if err != nil {
+			return "", err
+		}
+	default:
+		return "", fmt.Errorf("unknown version part %q: must be one of major, minor, patch, or prerelease", part)
+	}
+
+	// keep the "v" prefix, as used in git tags
+	if strings.HasPrefix(v.Original(), "v") {
+		return "v" + out.String(), nil
+	}
+
+	return out.String(), nil
+}
+
+// Sort -
+func (SemverFuncs) Sort(list interface{}) ([]interface{}, error) {
+	items, err := iconv.InterfaceSlice(list)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]*semver.Version, len(items))
+	for i, item := range items {
+		versions[i], err = toSemver(item)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Stable(semver.Collection(versions))
+
+	out := make([]interface{}, len(versions))
+	for i, v := range versions {
+		out[i] = v.Original()
+	}
+
+	return out, nil
+}
+
+// toSemver converts a version string (or a version) to a version
+func toSemver(in interface{}) (*semver.Version, error) {
+	switch v := in.(type) {
+	case *semver.Version:
+		return v, nil
+	case semver.Version:
+		return &v, nil
+	}
+
+	s := conv.ToString(in)
+
+	v, err := semver.NewVersion(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version %q: %w", s, err)
+	}
+
+	return v, nil
+}
+
+// bumpPrerelease increments the last numeric identifier of the prerelease
+// (e.g. "beta.1" becomes "beta.2"), or appends ".1" if there isn't one
+func bumpPrerelease(pre string) (string, error) {
+	if pre == "" {
+		return "", fmt.Errorf("can't bump prerelease: version has no prerelease")
+	}
+
+	ids := strings.Split(pre, ".")
+	last := ids[len(ids)-1]
+
+	n, err := strconv.ParseUint(last, 10, 64)
+	if err != nil {
+		return pre + ".1", nil
+	}
+
+	ids[len(ids)-1] = strconv.FormatUint(n+1, 10)
+
+	return strings.Join(ids, "."), nil
+}
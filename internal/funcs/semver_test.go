@@ -60,3 +60,136 @@ func TestSemverFuncs_MatchConstraint(t *testing.T) {
 		})
 	}
 }
+
+func TestSemverFuncs_Parse(t *testing.T) {
+	t.Parallel()
+
+	s := SemverFuncs{ctx: context.Background()}
+
+	out, err := s.Parse("v1.2.3-beta.1+build.5")
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{
+		"major":      uint64(1),
+		"minor":      uint64(2),
+		"patch":      uint64(3),
+		"prerelease": "beta.1",
+		"metadata":   "build.5",
+		"original":   "v1.2.3-beta.1+build.5",
+		"version":    "1.2.3-beta.1+build.5",
+	}, out)
+
+	out, err = s.Parse("1.2")
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.0", out["version"])
+
+	_, err = s.Parse("va.b.c")
+	require.Error(t, err)
+}
+
+func TestSemverFuncs_Compare(t *testing.T) {
+	t.Parallel()
+
+	s := SemverFuncs{ctx: context.Background()}
+
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"v1.0.0", "1.0.0", 0},
+		{"1.0.0", "1.0.1", -1},
+		{"1.10.0", "1.9.0", 1},
+		{"1.0.0-beta.2", "1.0.0-beta.10", -1},
+		{"1.0.0-rc.1", "1.0.0", -1},
+		{"1.0.0+build.1", "1.0.0+build.2", 0},
+	}
+
+	for _, tt := range tests {
+		got, err := s.Compare(tt.a, tt.b)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got, "%s <=> %s", tt.a, tt.b)
+	}
+
+	v, _ := s.Semver("2.0.0")
+	got, err := s.Compare(v, "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, 1, got)
+
+	_, err = s.Compare("1.0.0", "bogus")
+	require.Error(t, err)
+}
+
+func TestSemverFuncs_Satisfies(t *testing.T) {
+	t.Parallel()
+
+	s := SemverFuncs{ctx: context.Background()}
+
+	got, err := s.Satisfies("^1.2", "1.9.0")
+	require.NoError(t, err)
+	assert.True(t, got)
+
+	got, err = s.Satisfies("~1.2", "v1.3.0")
+	require.NoError(t, err)
+	assert.False(t, got)
+
+	_, err = s.Satisfies("abc", "1.0.0")
+	require.Error(t, err)
+
+	_, err = s.Satisfies(">1.0", "abc")
+	require.Error(t, err)
+}
+
+func TestSemverFuncs_Bump(t *testing.T) {
+	t.Parallel()
+
+	s := SemverFuncs{ctx: context.Background()}
+
+	tests := []struct {
+		part, in, want string
+	}{
+		{"major", "1.2.3", "2.0.0"},
+		{"minor", "1.2.3", "1.3.0"},
+		{"patch", "1.2.3", "1.2.4"},
+		{"Patch", "v1.2.3", "v1.2.4"},
+		{"major", "v1.2.3-beta.1+build", "v2.0.0"},
+		{"patch", "1.2.3-rc.1", "1.2.3"},
+		{"prerelease", "1.2.3-beta.1", "1.2.3-beta.2"},
+		{"prerelease", "v1.2.3-alpha.9+build", "v1.2.3-alpha.10"},
+		{"prerelease", "1.2.3-rc", "1.2.3-rc.1"},
+	}
+
+	for _, tt := range tests {
+		got, err := s.Bump(tt.part, tt.in)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got, "bump %s %s", tt.part, tt.in)
+	}
+
+	_, err := s.Bump("prerelease", "1.2.3")
+	require.Error(t, err)
+
+	_, err = s.Bump("build", "1.2.3")
+	require.Error(t, err)
+
+	_, err = s.Bump("major", "abc")
+	require.Error(t, err)
+}
+
+func TestSemverFuncs_Sort(t *testing.T) {
+	t.Parallel()
+
+	s := SemverFuncs{ctx: context.Background()}
+
+	out, err := s.Sort([]string{"v1.10.0", "1.2.0", "1.2.0-rc.1", "v0.9.1", "1.9.0"})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"v0.9.1", "1.2.0-rc.1", "1.2.0", "1.9.0", "v1.10.0"}, out)
+
+	out, err = s.Sort([]interface{}{})
+	require.NoError(t, err)
+	assert.Empty(t, out)
+
+	_, err = s.Sort([]string{"1.0.0", "bogus"})
+	require.Error(t, err)
+
+	_, err = s.Sort(42)
+	require.Error(t, err)
+}
@@ -0,0 +1,1277 @@
+package funcs
+
+import (
+	"context"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash/adler32"
+	"math"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/hairyhenderson/gomplate/v4/coll"
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/internal/parsers"
+	"golang.org/x/text/language"
+)
+
+// CreateSprigFuncs creates functions compatible with the (top-level) function
+// names and argument orders of the Sprig library, and the related functions
+// provided by Helm. These are only added when Sprig compatibility is enabled,
+// and some (like join, split, and has) override gomplate's own aliases, which
+// take arguments in a different order.
+func CreateSprigFuncs(ctx context.Context) map[string]interface{} {
+	f := map[string]interface{}{}
+
+	s := &SprigFuncs{ctx}
+	strs := &StringFuncs{ctx, language.Und}
+	cf := &CollFuncs{ctx}
+	rnd := CreateRandomFuncs(ctx)["random"].(func() interface{})().(*RandomFuncs)
+	envNS := &EnvFuncs{ctx}
+	semverNS := &SemverFuncs{ctx}
+	crypto := &CryptoFuncs{ctx}
+
+	// strings
+	f["trim"] = strings.TrimSpace
+	f["trimAll"] = s.TrimAll
+	f["trimPrefix"] = s.TrimPrefix
+	f["trimSuffix"] = s.TrimSuffix
+	f["upper"] = strings.ToUpper
+	f["lower"] = strings.ToLower
+	f["untitle"] = s.Untitle
+	f["repeat"] = s.Repeat
+	f["substr"] = s.Substr
+	f["nospace"] = s.Nospace
+	f["trunc"] = s.Trunc
+	f["abbrev"] = s.Abbrev
+	f["initials"] = s.Initials
+	f["contains"] = s.Contains
+	f["hasPrefix"] = s.HasPrefix
+	f["hasSuffix"] = s.HasSuffix
+	f["quote"] = s.Quote
+	f["squote"] = s.Squote
+	f["cat"] = s.Cat
+	f["replace"] = s.Replace
+	f["plural"] = s.Plural
+	f["snakecase"] = strs.SnakeCase
+	f["camelcase"] = s.Camelcase
+	f["kebabcase"] = strs.KebabCase
+	f["swapcase"] = s.Swapcase
+	f["wrap"] = s.Wrap
+	f["wrapWith"] = s.WrapWith
+	f["split"] = s.Split
+	f["splitList"] = s.SplitList
+	f["splitn"] = s.Splitn
+	f["join"] = s.Join
+	f["sortAlpha"] = s.SortAlpha
+	f["toString"] = conv.ToString
+	f["toStrings"] = s.ToStrings
+
+	// regular expressions
+	f["regexMatch"] = s.RegexMatch
+	f["regexFind"] = s.RegexFind
+	f["regexFindAll"] = s.RegexFindAll
+	f["regexReplaceAll"] = s.RegexReplaceAll
+	f["regexReplaceAllLiteral"] = s.RegexReplaceAllLiteral
+	f["regexSplit"] = s.RegexSplit
+	f["regexQuoteMeta"] = regexp.QuoteMeta
+
+	// conversions and integer math
+	f["atoi"] = s.Atoi
+	f["int"] = s.Int
+	f["int64"] = s.Int64
+	f["float64"] = s.Float64
+	f["add"] = s.Add
+	f["add1"] = s.Add1
+	f["sub"] = s.Sub
+	f["mul"] = s.Mul
+	f["div"] = s.Div
+	f["mod"] = s.Mod
+	f["max"] = s.Max
+	f["min"] = s.Min
+	f["addf"] = s.Addf
+	f["subf"] = s.Subf
+	f["mulf"] = s.Mulf
+	f["divf"] = s.Divf
+	f["maxf"] = s.Maxf
+	f["minf"] = s.Minf
+	f["floor"] = s.Floor
+	f["ceil"] = s.Ceil
+	f["round"] = s.Round
+	f["until"] = s.Until
+	f["untilStep"] = s.UntilStep
+
+	// defaults and flow control
+	f["empty"] = s.Empty
+	f["coalesce"] = s.Coalesce
+	f["all"] = s.All
+	f["any"] = s.Any
+
+	// encoding
+	f["b64enc"] = s.B64enc
+	f["b64dec"] = s.B64dec
+	f["b32enc"] = s.B32enc
+	f["b32dec"] = s.B32dec
+	f["toJson"] = s.ToJSON
+	f["toPrettyJson"] = s.ToPrettyJSON
+	f["toRawJson"] = s.ToRawJSON
+	f["fromJson"] = s.FromJSON
+	f["fromJsonArray"] = s.FromJSONArray
+	f["toYaml"] = s.ToYAML
+	f["fromYaml"] = s.FromYAML
+	f["fromYamlArray"] = s.FromYAMLArray
+
+	// lists
+	f["list"] = cf.Slice
+	f["tuple"] = cf.Slice
+	f["first"] = s.First
+	f["rest"] = s.Rest
+	f["last"] = s.Last
+	f["initial"] = s.Initial
+	f["append"] = s.Append
+	f["push"] = s.Append
+	f["prepend"] = s.Prepend
+	f["concat"] = s.Concat
+	f["without"] = s.Without
+	f["has"] = s.Has
+	f["compact"] = s.Compact
+	f["slice"] = s.Slice
+
+	// dicts
+	f["get"] = s.Get
+	f["set"] = s.Set
+	f["unset"] = s.Unset
+	f["hasKey"] = s.HasKey
+	f["pluck"] = s.Pluck
+	f["dig"] = s.Dig
+	f["pick"] = s.Pick
+	f["omit"] = s.Omit
+	f["mergeOverwrite"] = s.MergeOverwrite
+	f["deepCopy"] = s.DeepCopy
+
+	// reflection
+	f["typeOf"] = s.TypeOf
+	f["typeIs"] = s.TypeIs
+	f["kindOf"] = s.KindOf
+	f["kindIs"] = s.KindIs
+	f["deepEqual"] = reflect.DeepEqual
+
+	// hashes and random values
+	f["sha1sum"] = crypto.SHA1
+	f["sha256sum"] = crypto.SHA256
+	f["adler32sum"] = s.Adler32sum
+	f["uuidv4"] = func() string { return uuid.NewString() }
+	f["randAlphaNum"] = rnd.AlphaNum
+	f["randAlpha"] = rnd.Alpha
+	f["randNumeric"] = func(count interface{}) (string, error) {
+		return rnd.String(count, "[[:digit:]]")
+	}
+	f["randAscii"] = rnd.ASCII
+
+	// dates
+	f["now"] = time.Now
+	f["date"] = s.Date
+	f["dateInZone"] = s.DateInZone
+	f["toDate"] = s.ToDate
+	f["unixEpoch"] = s.UnixEpoch
+	f["dateModify"] = s.DateModify
+	f["duration"] = s.Duration
+
+	// paths
+	f["base"] = path.Base
+	f["dir"] = path.Dir
+	f["clean"] = path.Clean
+	f["ext"] = path.Ext
+	f["isAbs"] = path.IsAbs
+
+	// env and semver are both namespaces and functions - called without
+	// arguments they return the namespace, so env.Getenv still works
+	f["env"] = func(args ...interface{}) (interface{}, error) {
+		switch len(args) {
+		case 0:
+			return envNS, nil
+		case 1:
+			return envNS.Getenv(args[0]), nil
+		default:
+			return nil, fmt.Errorf("wrong number of args: want 0 or 1, got %d", len(args))
+		}
+	}
+	f["expandenv"] = envNS.ExpandEnv
+	f["semver"] = func(args ...interface{}) (interface{}, error) {
+		switch len(args) {
+		case 0:
+			return semverNS, nil
+		case 1:
+			return semverNS.Semver(conv.ToString(args[0]))
+		default:
+			return nil, fmt.Errorf("wrong number of args: want 0 or 1, got %d", len(args))
+		}
+	}
+	f["semverCompare"] = semverNS.CheckConstraint
+
+	return f
+}
+
+// SprigFuncs - Sprig-compatible functions, see CreateSprigFuncs
+type SprigFuncs struct {
+	ctx context.Context
+}
+
+// TrimAll -
+func (SprigFuncs) TrimAll(cutset, s string) string {
+	return strings.Trim(s, cutset)
+}
+
+// TrimPrefix -
+func (SprigFuncs) TrimPrefix(prefix, s string) string {
+	return strings.TrimPrefix(s, prefix)
+}
+
+// TrimSuffix -
+func (SprigFuncs) TrimSuffix(suffix, s string) string {
+	return strings.TrimSuffix(s, suffix)
+}
+
+// Untitle -
+func (SprigFuncs) Untitle(s string) string {
+	out := []rune(s)
+	inWord := false
+	for i, r := range out {
+		if unicode.IsSpace(r) {
+			inWord = false
+			continue
+		}
+
+		if !inWord {
+			out[i] = unicode.ToLower(r)
+		}
+		inWord = true
+	}
+
+	return string(out)
+}
+
+// Repeat -
+func (SprigFuncs) Repeat(count int, s string) string {
+	if count < 0 {
+		return ""
+	}
+
+	return strings.Repeat(s, count)
+}
+
+// Substr - like Sprig, negative or out-of-range offsets are clamped
+func (SprigFuncs) Substr(start, end int, s string) string {
+	if start < 0 {
+		return s[:clampIndex(end, len(s))]
+	}
+
+	if end < 0 || end > len(s) {
+		return s[clampIndex(start, len(s)):]
+	}
+
+	if start > end {
+		return ""
+	}
+
+	return s[start:end]
+}
+
+func clampIndex(i, n int) int {
+	if i < 0 || i > n {
+		return n
+	}
+
+	return i
+}
+
+// Nospace -
+func (SprigFuncs) Nospace(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+
+		return r
+	}, s)
+}
+
+// Trunc - truncates to the given length, or keeps the last characters when
+// the length is negative
+func (SprigFuncs) Trunc(length int, s string) string {
+	switch {
+	case length < 0 && len(s)+length > 0:
+		return s[len(s)+length:]
+	case length >= 0 && len(s) > length:
+		return s[:length]
+	default:
+		return s
+	}
+}
+
+// Abbrev -
+func (SprigFuncs) Abbrev(width int, s string) string {
+	if width < 4 || len(s) <= width {
+		return s
+	}
+
+	return s[:width-3] + "..."
+}
+
+// Initials -
+func (SprigFuncs) Initials(s string) string {
+	out := strings.Builder{}
+	for _, w := range strings.Fields(s) {
+		r := []rune(w)
+		out.WriteRune(r[0])
+	}
+
+	return out.String()
+}
+
+// Contains -
+func (SprigFuncs) Contains(substr, s string) bool {
+	return strings.Contains(s, substr)
+}
+
+// HasPrefix -
+func (SprigFuncs) HasPrefix(prefix, s string) bool {
+	return strings.HasPrefix(s, prefix)
+}
+
+// HasSuffix -
+func (SprigFuncs) HasSuffix(suffix, s string) bool {
+	return strings.HasSuffix(s, suffix)
+}
+
+// Quote - quotes each (non-nil) argument, separated by spaces
+func (SprigFuncs) Quote(in ...interface{}) string {
+	out := make([]string, 0, len(in))
+	for _, s := range in {
+		if s != nil {
+			out = append(out, strconv.Quote(conv.ToString(s)))
+		}
+	}
+
+	return strings.Join(out, " ")
+}
+
+// Squote - single-quotes each (non-nil) argument, separated by spaces
+func (SprigFuncs) Squote(in ...interface{}) string {
+	out := make([]string, 0, len(in))
+	for _, s := range in {
+		if s != nil {
+			out = append(out, "'"+conv.ToString(s)+"'")
+		}
+	}
+
+	return strings.Join(out, " ")
+}
+
+// Cat - concatenates the (non-nil) arguments, separated by spaces
+func (SprigFuncs) Cat(in ...interface{}) string {
+	out := make([]string, 0, len(in))
+	for _, s := range in {
+		if s != nil {
+			out = append(out, conv.ToString(s))
+		}
+	}
+
+	return strings.Join(out, " ")
+}
+
+// Replace -
+func (SprigFuncs) Replace(old, new, s string) string {
+	return strings.ReplaceAll(s, old, new)
+}
+
+// Plural -
+func (SprigFuncs) Plural(one, many string, count int) string {
+	if count == 1 {
+		return one
+	}
+
+	return many
+}
+
+// Camelcase - converts to upper camel case ("foo_bar" becomes "FooBar")
+func (SprigFuncs) Camelcase(s string) string {
+	parts := strings.FieldsFunc(s, func(r rune) bool {
+		return r == '_' || r == '-' || unicode.IsSpace(r)
+	})
+
+	out := strings.Builder{}
+	for _, p := range parts {
+		r := []rune(p)
+		out.WriteRune(unicode.ToUpper(r[0]))
+		out.WriteString(string(r[1:]))
+	}
+
+	return out.String()
+}
+
+// Swapcase -
+func (SprigFuncs) Swapcase(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsUpper(r):
+			return unicode.ToLower(r)
+		case unicode.IsLower(r):
+			return unicode.ToUpper(r)
+		default:
+			return r
+		}
+	}, s)
+}
+
+// Wrap -
+func (s SprigFuncs) Wrap(width int, in string) string {
+	return s.WrapWith(width, "\n", in)
+}
+
+// WrapWith - wraps words at the given width, with the given line separator
+func (SprigFuncs) WrapWith(width int, sep, in string) string {
+	out := strings.Builder{}
+	lineLen := 0
+	for i, w := range strings.Fields(in) {
+		switch {
+		case i == 0:
+		case lineLen+1+len(w) > width:
+			out.WriteString(sep)
+			lineLen = 0
+		default:
+			out.WriteByte(' ')
+			lineLen++
+		}
+
+		out.WriteString(w)
+		lineLen += len(w)
+	}
+
+	return out.String()
+}
+
+// Split - splits into a map with keys "_0", "_1", etc
+func (SprigFuncs) Split(sep, s string) map[string]string {
+	return indexedMap(strings.Split(s, sep))
+}
+
+// Splitn - splits into at most n parts, in a map with keys "_0", "_1", etc
+func (SprigFuncs) Splitn(sep string, n int, s string) map[string]string {
+	return indexedMap(strings.SplitN(s, sep, n))
+}
+
+func indexedMap(parts []string) map[string]string {
+	out := make(map[string]string, len(parts))
+	for i, p := range parts {
+		out["_"+strconv.Itoa(i)] = p
+	}
+
+	return out
+}
+
+// SplitList -
+func (SprigFuncs) SplitList(sep, s string) []string {
+	return strings.Split(s, sep)
+}
+
+// Join -
+func (SprigFuncs) Join(sep string, list interface{}) string {
+	return strings.Join(sprigStrings(list), sep)
+}
+
+// SortAlpha -
+func (SprigFuncs) SortAlpha(list interface{}) []string {
+	out := sprigStrings(list)
+	sort.Strings(out)
+
+	return out
+}
+
+// ToStrings -
+func (SprigFuncs) ToStrings(list interface{}) []string {
+	return sprigStrings(list)
+}
+
+// sprigStrings converts a list (or a single value) to a slice of strings,
+// omitting nil values
+func sprigStrings(in interface{}) []string {
+	if s, ok := in.([]string); ok {
+		return s
+	}
+
+	v := reflect.ValueOf(in)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		if in == nil {
+			return []string{}
+		}
+
+		return []string{conv.ToString(in)}
+	}
+
+	out := make([]string, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		e := v.Index(i).Interface()
+		if e != nil {
+			out = append(out, conv.ToString(e))
+		}
+	}
+
+	return out
+}
+
+// RegexMatch -
+func (SprigFuncs) RegexMatch(re, s string) (bool, error) {
+	return regexp.MatchString(re, s)
+}
+
+// RegexFind -
+func (SprigFuncs) RegexFind(re, s string) (string, error) {
+	r, err := regexp.Compile(re)
+	if err != nil {
+		return "", err
+	}
+
+	return r.FindString(s), nil
+}
+
+// RegexFindAll -
+func (SprigFuncs) RegexFindAll(re, s string, n int) ([]string, error) {
+	r, err := regexp.Compile(re)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.FindAllString(s, n), nil
+}
+
+// RegexReplaceAll -
+func (SprigFuncs) RegexReplaceAll(re, s, repl string) (string, error) {
+	r, err := regexp.Compile(re)
+	if err != nil {
+		return "", err
+	}
+
+	return r.ReplaceAllString(s, repl), nil
+}
+
+// RegexReplaceAllLiteral -
+func (SprigFuncs) RegexReplaceAllLiteral(re, s, repl string) (string, error) {
+	r, err := regexp.Compile(re)
+	if err != nil {
+		return "", err
+	}
+
+	return r.ReplaceAllLiteralString(s, repl), nil
+}
+
+// RegexSplit -
+func (SprigFuncs) RegexSplit(re, s string, n int) ([]string, error) {
+	r, err := regexp.Compile(re)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Split(s, n), nil
+}
+
+// Atoi - like Sprig, invalid numbers are converted to 0
+func (SprigFuncs) Atoi(s string) int {
+	i, _ := strconv.Atoi(s)
+	return i
+}
+
+// Int -
+func (SprigFuncs) Int(in interface{}) int {
+	i, _ := conv.ToInt(in)
+	return i
+}
+
+// Int64 -
+func (SprigFuncs) Int64(in interface{}) int64 {
+	i, _ := conv.ToInt64(in)
+	return i
+}
+
+// Float64 -
+func (SprigFuncs) Float64(in interface{}) float64 {
+	f, _ := conv.ToFloat64(in)
+	return f
+}
+
+func toInt64(in interface{}) int64 {
+	i, _ := conv.ToInt64(in)
+	return i
+}
+
+func toFloat64(in interface{}) float64 {
+	f, _ := conv.ToFloat64(in)
+	return f
+}
+
+// Add - integer addition
+func (SprigFuncs) Add(n ...interface{}) int64 {
+	var sum int64
+	for _, i := range n {
+		sum += toInt64(i)
+	}
+
+	return sum
+}
+
+// Add1 -
+func (SprigFuncs) Add1(n interface{}) int64 {
+	return toInt64(n) + 1
+}
+
+// Sub - integer subtraction
+func (SprigFuncs) Sub(a, b interface{}) int64 {
+	return toInt64(a) - toInt64(b)
+}
+
+// Mul - integer multiplication
+func (SprigFuncs) Mul(a interface{}, n ...interface{}) int64 {
+	out := toInt64(a)
+	for _, i := range n {
+		out *= toInt64(i)
+	}
+
+	return out
+}
+
+// Div - integer division
+func (SprigFuncs) Div(a, b interface{}) (int64, error) {
+	d := toInt64(b)
+	if d == 0 {
+		return 0, fmt.Errorf("division by 0")
+	}
+
+	return toInt64(a) / d, nil
+}
+
+// Mod -
+func (SprigFuncs) Mod(a, b interface{}) (int64, error) {
+	d := toInt64(b)
+	if d == 0 {
+		return 0, fmt.Errorf("division by 0")
+	}
+
+	return toInt64(a) % d, nil
+}
+
+// Max -
+func (SprigFuncs) Max(a interface{}, n ...interface{}) int64 {
+	out := toInt64(a)
+	for _, i := range n {
+		out = max(out, toInt64(i))
+	}
+
+	return out
+}
+
+// Min -
+func (SprigFuncs) Min(a interface{}, n ...interface{}) int64 {
+	out := toInt64(a)
+	for _, i := range n {
+		out = min(out, toInt64(i))
+	}
+
+	return out
+}
+
+// Addf -
+func (SprigFuncs) Addf(n ...interface{}) float64 {
+	var sum float64
+	for _, i := range n {
+		sum += toFloat64(i)
+	}
+
+	return sum
+}
+
+// Subf -
+func (SprigFuncs) Subf(a interface{}, n ...interface{}) float64 {
+	out := toFloat64(a)
+	for _, i := range n {
+		out -= toFloat64(i)
+	}
+
+	return out
+}
+
+// Mulf -
+func (SprigFuncs) Mulf(a interface{}, n ...interface{}) float64 {
+	out := toFloat64(a)
+	for _, i := range n {
+		out *= toFloat64(i)
+	}
+
+	return out
+}
+
+// Divf -
+func (SprigFuncs) Divf(a interface{}, n ...interface{}) (float64, error) {
+	out := toFloat64(a)
+	for _, i := range n {
+		d := toFloat64(i)
+		if d == 0 {
+			return 0, fmt.Errorf("division by 0")
+		}
+
+		out /= d
+	}
+
+	return out, nil
+}
+
+// Maxf -
+func (SprigFuncs) Maxf(a interface{}, n ...interface{}) float64 {
+	out := toFloat64(a)
+	for _, i := range n {
+		out = math.Max(out, toFloat64(i))
+	}
+
+	return out
+}
+
+// Minf -
+func (SprigFuncs) Minf(a interface{}, n ...interface{}) float64 {
+	out := toFloat64(a)
+	for _, i := range n {
+		out = math.Min(out, toFloat64(i))
+	}
+
+	return out
+}
+
+// Floor -
+func (SprigFuncs) Floor(n interface{}) float64 {
+	return math.Floor(toFloat64(n))
+}
+
+// Ceil -
+func (SprigFuncs) Ceil(n interface{}) float64 {
+	return math.Ceil(toFloat64(n))
+}
+
+// Round - rounds to the given precision, optionally with a custom rounding
+// point (0.5 by default)
+func (SprigFuncs) Round(n interface{}, precision int, roundOn ...float64) float64 {
+	ro := 0.5
+	if len(roundOn) > 0 {
+		ro = roundOn[0]
+	}
+
+	pow := math.Pow(10, float64(precision))
+	digit := pow * toFloat64(n)
+	_, frac := math.Modf(digit)
+
+	if frac >= ro {
+		return math.Ceil(digit) / pow
+	}
+
+	return math.Floor(digit) / pow
+}
+
+// Until - a list of integers from 0 to count (exclusive)
+func (s SprigFuncs) Until(count int) []int {
+	step := 1
+	if count < 0 {
+		step = -1
+	}
+
+	return s.UntilStep(0, count, step)
+}
+
+// UntilStep -
+func (SprigFuncs) UntilStep(start, stop, step int) []int {
+	out := []int{}
+	switch {
+	case step > 0:
+		for i := start; i < stop; i += step {
+			out = append(out, i)
+		}
+	case step < 0:
+		for i := start; i > stop; i += step {
+			out = append(out, i)
+		}
+	}
+
+	return out
+}
+
+// Empty - returns true for zero values, and empty collections
+func (SprigFuncs) Empty(in interface{}) bool {
+	truth, ok := template.IsTrue(in)
+	return !truth || !ok
+}
+
+// Coalesce - returns the first non-empty argument
+func (s SprigFuncs) Coalesce(in ...interface{}) interface{} {
+	for _, v := range in {
+		if !s.Empty(v) {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// All - returns true if all arguments are non-empty
+func (s SprigFuncs) All(in ...interface{}) bool {
+	for _, v := range in {
+		if s.Empty(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Any - returns true if any argument is non-empty
+func (s SprigFuncs) Any(in ...interface{}) bool {
+	for _, v := range in {
+		if !s.Empty(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// B64enc -
+func (SprigFuncs) B64enc(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
+
+// B64dec -
+func (SprigFuncs) B64dec(s string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	return string(b), err
+}
+
+// B32enc -
+func (SprigFuncs) B32enc(s string) string {
+	return base32.StdEncoding.EncodeToString([]byte(s))
+}
+
+// B32dec -
+func (SprigFuncs) B32dec(s string) (string, error) {
+	b, err := base32.StdEncoding.DecodeString(s)
+	return string(b), err
+}
+
+// ToJSON -
+func (SprigFuncs) ToJSON(in interface{}) (string, error) {
+	b, err := json.Marshal(in)
+	return string(b), err
+}
+
+// ToPrettyJSON -
+func (SprigFuncs) ToPrettyJSON(in interface{}) (string, error) {
+	b, err := json.MarshalIndent(in, "", "  ")
+	return string(b), err
+}
+
+// ToRawJSON - marshals to JSON without escaping HTML characters
+func (SprigFuncs) ToRawJSON(in interface{}) (string, error) {
+	buf := &strings.Builder{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+
+	err := enc.Encode(in)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// FromJSON -
+func (SprigFuncs) FromJSON(in string) (map[string]interface{}, error) {
+	return parsers.JSON(in)
+}
+
+// FromJSONArray -
+func (SprigFuncs) FromJSONArray(in string) ([]interface{}, error) {
+	return parsers.JSONArray(in)
+}
+
+// ToYAML - like Helm, the trailing newline is removed
+func (SprigFuncs) ToYAML(in interface{}) (string, error) {
+	s, err := parsers.ToYAML(in)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(s, "\n"), nil
+}
+
+// FromYAML -
+func (SprigFuncs) FromYAML(in string) (map[string]interface{}, error) {
+	return parsers.YAML(in)
+}
+
+// FromYAMLArray -
+func (SprigFuncs) FromYAMLArray(in string) ([]interface{}, error) {
+	return parsers.YAMLArray(in)
+}
+
+func sprigList(in interface{}) ([]interface{}, error) {
+	if in == nil {
+		return []interface{}{}, nil
+	}
+
+	v := reflect.ValueOf(in)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected a list, got %T", in)
+	}
+
+	out := make([]interface{}, v.Len())
+	for i := range out {
+		out[i] = v.Index(i).Interface()
+	}
+
+	return out, nil
+}
+
+// First -
+func (SprigFuncs) First(list interface{}) (interface{}, error) {
+	l, err := sprigList(list)
+	if err != nil || len(l) == 0 {
+		return nil, err
+	}
+
+	return l[0], nil
+}
+
+// Rest - all but the first element
+func (SprigFuncs) Rest(list interface{}) ([]interface{}, error) {
+	l, err := sprigList(list)
+	if err != nil || len(l) == 0 {
+		return l, err
+	}
+
+	return l[1:], nil
+}
+
+// Last -
+func (SprigFuncs) Last(list interface{}) (interface{}, error) {
+	l, err := sprigList(list)
+	if err != nil || len(l) == 0 {
+		return nil, err
+	}
+
+	return l[len(l)-1], nil
+}
+
+// Initial - all but the last element
+func (SprigFuncs) Initial(list interface{}) ([]interface{}, error) {
+	l, err := sprigList(list)
+	if err != nil || len(l) == 0 {
+		return l, err
+	}
+
+	return l[:len(l)-1], nil
+}
+
+// Append -
+func (SprigFuncs) Append(list, v interface{}) ([]interface{}, error) {
+	return coll.Append(v, list)
+}
+
+// Prepend -
+func (SprigFuncs) Prepend(list, v interface{}) ([]interface{}, error) {
+	return coll.Prepend(v, list)
+}
+
+// Concat -
+func (SprigFuncs) Concat(lists ...interface{}) ([]interface{}, error) {
+	out := []interface{}{}
+	for _, list := range lists {
+		l, err := sprigList(list)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, l...)
+	}
+
+	return out, nil
+}
+
+// Without - the list, without the given values
+func (SprigFuncs) Without(list interface{}, omit ...interface{}) ([]interface{}, error) {
+	l, err := sprigList(list)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []interface{}{}
+	for _, v := range l {
+		if !containsValue(omit, v) {
+			out = append(out, v)
+		}
+	}
+
+	return out, nil
+}
+
+func containsValue(list []interface{}, v interface{}) bool {
+	for _, e := range list {
+		if reflect.DeepEqual(e, v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Has - reports whether the list contains the value
+func (SprigFuncs) Has(needle, haystack interface{}) (bool, error) {
+	l, err := sprigList(haystack)
+	if err != nil {
+		return false, err
+	}
+
+	return containsValue(l, needle), nil
+}
+
+// Compact - the list, without empty values
+func (s SprigFuncs) Compact(list interface{}) ([]interface{}, error) {
+	l, err := sprigList(list)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []interface{}{}
+	for _, v := range l {
+		if !s.Empty(v) {
+			out = append(out, v)
+		}
+	}
+
+	return out, nil
+}
+
+// Slice - a slice of the list, from the start index (inclusive) to the end
+// index (exclusive)
+func (SprigFuncs) Slice(list interface{}, indexes ...int) ([]interface{}, error) {
+	l, err := sprigList(list)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := 0, len(l)
+	switch len(indexes) {
+	case 0:
+	case 1:
+		start = indexes[0]
+	case 2:
+		start, end = indexes[0], indexes[1]
+	default:
+		return nil, fmt.Errorf("wrong number of args: want 1 to 3, got %d", len(indexes)+1)
+	}
+
+	if start < 0 || end > len(l) || start > end {
+		return nil, fmt.Errorf("slice indexes [%d:%d] out of range for list of length %d", start, end, len(l))
+	}
+
+	return l[start:end], nil
+}
+
+// Get - the value of the key, or an empty string if it's not set
+func (SprigFuncs) Get(d map[string]interface{}, key string) interface{} {
+	if v, ok := d[key]; ok {
+		return v
+	}
+
+	return ""
+}
+
+// Set - sets the key in the dict (modifying it), and returns the dict
+func (SprigFuncs) Set(d map[string]interface{}, key string, value interface{}) map[string]interface{} {
+	d[key] = value
+	return d
+}
+
+// Unset - removes the key from the dict (modifying it), and returns the dict
+func (SprigFuncs) Unset(d map[string]interface{}, key string) map[string]interface{} {
+	delete(d, key)
+	return d
+}
+
+// HasKey -
+func (SprigFuncs) HasKey(d map[string]interface{}, key string) bool {
+	_, ok := d[key]
+	return ok
+}
+
+// Pluck - the values of the key in each of the dicts that have it
+func (SprigFuncs) Pluck(key string, dicts ...map[string]interface{}) []interface{} {
+	out := []interface{}{}
+	for _, d := range dicts {
+		if v, ok := d[key]; ok {
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
+
+// Dig - the value at the path of keys in nested dicts, or the default. The
+// arguments are the keys, followed by the default and the dict.
+func (SprigFuncs) Dig(args ...interface{}) (interface{}, error) {
+	if len(args) < 3 {
+		return nil, fmt.Errorf("wrong number of args: want at least 3, got %d", len(args))
+	}
+
+	keys := conv.ToStrings(args[:len(args)-2]...)
+	def := args[len(args)-2]
+
+	d, ok := args[len(args)-1].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a dict, got %T", args[len(args)-1])
+	}
+
+	var v interface{} = d
+	for _, k := range keys {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return def, nil
+		}
+
+		v, ok = m[k]
+		if !ok {
+			return def, nil
+		}
+	}
+
+	return v, nil
+}
+
+// Pick -
+func (SprigFuncs) Pick(d map[string]interface{}, keys ...string) map[string]interface{} {
+	return coll.Pick(d, keys...)
+}
+
+// Omit -
+func (SprigFuncs) Omit(d map[string]interface{}, keys ...string) map[string]interface{} {
+	return coll.Omit(d, keys...)
+}
+
+// MergeOverwrite - merges the dicts into the first, with later dicts taking
+// precedence
+func (SprigFuncs) MergeOverwrite(dst map[string]interface{}, srcs ...map[string]interface{}) (map[string]interface{}, error) {
+	if len(srcs) == 0 {
+		return dst, nil
+	}
+
+	all := make([]map[string]interface{}, 0, len(srcs)+1)
+	for i := len(srcs) - 1; i >= 0; i-- {
+		all = append(all, srcs[i])
+	}
+	all = append(all, dst)
+
+	return coll.Merge(all[0], all[1:]...)
+}
+
+// DeepCopy -
+func (SprigFuncs) DeepCopy(in interface{}) (interface{}, error) {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+
+	var out interface{}
+	err = json.Unmarshal(b, &out)
+
+	return out, err
+}
+
+// TypeOf -
+func (SprigFuncs) TypeOf(in interface{}) string {
+	return fmt.Sprintf("%T", in)
+}
+
+// TypeIs -
+func (s SprigFuncs) TypeIs(typ string, in interface{}) bool {
+	return typ == s.TypeOf(in)
+}
+
+// KindOf -
+func (SprigFuncs) KindOf(in interface{}) string {
+	return reflect.ValueOf(in).Kind().String()
+}
+
+// KindIs -
+func (s SprigFuncs) KindIs(kind string, in interface{}) bool {
+	return kind == s.KindOf(in)
+}
+
+// Adler32sum -
+func (SprigFuncs) Adler32sum(in string) string {
+	return strconv.FormatUint(uint64(adler32.Checksum([]byte(in))), 10)
+}
+
+// sprigTime converts a time.Time, or a Unix time in seconds, to a time.Time
+func sprigTime(in interface{}) time.Time {
+	switch t := in.(type) {
+	case time.Time:
+		return t
+	case *time.Time:
+		return *t
+	default:
+		return time.Unix(toInt64(in), 0)
+	}
+}
+
+// Date - formats the date with the given layout, in the local time zone
+func (s SprigFuncs) Date(layout string, date interface{}) (string, error) {
+	return s.DateInZone(layout, date, "Local")
+}
+
+// DateInZone -
+func (SprigFuncs) DateInZone(layout string, date interface{}, zone string) (string, error) {
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return "", err
+	}
+
+	return sprigTime(date).In(loc).Format(layout), nil
+}
+
+// ToDate - parses the date with the given layout, in the local time zone
+func (SprigFuncs) ToDate(layout, value string) (time.Time, error) {
+	return time.ParseInLocation(layout, value, time.Local)
+}
+
+// UnixEpoch -
+func (SprigFuncs) UnixEpoch(date time.Time) string {
+	return strconv.FormatInt(date.Unix(), 10)
+}
+
+// DateModify - adds the duration to the date
+func (SprigFuncs) DateModify(d string, date time.Time) (time.Time, error) {
+	dur, err := time.ParseDuration(d)
+	if err != nil {
+		return date, err
+	}
+
+	return date.Add(dur), nil
+}
+
+// Duration - formats a number of seconds as a duration
+func (SprigFuncs) Duration(sec interface{}) string {
+	return (time.Duration(toInt64(sec)) * time.Second).String()
+}
@@ -0,0 +1,421 @@
+package funcs
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateSprigFuncs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fmap := CreateSprigFuncs(ctx)
+
+	// a sample of the aliased and new functions
+	for _, name := range []string{
+		"trimSuffix", "upper", "toYaml", "toJson", "b64enc", "list", "dig",
+		"sha256sum", "date", "semverCompare", "env", "semver",
+	} {
+		assert.Contains(t, fmap, name)
+	}
+
+	// env and semver can be called as functions, or used as namespaces
+	envFunc := fmap["env"].(func(...interface{}) (interface{}, error))
+	ns, err := envFunc()
+	require.NoError(t, err)
+	assert.IsType(t, &EnvFuncs{}, ns)
+
+	v, err := envFunc("PATH")
+	require.NoError(t, err)
+	assert.Equal(t, os.Getenv("PATH"), v)
+
+	semverFunc := fmap["semver"].(func(...interface{}) (interface{}, error))
+	ns, err = semverFunc()
+	require.NoError(t, err)
+	assert.IsType(t, &SemverFuncs{}, ns)
+
+	_, err = semverFunc("1", "2")
+	require.Error(t, err)
+}
+
+func TestSprigStrings(t *testing.T) {
+	t.Parallel()
+
+	s := SprigFuncs{}
+
+	assert.Equal(t, "app", s.TrimSuffix("-", "app-"))
+	assert.Equal(t, "app", s.TrimPrefix("my-", "my-app"))
+	assert.Equal(t, "app", s.TrimAll("$", "$$app$"))
+	assert.Equal(t, "hello world", s.Untitle("Hello World"))
+	assert.Equal(t, "abab", s.Repeat(2, "ab"))
+	assert.Equal(t, "", s.Repeat(-1, "ab"))
+
+	assert.Equal(t, "ell", s.Substr(1, 4, "hello"))
+	assert.Equal(t, "hel", s.Substr(-1, 3, "hello"))
+	assert.Equal(t, "llo", s.Substr(2, -1, "hello"))
+	assert.Equal(t, "llo", s.Substr(2, 10, "hello"))
+	assert.Equal(t, "", s.Substr(4, 2, "hello"))
+
+	assert.Equal(t, "helloworld", s.Nospace("hel lo\two\nrld"))
+	assert.Equal(t, "hel", s.Trunc(3, "hello"))
+	assert.Equal(t, "llo", s.Trunc(-3, "hello"))
+	assert.Equal(t, "hello", s.Trunc(10, "hello"))
+	assert.Equal(t, "he...", s.Abbrev(5, "hello world"))
+	assert.Equal(t, "hello", s.Abbrev(5, "hello"))
+	assert.Equal(t, "HW", s.Initials("Hello World"))
+
+	assert.True(t, s.Contains("ell", "hello"))
+	assert.True(t, s.HasPrefix("he", "hello"))
+	assert.True(t, s.HasSuffix("lo", "hello"))
+
+	assert.Equal(t, `"a" "1"`, s.Quote("a", nil, 1))
+	assert.Equal(t, `'a' '1'`, s.Squote("a", nil, 1))
+	assert.Equal(t, "a 1 true", s.Cat("a", 1, nil, true))
+	assert.Equal(t, "a-b-c", s.Replace(" ", "-", "a b c"))
+	assert.Equal(t, "item", s.Plural("item", "items", 1))
+	assert.Equal(t, "items", s.Plural("item", "items", 2))
+	assert.Equal(t, "FooBarBaz", s.Camelcase("foo_bar-baz"))
+	assert.Equal(t, "hELLO wORLD", s.Swapcase("Hello World"))
+	assert.Equal(t, "the quick\nbrown fox", s.Wrap(10, "the quick brown fox"))
+	assert.Equal(t, "the quick<br>brown fox", s.WrapWith(10, "<br>", "the quick brown fox"))
+
+	assert.Equal(t, map[string]string{"_0": "a", "_1": "b", "_2": "c"}, s.Split(".", "a.b.c"))
+	assert.Equal(t, map[string]string{"_0": "a", "_1": "b.c"}, s.Splitn(".", 2, "a.b.c"))
+	assert.Equal(t, []string{"a", "b", "c"}, s.SplitList(".", "a.b.c"))
+	assert.Equal(t, "1,2,3", s.Join(",", []interface{}{1, nil, 2, 3}))
+	assert.Equal(t, "a", s.Join(",", "a"))
+	assert.Equal(t, []string{"a", "b", "c"}, s.SortAlpha([]interface{}{"c", "a", "b"}))
+	assert.Equal(t, []string{"1", "2"}, s.ToStrings([]int{1, 2}))
+}
+
+func TestSprigRegex(t *testing.T) {
+	t.Parallel()
+
+	s := SprigFuncs{}
+
+	ok, err := s.RegexMatch(`^[a-z]+\d$`, "abc1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	out, err := s.RegexFind(`\d+`, "abc123def456")
+	require.NoError(t, err)
+	assert.Equal(t, "123", out)
+
+	all, err := s.RegexFindAll(`\d+`, "abc123def456", -1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"123", "456"}, all)
+
+	out, err = s.RegexReplaceAll(`(\d+)`, "abc123", "<$1>")
+	require.NoError(t, err)
+	assert.Equal(t, "abc<123>", out)
+
+	out, err = s.RegexReplaceAllLiteral(`(\d+)`, "abc123", "<$1>")
+	require.NoError(t, err)
+	assert.Equal(t, "abc<$1>", out)
+
+	all, err = s.RegexSplit(`\s*,\s*`, "a , b,c", -1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, all)
+
+	_, err = s.RegexFind(`(`, "abc")
+	require.Error(t, err)
+}
+
+func TestSprigMath(t *testing.T) {
+	t.Parallel()
+
+	s := SprigFuncs{}
+
+	assert.Equal(t, 42, s.Atoi("42"))
+	assert.Equal(t, 0, s.Atoi("foo"))
+	assert.Equal(t, 42, s.Int("42"))
+	assert.Equal(t, int64(42), s.Int64(42.0))
+	assert.InEpsilon(t, 1.5, s.Float64("1.5"), 1e-9)
+
+	assert.Equal(t, int64(6), s.Add(1, "2", 3))
+	assert.Equal(t, int64(2), s.Add1(1))
+	assert.Equal(t, int64(-1), s.Sub(1, 2))
+	assert.Equal(t, int64(24), s.Mul(2, 3, 4))
+
+	d, err := s.Div(7, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), d)
+
+	_, err = s.Div(7, 0)
+	require.Error(t, err)
+
+	m, err := s.Mod(7, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), m)
+
+	assert.Equal(t, int64(5), s.Max(1, 5, 3))
+	assert.Equal(t, int64(1), s.Min(3, 1, 5))
+
+	assert.InEpsilon(t, 3.5, s.Addf(1.5, 2), 1e-9)
+	assert.InEpsilon(t, 0.5, s.Subf(2.5, 2), 1e-9)
+	assert.InEpsilon(t, 3.0, s.Mulf(1.5, 2), 1e-9)
+
+	f, err := s.Divf(7, 2)
+	require.NoError(t, err)
+	assert.InEpsilon(t, 3.5, f, 1e-9)
+
+	assert.InEpsilon(t, 5.5, s.Maxf(1, 5.5, 3), 1e-9)
+	assert.InEpsilon(t, 1.5, s.Minf(3, 1.5, 5), 1e-9)
+	assert.InEpsilon(t, 1.0, s.Floor(1.7), 1e-9)
+	assert.InEpsilon(t, 2.0, s.Ceil(1.2), 1e-9)
+	assert.InEpsilon(t, 123.56, s.Round(123.555, 2), 1e-9)
+	assert.InEpsilon(t, 123.55, s.Round(123.555, 2, 0.6), 1e-9)
+
+	assert.Equal(t, []int{0, 1, 2}, s.Until(3))
+	assert.Equal(t, []int{0, -1, -2}, s.Until(-3))
+	assert.Equal(t, []int{3, 5, 7}, s.UntilStep(3, 8, 2))
+	assert.Equal(t, []int{}, s.UntilStep(3, 8, 0))
+}
+
+func TestSprigDefaults(t *testing.T) {
+	t.Parallel()
+
+	s := SprigFuncs{}
+
+	for _, v := range []interface{}{nil, "", 0, false, []interface{}{}, map[string]interface{}{}} {
+		assert.True(t, s.Empty(v), "%#v", v)
+	}
+
+	for _, v := range []interface{}{"a", 1, true, []interface{}{1}} {
+		assert.False(t, s.Empty(v), "%#v", v)
+	}
+
+	assert.Equal(t, "b", s.Coalesce(nil, "", "b", "c"))
+	assert.Nil(t, s.Coalesce(nil, ""))
+	assert.True(t, s.All("a", 1, true))
+	assert.False(t, s.All("a", 0))
+	assert.True(t, s.Any("", 0, "a"))
+	assert.False(t, s.Any("", 0))
+}
+
+func TestSprigEncoding(t *testing.T) {
+	t.Parallel()
+
+	s := SprigFuncs{}
+
+	assert.Equal(t, "aGVsbG8=", s.B64enc("hello"))
+	out, err := s.B64dec("aGVsbG8=")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", out)
+
+	assert.Equal(t, "NBSWY3DP", s.B32enc("hello"))
+	out, err = s.B32dec("NBSWY3DP")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", out)
+
+	in := map[string]interface{}{"a": []interface{}{1, "<b>"}}
+
+	out, err = s.ToJSON(in)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":[1,"\u003cb\u003e"]}`, out)
+
+	out, err = s.ToRawJSON(in)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":[1,"<b>"]}`, out)
+
+	out, err = s.ToPrettyJSON(in)
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"a\": [\n    1,\n    \"\\u003cb\\u003e\"\n  ]\n}", out)
+
+	m, err := s.FromJSON(`{"a": 1}`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": 1}, m)
+
+	l, err := s.FromJSONArray(`[1, 2]`)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2}, l)
+
+	out, err = s.ToYAML(map[string]interface{}{"a": 1, "b": []string{"c"}})
+	require.NoError(t, err)
+	assert.Equal(t, "a: 1\nb:\n  - c", out)
+
+	m, err = s.FromYAML("a: 1\n")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": 1}, m)
+
+	l, err = s.FromYAMLArray("- a\n- b\n")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"a", "b"}, l)
+}
+
+func TestSprigLists(t *testing.T) {
+	t.Parallel()
+
+	s := SprigFuncs{}
+	list := []interface{}{1, 2, 3}
+
+	v, err := s.First(list)
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	v, err = s.Last([]string{"a", "b"})
+	require.NoError(t, err)
+	assert.Equal(t, "b", v)
+
+	v, err = s.First([]interface{}{})
+	require.NoError(t, err)
+	assert.Nil(t, v)
+
+	l, err := s.Rest(list)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{2, 3}, l)
+
+	l, err = s.Initial(list)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2}, l)
+
+	l, err = s.Append(list, 4)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2, 3, 4}, l)
+
+	l, err = s.Prepend(list, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{0, 1, 2, 3}, l)
+
+	l, err = s.Concat(list, []string{"a"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2, 3, "a"}, l)
+
+	l, err = s.Without(list, 2, 4)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 3}, l)
+
+	ok, err := s.Has(2, list)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = s.Has(4, list)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	l, err = s.Compact([]interface{}{"a", "", nil, 0, "b"})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"a", "b"}, l)
+
+	l, err = s.Slice(list, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{2, 3}, l)
+
+	l, err = s.Slice(list, 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2}, l)
+
+	_, err = s.Slice(list, 2, 5)
+	require.Error(t, err)
+
+	_, err = s.First("foo")
+	require.Error(t, err)
+}
+
+func TestSprigDicts(t *testing.T) {
+	t.Parallel()
+
+	s := SprigFuncs{}
+
+	d := map[string]interface{}{"a": 1}
+	assert.Equal(t, 1, s.Get(d, "a"))
+	assert.Equal(t, "", s.Get(d, "b"))
+
+	assert.Equal(t, map[string]interface{}{"a": 1, "b": 2}, s.Set(d, "b", 2))
+	assert.True(t, s.HasKey(d, "b"))
+	assert.Equal(t, map[string]interface{}{"a": 1}, s.Unset(d, "b"))
+	assert.False(t, s.HasKey(d, "b"))
+
+	assert.Equal(t, []interface{}{1, 3},
+		s.Pluck("a", map[string]interface{}{"a": 1}, map[string]interface{}{"b": 2}, map[string]interface{}{"a": 3}))
+
+	nested := map[string]interface{}{"a": map[string]interface{}{"b": "c"}}
+	v, err := s.Dig("a", "b", "default", nested)
+	require.NoError(t, err)
+	assert.Equal(t, "c", v)
+
+	v, err = s.Dig("a", "x", "default", nested)
+	require.NoError(t, err)
+	assert.Equal(t, "default", v)
+
+	v, err = s.Dig("a", "b", "x", "default", nested)
+	require.NoError(t, err)
+	assert.Equal(t, "default", v)
+
+	_, err = s.Dig("a", "default")
+	require.Error(t, err)
+
+	_, err = s.Dig("a", "default", "notadict")
+	require.Error(t, err)
+
+	abc := map[string]interface{}{"a": 1, "b": 2, "c": 3}
+	assert.Equal(t, map[string]interface{}{"a": 1, "c": 3}, s.Pick(abc, "a", "c"))
+	assert.Equal(t, map[string]interface{}{"b": 2}, s.Omit(abc, "a", "c"))
+
+	m, err := s.MergeOverwrite(
+		map[string]interface{}{"a": 1, "b": map[string]interface{}{"c": 1, "d": 1}},
+		map[string]interface{}{"b": map[string]interface{}{"c": 2}},
+		map[string]interface{}{"e": 3},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": 1, "b": map[string]interface{}{"c": 2, "d": 1}, "e": 3}, m)
+
+	c, err := s.DeepCopy(nested)
+	require.NoError(t, err)
+	assert.Equal(t, nested, c)
+}
+
+func TestSprigReflection(t *testing.T) {
+	t.Parallel()
+
+	s := SprigFuncs{}
+
+	assert.Equal(t, "string", s.TypeOf("a"))
+	assert.True(t, s.TypeIs("[]interface {}", []interface{}{}))
+	assert.Equal(t, "slice", s.KindOf([]string{}))
+	assert.True(t, s.KindIs("map", map[string]int{}))
+	assert.Equal(t, "38600999", s.Adler32sum("abc"))
+}
+
+func TestSprigDates(t *testing.T) {
+	t.Parallel()
+
+	s := SprigFuncs{}
+	d := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	out, err := s.DateInZone("2006-01-02 15:04", d, "UTC")
+	require.NoError(t, err)
+	assert.Equal(t, "2024-03-04 05:06", out)
+
+	out, err = s.DateInZone("2006-01-02", d.Unix(), "UTC")
+	require.NoError(t, err)
+	assert.Equal(t, "2024-03-04", out)
+
+	out, err = s.DateInZone("2006-01-02 15:04", &d, "America/New_York")
+	require.NoError(t, err)
+	assert.Equal(t, "2024-03-04 00:06", out)
+
+	_, err = s.DateInZone("2006", d, "Nowhere/Bogus")
+	require.Error(t, err)
+
+	p, err := s.ToDate("2006-01-02", "2024-03-04")
+	require.NoError(t, err)
+	assert.Equal(t, "2024-03-04", p.Format("2006-01-02"))
+
+	assert.Equal(t, "1709528767", s.UnixEpoch(d))
+
+	m, err := s.DateModify("-1.5h", d)
+	require.NoError(t, err)
+	assert.Equal(t, d.Add(-90*time.Minute), m)
+
+	_, err = s.DateModify("bogus", d)
+	require.Error(t, err)
+
+	assert.Equal(t, "1m35s", s.Duration(95))
+	assert.Equal(t, "1h0m0s", s.Duration("3600"))
+}
@@ -10,11 +10,13 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"slices"
 	"strings"
 	"unicode/utf8"
 
 	"github.com/Masterminds/goutils"
 	"github.com/hairyhenderson/gomplate/v4/conv"
+	iconv "github.com/hairyhenderson/gomplate/v4/internal/conv"
 	"github.com/hairyhenderson/gomplate/v4/internal/deprecated"
 	gompstrings "github.com/hairyhenderson/gomplate/v4/strings"
 
@@ -36,6 +38,7 @@ func CreateStringFuncs(ctx context.Context) map[string]interface{} {
 	f["toLower"] = ns.ToLower
 	f["trimSpace"] = ns.TrimSpace
 	f["indent"] = ns.Indent
+	f["nindent"] = ns.Nindent
 	f["quote"] = ns.Quote
 	f["shellQuote"] = ns.ShellQuote
 	f["squote"] = ns.Squote
@@ -273,6 +276,27 @@ func (StringFuncs) Trunc(length int, s interface{}) string {
 
 // Indent -
 func (StringFuncs) Indent(args ...interface{}) (string, error) {
+	width, indent, input, err := indentArgs(args)
+	if err != nil {
+		return "", err
+	}
+
+	return gompstrings.Indent(width, indent, input)
+}
+
+// Nindent -
+func (StringFuncs) Nindent(args ...interface{}) (string, error) {
+	width, indent, input, err := indentArgs(args)
+	if err != nil {
+		return "", err
+	}
+
+	return gompstrings.Nindent(width, indent, input)
+}
+
+// indentArgs returns the width, indent string, and input from the arguments
+// to Indent or Nindent
+func indentArgs(args []interface{}) (int, string, string, error) {
 	indent := " "
 	width := 1
 
@@ -280,13 +304,13 @@ func (StringFuncs) Indent(args ...interface{}) (string, error) {
 
 	switch len(args) {
 	case 0:
-		return "", fmt.Errorf("expected at least 1 argument")
+		return 0, "", "", fmt.Errorf("expected at least 1 argument")
 	case 2:
 		indent, ok = args[0].(string)
 		if !ok {
 			width, ok = args[0].(int)
 			if !ok {
-				return "", fmt.Errorf("invalid arguments")
+				return 0, "", "", fmt.Errorf("invalid arguments")
 			}
 
 			indent = " "
@@ -294,18 +318,220 @@ func (StringFuncs) Indent(args ...interface{}) (string, error) {
 	case 3:
 		width, ok = args[0].(int)
 		if !ok {
-			return "", fmt.Errorf("invalid arguments")
+			return 0, "", "", fmt.Errorf("invalid arguments")
 		}
 
 		indent, ok = args[1].(string)
 		if !ok {
-			return "", fmt.Errorf("invalid arguments")
+			return 0, "", "", fmt.Errorf("invalid arguments")
 		}
 	}
 
-	input := conv.ToString(args[len(args)-1])
+	return width, indent, conv.ToString(args[len(args)-1]), nil
+}
 
-	return gompstrings.Indent(width, indent, input)
+// Dedent -
+func (StringFuncs) Dedent(in interface{}) string {
+	return gompstrings.Dedent(conv.ToString(in))
+}
+
+// Wrap - wrap lines, with an optional leading width or map of options
+func (StringFuncs) Wrap(args ...interface{}) (string, error) {
+	opts := gompstrings.WrapOpts{}
+
+	switch len(args) {
+	case 1:
+	case 2:
+		m, ok := args[0].(map[string]interface{})
+		if !ok {
+			m = map[string]interface{}{"width": args[0]}
+		}
+
+		for k, v := range m {
+			switch k {
+			case "width":
+				w, err := conv.ToInt(v)
+				if err != nil || w <= 0 {
+					return "", fmt.Errorf("invalid width %v: must be a positive number", v)
+				}
+
+				opts.Width = w
+			case "prefix":
+				opts.Prefix = conv.ToString(v)
+			default:
+				return "", fmt.Errorf("unknown option %q: must be one of width or prefix", k)
+			}
+		}
+	default:
+		return "", fmt.Errorf("wrong number of args: want 1 or 2, got %d", len(args))
+	}
+
+	return gompstrings.Wrap(conv.ToString(args[len(args)-1]), opts), nil
+}
+
+// SortNatural -
+func (StringFuncs) SortNatural(list interface{}) ([]string, error) {
+	l, err := iconv.InterfaceSlice(list)
+	if err != nil {
+		return nil, err
+	}
+
+	return gompstrings.SortNatural(conv.ToStrings(l...)), nil
+}
+
+// Levenshtein -
+func (StringFuncs) Levenshtein(a, b interface{}) int {
+	return gompstrings.Levenshtein(conv.ToString(a), conv.ToString(b))
+}
+
+// Similarity -
+func (StringFuncs) Similarity(a, b interface{}) float64 {
+	return gompstrings.Similarity(conv.ToString(a), conv.ToString(b))
+}
+
+// Table - render a list of maps (or lists) as a text or Markdown table, with
+// options given as an optional leading map
+func (StringFuncs) Table(args ...interface{}) (string, error) {
+	m, in, err := optionsAndInput(args)
+	if err != nil {
+		return "", err
+	}
+
+	var columns []string
+
+	markdown := false
+	showHeader := true
+
+	for k, v := range m {
+		switch k {
+		case "columns":
+			columns = toStringList(v)
+		case "format":
+			switch f := conv.ToString(v); f {
+			case "text":
+			case "markdown":
+				markdown = true
+			default:
+				return "", fmt.Errorf("unknown format %q: must be text or markdown", f)
+			}
+		case "header":
+			showHeader = conv.ToBool(v)
+		default:
+			return "", fmt.Errorf("unknown option %q: must be one of columns, format, or header", k)
+		}
+	}
+
+	list, err := iconv.InterfaceSlice(in)
+	if err != nil {
+		return "", err
+	}
+
+	header, rows, err := tableRows(columns, list)
+	if err != nil {
+		return "", err
+	}
+
+	if !showHeader {
+		header = nil
+	}
+
+	return gompstrings.Table(header, rows, markdown), nil
+}
+
+// tableRows converts a list of maps or lists to rows of cells. The header is
+// the given columns, or for maps, the sorted keys of all maps.
+func tableRows(columns []string, list []interface{}) ([]string, [][]string, error) {
+	if columns == nil {
+		keys := map[string]struct{}{}
+		for _, item := range list {
+			v := reflect.Indirect(reflect.ValueOf(item))
+			if v.Kind() != reflect.Map {
+				continue
+			}
+
+			for _, k := range v.MapKeys() {
+				keys[conv.ToString(k.Interface())] = struct{}{}
+			}
+		}
+
+		for k := range keys {
+			columns = append(columns, k)
+		}
+
+		slices.Sort(columns)
+	}
+
+	rows := make([][]string, len(list))
+	for i, item := range list {
+		v := reflect.Indirect(reflect.ValueOf(item))
+
+		//nolint:exhaustive
+		switch v.Kind() {
+		case reflect.Map:
+			if v.Type().Key().Kind() != reflect.String {
+				return nil, nil, fmt.Errorf("table rows must have string keys, got %T", item)
+			}
+
+			row := make([]string, len(columns))
+			for j, c := range columns {
+				cv := v.MapIndex(reflect.ValueOf(c).Convert(v.Type().Key()))
+				if cv.IsValid() && cv.Interface() != nil {
+					row[j] = conv.ToString(cv.Interface())
+				}
+			}
+
+			rows[i] = row
+		case reflect.Slice, reflect.Array:
+			l, _ := iconv.InterfaceSlice(v.Interface())
+			rows[i] = conv.ToStrings(l...)
+		default:
+			return nil, nil, fmt.Errorf("table rows must be maps or lists, got %T", item)
+		}
+	}
+
+	return columns, rows, nil
+}
+
+// Columnize - align delimited columns in lines of text, with options given as
+// an optional leading map
+func (StringFuncs) Columnize(args ...interface{}) (string, error) {
+	m, in, err := optionsAndInput(args)
+	if err != nil {
+		return "", err
+	}
+
+	delim := "|"
+	gap := 2
+
+	for k, v := range m {
+		switch k {
+		case "delimiter":
+			delim = conv.ToString(v)
+			if delim == "" {
+				return "", fmt.Errorf("delimiter must not be empty")
+			}
+		case "gap":
+			gap, err = conv.ToInt(v)
+			if err != nil || gap < 0 {
+				return "", fmt.Errorf("invalid gap %v: must be a non-negative number", v)
+			}
+		default:
+			return "", fmt.Errorf("unknown option %q: must be one of delimiter or gap", k)
+		}
+	}
+
+	var lines []string
+
+	switch v := in.(type) {
+	case []string:
+		lines = v
+	case []interface{}:
+		lines = conv.ToStrings(v...)
+	default:
+		lines = strings.Split(strings.TrimRight(conv.ToString(in), "\n"), "\n")
+	}
+
+	return gompstrings.Columnize(lines, delim, gap), nil
 }
 
 // Slug -
@@ -313,6 +539,77 @@ func (StringFuncs) Slug(in interface{}) string {
 	return slug.Make(conv.ToString(in))
 }
 
+// Slugify - like Slug, but with options given as an optional leading map
+func (StringFuncs) Slugify(args ...interface{}) (string, error) {
+	m, in, err := optionsAndInput(args)
+	if err != nil {
+		return "", err
+	}
+
+	opts := gompstrings.SlugifyOpts{}
+
+	for k, v := range m {
+		switch k {
+		case "lang":
+			opts.Lang = conv.ToString(v)
+		case "separator":
+			opts.Separator = conv.ToString(v)
+		case "maxLength":
+			opts.MaxLength, err = conv.ToInt(v)
+			if err != nil {
+				return "", fmt.Errorf("invalid maxLength: %w", err)
+			}
+		default:
+			return "", fmt.Errorf("unknown option %q: must be one of lang, separator, or maxLength", k)
+		}
+	}
+
+	return gompstrings.Slugify(conv.ToString(in), opts), nil
+}
+
+// Transliterate -
+func (StringFuncs) Transliterate(in interface{}) string {
+	return gompstrings.Transliterate(conv.ToString(in))
+}
+
+// MarkdownToHTML - renders Markdown as HTML, with options given as an optional
+// leading map
+func (StringFuncs) MarkdownToHTML(args ...interface{}) (string, error) {
+	m, in, err := optionsAndInput(args)
+	if err != nil {
+		return "", err
+	}
+
+	opts := gompstrings.MarkdownOpts{GFM: true}
+
+	for k, v := range m {
+		switch k {
+		case "safe":
+			opts.Unsafe = !conv.ToBool(v)
+		case "gfm":
+			opts.GFM = conv.ToBool(v)
+		case "hardWraps":
+			opts.HardWraps = conv.ToBool(v)
+		default:
+			return "", fmt.Errorf("unknown option %q: must be one of safe, gfm, or hardWraps", k)
+		}
+	}
+
+	return gompstrings.MarkdownToHTML(conv.ToString(in), opts)
+}
+
+// SanitizeHTML -
+func (StringFuncs) SanitizeHTML(args ...interface{}) (string, error) {
+	switch len(args) {
+	case 1:
+		return gompstrings.SanitizeHTML("", conv.ToString(args[0]))
+	case 2:
+		return gompstrings.SanitizeHTML(conv.ToString(args[0]), conv.ToString(args[1]))
+	default:
+		return "", fmt.Errorf("wrong number of args: want 1 or 2, got %d", len(args))
+	}
+}
+
 // Quote -
 func (StringFuncs) Quote(in interface{}) string {
 	return fmt.Sprintf("%q", conv.ToString(in))
@@ -358,6 +655,43 @@ func (StringFuncs) KebabCase(in interface{}) (string, error) {
 	return gompstrings.KebabCase(conv.ToString(in)), nil
 }
 
+// DotCase -
+func (StringFuncs) DotCase(in interface{}) (string, error) {
+	return gompstrings.DotCase(conv.ToString(in)), nil
+}
+
+// TrainCase -
+func (StringFuncs) TrainCase(in interface{}) (string, error) {
+	return gompstrings.TrainCase(conv.ToString(in)), nil
+}
+
+// FlatCase -
+func (StringFuncs) FlatCase(in interface{}) (string, error) {
+	return gompstrings.FlatCase(conv.ToString(in)), nil
+}
+
+// Pluralize - with an optional leading count
+func (StringFuncs) Pluralize(args ...interface{}) (string, error) {
+	switch len(args) {
+	case 1:
+		return gompstrings.Pluralize(conv.ToString(args[0])), nil
+	case 2:
+		n, err := conv.ToInt(args[0])
+		if err != nil {
+			return "", fmt.Errorf("expected count to be a number: %w", err)
+		}
+
+		return gompstrings.PluralizeCount(n, conv.ToString(args[1])), nil
+	default:
+		return "", fmt.Errorf("wrong number of args: want 1 or 2, got %d", len(args))
+	}
+}
+
+// Singularize -
+func (StringFuncs) Singularize(in interface{}) string {
+	return gompstrings.Singularize(conv.ToString(in))
+}
+
 // WordWrap -
 func (StringFuncs) WordWrap(args ...interface{}) (string, error) {
 	if len(args) == 0 || len(args) > 3 {
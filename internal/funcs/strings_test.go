@@ -59,6 +59,55 @@ func TestIndent(t *testing.T) {
 	}
 }
 
+func TestNindentDedent(t *testing.T) {
+	t.Parallel()
+
+	sf := &StringFuncs{}
+
+	out, err := sf.Nindent(2, "foo: 1\nbar: 2\n")
+	require.NoError(t, err)
+	assert.Equal(t, "\n  foo: 1\n  bar: 2", out)
+
+	out, err = sf.Nindent(2, "-", "foo")
+	require.NoError(t, err)
+	assert.Equal(t, "\n--foo", out)
+
+	_, err = sf.Nindent()
+	require.Error(t, err)
+
+	_, err = sf.Nindent(2.5, "foo")
+	require.Error(t, err)
+
+	assert.Equal(t, "a\n b", sf.Dedent("  a\n   b"))
+}
+
+func TestWrap(t *testing.T) {
+	t.Parallel()
+
+	sf := &StringFuncs{}
+
+	out, err := sf.Wrap("a b c")
+	require.NoError(t, err)
+	assert.Equal(t, "a b c", out)
+
+	out, err = sf.Wrap(3, "a b c")
+	require.NoError(t, err)
+	assert.Equal(t, "a b\nc", out)
+
+	out, err = sf.Wrap(map[string]interface{}{"width": "5", "prefix": "# "}, "a b c")
+	require.NoError(t, err)
+	assert.Equal(t, "# a b\n# c", out)
+
+	_, err = sf.Wrap("foo", "a b c")
+	require.Error(t, err)
+
+	_, err = sf.Wrap(map[string]interface{}{"bogus": 1}, "a b c")
+	require.Error(t, err)
+
+	_, err = sf.Wrap(1, 2, "a b c")
+	require.Error(t, err)
+}
+
 func TestTrimPrefix(t *testing.T) {
 	t.Parallel()
 
@@ -123,6 +172,209 @@ func TestAbbrev(t *testing.T) {
 	assert.Equal(t, "...baz...", s)
 }
 
+func TestSortNatural(t *testing.T) {
+	t.Parallel()
+
+	sf := &StringFuncs{}
+
+	out, err := sf.SortNatural([]interface{}{"file10", "file2", 1})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "file2", "file10"}, out)
+
+	_, err = sf.SortNatural("foo")
+	require.Error(t, err)
+}
+
+func TestLevenshteinSimilarity(t *testing.T) {
+	t.Parallel()
+
+	sf := &StringFuncs{}
+
+	assert.Equal(t, 3, sf.Levenshtein("kitten", "sitting"))
+	assert.Equal(t, 1, sf.Levenshtein(10, 100))
+	assert.InDelta(t, 0.75, sf.Similarity("café", "cafe"), 0.0001)
+}
+
+func TestTable(t *testing.T) {
+	t.Parallel()
+
+	sf := &StringFuncs{}
+
+	rows := []interface{}{
+		map[string]interface{}{"name": "web", "port": 80},
+		map[string]interface{}{"name": "db", "port": 5432, "tls": true},
+	}
+
+	out, err := sf.Table(rows)
+	require.NoError(t, err)
+	assert.Equal(t, "name  port  tls\nweb   80\ndb    5432  true", out)
+
+	out, err = sf.Table(map[string]interface{}{
+		"columns": []interface{}{"port", "name"},
+		"format":  "markdown",
+	}, rows)
+	require.NoError(t, err)
+	assert.Equal(t, "| port | name |\n| ---- | ---- |\n| 80   | web  |\n| 5432 | db   |", out)
+
+	out, err = sf.Table(map[string]interface{}{"header": false}, [][]interface{}{{"a", 1}, {"bb", 2}})
+	require.NoError(t, err)
+	assert.Equal(t, "a   1\nbb  2", out)
+
+	out, err = sf.Table(map[string]interface{}{"columns": "x"}, []map[interface{}]string{{"x": "1"}})
+	require.Error(t, err)
+	assert.Empty(t, out)
+
+	_, err = sf.Table(map[string]interface{}{"format": "html"}, rows)
+	require.Error(t, err)
+
+	_, err = sf.Table(map[string]interface{}{"bogus": 1}, rows)
+	require.Error(t, err)
+
+	_, err = sf.Table([]interface{}{1, 2})
+	require.Error(t, err)
+
+	_, err = sf.Table("foo")
+	require.Error(t, err)
+}
+
+func TestColumnize(t *testing.T) {
+	t.Parallel()
+
+	sf := &StringFuncs{}
+
+	out, err := sf.Columnize("a | b\nccc | d\n")
+	require.NoError(t, err)
+	assert.Equal(t, "a    b\nccc  d", out)
+
+	out, err = sf.Columnize(map[string]interface{}{"delimiter": ",", "gap": 1}, []interface{}{"a,b", "ccc,d"})
+	require.NoError(t, err)
+	assert.Equal(t, "a   b\nccc d", out)
+
+	_, err = sf.Columnize(map[string]interface{}{"delimiter": ""}, "a")
+	require.Error(t, err)
+
+	_, err = sf.Columnize(map[string]interface{}{"gap": -1}, "a")
+	require.Error(t, err)
+
+	_, err = sf.Columnize(map[string]interface{}{"bogus": 1}, "a")
+	require.Error(t, err)
+}
+
+func TestMoreCaseFuncs(t *testing.T) {
+	t.Parallel()
+
+	sf := &StringFuncs{}
+
+	s, err := sf.DotCase("fooBar baz")
+	require.NoError(t, err)
+	assert.Equal(t, "foo.bar.baz", s)
+
+	s, err = sf.TrainCase("fooBar baz")
+	require.NoError(t, err)
+	assert.Equal(t, "Foo-Bar-Baz", s)
+
+	s, err = sf.FlatCase("fooBar baz")
+	require.NoError(t, err)
+	assert.Equal(t, "foobarbaz", s)
+}
+
+func TestPluralize(t *testing.T) {
+	t.Parallel()
+
+	sf := &StringFuncs{}
+
+	s, err := sf.Pluralize("box")
+	require.NoError(t, err)
+	assert.Equal(t, "boxes", s)
+
+	s, err = sf.Pluralize("1", "boxes")
+	require.NoError(t, err)
+	assert.Equal(t, "box", s)
+
+	s, err = sf.Pluralize(3, "box")
+	require.NoError(t, err)
+	assert.Equal(t, "boxes", s)
+
+	_, err = sf.Pluralize("foo", "box")
+	require.Error(t, err)
+
+	_, err = sf.Pluralize()
+	require.Error(t, err)
+
+	assert.Equal(t, "person", sf.Singularize("people"))
+}
+
+func TestSlugify(t *testing.T) {
+	t.Parallel()
+
+	sf := &StringFuncs{}
+
+	s, err := sf.Slugify("Grüße, Welt!")
+	require.NoError(t, err)
+	assert.Equal(t, "grusse-welt", s)
+
+	s, err = sf.Slugify(map[string]interface{}{
+		"lang": "de", "separator": "_", "maxLength": "12",
+	}, "Grüße aus der Welt")
+	require.NoError(t, err)
+	assert.Equal(t, "gruesse_aus", s)
+
+	_, err = sf.Slugify(map[string]interface{}{"maxLength": "foo"}, "a")
+	require.Error(t, err)
+
+	_, err = sf.Slugify(map[string]interface{}{"bogus": true}, "a")
+	require.Error(t, err)
+
+	_, err = sf.Slugify("foo", "bar")
+	require.Error(t, err)
+
+	assert.Equal(t, "Unicode", sf.Transliterate("Ünïcödé"))
+}
+
+func TestMarkdownToHTML(t *testing.T) {
+	t.Parallel()
+
+	sf := &StringFuncs{}
+
+	s, err := sf.MarkdownToHTML("# Hi\n\n~~old~~ <b>new</b>")
+	require.NoError(t, err)
+	assert.Equal(t, "<h1 id=\"hi\">Hi</h1>\n<p><del>old</del> <!-- raw HTML omitted -->new<!-- raw HTML omitted --></p>\n", s)
+
+	s, err = sf.MarkdownToHTML(map[string]interface{}{
+		"safe": false, "gfm": "false", "hardWraps": true,
+	}, "~~old~~\n<b>new</b>")
+	require.NoError(t, err)
+	assert.Equal(t, "<p>~~old~~<br>\n<b>new</b></p>\n", s)
+
+	_, err = sf.MarkdownToHTML(map[string]interface{}{"bogus": true}, "a")
+	require.Error(t, err)
+
+	_, err = sf.MarkdownToHTML()
+	require.Error(t, err)
+}
+
+func TestSanitizeHTML(t *testing.T) {
+	t.Parallel()
+
+	sf := &StringFuncs{}
+
+	in := `<p onclick="evil()">hi <b>there</b></p><script>alert(1)</script>`
+
+	s, err := sf.SanitizeHTML(in)
+	require.NoError(t, err)
+	assert.Equal(t, "<p>hi <b>there</b></p>", s)
+
+	s, err = sf.SanitizeHTML("strict", in)
+	require.NoError(t, err)
+	assert.Equal(t, "hi there", s)
+
+	_, err = sf.SanitizeHTML("bogus", in)
+	require.Error(t, err)
+
+	_, err = sf.SanitizeHTML()
+	require.Error(t, err)
+}
+
 func TestSlug(t *testing.T) {
 	sf := &StringFuncs{}
 	s := sf.Slug(nil)
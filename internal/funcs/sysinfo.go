@@ -0,0 +1,56 @@
+package funcs
+
+import (
+	"context"
+
+	"github.com/hairyhenderson/gomplate/v4/sysinfo"
+)
+
+// CreateSysInfoFuncs -
+func CreateSysInfoFuncs(ctx context.Context) map[string]interface{} {
+	ns := &SysInfoFuncs{ctx}
+
+	return map[string]interface{}{
+		"sysinfo": func() interface{} { return ns },
+	}
+}
+
+// SysInfoFuncs -
+type SysInfoFuncs struct {
+	ctx context.Context
+}
+
+// Hostname -
+func (SysInfoFuncs) Hostname() (string, error) {
+	return sysinfo.Hostname()
+}
+
+// FQDN -
+func (SysInfoFuncs) FQDN() (string, error) {
+	return sysinfo.FQDN()
+}
+
+// OS -
+func (SysInfoFuncs) OS() string {
+	return sysinfo.OS()
+}
+
+// Arch -
+func (SysInfoFuncs) Arch() string {
+	return sysinfo.Arch()
+}
+
+// NumCPU -
+func (SysInfoFuncs) NumCPU() int {
+	return sysinfo.NumCPU()
+}
+
+// TotalMemory -
+func (SysInfoFuncs) TotalMemory() (uint64, error) {
+	return sysinfo.TotalMemory()
+}
+
+// PrimaryInterface -
+func (SysInfoFuncs) PrimaryInterface() (*sysinfo.Interface, error) {
+	return sysinfo.PrimaryInterface()
+}
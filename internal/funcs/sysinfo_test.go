@@ -0,0 +1,46 @@
+package funcs
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateSysInfoFuncs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateSysInfoFuncs(ctx)
+			actual := fmap["sysinfo"].(func() interface{})
+
+			assert.Equal(t, ctx, actual().(*SysInfoFuncs).ctx)
+		})
+	}
+}
+
+func TestSysInfo(t *testing.T) {
+	t.Parallel()
+
+	s := SysInfoFuncs{}
+
+	expected, err := os.Hostname()
+	require.NoError(t, err)
+
+	h, err := s.Hostname()
+	require.NoError(t, err)
+	assert.Equal(t, expected, h)
+
+	assert.Equal(t, runtime.GOOS, s.OS())
+	assert.Equal(t, runtime.GOARCH, s.Arch())
+	assert.Equal(t, runtime.NumCPU(), s.NumCPU())
+}
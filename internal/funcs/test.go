@@ -3,12 +3,19 @@ package funcs
 import (
 	"context"
 	"fmt"
+	"net"
 	"reflect"
+	gotime "time"
 
 	"github.com/hairyhenderson/gomplate/v4/conv"
 	"github.com/hairyhenderson/gomplate/v4/test"
+	"github.com/hairyhenderson/gomplate/v4/time"
 )
 
+// defaultReachableTimeout is the timeout for TCPReachable and HTTPStatus when
+// none is given
+const defaultReachableTimeout = 5 * gotime.Second
+
 // CreateTestFuncs -
 func CreateTestFuncs(ctx context.Context) map[string]interface{} {
 	f := map[string]interface{}{}
@@ -102,3 +109,75 @@ func (f TestFuncs) IsKind(kind string, arg interface{}) bool {
 	}
 	return k == kind
 }
+
+// TCPReachable -
+func (f TestFuncs) TCPReachable(args ...interface{}) (bool, error) {
+	if len(args) < 2 || len(args) > 3 {
+		return false, fmt.Errorf("wrong number of args: want 2 or 3, got %d", len(args))
+	}
+
+	timeout := defaultReachableTimeout
+	if len(args) == 3 {
+		var err error
+
+		timeout, err = timeoutArg(args[2])
+		if err != nil {
+			return false, err
+		}
+	}
+
+	addr := net.JoinHostPort(conv.ToString(args[0]), conv.ToString(args[1]))
+
+	return test.TCPReachable(f.ctx, addr, timeout), nil
+}
+
+// HTTPStatus -
+func (f TestFuncs) HTTPStatus(args ...interface{}) (int, error) {
+	timeout := defaultReachableTimeout
+
+	switch len(args) {
+	case 1:
+	case 2:
+		var err error
+
+		timeout, err = timeoutArg(args[0])
+		if err != nil {
+			return 0, err
+		}
+	default:
+		return 0, fmt.Errorf("wrong number of args: want 1 or 2, got %d", len(args))
+	}
+
+	return test.HTTPStatus(f.ctx, conv.ToString(args[len(args)-1]), timeout)
+}
+
+// timeoutArg converts a duration, a duration string, or a number of seconds
+// to a duration
+func timeoutArg(v interface{}) (gotime.Duration, error) {
+	var d gotime.Duration
+
+	switch v := v.(type) {
+	case gotime.Duration:
+		d = v
+	case string:
+		var err error
+
+		d, err = time.ParseDuration(v)
+		if err != nil {
+			return 0, fmt.Errorf("invalid timeout: %w", err)
+		}
+	default:
+		n, err := conv.ToFloat64(v)
+		if err != nil {
+			return 0, fmt.Errorf("invalid timeout: must be a duration or a number of seconds")
+		}
+
+		d = gotime.Duration(n * float64(gotime.Second))
+	}
+
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid timeout %s: must be positive", d)
+	}
+
+	return d, nil
+}
@@ -2,8 +2,12 @@ package funcs
 
 import (
 	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"testing"
+	gotime "time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -172,3 +176,62 @@ func TestIsKind(t *testing.T) {
 		assert.False(t, f.IsKind(d.kind, d.arg))
 	}
 }
+
+func TestTCPReachable(t *testing.T) {
+	t.Parallel()
+
+	f := TestFuncs{ctx: context.Background()}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer l.Close()
+
+	host, port, _ := net.SplitHostPort(l.Addr().String())
+
+	ok, err := f.TCPReachable(host, port)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = f.TCPReachable(host, port, "1s")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = f.TCPReachable("127.0.0.1", 1, 0.5)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = f.TCPReachable(host, port, "soon")
+	require.Error(t, err)
+
+	_, err = f.TCPReachable(host, port, -1)
+	require.Error(t, err)
+
+	_, err = f.TCPReachable(host)
+	require.Error(t, err)
+}
+
+func TestHTTPStatus(t *testing.T) {
+	t.Parallel()
+
+	f := TestFuncs{ctx: context.Background()}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	status, err := f.HTTPStatus(srv.URL)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+
+	status, err = f.HTTPStatus(gotime.Second, srv.URL)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+
+	_, err = f.HTTPStatus("x", srv.URL)
+	require.Error(t, err)
+
+	_, err = f.HTTPStatus()
+	require.Error(t, err)
+}
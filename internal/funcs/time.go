@@ -10,6 +10,7 @@ import (
 
 	"github.com/hairyhenderson/gomplate/v4/conv"
 	"github.com/hairyhenderson/gomplate/v4/env"
+	iconv "github.com/hairyhenderson/gomplate/v4/internal/conv"
 	"github.com/hairyhenderson/gomplate/v4/time"
 )
 
@@ -166,7 +167,22 @@ func (TimeFuncs) Hour(n interface{}) (gotime.Duration, error) {
 
 // ParseDuration -
 func (TimeFuncs) ParseDuration(n interface{}) (gotime.Duration, error) {
-	return gotime.ParseDuration(conv.ToString(n))
+	return time.ParseDuration(conv.ToString(n))
+}
+
+// FormatDuration -
+func (TimeFuncs) FormatDuration(in interface{}) (string, error) {
+	d, ok := in.(gotime.Duration)
+	if !ok {
+		var err error
+
+		d, err = time.ParseDuration(conv.ToString(in))
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return time.FormatDuration(d), nil
 }
 
 // Since -
@@ -175,8 +191,137 @@ func (TimeFuncs) Since(n gotime.Time) gotime.Duration {
 }
 
 // Until -
-func (TimeFuncs) Until(n gotime.Time) gotime.Duration {
-	return gotime.Until(n)
+func (TimeFuncs) Until(n interface{}) (gotime.Duration, error) {
+	t, err := timeArg(n)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time: %w", err)
+	}
+
+	return gotime.Until(t), nil
+}
+
+// AddBusinessDays -
+func (TimeFuncs) AddBusinessDays(n interface{}, args ...interface{}) (gotime.Time, error) {
+	days, err := conv.ToInt(n)
+	if err != nil {
+		return gotime.Time{}, fmt.Errorf("number of days must be an integer: %w", err)
+	}
+
+	var holidays []gotime.Time
+
+	switch len(args) {
+	case 1:
+	case 2:
+		list, err := iconv.InterfaceSlice(args[0])
+		if err != nil {
+			return gotime.Time{}, fmt.Errorf("holidays must be a list: %w", err)
+		}
+
+		holidays = make([]gotime.Time, len(list))
+		for i, h := range list {
+			holidays[i], err = dateArg(h)
+			if err != nil {
+				return gotime.Time{}, fmt.Errorf("invalid holiday %v: %w", h, err)
+			}
+		}
+	default:
+		return gotime.Time{}, fmt.Errorf("wrong number of args: want 2 or 3, got %d", len(args)+1)
+	}
+
+	t, err := timeArg(args[len(args)-1])
+	if err != nil {
+		return gotime.Time{}, fmt.Errorf("invalid time: %w", err)
+	}
+
+	return time.AddBusinessDays(t, days, holidays), nil
+}
+
+// dateArg converts a date (in YYYY-MM-DD form) or a time to a time
+func dateArg(v interface{}) (gotime.Time, error) {
+	if s, ok := v.(string); ok {
+		if t, err := gotime.Parse(gotime.DateOnly, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return timeArg(v)
+}
+
+// In -
+func (TimeFuncs) In(zone string, t interface{}) (gotime.Time, error) {
+	tm, err := timeArg(t)
+	if err != nil {
+		return gotime.Time{}, fmt.Errorf("invalid time: %w", err)
+	}
+
+	return time.In(zone, tm)
+}
+
+// ZoneInfo -
+func (TimeFuncs) ZoneInfo(zone string, args ...interface{}) (map[string]interface{}, error) {
+	t, err := optionalTimeArg(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return time.ZoneInfo(zone, t)
+}
+
+// FormatLocale -
+func (TimeFuncs) FormatLocale(locale, layout string, t interface{}) (string, error) {
+	tm, err := timeArg(t)
+	if err != nil {
+		return "", fmt.Errorf("invalid time: %w", err)
+	}
+
+	return time.FormatLocale(locale, layout, tm)
+}
+
+// CronNext -
+func (TimeFuncs) CronNext(expr string, args ...interface{}) (gotime.Time, error) {
+	t, err := optionalTimeArg(args)
+	if err != nil {
+		return gotime.Time{}, err
+	}
+
+	return time.CronNext(expr, t)
+}
+
+// CronPrev -
+func (TimeFuncs) CronPrev(expr string, args ...interface{}) (gotime.Time, error) {
+	t, err := optionalTimeArg(args)
+	if err != nil {
+		return gotime.Time{}, err
+	}
+
+	return time.CronPrev(expr, t)
+}
+
+// CronMatches -
+func (TimeFuncs) CronMatches(expr string, args ...interface{}) (bool, error) {
+	t, err := optionalTimeArg(args)
+	if err != nil {
+		return false, err
+	}
+
+	return time.CronMatches(expr, t)
+}
+
+// optionalTimeArg returns the optional time argument, defaulting to now
+func optionalTimeArg(args []interface{}) (gotime.Time, error) {
+	switch len(args) {
+	case 0:
+		return gotime.Now(), nil
+	case 1:
+		t, err := timeArg(args[0])
+		if err != nil {
+			return gotime.Time{}, fmt.Errorf("invalid time: %w", err)
+		}
+
+		return t, nil
+	default:
+		return gotime.Time{}, fmt.Errorf("wrong number of args: want 1 or 2, got %d", len(args)+1)
+	}
 }
 
 // convert a number input to a pair of int64s, representing the integer portion and the decimal remainder
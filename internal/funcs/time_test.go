@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"strconv"
 	"testing"
+	gotime "time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -80,3 +81,127 @@ func TestParseNum(t *testing.T) {
 	assert.Zero(t, f)
 	require.NoError(t, err)
 }
+
+func TestCron(t *testing.T) {
+	t.Parallel()
+
+	tf := &TimeFuncs{}
+	from := gotime.Date(2024, 5, 1, 12, 30, 15, 0, gotime.UTC)
+
+	next, err := tf.CronNext("0 3 * * *", from)
+	require.NoError(t, err)
+	assert.Equal(t, gotime.Date(2024, 5, 2, 3, 0, 0, 0, gotime.UTC), next)
+
+	next, err = tf.CronNext("0 3 * * *", "2024-05-01T12:30:15Z")
+	require.NoError(t, err)
+	assert.Equal(t, gotime.Date(2024, 5, 2, 3, 0, 0, 0, gotime.UTC), next.UTC())
+
+	next, err = tf.CronNext("* * * * *")
+	require.NoError(t, err)
+	assert.WithinDuration(t, gotime.Now(), next, gotime.Minute)
+
+	prev, err := tf.CronPrev("0 3 * * *", from.Unix())
+	require.NoError(t, err)
+	assert.Equal(t, gotime.Date(2024, 5, 1, 3, 0, 0, 0, gotime.UTC), prev.UTC())
+
+	ok, err := tf.CronMatches("* 12 * * WED", from)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = tf.CronMatches("* 12 * * THU", from)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = tf.CronNext("0 3 * * *", "not a time")
+	require.Error(t, err)
+
+	_, err = tf.CronMatches("0 3 * * *", from, from)
+	require.Error(t, err)
+}
+
+func TestZones(t *testing.T) {
+	t.Parallel()
+
+	tf := &TimeFuncs{}
+	in := gotime.Date(2024, 7, 1, 12, 0, 0, 0, gotime.UTC)
+
+	out, err := tf.In("Asia/Tokyo", in)
+	require.NoError(t, err)
+	assert.Equal(t, "2024-07-01T21:00:00+09:00", out.Format(gotime.RFC3339))
+
+	out, err = tf.In("Asia/Tokyo", "2024-07-01T12:00:00Z")
+	require.NoError(t, err)
+	assert.Equal(t, "2024-07-01T21:00:00+09:00", out.Format(gotime.RFC3339))
+
+	_, err = tf.In("Nowhere/Special", in)
+	require.Error(t, err)
+
+	info, err := tf.ZoneInfo("Europe/London", in)
+	require.NoError(t, err)
+	assert.Equal(t, "BST", info["abbreviation"])
+	assert.Equal(t, "+01:00", info["offsetString"])
+
+	info, err = tf.ZoneInfo("UTC")
+	require.NoError(t, err)
+	assert.Equal(t, 0, info["offset"])
+
+	s, err := tf.FormatLocale("it", "Monday 2 January", in)
+	require.NoError(t, err)
+	assert.Equal(t, "lunedì 1 luglio", s)
+
+	_, err = tf.FormatLocale("it", "Monday", "not a time")
+	require.Error(t, err)
+}
+
+func TestBusinessDaysAndDurations(t *testing.T) {
+	t.Parallel()
+
+	tf := &TimeFuncs{}
+
+	// a Friday
+	fri := gotime.Date(2024, 12, 20, 9, 0, 0, 0, gotime.UTC)
+
+	out, err := tf.AddBusinessDays(1, fri)
+	require.NoError(t, err)
+	assert.Equal(t, gotime.Date(2024, 12, 23, 9, 0, 0, 0, gotime.UTC), out)
+
+	out, err = tf.AddBusinessDays("3", []interface{}{"2024-12-24", gotime.Date(2024, 12, 25, 0, 0, 0, 0, gotime.UTC)}, fri)
+	require.NoError(t, err)
+	assert.Equal(t, gotime.Date(2024, 12, 27, 9, 0, 0, 0, gotime.UTC), out)
+
+	out, err = tf.AddBusinessDays(-1, "2024-12-23T09:00:00Z")
+	require.NoError(t, err)
+	assert.Equal(t, fri, out.UTC())
+
+	_, err = tf.AddBusinessDays("x", fri)
+	require.Error(t, err)
+
+	_, err = tf.AddBusinessDays(1, []string{"christmas"}, fri)
+	require.Error(t, err)
+
+	_, err = tf.AddBusinessDays(1)
+	require.Error(t, err)
+
+	d, err := tf.ParseDuration("3d12h")
+	require.NoError(t, err)
+	assert.Equal(t, 84*gotime.Hour, d)
+
+	s, err := tf.FormatDuration(84 * gotime.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, "3d12h", s)
+
+	s, err = tf.FormatDuration("36h")
+	require.NoError(t, err)
+	assert.Equal(t, "1d12h", s)
+
+	_, err = tf.FormatDuration("bogus")
+	require.Error(t, err)
+
+	d, err = tf.Until(gotime.Now().Add(gotime.Hour))
+	require.NoError(t, err)
+	assert.InDelta(t, gotime.Hour, d, float64(gotime.Minute))
+
+	d, err = tf.Until(gotime.Now().Add(-gotime.Hour).Format(gotime.RFC3339))
+	require.NoError(t, err)
+	assert.Less(t, d, gotime.Duration(0))
+}
@@ -0,0 +1,60 @@
+package funcs
+
+import (
+	"context"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+	"github.com/hairyhenderson/gomplate/v4/tmpl"
+)
+
+// CreateTmplFuncs - the tmpl functions need the template being rendered, so
+// are created separately for each template
+func CreateTmplFuncs(ctx context.Context, t *tmpl.Template) map[string]interface{} {
+	ns := &TmplFuncs{ctx: ctx, t: t}
+
+	f := map[string]interface{}{
+		"tmpl": func() interface{} { return ns },
+		"tpl":  ns.Inline,
+	}
+
+	// Helm's include function, which replaces the datasource include function
+	// in Sprig compatibility mode
+	if config.SprigEnabled(ctx) {
+		f["include"] = ns.Exec
+	}
+
+	return f
+}
+
+// TmplFuncs -
+type TmplFuncs struct {
+	ctx context.Context
+	t   *tmpl.Template
+}
+
+// Path -
+func (f *TmplFuncs) Path() (string, error) {
+	return f.t.Path()
+}
+
+// PathDir -
+func (f *TmplFuncs) PathDir() (string, error) {
+	return f.t.PathDir()
+}
+
+// Inline -
+func (f *TmplFuncs) Inline(args ...interface{}) (string, error) {
+	return f.t.Inline(args...)
+}
+
+// Exec - the name can be computed (e.g. from data), so that templates can be
+// chosen dynamically
+func (f *TmplFuncs) Exec(name interface{}, tmplcontext ...interface{}) (string, error) {
+	return f.t.Exec(conv.ToString(name), tmplcontext...)
+}
+
+// ExecFromDatasource -
+func (f *TmplFuncs) ExecFromDatasource(alias string, tmplcontext ...interface{}) (string, error) {
+	return f.t.ExecFromDatasource(alias, tmplcontext...)
+}
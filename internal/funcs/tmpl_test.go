@@ -0,0 +1,55 @@
+package funcs
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"text/template"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
+	"github.com/hairyhenderson/gomplate/v4/tmpl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTmplFuncs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateTmplFuncs(ctx, nil)
+			actual := fmap["tmpl"].(func() interface{})
+
+			assert.Equal(t, ctx, actual().(*TmplFuncs).ctx)
+			assert.NotContains(t, fmap, "include")
+		})
+	}
+
+	fmap := CreateTmplFuncs(config.WithSprig(context.Background()), nil)
+	assert.Contains(t, fmap, "include")
+}
+
+func TestTmplExecComputedName(t *testing.T) {
+	t.Parallel()
+
+	root := template.New("root")
+	template.Must(root.New("kind_web").Parse("web"))
+	template.Must(root.New("42").Parse("forty-two"))
+
+	ns := CreateTmplFuncs(context.Background(), tmpl.New(root, nil, ""))["tmpl"].(func() interface{})().(*TmplFuncs)
+
+	out, err := ns.Exec("kind_" + "web")
+	require.NoError(t, err)
+	assert.Equal(t, "web", out)
+
+	out, err = ns.Exec(42)
+	require.NoError(t, err)
+	assert.Equal(t, "forty-two", out)
+
+	_, err = ns.Exec("kind_db")
+	assert.ErrorContains(t, err, `template "kind_db" not defined`)
+}
@@ -2,10 +2,14 @@ package funcs
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/hairyhenderson/gomplate/v4/conv"
 
 	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+	"github.com/segmentio/ksuid"
 )
 
 // CreateUUIDFuncs -
@@ -40,6 +44,64 @@ func (UUIDFuncs) V4() (string, error) {
 	return u.String(), nil
 }
 
+// V7 - return a version 7 UUID (based on the current time, with random bits),
+// which sorts in order of creation
+func (UUIDFuncs) V7() (string, error) {
+	u, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// ULID - return a Universally Unique Lexicographically Sortable Identifier
+func (UUIDFuncs) ULID() string {
+	return ulid.Make().String()
+}
+
+// KSUID - return a K-Sortable Unique IDentifier
+func (UUIDFuncs) KSUID() (string, error) {
+	k, err := ksuid.NewRandom()
+	if err != nil {
+		return "", err
+	}
+	return k.String(), nil
+}
+
+// Time - return the timestamp embedded in a time-based UUID (version 1, 6, or
+// 7), ULID, or KSUID. The type of identifier is detected from its length.
+func (f UUIDFuncs) Time(in interface{}) (time.Time, error) {
+	s := conv.ToString(in)
+
+	switch len(s) {
+	case ulid.EncodedSize:
+		id, err := ulid.ParseStrict(s)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid ULID %q: %w", s, err)
+		}
+		return ulid.Time(id.Time()).UTC(), nil
+	case 27: // the length of a string-encoded KSUID
+		k, err := ksuid.Parse(s)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid KSUID %q: %w", s, err)
+		}
+		return k.Time().UTC(), nil
+	}
+
+	u, err := f.Parse(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	switch u.Version() {
+	case 1, 2, 6, 7:
+		sec, nsec := u.Time().UnixTime()
+		return time.Unix(sec, nsec).UTC(), nil
+	default:
+		return time.Time{}, fmt.Errorf("version %d UUIDs do not contain a timestamp", u.Version())
+	}
+}
+
 // Nil -
 func (UUIDFuncs) Nil() (string, error) {
 	return uuid.Nil.String(), nil
@@ -5,6 +5,7 @@ import (
 	"net/url"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -30,6 +31,7 @@ func TestCreateUUIDFuncs(t *testing.T) {
 const (
 	uuidV1Pattern = "^[[:xdigit:]]{8}-[[:xdigit:]]{4}-1[[:xdigit:]]{3}-[89ab][[:xdigit:]]{3}-[[:xdigit:]]{12}$"
 	uuidV4Pattern = "^[[:xdigit:]]{8}-[[:xdigit:]]{4}-4[[:xdigit:]]{3}-[89ab][[:xdigit:]]{3}-[[:xdigit:]]{12}$"
+	uuidV7Pattern = "^[[:xdigit:]]{8}-[[:xdigit:]]{4}-7[[:xdigit:]]{3}-[89ab][[:xdigit:]]{3}-[[:xdigit:]]{12}$"
 )
 
 func TestV1(t *testing.T) {
@@ -50,6 +52,82 @@ func TestV4(t *testing.T) {
 	assert.Regexp(t, uuidV4Pattern, i)
 }
 
+func TestV7(t *testing.T) {
+	t.Parallel()
+
+	u := UUIDFuncs{ctx: context.Background()}
+	i, err := u.V7()
+	require.NoError(t, err)
+	assert.Regexp(t, uuidV7Pattern, i)
+}
+
+func TestULID(t *testing.T) {
+	t.Parallel()
+
+	u := UUIDFuncs{ctx: context.Background()}
+	assert.Regexp(t, "^[0-9A-HJKMNP-TV-Z]{26}$", u.ULID())
+}
+
+func TestKSUID(t *testing.T) {
+	t.Parallel()
+
+	u := UUIDFuncs{ctx: context.Background()}
+	i, err := u.KSUID()
+	require.NoError(t, err)
+	assert.Regexp(t, "^[0-9A-Za-z]{27}$", i)
+}
+
+func TestUUIDTime(t *testing.T) {
+	t.Parallel()
+
+	u := UUIDFuncs{ctx: context.Background()}
+
+	testdata := []struct {
+		in       string
+		expected time.Time
+	}{
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAV", time.Date(2016, 7, 30, 23, 54, 10, 259000000, time.UTC)},
+		{"0ujtsYcgvSTl8PAuAdqWYSMnLOv", time.Date(2017, 10, 10, 4, 0, 47, 0, time.UTC)},
+		{"01937c3a-24a0-7e1f-8d4e-4f3a7b2c1d0e", time.Date(2024, 11, 30, 8, 40, 5, 536000000, time.UTC)},
+		{"c232ab00-9414-11ec-b3c8-9f6bdeced846", time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)},
+	}
+
+	for _, d := range testdata {
+		out, err := u.Time(d.in)
+		require.NoError(t, err, d.in)
+		assert.Equal(t, d.expected, out, d.in)
+	}
+
+	// times of new identifiers are close to now
+	now := time.Now()
+
+	ids := []string{u.ULID()}
+	i, _ := u.V7()
+	ids = append(ids, i)
+	i, _ = u.KSUID()
+	ids = append(ids, i)
+	i, _ = u.V1()
+	ids = append(ids, i)
+
+	for _, id := range ids {
+		out, err := u.Time(id)
+		require.NoError(t, err, id)
+		assert.WithinDuration(t, now, out, 2*time.Second, id)
+	}
+
+	_, err := u.Time("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	require.Error(t, err)
+
+	_, err = u.Time("not an id")
+	require.Error(t, err)
+
+	_, err = u.Time("!!ARZ3NDEKTSV4RRFFQ69G5FAV")
+	require.Error(t, err)
+
+	_, err = u.Time("!!jtsYcgvSTl8PAuAdqWYSMnLOv")
+	require.Error(t, err)
+}
+
 func TestNil(t *testing.T) {
 	t.Parallel()
 
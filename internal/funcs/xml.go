@@ -0,0 +1,71 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/internal/parsers"
+)
+
+// CreateXMLFuncs -
+func CreateXMLFuncs(ctx context.Context) map[string]interface{} {
+	ns := &XMLFuncs{ctx}
+
+	return map[string]interface{}{
+		"xml": func() interface{} { return ns },
+	}
+}
+
+// XMLFuncs -
+type XMLFuncs struct {
+	ctx context.Context
+}
+
+// XPath - evaluates an XPath expression against an XML document (or a value
+// parsed from one), optionally with a map of namespace prefixes before the
+// input
+func (XMLFuncs) XPath(expr string, args ...interface{}) (interface{}, error) {
+	var namespaces map[string]string
+
+	var in interface{}
+
+	switch len(args) {
+	case 1:
+		in = args[0]
+	case 2:
+		m, ok := args[0].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected a map of namespaces, got %T", args[0])
+		}
+
+		namespaces = make(map[string]string, len(m))
+		for k, v := range m {
+			namespaces[k] = conv.ToString(v)
+		}
+
+		in = args[1]
+	default:
+		return nil, fmt.Errorf("wrong number of args: want 2 or 3, got %d", len(args)+1)
+	}
+
+	doc, err := xmlDocument(in)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsers.XPath(doc, expr, namespaces)
+}
+
+// xmlDocument returns the input as an XML document - strings are used as-is,
+// and other values (such as maps parsed by data.XML) are marshalled to XML
+func xmlDocument(in interface{}) (string, error) {
+	switch in := in.(type) {
+	case string:
+		return in, nil
+	case []byte:
+		return string(in), nil
+	}
+
+	return parsers.ToXML(in, parsers.XMLOptions{})
+}
@@ -0,0 +1,68 @@
+package funcs
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateXMLFuncs(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 10; i++ {
+		// Run this a bunch to catch race conditions
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fmap := CreateXMLFuncs(ctx)
+			actual := fmap["xml"].(func() interface{})
+
+			assert.Equal(t, ctx, actual().(*XMLFuncs).ctx)
+		})
+	}
+}
+
+func TestXPath(t *testing.T) {
+	t.Parallel()
+
+	x := XMLFuncs{}
+
+	doc := `<services><service name="web"><port>80</port></service><service name="api"><port>8080</port></service></services>`
+
+	out, err := x.XPath("//service[@name='api']/port", doc)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"8080"}, out)
+
+	out, err = x.XPath("//service[@name='api']/port", []byte(doc))
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"8080"}, out)
+
+	// parsed documents are accepted too
+	parsed := map[string]interface{}{
+		"services": map[string]interface{}{
+			"service": []interface{}{
+				map[string]interface{}{"@name": "web", "port": "80"},
+				map[string]interface{}{"@name": "api", "port": "8080"},
+			},
+		},
+	}
+
+	out, err = x.XPath("//service/@name", parsed)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"web", "api"}, out)
+
+	out, err = x.XPath("//s:port", map[string]interface{}{"s": "urn:services"},
+		`<services xmlns="urn:services"><port>80</port></services>`)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"80"}, out)
+
+	_, err = x.XPath("//port")
+	require.Error(t, err)
+
+	_, err = x.XPath("//port", "s", doc)
+	require.ErrorContains(t, err, "expected a map of namespaces")
+}
@@ -0,0 +1,193 @@
+// Package i18n contains functions for loading message catalogs and looking up
+// translated messages.
+package i18n
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/chai2010/gettext-go/mo"
+	"github.com/chai2010/gettext-go/plural"
+	"github.com/chai2010/gettext-go/po"
+)
+
+// Catalog is a set of translated messages for a single locale
+type Catalog struct {
+	// plural selects the plural form to use for a given count
+	plural func(n int) int
+	// messages maps message IDs to their translations - plural messages
+	// have one translation per plural form
+	messages map[string][]string
+	// Locale is the catalog's locale (e.g. "de" or "pt_BR")
+	Locale string
+}
+
+// Parse parses a message catalog for the given locale. The format is detected
+// from the content: gettext .mo files are recognized by their magic number,
+// JSON objects by a leading '{', and anything else is parsed as a gettext .po
+// file.
+func Parse(locale string, b []byte) (*Catalog, error) {
+	trimmed := bytes.TrimSpace(b)
+
+	switch {
+	case isMO(b):
+		return ParseMO(locale, b)
+	case len(trimmed) > 0 && trimmed[0] == '{':
+		return ParseJSON(locale, b)
+	default:
+		return ParsePO(locale, b)
+	}
+}
+
+func isMO(b []byte) bool {
+	if len(b) < 4 {
+		return false
+	}
+
+	magic := binary.LittleEndian.Uint32(b)
+
+	return magic == mo.MoMagicLittleEndian || magic == mo.MoMagicBigEndian
+}
+
+// ParsePO parses a gettext .po file
+func ParsePO(locale string, b []byte) (*Catalog, error) {
+	f, err := po.Load(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse .po catalog: %w", err)
+	}
+
+	c := newCatalog(locale, f.MimeHeader.Language)
+	for _, m := range f.Messages {
+		c.add(m.MsgContext, m.MsgId, m.MsgStr, m.MsgStrPlural)
+	}
+
+	return c, nil
+}
+
+// ParseMO parses a compiled gettext .mo file
+func ParseMO(locale string, b []byte) (*Catalog, error) {
+	f, err := mo.Load(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse .mo catalog: %w", err)
+	}
+
+	c := newCatalog(locale, f.MimeHeader.Language)
+	for _, m := range f.Messages {
+		c.add(m.MsgContext, m.MsgId, m.MsgStr, m.MsgStrPlural)
+	}
+
+	return c, nil
+}
+
+// ParseJSON parses a JSON catalog - an object mapping message IDs to either
+// a translated string, or to an array of translations (one per plural form).
+func ParseJSON(locale string, b []byte) (*Catalog, error) {
+	raw := map[string]interface{}{}
+
+	err := json.Unmarshal(b, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JSON catalog: %w", err)
+	}
+
+	c := newCatalog(locale, "")
+
+	for k, v := range raw {
+		switch v := v.(type) {
+		case string:
+			c.add("", k, v, nil)
+		case []interface{}:
+			forms := make([]string, len(v))
+			for i, f := range v {
+				s, ok := f.(string)
+				if !ok {
+					return nil, fmt.Errorf("invalid plural form %d for message %q: expected a string, got %T", i, k, f)
+				}
+
+				forms[i] = s
+			}
+
+			c.add("", k, "", forms)
+		default:
+			return nil, fmt.Errorf("invalid translation for message %q: expected a string or an array of strings, got %T", k, v)
+		}
+	}
+
+	return c, nil
+}
+
+// newCatalog creates an empty catalog. The plural rules are the standard
+// gettext rules for the catalog's language, given either by the locale or
+// (when the locale is empty) the catalog's Language header.
+func newCatalog(locale, lang string) *Catalog {
+	if locale == "" {
+		locale = lang
+	}
+
+	locale = NormalizeLocale(locale)
+
+	return &Catalog{
+		Locale:   locale,
+		plural:   plural.Formula(locale),
+		messages: map[string][]string{},
+	}
+}
+
+// NormalizeLocale converts a locale identifier to the gettext form, so that
+// "pt-BR" becomes "pt_BR"
+func NormalizeLocale(locale string) string {
+	return strings.ReplaceAll(strings.TrimSpace(locale), "-", "_")
+}
+
+func (c *Catalog) add(msgctxt, msgid, msgstr string, forms []string) {
+	// untranslated messages are left out so lookups fall back to the ID
+	if msgid == "" || (msgstr == "" && len(forms) == 0) {
+		return
+	}
+
+	if len(forms) == 0 {
+		forms = []string{msgstr}
+	}
+
+	// messages with a context (msgctxt) are kept apart from those without,
+	// so that they don't replace the context-free translations
+	if msgctxt != "" {
+		msgid = msgctxt + "\x04" + msgid
+	}
+
+	c.messages[msgid] = forms
+}
+
+// T returns the translation of msgid, or msgid itself when there is no
+// translation
+func (c *Catalog) T(msgid string) string {
+	if c != nil {
+		if forms, ok := c.messages[msgid]; ok && forms[0] != "" {
+			return forms[0]
+		}
+	}
+
+	return msgid
+}
+
+// TN returns the plural form of the translation of msgid that is appropriate
+// for the count n. When there is no translation, msgid is returned if n is 1,
+// otherwise msgidPlural is returned.
+func (c *Catalog) TN(msgid, msgidPlural string, n int) string {
+	if c != nil {
+		if forms, ok := c.messages[msgid]; ok {
+			i := c.plural(n)
+			if i >= 0 && i < len(forms) && forms[i] != "" {
+				return forms[i]
+			}
+		}
+	}
+
+	if n == 1 {
+		return msgid
+	}
+
+	return msgidPlural
+}
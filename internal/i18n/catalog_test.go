@@ -0,0 +1,142 @@
+package i18n
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/chai2010/gettext-go/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPO = `msgid ""
+msgstr ""
+"Language: de\n"
+"Content-Type: text/plain; charset=UTF-8\n"
+"Plural-Forms: nplurals=2; plural=(n != 1);\n"
+
+msgid "Hello"
+msgstr "Hallo"
+
+msgctxt "menu"
+msgid "Open"
+msgstr "Öffnen"
+
+msgid "Untranslated"
+msgstr ""
+
+msgid "%d file"
+msgid_plural "%d files"
+msgstr[0] "%d Datei"
+msgstr[1] "%d Dateien"
+`
+
+func TestParsePO(t *testing.T) {
+	t.Parallel()
+
+	c, err := Parse("", []byte(testPO))
+	require.NoError(t, err)
+
+	assert.Equal(t, "de", c.Locale)
+	assert.Equal(t, "Hallo", c.T("Hello"))
+	assert.Equal(t, "Open", c.T("Open"))
+	assert.Equal(t, "Untranslated", c.T("Untranslated"))
+	assert.Equal(t, "Goodbye", c.T("Goodbye"))
+	assert.Equal(t, "%d Datei", c.TN("%d file", "%d files", 1))
+	assert.Equal(t, "%d Dateien", c.TN("%d file", "%d files", 2))
+	assert.Equal(t, "%d Dateien", c.TN("%d file", "%d files", 0))
+	assert.Equal(t, "apple", c.TN("apple", "apples", 1))
+	assert.Equal(t, "apples", c.TN("apple", "apples", 5))
+
+	// the locale overrides the catalog's Language header
+	c, err = ParsePO("de-AT", []byte(testPO))
+	require.NoError(t, err)
+	assert.Equal(t, "de_AT", c.Locale)
+}
+
+func TestParseMO(t *testing.T) {
+	t.Parallel()
+
+	b := buildMO([][2]string{
+		{"", "Language: pl\nPlural-Forms: nplurals=3;\n"},
+		{"%d file\x00%d files", "%d plik\x00%d pliki\x00%d plików"},
+		{"Hello", "Cześć"},
+	})
+
+	c, err := Parse("", b)
+	require.NoError(t, err)
+
+	assert.Equal(t, "pl", c.Locale)
+	assert.Equal(t, "Cześć", c.T("Hello"))
+	assert.Equal(t, "%d plik", c.TN("%d file", "%d files", 1))
+	assert.Equal(t, "%d pliki", c.TN("%d file", "%d files", 3))
+	assert.Equal(t, "%d plików", c.TN("%d file", "%d files", 5))
+	assert.Equal(t, "%d pliki", c.TN("%d file", "%d files", 22))
+}
+
+// buildMO encodes the (sorted) message ID/translation pairs as a .mo file
+func buildMO(msgs [][2]string) []byte {
+	const headerSize = 28
+
+	n := uint32(len(msgs))
+	idTable := headerSize
+	strTable := idTable + 8*len(msgs)
+	offset := strTable + 8*len(msgs)
+
+	hdr := []uint32{mo.MoMagicLittleEndian, 0, n, uint32(idTable), uint32(strTable), 0, 0}
+
+	var ids, strs, data []uint32
+	var strData bytes.Buffer
+	for _, m := range msgs {
+		ids = append(ids, uint32(len(m[0])), uint32(offset+strData.Len()))
+		strData.WriteString(m[0] + "\x00")
+	}
+	for _, m := range msgs {
+		strs = append(strs, uint32(len(m[1])), uint32(offset+strData.Len()))
+		strData.WriteString(m[1] + "\x00")
+	}
+
+	data = append(data, hdr...)
+	data = append(data, ids...)
+	data = append(data, strs...)
+
+	buf := &bytes.Buffer{}
+	_ = binary.Write(buf, binary.LittleEndian, data)
+	buf.Write(strData.Bytes())
+
+	return buf.Bytes()
+}
+
+func TestParseJSON(t *testing.T) {
+	t.Parallel()
+
+	c, err := Parse("fr", []byte(`{
+		"Hello": "Bonjour",
+		"%d file": ["%d fichier", "%d fichiers"]
+	}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Bonjour", c.T("Hello"))
+	// French uses the singular for 0
+	assert.Equal(t, "%d fichier", c.TN("%d file", "%d files", 0))
+	assert.Equal(t, "%d fichier", c.TN("%d file", "%d files", 1))
+	assert.Equal(t, "%d fichiers", c.TN("%d file", "%d files", 2))
+
+	_, err = ParseJSON("fr", []byte(`{"Hello": 42}`))
+	require.Error(t, err)
+
+	_, err = ParseJSON("fr", []byte(`{"Hello": ["a", 1]}`))
+	require.Error(t, err)
+
+	_, err = ParseJSON("fr", []byte(`{`))
+	require.Error(t, err)
+}
+
+func TestNilCatalog(t *testing.T) {
+	t.Parallel()
+
+	var c *Catalog
+	assert.Equal(t, "Hello", c.T("Hello"))
+	assert.Equal(t, "files", c.TN("file", "files", 2))
+}
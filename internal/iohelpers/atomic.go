@@ -0,0 +1,152 @@
+package iohelpers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/hack-pad/hackpadfs"
+)
+
+// Aborter is implemented by writers which can discard what's been written to
+// them, instead of keeping it when they're closed. After Abort, Close should
+// do nothing.
+type Aborter interface {
+	Abort() error
+}
+
+// atomicWriter writes to a temporary file, which replaces the target file
+// when it's closed
+type atomicWriter struct {
+	fsys   fs.FS
+	f      hackpadfs.File
+	name   string
+	tmp    string
+	sync   bool
+	closed bool
+}
+
+var (
+	_ io.WriteCloser = (*atomicWriter)(nil)
+	_ Aborter        = (*atomicWriter)(nil)
+)
+
+// AtomicWriter creates an io.WriteCloser which writes to a temporary file in
+// the same directory as the named file, and renames it into place on Close,
+// so that readers never see a partly-written file. When sync is true, the
+// file is synced to disk before it's renamed.
+//
+// New files are created with the given mode, and existing files keep their
+// permissions. The parent directory must already exist.
+func AtomicWriter(fsys fs.FS, filename string, mode fs.FileMode, sync bool) (io.WriteCloser, error) {
+	dir, base := filepath.Split(filename)
+
+	var existing fs.FileInfo
+	if fi, err := hackpadfs.Stat(fsys, filename); err == nil {
+		existing = fi
+	}
+
+	// the temp file is hidden, and has a random suffix so concurrent writers
+	// don't collide
+	for range 10 {
+		tmp := filepath.Join(dir, "."+base+"."+strconv.FormatUint(rand.Uint64(), 36)+".tmp")
+
+		f, err := hackpadfs.OpenFile(fsys, tmp, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temporary file for %q: %w", filename, err)
+		}
+
+		if existing != nil {
+			if err := hackpadfs.ChmodFile(f, existing.Mode().Perm()); err != nil {
+				_ = f.Close()
+				_ = hackpadfs.Remove(fsys, tmp)
+
+				return nil, fmt.Errorf("failed to set permissions of temporary file for %q: %w", filename, err)
+			}
+		}
+
+		return &atomicWriter{fsys: fsys, f: f, name: filename, tmp: tmp, sync: sync}, nil
+	}
+
+	return nil, fmt.Errorf("failed to create temporary file for %q: too many collisions", filename)
+}
+
+func (a *atomicWriter) Write(p []byte) (int, error) {
+	return hackpadfs.WriteFile(a.f, p)
+}
+
+// Close - syncs (if needed) and closes the temporary file, and renames it to
+// the target file
+func (a *atomicWriter) Close() error {
+	if a.closed {
+		return nil
+	}
+
+	a.closed = true
+
+	if a.sync {
+		if err := hackpadfs.SyncFile(a.f); err != nil {
+			_ = a.f.Close()
+			_ = hackpadfs.Remove(a.fsys, a.tmp)
+
+			return fmt.Errorf("failed to sync %q: %w", a.name, err)
+		}
+	}
+
+	if err := a.f.Close(); err != nil {
+		_ = hackpadfs.Remove(a.fsys, a.tmp)
+		return fmt.Errorf("failed to close %q: %w", a.name, err)
+	}
+
+	if err := hackpadfs.Rename(a.fsys, a.tmp, a.name); err != nil {
+		_ = hackpadfs.Remove(a.fsys, a.tmp)
+		return fmt.Errorf("failed to replace %q: %w", a.name, err)
+	}
+
+	return nil
+}
+
+// Abort - removes the temporary file, leaving the target file untouched
+func (a *atomicWriter) Abort() error {
+	if a.closed {
+		return nil
+	}
+
+	a.closed = true
+
+	_ = a.f.Close()
+
+	return hackpadfs.Remove(a.fsys, a.tmp)
+}
+
+// syncCloser syncs a file to disk before closing it
+type syncCloser struct {
+	hackpadfs.File
+}
+
+// SyncCloser wraps the file, so that it's synced to disk before it's closed
+func SyncCloser(f hackpadfs.File) io.WriteCloser {
+	return &syncCloser{File: f}
+}
+
+func (s *syncCloser) Write(p []byte) (int, error) {
+	return hackpadfs.WriteFile(s.File, p)
+}
+
+// Close - implements io.Closer
+func (s *syncCloser) Close() error {
+	if err := hackpadfs.SyncFile(s.File); err != nil {
+		_ = s.File.Close()
+		return fmt.Errorf("failed to sync: %w", err)
+	}
+
+	return s.File.Close()
+}
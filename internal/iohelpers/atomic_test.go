@@ -0,0 +1,85 @@
+package iohelpers
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	osfs "github.com/hack-pad/hackpadfs/os"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAtomicWriter(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping non-Windows test")
+	}
+
+	dir := t.TempDir()
+
+	fsys, err := osfs.NewFS().Sub(filepath.ToSlash(dir)[1:])
+	require.NoError(t, err)
+
+	// the existing file is replaced, keeping its permissions
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "out.txt"), []byte("old"), 0o600))
+
+	w, err := AtomicWriter(fsys, "out.txt", 0o644, true)
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("new content"))
+	require.NoError(t, err)
+
+	// not replaced yet
+	b, err := os.ReadFile(filepath.Join(dir, "out.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(b))
+
+	require.NoError(t, w.Close())
+	require.NoError(t, w.Close())
+
+	b, err = os.ReadFile(filepath.Join(dir, "out.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "new content", string(b))
+
+	fi, err := os.Stat(filepath.Join(dir, "out.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), fi.Mode().Perm())
+
+	// aborted writes leave the existing file alone
+	w, err = AtomicWriter(fsys, "out.txt", 0o644, false)
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("partial"))
+	require.NoError(t, err)
+
+	a, ok := w.(Aborter)
+	require.True(t, ok)
+	require.NoError(t, a.Abort())
+	require.NoError(t, w.Close())
+
+	b, err = os.ReadFile(filepath.Join(dir, "out.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "new content", string(b))
+
+	// no temporary files are left behind
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "out.txt", entries[0].Name())
+
+	// new files
+	w, err = AtomicWriter(fsys, "new.txt", 0o600, false)
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("hello"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	b, err = os.ReadFile(filepath.Join(dir, "new.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(b))
+
+	_, err = AtomicWriter(fsys, "missing/new.txt", 0o600, false)
+	require.Error(t, err)
+}
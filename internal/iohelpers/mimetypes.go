@@ -7,19 +7,45 @@ import (
 const (
 	TextMimetype      = "text/plain"
 	CSVMimetype       = "text/csv"
+	TSVMimetype       = "text/tab-separated-values"
 	JSONMimetype      = "application/json"
 	JSONArrayMimetype = "application/array+json"
 	TOMLMimetype      = "application/toml"
 	YAMLMimetype      = "application/yaml"
 	EnvMimetype       = "application/x-env"
 	CUEMimetype       = "application/cue"
+	HCLMimetype       = "application/hcl"
+	JsonnetMimetype   = "application/jsonnet"
+	INIMimetype       = "text/x-ini"
+	XMLMimetype       = "application/xml"
+	MsgPackMimetype   = "application/msgpack"
+	AvroMimetype      = "application/avro"
+	ParquetMimetype   = "application/vnd.apache.parquet"
+	NDJSONMimetype    = "application/x-ndjson"
+	JSON5Mimetype     = "application/json5"
+	MarkdownMimetype  = "text/markdown"
+	BSONMimetype      = "application/bson"
+	JSONPatchMimetype = "application/json-patch+json"
 )
 
 // mimeTypeAliases defines a mapping for non-canonical mime types that are
 // sometimes seen in the wild
 var mimeTypeAliases = map[string]string{
-	"application/x-yaml": YAMLMimetype,
-	"application/text":   TextMimetype,
+	"application/x-yaml":      YAMLMimetype,
+	"application/text":        TextMimetype,
+	"application/x-hcl":       HCLMimetype,
+	"application/x-jsonnet":   JsonnetMimetype,
+	"text/x-dotenv":           EnvMimetype,
+	"text/xml":                XMLMimetype,
+	"application/x-msgpack":   MsgPackMimetype,
+	"application/vnd.msgpack": MsgPackMimetype,
+	"application/x-parquet":   ParquetMimetype,
+	"application/jsonl":       NDJSONMimetype,
+	"application/jsonlines":   NDJSONMimetype,
+	"application/x-jsonlines": NDJSONMimetype,
+	"application/jsonc":       JSON5Mimetype,
+	"application/x-json5":     JSON5Mimetype,
+	"text/x-markdown":         MarkdownMimetype,
 }
 
 func MimeAlias(m string) string {
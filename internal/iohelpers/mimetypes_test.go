@@ -14,6 +14,10 @@ func TestMimeAlias(t *testing.T) {
 		{CSVMimetype, CSVMimetype},
 		{YAMLMimetype, YAMLMimetype},
 		{"application/x-yaml", YAMLMimetype},
+		{"text/x-dotenv; charset=utf-8", EnvMimetype},
+		{"application/jsonl", NDJSONMimetype},
+		{"application/jsonc", JSON5Mimetype},
+		{"text/x-markdown", MarkdownMimetype},
 	}
 
 	for _, d := range data {
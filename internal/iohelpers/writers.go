@@ -70,6 +70,14 @@ func (f *emptySkipper) Close() error {
 	return nil
 }
 
+// Abort - implements Aborter, aborting the wrapped writer (if it was opened)
+func (f *emptySkipper) Abort() error {
+	if a, ok := f.w.(Aborter); ok {
+		return a.Abort()
+	}
+	return nil
+}
+
 func allWhitespace(p []byte) bool {
 	for _, b := range p {
 		if b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\v' {
@@ -99,24 +107,35 @@ var (
 	_ io.WriteCloser = (*nopCloser)(nil)
 	_ io.WriteCloser = (*emptySkipper)(nil)
 	_ io.WriteCloser = (*sameSkipper)(nil)
+
+	_ Aborter = (*emptySkipper)(nil)
+	_ Aborter = (*sameSkipper)(nil)
+	_ Aborter = (*lazyWriteCloser)(nil)
 )
 
 type sameSkipper struct {
 	open func() (io.WriteCloser, error)
 
 	// internal
-	r    *bufio.Reader
-	w    io.WriteCloser
-	buf  *bytes.Buffer
-	diff bool
+	src     io.Reader
+	r       *bufio.Reader
+	w       io.WriteCloser
+	buf     *bytes.Buffer
+	diff    bool
+	aborted bool
 }
 
 // SameSkipper creates an io.WriteCloser that will only start writing once a
 // difference with the current output has been encountered. The wrapped
-// io.WriteCloser must be provided by 'open'.
+// io.WriteCloser must be provided by 'open'. When the output is identical,
+// nothing is written, so the output's modification time is preserved.
+//
+// If r is an io.Closer, it's closed as soon as it's no longer needed (before
+// 'open' is called, or on Close).
 func SameSkipper(r io.Reader, open func() (io.WriteCloser, error)) io.WriteCloser {
 	br := bufio.NewReader(r)
 	return &sameSkipper{
+		src:  r,
 		r:    br,
 		w:    nil,
 		buf:  &bytes.Buffer{},
@@ -130,11 +149,14 @@ func SameSkipper(r io.Reader, open func() (io.WriteCloser, error)) io.WriteClose
 func (f *sameSkipper) Write(p []byte) (n int, err error) {
 	if !f.diff {
 		in := make([]byte, len(p))
-		_, err := f.r.Read(in)
-		if err != nil && err != io.EOF {
+
+		// a plain Read may return fewer bytes than are available, which would
+		// look like a difference
+		n, err := io.ReadFull(f.r, in)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
 			return 0, fmt.Errorf("failed to read: %w", err)
 		}
-		if bytes.Equal(in, p) {
+		if n == len(p) && bytes.Equal(in, p) {
 			return f.buf.Write(p)
 		}
 
@@ -147,8 +169,24 @@ func (f *sameSkipper) Write(p []byte) (n int, err error) {
 	return f.w.Write(p)
 }
 
+// closeReader closes the current output's reader, if it can be closed. This
+// needs to happen before the output is opened for writing, as some platforms
+// don't allow replacing files which are open.
+func (f *sameSkipper) closeReader() error {
+	if c, ok := f.src.(io.Closer); ok {
+		f.src = nil
+		return c.Close()
+	}
+
+	return nil
+}
+
 func (f *sameSkipper) flush() (err error) {
 	if f.w == nil {
+		if err = f.closeReader(); err != nil {
+			return fmt.Errorf("failed to close reader: %w", err)
+		}
+
 		f.w, err = f.open()
 		if err != nil {
 			return err
@@ -164,6 +202,10 @@ func (f *sameSkipper) flush() (err error) {
 
 // Close - implements io.Closer
 func (f *sameSkipper) Close() error {
+	if f.aborted {
+		return nil
+	}
+
 	// Check to see if we missed anything in the reader
 	if !f.diff {
 		n, err := f.r.Peek(1)
@@ -175,12 +217,39 @@ func (f *sameSkipper) Close() error {
 		}
 	}
 
+	if err := f.closeReader(); err != nil {
+		return fmt.Errorf("failed to close reader: %w", err)
+	}
+
 	if f.w != nil {
 		return f.w.Close()
 	}
 	return nil
 }
 
+// Abort - implements Aborter. The current output is left as-is, unless a
+// difference was already written to it (and the wrapped writer can't be
+// aborted).
+func (f *sameSkipper) Abort() error {
+	if f.aborted {
+		return nil
+	}
+
+	f.aborted = true
+
+	err := f.closeReader()
+
+	if a, ok := f.w.(Aborter); ok {
+		return errors.Join(err, a.Abort())
+	}
+
+	if f.w != nil {
+		return errors.Join(err, f.w.Close())
+	}
+
+	return err
+}
+
 // LazyWriteCloser provides an interface to a WriteCloser that will open on the
 // first access. The wrapped io.WriteCloser must be provided by 'open'.
 func LazyWriteCloser(open func() (io.WriteCloser, error)) io.WriteCloser {
@@ -196,6 +265,7 @@ type lazyWriteCloser struct {
 	openErr error
 	open    func() (io.WriteCloser, error)
 	opened  sync.Once
+	aborted bool
 }
 
 var _ io.WriteCloser = (*lazyWriteCloser)(nil)
@@ -208,6 +278,10 @@ func (l *lazyWriteCloser) openWriter() (r io.WriteCloser, err error) {
 }
 
 func (l *lazyWriteCloser) Close() error {
+	if l.aborted {
+		return nil
+	}
+
 	w, err := l.openWriter()
 	if err != nil {
 		return err
@@ -215,6 +289,26 @@ func (l *lazyWriteCloser) Close() error {
 	return w.Close()
 }
 
+// Abort - implements Aborter. If the wrapped writer hasn't been opened, it
+// won't be.
+func (l *lazyWriteCloser) Abort() error {
+	if l.aborted {
+		return nil
+	}
+
+	l.aborted = true
+
+	if l.w == nil {
+		return nil
+	}
+
+	if a, ok := l.w.(Aborter); ok {
+		return a.Abort()
+	}
+
+	return l.w.Close()
+}
+
 func (l *lazyWriteCloser) Write(p []byte) (n int, err error) {
 	w, err := l.openWriter()
 	if err != nil {
@@ -122,6 +122,64 @@ func TestSameSkipper(t *testing.T) {
 	}
 }
 
+// readCloser is an io.ReadCloser which only returns a few bytes per read, like
+// some files and network streams
+type readCloser struct {
+	r      io.Reader
+	closed bool
+}
+
+func (r *readCloser) Read(p []byte) (int, error) {
+	if len(p) > 3 {
+		p = p[:3]
+	}
+
+	return r.r.Read(p)
+}
+
+func (r *readCloser) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestSameSkipper_MultipleWrites(t *testing.T) {
+	large := bytes.Repeat([]byte("0123456789"), 1000)
+	content := append([]byte("header\n"), large...)
+
+	r := &readCloser{r: bytes.NewReader(content)}
+	opened := false
+	f := SameSkipper(r, func() (io.WriteCloser, error) {
+		opened = true
+		return newBufferCloser(&bytes.Buffer{}), nil
+	})
+
+	_, err := f.Write([]byte("header\n"))
+	require.NoError(t, err)
+	_, err = f.Write(large)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	assert.False(t, opened)
+	assert.True(t, r.closed)
+
+	// the reader's closed before the output is opened
+	r = &readCloser{r: bytes.NewReader(content)}
+	w := newBufferCloser(&bytes.Buffer{})
+	f = SameSkipper(r, func() (io.WriteCloser, error) {
+		assert.True(t, r.closed)
+		return w, nil
+	})
+
+	_, err = f.Write([]byte("header\n"))
+	require.NoError(t, err)
+	_, err = f.Write([]byte("changed"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	assert.Equal(t, "header\nchanged", w.String())
+	assert.True(t, w.closed)
+}
+
 func TestLazyWriteCloser(t *testing.T) {
 	w := newBufferCloser(&bytes.Buffer{})
 	opened := false
@@ -0,0 +1,130 @@
+package parsers
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/hamba/avro/v2"
+	"github.com/hamba/avro/v2/ocf"
+)
+
+// Avro - Unmarshal an Avro Object Container File, which embeds its own schema,
+// into a list of records.
+//
+// Values of union types are presented directly, without the type name which
+// would otherwise wrap them.
+func Avro(in string) ([]interface{}, error) {
+	dec, err := ocf.NewDecoder(strings.NewReader(in))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read Avro container: %w", err)
+	}
+
+	out := []interface{}{}
+
+	for dec.HasNext() {
+		var v interface{}
+
+		err = dec.Decode(&v)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode Avro record: %w", err)
+		}
+
+		out = append(out, unwrapAvroUnions(dec.Schema(), v))
+	}
+
+	if dec.Error() != nil {
+		return nil, fmt.Errorf("unable to read Avro container: %w", dec.Error())
+	}
+
+	return out, nil
+}
+
+// AvroDatum - Unmarshal a single Avro-encoded value (such as a Kafka message
+// payload), given the writer's schema.
+func AvroDatum(schema string, in []byte) (interface{}, error) {
+	s, err := avro.Parse(schema)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse Avro schema: %w", err)
+	}
+
+	var v interface{}
+
+	err = avro.NewDecoderForSchema(s, bytes.NewReader(in)).Decode(&v)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode Avro value: %w", err)
+	}
+
+	return unwrapAvroUnions(s, v), nil
+}
+
+// unwrapAvroUnions replaces the single-key maps which name the type of a
+// union's value (as in {"com.example.Address": {...}}) with the value itself
+func unwrapAvroUnions(schema avro.Schema, v interface{}) interface{} {
+	switch s := schema.(type) {
+	case *avro.RecordSchema:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return v
+		}
+
+		for _, f := range s.Fields() {
+			if fv, ok := m[f.Name()]; ok {
+				m[f.Name()] = unwrapAvroUnions(f.Type(), fv)
+			}
+		}
+
+		return m
+	case *avro.ArraySchema:
+		l, ok := v.([]interface{})
+		if !ok {
+			return v
+		}
+
+		for i, item := range l {
+			l[i] = unwrapAvroUnions(s.Items(), item)
+		}
+
+		return l
+	case *avro.MapSchema:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return v
+		}
+
+		for k, item := range m {
+			m[k] = unwrapAvroUnions(s.Values(), item)
+		}
+
+		return m
+	case *avro.UnionSchema:
+		m, ok := v.(map[string]interface{})
+		if !ok || len(m) != 1 {
+			return v
+		}
+
+		for _, t := range s.Types() {
+			name := avroTypeName(t)
+			if inner, ok := m[name]; ok {
+				return unwrapAvroUnions(t, inner)
+			}
+		}
+
+		return v
+	default:
+		return v
+	}
+}
+
+// avroTypeName returns the name used to identify a union member's type
+func avroTypeName(s avro.Schema) string {
+	if n, ok := s.(avro.NamedSchema); ok {
+		return n.FullName()
+	}
+
+	if l, ok := s.(avro.LogicalTypeSchema); ok && l.Logical() != nil {
+		return string(s.Type()) + "." + string(l.Logical().Type())
+	}
+
+	return string(s.Type())
+}
@@ -0,0 +1,94 @@
+package parsers
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hamba/avro/v2"
+	"github.com/hamba/avro/v2/ocf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAvroSchema = `{
+	"type": "record",
+	"name": "Service",
+	"namespace": "config",
+	"fields": [
+		{"name": "name", "type": "string"},
+		{"name": "port", "type": "int"},
+		{"name": "tags", "type": {"type": "array", "items": "string"}},
+		{"name": "owner", "type": ["null", "string"]},
+		{"name": "upstream", "type": ["null", {
+			"type": "record",
+			"name": "Upstream",
+			"fields": [{"name": "host", "type": "string"}]
+		}]},
+		{"name": "limits", "type": {"type": "map", "values": ["null", "long"]}}
+	]
+}`
+
+func testAvroRecord() map[string]interface{} {
+	return map[string]interface{}{
+		"name":  "web",
+		"port":  8080,
+		"tags":  []interface{}{"a", "b"},
+		"owner": "ops",
+		// named types in unions must be identified when encoding
+		"upstream": map[string]interface{}{
+			"config.Upstream": map[string]interface{}{"host": "backend"},
+		},
+		"limits": map[string]interface{}{"rps": int64(100), "burst": nil},
+	}
+}
+
+func TestAvroDatum(t *testing.T) {
+	b, err := avro.Marshal(avro.MustParse(testAvroSchema), testAvroRecord())
+	require.NoError(t, err)
+
+	out, err := AvroDatum(testAvroSchema, b)
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{
+		"name":     "web",
+		"port":     8080,
+		"tags":     []interface{}{"a", "b"},
+		"owner":    "ops",
+		"upstream": map[string]interface{}{"host": "backend"},
+		"limits":   map[string]interface{}{"rps": int64(100), "burst": nil},
+	}, out)
+
+	_, err = AvroDatum(`{"type": "bogus"}`, b)
+	require.ErrorContains(t, err, "schema")
+
+	_, err = AvroDatum(testAvroSchema, b[:3])
+	require.Error(t, err)
+}
+
+func TestAvro(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	enc, err := ocf.NewEncoder(testAvroSchema, buf)
+	require.NoError(t, err)
+
+	other := testAvroRecord()
+	other["name"] = "api"
+	other["owner"] = nil
+	other["upstream"] = nil
+
+	require.NoError(t, enc.Encode(testAvroRecord()))
+	require.NoError(t, enc.Encode(other))
+	require.NoError(t, enc.Close())
+
+	out, err := Avro(buf.String())
+	require.NoError(t, err)
+	require.Len(t, out, 2)
+
+	assert.Equal(t, "web", out[0].(map[string]interface{})["name"])
+	assert.Equal(t, map[string]interface{}{"host": "backend"}, out[0].(map[string]interface{})["upstream"])
+	assert.Equal(t, "api", out[1].(map[string]interface{})["name"])
+	assert.Nil(t, out[1].(map[string]interface{})["owner"])
+	assert.Nil(t, out[1].(map[string]interface{})["upstream"])
+
+	_, err = Avro("not an avro file")
+	require.Error(t, err)
+}
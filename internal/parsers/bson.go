@@ -0,0 +1,125 @@
+package parsers
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// BSON - Unmarshal a sequence of BSON documents (such as a collection dumped
+// by mongodump) into a list of maps, one for each document.
+//
+// Object IDs are presented as hex strings, dates as times in UTC, binary data
+// as byte slices, and integers as ints. Other MongoDB-specific types (such as
+// timestamps, decimals, and regular expressions) are presented as in the
+// canonical form of MongoDB Extended JSON (v2).
+func BSON(in string) ([]interface{}, error) {
+	b := []byte(in)
+	out := []interface{}{}
+
+	for n := 1; len(b) > 0; n++ {
+		if len(b) < 4 {
+			return nil, fmt.Errorf("unable to unmarshal BSON document %d: truncated length", n)
+		}
+
+		size := binary.LittleEndian.Uint32(b)
+		if size < 5 || uint64(size) > uint64(len(b)) {
+			return nil, fmt.Errorf("unable to unmarshal BSON document %d: invalid length %d", n, size)
+		}
+
+		doc := bson.Raw(b[:size])
+
+		err := doc.Validate()
+		if err != nil {
+			return nil, fmt.Errorf("unable to unmarshal BSON document %d: %w", n, err)
+		}
+
+		v, err := bsonDocument(doc)
+		if err != nil {
+			return nil, fmt.Errorf("unable to unmarshal BSON document %d: %w", n, err)
+		}
+
+		out = append(out, v)
+		b = b[size:]
+	}
+
+	return out, nil
+}
+
+func bsonDocument(doc bson.Raw) (map[string]interface{}, error) {
+	elems, err := doc.Elements()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]interface{}, len(elems))
+
+	for _, e := range elems {
+		out[e.Key()], err = bsonValue(e.Value())
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", e.Key(), err)
+		}
+	}
+
+	return out, nil
+}
+
+func bsonValue(v bson.RawValue) (interface{}, error) {
+	//nolint:exhaustive
+	switch v.Type {
+	case bsontype.EmbeddedDocument:
+		return bsonDocument(v.Document())
+	case bsontype.Array:
+		values, err := v.Array().Values()
+		if err != nil {
+			return nil, err
+		}
+
+		out := make([]interface{}, len(values))
+		for i, item := range values {
+			out[i], err = bsonValue(item)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return out, nil
+	case bsontype.Double:
+		return v.Double(), nil
+	case bsontype.String:
+		return v.StringValue(), nil
+	case bsontype.Binary:
+		_, data := v.Binary()
+		return data, nil
+	case bsontype.ObjectID:
+		return v.ObjectID().Hex(), nil
+	case bsontype.Boolean:
+		return v.Boolean(), nil
+	case bsontype.DateTime:
+		return time.UnixMilli(v.DateTime()).UTC(), nil
+	case bsontype.Null, bsontype.Undefined:
+		return nil, nil
+	case bsontype.Int32:
+		return int(v.Int32()), nil
+	case bsontype.Int64:
+		n := v.Int64()
+		if n < math.MinInt || n > math.MaxInt {
+			return n, nil
+		}
+
+		return int(n), nil
+	default:
+		// the remaining types are rarely seen outside of MongoDB, and are
+		// presented in Extended JSON form
+		m, err := JSON(fmt.Sprintf(`{"v": %s}`, v.String()))
+		if err != nil {
+			return nil, err
+		}
+
+		return m["v"], nil
+	}
+}
@@ -0,0 +1,73 @@
+package parsers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestBSON(t *testing.T) {
+	id, err := primitive.ObjectIDFromHex("5f1d7a3e9c1b2a0012345678")
+	require.NoError(t, err)
+
+	created := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+
+	dec, err := primitive.ParseDecimal128("1.50")
+	require.NoError(t, err)
+
+	doc1, err := bson.Marshal(bson.D{
+		{Key: "_id", Value: id},
+		{Key: "name", Value: "web"},
+		{Key: "port", Value: int32(8080)},
+		{Key: "size", Value: int64(1 << 40)},
+		{Key: "ratio", Value: 0.5},
+		{Key: "enabled", Value: true},
+		{Key: "created", Value: primitive.NewDateTimeFromTime(created)},
+		{Key: "owner", Value: nil},
+		{Key: "tags", Value: bson.A{"a", int32(1), bson.D{{Key: "x", Value: "y"}}}},
+		{Key: "data", Value: primitive.Binary{Data: []byte("hi")}},
+		{Key: "price", Value: dec},
+		{Key: "ts", Value: primitive.Timestamp{T: 1714566600, I: 2}},
+	})
+	require.NoError(t, err)
+
+	doc2, err := bson.Marshal(bson.D{{Key: "name", Value: "api"}})
+	require.NoError(t, err)
+
+	out, err := BSON(string(append(doc1, doc2...)))
+	require.NoError(t, err)
+	assert.EqualValues(t, []interface{}{
+		map[string]interface{}{
+			"_id":     "5f1d7a3e9c1b2a0012345678",
+			"name":    "web",
+			"port":    8080,
+			"size":    1 << 40,
+			"ratio":   0.5,
+			"enabled": true,
+			"created": created,
+			"owner":   nil,
+			"tags":    []interface{}{"a", 1, map[string]interface{}{"x": "y"}},
+			"data":    []byte("hi"),
+			"price":   map[string]interface{}{"$numberDecimal": "1.50"},
+			"ts":      map[string]interface{}{"$timestamp": map[string]interface{}{"t": 1714566600, "i": 2}},
+		},
+		map[string]interface{}{"name": "api"},
+	}, out)
+
+	out, err = BSON("")
+	require.NoError(t, err)
+	assert.Empty(t, out)
+
+	_, err = BSON(string(doc1[:len(doc1)-1]))
+	require.ErrorContains(t, err, "document 1")
+
+	_, err = BSON(string(append(doc2, 0, 0)))
+	require.ErrorContains(t, err, "document 2")
+
+	_, err = BSON("not bson at all")
+	require.Error(t, err)
+}
@@ -0,0 +1,345 @@
+package parsers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"mime"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
+)
+
+// csvParams are the media type parameters (and datasource query parameters)
+// which control how CSV documents are parsed
+//
+//nolint:gochecknoglobals
+var csvParams = []string{"delimiter", "comment", "header", "inferTypes"}
+
+// CSVOptions control how CSV documents are parsed
+type CSVOptions struct {
+	// Delimiter separates fields. Defaults to ',' (or a tab, for TSV).
+	Delimiter rune
+	// Comment starts a comment line, when set
+	Comment rune
+	// NoHeader indicates that the first line is not a header. Columns are
+	// named A, B, C, and so on.
+	NoHeader bool
+	// InferTypes converts columns which contain only numbers or booleans (and
+	// empty cells) to those types. Empty cells in such columns are nil.
+	InferTypes bool
+}
+
+// CSVMediaType returns the given CSV (or TSV) media type, with parameters set
+// from any CSV options in the query
+func CSVMediaType(mimeType string, q url.Values) string {
+	mt, params, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return mimeType
+	}
+
+	set := false
+
+	for _, p := range csvParams {
+		if q.Has(p) {
+			// media type parameter names are case-insensitive
+			params[strings.ToLower(p)] = q.Get(p)
+			set = true
+		}
+	}
+
+	if !set {
+		return mimeType
+	}
+
+	return mime.FormatMediaType(mt, params)
+}
+
+// CSVQueryParams returns the names of the query parameters which set CSV
+// options
+func CSVQueryParams() []string {
+	return append([]string{}, csvParams...)
+}
+
+// CSVOptionsFromMediaType reads CSVOptions from the parameters of a CSV (or
+// TSV) media type
+func CSVOptionsFromMediaType(mediaType string) (CSVOptions, error) {
+	opts := CSVOptions{Delimiter: ','}
+
+	mt, params, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return opts, fmt.Errorf("invalid CSV media type %q: %w", mediaType, err)
+	}
+
+	if iohelpers.MimeAlias(mt) == iohelpers.TSVMimetype {
+		opts.Delimiter = '\t'
+	}
+
+	if v, ok := params["delimiter"]; ok {
+		opts.Delimiter, err = csvRune(v)
+		if err != nil {
+			return opts, fmt.Errorf("invalid CSV delimiter: %w", err)
+		}
+	}
+
+	if v, ok := params["comment"]; ok {
+		opts.Comment, err = csvRune(v)
+		if err != nil {
+			return opts, fmt.Errorf("invalid CSV comment character: %w", err)
+		}
+	}
+
+	switch strings.ToLower(params["header"]) {
+	case "", "present":
+	case "absent":
+		opts.NoHeader = true
+	default:
+		return opts, fmt.Errorf("invalid CSV header parameter %q: must be present or absent", params["header"])
+	}
+
+	if v, ok := params["infertypes"]; ok {
+		opts.InferTypes = v == "" || conv.ToBool(v)
+	}
+
+	return opts, nil
+}
+
+// csvRune parses a single-character option, where "\t" and "tab" are also
+// accepted for a tab
+func csvRune(s string) (rune, error) {
+	if s == `\t` || strings.EqualFold(s, "tab") {
+		return '\t', nil
+	}
+
+	if utf8.RuneCountInString(s) != 1 {
+		return 0, fmt.Errorf("%q must be a single character", s)
+	}
+
+	r, _ := utf8.DecodeRuneInString(s)
+
+	return r, nil
+}
+
+// CSVWithOptions - Unmarshal CSV, like [CSV], with the given options. The
+// first row is always the header.
+func CSVWithOptions(in string, opts CSVOptions) ([][]interface{}, error) {
+	c := csv.NewReader(strings.NewReader(in))
+	c.Comma = opts.Delimiter
+	c.Comment = opts.Comment
+
+	records, err := c.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return [][]interface{}{}, nil
+	}
+
+	var hdr []string
+	if opts.NoHeader {
+		hdr = make([]string, len(records[0]))
+		for i := range hdr {
+			hdr[i] = autoIndex(i)
+		}
+	} else {
+		hdr, records = records[0], records[1:]
+	}
+
+	out := make([][]interface{}, 0, len(records)+1)
+
+	row := make([]interface{}, len(hdr))
+	for i, h := range hdr {
+		row[i] = h
+	}
+
+	out = append(out, row)
+
+	var types []csvColumnType
+	if opts.InferTypes {
+		types = inferCSVColumnTypes(records)
+	}
+
+	for _, record := range records {
+		row := make([]interface{}, len(record))
+
+		for i, v := range record {
+			if types != nil {
+				row[i] = types[i].convert(v)
+			} else {
+				row[i] = v
+			}
+		}
+
+		out = append(out, row)
+	}
+
+	return out, nil
+}
+
+type csvColumnType int
+
+const (
+	csvString csvColumnType = iota
+	csvInt
+	csvFloat
+	csvBool
+)
+
+// inferCSVColumnTypes finds the narrowest type for each column, ignoring
+// empty cells. Columns which are entirely empty are strings.
+func inferCSVColumnTypes(records [][]string) []csvColumnType {
+	if len(records) == 0 {
+		return nil
+	}
+
+	types := make([]csvColumnType, len(records[0]))
+
+	for col := range types {
+		isInt, isFloat, isBool, seen := true, true, true, false
+
+		for _, record := range records {
+			v := record[col]
+			if v == "" {
+				continue
+			}
+
+			seen = true
+
+			if _, err := strconv.Atoi(v); err != nil {
+				isInt = false
+			}
+
+			if _, err := strconv.ParseFloat(v, 64); err != nil {
+				isFloat = false
+			}
+
+			if l := strings.ToLower(v); l != "true" && l != "false" {
+				isBool = false
+			}
+		}
+
+		switch {
+		case !seen:
+			types[col] = csvString
+		case isInt:
+			types[col] = csvInt
+		case isFloat:
+			types[col] = csvFloat
+		case isBool:
+			types[col] = csvBool
+		}
+	}
+
+	return types
+}
+
+func (t csvColumnType) convert(v string) interface{} {
+	if t != csvString && v == "" {
+		return nil
+	}
+
+	switch t {
+	case csvInt:
+		n, _ := strconv.Atoi(v)
+		return n
+	case csvFloat:
+		f, _ := strconv.ParseFloat(v, 64)
+		return f
+	case csvBool:
+		return strings.EqualFold(v, "true")
+	default:
+		return v
+	}
+}
+
+// CSVWriteOptions control how CSV documents are written
+type CSVWriteOptions struct {
+	// Delimiter separates fields. Defaults to ','.
+	Delimiter rune
+	// QuoteAll quotes every field, rather than only those which need it
+	QuoteAll bool
+	// LF ends lines with "\n", rather than "\r\n" as in RFC 4180
+	LF bool
+}
+
+// CSVWriteOptionsFromMap reads CSVWriteOptions from a map, as provided to
+// template functions. The keys are delimiter, quoteAll, and lineEnding ("crlf"
+// or "lf").
+func CSVWriteOptionsFromMap(m map[string]interface{}) (CSVWriteOptions, error) {
+	opts := CSVWriteOptions{Delimiter: ','}
+
+	var err error
+
+	for k, v := range m {
+		switch k {
+		case "delimiter":
+			opts.Delimiter, err = csvRune(conv.ToString(v))
+			if err != nil {
+				return opts, fmt.Errorf("invalid CSV delimiter: %w", err)
+			}
+		case "quoteAll":
+			opts.QuoteAll = conv.ToBool(v)
+		case "lineEnding":
+			switch strings.ToLower(conv.ToString(v)) {
+			case "crlf":
+				opts.LF = false
+			case "lf":
+				opts.LF = true
+			default:
+				return opts, fmt.Errorf("invalid CSV lineEnding %q: must be crlf or lf", v)
+			}
+		default:
+			return opts, fmt.Errorf("unknown CSV option %q", k)
+		}
+	}
+
+	return opts, nil
+}
+
+// writeCSV writes the records with the given options
+func writeCSV(in [][]string, opts CSVWriteOptions) (string, error) {
+	if opts.Delimiter == '"' || opts.Delimiter == '\r' || opts.Delimiter == '\n' || opts.Delimiter == utf8.RuneError {
+		return "", fmt.Errorf("invalid CSV delimiter %q", opts.Delimiter)
+	}
+
+	b := &bytes.Buffer{}
+
+	if !opts.QuoteAll {
+		c := csv.NewWriter(b)
+		c.Comma = opts.Delimiter
+		c.UseCRLF = !opts.LF
+
+		err := c.WriteAll(in)
+		if err != nil {
+			return "", err
+		}
+
+		return b.String(), nil
+	}
+
+	eol := "\r\n"
+	if opts.LF {
+		eol = "\n"
+	}
+
+	for _, record := range in {
+		for i, field := range record {
+			if i > 0 {
+				b.WriteRune(opts.Delimiter)
+			}
+
+			b.WriteByte('"')
+			b.WriteString(strings.ReplaceAll(field, `"`, `""`))
+			b.WriteByte('"')
+		}
+
+		b.WriteString(eol)
+	}
+
+	return b.String(), nil
+}
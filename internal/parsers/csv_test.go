@@ -0,0 +1,118 @@
+package parsers
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVMediaType(t *testing.T) {
+	q := url.Values{"delimiter": {";"}, "header": {"absent"}, "other": {"x"}}
+
+	assert.Equal(t, `text/csv; delimiter=";"; header=absent`, CSVMediaType("text/csv", q))
+	assert.Equal(t, `text/csv; charset=utf-8; delimiter=";"; header=absent`, CSVMediaType("text/csv; charset=utf-8", q))
+	assert.Equal(t, "text/csv", CSVMediaType("text/csv", url.Values{"other": {"x"}}))
+}
+
+func TestCSVOptionsFromMediaType(t *testing.T) {
+	testdata := []struct {
+		in       string
+		expected CSVOptions
+	}{
+		{"text/csv", CSVOptions{Delimiter: ','}},
+		{"text/csv; charset=utf-8; header=present", CSVOptions{Delimiter: ','}},
+		{"text/tab-separated-values", CSVOptions{Delimiter: '\t'}},
+		{`text/csv; delimiter="\t"`, CSVOptions{Delimiter: '\t'}},
+		{"text/csv; delimiter=tab", CSVOptions{Delimiter: '\t'}},
+		{`text/csv; delimiter=";"; comment="#"`, CSVOptions{Delimiter: ';', Comment: '#'}},
+		{"text/csv; header=absent; inferTypes=true", CSVOptions{Delimiter: ',', NoHeader: true, InferTypes: true}},
+		{`text/csv; inferTypes=""`, CSVOptions{Delimiter: ',', InferTypes: true}},
+	}
+
+	for _, d := range testdata {
+		t.Run(d.in, func(t *testing.T) {
+			opts, err := CSVOptionsFromMediaType(d.in)
+			require.NoError(t, err)
+			assert.Equal(t, d.expected, opts)
+		})
+	}
+
+	_, err := CSVOptionsFromMediaType(`text/csv; delimiter=";;"`)
+	require.Error(t, err)
+
+	_, err = CSVOptionsFromMediaType("text/csv; header=maybe")
+	require.Error(t, err)
+}
+
+func TestCSVWithOptions(t *testing.T) {
+	in := "name;port;weight;enabled;note\n" +
+		"# a comment\n" +
+		"web;8080;0.5;true;\n" +
+		"api;9090;;FALSE;x\n"
+
+	out, err := CSVWithOptions(in, CSVOptions{Delimiter: ';', Comment: '#'})
+	require.NoError(t, err)
+	assert.Equal(t, [][]interface{}{
+		{"name", "port", "weight", "enabled", "note"},
+		{"web", "8080", "0.5", "true", ""},
+		{"api", "9090", "", "FALSE", "x"},
+	}, out)
+
+	out, err = CSVWithOptions(in, CSVOptions{Delimiter: ';', Comment: '#', InferTypes: true})
+	require.NoError(t, err)
+	assert.Equal(t, [][]interface{}{
+		{"name", "port", "weight", "enabled", "note"},
+		{"web", 8080, 0.5, true, ""},
+		{"api", 9090, nil, false, "x"},
+	}, out)
+
+	out, err = CSVWithOptions("a\tb\n1\t2\n", CSVOptions{Delimiter: '\t', NoHeader: true, InferTypes: true})
+	require.NoError(t, err)
+	assert.Equal(t, [][]interface{}{
+		{"A", "B"},
+		{"a", "b"},
+		{"1", "2"},
+	}, out)
+
+	out, err = CSVWithOptions("", CSVOptions{Delimiter: ','})
+	require.NoError(t, err)
+	assert.Empty(t, out)
+
+	_, err = CSVWithOptions("a,b\n1,2,3\n", CSVOptions{Delimiter: ','})
+	require.Error(t, err)
+}
+
+func TestToCSVWithOptions(t *testing.T) {
+	in := [][]interface{}{
+		{"name", "note"},
+		{"web", `say "hi"`},
+	}
+
+	out, err := ToCSV(map[string]interface{}{"delimiter": ";", "lineEnding": "lf"}, in)
+	require.NoError(t, err)
+	assert.Equal(t, "name;note\nweb;\"say \"\"hi\"\"\"\n", out)
+
+	out, err = ToCSV(map[string]interface{}{"quoteAll": true}, in)
+	require.NoError(t, err)
+	assert.Equal(t, "\"name\",\"note\"\r\n\"web\",\"say \"\"hi\"\"\"\r\n", out)
+
+	out, err = ToCSV(map[string]interface{}{"delimiter": `\t`, "quoteAll": true, "lineEnding": "LF"}, in)
+	require.NoError(t, err)
+	assert.Equal(t, "\"name\"\t\"note\"\n\"web\"\t\"say \"\"hi\"\"\"\n", out)
+
+	// nil values (as from inferred types) are empty
+	out, err = ToCSV(map[string]interface{}{"lineEnding": "lf"}, [][]interface{}{{"a", "b"}, {1, nil}})
+	require.NoError(t, err)
+	assert.Equal(t, "a,b\n1,\n", out)
+
+	_, err = ToCSV(map[string]interface{}{"delimiter": `"`}, in)
+	require.Error(t, err)
+
+	_, err = ToCSV(map[string]interface{}{"lineEnding": "cr"}, in)
+	require.Error(t, err)
+
+	_, err = ToCSV(map[string]interface{}{"bogus": true}, in)
+	require.Error(t, err)
+}
@@ -0,0 +1,411 @@
+package parsers
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/hairyhenderson/yaml"
+)
+
+// YAMLOptions control how values are formatted by ToYAMLWithOptions
+type YAMLOptions struct {
+	// SortKeys, when set, controls whether mapping keys are sorted. When true,
+	// keys are sorted alphabetically. When false, keys are written in the
+	// input's order, for inputs that have one (such as structs). Maps have no
+	// order, so their keys are always sorted.
+	SortKeys *bool
+	// Quote is the style used for all string values: "single" or "double".
+	// By default, strings are only quoted when necessary. Keys and multi-line
+	// strings are never affected.
+	Quote string
+	// Indent is the number of spaces nested values are indented by. Defaults
+	// to 2.
+	Indent int
+	// FlowMaxKeys is the largest number of keys a mapping can have to be
+	// written in flow style (like "{a: 1, b: 2}"), when all its values are
+	// scalars. Zero (the default) disables flow style.
+	FlowMaxKeys int
+	// Source is a YAML document which the output should stay as close to as
+	// possible - where the value is unchanged from it, the source's comments,
+	// anchors and aliases, key order, and styles are kept. The other options
+	// only apply to new values.
+	Source string
+}
+
+// YAMLOptionsFromMap - converts a map of options (as given in a template) to
+// YAMLOptions
+func YAMLOptionsFromMap(m map[string]interface{}) (YAMLOptions, error) {
+	opts := YAMLOptions{}
+
+	for k, v := range m {
+		switch k {
+		case "indent":
+			n, err := conv.ToInt(v)
+			if err != nil || n < 1 || n > 9 {
+				return opts, fmt.Errorf("indent must be a number from 1 to 9, not %v", v)
+			}
+
+			opts.Indent = n
+		case "sortKeys":
+			b := conv.ToBool(v)
+			opts.SortKeys = &b
+		case "flowMaxKeys":
+			n, err := conv.ToInt(v)
+			if err != nil || n < 0 {
+				return opts, fmt.Errorf("flowMaxKeys must be a non-negative number, not %v", v)
+			}
+
+			opts.FlowMaxKeys = n
+		case "quote":
+			opts.Quote = conv.ToString(v)
+			if opts.Quote != "single" && opts.Quote != "double" {
+				return opts, fmt.Errorf("quote must be single or double, not %q", opts.Quote)
+			}
+		case "source":
+			opts.Source = conv.ToString(v)
+		default:
+			return opts, fmt.Errorf("unknown YAML option %q", k)
+		}
+	}
+
+	return opts, nil
+}
+
+// ToYAMLWithOptions - Stringify a struct as YAML, formatted according to the
+// given options
+func ToYAMLWithOptions(in interface{}, opts YAMLOptions) (string, error) {
+	if opts.Source != "" {
+		return toYAMLRoundTrip(in, opts)
+	}
+
+	n := &yaml.Node{}
+	if err := n.Encode(in); err != nil {
+		return "", fmt.Errorf("unable to marshal object %s: %w", in, err)
+	}
+
+	formatYAMLNode(n, opts)
+
+	indent := opts.Indent
+	if indent == 0 {
+		indent = 2
+	}
+
+	buf := &bytes.Buffer{}
+	e := yaml.NewEncoder(buf)
+	e.SetIndent(indent)
+
+	if err := e.Encode(n); err != nil {
+		return "", fmt.Errorf("unable to marshal object %s: %w", in, err)
+	}
+
+	if err := e.Close(); err != nil {
+		return "", fmt.Errorf("unable to marshal object %s: %w", in, err)
+	}
+
+	return buf.String(), nil
+}
+
+func formatYAMLNode(n *yaml.Node, opts YAMLOptions) {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		for _, c := range n.Content {
+			formatYAMLNode(c, opts)
+		}
+	case yaml.SequenceNode:
+		for _, c := range n.Content {
+			quoteYAMLScalar(c, opts.Quote)
+			formatYAMLNode(c, opts)
+		}
+	case yaml.MappingNode:
+		if opts.SortKeys != nil && *opts.SortKeys {
+			sortYAMLMapping(n)
+		}
+
+		scalars := true
+		for i := 1; i < len(n.Content); i += 2 {
+			v := n.Content[i]
+			quoteYAMLScalar(v, opts.Quote)
+			formatYAMLNode(v, opts)
+
+			if v.Kind != yaml.ScalarNode && v.Kind != yaml.AliasNode {
+				scalars = false
+			}
+		}
+
+		keys := len(n.Content) / 2
+		if scalars && keys > 0 && keys <= opts.FlowMaxKeys {
+			n.Style |= yaml.FlowStyle
+		}
+	}
+}
+
+func quoteYAMLScalar(n *yaml.Node, quote string) {
+	if quote == "" || n.Kind != yaml.ScalarNode || n.ShortTag() != "!!str" {
+		return
+	}
+
+	if strings.Contains(n.Value, "\n") {
+		return
+	}
+
+	if quote == "single" {
+		n.Style = yaml.SingleQuotedStyle
+	} else {
+		n.Style = yaml.DoubleQuotedStyle
+	}
+}
+
+// sortYAMLMapping sorts a mapping node's key/value pairs by key
+func sortYAMLMapping(n *yaml.Node) {
+	pairs := make([][2]*yaml.Node, 0, len(n.Content)/2)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		pairs = append(pairs, [2]*yaml.Node{n.Content[i], n.Content[i+1]})
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i][0].Value < pairs[j][0].Value
+	})
+
+	for i, p := range pairs {
+		n.Content[2*i], n.Content[2*i+1] = p[0], p[1]
+	}
+}
+
+// JSONOptions control how values are formatted by ToJSONPrettyWithOptions
+type JSONOptions struct {
+	// Indent is used to indent nested values
+	Indent string
+	// FlowMaxKeys is the largest number of keys an object can have to be
+	// written on a single line, when all its values are scalars. Zero (the
+	// default) disables this.
+	FlowMaxKeys int
+}
+
+// JSONOptionsFromMap - converts a map of options (as given in a template) to
+// JSONOptions. The indent defaults to two spaces.
+func JSONOptionsFromMap(m map[string]interface{}) (JSONOptions, error) {
+	opts := JSONOptions{}
+
+	for k, v := range m {
+		switch k {
+		case "indent":
+			// a number of spaces, or the indent string itself
+			if s, ok := v.(string); ok {
+				opts.Indent = s
+				continue
+			}
+
+			n, err := conv.ToInt(v)
+			if err != nil || n < 0 {
+				return opts, fmt.Errorf("indent must be a string or a non-negative number, not %v", v)
+			}
+
+			opts.Indent = strings.Repeat(" ", n)
+		case "flowMaxKeys":
+			n, err := conv.ToInt(v)
+			if err != nil || n < 0 {
+				return opts, fmt.Errorf("flowMaxKeys must be a non-negative number, not %v", v)
+			}
+
+			opts.FlowMaxKeys = n
+		default:
+			return opts, fmt.Errorf("unknown JSON option %q", k)
+		}
+	}
+
+	if _, ok := m["indent"]; !ok {
+		opts.Indent = "  "
+	}
+
+	return opts, nil
+}
+
+// ToJSONPrettyWithOptions - Stringify a struct as indented JSON, formatted
+// according to the given options
+func ToJSONPrettyWithOptions(in interface{}, opts JSONOptions) (string, error) {
+	b, err := toJSONBytes(in)
+	if err != nil {
+		return "", err
+	}
+
+	// the encoded JSON is compact, so there's no whitespace outside of
+	// strings to deal with
+	b = bytes.TrimSpace(b)
+
+	w := &jsonWriter{b: b, opts: opts}
+	w.value(0)
+
+	return w.out.String(), nil
+}
+
+// jsonWriter re-formats compact JSON. Strings and numbers are copied
+// verbatim, so their encoding is preserved.
+type jsonWriter struct {
+	out  bytes.Buffer
+	b    []byte
+	opts JSONOptions
+	pos  int
+}
+
+// value writes the value at the current position, at the given depth
+func (w *jsonWriter) value(depth int) {
+	switch w.b[w.pos] {
+	case '{', '[':
+		w.container(depth)
+	default:
+		end := w.scalarEnd(w.pos)
+		w.out.Write(w.b[w.pos:end])
+		w.pos = end
+	}
+}
+
+func (w *jsonWriter) container(depth int) {
+	open := w.b[w.pos]
+	end := w.containerEnd(w.pos)
+
+	// empty containers are written as-is
+	if end-w.pos == 2 {
+		w.out.Write(w.b[w.pos:end])
+		w.pos = end
+
+		return
+	}
+
+	if open == '{' && w.isFlow(w.pos, end) {
+		w.flowObject(end)
+		return
+	}
+
+	w.out.WriteByte(open)
+	w.pos++
+
+	for {
+		w.newline(depth + 1)
+
+		if open == '{' {
+			// key and colon
+			kend := w.scalarEnd(w.pos)
+			w.out.Write(w.b[w.pos:kend])
+			w.out.WriteString(": ")
+			w.pos = kend + 1
+		}
+
+		w.value(depth + 1)
+
+		if w.b[w.pos] != ',' {
+			break
+		}
+
+		w.out.WriteByte(',')
+		w.pos++
+	}
+
+	w.newline(depth)
+	w.out.WriteByte(w.b[w.pos])
+	w.pos++
+}
+
+// flowObject writes the object at the current position on a single line
+func (w *jsonWriter) flowObject(end int) {
+	w.out.WriteByte('{')
+	w.pos++
+
+	for w.pos < end-1 {
+		kend := w.scalarEnd(w.pos)
+		w.out.Write(w.b[w.pos:kend])
+		w.out.WriteString(": ")
+
+		vend := w.scalarEnd(kend + 1)
+		w.out.Write(w.b[kend+1 : vend])
+		w.pos = vend
+
+		if w.b[w.pos] == ',' {
+			w.out.WriteString(", ")
+			w.pos++
+		}
+	}
+
+	w.out.WriteByte('}')
+	w.pos = end
+}
+
+// isFlow returns true when the object between start and end has few enough
+// keys, and only scalar values
+func (w *jsonWriter) isFlow(start, end int) bool {
+	if w.opts.FlowMaxKeys == 0 {
+		return false
+	}
+
+	keys := 0
+	for i := start + 1; i < end-1; {
+		// skip the key and colon
+		i = w.scalarEnd(i) + 1
+		if w.b[i] == '{' || w.b[i] == '[' {
+			return false
+		}
+
+		i = w.scalarEnd(i)
+		keys++
+
+		if w.b[i] == ',' {
+			i++
+		}
+	}
+
+	return keys <= w.opts.FlowMaxKeys
+}
+
+func (w *jsonWriter) newline(depth int) {
+	w.out.WriteByte('\n')
+	for range depth {
+		w.out.WriteString(w.opts.Indent)
+	}
+}
+
+// scalarEnd returns the index just past the string, number, or literal
+// starting at i
+func (w *jsonWriter) scalarEnd(i int) int {
+	if w.b[i] == '"' {
+		for i++; i < len(w.b); i++ {
+			switch w.b[i] {
+			case '\\':
+				i++
+			case '"':
+				return i + 1
+			}
+		}
+
+		return i
+	}
+
+	for i < len(w.b) && !bytes.ContainsAny(w.b[i:i+1], ",:]}") {
+		i++
+	}
+
+	return i
+}
+
+// containerEnd returns the index just past the object or array starting at i
+func (w *jsonWriter) containerEnd(i int) int {
+	depth := 0
+	for i < len(w.b) {
+		switch w.b[i] {
+		case '"':
+			i = w.scalarEnd(i)
+			continue
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+		i++
+	}
+
+	return i
+}
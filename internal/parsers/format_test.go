@@ -0,0 +1,158 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestYAMLOptionsFromMap(t *testing.T) {
+	opts, err := YAMLOptionsFromMap(map[string]interface{}{
+		"indent":      "4",
+		"sortKeys":    false,
+		"flowMaxKeys": 3,
+		"quote":       "single",
+	})
+	require.NoError(t, err)
+
+	sortKeys := false
+	assert.Equal(t, YAMLOptions{Indent: 4, SortKeys: &sortKeys, FlowMaxKeys: 3, Quote: "single"}, opts)
+
+	for _, m := range []map[string]interface{}{
+		{"indent": 0},
+		{"indent": "foo"},
+		{"flowMaxKeys": -1},
+		{"quote": "backtick"},
+		{"bogus": true},
+	} {
+		_, err = YAMLOptionsFromMap(m)
+		assert.Error(t, err, m)
+	}
+}
+
+func TestToYAMLWithOptions(t *testing.T) {
+	in := map[string]interface{}{
+		"name": "web",
+		"ports": []interface{}{
+			map[string]interface{}{"port": 80, "protocol": "TCP"},
+			map[string]interface{}{"port": 443, "protocol": "TCP", "name": "https"},
+		},
+		"labels":  map[string]interface{}{"app": "web"},
+		"script":  "echo hello\necho world\n",
+		"enabled": true,
+		"version": "1.0",
+	}
+
+	// no options is the same as ToYAML
+	expected, err := ToYAML(in)
+	require.NoError(t, err)
+
+	out, err := ToYAMLWithOptions(in, YAMLOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, expected, out)
+
+	out, err = ToYAMLWithOptions(in, YAMLOptions{Indent: 4, FlowMaxKeys: 2, Quote: "single"})
+	require.NoError(t, err)
+	assert.Equal(t, `enabled: true
+labels: {app: 'web'}
+name: 'web'
+ports:
+    - {port: 80, protocol: 'TCP'}
+    - name: 'https'
+      port: 443
+      protocol: 'TCP'
+script: |
+    echo hello
+    echo world
+version: '1.0'
+`, out)
+
+	out, err = ToYAMLWithOptions(map[string]interface{}{"a": "yes", "b": 1, "c": ""}, YAMLOptions{Quote: "double"})
+	require.NoError(t, err)
+	assert.Equal(t, "a: \"yes\"\nb: 1\nc: \"\"\n", out)
+
+	// structs keep their field order unless sorted
+	s := struct {
+		Zed   string
+		Alpha string
+	}{"z", "a"}
+
+	out, err = ToYAMLWithOptions(s, YAMLOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "zed: z\nalpha: a\n", out)
+
+	sortKeys := true
+	out, err = ToYAMLWithOptions(s, YAMLOptions{SortKeys: &sortKeys})
+	require.NoError(t, err)
+	assert.Equal(t, "alpha: a\nzed: z\n", out)
+}
+
+func TestJSONOptionsFromMap(t *testing.T) {
+	opts, err := JSONOptionsFromMap(map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, JSONOptions{Indent: "  "}, opts)
+
+	opts, err = JSONOptionsFromMap(map[string]interface{}{"indent": 4, "flowMaxKeys": "2"})
+	require.NoError(t, err)
+	assert.Equal(t, JSONOptions{Indent: "    ", FlowMaxKeys: 2}, opts)
+
+	opts, err = JSONOptionsFromMap(map[string]interface{}{"indent": "\t"})
+	require.NoError(t, err)
+	assert.Equal(t, JSONOptions{Indent: "\t"}, opts)
+
+	for _, m := range []map[string]interface{}{
+		{"indent": -1},
+		{"flowMaxKeys": "foo"},
+		{"bogus": true},
+	} {
+		_, err = JSONOptionsFromMap(m)
+		assert.Error(t, err, m)
+	}
+}
+
+func TestToJSONPrettyWithOptions(t *testing.T) {
+	in := map[string]interface{}{
+		"a": []interface{}{1, 2.5, "x,y:]}", map[string]interface{}{}, []interface{}{}},
+		"b": map[string]interface{}{"c": `quo"te\`, "d": nil, "e": true},
+		"f": map[string]interface{}{"g": map[string]interface{}{"h": 1}},
+		"<": "&",
+		"e": []interface{}{},
+	}
+
+	// with no flow style, the output is the same as ToJSONPretty
+	for _, indent := range []string{"", "  ", "\t"} {
+		expected, err := ToJSONPretty(indent, in)
+		require.NoError(t, err)
+
+		out, err := ToJSONPrettyWithOptions(in, JSONOptions{Indent: indent})
+		require.NoError(t, err)
+		assert.Equal(t, expected, out)
+	}
+
+	out, err := ToJSONPrettyWithOptions(in, JSONOptions{Indent: "  ", FlowMaxKeys: 3})
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  "\u003c": "\u0026",
+  "a": [
+    1,
+    2.5,
+    "x,y:]}",
+    {},
+    []
+  ],
+  "b": {"c": "quo\"te\\", "d": null, "e": true},
+  "e": [],
+  "f": {
+    "g": {"h": 1}
+  }
+}`, out)
+
+	out, err = ToJSONPrettyWithOptions(in["b"], JSONOptions{Indent: "  ", FlowMaxKeys: 2})
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"c\": \"quo\\\"te\\\\\",\n  \"d\": null,\n  \"e\": true\n}", out)
+
+	out, err = ToJSONPrettyWithOptions("scalar", JSONOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, `"scalar"`, out)
+}
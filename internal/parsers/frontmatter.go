@@ -0,0 +1,74 @@
+package parsers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FrontMatter - Split a document (usually Markdown) into its front matter and
+// body. The front matter is a YAML block delimited by "---" lines, or a TOML
+// block delimited by "+++" lines, at the very start of the document.
+//
+// The result is a map with the parsed front matter in "matter", and the rest
+// of the document in "body". When there's no front matter, "matter" is an
+// empty map and "body" is the whole document.
+func FrontMatter(in string) (map[string]interface{}, error) {
+	in = strings.TrimPrefix(in, "\ufeff")
+
+	first, rest, _ := strings.Cut(in, "\n")
+	delim := strings.TrimRight(first, " \t\r")
+
+	var parse func(string) (interface{}, error)
+
+	switch delim {
+	case "---":
+		parse = func(s string) (interface{}, error) { return YAML(s) }
+	case "+++":
+		parse = TOML
+	default:
+		return map[string]interface{}{
+			"matter": map[string]interface{}{},
+			"body":   in,
+		}, nil
+	}
+
+	matter, body, ok := cutFrontMatter(rest, delim)
+	if !ok {
+		return nil, fmt.Errorf("unable to parse front matter: no closing %q line", delim)
+	}
+
+	m, err := parse(matter)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse front matter: %w", err)
+	}
+
+	return map[string]interface{}{
+		"matter": m,
+		"body":   body,
+	}, nil
+}
+
+// cutFrontMatter splits the document (after the opening delimiter line) at the
+// closing delimiter line
+func cutFrontMatter(in, delim string) (matter, body string, ok bool) {
+	for off := 0; off <= len(in); {
+		line, _, found := strings.Cut(in[off:], "\n")
+
+		if strings.TrimRight(line, " \t\r") == delim {
+			end := off + len(line)
+			if found {
+				end++
+			}
+
+			return in[:off], in[end:], true
+		}
+
+		if !found {
+			break
+		}
+
+		off += len(line) + 1
+	}
+
+	return "", "", false
+}
@@ -0,0 +1,61 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrontMatter(t *testing.T) {
+	testdata := []struct {
+		in     string
+		matter map[string]interface{}
+		body   string
+	}{
+		{
+			"---\ntitle: Hello\ntags: [a, b]\n---\n# Hello\n\nWorld\n",
+			map[string]interface{}{"title": "Hello", "tags": []interface{}{"a", "b"}},
+			"# Hello\n\nWorld\n",
+		},
+		{
+			"+++\ntitle = \"Hello\"\ndraft = true\n+++\n# Hello\n",
+			map[string]interface{}{"title": "Hello", "draft": true},
+			"# Hello\n",
+		},
+		{
+			"\ufeff---\r\ntitle: Hello\r\n---\r\nbody",
+			map[string]interface{}{"title": "Hello"},
+			"body",
+		},
+		{"---\n---\nbody\n", map[string]interface{}{}, "body\n"},
+		{"---\ntitle: Hello\n---", map[string]interface{}{"title": "Hello"}, ""},
+		{"# Hello\n---\nfoo: bar\n---\n", map[string]interface{}{}, "# Hello\n---\nfoo: bar\n---\n"},
+		{"", map[string]interface{}{}, ""},
+	}
+
+	for _, d := range testdata {
+		out, err := FrontMatter(d.in)
+		require.NoError(t, err, d.in)
+		assert.EqualValues(t, d.matter, out["matter"], d.in)
+		assert.Equal(t, d.body, out["body"], d.in)
+	}
+
+	_, err := FrontMatter("---\ntitle: Hello\n# Hello\n")
+	require.ErrorContains(t, err, "no closing")
+
+	_, err = FrontMatter("---\n- a\n- b\n---\n")
+	require.Error(t, err)
+
+	_, err = FrontMatter("+++\ntitle = \n+++\n")
+	require.Error(t, err)
+}
+
+func TestParseData_FrontMatter(t *testing.T) {
+	out, err := ParseData("text/markdown; charset=utf-8", "---\ntitle: Hello\n---\nbody\n")
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{
+		"matter": map[string]interface{}{"title": "Hello"},
+		"body":   "body\n",
+	}, out)
+}
@@ -0,0 +1,380 @@
+package parsers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// HCL - Unmarshal an HCL (version 2) document, such as a Terraform
+// configuration, into a map.
+//
+// Attributes become map entries. Blocks are nested under their type and then
+// each of their labels, and are always presented as lists of maps, since
+// blocks may be repeated. Expressions which can't be evaluated without
+// context (such as references to variables) are presented as strings in
+// "${...}" interpolation syntax.
+func HCL(in string) (map[string]interface{}, error) {
+	src := []byte(in)
+
+	f, diags := hclsyntax.ParseConfig(src, "<input>", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("unable to parse HCL: %w", diags)
+	}
+
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, fmt.Errorf("unable to parse HCL: unexpected body type %T", f.Body)
+	}
+
+	return hclBodyToMap(body, src)
+}
+
+func hclBodyToMap(body *hclsyntax.Body, src []byte) (map[string]interface{}, error) {
+	out := make(map[string]interface{}, len(body.Attributes)+len(body.Blocks))
+
+	for name, attr := range body.Attributes {
+		v, err := hclExprValue(attr.Expr, src)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %q: %w", name, err)
+		}
+
+		out[name] = v
+	}
+
+	for _, block := range body.Blocks {
+		content, err := hclBodyToMap(block.Body, src)
+		if err != nil {
+			return nil, err
+		}
+
+		m := out
+		key := block.Type
+
+		for _, label := range block.Labels {
+			next, ok := m[key].(map[string]interface{})
+			if !ok {
+				if _, exists := m[key]; exists {
+					return nil, hclConflictError(block, key)
+				}
+
+				next = map[string]interface{}{}
+				m[key] = next
+			}
+
+			m = next
+			key = label
+		}
+
+		list, ok := m[key].([]interface{})
+		if !ok {
+			if _, exists := m[key]; exists {
+				return nil, hclConflictError(block, key)
+			}
+		}
+
+		m[key] = append(list, content)
+	}
+
+	return out, nil
+}
+
+// hclConflictError describes a block which can't be added to the map because
+// its type or one of its labels (key) is already used differently - by an
+// attribute, or by blocks with a different number of labels
+func hclConflictError(block *hclsyntax.Block, key string) error {
+	name := block.Type
+	for _, label := range block.Labels {
+		name += fmt.Sprintf(" %q", label)
+	}
+
+	return fmt.Errorf("block %s: key %q is already used by an attribute or by blocks with a different number of labels, and a map can't be both a block and an attribute", name, key)
+}
+
+// hclExprValue evaluates the expression, falling back to the expression's
+// source text when it can't be evaluated without context
+func hclExprValue(expr hclsyntax.Expression, src []byte) (interface{}, error) {
+	v, diags := expr.Value(nil)
+	if !diags.HasErrors() {
+		return ctyToGo(v)
+	}
+
+	rng := expr.Range()
+	raw := string(src[rng.Start.Byte:rng.End.Byte])
+
+	if _, ok := expr.(*hclsyntax.TemplateExpr); ok && strings.HasPrefix(raw, `"`) {
+		return strings.TrimSuffix(strings.TrimPrefix(raw, `"`), `"`), nil
+	}
+
+	return "${" + raw + "}", nil
+}
+
+func ctyToGo(v cty.Value) (interface{}, error) {
+	if v.IsNull() || !v.IsKnown() {
+		return nil, nil
+	}
+
+	t := v.Type()
+
+	switch {
+	case t == cty.String:
+		return v.AsString(), nil
+	case t == cty.Bool:
+		return v.True(), nil
+	case t == cty.Number:
+		bf := v.AsBigFloat()
+		if bf.IsInt() {
+			if i, acc := bf.Int64(); acc == big.Exact {
+				return int(i), nil
+			}
+		}
+
+		f, _ := bf.Float64()
+
+		return f, nil
+	case t.IsListType() || t.IsSetType() || t.IsTupleType():
+		out := make([]interface{}, 0, v.LengthInt())
+
+		for it := v.ElementIterator(); it.Next(); {
+			_, ev := it.Element()
+
+			e, err := ctyToGo(ev)
+			if err != nil {
+				return nil, err
+			}
+
+			out = append(out, e)
+		}
+
+		return out, nil
+	case t.IsMapType() || t.IsObjectType():
+		out := make(map[string]interface{}, v.LengthInt())
+
+		for it := v.ElementIterator(); it.Next(); {
+			k, ev := it.Element()
+
+			e, err := ctyToGo(ev)
+			if err != nil {
+				return nil, err
+			}
+
+			out[k.AsString()] = e
+		}
+
+		return out, nil
+	default:
+		return nil, fmt.Errorf("unsupported HCL type %s", t.FriendlyName())
+	}
+}
+
+// ToHCL - Stringify a map as an HCL document. This is the inverse of [HCL]:
+// lists of maps (optionally nested in maps, which provide the labels) are
+// written as blocks, and strings in "${...}" interpolation syntax are written
+// as expressions.
+func ToHCL(in interface{}) (string, error) {
+	var obj map[string]interface{}
+	if err := normalizeJSON(in, &obj, "HCL"); err != nil {
+		return "", err
+	}
+
+	f := hclwrite.NewEmptyFile()
+
+	err := writeHCLBody(f.Body(), obj)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hclwrite.Format(f.Bytes())), nil
+}
+
+func writeHCLBody(body *hclwrite.Body, obj map[string]interface{}) error {
+	keys := sortedKeys(obj)
+
+	// attributes first, then blocks
+	for _, k := range keys {
+		if isHCLBlockTree(obj[k]) {
+			continue
+		}
+
+		if !hclsyntax.ValidIdentifier(k) {
+			return fmt.Errorf("invalid HCL attribute name %q", k)
+		}
+
+		tokens, err := hclValueTokens(obj[k])
+		if err != nil {
+			return fmt.Errorf("attribute %q: %w", k, err)
+		}
+
+		body.SetAttributeRaw(k, tokens)
+	}
+
+	for _, k := range keys {
+		if !isHCLBlockTree(obj[k]) {
+			continue
+		}
+
+		if !hclsyntax.ValidIdentifier(k) {
+			return fmt.Errorf("invalid HCL block type %q", k)
+		}
+
+		err := writeHCLBlocks(body, k, nil, obj[k])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeHCLBlocks(body *hclwrite.Body, typeName string, labels []string, v interface{}) error {
+	switch v := v.(type) {
+	case []interface{}:
+		for _, item := range v {
+			content := item.(map[string]interface{})
+
+			block := body.AppendNewBlock(typeName, labels)
+
+			err := writeHCLBody(block.Body(), content)
+			if err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		for _, k := range sortedKeys(v) {
+			err := writeHCLBlocks(body, typeName, append(labels[:len(labels):len(labels)], k), v[k])
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// isHCLBlockTree returns true when the value is a list of maps, or a non-empty
+// map of which all values are block trees
+func isHCLBlockTree(v interface{}) bool {
+	switch v := v.(type) {
+	case []interface{}:
+		if len(v) == 0 {
+			return false
+		}
+
+		for _, item := range v {
+			if _, ok := item.(map[string]interface{}); !ok {
+				return false
+			}
+		}
+
+		return true
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return false
+		}
+
+		for _, item := range v {
+			if !isHCLBlockTree(item) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return false
+	}
+}
+
+func hclValueTokens(v interface{}) (hclwrite.Tokens, error) {
+	switch v := v.(type) {
+	case nil:
+		return hclwrite.TokensForValue(cty.NullVal(cty.DynamicPseudoType)), nil
+	case bool:
+		return hclwrite.TokensForValue(cty.BoolVal(v)), nil
+	case json.Number:
+		n, err := cty.ParseNumberVal(v.String())
+		if err != nil {
+			return nil, err
+		}
+
+		return hclwrite.TokensForValue(n), nil
+	case string:
+		return hclStringTokens(v), nil
+	case []interface{}:
+		elems := make([]hclwrite.Tokens, len(v))
+
+		for i, item := range v {
+			t, err := hclValueTokens(item)
+			if err != nil {
+				return nil, err
+			}
+
+			elems[i] = t
+		}
+
+		return hclwrite.TokensForTuple(elems), nil
+	case map[string]interface{}:
+		attrs := make([]hclwrite.ObjectAttrTokens, 0, len(v))
+
+		for _, k := range sortedKeys(v) {
+			t, err := hclValueTokens(v[k])
+			if err != nil {
+				return nil, err
+			}
+
+			var name hclwrite.Tokens
+			if hclsyntax.ValidIdentifier(k) {
+				name = hclwrite.TokensForIdentifier(k)
+			} else {
+				name = hclwrite.TokensForValue(cty.StringVal(k))
+			}
+
+			attrs = append(attrs, hclwrite.ObjectAttrTokens{Name: name, Value: t})
+		}
+
+		return hclwrite.TokensForObject(attrs), nil
+	default:
+		return nil, fmt.Errorf("unsupported type %T", v)
+	}
+}
+
+// hclStringTokens returns the tokens for a string, treating "${" sequences as
+// interpolations. A string consisting only of a single interpolation is
+// written as a bare expression.
+func hclStringTokens(s string) hclwrite.Tokens {
+	if strings.HasPrefix(s, "${") && strings.HasSuffix(s, "}") && strings.Count(s, "${") == 1 {
+		f, diags := hclwrite.ParseConfig([]byte("x = "+s[2:len(s)-1]), "", hcl.InitialPos)
+		if !diags.HasErrors() {
+			if attr := f.Body().GetAttribute("x"); attr != nil {
+				return attr.Expr().BuildTokens(nil)
+			}
+		}
+	}
+
+	tokens := hclwrite.TokensForValue(cty.StringVal(s))
+	for _, t := range tokens {
+		if t.Type == hclsyntax.TokenQuotedLit {
+			t.Bytes = bytes.ReplaceAll(t.Bytes, []byte("$${"), []byte("${"))
+		}
+	}
+
+	return tokens
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
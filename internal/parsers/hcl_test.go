@@ -0,0 +1,153 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHCL(t *testing.T) {
+	in := `
+region = "us-east-1"
+count  = 3
+ratio  = 0.5
+enabled = true
+zones  = ["a", "b"]
+tags = {
+  Name = "web"
+  "kubernetes.io/role" = "node"
+}
+
+variable "env" {
+  default = "prod"
+}
+
+resource "aws_instance" "web" {
+  ami  = var.ami
+  name = "web-${var.env}"
+
+  ingress {
+    port = 80
+  }
+  ingress {
+    port = 443
+  }
+}
+`
+
+	expected := map[string]interface{}{
+		"region":  "us-east-1",
+		"count":   3,
+		"ratio":   0.5,
+		"enabled": true,
+		"zones":   []interface{}{"a", "b"},
+		"tags": map[string]interface{}{
+			"Name":               "web",
+			"kubernetes.io/role": "node",
+		},
+		"variable": map[string]interface{}{
+			"env": []interface{}{
+				map[string]interface{}{"default": "prod"},
+			},
+		},
+		"resource": map[string]interface{}{
+			"aws_instance": map[string]interface{}{
+				"web": []interface{}{
+					map[string]interface{}{
+						"ami":  "${var.ami}",
+						"name": "web-${var.env}",
+						"ingress": []interface{}{
+							map[string]interface{}{"port": 80},
+							map[string]interface{}{"port": 443},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	out, err := HCL(in)
+	require.NoError(t, err)
+	assert.Equal(t, expected, out)
+
+	_, err = HCL(`foo = `)
+	require.Error(t, err)
+
+	_, err = HCL("foo = 1\nfoo {\n}\n")
+	require.EqualError(t, err, `block foo: key "foo" is already used by an attribute or by blocks with a different number of labels, and a map can't be both a block and an attribute`)
+
+	// labelled and unlabelled blocks of the same type can't be mixed either
+	_, err = HCL("service \"web\" {\n}\nservice {\n}\n")
+	require.ErrorContains(t, err, `block service: key "service" is already used`)
+
+	_, err = HCL("service {\n}\nservice \"web\" \"a\" {\n}\n")
+	require.ErrorContains(t, err, `block service "web" "a": key "service" is already used`)
+
+	_, err = HCL("service \"web\" {\n}\nservice \"web\" \"a\" {\n}\n")
+	require.ErrorContains(t, err, `block service "web" "a": key "web" is already used`)
+}
+
+func TestToHCL(t *testing.T) {
+	in := map[string]interface{}{
+		"region": "us-east-1",
+		"count":  3,
+		"ratio":  0.5,
+		"zones":  []string{"a", "b"},
+		"tags": map[string]interface{}{
+			"Name":               "web",
+			"kubernetes.io/role": "node",
+		},
+		"resource": map[string]interface{}{
+			"aws_instance": map[string]interface{}{
+				"web": []interface{}{
+					map[string]interface{}{
+						"ami":  "${var.ami}",
+						"name": "web-${var.env}",
+						"ingress": []interface{}{
+							map[string]interface{}{"port": 80},
+							map[string]interface{}{"port": 443},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	expected := `count  = 3
+ratio  = 0.5
+region = "us-east-1"
+tags = {
+  Name                 = "web"
+  "kubernetes.io/role" = "node"
+}
+zones = ["a", "b"]
+resource "aws_instance" "web" {
+  ami  = var.ami
+  name = "web-${var.env}"
+  ingress {
+    port = 80
+  }
+  ingress {
+    port = 443
+  }
+}
+`
+
+	out, err := ToHCL(in)
+	require.NoError(t, err)
+	assert.Equal(t, expected, out)
+
+	// round-trip
+	parsed, err := HCL(out)
+	require.NoError(t, err)
+	out2, err := ToHCL(parsed)
+	require.NoError(t, err)
+	assert.Equal(t, out, out2)
+
+	_, err = ToHCL([]interface{}{"a", "b"})
+	require.Error(t, err)
+
+	_, err = ToHCL(map[string]interface{}{"not valid": 1})
+	require.Error(t, err)
+}
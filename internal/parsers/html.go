@@ -0,0 +1,147 @@
+package parsers
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/andybalholm/cascadia"
+	"golang.org/x/net/html"
+)
+
+// HTMLNode is an element in a parsed HTML document
+type HTMLNode struct {
+	node *html.Node
+
+	// Attrs are the element's attributes
+	Attrs map[string]string `json:"attrs"`
+	// Tag is the element's (lower-case) tag name
+	Tag string `json:"tag"`
+	// Text is all of the text within the element (including its children),
+	// with runs of whitespace collapsed to single spaces, and leading and
+	// trailing whitespace removed
+	Text string `json:"text"`
+	// Children are the element's child elements
+	Children []*HTMLNode `json:"children"`
+}
+
+// String returns the element's text
+func (n *HTMLNode) String() string {
+	return n.Text
+}
+
+// HTML renders the element (including its children) as HTML
+func (n *HTMLNode) HTML() (string, error) {
+	buf := &bytes.Buffer{}
+
+	err := html.Render(buf, n.node)
+	if err != nil {
+		return "", fmt.Errorf("unable to render HTML: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// HTML - Parse an HTML document, returning its root (html) element. Like a web
+// browser, the parser is lenient - missing elements (such as html, head, and
+// body) are added, and unclosed elements are closed.
+func HTML(in string) (*HTMLNode, error) {
+	doc, err := html.Parse(strings.NewReader(in))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse HTML: %w", err)
+	}
+
+	for c := doc.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode {
+			return newHTMLNode(c), nil
+		}
+	}
+
+	return nil, fmt.Errorf("unable to parse HTML: no root element")
+}
+
+// HTMLQuery - Find the elements within n (not including n itself) which match
+// the CSS selector, in document order.
+func HTMLQuery(selector string, n *HTMLNode) ([]*HTMLNode, error) {
+	sel, err := cascadia.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSS selector %q: %w", selector, err)
+	}
+
+	matches := cascadia.QueryAll(n.node, sel)
+
+	out := make([]*HTMLNode, len(matches))
+	for i, m := range matches {
+		out[i] = newHTMLNode(m)
+	}
+
+	return out, nil
+}
+
+func newHTMLNode(n *html.Node) *HTMLNode {
+	return buildHTMLNode(&strings.Builder{}, n)
+}
+
+// buildHTMLNode converts n and its child elements, writing the text within n
+// to w as it goes. Each element's text is the part of w written while building
+// it, so the subtree is only walked once, instead of once per ancestor.
+func buildHTMLNode(w *strings.Builder, n *html.Node) *HTMLNode {
+	out := &HTMLNode{
+		node:     n,
+		Tag:      n.Data,
+		Attrs:    make(map[string]string, len(n.Attr)),
+		Children: []*HTMLNode{},
+	}
+
+	for _, a := range n.Attr {
+		name := a.Key
+		if a.Namespace != "" {
+			name = a.Namespace + ":" + a.Key
+		}
+
+		out.Attrs[name] = a.Val
+	}
+
+	// the text in scripts and styles isn't included
+	skipText := n.Type == html.ElementNode && (n.Data == "script" || n.Data == "style")
+	if skipText {
+		w = &strings.Builder{}
+	}
+
+	start := w.Len()
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.TextNode {
+			w.WriteString(c.Data)
+		}
+
+		if c.Type != html.ElementNode {
+			continue
+		}
+
+		out.Children = append(out.Children, buildHTMLNode(w, c))
+
+		// block elements are separated, so "<td>a</td><td>b</td>" isn't "ab"
+		if htmlBlockElements[c.Data] {
+			w.WriteByte(' ')
+		}
+	}
+
+	if !skipText {
+		out.Text = strings.Join(strings.Fields(w.String()[start:]), " ")
+	}
+
+	return out
+}
+
+//nolint:gochecknoglobals
+var htmlBlockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true,
+	"body": true, "br": true, "dd": true, "div": true, "dl": true, "dt": true,
+	"fieldset": true, "figcaption": true, "figure": true, "footer": true,
+	"form": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true,
+	"h6": true, "head": true, "header": true, "hr": true, "li": true, "main": true,
+	"nav": true, "ol": true, "option": true, "p": true, "pre": true,
+	"section": true, "table": true, "tbody": true, "td": true, "tfoot": true,
+	"th": true, "thead": true, "title": true, "tr": true, "ul": true,
+}
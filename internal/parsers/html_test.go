@@ -0,0 +1,103 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testHTML = `<!DOCTYPE html>
+<html lang="en">
+<head><title>Status</title><style>td { color: red }</style></head>
+<body>
+  <h1>Service <em>status</em></h1>
+  <table id="services">
+    <tr class="ok"><td class="name">web</td><td class="state">up</td></tr>
+    <tr class="failed"><td class="name">api</td><td class="state">down</td></tr>
+  </table>
+  <a href="/details" data-id="1">Details</a>
+  <script>var x = "hidden";</script>
+</body>
+</html>`
+
+func TestHTML(t *testing.T) {
+	doc, err := HTML(testHTML)
+	require.NoError(t, err)
+	assert.Equal(t, "html", doc.Tag)
+	assert.Equal(t, map[string]string{"lang": "en"}, doc.Attrs)
+	assert.Equal(t, "Status Service status web up api down Details", doc.Text)
+	assert.Equal(t, doc.Text, doc.String())
+	require.Len(t, doc.Children, 2)
+	assert.Equal(t, "head", doc.Children[0].Tag)
+	assert.Equal(t, "body", doc.Children[1].Tag)
+
+	// missing elements are added, and unclosed ones are closed
+	doc, err = HTML("<p>hello <b>world")
+	require.NoError(t, err)
+	assert.Equal(t, "html", doc.Tag)
+	assert.Equal(t, "hello world", doc.Text)
+
+	h, err := doc.HTML()
+	require.NoError(t, err)
+	assert.Equal(t, "<html><head></head><body><p>hello <b>world</b></p></body></html>", h)
+}
+
+func TestHTMLQuery(t *testing.T) {
+	doc, err := HTML(testHTML)
+	require.NoError(t, err)
+
+	out, err := HTMLQuery("tr.failed td.name", doc)
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+	assert.Equal(t, "api", out[0].Text)
+	assert.Equal(t, "td", out[0].Tag)
+
+	out, err = HTMLQuery("#services tr", doc)
+	require.NoError(t, err)
+	require.Len(t, out, 2)
+	assert.Equal(t, "web up", out[0].Text)
+	assert.Equal(t, map[string]string{"class": "failed"}, out[1].Attrs)
+	require.Len(t, out[1].Children, 2)
+	assert.Equal(t, "down", out[1].Children[1].Text)
+
+	out, err = HTMLQuery("a[data-id]", doc)
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+	assert.Equal(t, "/details", out[0].Attrs["href"])
+
+	h, err := out[0].HTML()
+	require.NoError(t, err)
+	assert.Equal(t, `<a href="/details" data-id="1">Details</a>`, h)
+
+	// queries can be nested
+	rows, err := HTMLQuery("tr", doc)
+	require.NoError(t, err)
+
+	out, err = HTMLQuery(".state", rows[1])
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+	assert.Equal(t, "down", out[0].Text)
+
+	out, err = HTMLQuery("blink", doc)
+	require.NoError(t, err)
+	assert.Empty(t, out)
+
+	_, err = HTMLQuery("tr[", doc)
+	require.ErrorContains(t, err, "invalid CSS selector")
+}
+
+func TestHTMLText_Nested(t *testing.T) {
+	doc, err := HTML(`<div>a<div>b<p>c</p><style>p {}</style>d</div>e</div>`)
+	require.NoError(t, err)
+
+	body := doc.Children[1]
+	outer := body.Children[0]
+	inner := outer.Children[0]
+
+	assert.Equal(t, "abc d e", outer.Text)
+	assert.Equal(t, "bc d", inner.Text)
+	assert.Equal(t, "c", inner.Children[0].Text)
+	assert.Equal(t, "", inner.Children[1].Text)
+	assert.Equal(t, "abc d e", body.Text)
+}
@@ -0,0 +1,196 @@
+package parsers
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"gopkg.in/ini.v1"
+)
+
+// INI - Unmarshal an INI document into a map.
+//
+// Keys in the default (unnamed) section are at the top level, and each
+// section is a nested map. Section names containing dots (like "[a.b]") are
+// nested further, so "[a.b]" is found at a.b. All values are strings, except
+// for keys which are repeated within a section, which are presented as lists
+// of strings.
+func INI(in string) (map[string]interface{}, error) {
+	cfg, err := ini.LoadSources(iniOptions(), []byte(in))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse INI: %w", err)
+	}
+
+	out := map[string]interface{}{}
+
+	for _, sec := range cfg.Sections() {
+		m := out
+
+		if sec.Name() != ini.DefaultSection {
+			for _, part := range strings.Split(sec.Name(), ".") {
+				next, ok := m[part].(map[string]interface{})
+				if !ok {
+					if _, exists := m[part]; exists {
+						return nil, fmt.Errorf("unable to parse INI: section %q conflicts with a key of the same name", sec.Name())
+					}
+
+					next = map[string]interface{}{}
+					m[part] = next
+				}
+
+				m = next
+			}
+		}
+
+		for _, key := range sec.Keys() {
+			if _, exists := m[key.Name()]; exists {
+				return nil, fmt.Errorf("unable to parse INI: key %q in section %q conflicts with a section of the same name", key.Name(), sec.Name())
+			}
+
+			values := key.ValueWithShadows()
+			if len(values) == 1 {
+				m[key.Name()] = values[0]
+
+				continue
+			}
+
+			list := make([]interface{}, len(values))
+			for i, v := range values {
+				list[i] = v
+			}
+
+			m[key.Name()] = list
+		}
+	}
+
+	return out, nil
+}
+
+// ToINI - Stringify a map as an INI document. This is the inverse of [INI]:
+// top-level values are written in the default section, and maps are written
+// as sections, with nested maps written as sections with dotted names. Lists
+// are written as repeated keys.
+func ToINI(in interface{}) (string, error) {
+	var obj map[string]interface{}
+	if err := normalizeJSON(in, &obj, "INI"); err != nil {
+		return "", err
+	}
+
+	cfg := ini.Empty(iniOptions())
+
+	err := writeINISection(cfg, "", obj)
+	if err != nil {
+		return "", err
+	}
+
+	buf := &bytes.Buffer{}
+
+	_, err = cfg.WriteTo(buf)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal INI: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+func iniOptions() ini.LoadOptions {
+	return ini.LoadOptions{
+		AllowShadows: true,
+		// preserve values as-is, since backslashes are common in paths
+		IgnoreContinuation: true,
+	}
+}
+
+// writeINISection writes the keys for the named section, followed by any
+// subsections. Sections with no keys of their own aren't written.
+func writeINISection(cfg *ini.File, name string, obj map[string]interface{}) error {
+	keys := sortedKeys(obj)
+
+	var sec *ini.Section
+
+	for _, k := range keys {
+		if _, ok := obj[k].(map[string]interface{}); ok {
+			continue
+		}
+
+		if sec == nil {
+			var err error
+
+			sec, err = cfg.NewSection(iniSectionName(name))
+			if err != nil {
+				return fmt.Errorf("unable to marshal INI section %q: %w", name, err)
+			}
+		}
+
+		err := writeINIKey(sec, k, obj[k])
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, k := range keys {
+		sub, ok := obj[k].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		subName := k
+		if name != "" {
+			subName = name + "." + k
+		}
+
+		err := writeINISection(cfg, subName, sub)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeINIKey(sec *ini.Section, k string, v interface{}) error {
+	var values []string
+
+	switch v := v.(type) {
+	case []interface{}:
+		for _, item := range v {
+			switch item.(type) {
+			case map[string]interface{}, []interface{}:
+				return fmt.Errorf("unable to marshal INI key %q: lists may only contain scalar values", k)
+			}
+
+			values = append(values, conv.ToString(item))
+		}
+	case nil:
+		values = []string{""}
+	default:
+		values = []string{conv.ToString(v)}
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	key, err := sec.NewKey(k, values[0])
+	if err != nil {
+		return fmt.Errorf("unable to marshal INI key %q: %w", k, err)
+	}
+
+	for _, s := range values[1:] {
+		err = key.AddShadow(s)
+		if err != nil {
+			return fmt.Errorf("unable to marshal INI key %q: %w", k, err)
+		}
+	}
+
+	return nil
+}
+
+func iniSectionName(name string) string {
+	if name == "" {
+		return ini.DefaultSection
+	}
+
+	return name
+}
@@ -0,0 +1,116 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestINI(t *testing.T) {
+	in := `; a comment
+user = nobody
+pidfile = /var/run/app.pid
+
+[server]
+host = 0.0.0.0
+port = 8080
+path = C:\app\data
+
+[server.tls]
+enabled = true
+
+[php]
+extension = curl
+extension = gd
+`
+
+	expected := map[string]interface{}{
+		"user":    "nobody",
+		"pidfile": "/var/run/app.pid",
+		"server": map[string]interface{}{
+			"host": "0.0.0.0",
+			"port": "8080",
+			"path": `C:\app\data`,
+			"tls": map[string]interface{}{
+				"enabled": "true",
+			},
+		},
+		"php": map[string]interface{}{
+			"extension": []interface{}{"curl", "gd"},
+		},
+	}
+
+	out, err := INI(in)
+	require.NoError(t, err)
+	assert.Equal(t, expected, out)
+
+	out, err = INI("")
+	require.NoError(t, err)
+	assert.Empty(t, out)
+
+	_, err = INI("server = foo\n[server]\nport = 80\n")
+	require.Error(t, err)
+
+	_, err = INI("[a]\nb = 1\n[a.b]\nc = 2\n")
+	require.Error(t, err)
+
+	_, err = INI("[unclosed\n")
+	require.Error(t, err)
+}
+
+func TestToINI(t *testing.T) {
+	in := map[string]interface{}{
+		"user": "nobody",
+		"server": map[string]interface{}{
+			"port": 8080,
+			"host": "0.0.0.0",
+			"tls": map[string]interface{}{
+				"enabled": true,
+			},
+		},
+		"logging": map[string]interface{}{
+			"file": map[string]interface{}{
+				"path": "/var/log/app.log",
+			},
+		},
+		"php": map[string]interface{}{
+			"extension": []interface{}{"curl", "gd"},
+		},
+	}
+
+	expected := `user = nobody
+
+[logging.file]
+path = /var/log/app.log
+
+[php]
+extension = curl
+extension = gd
+
+[server]
+host = 0.0.0.0
+port = 8080
+
+[server.tls]
+enabled = true
+`
+
+	out, err := ToINI(in)
+	require.NoError(t, err)
+	assert.Equal(t, expected, out)
+
+	// round-trip
+	parsed, err := INI(out)
+	require.NoError(t, err)
+
+	out2, err := ToINI(parsed)
+	require.NoError(t, err)
+	assert.Equal(t, out, out2)
+
+	_, err = ToINI([]interface{}{"a"})
+	require.Error(t, err)
+
+	_, err = ToINI(map[string]interface{}{"a": []interface{}{map[string]interface{}{}}})
+	require.Error(t, err)
+}
@@ -0,0 +1,81 @@
+package parsers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/titanous/json5"
+)
+
+// JSON5 - Unmarshal a JSON5 document. Any type of value is supported.
+//
+// JSON5 is a superset of JSON which allows comments, trailing commas, unquoted
+// keys, single-quoted strings, and hexadecimal numbers, among other things.
+// Documents in the JSONC ("JSON with comments") dialect are also JSON5
+// documents.
+//
+// Integers are presented as ints where possible, as with [JSON].
+func JSON5(in string) (interface{}, error) {
+	dec := json5.NewDecoder(strings.NewReader(in))
+	dec.UseNumber()
+
+	var out interface{}
+
+	err := dec.Decode(&out)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal JSON5: %w", err)
+	}
+
+	// only a single value is allowed
+	var extra interface{}
+
+	err = dec.Decode(&extra)
+	if !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("unable to unmarshal JSON5: unexpected content after the top-level value")
+	}
+
+	return normalizeJSON5(out)
+}
+
+func normalizeJSON5(in interface{}) (interface{}, error) {
+	switch v := in.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			n, err := normalizeJSON5(item)
+			if err != nil {
+				return nil, err
+			}
+
+			v[k] = n
+		}
+
+		return v, nil
+	case []interface{}:
+		for i, item := range v {
+			n, err := normalizeJSON5(item)
+			if err != nil {
+				return nil, err
+			}
+
+			v[i] = n
+		}
+
+		return v, nil
+	case json5.Number:
+		if i, err := v.Int64(); err == nil && i >= math.MinInt && i <= math.MaxInt {
+			return int(i), nil
+		}
+
+		f, err := v.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("unable to unmarshal JSON5: invalid number %s: %w", v, err)
+		}
+
+		return f, nil
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,68 @@
+package parsers
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSON5(t *testing.T) {
+	// a JSONC document, as used by tsconfig.json
+	in := `{
+	// compiler options
+	"compilerOptions": {
+		"target": "es2020",
+		"strict": true, /* be strict */
+		"paths": ["src", "lib",],
+	},
+}`
+
+	out, err := JSON5(in)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"compilerOptions": map[string]interface{}{
+			"target": "es2020",
+			"strict": true,
+			"paths":  []interface{}{"src", "lib"},
+		},
+	}, out)
+
+	in = `{
+	unquoted: 'single-quoted',
+	hex: 0xFF,
+	positive: +1,
+	ratio: .5,
+	big: 1e3,
+	nothing: null,
+	lineBreak: "a\
+b",
+}`
+
+	out, err = JSON5(in)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"unquoted":  "single-quoted",
+		"hex":       255,
+		"positive":  1,
+		"ratio":     0.5,
+		"big":       1000.0,
+		"nothing":   nil,
+		"lineBreak": "ab",
+	}, out)
+
+	out, err = JSON5(`[1, 'two', Infinity,]`)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, "two", math.Inf(1)}, out)
+
+	out, err = JSON5(`"just a string"`)
+	require.NoError(t, err)
+	assert.Equal(t, "just a string", out)
+
+	_, err = JSON5(`{"a": }`)
+	require.Error(t, err)
+
+	_, err = JSON5(`{"a": 1} {"b": 2}`)
+	require.Error(t, err)
+}
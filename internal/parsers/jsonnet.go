@@ -0,0 +1,78 @@
+package parsers
+
+import (
+	"fmt"
+	"mime"
+	"net/url"
+	"path/filepath"
+
+	"github.com/google/go-jsonnet"
+	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
+	"github.com/hairyhenderson/yaml"
+)
+
+// Jsonnet - Evaluate a Jsonnet document, and unmarshal the result. The
+// extVars are made available to the document as external variables (with
+// std.extVar).
+//
+// Imports are resolved relative to the working directory, and then to the
+// directories listed in the JSONNET_PATH environment variable.
+func Jsonnet(in string, extVars map[string]string) (interface{}, error) {
+	vm := jsonnet.MakeVM()
+	vm.Importer(&jsonnet.FileImporter{
+		JPaths: filepath.SplitList(getenv("JSONNET_PATH")),
+	})
+
+	for k, v := range extVars {
+		vm.ExtVar(k, v)
+	}
+
+	out, err := vm.EvaluateAnonymousSnippet("<input>", in)
+	if err != nil {
+		return nil, fmt.Errorf("unable to evaluate Jsonnet: %w", err)
+	}
+
+	var v interface{}
+
+	err = yaml.Unmarshal([]byte(out), &v)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal Jsonnet output: %w", err)
+	}
+
+	return v, nil
+}
+
+// JsonnetMediaType returns the Jsonnet media type, with the given external
+// variables encoded in an "ext" parameter. This is how external variables set
+// in a datasource URL's query string are passed along to [ParseData].
+func JsonnetMediaType(extVars url.Values) string {
+	if len(extVars) == 0 {
+		return iohelpers.JsonnetMimetype
+	}
+
+	return mime.FormatMediaType(iohelpers.JsonnetMimetype, map[string]string{
+		"ext": extVars.Encode(),
+	})
+}
+
+// jsonnetExtVars decodes the external variables encoded in the media type by
+// [JsonnetMediaType]. When a variable is set more than once, the last value
+// is used.
+func jsonnetExtVars(mediaType string) (map[string]string, error) {
+	_, params, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid media type %q: %w", mediaType, err)
+	}
+
+	q, err := url.ParseQuery(params["ext"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid Jsonnet external variables %q: %w", params["ext"], err)
+	}
+
+	extVars := make(map[string]string, len(q))
+	for k, v := range q {
+		extVars[k] = v[len(v)-1]
+	}
+
+	return extVars, nil
+}
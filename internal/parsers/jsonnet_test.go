@@ -0,0 +1,76 @@
+package parsers
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJsonnet(t *testing.T) {
+	in := `local greeting = 'hello';
+{
+  greeting: greeting,
+  env: std.extVar('env'),
+  ports: [80, 443],
+  ratio: 0.5,
+  nested: { enabled: true },
+}`
+
+	expected := map[string]interface{}{
+		"greeting": "hello",
+		"env":      "prod",
+		"ports":    []interface{}{80, 443},
+		"ratio":    0.5,
+		"nested":   map[string]interface{}{"enabled": true},
+	}
+
+	out, err := Jsonnet(in, map[string]string{"env": "prod"})
+	require.NoError(t, err)
+	assert.Equal(t, expected, out)
+
+	out, err = Jsonnet(`[1, 'two', null]`, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, "two", nil}, out)
+
+	out, err = Jsonnet(`'a' + 'b'`, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "ab", out)
+
+	_, err = Jsonnet(`{env: std.extVar('env')}`, nil)
+	require.ErrorContains(t, err, "Undefined external variable: env")
+
+	_, err = Jsonnet(`{`, nil)
+	require.Error(t, err)
+}
+
+func TestJsonnet_Imports(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "util.libsonnet"),
+		[]byte(`{ double(x):: x * 2 }`), 0o600)
+	require.NoError(t, err)
+
+	t.Setenv("JSONNET_PATH", dir)
+
+	out, err := Jsonnet(`local u = import 'util.libsonnet'; { n: u.double(21) }`, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"n": 42}, out)
+}
+
+func TestJsonnetMediaType(t *testing.T) {
+	assert.Equal(t, "application/jsonnet", JsonnetMediaType(nil))
+
+	mt := JsonnetMediaType(url.Values{"env": {"dev", "prod"}, "Region": {"us east"}})
+	assert.Equal(t, `application/jsonnet; ext="Region=us+east&env=dev&env=prod"`, mt)
+
+	extVars, err := jsonnetExtVars(mt)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "prod", "Region": "us east"}, extVars)
+
+	out, err := ParseData(mt, `{env: std.extVar('env'), region: std.extVar('Region')}`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"env": "prod", "region": "us east"}, out)
+}
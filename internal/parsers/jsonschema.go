@@ -0,0 +1,100 @@
+package parsers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	cueerrors "cuelang.org/go/cue/errors"
+	"cuelang.org/go/encoding/jsonschema"
+)
+
+// SchemaError is a single JSON Schema validation failure
+type SchemaError struct {
+	// Path is a JSON Pointer (RFC 6901) to the invalid value, which is "" for
+	// the document root
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (e SchemaError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+
+	return e.Path + ": " + e.Message
+}
+
+// JSONSchemaValidate validates the input against the given (already-parsed)
+// JSON Schema, returning every validation failure found. An error is returned
+// only when the schema itself is invalid.
+//
+// Validation is done by converting the schema to CUE, so keywords that CUE
+// can't represent (like "format") aren't enforced. Missing required properties
+// are only reported when there are no other errors.
+func JSONSchemaValidate(schema, in interface{}) ([]SchemaError, error) {
+	cuectx := cuecontext.New()
+
+	sv := cuectx.Encode(schema)
+	if sv.Err() != nil {
+		return nil, fmt.Errorf("unable to encode JSON Schema: %w", sv.Err())
+	}
+
+	f, err := jsonschema.Extract(sv, &jsonschema.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to compile JSON Schema: %w", cueError(err))
+	}
+
+	s := cuectx.BuildFile(f)
+	if s.Err() != nil {
+		return nil, fmt.Errorf("unable to compile JSON Schema: %w", cueError(s.Err()))
+	}
+
+	v := cuectx.Encode(in)
+	if v.Err() != nil {
+		return nil, fmt.Errorf("unable to encode value: %w", v.Err())
+	}
+
+	err = s.Unify(v).Validate(cue.Concrete(true))
+	if err == nil {
+		return nil, nil
+	}
+
+	out := []SchemaError{}
+	for _, e := range cueerrors.Errors(err) {
+		format, args := e.Msg()
+		msg := fmt.Sprintf(format, args...)
+
+		// skip summary messages like "2 errors in empty disjunction:", since
+		// each of the underlying errors is also reported
+		if strings.HasSuffix(msg, ":") {
+			continue
+		}
+
+		out = append(out, SchemaError{Path: jsonPointer(e.Path()), Message: msg})
+	}
+
+	return out, nil
+}
+
+// jsonPointer converts a CUE error path to a JSON Pointer - the root is the
+// empty string
+func jsonPointer(path []string) string {
+	sb := strings.Builder{}
+	for _, p := range path {
+		// CUE quotes labels that aren't valid identifiers
+		if uq, err := strconv.Unquote(p); err == nil {
+			p = uq
+		}
+
+		p = strings.ReplaceAll(p, "~", "~0")
+		p = strings.ReplaceAll(p, "/", "~1")
+
+		sb.WriteString("/")
+		sb.WriteString(p)
+	}
+
+	return sb.String()
+}
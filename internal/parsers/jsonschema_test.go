@@ -0,0 +1,85 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONSchemaValidate(t *testing.T) {
+	schema := map[string]interface{}{
+		"$schema":  "https://json-schema.org/draft/2020-12/schema",
+		"type":     "object",
+		"required": []interface{}{"name", "port"},
+		"properties": map[string]interface{}{
+			"name": map[string]interface{}{"type": "string", "minLength": 3},
+			"port": map[string]interface{}{"type": "integer", "minimum": 1, "maximum": 65535},
+			"tags": map[string]interface{}{
+				"type":  "array",
+				"items": map[string]interface{}{"type": "string"},
+			},
+			"mode":       map[string]interface{}{"enum": []interface{}{"a", "b"}},
+			"a/b~c":      map[string]interface{}{"type": "boolean"},
+			"with space": map[string]interface{}{"type": "boolean"},
+		},
+		"additionalProperties": false,
+	}
+
+	errs, err := JSONSchemaValidate(schema, map[string]interface{}{
+		"name": "abc",
+		"port": 80,
+		"tags": []interface{}{"x"},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, errs)
+
+	errs, err = JSONSchemaValidate(schema, map[string]interface{}{
+		"name":       "ab",
+		"port":       70000,
+		"tags":       []interface{}{1, "x"},
+		"mode":       "c",
+		"extra":      true,
+		"a/b~c":      "no",
+		"with space": 1,
+	})
+	require.NoError(t, err)
+
+	paths := map[string]bool{}
+	for _, e := range errs {
+		assert.NotEmpty(t, e.Message)
+		assert.NotContains(t, e.Message, "errors in empty disjunction")
+		paths[e.Path] = true
+	}
+	assert.Equal(t, map[string]bool{
+		"/name":       true,
+		"/port":       true,
+		"/tags/0":     true,
+		"/mode":       true,
+		"/extra":      true,
+		"/a~1b~0c":    true,
+		"/with space": true,
+	}, paths)
+
+	errs, err = JSONSchemaValidate(schema, map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, []SchemaError{
+		{Path: "/name", Message: "field is required but not present"},
+		{Path: "/port", Message: "field is required but not present"},
+	}, errs)
+
+	errs, err = JSONSchemaValidate(map[string]interface{}{"type": "string"}, 42)
+	require.NoError(t, err)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "", errs[0].Path)
+	assert.Equal(t, errs[0].Message, errs[0].Error())
+
+	_, err = JSONSchemaValidate(map[string]interface{}{"type": 42}, "foo")
+	require.Error(t, err)
+}
+
+func TestJSONPointer(t *testing.T) {
+	assert.Equal(t, "", jsonPointer(nil))
+	assert.Equal(t, "/a/0", jsonPointer([]string{"a", "0"}))
+	assert.Equal(t, "/a~1b/c~0d", jsonPointer([]string{`"a/b"`, "c~d"}))
+}
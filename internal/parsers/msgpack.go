@@ -0,0 +1,83 @@
+package parsers
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+	"github.com/ugorji/go/codec"
+)
+
+func msgpackHandle() *codec.MsgpackHandle {
+	h := &codec.MsgpackHandle{}
+	// decode strings (raw) as strings, rather than []byte
+	h.RawToString = true
+	// use the str8 and bin types from the current spec
+	h.WriteExt = true
+	// sort map keys, so output is deterministic
+	h.Canonical = true
+	// use the most compact encoding for positive integers
+	h.PositiveIntUnsigned = true
+
+	return h
+}
+
+// MsgPack - Unmarshal a MessagePack document. Any type of value is supported.
+// Map keys are converted to strings, and integers are presented as ints where
+// possible.
+func MsgPack(in string) (interface{}, error) {
+	var out interface{}
+
+	err := codec.NewDecoderBytes([]byte(in), msgpackHandle()).Decode(&out)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal MessagePack: %w", err)
+	}
+
+	return normalizeMsgPack(out), nil
+}
+
+func normalizeMsgPack(in interface{}) interface{} {
+	switch v := in.(type) {
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			out[conv.ToString(k)] = normalizeMsgPack(item)
+		}
+
+		return out
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeMsgPack(item)
+		}
+
+		return v
+	case int64:
+		if v >= math.MinInt && v <= math.MaxInt {
+			return int(v)
+		}
+
+		return v
+	case uint64:
+		if v <= math.MaxInt {
+			return int(v)
+		}
+
+		return v
+	default:
+		return v
+	}
+}
+
+// ToMsgPack - Marshal an object as MessagePack. Map keys are written in sorted
+// order.
+func ToMsgPack(in interface{}) (string, error) {
+	buf := &bytes.Buffer{}
+
+	err := codec.NewEncoder(buf, msgpackHandle()).Encode(in)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal MessagePack: %w", err)
+	}
+
+	return buf.String(), nil
+}
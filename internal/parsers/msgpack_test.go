@@ -0,0 +1,64 @@
+package parsers
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMsgPack(t *testing.T) {
+	// {"name": "web", "port": 8080, "tags": ["a", "b"], "debug": false, "ratio": 0.5, "nested": {"x": null}}
+	in := "\x86\xa4name\xa3web\xa4port\xcd\x1f\x90\xa4tags\x92\xa1a\xa1b" +
+		"\xa5debug\xc2\xa5ratio\xcb\x3f\xe0\x00\x00\x00\x00\x00\x00" +
+		"\xa6nested\x81\xa1x\xc0"
+
+	out, err := MsgPack(in)
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{
+		"name":   "web",
+		"port":   8080,
+		"tags":   []interface{}{"a", "b"},
+		"debug":  false,
+		"ratio":  0.5,
+		"nested": map[string]interface{}{"x": nil},
+	}, out)
+
+	// non-string map keys are stringified
+	out, err = MsgPack("\x81\x01\xa3one")
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{"1": "one"}, out)
+
+	out, err = MsgPack("\x93\x01\x02\x03")
+	require.NoError(t, err)
+	assert.EqualValues(t, []interface{}{1, 2, 3}, out)
+
+	// large unsigned integers are preserved
+	out, err = MsgPack("\xcf\xff\xff\xff\xff\xff\xff\xff\xff")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), out)
+
+	_, err = MsgPack("\x82\xa1a")
+	require.Error(t, err)
+}
+
+func TestToMsgPack(t *testing.T) {
+	out, err := ToMsgPack(map[string]interface{}{
+		"port": 8080,
+		"name": "web",
+		"tags": []interface{}{"a", "b"},
+	})
+	require.NoError(t, err)
+	// keys are sorted
+	assert.Equal(t, "\x83\xa4name\xa3web\xa4port\xcd\x1f\x90\xa4tags\x92\xa1a\xa1b", out)
+
+	// round-trip
+	v, err := MsgPack(out)
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{
+		"name": "web",
+		"port": 8080,
+		"tags": []interface{}{"a", "b"},
+	}, v)
+}
@@ -0,0 +1,60 @@
+package parsers
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hairyhenderson/yaml"
+)
+
+// NDJSON - Unmarshal a newline-delimited JSON (JSON Lines) document into a
+// list, with an element for each line. Blank lines are ignored.
+func NDJSON(in string) ([]interface{}, error) {
+	out := []interface{}{}
+
+	err := NDJSONEach(strings.NewReader(in), func(v interface{}) error {
+		out = append(out, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// NDJSONEach - Unmarshal each line of a newline-delimited JSON (JSON Lines)
+// document as it's read, calling fn with each value. Reading stops at the
+// first error, either from unmarshaling or from fn. Blank lines are ignored.
+func NDJSONEach(r io.Reader, fn func(v interface{}) error) error {
+	br := bufio.NewReader(r)
+
+	for n := 1; ; n++ {
+		line, err := br.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("unable to read NDJSON line %d: %w", n, err)
+		}
+
+		if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+			var v interface{}
+
+			uerr := yaml.Unmarshal(trimmed, &v)
+			if uerr != nil {
+				return fmt.Errorf("unable to unmarshal NDJSON line %d: %w", n, uerr)
+			}
+
+			uerr = fn(v)
+			if uerr != nil {
+				return uerr
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package parsers
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNDJSON(t *testing.T) {
+	in := `{"name": "web", "port": 8080, "tags": ["a", "b"]}
+{"name": "api", "port": 9090, "nested": {"x": null}}
+
+["not", "an", "object"]
+42
+`
+
+	out, err := NDJSON(in)
+	require.NoError(t, err)
+	assert.EqualValues(t, []interface{}{
+		map[string]interface{}{"name": "web", "port": 8080, "tags": []interface{}{"a", "b"}},
+		map[string]interface{}{"name": "api", "port": 9090, "nested": map[string]interface{}{"x": nil}},
+		[]interface{}{"not", "an", "object"},
+		42,
+	}, out)
+
+	// the final newline and carriage returns are optional
+	out, err = NDJSON("{\"a\": 1}\r\n{\"b\": 2}")
+	require.NoError(t, err)
+	assert.EqualValues(t, []interface{}{
+		map[string]interface{}{"a": 1},
+		map[string]interface{}{"b": 2},
+	}, out)
+
+	out, err = NDJSON("")
+	require.NoError(t, err)
+	assert.Empty(t, out)
+
+	_, err = NDJSON("{\"a\": 1}\n{\"b\": \n")
+	require.ErrorContains(t, err, "line 2")
+}
+
+func TestNDJSONEach(t *testing.T) {
+	in := "{\"n\": 1}\n{\"n\": 2}\n{\"n\": 3}\n"
+
+	seen := []interface{}{}
+	err := NDJSONEach(strings.NewReader(in), func(v interface{}) error {
+		seen = append(seen, v.(map[string]interface{})["n"])
+		return nil
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, []interface{}{1, 2, 3}, seen)
+
+	// errors from the callback stop reading
+	seen = []interface{}{}
+	err = NDJSONEach(strings.NewReader(in), func(v interface{}) error {
+		seen = append(seen, v)
+		if len(seen) == 2 {
+			return fmt.Errorf("stop")
+		}
+
+		return nil
+	})
+	require.EqualError(t, err, "stop")
+	assert.Len(t, seen, 2)
+}
@@ -0,0 +1,268 @@
+package parsers
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"mime"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/xitongsys/parquet-go/common"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/schema"
+	"github.com/xitongsys/parquet-go/source"
+)
+
+// ParquetColumnsParam is the parameter listing the columns to read from a
+// Parquet file, in both datasource URLs' query strings and the media type
+const ParquetColumnsParam = "columns"
+
+// ParquetMediaType returns the Parquet media type, with the given
+// (comma-separated) list of columns to read, if any.
+func ParquetMediaType(mimeType, columns string) string {
+	if columns == "" {
+		return mimeType
+	}
+
+	return mime.FormatMediaType(mimeType, map[string]string{ParquetColumnsParam: columns})
+}
+
+// parquetColumns returns the list of columns named in the media type, if any
+func parquetColumns(mediaType string) ([]string, error) {
+	_, params, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Parquet media type %q: %w", mediaType, err)
+	}
+
+	if params[ParquetColumnsParam] == "" {
+		return nil, nil
+	}
+
+	columns := strings.Split(params[ParquetColumnsParam], ",")
+	for i, c := range columns {
+		columns[i] = strings.TrimSpace(c)
+	}
+
+	return columns, nil
+}
+
+// Parquet - Unmarshal the rows of a Parquet file (from all row groups, in
+// order) into a list of maps, keyed by column name. When columns are given,
+// only those (top-level) columns are read.
+//
+// Dates and timestamps are presented as times in UTC, and other values are
+// presented with their physical types.
+func Parquet(in string, columns []string) ([]interface{}, error) {
+	// the footer is read first to get the schema, so that only the requested
+	// columns need to be read
+	fr := &reader.ParquetReader{PFile: newParquetBuffer(in)}
+	if err := fr.ReadFooter(); err != nil {
+		return nil, fmt.Errorf("unable to read Parquet file: %w", err)
+	}
+
+	elems := fr.Footer.GetSchema()
+	if len(elems) == 0 {
+		return nil, fmt.Errorf("unable to read Parquet file: no schema")
+	}
+
+	if len(columns) > 0 {
+		var err error
+
+		elems, err = parquetProjection(elems, columns)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read Parquet file: %w", err)
+		}
+	}
+
+	pr, err := reader.NewParquetReader(newParquetBuffer(in), elems, 1)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read Parquet file: %w", err)
+	}
+	defer pr.ReadStop()
+
+	sh := pr.SchemaHandler
+	root := sh.GetRootInName()
+
+	rows, err := pr.ReadByNumber(int(pr.GetNumRows()))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read Parquet file: %w", err)
+	}
+
+	out := make([]interface{}, len(rows))
+
+	for i, row := range rows {
+		m, _ := parquetValue(sh, root, reflect.ValueOf(row)).(map[string]interface{})
+		out[i] = m
+	}
+
+	return out, nil
+}
+
+// parquetProjection returns a copy of the (flattened, depth-first) schema
+// elements, with only the given top-level columns and their children
+func parquetProjection(elems []*parquet.SchemaElement, columns []string) ([]*parquet.SchemaElement, error) {
+	keep := map[string]bool{}
+	for _, c := range columns {
+		keep[c] = true
+	}
+
+	root := *elems[0]
+	out := []*parquet.SchemaElement{&root}
+	found := map[string]bool{}
+
+	for i := 1; i < len(elems); {
+		end := min(i+parquetSubtreeSize(elems, i), len(elems))
+
+		if name := elems[i].GetName(); keep[name] {
+			found[name] = true
+
+			for _, e := range elems[i:end] {
+				c := *e
+				out = append(out, &c)
+			}
+		}
+
+		i = end
+	}
+
+	for _, c := range columns {
+		if !found[c] {
+			return nil, fmt.Errorf("no such column %q", c)
+		}
+	}
+
+	n := int32(len(found))
+	root.NumChildren = &n
+
+	return out, nil
+}
+
+// parquetSubtreeSize returns the number of schema elements in the subtree
+// starting at index i (including the element itself)
+func parquetSubtreeSize(elems []*parquet.SchemaElement, i int) int {
+	n := 1
+	for c := int32(0); c < elems[i].GetNumChildren() && i+n < len(elems); c++ {
+		n += parquetSubtreeSize(elems, i+n)
+	}
+
+	return n
+}
+
+// parquetValue converts a value read from the column at the given (internal)
+// path to a plain value, restoring the original names of nested columns
+func parquetValue(sh *schema.SchemaHandler, path string, v reflect.Value) interface{} {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+
+		v = v.Elem()
+	}
+
+	idx, ok := sh.MapIndex[path]
+	if !ok {
+		return v.Interface()
+	}
+
+	elem := sh.SchemaElements[idx]
+
+	switch v.Kind() {
+	case reflect.Struct:
+		out := make(map[string]interface{}, v.NumField())
+
+		for i := 0; i < v.NumField(); i++ {
+			childPath := common.PathToStr([]string{path, v.Type().Field(i).Name})
+			exPath := common.StrToPath(sh.InPathToExPath[childPath])
+			out[exPath[len(exPath)-1]] = parquetValue(sh, childPath, v.Field(i))
+		}
+
+		return out
+	case reflect.Slice:
+		// lists are nested in List and Element groups, while repeated fields
+		// aren't
+		itemPath := path
+		if elem.GetConvertedType() == parquet.ConvertedType_LIST {
+			itemPath = common.PathToStr([]string{path, "List", "Element"})
+		}
+
+		out := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out[i] = parquetValue(sh, itemPath, v.Index(i))
+		}
+
+		return out
+	case reflect.Map:
+		valuePath := common.PathToStr([]string{path, "Key_value", "Value"})
+
+		out := make(map[string]interface{}, v.Len())
+
+		iter := v.MapRange()
+		for iter.Next() {
+			out[fmt.Sprint(iter.Key().Interface())] = parquetValue(sh, valuePath, iter.Value())
+		}
+
+		return out
+	default:
+		return parquetScalar(elem, v.Interface())
+	}
+}
+
+func parquetScalar(elem *parquet.SchemaElement, v interface{}) interface{} {
+	switch n := v.(type) {
+	case int32:
+		if elem.GetConvertedType() == parquet.ConvertedType_DATE {
+			return time.Unix(int64(n)*24*60*60, 0).UTC()
+		}
+
+		return int(n)
+	case int64:
+		switch {
+		case elem.GetConvertedType() == parquet.ConvertedType_TIMESTAMP_MILLIS:
+			return time.UnixMilli(n).UTC()
+		case elem.GetConvertedType() == parquet.ConvertedType_TIMESTAMP_MICROS:
+			return time.UnixMicro(n).UTC()
+		case elem.IsSetLogicalType() && elem.GetLogicalType().IsSetTIMESTAMP() &&
+			elem.GetLogicalType().GetTIMESTAMP().GetUnit().IsSetNANOS():
+			return time.Unix(0, n).UTC()
+		case n < math.MinInt || n > math.MaxInt:
+			return n
+		}
+
+		return int(n)
+	case float32:
+		return float64(n)
+	default:
+		return v
+	}
+}
+
+// parquetBuffer is an in-memory source.ParquetFile, for reading only
+type parquetBuffer struct {
+	*bytes.Reader
+	data string
+}
+
+var _ source.ParquetFile = (*parquetBuffer)(nil)
+
+func newParquetBuffer(data string) *parquetBuffer {
+	return &parquetBuffer{Reader: bytes.NewReader([]byte(data)), data: data}
+}
+
+func (b *parquetBuffer) Open(_ string) (source.ParquetFile, error) {
+	return newParquetBuffer(b.data), nil
+}
+
+func (b *parquetBuffer) Create(_ string) (source.ParquetFile, error) {
+	return nil, fmt.Errorf("parquet buffer is read-only")
+}
+
+func (b *parquetBuffer) Write(_ []byte) (int, error) {
+	return 0, fmt.Errorf("parquet buffer is read-only")
+}
+
+func (b *parquetBuffer) Close() error {
+	return nil
+}
@@ -0,0 +1,135 @@
+package parsers
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+type testParquetRow struct {
+	Name    string            `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Port    int32             `parquet:"name=port, type=INT32"`
+	Weight  *float64          `parquet:"name=weight, type=DOUBLE"`
+	Tags    []string          `parquet:"name=tags, type=LIST, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	Labels  map[string]string `parquet:"name=labels, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	Created int64             `parquet:"name=created_at, type=INT64, convertedtype=TIMESTAMP_MILLIS"`
+}
+
+func testParquetFile(t *testing.T) string {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+
+	pw, err := writer.NewParquetWriterFromWriter(buf, new(testParquetRow), 1)
+	require.NoError(t, err)
+
+	// a small row group size, so that the rows are split across row groups
+	pw.RowGroupSize = 1
+
+	weight := 0.5
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	require.NoError(t, pw.Write(testParquetRow{
+		Name: "web", Port: 8080, Weight: &weight,
+		Tags:    []string{"a", "b"},
+		Labels:  map[string]string{"team": "ops"},
+		Created: created.UnixMilli(),
+	}))
+	require.NoError(t, pw.Write(testParquetRow{
+		Name: "api", Port: 9090,
+		Tags:    []string{},
+		Labels:  map[string]string{},
+		Created: created.UnixMilli(),
+	}))
+	require.NoError(t, pw.WriteStop())
+
+	return buf.String()
+}
+
+func TestParquet(t *testing.T) {
+	in := testParquetFile(t)
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	out, err := Parquet(in, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, []interface{}{
+		map[string]interface{}{
+			"name":       "web",
+			"port":       8080,
+			"weight":     0.5,
+			"tags":       []interface{}{"a", "b"},
+			"labels":     map[string]interface{}{"team": "ops"},
+			"created_at": created,
+		},
+		map[string]interface{}{
+			"name":       "api",
+			"port":       9090,
+			"weight":     nil,
+			"tags":       []interface{}{},
+			"labels":     map[string]interface{}{},
+			"created_at": created,
+		},
+	}, out)
+
+	out, err = Parquet(in, []string{"name", "port"})
+	require.NoError(t, err)
+	assert.EqualValues(t, []interface{}{
+		map[string]interface{}{"name": "web", "port": 8080},
+		map[string]interface{}{"name": "api", "port": 9090},
+	}, out)
+
+	// nested columns are projected with all their children
+	out, err = Parquet(in, []string{"labels", "tags"})
+	require.NoError(t, err)
+	assert.EqualValues(t, []interface{}{
+		map[string]interface{}{"tags": []interface{}{"a", "b"}, "labels": map[string]interface{}{"team": "ops"}},
+		map[string]interface{}{"tags": []interface{}{}, "labels": map[string]interface{}{}},
+	}, out)
+
+	_, err = Parquet(in, []string{"bogus"})
+	require.ErrorContains(t, err, "bogus")
+
+	_, err = Parquet("not a parquet file", nil)
+	require.Error(t, err)
+}
+
+func TestParquetProjection(t *testing.T) {
+	fr := &reader.ParquetReader{PFile: newParquetBuffer(testParquetFile(t))}
+	require.NoError(t, fr.ReadFooter())
+
+	elems := fr.Footer.GetSchema()
+
+	out, err := parquetProjection(elems, []string{"port", "tags"})
+	require.NoError(t, err)
+
+	names := make([]string, len(out))
+	for i, e := range out {
+		names[i] = e.GetName()
+	}
+
+	// the root, port, and the tags list with its repeated group and element
+	assert.Equal(t, []string{elems[0].GetName(), "port", "tags", "list", "element"}, names)
+	assert.Equal(t, int32(2), out[0].GetNumChildren())
+
+	// the original schema isn't modified
+	assert.Equal(t, int32(6), elems[0].GetNumChildren())
+
+	_, err = parquetProjection(elems, []string{"port", "bogus"})
+	require.ErrorContains(t, err, `no such column "bogus"`)
+}
+
+func TestParquetMediaType(t *testing.T) {
+	assert.Equal(t, "application/vnd.apache.parquet", ParquetMediaType("application/vnd.apache.parquet", ""))
+
+	mt := ParquetMediaType("application/vnd.apache.parquet", "name, port")
+	assert.Equal(t, `application/vnd.apache.parquet; columns="name, port"`, mt)
+
+	columns, err := parquetColumns(mt)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name", "port"}, columns)
+}
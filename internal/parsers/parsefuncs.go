@@ -5,13 +5,16 @@ import (
 	"bytes"
 	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"regexp"
 	"strings"
 
 	"cuelang.org/go/cue"
 	"cuelang.org/go/cue/cuecontext"
+	cueerrors "cuelang.org/go/cue/errors"
 	"cuelang.org/go/cue/format"
 	"github.com/Shopify/ejson"
 	ejsonJson "github.com/Shopify/ejson/json"
@@ -149,6 +152,27 @@ func YAMLArray(in string) ([]interface{}, error) {
 	return obj, err
 }
 
+// EmptyYAML - returns true when the input (YAML or JSON) contains no
+// documents, or only null documents. Note that empty objects and arrays (like
+// `{}`) are not considered empty.
+func EmptyYAML(in string) bool {
+	d := yaml.NewDecoder(strings.NewReader(in))
+	for {
+		var v interface{}
+
+		err := d.Decode(&v)
+		if err != nil {
+			// invalid input isn't empty - the error will be reported by the
+			// parser
+			return err == io.EOF
+		}
+
+		if v != nil {
+			return false
+		}
+	}
+}
+
 // stringifyYAMLArrayMapKeys recurses into the input array and changes all
 // non-string map keys to string map keys. Modifies the input array.
 func stringifyYAMLArrayMapKeys(in []interface{}) error {
@@ -224,6 +248,64 @@ func DotEnv(in string) (interface{}, error) {
 	return out, nil
 }
 
+// ToDotEnv - Stringify a flat map as a dotenv file, with one KEY=value line per
+// key, sorted by key. Values are quoted and escaped when necessary, so that
+// they're read back unchanged by [DotEnv] (and most other dotenv parsers).
+func ToDotEnv(in interface{}) (string, error) {
+	var obj map[string]interface{}
+	if err := normalizeJSON(in, &obj, "dotenv"); err != nil {
+		return "", err
+	}
+
+	sb := strings.Builder{}
+
+	for _, k := range sortedKeys(obj) {
+		if !dotEnvKeyRe.MatchString(k) {
+			return "", fmt.Errorf("unable to marshal dotenv: invalid key %q", k)
+		}
+
+		var v string
+
+		switch val := obj[k].(type) {
+		case map[string]interface{}, []interface{}:
+			return "", fmt.Errorf("unable to marshal dotenv: value for key %q must be a scalar, not %T", k, val)
+		case nil:
+		default:
+			v = conv.ToString(val)
+		}
+
+		sb.WriteString(k)
+		sb.WriteByte('=')
+		sb.WriteString(dotEnvQuote(v))
+		sb.WriteByte('\n')
+	}
+
+	return sb.String(), nil
+}
+
+//nolint:gochecknoglobals
+var (
+	dotEnvKeyRe      = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+	dotEnvUnquotedRe = regexp.MustCompile(`^[A-Za-z0-9_./:@%+,-]*$`)
+	dotEnvEscaper    = strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"\n", `\n`,
+		"\r", `\r`,
+		`$`, `\$`,
+	)
+)
+
+// dotEnvQuote double-quotes the value if it contains any characters which
+// would otherwise be interpreted by a dotenv parser
+func dotEnvQuote(v string) string {
+	if dotEnvUnquotedRe.MatchString(v) {
+		return v
+	}
+
+	return `"` + dotEnvEscaper.Replace(v) + `"`
+}
+
 func parseCSV(args ...string) ([][]string, []string, error) {
 	in, delim, hdr := csvParseArgs(args...)
 	c := csv.NewReader(strings.NewReader(in))
@@ -351,15 +433,26 @@ func CSVByColumn(args ...string) (cols map[string][]string, err error) {
 	return cols, nil
 }
 
-// ToCSV -
+// ToCSV - Marshal a two-dimensional array as CSV. The first argument may be a
+// delimiter, or a map of options (see [CSVWriteOptionsFromMap]).
 func ToCSV(args ...interface{}) (string, error) {
-	delim := ","
+	opts := CSVWriteOptions{Delimiter: ','}
 	var in [][]string
 	if len(args) == 2 {
-		var ok bool
-		delim, ok = args[0].(string)
-		if !ok {
-			return "", fmt.Errorf("can't parse ToCSV delimiter (%v) - must be string (is a %T)", args[0], args[0])
+		switch o := args[0].(type) {
+		case string:
+			if o == "" {
+				return "", fmt.Errorf("can't parse ToCSV delimiter - must not be empty")
+			}
+			opts.Delimiter = rune(o[0])
+		case map[string]interface{}:
+			var err error
+			opts, err = CSVWriteOptionsFromMap(o)
+			if err != nil {
+				return "", err
+			}
+		default:
+			return "", fmt.Errorf("can't parse ToCSV delimiter (%v) - must be string or map of options (is a %T)", args[0], args[0])
 		}
 		args = args[1:]
 	}
@@ -370,7 +463,7 @@ func ToCSV(args ...interface{}) (string, error) {
 		case [][]interface{}:
 			in = make([][]string, len(a))
 			for i, v := range a {
-				in[i] = conv.ToStrings(v...)
+				in[i] = csvFields(v)
 			}
 		case []interface{}:
 			in = make([][]string, len(a))
@@ -379,22 +472,25 @@ func ToCSV(args ...interface{}) (string, error) {
 				if !ok {
 					return "", fmt.Errorf("can't parse ToCSV input - must be a two-dimensional array (like [][]string or [][]interface{}) (was %T)", args[0])
 				}
-				in[i] = conv.ToStrings(ar...)
+				in[i] = csvFields(ar)
 			}
 		default:
 			return "", fmt.Errorf("can't parse ToCSV input - must be a two-dimensional array (like [][]string or [][]interface{}) (was %T)", args[0])
 		}
 	}
-	b := &bytes.Buffer{}
-	c := csv.NewWriter(b)
-	c.Comma = rune(delim[0])
-	// We output RFC4180 CSV, so force this to CRLF
-	c.UseCRLF = true
-	err := c.WriteAll(in)
-	if err != nil {
-		return "", err
+	// We output RFC4180 CSV (with CRLF line endings) unless told otherwise
+	return writeCSV(in, opts)
+}
+
+// csvFields converts the values to strings, with nil values as empty strings
+func csvFields(in []interface{}) []string {
+	out := make([]string, len(in))
+	for i, v := range in {
+		if v != nil {
+			out[i] = conv.ToString(v)
+		}
 	}
-	return b.String(), nil
+	return out
 }
 
 func marshalObj(obj interface{}, f func(interface{}) ([]byte, error)) (string, error) {
@@ -406,6 +502,30 @@ func marshalObj(obj interface{}, f func(interface{}) ([]byte, error)) (string, e
 	return string(b), nil
 }
 
+// normalizeJSON converts the input (which may be a struct, or a map with
+// non-string keys) into out by round-tripping it through JSON, so that the
+// encoders for other formats only need to handle plain maps, lists, and
+// json.Number values. The format is used in error messages.
+func normalizeJSON(in, out interface{}, format string) error {
+	b, err := toJSONBytes(in)
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	if err := dec.Decode(out); err != nil {
+		if _, ok := out.(*map[string]interface{}); ok {
+			return fmt.Errorf("unable to marshal %T as %s, only maps are supported: %w", in, format, err)
+		}
+
+		return fmt.Errorf("unable to marshal %T as %s: %w", in, format, err)
+	}
+
+	return nil
+}
+
 func toJSONBytes(in interface{}) ([]byte, error) {
 	h := &codec.JsonHandle{}
 	h.Canonical = true
@@ -476,6 +596,12 @@ func CUE(in string) (interface{}, error) {
 		return nil, fmt.Errorf("unable to process CUE: %w", val.Err())
 	}
 
+	// make sure the value (with defaults applied) is concrete, so that
+	// incomplete fields aren't silently decoded as null
+	if err := val.Validate(cue.Concrete(true)); err != nil {
+		return nil, fmt.Errorf("unable to process CUE: %w", cueError(err))
+	}
+
 	switch val.Kind() {
 	case cue.StructKind:
 		out := map[string]interface{}{}
@@ -526,3 +652,32 @@ func ToCUE(in interface{}) (string, error) {
 
 	return string(bs), nil
 }
+
+// CUEValidate - validate the input against the given CUE schema. The input
+// must unify with the schema, and the result must be concrete.
+func CUEValidate(schema string, in interface{}) error {
+	cuectx := cuecontext.New()
+
+	s := cuectx.CompileString(schema)
+	if s.Err() != nil {
+		return fmt.Errorf("unable to compile CUE schema: %w", cueError(s.Err()))
+	}
+
+	v := cuectx.Encode(in)
+	if v.Err() != nil {
+		return fmt.Errorf("unable to encode value as CUE: %w", v.Err())
+	}
+
+	err := s.Unify(v).Validate(cue.Concrete(true))
+	if err != nil {
+		return fmt.Errorf("CUE validation failed: %w", cueError(err))
+	}
+
+	return nil
+}
+
+// cueError returns an error describing every problem CUE found, rather than
+// just the first
+func cueError(err error) error {
+	return errors.New(strings.TrimSpace(cueerrors.Details(err, nil)))
+}
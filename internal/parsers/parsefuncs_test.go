@@ -1,6 +1,7 @@
 package parsers
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"testing"
@@ -567,6 +568,85 @@ QUX='single quotes ignore $variables'
 	assert.EqualValues(t, expected, out)
 }
 
+func TestToDotEnv(t *testing.T) {
+	in := map[string]interface{}{
+		"PORT":     8080,
+		"ZIP":      "007",
+		"DEBUG":    true,
+		"EMPTY":    "",
+		"NULL":     nil,
+		"URL":      "https://example.com/path?a=b",
+		"PATH_DIR": "/usr/local/bin",
+		"GREETING": "hello world",
+		"QUOTED":   `say "hi" # not a comment`,
+		"MULTI":    "line one\nline two",
+		"DOLLARS":  `$HOME and ${USER} and \backslash`,
+		"app.name": "web",
+	}
+
+	expected := `DEBUG=true
+DOLLARS="\$HOME and \${USER} and \\backslash"
+EMPTY=
+GREETING="hello world"
+MULTI="line one\nline two"
+NULL=
+PATH_DIR=/usr/local/bin
+PORT=8080
+QUOTED="say \"hi\" # not a comment"
+URL="https://example.com/path?a=b"
+ZIP=007
+app.name=web
+`
+
+	out, err := ToDotEnv(in)
+	require.NoError(t, err)
+	assert.Equal(t, expected, out)
+
+	// values must be read back unchanged
+	parsed, err := DotEnv(out)
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{
+		"PORT":     "8080",
+		"ZIP":      "007",
+		"DEBUG":    "true",
+		"EMPTY":    "",
+		"NULL":     "",
+		"URL":      "https://example.com/path?a=b",
+		"PATH_DIR": "/usr/local/bin",
+		"GREETING": "hello world",
+		"QUOTED":   `say "hi" # not a comment`,
+		"MULTI":    "line one\nline two",
+		"DOLLARS":  `$HOME and ${USER} and \backslash`,
+		"app.name": "web",
+	}, parsed)
+
+	out, err = ToDotEnv(map[string]string{"FOO": "bar"})
+	require.NoError(t, err)
+	assert.Equal(t, "FOO=bar\n", out)
+
+	_, err = ToDotEnv(map[string]interface{}{"1FOO": "bar"})
+	require.Error(t, err)
+
+	_, err = ToDotEnv(map[string]interface{}{"FOO BAR": "bar"})
+	require.Error(t, err)
+
+	_, err = ToDotEnv(map[string]interface{}{"FOO": map[string]interface{}{"a": "b"}})
+	require.Error(t, err)
+
+	_, err = ToDotEnv([]interface{}{"a"})
+	require.Error(t, err)
+}
+
+func TestEmptyYAML(t *testing.T) {
+	for _, in := range []string{"", "  \n", "null", "~", "# just a comment", "---\n---\nnull\n"} {
+		assert.True(t, EmptyYAML(in), in)
+	}
+
+	for _, in := range []string{"{}", "[]", `""`, "0", "false", "a: b", "---\nnull\n---\nfoo\n", "{invalid"} {
+		assert.False(t, EmptyYAML(in), in)
+	}
+}
+
 func TestStringifyYAMLArrayMapKeys(t *testing.T) {
 	cases := []struct {
 		input    []interface{}
@@ -717,6 +797,48 @@ list: [ 1, 2, 3 ]
 
 	_, err = CUE(`>=0 & <=7 & >=3 & <=10`)
 	require.Error(t, err)
+
+	// defaults are applied
+	out, err = CUE(`port: int | *8080, #Host: {name: string}, host: #Host & {name: "db"}`)
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]interface{}{
+		"port": 8080,
+		"host": map[string]interface{}{"name": "db"},
+	}, out)
+
+	// incomplete values are not allowed
+	_, err = CUE(`port: int, name: "foo"`)
+	require.ErrorContains(t, err, "port: incomplete value int")
+}
+
+func TestCUEValidate(t *testing.T) {
+	schema := `#Config: {
+	name: string
+	port: int & >0 & <65536
+	tags?: [...string]
+}
+#Config`
+
+	err := CUEValidate(schema, map[string]interface{}{"name": "web", "port": 443})
+	require.NoError(t, err)
+
+	err = CUEValidate(schema, map[string]interface{}{"name": "web", "port": 0})
+	require.ErrorContains(t, err, "port: invalid value 0 (out of bound >0)")
+
+	// every error is reported
+	err = CUEValidate(schema, map[string]interface{}{"port": "443", "extra": true})
+	require.ErrorContains(t, err, "extra: field not allowed")
+	require.ErrorContains(t, err, "port: conflicting values")
+
+	// missing fields are reported as incomplete
+	err = CUEValidate(schema, map[string]interface{}{"port": 443})
+	require.ErrorContains(t, err, "name: incomplete value string")
+
+	err = CUEValidate(`{`, map[string]interface{}{})
+	require.ErrorContains(t, err, "unable to compile CUE schema")
+
+	err = CUEValidate(`[...int]`, []interface{}{1, 2, "three"})
+	require.Error(t, err)
 }
 
 func TestToCUE(t *testing.T) {
@@ -776,3 +898,21 @@ func TestToCUE(t *testing.T) {
 	require.NoError(t, err)
 	assert.EqualValues(t, `{}`, out)
 }
+
+func TestNormalizeJSON(t *testing.T) {
+	var obj map[string]interface{}
+	err := normalizeJSON(map[interface{}]interface{}{"a": 1, "b": []int{3}}, &obj, "test")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"a": json.Number("1"),
+		"b": []interface{}{json.Number("3")},
+	}, obj)
+
+	err = normalizeJSON([]int{1}, &obj, "test")
+	require.EqualError(t, err, "unable to marshal []int as test, only maps are supported: json: cannot unmarshal array into Go value of type map[string]interface {}")
+
+	var v interface{}
+	err = normalizeJSON([]int{1}, &v, "test")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{json.Number("1")}, v)
+}
@@ -6,6 +6,21 @@ import (
 	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
 )
 
+// parseCSVMediaType parses CSV (or TSV) with the options given in the media
+// type's parameters, if any
+func parseCSVMediaType(mimeType, s string) (any, error) {
+	opts, err := CSVOptionsFromMediaType(mimeType)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts == (CSVOptions{Delimiter: ','}) {
+		return CSV(s)
+	}
+
+	return CSVWithOptions(s, opts)
+}
+
 func ParseData(mimeType, s string) (out any, err error) {
 	switch iohelpers.MimeAlias(mimeType) {
 	case iohelpers.JSONMimetype:
@@ -14,24 +29,60 @@ func ParseData(mimeType, s string) (out any, err error) {
 			// maybe it's a JSON array
 			out, err = JSONArray(s)
 		}
-	case iohelpers.JSONArrayMimetype:
+	case iohelpers.JSONArrayMimetype, iohelpers.JSONPatchMimetype:
 		out, err = JSONArray(s)
+	case iohelpers.JSON5Mimetype:
+		out, err = JSON5(s)
+	case iohelpers.NDJSONMimetype:
+		out, err = NDJSON(s)
 	case iohelpers.YAMLMimetype:
 		out, err = YAML(s)
 		if err != nil {
 			// maybe it's a YAML array
 			out, err = YAMLArray(s)
 		}
-	case iohelpers.CSVMimetype:
-		out, err = CSV(s)
+	case iohelpers.CSVMimetype, iohelpers.TSVMimetype:
+		out, err = parseCSVMediaType(mimeType, s)
 	case iohelpers.TOMLMimetype:
 		out, err = TOML(s)
 	case iohelpers.EnvMimetype:
 		out, err = DotEnv(s)
 	case iohelpers.TextMimetype:
 		out = s
+	case iohelpers.MarkdownMimetype:
+		out, err = FrontMatter(s)
 	case iohelpers.CUEMimetype:
 		out, err = CUE(s)
+	case iohelpers.HCLMimetype:
+		out, err = HCL(s)
+	case iohelpers.INIMimetype:
+		out, err = INI(s)
+	case iohelpers.BSONMimetype:
+		out, err = BSON(s)
+	case iohelpers.MsgPackMimetype:
+		out, err = MsgPack(s)
+	case iohelpers.AvroMimetype:
+		out, err = Avro(s)
+	case iohelpers.ParquetMimetype:
+		var columns []string
+
+		columns, err = parquetColumns(mimeType)
+		if err != nil {
+			return nil, err
+		}
+
+		out, err = Parquet(s, columns)
+	case iohelpers.XMLMimetype:
+		out, err = XML(s, XMLOptions{})
+	case iohelpers.JsonnetMimetype:
+		var extVars map[string]string
+
+		extVars, err = jsonnetExtVars(mimeType)
+		if err != nil {
+			return nil, err
+		}
+
+		out, err = Jsonnet(s, extVars)
 	default:
 		return nil, fmt.Errorf("data of type %q not yet supported", mimeType)
 	}
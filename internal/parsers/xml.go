@@ -0,0 +1,403 @@
+package parsers
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hairyhenderson/gomplate/v4/conv"
+)
+
+// XMLOptions control how XML documents are mapped to and from objects
+type XMLOptions struct {
+	// AttrPrefix is prepended to attribute names, to distinguish them from
+	// child elements. Defaults to "@".
+	AttrPrefix string
+	// TextKey is the key for an element's text, when the element also has
+	// attributes or child elements. Defaults to "#text".
+	TextKey string
+	// ForceList names elements which are always presented as lists, even when
+	// they're not repeated (parsing only)
+	ForceList []string
+	// Root is the name of the root element to wrap the input in, rather than
+	// using the input's only key (serialization only)
+	Root string
+	// Namespaces are declared on the root element, keyed by prefix. The
+	// default namespace has an empty prefix (serialization only).
+	Namespaces map[string]string
+	// Indent is used to indent nested elements. The output is not indented by
+	// default (serialization only).
+	Indent string
+	// OmitHeader omits the XML declaration (serialization only)
+	OmitHeader bool
+}
+
+// XMLOptionsFromMap reads XMLOptions from a map, as provided to template
+// functions. The keys are the (lower camel-cased) field names.
+func XMLOptionsFromMap(m map[string]interface{}) (XMLOptions, error) {
+	opts := XMLOptions{}
+
+	for k, v := range m {
+		switch k {
+		case "attrPrefix":
+			opts.AttrPrefix = conv.ToString(v)
+		case "textKey":
+			opts.TextKey = conv.ToString(v)
+		case "forceList":
+			l, ok := v.([]interface{})
+			if !ok {
+				return opts, fmt.Errorf("forceList must be a list, not %T", v)
+			}
+
+			for _, item := range l {
+				opts.ForceList = append(opts.ForceList, conv.ToString(item))
+			}
+		case "root":
+			opts.Root = conv.ToString(v)
+		case "namespaces":
+			ns, ok := v.(map[string]interface{})
+			if !ok {
+				return opts, fmt.Errorf("namespaces must be a map, not %T", v)
+			}
+
+			opts.Namespaces = make(map[string]string, len(ns))
+			for p, uri := range ns {
+				opts.Namespaces[p] = conv.ToString(uri)
+			}
+		case "indent":
+			opts.Indent = conv.ToString(v)
+		case "header":
+			opts.OmitHeader = !conv.ToBool(v)
+		default:
+			return opts, fmt.Errorf("unknown XML option %q", k)
+		}
+	}
+
+	return opts, nil
+}
+
+func (o XMLOptions) attrPrefix() string {
+	if o.AttrPrefix == "" {
+		return "@"
+	}
+
+	return o.AttrPrefix
+}
+
+func (o XMLOptions) textKey() string {
+	if o.TextKey == "" {
+		return "#text"
+	}
+
+	return o.TextKey
+}
+
+type xmlElement struct {
+	name     string
+	attrs    []xml.Attr
+	children []*xmlElement
+	text     strings.Builder
+}
+
+// XML - Unmarshal an XML document into a map, keyed by the root element's name.
+//
+// Attributes are presented with the AttrPrefix ("@" by default), and elements
+// with neither attributes nor child elements are presented as strings.
+// Otherwise, an element's text is presented with the TextKey ("#text" by
+// default). Repeated child elements are presented as lists, in document order.
+//
+// An element's text is all of its own character data, excluding that of its
+// children, with leading and trailing whitespace removed. Namespace prefixes
+// are kept as written in the document (as in "soap:Body"), and namespace
+// declarations are presented as attributes. Comments and processing
+// instructions are ignored.
+func XML(in string, opts XMLOptions) (map[string]interface{}, error) {
+	root, err := parseXMLTree(in)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse XML: %w", err)
+	}
+
+	forceList := make(map[string]bool, len(opts.ForceList))
+	for _, name := range opts.ForceList {
+		forceList[name] = true
+	}
+
+	v, err := xmlElementValue(root, opts, forceList)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse XML: %w", err)
+	}
+
+	return map[string]interface{}{root.name: v}, nil
+}
+
+func parseXMLTree(in string) (*xmlElement, error) {
+	dec := xml.NewDecoder(strings.NewReader(in))
+
+	var root *xmlElement
+
+	stack := []*xmlElement{}
+
+	for {
+		// RawToken is used so that namespace prefixes are preserved
+		tok, err := dec.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			e := &xmlElement{name: xmlName(t.Name), attrs: t.Copy().Attr}
+
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, e)
+			} else if root != nil {
+				return nil, fmt.Errorf("multiple root elements (%s and %s)", root.name, e.name)
+			} else {
+				root = e
+			}
+
+			stack = append(stack, e)
+		case xml.EndElement:
+			if len(stack) == 0 || stack[len(stack)-1].name != xmlName(t.Name) {
+				return nil, fmt.Errorf("unexpected end element </%s>", xmlName(t.Name))
+			}
+
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(t)
+			} else if len(bytes.TrimSpace(t)) > 0 {
+				return nil, fmt.Errorf("unexpected text outside of root element")
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, fmt.Errorf("no root element")
+	}
+
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("unclosed element <%s>", stack[len(stack)-1].name)
+	}
+
+	return root, nil
+}
+
+func xmlName(n xml.Name) string {
+	if n.Space == "" {
+		return n.Local
+	}
+
+	return n.Space + ":" + n.Local
+}
+
+func xmlElementValue(e *xmlElement, opts XMLOptions, forceList map[string]bool) (interface{}, error) {
+	text := strings.TrimSpace(e.text.String())
+
+	if len(e.attrs) == 0 && len(e.children) == 0 {
+		return text, nil
+	}
+
+	out := make(map[string]interface{}, len(e.attrs)+len(e.children)+1)
+
+	for _, a := range e.attrs {
+		out[opts.attrPrefix()+xmlName(a.Name)] = a.Value
+	}
+
+	for _, c := range e.children {
+		v, err := xmlElementValue(c, opts, forceList)
+		if err != nil {
+			return nil, err
+		}
+
+		existing, exists := out[c.name]
+
+		switch {
+		case !exists && forceList[c.name]:
+			out[c.name] = []interface{}{v}
+		case !exists:
+			out[c.name] = v
+		default:
+			list, ok := existing.([]interface{})
+			if !ok {
+				list = []interface{}{existing}
+			}
+
+			out[c.name] = append(list, v)
+		}
+	}
+
+	if text != "" {
+		if _, exists := out[opts.textKey()]; exists {
+			return nil, fmt.Errorf("text of element <%s> conflicts with %q", e.name, opts.textKey())
+		}
+
+		out[opts.textKey()] = text
+	}
+
+	return out, nil
+}
+
+//nolint:gochecknoglobals
+var xmlNameRe = regexp.MustCompile(`^[A-Za-z_][\w.\-]*(:[A-Za-z_][\w.\-]*)?$`)
+
+// ToXML - Stringify an object as an XML document. This is the inverse of
+// [XML]: keys with the AttrPrefix are written as attributes, the TextKey is
+// written as text, lists are written as repeated elements, and other keys are
+// written as child elements. Attributes and child elements are written in key
+// order.
+//
+// The input must be a map with a single key naming the root element, unless
+// the Root option is set.
+func ToXML(in interface{}, opts XMLOptions) (string, error) {
+	var obj interface{}
+	if err := normalizeJSON(in, &obj, "XML"); err != nil {
+		return "", err
+	}
+
+	rootName := opts.Root
+	if rootName == "" {
+		m, ok := obj.(map[string]interface{})
+		if !ok || len(m) != 1 {
+			return "", fmt.Errorf("unable to marshal XML: input must be a map with a single key (the root element), or the root option must be set")
+		}
+
+		for k, v := range m {
+			rootName, obj = k, v
+		}
+	}
+
+	if _, ok := obj.([]interface{}); ok {
+		return "", fmt.Errorf("unable to marshal XML: root element <%s> must not be a list", rootName)
+	}
+
+	buf := &bytes.Buffer{}
+	if !opts.OmitHeader {
+		buf.WriteString(xml.Header)
+	}
+
+	enc := xml.NewEncoder(buf)
+	enc.Indent("", opts.Indent)
+
+	prefixes := make([]string, 0, len(opts.Namespaces))
+	for p := range opts.Namespaces {
+		prefixes = append(prefixes, p)
+	}
+
+	sort.Strings(prefixes)
+
+	nsAttrs := make([]xml.Attr, 0, len(prefixes))
+
+	for _, p := range prefixes {
+		name := "xmlns"
+		if p != "" {
+			name += ":" + p
+		}
+
+		nsAttrs = append(nsAttrs, xml.Attr{Name: xml.Name{Local: name}, Value: opts.Namespaces[p]})
+	}
+
+	err := writeXMLElement(enc, opts, rootName, obj, nsAttrs)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal XML: %w", err)
+	}
+
+	err = enc.Flush()
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal XML: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+func writeXMLElement(enc *xml.Encoder, opts XMLOptions, name string, v interface{}, attrs []xml.Attr) error {
+	if !xmlNameRe.MatchString(name) {
+		return fmt.Errorf("invalid element name %q", name)
+	}
+
+	start := xml.StartElement{Name: xml.Name{Local: name}, Attr: attrs}
+
+	var text string
+
+	var children []string
+
+	switch v := v.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if _, ok := item.([]interface{}); ok {
+				return fmt.Errorf("element <%s> contains a nested list", name)
+			}
+
+			err := writeXMLElement(enc, opts, name, item, attrs)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case map[string]interface{}:
+		for _, k := range sortedKeys(v) {
+			switch {
+			case k == opts.textKey():
+				text = xmlScalar(v[k])
+			case strings.HasPrefix(k, opts.attrPrefix()):
+				attrName := strings.TrimPrefix(k, opts.attrPrefix())
+				if !xmlNameRe.MatchString(attrName) {
+					return fmt.Errorf("invalid attribute name %q", attrName)
+				}
+
+				switch v[k].(type) {
+				case map[string]interface{}, []interface{}:
+					return fmt.Errorf("attribute %q of element <%s> must be a scalar", attrName, name)
+				}
+
+				start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: attrName}, Value: xmlScalar(v[k])})
+			default:
+				children = append(children, k)
+			}
+		}
+	default:
+		text = xmlScalar(v)
+	}
+
+	err := enc.EncodeToken(start)
+	if err != nil {
+		return err
+	}
+
+	if text != "" {
+		err = enc.EncodeToken(xml.CharData(text))
+		if err != nil {
+			return err
+		}
+	}
+
+	if m, ok := v.(map[string]interface{}); ok {
+		for _, k := range children {
+			err = writeXMLElement(enc, opts, k, m[k], nil)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return enc.EncodeToken(start.End())
+}
+
+func xmlScalar(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+
+	return conv.ToString(v)
+}
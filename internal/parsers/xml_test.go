@@ -0,0 +1,204 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXML(t *testing.T) {
+	in := `<?xml version="1.0" encoding="UTF-8"?>
+<!-- a comment -->
+<catalog version="2">
+  <book id="bk101">
+    <author>Gambardella, Matthew</author>
+    <title lang="en">XML Developer's Guide</title>
+    <price>44.95</price>
+  </book>
+  <book id="bk102">
+    <author>Ralls, Kim</author>
+    <title><![CDATA[Midnight <Rain>]]></title>
+    <price/>
+  </book>
+  <note>Hello <b>world</b>!</note>
+</catalog>`
+
+	expected := map[string]interface{}{
+		"catalog": map[string]interface{}{
+			"@version": "2",
+			"book": []interface{}{
+				map[string]interface{}{
+					"@id":    "bk101",
+					"author": "Gambardella, Matthew",
+					"title": map[string]interface{}{
+						"@lang": "en",
+						"#text": "XML Developer's Guide",
+					},
+					"price": "44.95",
+				},
+				map[string]interface{}{
+					"@id":    "bk102",
+					"author": "Ralls, Kim",
+					"title":  "Midnight <Rain>",
+					"price":  "",
+				},
+			},
+			"note": map[string]interface{}{
+				"#text": "Hello !",
+				"b":     "world",
+			},
+		},
+	}
+
+	out, err := XML(in, XMLOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, expected, out)
+
+	t.Run("options", func(t *testing.T) {
+		out, err := XML(`<a x="1"><b>one</b>text</a>`, XMLOptions{
+			AttrPrefix: "-",
+			TextKey:    "_",
+			ForceList:  []string{"b"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"a": map[string]interface{}{
+				"-x": "1",
+				"_":  "text",
+				"b":  []interface{}{"one"},
+			},
+		}, out)
+	})
+
+	t.Run("namespaces", func(t *testing.T) {
+		out, err := XML(`<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope" xmlns="urn:example">
+  <soap:Body><m:price xmlns:m="urn:m">42</m:price></soap:Body>
+</soap:Envelope>`, XMLOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"soap:Envelope": map[string]interface{}{
+				"@xmlns:soap": "http://www.w3.org/2003/05/soap-envelope",
+				"@xmlns":      "urn:example",
+				"soap:Body": map[string]interface{}{
+					"m:price": map[string]interface{}{
+						"@xmlns:m": "urn:m",
+						"#text":    "42",
+					},
+				},
+			},
+		}, out)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, in := range []string{
+			"",
+			"not xml",
+			"<a><b></a>",
+			"<a></a><b></b>",
+			"<a>",
+		} {
+			_, err := XML(in, XMLOptions{})
+			assert.Error(t, err, in)
+		}
+	})
+}
+
+func TestToXML(t *testing.T) {
+	in := map[string]interface{}{
+		"catalog": map[string]interface{}{
+			"@version": 2,
+			"book": []interface{}{
+				map[string]interface{}{
+					"@id":   "bk101",
+					"title": map[string]interface{}{"@lang": "en", "#text": "XML & You"},
+					"price": 44.95,
+				},
+				map[string]interface{}{
+					"@id":   "bk102",
+					"title": "Midnight <Rain>",
+					"price": nil,
+				},
+			},
+		},
+	}
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<catalog version="2">
+  <book id="bk101">
+    <price>44.95</price>
+    <title lang="en">XML &amp; You</title>
+  </book>
+  <book id="bk102">
+    <price></price>
+    <title>Midnight &lt;Rain&gt;</title>
+  </book>
+</catalog>`
+
+	out, err := ToXML(in, XMLOptions{Indent: "  "})
+	require.NoError(t, err)
+	assert.Equal(t, expected, out)
+
+	// round-trip
+	parsed, err := XML(out, XMLOptions{})
+	require.NoError(t, err)
+
+	out2, err := ToXML(parsed, XMLOptions{Indent: "  "})
+	require.NoError(t, err)
+	assert.Equal(t, out, out2)
+
+	t.Run("root and namespaces", func(t *testing.T) {
+		out, err := ToXML(map[string]interface{}{
+			"soap:Body": map[string]interface{}{"m:price": 42},
+		}, XMLOptions{
+			Root:       "soap:Envelope",
+			Namespaces: map[string]string{"soap": "http://www.w3.org/2003/05/soap-envelope", "": "urn:example"},
+			OmitHeader: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `<soap:Envelope xmlns="urn:example" xmlns:soap="http://www.w3.org/2003/05/soap-envelope"><soap:Body><m:price>42</m:price></soap:Body></soap:Envelope>`, out)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, in := range []interface{}{
+			"string",
+			map[string]interface{}{"a": 1, "b": 2},
+			map[string]interface{}{"a": []interface{}{1, 2}},
+			map[string]interface{}{"1a": "x"},
+			map[string]interface{}{"a": map[string]interface{}{"@x y": "z"}},
+			map[string]interface{}{"a": map[string]interface{}{"@x": []interface{}{1}}},
+			map[string]interface{}{"a": map[string]interface{}{"b": []interface{}{[]interface{}{1}}}},
+		} {
+			_, err := ToXML(in, XMLOptions{})
+			assert.Error(t, err, in)
+		}
+	})
+}
+
+func TestXMLOptionsFromMap(t *testing.T) {
+	opts, err := XMLOptionsFromMap(map[string]interface{}{
+		"attrPrefix": "-",
+		"textKey":    "_",
+		"forceList":  []interface{}{"item"},
+		"root":       "doc",
+		"namespaces": map[string]interface{}{"x": "urn:x"},
+		"indent":     "\t",
+		"header":     false,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, XMLOptions{
+		AttrPrefix: "-",
+		TextKey:    "_",
+		ForceList:  []string{"item"},
+		Root:       "doc",
+		Namespaces: map[string]string{"x": "urn:x"},
+		Indent:     "\t",
+		OmitHeader: true,
+	}, opts)
+
+	_, err = XMLOptionsFromMap(map[string]interface{}{"bogus": true})
+	require.Error(t, err)
+
+	_, err = XMLOptionsFromMap(map[string]interface{}{"forceList": "item"})
+	require.Error(t, err)
+}
@@ -0,0 +1,76 @@
+package parsers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/antchfx/xpath"
+)
+
+// XPath - Evaluate an XPath (1.0) expression against an XML document.
+// Namespace prefixes used in the expression can be mapped to namespace URIs
+// with namespaces.
+//
+// Expressions which select nodes result in a list, with one item per node, in
+// document order. Elements are presented the same way as by XML (without the
+// wrapping map), so elements with neither attributes nor child elements are
+// strings. Attributes, text, and comments are presented as strings, and the
+// document root as the whole document's map. Other expressions result in a
+// string, number (float64), or boolean.
+func XPath(in, expr string, namespaces map[string]string) (interface{}, error) {
+	doc, err := xmlquery.Parse(strings.NewReader(in))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse XML: %w", err)
+	}
+
+	e, err := xpath.CompileWithNS(expr, namespaces)
+	if err != nil {
+		return nil, fmt.Errorf("invalid XPath expression %q: %w", expr, err)
+	}
+
+	result := e.Evaluate(xmlquery.CreateXPathNavigator(doc))
+
+	iter, ok := result.(*xpath.NodeIterator)
+	if !ok {
+		return result, nil
+	}
+
+	out := []interface{}{}
+
+	for iter.MoveNext() {
+		v, err := xpathNodeValue(iter.Current())
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, v)
+	}
+
+	return out, nil
+}
+
+func xpathNodeValue(nav xpath.NodeNavigator) (interface{}, error) {
+	//nolint:exhaustive
+	switch nav.NodeType() {
+	case xpath.RootNode:
+		n := nav.(*xmlquery.NodeNavigator).Current()
+
+		return XML(n.OutputXML(false), XMLOptions{})
+	case xpath.ElementNode:
+		n := nav.(*xmlquery.NodeNavigator).Current()
+
+		m, err := XML(n.OutputXML(true), XMLOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range m {
+			return v, nil
+		}
+
+		return nil, nil
+	default:
+		return nav.Value(), nil
+	}
+}
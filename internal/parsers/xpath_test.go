@@ -0,0 +1,91 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXPath(t *testing.T) {
+	in := `<?xml version="1.0"?>
+<catalog>
+  <!-- the books -->
+  <book id="bk101" lang="en">
+    <author>Gambardella, Matthew</author>
+    <title>XML Developer's Guide</title>
+    <price>44.95</price>
+  </book>
+  <book id="bk102">
+    <author>Ralls, Kim</author>
+    <title>Midnight Rain</title>
+    <price>5.95</price>
+  </book>
+</catalog>`
+
+	testdata := []struct {
+		expected interface{}
+		expr     string
+	}{
+		{[]interface{}{"XML Developer's Guide", "Midnight Rain"}, "//book/title"},
+		{[]interface{}{"bk101", "bk102"}, "//book/@id"},
+		{[]interface{}{"Midnight Rain"}, "//book[price < 10]/title/text()"},
+		{[]interface{}{" the books "}, "//comment()"},
+		{[]interface{}{}, "//magazine"},
+		{
+			[]interface{}{map[string]interface{}{
+				"@id":    "bk102",
+				"author": "Ralls, Kim",
+				"title":  "Midnight Rain",
+				"price":  "5.95",
+			}},
+			"/catalog/book[2]",
+		},
+		{2.0, "count(//book)"},
+		{50.9, "sum(//price)"},
+		{"Ralls, Kim", "string(//book[@id='bk102']/author)"},
+		{true, "boolean(//book[@lang='en'])"},
+	}
+
+	for _, d := range testdata {
+		out, err := XPath(in, d.expr, nil)
+		require.NoError(t, err, d.expr)
+
+		if f, ok := d.expected.(float64); ok {
+			assert.InEpsilon(t, f, out, 1e-9, d.expr)
+			continue
+		}
+
+		assert.Equal(t, d.expected, out, d.expr)
+	}
+
+	out, err := XPath(in, "/", nil)
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+	assert.Contains(t, out.([]interface{})[0], "catalog")
+
+	_, err = XPath(in, "//book[", nil)
+	require.ErrorContains(t, err, "invalid XPath expression")
+
+	_, err = XPath("<catalog>", "//book", nil)
+	require.ErrorContains(t, err, "unable to parse XML")
+}
+
+func TestXPath_Namespaces(t *testing.T) {
+	in := `<feed xmlns="http://www.w3.org/2005/Atom" xmlns:g="http://base.google.com/ns/1.0">
+  <entry><title>One</title><g:price>10</g:price></entry>
+  <entry><title>Two</title><g:price>20</g:price></entry>
+</feed>`
+
+	out, err := XPath(in, "//a:entry/g:price", map[string]string{
+		"a": "http://www.w3.org/2005/Atom",
+		"g": "http://base.google.com/ns/1.0",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"10", "20"}, out)
+
+	// prefixes as written in the document can be used too
+	out, err = XPath(in, "//g:price", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"10", "20"}, out)
+}
@@ -0,0 +1,304 @@
+package parsers
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	"github.com/hairyhenderson/yaml"
+)
+
+// toYAMLRoundTrip marshals the value as YAML, reusing the nodes of the source
+// document wherever the value is unchanged from it, so that comments, anchors
+// and aliases, key order, and styles survive a round trip through a template.
+//
+// Mappings keep their keys in the source's order (with removed keys dropped
+// and new keys appended), and sequence items are matched by index. Formatting
+// options are only applied to new nodes.
+func toYAMLRoundTrip(in interface{}, opts YAMLOptions) (string, error) {
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal([]byte(opts.Source), doc); err != nil {
+		return "", fmt.Errorf("unable to parse source YAML: %w", err)
+	}
+
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		opts.Source = ""
+		return ToYAMLWithOptions(in, opts)
+	}
+
+	n := &yaml.Node{}
+	if err := n.Encode(in); err != nil {
+		return "", fmt.Errorf("unable to marshal object %s: %w", in, err)
+	}
+
+	r := &yamlReconciler{opts: opts, anchors: map[string]*yaml.Node{}}
+	doc.Content[0] = r.reconcile(doc.Content[0], n)
+
+	indent := opts.Indent
+	if indent == 0 {
+		indent = 2
+	}
+
+	buf := &bytes.Buffer{}
+	e := yaml.NewEncoder(buf)
+	e.SetIndent(indent)
+
+	if err := e.Encode(doc); err != nil {
+		return "", fmt.Errorf("unable to marshal object %s: %w", in, err)
+	}
+
+	if err := e.Close(); err != nil {
+		return "", fmt.Errorf("unable to marshal object %s: %w", in, err)
+	}
+
+	return buf.String(), nil
+}
+
+// yamlReconciler merges newly-encoded nodes into a source document's nodes
+type yamlReconciler struct {
+	// anchors maps anchor names to the reconciled nodes which define them, so
+	// aliases can be kept only when they still resolve to the right value
+	anchors map[string]*yaml.Node
+	opts    YAMLOptions
+}
+
+// reconcile returns the node to write for the new node n, given the source
+// node at the same position
+func (r *yamlReconciler) reconcile(orig, n *yaml.Node) *yaml.Node {
+	var out *yaml.Node
+
+	switch {
+	case orig.Kind == yaml.AliasNode:
+		out = r.alias(orig, n)
+	case orig.Kind == yaml.MappingNode && n.Kind == yaml.MappingNode:
+		out = r.mapping(orig, n)
+	case orig.Kind == yaml.SequenceNode && n.Kind == yaml.SequenceNode:
+		out = r.sequence(orig, n)
+	case orig.Kind == yaml.ScalarNode && n.Kind == yaml.ScalarNode && yamlNodesEqual(orig, n):
+		out = orig
+	default:
+		out = r.replace(orig, n)
+	}
+
+	if orig.Anchor != "" && out.Kind != yaml.AliasNode {
+		out.Anchor = orig.Anchor
+		r.anchors[orig.Anchor] = out
+	}
+
+	return out
+}
+
+// alias keeps an alias if its anchor is still present, and still has the
+// new value
+func (r *yamlReconciler) alias(orig, n *yaml.Node) *yaml.Node {
+	target, ok := r.anchors[orig.Value]
+	if !ok || !yamlNodesEqual(target, n) {
+		return r.replace(orig, n)
+	}
+
+	out := *orig
+	out.Alias = target
+
+	return &out
+}
+
+func (r *yamlReconciler) sequence(orig, n *yaml.Node) *yaml.Node {
+	out := *orig
+	out.Content = make([]*yaml.Node, len(n.Content))
+
+	for i, item := range n.Content {
+		if i < len(orig.Content) {
+			out.Content[i] = r.reconcile(orig.Content[i], item)
+		} else {
+			out.Content[i] = r.fresh(item)
+		}
+	}
+
+	return &out
+}
+
+func (r *yamlReconciler) mapping(orig, n *yaml.Node) *yaml.Node {
+	values := make(map[string]*yaml.Node, len(n.Content)/2)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		values[n.Content[i].Value] = n.Content[i+1]
+	}
+
+	explicit := map[string]bool{}
+	merges := []*yaml.Node{}
+
+	for i := 0; i+1 < len(orig.Content); i += 2 {
+		if isYAMLMergeKey(orig.Content[i]) {
+			// an explicit !!merge tag would be written out, so it's cleared
+			k := *orig.Content[i]
+			k.Tag = ""
+			merges = append(merges, &k, r.mergeValue(orig.Content[i+1]))
+		} else {
+			explicit[orig.Content[i].Value] = true
+		}
+	}
+
+	inherited, keepMerges := r.inherited(merges, explicit, values)
+
+	out := *orig
+	out.Content = make([]*yaml.Node, 0, len(n.Content)+len(merges))
+
+	for i := 0; i+1 < len(orig.Content); i += 2 {
+		k := orig.Content[i]
+		if isYAMLMergeKey(k) {
+			continue
+		}
+
+		if v, ok := values[k.Value]; ok {
+			k, v = blockLineComment(k, r.reconcile(orig.Content[i+1], v))
+			out.Content = append(out.Content, k, v)
+		}
+	}
+
+	if keepMerges {
+		// merge keys are conventionally first
+		out.Content = append(merges, out.Content...)
+	}
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k, v := n.Content[i], n.Content[i+1]
+		if explicit[k.Value] {
+			continue
+		}
+
+		if iv, ok := inherited[k.Value]; ok && keepMerges {
+			if nv, err := decodeYAMLNode(v); err == nil && reflect.DeepEqual(iv, nv) {
+				continue
+			}
+		}
+
+		out.Content = append(out.Content, k, r.fresh(v))
+	}
+
+	return &out
+}
+
+// mergeValue returns the value of a merge key ("<<"), with its aliases
+// pointing at the reconciled anchors. Unknown anchors result in nil.
+func (r *yamlReconciler) mergeValue(v *yaml.Node) *yaml.Node {
+	switch v.Kind {
+	case yaml.AliasNode:
+		target, ok := r.anchors[v.Value]
+		if !ok {
+			return nil
+		}
+
+		out := *v
+		out.Alias = target
+
+		return &out
+	case yaml.SequenceNode:
+		out := *v
+		out.Content = make([]*yaml.Node, len(v.Content))
+
+		for i, item := range v.Content {
+			out.Content[i] = r.mergeValue(item)
+			if out.Content[i] == nil {
+				return nil
+			}
+		}
+
+		return &out
+	default:
+		return v
+	}
+}
+
+// inherited returns the values a mapping inherits through its merge keys, and
+// whether the merge keys can be kept - they can't when an anchor they refer to
+// is gone, or when an inherited key has been removed
+func (r *yamlReconciler) inherited(merges []*yaml.Node, explicit map[string]bool, values map[string]*yaml.Node) (map[string]interface{}, bool) {
+	if len(merges) == 0 {
+		return nil, false
+	}
+
+	for i := 1; i < len(merges); i += 2 {
+		if merges[i] == nil {
+			return nil, false
+		}
+	}
+
+	inherited := map[string]interface{}{}
+	if err := (&yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: merges}).Decode(&inherited); err != nil {
+		return nil, false
+	}
+
+	for k := range inherited {
+		if _, ok := values[k]; !ok && !explicit[k] {
+			return nil, false
+		}
+	}
+
+	return inherited, true
+}
+
+// replace returns the new node in place of the source node, keeping the source
+// node's comments (and string style, for strings replacing strings)
+func (r *yamlReconciler) replace(orig, n *yaml.Node) *yaml.Node {
+	out := r.fresh(n)
+
+	if orig.Kind == yaml.ScalarNode && out.Kind == yaml.ScalarNode &&
+		orig.ShortTag() == "!!str" && out.ShortTag() == "!!str" {
+		out.Style = orig.Style
+	}
+
+	out.HeadComment = orig.HeadComment
+	out.LineComment = orig.LineComment
+	out.FootComment = orig.FootComment
+
+	return out
+}
+
+// fresh formats a new node according to the options
+func (r *yamlReconciler) fresh(n *yaml.Node) *yaml.Node {
+	if r.opts.SortKeys != nil && *r.opts.SortKeys && n.Kind == yaml.MappingNode {
+		sortYAMLMapping(n)
+	}
+
+	quoteYAMLScalar(n, r.opts.Quote)
+	formatYAMLNode(n, r.opts)
+
+	return n
+}
+
+// blockLineComment moves a line comment from a block collection value (where
+// it would be written after the collection's first item) to its key
+func blockLineComment(k, v *yaml.Node) (*yaml.Node, *yaml.Node) {
+	if v.LineComment == "" || k.LineComment != "" || v.Style&yaml.FlowStyle != 0 ||
+		(v.Kind != yaml.MappingNode && v.Kind != yaml.SequenceNode) {
+		return k, v
+	}
+
+	kc, vc := *k, *v
+	kc.LineComment, vc.LineComment = v.LineComment, ""
+
+	return &kc, &vc
+}
+
+func isYAMLMergeKey(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.Value == "<<" && n.ShortTag() == "!!merge"
+}
+
+// yamlNodesEqual reports whether the nodes represent the same value
+func yamlNodesEqual(a, b *yaml.Node) bool {
+	av, err := decodeYAMLNode(a)
+	if err != nil {
+		return false
+	}
+
+	bv, err := decodeYAMLNode(b)
+	if err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(av, bv)
+}
+
+func decodeYAMLNode(n *yaml.Node) (v interface{}, err error) {
+	err = n.Decode(&v)
+	return v, err
+}
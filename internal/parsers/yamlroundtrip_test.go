@@ -0,0 +1,158 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToYAMLRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	src := `# the config
+name: web # the name
+image: 'nginx:1.25'
+ports:
+  - 80 # http
+  - 443
+defaults: &defaults
+  timeout: 30
+  retries: 3
+primary:
+  <<: *defaults
+  retries: 5
+backup: *defaults
+labels: {app: web}
+`
+
+	roundTrip := func(in interface{}, opts YAMLOptions) string {
+		t.Helper()
+
+		opts.Source = src
+		out, err := ToYAMLWithOptions(in, opts)
+		require.NoError(t, err)
+
+		return out
+	}
+
+	orig, err := YAML(src)
+	require.NoError(t, err)
+
+	// unchanged
+	assert.Equal(t, src, roundTrip(orig, YAMLOptions{}))
+
+	in := map[string]interface{}{
+		"name":  "api",
+		"image": "api:2",
+		"ports": []interface{}{80, 8080, 8443},
+		"defaults": map[string]interface{}{
+			"timeout": 30,
+			"retries": 3,
+		},
+		"primary": map[string]interface{}{
+			"timeout": 30,
+			"retries": 5,
+		},
+		"backup": map[string]interface{}{
+			"timeout": 30,
+			"retries": 3,
+		},
+		"labels": map[string]interface{}{"app": "api"},
+		"extra":  map[string]interface{}{"b": "x", "a": "y"},
+	}
+
+	assert.Equal(t, `# the config
+name: api # the name
+image: 'api:2'
+ports:
+  - 80 # http
+  - 8080
+  - 8443
+defaults: &defaults
+  timeout: 30
+  retries: 3
+primary:
+  <<: *defaults
+  retries: 5
+backup: *defaults
+labels: {app: api}
+extra:
+  a: "y"
+  b: x
+`, roundTrip(in, YAMLOptions{}))
+
+	t.Run("formatting options only apply to new values", func(t *testing.T) {
+		out := roundTrip(map[string]interface{}{
+			"name":  "web",
+			"extra": map[string]interface{}{"a": "b"},
+		}, YAMLOptions{Quote: "double", Indent: 4})
+		assert.Equal(t, "# the config\nname: web # the name\nextra:\n    a: \"b\"\n", out)
+	})
+
+	t.Run("changed anchors", func(t *testing.T) {
+		in := map[string]interface{}{
+			"defaults": map[string]interface{}{"timeout": 60, "retries": 3},
+			"primary":  map[string]interface{}{"timeout": 60, "retries": 5},
+			"backup":   map[string]interface{}{"timeout": 30, "retries": 3},
+		}
+
+		assert.Equal(t, `defaults: &defaults
+  timeout: 60
+  retries: 3
+primary:
+  <<: *defaults
+  retries: 5
+backup:
+  retries: 3
+  timeout: 30
+`, roundTrip(in, YAMLOptions{}))
+	})
+
+	t.Run("removed inherited keys", func(t *testing.T) {
+		in := map[string]interface{}{
+			"defaults": map[string]interface{}{"timeout": 30, "retries": 3},
+			"primary":  map[string]interface{}{"retries": 5},
+		}
+
+		assert.Equal(t, `defaults: &defaults
+  timeout: 30
+  retries: 3
+primary:
+  retries: 5
+`, roundTrip(in, YAMLOptions{}))
+	})
+
+	t.Run("removed anchors", func(t *testing.T) {
+		in := map[string]interface{}{
+			"primary": map[string]interface{}{"timeout": 30, "retries": 5},
+			"backup":  map[string]interface{}{"timeout": 30, "retries": 3},
+		}
+
+		assert.Equal(t, `primary:
+  retries: 5
+  timeout: 30
+backup:
+  retries: 3
+  timeout: 30
+`, roundTrip(in, YAMLOptions{}))
+	})
+
+	t.Run("changed types", func(t *testing.T) {
+		in := map[string]interface{}{
+			"name":  []interface{}{"a", "b"},
+			"ports": "none",
+		}
+
+		assert.Equal(t, "# the config\nname: # the name\n  - a\n  - b\nports: none\n", roundTrip(in, YAMLOptions{}))
+	})
+
+	t.Run("empty and invalid sources", func(t *testing.T) {
+		out, err := ToYAMLWithOptions(map[string]interface{}{"a": 1}, YAMLOptions{Source: "# nothing\n"})
+		require.NoError(t, err)
+		assert.Equal(t, "a: 1\n", out)
+
+		_, err = ToYAMLWithOptions(map[string]interface{}{"a": 1}, YAMLOptions{Source: "a: [b"})
+		require.ErrorContains(t, err, "unable to parse source YAML")
+	})
+}
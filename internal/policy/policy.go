@@ -0,0 +1,166 @@
+// Package policy evaluates Open Policy Agent (OPA) Rego policies.
+package policy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/open-policy-agent/opa/v1/rego"
+)
+
+// DenyQuery is the query evaluated by Deny. Policies that should be enforced
+// on the template context must be in the "gomplate" package, and define a
+// "deny" rule.
+const DenyQuery = "data.gomplate.deny"
+
+// Evaluator evaluates Rego queries, caching prepared queries so that
+// repeated evaluations (e.g. in a loop) don't need to recompile the policy.
+type Evaluator struct {
+	cache map[string]rego.PreparedEvalQuery
+	mu    sync.Mutex
+}
+
+// NewEvaluator creates a new Evaluator
+func NewEvaluator() *Evaluator {
+	return &Evaluator{cache: map[string]rego.PreparedEvalQuery{}}
+}
+
+// Eval evaluates the query against the given input, with the given policy
+// modules (a map of file names to Rego source). The value of the query's
+// first expression is returned when there's exactly one result, and a slice
+// of values when there are more. When the query is undefined, nil is
+// returned.
+func (e *Evaluator) Eval(ctx context.Context, query string, modules map[string]string, input interface{}) (interface{}, error) {
+	pq, err := e.prepare(ctx, query, modules)
+	if err != nil {
+		return nil, err
+	}
+
+	rs, err := pq.Eval(ctx, rego.EvalInput(input))
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate %q: %w", query, err)
+	}
+
+	switch len(rs) {
+	case 0:
+		return nil, nil
+	case 1:
+		return firstValue(rs[0]), nil
+	default:
+		out := make([]interface{}, len(rs))
+		for i, r := range rs {
+			out[i] = firstValue(r)
+		}
+
+		return out, nil
+	}
+}
+
+func firstValue(r rego.Result) interface{} {
+	if len(r.Expressions) == 0 {
+		return nil
+	}
+
+	return normalize(r.Expressions[0].Value)
+}
+
+// normalize converts the json.Number values in OPA results to int64 or
+// float64, so they can be used directly in templates
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+
+		f, _ := v.Float64()
+		return f
+	case []interface{}:
+		for i, e := range v {
+			v[i] = normalize(e)
+		}
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = normalize(e)
+		}
+	}
+
+	return v
+}
+
+// Deny evaluates DenyQuery against the input, returning the (sorted) messages
+// produced by the policies' deny rules. No messages means the input is
+// allowed. An error is returned when no "deny" rule is defined in the
+// "gomplate" package, so that a misnamed package or rule can't silently allow
+// everything.
+func (e *Evaluator) Deny(ctx context.Context, modules map[string]string, input interface{}) ([]string, error) {
+	v, err := e.Eval(ctx, DenyQuery, modules, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if v == nil {
+		return nil, fmt.Errorf("%s is undefined: policies must define a deny rule in the gomplate package", DenyQuery)
+	}
+
+	vals, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be a set of messages, not %T", DenyQuery, v)
+	}
+
+	msgs := make([]string, len(vals))
+	for i, m := range vals {
+		if s, ok := m.(string); ok {
+			msgs[i] = s
+		} else {
+			msgs[i] = fmt.Sprintf("%v", m)
+		}
+	}
+	sort.Strings(msgs)
+
+	return msgs, nil
+}
+
+func (e *Evaluator) prepare(ctx context.Context, query string, modules map[string]string) (rego.PreparedEvalQuery, error) {
+	names := make([]string, 0, len(modules))
+	for name := range modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	h := sha256.New()
+	h.Write([]byte(query))
+	for _, name := range names {
+		h.Write([]byte{0})
+		h.Write([]byte(name))
+		h.Write([]byte{0})
+		h.Write([]byte(modules[name]))
+	}
+	key := hex.EncodeToString(h.Sum(nil))
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if pq, ok := e.cache[key]; ok {
+		return pq, nil
+	}
+
+	opts := []func(*rego.Rego){rego.Query(query)}
+	for _, name := range names {
+		opts = append(opts, rego.Module(name, modules[name]))
+	}
+
+	pq, err := rego.New(opts...).PrepareForEval(ctx)
+	if err != nil {
+		return pq, fmt.Errorf("failed to prepare policy query %q: %w", query, err)
+	}
+
+	e.cache[key] = pq
+
+	return pq, nil
+}
@@ -0,0 +1,113 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPolicy = `package k8s
+
+default allow := false
+
+allow if input.kind == "Deployment"
+
+replicas := input.spec.replicas
+
+names contains n if some n in input.names
+`
+
+func TestEval(t *testing.T) {
+	ctx := context.Background()
+	e := NewEvaluator()
+	modules := map[string]string{"k8s.rego": testPolicy}
+
+	out, err := e.Eval(ctx, "data.k8s.allow", modules, map[string]interface{}{"kind": "Deployment"})
+	require.NoError(t, err)
+	assert.Equal(t, true, out)
+
+	out, err = e.Eval(ctx, "data.k8s.allow", modules, map[string]interface{}{"kind": "Pod"})
+	require.NoError(t, err)
+	assert.Equal(t, false, out)
+
+	// undefined
+	out, err = e.Eval(ctx, "data.k8s.replicas", modules, map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Nil(t, out)
+
+	out, err = e.Eval(ctx, "data.k8s.names", modules, map[string]interface{}{
+		"names": []interface{}{"b", "a", "b"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"a", "b"}, out)
+
+	// multiple results
+	out, err = e.Eval(ctx, "data.k8s.names[x]", modules, map[string]interface{}{
+		"names": []interface{}{"b", "a"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"a", "b"}, out)
+
+	// no modules
+	out, err = e.Eval(ctx, "input.a + 1", nil, map[string]interface{}{"a": 41})
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), out)
+
+	out, err = e.Eval(ctx, `{"a": [input.a / 2]}`, nil, map[string]interface{}{"a": 3})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": []interface{}{1.5}}, out)
+
+	assert.Len(t, e.cache, 6)
+
+	_, err = e.Eval(ctx, "data.k8s.allow", map[string]string{"bad.rego": "package k8s\nallow if {"}, nil)
+	require.ErrorContains(t, err, "bad.rego")
+}
+
+func TestDeny(t *testing.T) {
+	ctx := context.Background()
+	e := NewEvaluator()
+	modules := map[string]string{
+		"deny.rego": `package gomplate
+
+deny contains msg if {
+	input.config.replicas < 2
+	msg := "replicas must be at least 2"
+}
+
+deny contains msg if {
+	not input.config.owner
+	msg := "owner is required"
+}
+`,
+	}
+
+	msgs, err := e.Deny(ctx, modules, map[string]interface{}{
+		"config": map[string]interface{}{"replicas": 1},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"owner is required", "replicas must be at least 2"}, msgs)
+
+	msgs, err = e.Deny(ctx, modules, map[string]interface{}{
+		"config": map[string]interface{}{"replicas": 3, "owner": "me"},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, msgs)
+
+	// deny rules that produce no messages allow the input
+	msgs, err = e.Deny(ctx, map[string]string{"x.rego": "package gomplate\n\ndeny contains msg if {\n\tinput.x\n\tmsg := \"x\"\n}\n"}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, msgs)
+
+	// no deny rules at all - fail closed
+	_, err = e.Deny(ctx, map[string]string{"x.rego": "package other\n\nx := 1\n"}, nil)
+	require.ErrorContains(t, err, "is undefined")
+
+	// gomplate package, but no deny rule
+	_, err = e.Deny(ctx, map[string]string{"x.rego": "package gomplate\n\nallow := true\n"}, nil)
+	require.ErrorContains(t, err, "is undefined")
+
+	_, err = e.Deny(ctx, map[string]string{"x.rego": "package gomplate\n\ndeny := true\n"}, nil)
+	require.ErrorContains(t, err, "must be a set of messages")
+}
@@ -29,7 +29,7 @@ func TestFile_Write(t *testing.T) {
 
 	outDir := tmpDir.Join("writeOutput")
 	os.MkdirAll(outDir, 0o755)
-	o, e, err := cmd(t, "-i", `{{ "hello world" | file.Write "./out" }}`).
+	o, e, err := cmd(t, "--allow-write", "-i", `{{ "hello world" | file.Write "./out" }}`).
 		withDir(outDir).run()
 	assertSuccess(t, o, e, err, "")
 
@@ -76,6 +76,7 @@ func TestTmpl_Exec(t *testing.T) {
 		assert.NilError(t, err)
 	}
 	o, e, err = cmd(t,
+		"--allow-write",
 		"-d", "services="+tmpDir.Join("services.yaml"),
 		"-i", `{{- define "config" }}{{ .config | data.ToJSONPretty " " }}{{ end }}
 {{- range (ds "services").services -}}
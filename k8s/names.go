@@ -0,0 +1,57 @@
+package k8s
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	// DNS1123LabelMaxLength is the maximum length of a DNS-1123 label, used for
+	// most Kubernetes object names (Services, Namespaces, etc)
+	DNS1123LabelMaxLength = 63
+	// DNS1123SubdomainMaxLength is the maximum length of a DNS-1123 subdomain,
+	// used for many other object names (ConfigMaps, Secrets, etc)
+	DNS1123SubdomainMaxLength = 253
+)
+
+var (
+	dns1123LabelRE     = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	dns1123SubdomainRE = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+	invalidLabelCharRE = regexp.MustCompile(`[^a-z0-9-]+`)
+	repeatedDashRE     = regexp.MustCompile(`-{2,}`)
+)
+
+// IsDNS1123Label returns whether s is a valid DNS-1123 label: at most 63
+// lowercase alphanumeric characters or '-', starting and ending with an
+// alphanumeric character.
+func IsDNS1123Label(s string) bool {
+	return len(s) <= DNS1123LabelMaxLength && dns1123LabelRE.MatchString(s)
+}
+
+// IsDNS1123Subdomain returns whether s is a valid DNS-1123 subdomain: at most
+// 253 characters, made of DNS-1123 labels separated by '.'.
+func IsDNS1123Subdomain(s string) bool {
+	return len(s) <= DNS1123SubdomainMaxLength && dns1123SubdomainRE.MatchString(s)
+}
+
+// SanitizeName converts s into a valid DNS-1123 label of at most maxLen
+// characters (or 63 when maxLen is not positive). Letters are lowercased, runs
+// of invalid characters are replaced with a single '-', and leading or
+// trailing '-' are removed. The result may be empty, if s contains no
+// alphanumeric characters.
+func SanitizeName(s string, maxLen int) string {
+	if maxLen <= 0 || maxLen > DNS1123LabelMaxLength {
+		maxLen = DNS1123LabelMaxLength
+	}
+
+	s = strings.ToLower(s)
+	s = invalidLabelCharRE.ReplaceAllString(s, "-")
+	s = repeatedDashRE.ReplaceAllString(s, "-")
+	s = strings.Trim(s, "-")
+
+	if len(s) > maxLen {
+		s = strings.TrimRight(s[:maxLen], "-")
+	}
+
+	return s
+}
@@ -0,0 +1,54 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsDNS1123Label(t *testing.T) {
+	for _, s := range []string{"a", "ab", "a-b", "1", "my-app-2", strings.Repeat("a", 63)} {
+		assert.True(t, IsDNS1123Label(s), s)
+	}
+
+	for _, s := range []string{"", "-a", "a-", "A", "a.b", "a_b", strings.Repeat("a", 64)} {
+		assert.False(t, IsDNS1123Label(s), s)
+	}
+}
+
+func TestIsDNS1123Subdomain(t *testing.T) {
+	for _, s := range []string{"a", "a.b", "my-app.example.com", "1.2.3"} {
+		assert.True(t, IsDNS1123Subdomain(s), s)
+	}
+
+	for _, s := range []string{"", ".a", "a.", "a..b", "A.b", "a_b.c", strings.Repeat("a", 254)} {
+		assert.False(t, IsDNS1123Subdomain(s), s)
+	}
+}
+
+func TestSanitizeName(t *testing.T) {
+	testdata := []struct {
+		in       string
+		maxLen   int
+		expected string
+	}{
+		{"my-app", 0, "my-app"},
+		{"My_App", 0, "my-app"},
+		{"feature/JIRA-123_fix stuff", 0, "feature-jira-123-fix-stuff"},
+		{"--a--b--", 0, "a-b"},
+		{"___", 0, ""},
+		{"über.app", 0, "ber-app"},
+		{strings.Repeat("a", 70), 0, strings.Repeat("a", 63)},
+		{"abcde-fgh", 6, "abcde"},
+		{"abc", 100, "abc"},
+	}
+
+	for _, d := range testdata {
+		out := SanitizeName(d.in, d.maxLen)
+		assert.Equal(t, d.expected, out, d.in)
+		if out != "" {
+			assert.True(t, IsDNS1123Label(out), out)
+		}
+	}
+}
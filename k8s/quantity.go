@@ -0,0 +1,211 @@
+// Package k8s contains helpers for working with Kubernetes conventions, like
+// resource quantities, label selectors, and object names, without depending on
+// the Kubernetes libraries.
+package k8s
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// maxExponent bounds exponents in quantities like "1e3", so that parsing
+// can't be made to allocate huge numbers
+const maxExponent = 1000
+
+// QuantityFormat is the suffix style used when formatting a quantity
+type QuantityFormat string
+
+const (
+	// BinarySI formats with power-of-two suffixes, like "Ki", "Mi", and "Gi"
+	BinarySI QuantityFormat = "BinarySI"
+	// DecimalSI formats with power-of-ten suffixes, like "m", "k", and "M"
+	DecimalSI QuantityFormat = "DecimalSI"
+	// DecimalExponent formats with an exponent, like "1e3"
+	DecimalExponent QuantityFormat = "DecimalExponent"
+)
+
+type suffix struct {
+	name string
+	base int64
+	exp  int
+}
+
+var (
+	// binary suffixes, largest first
+	binarySuffixes = []suffix{
+		{"Ei", 2, 60}, {"Pi", 2, 50}, {"Ti", 2, 40},
+		{"Gi", 2, 30}, {"Mi", 2, 20}, {"Ki", 2, 10},
+	}
+
+	// decimal suffixes, largest first
+	decimalSuffixes = []suffix{
+		{"E", 10, 18}, {"P", 10, 15}, {"T", 10, 12}, {"G", 10, 9},
+		{"M", 10, 6}, {"k", 10, 3}, {"", 10, 0},
+		{"m", 10, -3}, {"u", 10, -6}, {"n", 10, -9},
+	}
+)
+
+// ParseQuantity parses a Kubernetes resource quantity, like "512Mi", "100m",
+// or "1.5e3", into an exact rational value. The format of the quantity's
+// suffix is also returned, so it can be preserved when formatting.
+func ParseQuantity(s string) (*big.Rat, QuantityFormat, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, "", fmt.Errorf("quantity must not be empty")
+	}
+
+	num, sfx := splitQuantity(s)
+	if num == "" || num == "+" || num == "-" || num == "." {
+		return nil, "", fmt.Errorf("invalid quantity %q: missing number", s)
+	}
+
+	r, ok := new(big.Rat).SetString(num)
+	if !ok || strings.ContainsAny(num, "eE/") {
+		return nil, "", fmt.Errorf("invalid quantity %q: malformed number", s)
+	}
+
+	mult, format, err := parseSuffix(sfx)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid quantity %q: %w", s, err)
+	}
+
+	return r.Mul(r, mult), format, nil
+}
+
+// splitQuantity splits s into the numeric part and the suffix
+func splitQuantity(s string) (num, sfx string) {
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+	for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.') {
+		i++
+	}
+
+	return s[:i], s[i:]
+}
+
+func parseSuffix(sfx string) (*big.Rat, QuantityFormat, error) {
+	for _, suf := range binarySuffixes {
+		if suf.name == sfx {
+			return pow(suf.base, suf.exp), BinarySI, nil
+		}
+	}
+
+	for _, suf := range decimalSuffixes {
+		if suf.name == sfx {
+			return pow(suf.base, suf.exp), DecimalSI, nil
+		}
+	}
+
+	if len(sfx) > 1 && (sfx[0] == 'e' || sfx[0] == 'E') {
+		exp, err := strconv.Atoi(sfx[1:])
+		if err == nil && exp >= -maxExponent && exp <= maxExponent {
+			return pow(10, exp), DecimalExponent, nil
+		}
+	}
+
+	return nil, "", fmt.Errorf("unknown suffix %q", sfx)
+}
+
+// pow returns base^exp as a rational
+func pow(base int64, exp int) *big.Rat {
+	neg := exp < 0
+	if neg {
+		exp = -exp
+	}
+
+	n := new(big.Int).Exp(big.NewInt(base), big.NewInt(int64(exp)), nil)
+	if neg {
+		return new(big.Rat).SetFrac(big.NewInt(1), n)
+	}
+
+	return new(big.Rat).SetInt(n)
+}
+
+// FormatQuantity formats the value as a canonical Kubernetes quantity in the
+// given format, using the largest suffix that represents it exactly. Like
+// Kubernetes, BinarySI falls back to DecimalSI for values that can't be
+// represented as a whole number of bytes, and values more precise than a nano
+// unit are rounded up.
+func FormatQuantity(r *big.Rat, format QuantityFormat) (string, error) {
+	r = roundUpNano(r)
+
+	switch format {
+	case BinarySI:
+		if r.IsInt() {
+			return formatBinary(r.Num()), nil
+		}
+
+		return formatDecimal(r, false), nil
+	case DecimalSI, "":
+		return formatDecimal(r, false), nil
+	case DecimalExponent:
+		return formatDecimal(r, true), nil
+	default:
+		return "", fmt.Errorf("unknown format %q: must be one of %s, %s, or %s", format, BinarySI, DecimalSI, DecimalExponent)
+	}
+}
+
+// roundUpNano rounds the value up (away from zero) to a whole number of nano
+// units
+func roundUpNano(r *big.Rat) *big.Rat {
+	nano := pow(10, 9)
+	scaled := new(big.Rat).Mul(r, nano)
+	if scaled.IsInt() {
+		return r
+	}
+
+	q, m := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	if m.Sign() > 0 {
+		q.Add(q, big.NewInt(1))
+	} else if m.Sign() < 0 {
+		q.Sub(q, big.NewInt(1))
+	}
+
+	return new(big.Rat).Quo(new(big.Rat).SetInt(q), nano)
+}
+
+func formatBinary(n *big.Int) string {
+	if n.Sign() == 0 {
+		return "0"
+	}
+
+	for _, suf := range binarySuffixes {
+		div := pow(suf.base, suf.exp).Num()
+		q, m := new(big.Int).QuoRem(n, div, new(big.Int))
+		if m.Sign() == 0 {
+			return q.String() + suf.name
+		}
+	}
+
+	return n.String()
+}
+
+func formatDecimal(r *big.Rat, exponent bool) string {
+	if r.Sign() == 0 {
+		return "0"
+	}
+
+	for _, suf := range decimalSuffixes {
+		v := new(big.Rat).Quo(r, pow(suf.base, suf.exp))
+		if !v.IsInt() {
+			continue
+		}
+
+		if !exponent {
+			return v.Num().String() + suf.name
+		}
+
+		if suf.exp == 0 {
+			return v.Num().String()
+		}
+
+		return fmt.Sprintf("%se%d", v.Num().String(), suf.exp)
+	}
+
+	// unreachable, since the value has been rounded to nano units
+	return r.FloatString(9)
+}
@@ -0,0 +1,96 @@
+package k8s
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuantity(t *testing.T) {
+	testdata := []struct {
+		in       string
+		expected string
+		format   QuantityFormat
+	}{
+		{"0", "0", DecimalSI},
+		{"1", "1", DecimalSI},
+		{"512Mi", "536870912", BinarySI},
+		{"1.5Gi", "1610612736", BinarySI},
+		{"1Ki", "1024", BinarySI},
+		{"2Ei", "2305843009213693952", BinarySI},
+		{"100m", "1/10", DecimalSI},
+		{"250u", "1/4000", DecimalSI},
+		{"5n", "1/200000000", DecimalSI},
+		{"1k", "1000", DecimalSI},
+		{"1.5M", "1500000", DecimalSI},
+		{"2G", "2000000000", DecimalSI},
+		{"1e3", "1000", DecimalExponent},
+		{"1E-3", "1/1000", DecimalExponent},
+		{"1.5e+6", "1500000", DecimalExponent},
+		{"-2", "-2", DecimalSI},
+		{"+2Ki", "2048", BinarySI},
+		{".5", "1/2", DecimalSI},
+		{" 3 ", "3", DecimalSI},
+	}
+
+	for _, d := range testdata {
+		t.Run(d.in, func(t *testing.T) {
+			r, f, err := ParseQuantity(d.in)
+			require.NoError(t, err)
+			assert.Equal(t, d.expected, r.RatString())
+			assert.Equal(t, d.format, f)
+		})
+	}
+
+	for _, in := range []string{"", "Mi", "1.2.3", "1Q", "1KB", "1e", "1ki", "-", "1e99999", "1/2"} {
+		t.Run("invalid "+in, func(t *testing.T) {
+			_, _, err := ParseQuantity(in)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFormatQuantity(t *testing.T) {
+	testdata := []struct {
+		in       string
+		format   QuantityFormat
+		expected string
+	}{
+		{"0", BinarySI, "0"},
+		{"1024", BinarySI, "1Ki"},
+		{"1536", BinarySI, "1536"},
+		{"1610612736", BinarySI, "1536Mi"},
+		{"1073741824", BinarySI, "1Gi"},
+		{"1000", BinarySI, "1000"},
+		{"1/2", BinarySI, "500m"},
+		{"-2048", BinarySI, "-2Ki"},
+		{"1000", DecimalSI, "1k"},
+		{"1500", DecimalSI, "1500"},
+		{"1500000", DecimalSI, "1500k"},
+		{"1/10", DecimalSI, "100m"},
+		{"1/4000", DecimalSI, "250u"},
+		{"1", DecimalSI, "1"},
+		{"1/3", DecimalSI, "333333334n"},
+		{"-1/3", DecimalSI, "-333333334n"},
+		{"1024", "", "1024"},
+		{"1000", DecimalExponent, "1e3"},
+		{"1/10", DecimalExponent, "100e-3"},
+		{"7", DecimalExponent, "7"},
+	}
+
+	for _, d := range testdata {
+		t.Run(d.in+string(d.format), func(t *testing.T) {
+			r, ok := new(big.Rat).SetString(d.in)
+			require.True(t, ok)
+
+			out, err := FormatQuantity(r, d.format)
+			require.NoError(t, err)
+			assert.Equal(t, d.expected, out)
+		})
+	}
+
+	_, err := FormatQuantity(big.NewRat(1, 1), "bogus")
+	assert.Error(t, err)
+}
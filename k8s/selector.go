@@ -0,0 +1,224 @@
+package k8s
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Operator is a label selector requirement operator
+type Operator string
+
+// Supported label selector operators
+const (
+	Equals       Operator = "="
+	NotEquals    Operator = "!="
+	In           Operator = "in"
+	NotIn        Operator = "notin"
+	Exists       Operator = "exists"
+	DoesNotExist Operator = "!"
+)
+
+// Requirement is a single requirement in a label selector, like "app=web" or
+// "tier in (frontend,backend)"
+type Requirement struct {
+	Key      string
+	Operator Operator
+	Values   []string
+}
+
+// Matches returns whether the labels satisfy this requirement
+func (r Requirement) Matches(labels map[string]string) bool {
+	v, ok := labels[r.Key]
+
+	switch r.Operator {
+	case Exists:
+		return ok
+	case DoesNotExist:
+		return !ok
+	case Equals, In:
+		return ok && contains(r.Values, v)
+	case NotEquals, NotIn:
+		return !ok || !contains(r.Values, v)
+	default:
+		return false
+	}
+}
+
+func contains(values []string, v string) bool {
+	for _, val := range values {
+		if val == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Selector is a set of requirements, all of which must match
+type Selector []Requirement
+
+// Matches returns whether the labels satisfy all requirements in the selector.
+// An empty selector matches everything.
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, r := range s {
+		if !r.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// SelectorFromMap returns a selector requiring each of the given labels, like a
+// Kubernetes `matchLabels` block.
+func SelectorFromMap(m map[string]string) Selector {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	s := make(Selector, 0, len(m))
+	for _, k := range keys {
+		s = append(s, Requirement{Key: k, Operator: Equals, Values: []string{m[k]}})
+	}
+
+	return s
+}
+
+// ParseSelector parses a label selector string, using the same syntax as
+// `kubectl get -l`. Requirements are comma-separated, and can be one of:
+//
+//	key                  (exists)
+//	!key                 (does not exist)
+//	key=value, key==value
+//	key!=value
+//	key in (v1,v2)
+//	key notin (v1,v2)
+func ParseSelector(s string) (Selector, error) {
+	parts, err := splitRequirements(s)
+	if err != nil {
+		return nil, err
+	}
+
+	sel := make(Selector, 0, len(parts))
+	for _, part := range parts {
+		r, err := parseRequirement(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector %q: %w", s, err)
+		}
+		sel = append(sel, r)
+	}
+
+	return sel, nil
+}
+
+// splitRequirements splits on commas that aren't inside parentheses
+func splitRequirements(s string) ([]string, error) {
+	parts := []string{}
+	depth := 0
+	start := 0
+
+	for i, c := range s {
+		switch c {
+		case '(':
+			depth++
+			if depth > 1 {
+				return nil, fmt.Errorf("invalid selector %q: nested parentheses", s)
+			}
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("invalid selector %q: unbalanced parentheses", s)
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("invalid selector %q: unbalanced parentheses", s)
+	}
+	parts = append(parts, s[start:])
+
+	// an entirely empty selector is valid, and matches everything
+	if len(parts) == 1 && strings.TrimSpace(parts[0]) == "" {
+		return nil, nil
+	}
+
+	return parts, nil
+}
+
+func parseRequirement(s string) (Requirement, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Requirement{}, fmt.Errorf("empty requirement")
+	}
+
+	if key, ok := strings.CutPrefix(s, "!"); ok {
+		key = strings.TrimSpace(key)
+		if err := validateKey(key); err != nil {
+			return Requirement{}, err
+		}
+
+		return Requirement{Key: key, Operator: DoesNotExist}, nil
+	}
+
+	// set-based: "key in (a,b)" / "key notin (a,b)"
+	if i := strings.Index(s, "("); i >= 0 {
+		fields := strings.Fields(s[:i])
+		if len(fields) != 2 || !strings.HasSuffix(s, ")") {
+			return Requirement{}, fmt.Errorf("malformed set requirement %q", s)
+		}
+
+		op := Operator(fields[1])
+		if op != In && op != NotIn {
+			return Requirement{}, fmt.Errorf("unknown operator %q in %q: must be one of in or notin", fields[1], s)
+		}
+
+		key := fields[0]
+		if err := validateKey(key); err != nil {
+			return Requirement{}, err
+		}
+
+		values := []string{}
+		for _, v := range strings.Split(s[i+1:len(s)-1], ",") {
+			values = append(values, strings.TrimSpace(v))
+		}
+
+		return Requirement{Key: key, Operator: op, Values: values}, nil
+	}
+
+	for _, op := range []string{"!=", "==", "="} {
+		if key, value, ok := strings.Cut(s, op); ok {
+			key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+			if err := validateKey(key); err != nil {
+				return Requirement{}, err
+			}
+
+			o := Equals
+			if op == "!=" {
+				o = NotEquals
+			}
+
+			return Requirement{Key: key, Operator: o, Values: []string{value}}, nil
+		}
+	}
+
+	if err := validateKey(s); err != nil {
+		return Requirement{}, err
+	}
+
+	return Requirement{Key: s, Operator: Exists}, nil
+}
+
+func validateKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("missing key")
+	}
+	if strings.ContainsAny(key, " \t=!(),") {
+		return fmt.Errorf("invalid key %q", key)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSelector(t *testing.T) {
+	sel, err := ParseSelector("")
+	require.NoError(t, err)
+	assert.Empty(t, sel)
+
+	sel, err = ParseSelector("app=web, tier in (frontend, backend),env!=dev,release,!canary,x==y,z notin (a)")
+	require.NoError(t, err)
+	assert.Equal(t, Selector{
+		{Key: "app", Operator: Equals, Values: []string{"web"}},
+		{Key: "tier", Operator: In, Values: []string{"frontend", "backend"}},
+		{Key: "env", Operator: NotEquals, Values: []string{"dev"}},
+		{Key: "release", Operator: Exists},
+		{Key: "canary", Operator: DoesNotExist},
+		{Key: "x", Operator: Equals, Values: []string{"y"}},
+		{Key: "z", Operator: NotIn, Values: []string{"a"}},
+	}, sel)
+
+	sel, err = ParseSelector("app.kubernetes.io/name=gomplate")
+	require.NoError(t, err)
+	assert.Equal(t, Selector{
+		{Key: "app.kubernetes.io/name", Operator: Equals, Values: []string{"gomplate"}},
+	}, sel)
+
+	for _, in := range []string{
+		"a,,b", "=b", "a in (b", "a in b)", "a is (b)", "in (a)", "a in ((b))", "!", "a b",
+	} {
+		_, err = ParseSelector(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestSelectorMatches(t *testing.T) {
+	labels := map[string]string{
+		"app":  "web",
+		"tier": "frontend",
+		"env":  "prod",
+	}
+
+	testdata := []struct {
+		selector string
+		expected bool
+	}{
+		{"", true},
+		{"app=web", true},
+		{"app==web", true},
+		{"app=api", false},
+		{"app!=api", true},
+		{"app!=web", false},
+		{"missing!=x", true},
+		{"tier in (frontend,backend)", true},
+		{"tier in (backend)", false},
+		{"missing in (a)", false},
+		{"tier notin (backend)", true},
+		{"tier notin (frontend)", false},
+		{"missing notin (a)", true},
+		{"env", true},
+		{"missing", false},
+		{"!env", false},
+		{"!missing", true},
+		{"app=web,env=prod", true},
+		{"app=web,env=dev", false},
+	}
+
+	for _, d := range testdata {
+		sel, err := ParseSelector(d.selector)
+		require.NoError(t, err)
+		assert.Equal(t, d.expected, sel.Matches(labels), d.selector)
+	}
+}
+
+func TestSelectorFromMap(t *testing.T) {
+	sel := SelectorFromMap(map[string]string{"b": "2", "a": "1"})
+	assert.Equal(t, Selector{
+		{Key: "a", Operator: Equals, Values: []string{"1"}},
+		{Key: "b", Operator: Equals, Values: []string{"2"}},
+	}, sel)
+
+	assert.True(t, sel.Matches(map[string]string{"a": "1", "b": "2", "c": "3"}))
+	assert.False(t, sel.Matches(map[string]string{"a": "1"}))
+	assert.True(t, SelectorFromMap(nil).Matches(nil))
+}
@@ -0,0 +1,55 @@
+package math
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ParseDecimal parses an arbitrary-precision decimal number, returning its
+// exact value and its scale (the number of digits after the decimal point).
+// Exponents (e.g. "1.5e3") and integers with base prefixes (e.g. "0xff") are
+// also accepted.
+func ParseDecimal(s string) (*big.Rat, int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.Contains(s, "/") {
+		return nil, 0, fmt.Errorf("invalid decimal number %q", s)
+	}
+
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, 0, fmt.Errorf("invalid decimal number %q", s)
+	}
+
+	return r, decimalScale(s), nil
+}
+
+// decimalScale returns the number of digits after the decimal point in the
+// (valid) number s, taking any exponent into account
+func decimalScale(s string) int {
+	s = strings.TrimLeft(s, "+-")
+	if len(s) > 1 && s[0] == '0' && strings.ContainsAny(s[1:2], "xXbBoO") {
+		return 0
+	}
+
+	exp := 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		exp, _ = strconv.Atoi(s[i+1:])
+		s = s[:i]
+	}
+
+	scale := 0
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		scale = len(strings.ReplaceAll(s[i+1:], "_", ""))
+	}
+
+	return max(scale-exp, 0)
+}
+
+// FormatDecimal formats r as a decimal number with exactly scale digits after
+// the decimal point. If r can't be represented exactly, the last digit is
+// rounded to nearest, with halves rounded away from zero.
+func FormatDecimal(r *big.Rat, scale int) string {
+	return r.FloatString(max(scale, 0))
+}
@@ -0,0 +1,53 @@
+package math
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDecimal(t *testing.T) {
+	testdata := []struct {
+		in    string
+		value *big.Rat
+		scale int
+	}{
+		{"0", big.NewRat(0, 1), 0},
+		{"42", big.NewRat(42, 1), 0},
+		{"-1.50", big.NewRat(-3, 2), 2},
+		{" 0.001 ", big.NewRat(1, 1000), 3},
+		{"1.5e3", big.NewRat(1500, 1), 0},
+		{"1.5e-3", big.NewRat(15, 10000), 4},
+		{"0xff", big.NewRat(255, 1), 0},
+		{"1_000.25", big.NewRat(4001, 4), 2},
+		{"123456789012345678901234567890", new(big.Rat).SetFrac(bigInt("123456789012345678901234567890"), big.NewInt(1)), 0},
+	}
+
+	for _, d := range testdata {
+		r, scale, err := ParseDecimal(d.in)
+		require.NoError(t, err, d.in)
+		assert.Equal(t, 0, d.value.Cmp(r), "%s: %s != %s", d.in, d.value, r)
+		assert.Equal(t, d.scale, scale, d.in)
+	}
+
+	for _, in := range []string{"", "foo", "1/3", "1.2.3"} {
+		_, _, err := ParseDecimal(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestFormatDecimal(t *testing.T) {
+	assert.Equal(t, "1.50", FormatDecimal(big.NewRat(3, 2), 2))
+	assert.Equal(t, "0.33", FormatDecimal(big.NewRat(1, 3), 2))
+	assert.Equal(t, "0.67", FormatDecimal(big.NewRat(2, 3), 2))
+	assert.Equal(t, "3", FormatDecimal(big.NewRat(5, 2), 0))
+	assert.Equal(t, "-3", FormatDecimal(big.NewRat(-5, 2), 0))
+	assert.Equal(t, "2", FormatDecimal(big.NewRat(2, 1), -1))
+}
+
+func bigInt(s string) *big.Int {
+	i, _ := new(big.Int).SetString(s, 10)
+	return i
+}
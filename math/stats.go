@@ -0,0 +1,68 @@
+package math
+
+import (
+	"math"
+	"slices"
+)
+
+// Sum returns the sum of the given numbers.
+func Sum(nums []float64) float64 {
+	var x float64
+	for _, n := range nums {
+		x += n
+	}
+	return x
+}
+
+// Mean returns the arithmetic mean (average) of the given numbers, or NaN
+// if there are none.
+func Mean(nums []float64) float64 {
+	if len(nums) == 0 {
+		return math.NaN()
+	}
+	return Sum(nums) / float64(len(nums))
+}
+
+// Median returns the middle value of the given numbers, or the mean of the
+// two middle values when there is an even number of them. NaN is returned
+// if there are no numbers. The input is not modified.
+func Median(nums []float64) float64 {
+	return Percentile(50, nums)
+}
+
+// StdDev returns the population standard deviation of the given numbers, or
+// NaN if there are none.
+func StdDev(nums []float64) float64 {
+	if len(nums) == 0 {
+		return math.NaN()
+	}
+
+	m := Mean(nums)
+
+	var v float64
+	for _, n := range nums {
+		v += (n - m) * (n - m)
+	}
+
+	return math.Sqrt(v / float64(len(nums)))
+}
+
+// Percentile returns the p-th percentile (0-100) of the given numbers,
+// linearly interpolating between the closest ranks. This is the same method
+// used by spreadsheet PERCENTILE functions and NumPy's default. NaN is
+// returned if there are no numbers, or if p is out of range. The input is
+// not modified.
+func Percentile(p float64, nums []float64) float64 {
+	if len(nums) == 0 || p < 0 || p > 100 {
+		return math.NaN()
+	}
+
+	sorted := slices.Clone(nums)
+	slices.Sort(sorted)
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(rank-float64(lo))
+}
@@ -0,0 +1,44 @@
+package math
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	nums := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+
+	assert.InDelta(t, 40.0, Sum(nums), 1e-9)
+	assert.InDelta(t, 5.0, Mean(nums), 1e-9)
+	assert.InDelta(t, 4.5, Median(nums), 1e-9)
+	assert.InDelta(t, 2.0, StdDev(nums), 1e-9)
+
+	assert.InDelta(t, 3.0, Median([]float64{5, 1, 3}), 1e-9)
+
+	assert.True(t, math.IsNaN(Mean(nil)))
+	assert.True(t, math.IsNaN(Median(nil)))
+	assert.True(t, math.IsNaN(StdDev(nil)))
+	assert.Zero(t, Sum(nil))
+}
+
+func TestPercentile(t *testing.T) {
+	nums := []float64{15, 20, 35, 40, 50}
+
+	assert.InDelta(t, 15.0, Percentile(0, nums), 1e-9)
+	assert.InDelta(t, 50.0, Percentile(100, nums), 1e-9)
+	assert.InDelta(t, 35.0, Percentile(50, nums), 1e-9)
+	assert.InDelta(t, 29.0, Percentile(40, nums), 1e-9)
+	assert.InDelta(t, 48.0, Percentile(95, nums), 1e-9)
+	assert.InDelta(t, 7.0, Percentile(90, []float64{7}), 1e-9)
+
+	// the input isn't modified
+	in := []float64{3, 1, 2}
+	Percentile(50, in)
+	assert.Equal(t, []float64{3, 1, 2}, in)
+
+	assert.True(t, math.IsNaN(Percentile(50, nil)))
+	assert.True(t, math.IsNaN(Percentile(-1, nums)))
+	assert.True(t, math.IsNaN(Percentile(101, nums)))
+}
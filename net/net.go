@@ -2,6 +2,7 @@
 package net
 
 import (
+	"context"
 	"net"
 )
 
@@ -19,23 +20,7 @@ func LookupIP(name string) (string, error) {
 
 // LookupIPs -
 func LookupIPs(name string) ([]string, error) {
-	srcIPs, err := net.LookupIP(name)
-	if err != nil {
-		return nil, err
-	}
-
-	// perf note: this slice is not really worth pre-allocating - srcIPs tends
-	// to be very small, and net.LookupIP is relatively expensive
-	var ips []string
-	for _, v := range srcIPs {
-		if v.To4() != nil {
-			s := v.String()
-			if !contains(ips, s) {
-				ips = append(ips, s)
-			}
-		}
-	}
-	return ips, nil
+	return defaultResolver().LookupIPs(context.Background(), name)
 }
 
 func contains(a []string, s string) bool {
@@ -74,3 +59,15 @@ func LookupSRVs(name string) ([]*net.SRV, error) {
 	}
 	return addrs, nil
 }
+
+// LookupMX -
+func LookupMX(name string) ([]*net.MX, error) {
+	return defaultResolver().LookupMX(context.Background(), name)
+}
+
+// defaultResolver returns a new resolver using the system's DNS servers
+func defaultResolver() *Resolver {
+	r, _ := NewResolver("")
+
+	return r
+}
@@ -0,0 +1,167 @@
+package net
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+)
+
+// Resolver looks up DNS records, optionally querying a specific DNS server,
+// and caches successful results for its lifetime
+type Resolver struct {
+	resolver *net.Resolver
+	cache    map[string]interface{}
+	mu       sync.Mutex
+}
+
+// NewResolver creates a Resolver which queries the given DNS server (in
+// "host" or "host:port" form - the port defaults to 53), or the system's
+// configured DNS servers when server is empty.
+func NewResolver(server string) (*Resolver, error) {
+	r := &Resolver{
+		resolver: net.DefaultResolver,
+		cache:    map[string]interface{}{},
+	}
+
+	if server == "" {
+		return r, nil
+	}
+
+	addr := server
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		addr = net.JoinHostPort(server, "53")
+	}
+
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		return nil, fmt.Errorf("invalid DNS server %q: %w", server, err)
+	}
+
+	r.resolver = &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			d := net.Dialer{}
+
+			return d.DialContext(ctx, network, addr)
+		},
+	}
+
+	return r, nil
+}
+
+// LookupIPs - look up the IPv4 addresses for the host name
+func (r *Resolver) LookupIPs(ctx context.Context, name string) ([]string, error) {
+	v, err := r.cached("ip", name, func() (interface{}, error) {
+		srcIPs, err := r.resolver.LookupIP(ctx, "ip4", name)
+		if err != nil {
+			return nil, err
+		}
+
+		var ips []string
+		for _, v := range srcIPs {
+			s := v.String()
+			if !contains(ips, s) {
+				ips = append(ips, s)
+			}
+		}
+
+		return ips, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.([]string), nil
+}
+
+// LookupCNAME - look up the canonical name for the host name
+func (r *Resolver) LookupCNAME(ctx context.Context, name string) (string, error) {
+	v, err := r.cached("cname", name, func() (interface{}, error) {
+		return r.resolver.LookupCNAME(ctx, name)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return v.(string), nil
+}
+
+// LookupTXT - look up the TXT records for the name
+func (r *Resolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	v, err := r.cached("txt", name, func() (interface{}, error) {
+		return r.resolver.LookupTXT(ctx, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.([]string), nil
+}
+
+// LookupSRVs - look up the SRV records for the name, sorted by priority and
+// randomized by weight
+func (r *Resolver) LookupSRVs(ctx context.Context, name string) ([]*net.SRV, error) {
+	v, err := r.cached("srv", name, func() (interface{}, error) {
+		_, addrs, err := r.resolver.LookupSRV(ctx, "", "", name)
+
+		return addrs, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.([]*net.SRV), nil
+}
+
+// LookupMX - look up the MX records for the name, sorted by preference
+func (r *Resolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	v, err := r.cached("mx", name, func() (interface{}, error) {
+		mxs, err := r.resolver.LookupMX(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		// the resolver randomizes records with equal preference, but it's
+		// more useful for the output to be stable
+		sort.SliceStable(mxs, func(i, j int) bool {
+			if mxs[i].Pref != mxs[j].Pref {
+				return mxs[i].Pref < mxs[j].Pref
+			}
+
+			return mxs[i].Host < mxs[j].Host
+		})
+
+		return mxs, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.([]*net.MX), nil
+}
+
+// cached returns the cached result for the lookup, or performs the lookup and
+// caches the result if it succeeds
+func (r *Resolver) cached(kind, name string, lookup func() (interface{}, error)) (interface{}, error) {
+	key := kind + ":" + name
+
+	r.mu.Lock()
+	v, ok := r.cache[key]
+	r.mu.Unlock()
+
+	if ok {
+		return v, nil
+	}
+
+	v, err := lookup()
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.cache[key] = v
+	r.mu.Unlock()
+
+	return v, nil
+}
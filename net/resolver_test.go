@@ -0,0 +1,159 @@
+package net
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// testDNSServer starts a DNS server on a local UDP port, answering queries
+// for test.example., and returns its address and a counter of queries received
+func testDNSServer(t *testing.T) (string, *int32) {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { conn.Close() })
+
+	name := dnsmessage.MustNewName("test.example.")
+	queries := new(int32)
+
+	go func() {
+		buf := make([]byte, 512)
+
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			atomic.AddInt32(queries, 1)
+
+			var msg dnsmessage.Message
+			if err := msg.Unpack(buf[:n]); err != nil || len(msg.Questions) != 1 {
+				continue
+			}
+
+			q := msg.Questions[0]
+			hdr := dnsmessage.ResourceHeader{Name: q.Name, Class: dnsmessage.ClassINET, TTL: 60}
+
+			msg.Header.Response = true
+			msg.Header.Authoritative = true
+
+			if q.Name != name {
+				msg.Header.RCode = dnsmessage.RCodeNameError
+			} else {
+				switch q.Type {
+				case dnsmessage.TypeA:
+					msg.Answers = []dnsmessage.Resource{
+						{Header: hdr, Body: &dnsmessage.AResource{A: [4]byte{192, 0, 2, 1}}},
+					}
+				case dnsmessage.TypeTXT:
+					msg.Answers = []dnsmessage.Resource{
+						{Header: hdr, Body: &dnsmessage.TXTResource{TXT: []string{"v=spf1 -all"}}},
+					}
+				case dnsmessage.TypeMX:
+					msg.Answers = []dnsmessage.Resource{
+						{Header: hdr, Body: &dnsmessage.MXResource{Pref: 20, MX: dnsmessage.MustNewName("mx2.test.example.")}},
+						{Header: hdr, Body: &dnsmessage.MXResource{Pref: 10, MX: dnsmessage.MustNewName("mx1.test.example.")}},
+					}
+				case dnsmessage.TypeSRV:
+					msg.Answers = []dnsmessage.Resource{
+						{Header: hdr, Body: &dnsmessage.SRVResource{Priority: 10, Weight: 1, Port: 8080, Target: dnsmessage.MustNewName("web.test.example.")}},
+					}
+				}
+			}
+
+			out, err := msg.Pack()
+			if err != nil {
+				continue
+			}
+
+			_, _ = conn.WriteTo(out, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String(), queries
+}
+
+func TestResolver(t *testing.T) {
+	t.Parallel()
+
+	addr, queries := testDNSServer(t)
+	ctx := context.Background()
+
+	r, err := NewResolver(addr)
+	require.NoError(t, err)
+
+	ips, err := r.LookupIPs(ctx, "test.example.")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"192.0.2.1"}, ips)
+
+	txt, err := r.LookupTXT(ctx, "test.example.")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v=spf1 -all"}, txt)
+
+	mxs, err := r.LookupMX(ctx, "test.example.")
+	require.NoError(t, err)
+	assert.Equal(t, []*net.MX{
+		{Host: "mx1.test.example.", Pref: 10},
+		{Host: "mx2.test.example.", Pref: 20},
+	}, mxs)
+
+	srvs, err := r.LookupSRVs(ctx, "test.example.")
+	require.NoError(t, err)
+	assert.Equal(t, []*net.SRV{
+		{Target: "web.test.example.", Port: 8080, Priority: 10, Weight: 1},
+	}, srvs)
+
+	// results are cached, so repeated lookups don't query the server
+	n := atomic.LoadInt32(queries)
+
+	_, err = r.LookupIPs(ctx, "test.example.")
+	require.NoError(t, err)
+	_, err = r.LookupMX(ctx, "test.example.")
+	require.NoError(t, err)
+
+	assert.Equal(t, n, atomic.LoadInt32(queries))
+
+	// errors aren't cached
+	_, err = r.LookupTXT(ctx, "missing.example.")
+	require.Error(t, err)
+
+	n = atomic.LoadInt32(queries)
+
+	_, err = r.LookupTXT(ctx, "missing.example.")
+	require.Error(t, err)
+	assert.Greater(t, atomic.LoadInt32(queries), n)
+
+	// a separate resolver has a separate cache
+	r2, err := NewResolver(addr)
+	require.NoError(t, err)
+
+	n = atomic.LoadInt32(queries)
+
+	_, err = r2.LookupIPs(ctx, "test.example.")
+	require.NoError(t, err)
+	assert.Greater(t, atomic.LoadInt32(queries), n)
+}
+
+func TestNewResolver(t *testing.T) {
+	t.Parallel()
+
+	r, err := NewResolver("")
+	require.NoError(t, err)
+	assert.Equal(t, net.DefaultResolver, r.resolver)
+
+	r, err = NewResolver("1.1.1.1")
+	require.NoError(t, err)
+	assert.NotEqual(t, net.DefaultResolver, r.resolver)
+
+	_, err = NewResolver("[::1")
+	require.Error(t, err)
+}
@@ -0,0 +1,61 @@
+package gomplate
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+)
+
+// readPolicies reads the given Rego policy files, returning a map of file
+// names to source, suitable for RenderOptions.Policies
+func readPolicies(ctx context.Context, paths []string) (map[string]string, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	policies := make(map[string]string, len(paths))
+	for _, p := range paths {
+		fsys, err := datafs.FSysForPath(ctx, p)
+		if err != nil {
+			return nil, fmt.Errorf("fsysForPath: %w", err)
+		}
+
+		b, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return nil, fmt.Errorf("read policy %q: %w", p, err)
+		}
+
+		policies[filepath.ToSlash(p)] = string(b)
+	}
+
+	return policies, nil
+}
+
+// checkPolicies evaluates the policies' deny rules against the template
+// context, returning an error listing the messages if any are denied
+func (r *renderer) checkPolicies(ctx context.Context, tctx interface{}) error {
+	if len(r.policies) == 0 {
+		return nil
+	}
+
+	// the context is a named map type, which OPA can't convert directly
+	input := tctx
+	if tc, ok := tctx.(*tmplctx); ok {
+		input = map[string]interface{}(*tc)
+	}
+
+	msgs, err := r.policyEval.Deny(ctx, r.policies, input)
+	if err != nil {
+		return fmt.Errorf("policy evaluation failed: %w", err)
+	}
+
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("policy check failed:\n  - %s", strings.Join(msgs, "\n  - "))
+}
@@ -0,0 +1,56 @@
+package random
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// NanoIDAlphabet is the default (URL-safe) alphabet for NanoIDs
+const NanoIDAlphabet = "_-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// NanoID - generate a random NanoID of the given size, from the given
+// alphabet (NanoIDAlphabet if empty). Unlike the other functions in this
+// package, a cryptographically secure random number generator is used, so
+// NanoIDs are suitable as unguessable identifiers.
+func NanoID(size int, alphabet string) (string, error) {
+	if alphabet == "" {
+		alphabet = NanoIDAlphabet
+	}
+
+	chars := []rune(alphabet)
+	if len(chars) < 2 || len(chars) > 256 {
+		return "", fmt.Errorf("alphabet must contain between 2 and 256 characters, got %d", len(chars))
+	}
+
+	if size < 1 {
+		return "", fmt.Errorf("size must be greater than 0, got %d", size)
+	}
+
+	// random bytes are masked to the smallest power of 2 that covers the
+	// alphabet, and those that fall outside it are discarded, so that every
+	// character is equally likely
+	mask := 1<<bits.Len(uint(len(chars)-1)) - 1
+	step := int(math.Ceil(1.6 * float64(mask*size) / float64(len(chars))))
+
+	id := make([]rune, 0, size)
+	b := make([]byte, step)
+
+	for {
+		_, err := crand.Read(b)
+		if err != nil {
+			return "", fmt.Errorf("failed to read random bytes: %w", err)
+		}
+
+		for _, r := range b {
+			i := int(r) & mask
+			if i < len(chars) {
+				id = append(id, chars[i])
+				if len(id) == size {
+					return string(id), nil
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package random
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"math/big"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/sethvargo/go-diceware/diceware"
+)
+
+// DefaultPasswordSymbols are the symbols used in passwords by default. Quotes,
+// backslashes, backticks, and spaces are left out, so that passwords can be
+// used in most config files and shell commands without escaping.
+const DefaultPasswordSymbols = "!#$%&*+-=?@^_~"
+
+// passwordClasses are the character classes passwords can contain
+//
+//nolint:gochecknoglobals
+var passwordClasses = map[string]string{
+	"lower":  "abcdefghijklmnopqrstuvwxyz",
+	"upper":  "ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+	"digits": "0123456789",
+	// symbols are set by the policy
+	"symbols": "",
+}
+
+// PasswordPolicy controls how passwords are generated by Password. The zero
+// value generates passwords from all character classes, with at least one
+// character from each.
+type PasswordPolicy struct {
+	// Min is the minimum number of characters from each character class used
+	// ("lower", "upper", "digits", and "symbols"). Classes not in the map
+	// aren't used at all. When nil, all classes are used, with a minimum of 1.
+	Min map[string]int
+	// Symbols are the characters in the "symbols" class. Defaults to
+	// DefaultPasswordSymbols.
+	Symbols string
+	// Exclude are characters which are never used, such as ones which are
+	// easily confused (like "Il1O0").
+	Exclude string
+	// Words generates a diceware-style passphrase of random words, rather
+	// than a password of random characters. The other character options are
+	// ignored.
+	Words bool
+	// Separator is placed between the words of a passphrase. Defaults to "-".
+	Separator string
+	// WordList is the list words are chosen from: "large" (the default - the
+	// EFF's large list of 7776 words), "short" (the EFF's short list of 1296
+	// words), or "original" (Arnold Reinhold's original diceware list).
+	WordList string
+}
+
+// Password - generate a random password of the given length, according to the
+// policy. For passphrases, the length is the number of words. Like NanoID, a
+// cryptographically secure random number generator is always used.
+func Password(length int, policy PasswordPolicy) (string, error) {
+	if length < 1 {
+		return "", fmt.Errorf("length must be greater than 0, got %d", length)
+	}
+
+	if policy.Words {
+		return passphrase(length, policy)
+	}
+
+	minimums := policy.Min
+	if minimums == nil {
+		minimums = map[string]int{"lower": 1, "upper": 1, "digits": 1, "symbols": 1}
+	}
+
+	symbols := policy.Symbols
+	if symbols == "" {
+		symbols = DefaultPasswordSymbols
+	}
+
+	// sorted for a predictable order of errors
+	names := make([]string, 0, len(minimums))
+	for name := range minimums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	required := 0
+	all := []rune{}
+	password := make([]rune, 0, length)
+
+	for _, name := range names {
+		chars, ok := passwordClasses[name]
+		if !ok {
+			return "", fmt.Errorf("unknown character class %q: must be lower, upper, digits, or symbols", name)
+		}
+
+		if name == "symbols" {
+			chars = symbols
+		}
+
+		set := excludeChars(chars, policy.Exclude)
+		if len(set) == 0 {
+			return "", fmt.Errorf("no characters left in the %s class after exclusions", name)
+		}
+
+		n := minimums[name]
+		if n < 0 {
+			return "", fmt.Errorf("minimum number of %s must not be negative, got %d", name, n)
+		}
+
+		required += n
+
+		for _, c := range set {
+			if !slices.Contains(all, c) {
+				all = append(all, c)
+			}
+		}
+
+		for range n {
+			c, err := pick(set)
+			if err != nil {
+				return "", err
+			}
+
+			password = append(password, c)
+		}
+	}
+
+	if len(all) == 0 {
+		return "", fmt.Errorf("at least one character class must be used")
+	}
+
+	if required > length {
+		return "", fmt.Errorf("length %d is too short for the minimum of %d required characters", length, required)
+	}
+
+	for len(password) < length {
+		c, err := pick(all)
+		if err != nil {
+			return "", err
+		}
+
+		password = append(password, c)
+	}
+
+	// the required characters were chosen first, so they need to be moved
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := cryptoIntN(i + 1)
+		if err != nil {
+			return "", err
+		}
+
+		password[i], password[j] = password[j], password[i]
+	}
+
+	return string(password), nil
+}
+
+func passphrase(words int, policy PasswordPolicy) (string, error) {
+	var list diceware.WordList
+
+	switch policy.WordList {
+	case "", "large":
+		list = diceware.WordListEffLarge()
+	case "short":
+		list = diceware.WordListEffSmall()
+	case "original":
+		list = diceware.WordListOriginal()
+	default:
+		return "", fmt.Errorf("unknown word list %q: must be large, short, or original", policy.WordList)
+	}
+
+	gen, err := diceware.NewGenerator(&diceware.GeneratorInput{WordList: list})
+	if err != nil {
+		return "", err
+	}
+
+	out, err := gen.Generate(words)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate passphrase: %w", err)
+	}
+
+	sep := policy.Separator
+	if sep == "" {
+		sep = "-"
+	}
+
+	return strings.Join(out, sep), nil
+}
+
+func excludeChars(chars, exclude string) []rune {
+	out := make([]rune, 0, len(chars))
+
+	for _, c := range chars {
+		if !strings.ContainsRune(exclude, c) && !slices.Contains(out, c) {
+			out = append(out, c)
+		}
+	}
+
+	return out
+}
+
+func pick(chars []rune) (rune, error) {
+	i, err := cryptoIntN(len(chars))
+	if err != nil {
+		return 0, err
+	}
+
+	return chars[i], nil
+}
+
+// cryptoIntN returns a uniformly-distributed random integer in [0, n), from
+// a cryptographically secure source
+func cryptoIntN(n int) (int, error) {
+	i, err := crand.Int(crand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read random bytes: %w", err)
+	}
+
+	return int(i.Int64()), nil
+}
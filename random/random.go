@@ -2,19 +2,87 @@
 package random
 
 import (
+	"crypto/sha256"
+	"encoding/binary"
 	"fmt"
 	"math"
 	"math/rand/v2"
 	"regexp"
+	"sync"
 	"unicode"
 )
 
 // Default set, matches "[a-zA-Z0-9_.-]"
 const defaultSet = "-.0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ_abcdefghijklmnopqrstuvwxyz"
 
+// Generator generates random values. A nil *Generator, or one created without
+// a seed, uses the (randomly-seeded) global source from math/rand/v2. A
+// Generator is safe for concurrent use.
+type Generator struct {
+	r  *rand.Rand
+	mu sync.Mutex
+}
+
+// NewSeededGenerator returns a Generator that produces the same sequence of
+// values for the same seed, on every platform. Any string can be used as a
+// seed.
+func NewSeededGenerator(seed string) *Generator {
+	h := sha256.Sum256([]byte(seed))
+
+	//nolint:gosec
+	return &Generator{
+		r: rand.New(rand.NewPCG(binary.BigEndian.Uint64(h[:8]), binary.BigEndian.Uint64(h[8:16]))),
+	}
+}
+
+//nolint:gochecknoglobals
+var defaultGenerator = &Generator{}
+
+func (g *Generator) intN(n int) int {
+	if g == nil || g.r == nil {
+		//nolint:gosec
+		return rand.IntN(n)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.r.IntN(n)
+}
+
+func (g *Generator) int64N(n int64) int64 {
+	if g == nil || g.r == nil {
+		//nolint:gosec
+		return rand.Int64N(n)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.r.Int64N(n)
+}
+
+func (g *Generator) float64() float64 {
+	if g == nil || g.r == nil {
+		//nolint:gosec
+		return rand.Float64()
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.r.Float64()
+}
+
 // StringRE - Generate a random string that matches a given regular
 // expression. Defaults to "[a-zA-Z0-9_.-]"
 func StringRE(count int, match string) (r string, err error) {
+	return defaultGenerator.StringRE(count, match)
+}
+
+// StringRE - Generate a random string that matches a given regular
+// expression. Defaults to "[a-zA-Z0-9_.-]"
+func (g *Generator) StringRE(count int, match string) (r string, err error) {
 	chars := []rune(defaultSet)
 	if match != "" {
 		chars, err = matchChars(match)
@@ -23,7 +91,7 @@ func StringRE(count int, match string) (r string, err error) {
 		}
 	}
 
-	return rndString(count, chars)
+	return g.rndString(count, chars)
 }
 
 // StringBounds returns a random string of characters with a codepoint
@@ -31,19 +99,26 @@ func StringRE(count int, match string) (r string, err error) {
 // and if a range is given where no valid characters can be found, an error
 // will be returned.
 func StringBounds(count int, lower, upper rune) (r string, err error) {
+	return defaultGenerator.StringBounds(count, lower, upper)
+}
+
+// StringBounds returns a random string of characters with a codepoint
+// between the lower and upper bounds. Only valid characters are returned
+// and if a range is given where no valid characters can be found, an error
+// will be returned.
+func (g *Generator) StringBounds(count int, lower, upper rune) (r string, err error) {
 	chars := filterRange(lower, upper)
 	if len(chars) == 0 {
 		return "", fmt.Errorf("no printable codepoints found between U%#q and U%#q", lower, upper)
 	}
-	return rndString(count, chars)
+	return g.rndString(count, chars)
 }
 
 // produce a string containing a random selection of given characters
-func rndString(count int, chars []rune) (string, error) {
+func (g *Generator) rndString(count int, chars []rune) (string, error) {
 	s := make([]rune, count)
 	for i := range s {
-		//nolint:gosec
-		s[i] = chars[rand.IntN(len(chars))]
+		s[i] = chars[g.intN(len(chars))]
 	}
 	return string(s), nil
 }
@@ -75,6 +150,11 @@ func matchChars(match string) ([]rune, error) {
 
 // Item -
 func Item(items []interface{}) (interface{}, error) {
+	return defaultGenerator.Item(items)
+}
+
+// Item -
+func (g *Generator) Item(items []interface{}) (interface{}, error) {
 	if len(items) == 0 {
 		return nil, fmt.Errorf("expected a non-empty array or slice")
 	}
@@ -82,8 +162,7 @@ func Item(items []interface{}) (interface{}, error) {
 		return items[0], nil
 	}
 
-	//nolint:gosec
-	n := rand.IntN(len(items))
+	n := g.intN(len(items))
 	return items[n], nil
 }
 
@@ -91,6 +170,13 @@ func Item(items []interface{}) (interface{}, error) {
 //
 //nolint:revive
 func Number(min, max int64) (int64, error) {
+	return defaultGenerator.Number(min, max)
+}
+
+// Number -
+//
+//nolint:revive
+func (g *Generator) Number(min, max int64) (int64, error) {
 	if min > max {
 		return 0, fmt.Errorf("min must not be greater than max (was %d, %d)", min, max)
 	}
@@ -101,14 +187,73 @@ func Number(min, max int64) (int64, error) {
 		return 0, fmt.Errorf("spread between min and max too high - must not be greater than 63-bit maximum (%d - %d = %d)", max, min, max-min)
 	}
 
-	//nolint:gosec
-	return rand.Int64N(max-min+1) + min, nil
+	return g.int64N(max-min+1) + min, nil
 }
 
 // Float - For now this is really just a wrapper around `rand.Float64`
 //
 //nolint:revive
 func Float(min, max float64) (float64, error) {
-	//nolint:gosec
-	return min + rand.Float64()*(max-min), nil
+	return defaultGenerator.Float(min, max)
+}
+
+// Float - For now this is really just a wrapper around `rand.Float64`
+//
+//nolint:revive
+func (g *Generator) Float(min, max float64) (float64, error) {
+	return min + g.float64()*(max-min), nil
+}
+
+// WeightedChoice - choose an item at random, where each item's chance of
+// being chosen is proportional to its weight
+func (g *Generator) WeightedChoice(items []interface{}, weights []float64) (interface{}, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("expected a non-empty array or slice")
+	}
+	if len(items) != len(weights) {
+		return nil, fmt.Errorf("expected the same number of items and weights, got %d and %d", len(items), len(weights))
+	}
+
+	total := 0.0
+	for i, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return nil, fmt.Errorf("invalid weight %v for item %d: must be a non-negative number", w, i)
+		}
+		total += w
+	}
+
+	if total == 0 {
+		return nil, fmt.Errorf("at least one weight must be greater than 0")
+	}
+
+	n := g.float64() * total
+	for i, w := range weights {
+		if n < w {
+			return items[i], nil
+		}
+		n -= w
+	}
+
+	// only reachable through floating-point rounding - choose the last item
+	// with a non-zero weight
+	for i := len(weights) - 1; i >= 0; i-- {
+		if weights[i] > 0 {
+			return items[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no item chosen")
+}
+
+// Shuffle - return a copy of the items in a random order
+func (g *Generator) Shuffle(items []interface{}) []interface{} {
+	out := make([]interface{}, len(items))
+	copy(out, items)
+
+	for i := len(out) - 1; i > 0; i-- {
+		j := g.intN(i + 1)
+		out[i], out[j] = out[j], out[i]
+	}
+
+	return out
 }
@@ -3,6 +3,7 @@ package random
 import (
 	"math"
 	"strconv"
+	"strings"
 	"testing"
 	"unicode/utf8"
 
@@ -144,3 +145,173 @@ func TestFloat(t *testing.T) {
 		assert.InDelta(t, d.expected, n, d.delta)
 	}
 }
+
+func TestNanoID(t *testing.T) {
+	id, err := NanoID(21, "")
+	require.NoError(t, err)
+	assert.Regexp(t, "^[A-Za-z0-9_-]{21}$", id)
+
+	id, err = NanoID(100, "01")
+	require.NoError(t, err)
+	assert.Regexp(t, "^[01]{100}$", id)
+
+	id, err = NanoID(8, "αβγ")
+	require.NoError(t, err)
+	assert.Equal(t, 8, utf8.RuneCountInString(id))
+	assert.Regexp(t, "^[αβγ]{8}$", id)
+
+	_, err = NanoID(0, "")
+	require.Error(t, err)
+
+	_, err = NanoID(10, "a")
+	require.Error(t, err)
+}
+
+func TestSeededGenerator(t *testing.T) {
+	a := NewSeededGenerator("build-42")
+	b := NewSeededGenerator("build-42")
+	c := NewSeededGenerator("build-43")
+
+	sa, err := a.StringRE(20, "")
+	require.NoError(t, err)
+	sb, err := b.StringRE(20, "")
+	require.NoError(t, err)
+	sc, err := c.StringRE(20, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, sa, sb)
+	assert.NotEqual(t, sa, sc)
+
+	na, err := a.Number(0, 1000000)
+	require.NoError(t, err)
+	nb, err := b.Number(0, 1000000)
+	require.NoError(t, err)
+	assert.Equal(t, na, nb)
+
+	fa, err := a.Float(0, 1)
+	require.NoError(t, err)
+	fb, err := b.Float(0, 1)
+	require.NoError(t, err)
+	assert.InDelta(t, fa, fb, 0)
+
+	items := []interface{}{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	assert.Equal(t, a.Shuffle(items), b.Shuffle(items))
+}
+
+func TestWeightedChoice(t *testing.T) {
+	g := NewSeededGenerator("weights")
+
+	items := []interface{}{"a", "b", "c"}
+
+	counts := map[interface{}]int{}
+	for i := 0; i < 1000; i++ {
+		item, err := g.WeightedChoice(items, []float64{1, 0, 3})
+		require.NoError(t, err)
+		counts[item]++
+	}
+
+	assert.Zero(t, counts["b"])
+	assert.InDelta(t, 250, counts["a"], 60)
+	assert.InDelta(t, 750, counts["c"], 60)
+
+	// a nil generator uses the global source
+	var ng *Generator
+	item, err := ng.WeightedChoice(items, []float64{0, 1, 0})
+	require.NoError(t, err)
+	assert.Equal(t, "b", item)
+
+	_, err = g.WeightedChoice(nil, nil)
+	require.Error(t, err)
+
+	_, err = g.WeightedChoice(items, []float64{1, 2})
+	require.Error(t, err)
+
+	_, err = g.WeightedChoice(items, []float64{1, -2, 3})
+	require.Error(t, err)
+
+	_, err = g.WeightedChoice(items, []float64{0, 0, 0})
+	require.Error(t, err)
+
+	_, err = g.WeightedChoice(items, []float64{1, math.NaN(), 1})
+	require.Error(t, err)
+}
+
+func TestShuffle(t *testing.T) {
+	g := NewSeededGenerator("shuffle")
+
+	items := []interface{}{1, 2, 3, 4, 5}
+	out := g.Shuffle(items)
+
+	assert.ElementsMatch(t, items, out)
+	// the input is not modified
+	assert.Equal(t, []interface{}{1, 2, 3, 4, 5}, items)
+
+	assert.Empty(t, g.Shuffle(nil))
+}
+
+func TestPassword(t *testing.T) {
+	t.Parallel()
+
+	countIn := func(s, chars string) int {
+		n := 0
+		for _, c := range s {
+			if strings.ContainsRune(chars, c) {
+				n++
+			}
+		}
+
+		return n
+	}
+
+	for range 20 {
+		p, err := Password(8, PasswordPolicy{})
+		require.NoError(t, err)
+		assert.Len(t, p, 8)
+		assert.GreaterOrEqual(t, countIn(p, passwordClasses["lower"]), 1)
+		assert.GreaterOrEqual(t, countIn(p, passwordClasses["upper"]), 1)
+		assert.GreaterOrEqual(t, countIn(p, passwordClasses["digits"]), 1)
+		assert.GreaterOrEqual(t, countIn(p, DefaultPasswordSymbols), 1)
+
+		p, err = Password(12, PasswordPolicy{
+			Min:     map[string]int{"digits": 4, "upper": 0},
+			Exclude: "0O1I",
+		})
+		require.NoError(t, err)
+		assert.Len(t, p, 12)
+		assert.GreaterOrEqual(t, countIn(p, passwordClasses["digits"]), 4)
+		assert.Equal(t, 12, countIn(p, "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"), p)
+
+		p, err = Password(6, PasswordPolicy{Min: map[string]int{"symbols": 6}, Symbols: "@@!"})
+		require.NoError(t, err)
+		assert.Equal(t, 6, countIn(p, "@!"), p)
+	}
+
+	p, err := Password(4, PasswordPolicy{Words: true, Separator: " "})
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(p, " "), 4)
+
+	p, err = Password(3, PasswordPolicy{Words: true, WordList: "short"})
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(p, "-"), 3)
+
+	_, err = Password(3, PasswordPolicy{Words: true, WordList: "klingon"})
+	require.ErrorContains(t, err, "unknown word list")
+
+	_, err = Password(0, PasswordPolicy{})
+	require.Error(t, err)
+
+	_, err = Password(3, PasswordPolicy{})
+	require.ErrorContains(t, err, "too short")
+
+	_, err = Password(8, PasswordPolicy{Min: map[string]int{}})
+	require.ErrorContains(t, err, "at least one character class")
+
+	_, err = Password(8, PasswordPolicy{Min: map[string]int{"emoji": 1}})
+	require.ErrorContains(t, err, "unknown character class")
+
+	_, err = Password(8, PasswordPolicy{Min: map[string]int{"digits": -1}})
+	require.Error(t, err)
+
+	_, err = Password(8, PasswordPolicy{Min: map[string]int{"digits": 1}, Exclude: "0123456789"})
+	require.ErrorContains(t, err, "no characters left in the digits class")
+}
@@ -4,6 +4,7 @@ package regexp
 import (
 	"fmt"
 	stdre "regexp"
+	"strings"
 )
 
 // Find -
@@ -24,6 +25,54 @@ func FindAll(expression string, n int, input string) ([]string, error) {
 	return re.FindAllString(input, n), nil
 }
 
+// FindNamed - find the first match of the expression in the input, returning
+// a map of the named capture groups to their matched values. An empty map is
+// returned when there's no match.
+func FindNamed(expression, input string) (map[string]interface{}, error) {
+	re, err := stdre.Compile(expression)
+	if err != nil {
+		return nil, fmt.Errorf("error compiling expression: %w", err)
+	}
+
+	m := re.FindStringSubmatch(input)
+	if m == nil {
+		return map[string]interface{}{}, nil
+	}
+
+	return namedGroups(re, m), nil
+}
+
+// FindAllNamed - find up to n matches of the expression in the input (or all
+// matches when n is negative), returning a map of the named capture groups to
+// their matched values for each match.
+func FindAllNamed(expression string, n int, input string) ([]map[string]interface{}, error) {
+	re, err := stdre.Compile(expression)
+	if err != nil {
+		return nil, fmt.Errorf("error compiling expression: %w", err)
+	}
+
+	matches := re.FindAllStringSubmatch(input, n)
+	out := make([]map[string]interface{}, len(matches))
+	for i, m := range matches {
+		out[i] = namedGroups(re, m)
+	}
+
+	return out, nil
+}
+
+// namedGroups maps the named capture groups in re to their values in the
+// submatch m. Groups which didn't participate in the match are empty.
+func namedGroups(re *stdre.Regexp, m []string) map[string]interface{} {
+	groups := map[string]interface{}{}
+	for i, name := range re.SubexpNames() {
+		if name != "" {
+			groups[name] = m[i]
+		}
+	}
+
+	return groups
+}
+
 // Match -
 func Match(expression, input string) (bool, error) {
 	re, err := stdre.Compile(expression)
@@ -39,6 +88,12 @@ func QuoteMeta(input string) string {
 	return stdre.QuoteMeta(input)
 }
 
+// QuoteReplacement - escape the input so it can be used literally as the
+// replacement in Replace, which otherwise expands `$` references
+func QuoteReplacement(input string) string {
+	return strings.ReplaceAll(input, "$", "$$")
+}
+
 // Replace -
 func Replace(expression, replacement, input string) (string, error) {
 	re, err := stdre.Compile(expression)
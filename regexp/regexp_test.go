@@ -126,3 +126,52 @@ func TestSplit(t *testing.T) {
 func TestQuoteMeta(t *testing.T) {
 	assert.Equal(t, `foo\{\(\\`, QuoteMeta(`foo{(\`))
 }
+
+func TestFindNamed(t *testing.T) {
+	_, err := FindNamed(`[a-`, "")
+	require.Error(t, err)
+
+	m, err := FindNamed(`(?P<key>\w+)=(?P<value>\w*)(?: (?P<comment>#.*))?`, "a=1 b=2")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"key": "a", "value": "1", "comment": ""}, m)
+
+	m, err = FindNamed(`(?P<major>\d+)\.(\d+)`, "v1.2")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"major": "1"}, m)
+
+	m, err = FindNamed(`(?P<major>\d+)`, "none")
+	require.NoError(t, err)
+	assert.Empty(t, m)
+}
+
+func TestFindAllNamed(t *testing.T) {
+	_, err := FindAllNamed(`[a-`, -1, "")
+	require.Error(t, err)
+
+	re := `(?P<key>\w+)=(?P<value>\w*)`
+
+	m, err := FindAllNamed(re, -1, "a=1 b=2 c=")
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{
+		{"key": "a", "value": "1"},
+		{"key": "b", "value": "2"},
+		{"key": "c", "value": ""},
+	}, m)
+
+	m, err = FindAllNamed(re, 1, "a=1 b=2")
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{{"key": "a", "value": "1"}}, m)
+
+	m, err = FindAllNamed(re, -1, "nothing here")
+	require.NoError(t, err)
+	assert.Empty(t, m)
+}
+
+func TestQuoteReplacement(t *testing.T) {
+	assert.Equal(t, "", QuoteReplacement(""))
+	assert.Equal(t, "$$1.00 and $${name}", QuoteReplacement("$1.00 and ${name}"))
+
+	r, err := Replace(`price`, QuoteReplacement("$1.00"), "the price")
+	require.NoError(t, err)
+	assert.Equal(t, "the $1.00", r)
+}
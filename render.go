@@ -7,6 +7,7 @@ import (
 	"io/fs"
 	"net/http"
 	"path"
+	"path/filepath"
 	"slices"
 	"strings"
 	"sync"
@@ -15,8 +16,11 @@ import (
 
 	"github.com/hairyhenderson/go-fsimpl"
 	"github.com/hairyhenderson/go-fsimpl/autofs"
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
 	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
 	"github.com/hairyhenderson/gomplate/v4/internal/funcs"
+	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
+	"github.com/hairyhenderson/gomplate/v4/internal/policy"
 )
 
 // RenderOptions - options for controlling how templates are rendered, and
@@ -48,8 +52,52 @@ type RenderOptions struct {
 
 	// MissingKey controls the behavior during execution if a map is indexed with a key that is not present in the map
 	MissingKey string
+
+	// Strict - when true, rendering fails when a template outputs a missing
+	// (nil) value, instead of printing "<no value>". MissingKey is always
+	// "error" in strict mode.
+	Strict bool
+
+	// MergeFunc - a custom function for merging the documents read by 'merge:'
+	// datasources, replacing the default merge behaviour (and the merge
+	// options given in the datasource URL). See [MergeFunc].
+	MergeFunc MergeFunc
+
+	// FuncAliases - map of alias names to the namespaces or functions they
+	// refer to. Functions in namespaces can be aliased too, like
+	// "strings.ToUpper".
+	FuncAliases map[string]string
+	// AllowFuncs - when set, only these namespaces and top-level functions
+	// are available to templates
+	AllowFuncs []string
+	// DenyFuncs - namespaces and top-level functions that are not available
+	// to templates. Denying a namespace also denies the top-level functions
+	// that call into it (like "getenv" for "env").
+	DenyFuncs []string
+
+	// AllowWrite - when true, templates are allowed to write files with
+	// file.Write. Otherwise file.Write fails.
+	AllowWrite bool
+
+	// Policies - map of Rego policy file names to source. When set, the
+	// policies' deny rules (in the "gomplate" package) are evaluated against
+	// the template context before rendering, and rendering fails if any
+	// messages are produced.
+	Policies map[string]string
 }
 
+// MergeFunc merges the parsed documents read from the datasources listed in
+// a 'merge:' datasource URL, in order of precedence (the left-most first).
+// Documents can be of any type (maps, slices, or other values), including nil.
+// The result is marshalled in the merge datasource's output format.
+//
+// JSON Patch datasources are not given to the function - instead, it's called
+// for the documents between each patch, with the patched result of the
+// documents to their right as the last document.
+//
+// Experimental: subject to breaking changes before the next major release
+type MergeFunc func(docs []interface{}) (interface{}, error)
+
 // optionsFromConfig - translate the internal config struct to a RenderOptions.
 // Does not set the Funcs field.
 func optionsFromConfig(cfg *Config) RenderOptions {
@@ -61,6 +109,11 @@ func optionsFromConfig(cfg *Config) RenderOptions {
 		LDelim:       cfg.LDelim,
 		RDelim:       cfg.RDelim,
 		MissingKey:   cfg.MissingKey,
+		Strict:       cfg.Strict,
+		FuncAliases:  cfg.FuncAliases,
+		AllowFuncs:   cfg.AllowFuncs,
+		DenyFuncs:    cfg.DenyFuncs,
+		AllowWrite:   cfg.AllowWrite,
 	}
 
 	return opts
@@ -73,7 +126,15 @@ type renderer struct {
 	lDelim      string
 	rDelim      string
 	missingKey  string
+	strict      bool
+	mergeFunc   MergeFunc
 	tctxAliases []string
+	policies    map[string]string
+	policyEval  *policy.Evaluator
+	funcAliases map[string]string
+	allowFuncs  []string
+	denyFuncs   []string
+	allowWrite  bool
 }
 
 // Renderer provides gomplate's core template rendering functionality.
@@ -157,6 +218,14 @@ func newRenderer(opts RenderOptions) *renderer {
 		lDelim:      opts.LDelim,
 		rDelim:      opts.RDelim,
 		missingKey:  missingKey,
+		strict:      opts.Strict,
+		mergeFunc:   opts.MergeFunc,
+		policies:    opts.Policies,
+		policyEval:  policy.NewEvaluator(),
+		funcAliases: opts.FuncAliases,
+		allowFuncs:  opts.AllowFuncs,
+		denyFuncs:   opts.DenyFuncs,
+		allowWrite:  opts.AllowWrite,
 	}
 }
 
@@ -170,6 +239,14 @@ type Template struct {
 	Name string
 	// Text is the template text
 	Text string
+	// LDelim and RDelim - when set, override the renderer's delimiters for
+	// this template (but not for nested templates)
+	LDelim string
+	RDelim string
+
+	// stdout - the standard output, when Writer writes to it (through a
+	// wrapper), so that color can be enabled for terminals
+	stdout io.Writer
 }
 
 func (r *renderer) RenderTemplates(ctx context.Context, templates []Template) error {
@@ -177,6 +254,14 @@ func (r *renderer) RenderTemplates(ctx context.Context, templates []Template) er
 		ctx = datafs.ContextWithFSProvider(ctx, DefaultFSProvider)
 	}
 
+	if r.mergeFunc != nil {
+		ctx = datafs.ContextWithMergeFunc(ctx, datafs.MergeFunc(r.mergeFunc))
+	}
+
+	if r.allowWrite {
+		ctx = config.WithWriteAllowed(ctx)
+	}
+
 	// configure the template context with the refreshed Data value
 	// only done here because the data context may have changed
 	tmplctx, err := createTmplContext(ctx, r.tctxAliases, r.sr)
@@ -184,6 +269,17 @@ func (r *renderer) RenderTemplates(ctx context.Context, templates []Template) er
 		return err
 	}
 
+	err = r.checkPolicies(ctx, tmplctx)
+	if err != nil {
+		return err
+	}
+
+	// the environment is also available as .Env, which mustn't be left
+	// available when the env functions aren't
+	if funcDenied("env", r.allowFuncs, r.denyFuncs) {
+		tmplctx = withoutEnv(tmplctx)
+	}
+
 	return r.renderTemplatesWithData(ctx, templates, tmplctx)
 }
 
@@ -192,8 +288,13 @@ func (r *renderer) renderTemplatesWithData(ctx context.Context, templates []Temp
 	// only done here to ensure the context is properly set in func namespaces
 	f := CreateFuncs(ctx)
 
-	// add datasource funcs here because they need to share the source reader
-	addToMap(f, funcs.CreateDataSourceFuncs(ctx, r.sr))
+	// add datasource (and data, i18n, and archive) funcs here because they
+	// need to share the source reader
+	dsFuncs := funcs.CreateDataSourceFuncs(ctx, r.sr)
+	addToMap(f, dsFuncs)
+	addToMap(f, funcs.CreateDataFuncsWithReader(ctx, r.sr))
+	addToMap(f, funcs.CreateI18nFuncs(ctx, r.sr))
+	addToMap(f, funcs.CreateArchiveFuncs(ctx, r.sr))
 
 	// add user-defined funcs last so they override the built-in funcs
 	addToMap(f, r.funcs)
@@ -202,7 +303,9 @@ func (r *renderer) renderTemplatesWithData(ctx context.Context, templates []Temp
 	start := time.Now()
 	defer func() { Metrics.TotalRenderDuration = time.Since(start) }()
 	for _, template := range templates {
-		err := r.renderTemplate(ctx, template, f, tmplctx)
+		err := r.renderTemplate(ctx, template, f, tmplctx, func() error {
+			return funcs.CloseDataSourceStreams(dsFuncs)
+		})
 		if err != nil {
 			return fmt.Errorf("renderTemplate: %w", err)
 		}
@@ -210,20 +313,53 @@ func (r *renderer) renderTemplatesWithData(ctx context.Context, templates []Temp
 	return nil
 }
 
-func (r *renderer) renderTemplate(ctx context.Context, template Template, f template.FuncMap, tmplctx interface{}) error {
+// renderTemplate - renders a single template. closeStreams is called once the
+// template has been executed, to stop any datasource streams it opened.
+func (r *renderer) renderTemplate(ctx context.Context, template Template, f template.FuncMap, tmplctx interface{}, closeStreams func() error) (err error) {
 	if template.Writer != nil {
 		if wr, ok := template.Writer.(io.Closer); ok {
-			defer wr.Close()
+			defer func() {
+				// with atomic writes, output which failed to render is
+				// discarded - otherwise the partial output is kept, as usual
+				atomic := writeOptionsFromContext(ctx).atomic
+				if a, ok := wr.(iohelpers.Aborter); ok && atomic && err != nil {
+					_ = a.Abort()
+				}
+
+				if cerr := wr.Close(); cerr != nil && err == nil {
+					err = fmt.Errorf("failed to write output for %s: %w", template.Name, cerr)
+				}
+			}()
 		}
 	}
 
 	tstart := time.Now()
-	tmpl, err := r.parseTemplate(ctx, template.Name, template.Text, f, tmplctx)
+	lDelim, rDelim := r.lDelim, r.rDelim
+	if template.LDelim != "" {
+		lDelim = template.LDelim
+	}
+	if template.RDelim != "" {
+		rDelim = template.RDelim
+	}
+
+	out := template.Writer
+	if template.stdout != nil {
+		out = template.stdout
+	}
+
+	tmpl, err := r.parseTemplate(ctx, template.Name, template.Text, lDelim, rDelim, f, tmplctx, out)
 	if err != nil {
 		return fmt.Errorf("parse template %s: %w", template.Name, err)
 	}
 
 	err = tmpl.Execute(template.Writer, tmplctx)
+
+	// streams which failed part-way through would otherwise leave the output
+	// silently truncated
+	if serr := closeStreams(); err == nil {
+		err = serr
+	}
+
 	Metrics.RenderDuration[template.Name] = time.Since(tstart)
 	if err != nil {
 		Metrics.Errors++
@@ -241,11 +377,11 @@ func (r *renderer) Render(ctx context.Context, name, text string, wr io.Writer)
 }
 
 // parseTemplate - parses text as a Go template with the given name and options
-func (r *renderer) parseTemplate(ctx context.Context, name, text string, funcs template.FuncMap, tmplctx interface{}) (tmpl *template.Template, err error) {
+func (r *renderer) parseTemplate(ctx context.Context, name, text, lDelim, rDelim string, f template.FuncMap, tmplctx interface{}, out io.Writer) (tmpl *template.Template, err error) {
 	tmpl = template.New(name)
 
 	missingKey := r.missingKey
-	if missingKey == "" {
+	if missingKey == "" || r.strict {
 		missingKey = "error"
 	}
 
@@ -256,22 +392,53 @@ func (r *renderer) parseTemplate(ctx context.Context, name, text string, funcs t
 
 	tmpl.Option("missingkey=" + missingKey)
 
-	funcMap := copyFuncMap(funcs)
+	funcMap := copyFuncMap(f)
+
+	// the "tmpl" funcs get added here because they need access to the root
+	// template and context, and the source reader
+	addTmplFuncs(ctx, funcMap, tmpl, tmplctx, name, func(alias string) ([]byte, error) {
+		_, b, err := r.sr.ReadSource(ctx, alias)
+		return b, err
+	})
+
+	// gitinfo functions default to the repository containing the template
+	funcs.SetGitInfoDir(funcMap, filepath.Dir(name))
+
+	// color is only output to terminals, unless forced
+	funcs.SetColorOutput(funcMap, out)
+
+	err = applyFuncRules(ctx, funcMap, r.funcAliases, r.allowFuncs, r.denyFuncs)
+	if err != nil {
+		return nil, err
+	}
+
+	// added after the rules are applied, since it's never called directly
+	if r.strict {
+		funcMap[strictFuncName] = strictValue
+	}
 
-	// the "tmpl" funcs get added here because they need access to the root template and context
-	addTmplFuncs(funcMap, tmpl, tmplctx, name)
 	tmpl.Funcs(funcMap)
-	tmpl.Delims(r.lDelim, r.rDelim)
+	tmpl.Delims(lDelim, rDelim)
 	_, err = tmpl.Parse(text)
 	if err != nil {
 		return nil, err
 	}
 
+	// nested templates (and templates parsed while rendering) always use the
+	// renderer's delimiters
+	tmpl.Delims(r.lDelim, r.rDelim)
+
 	err = r.parseNestedTemplates(ctx, tmpl)
 	if err != nil {
 		return nil, fmt.Errorf("parse nested templates: %w", err)
 	}
 
+	if r.strict {
+		for _, t := range tmpl.Templates() {
+			addStrictChecks(t.Tree)
+		}
+	}
+
 	return tmpl, nil
 }
 
@@ -386,6 +553,9 @@ var DefaultFSProvider = sync.OnceValue(
 		fsp.Add(datafs.EnvFS)
 		fsp.Add(datafs.StdinFS)
 		fsp.Add(datafs.MergeFS)
+		fsp.Add(datafs.FeatureFlagFS)
+		fsp.Add(datafs.GeoIPFS)
+		fsp.Add(datafs.JWTEnvFS)
 
 		return fsp
 	})()
@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"testing/fstest"
 
 	"github.com/hairyhenderson/go-fsimpl"
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
 	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -74,12 +76,313 @@ func TestRenderTemplate(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "HELLO", out.String())
 
+	// a template loaded from a datasource at render time
+	lu, _ := url.Parse("mem:///lib.tmpl")
+	fsys["lib.tmpl"] = &fstest.MapFile{Data: []byte(
+		`{{ define "shout" }}{{ . | toUpper }}!{{ end }}hi {{ . }}`)}
+
+	tr = NewRenderer(RenderOptions{
+		Datasources: map[string]DataSource{
+			"lib": {URL: lu},
+		},
+	})
+	out = &bytes.Buffer{}
+	err = tr.Render(ctx, "test", `{{ tmpl.ExecFromDatasource "lib" "there" }}, {{ tmpl.Exec "shout" "hey" }}`, out)
+	require.NoError(t, err)
+	assert.Equal(t, "hi there, HEY!", out.String())
+
+	// a value validated against a JSON Schema read from a datasource
+	su, _ := url.Parse("mem:///schema.json")
+	fsys["schema.json"] = &fstest.MapFile{Data: []byte(`{"type": "integer", "minimum": 1}`)}
+
+	tr = NewRenderer(RenderOptions{
+		Datasources: map[string]DataSource{
+			"schema": {URL: su},
+		},
+	})
+	out = &bytes.Buffer{}
+	err = tr.Render(ctx, "test", `{{ 42 | data.ValidateJSONSchema "schema" }}`, out)
+	require.NoError(t, err)
+	assert.Equal(t, "42", out.String())
+
+	err = tr.Render(ctx, "test", `{{ 0 | data.ValidateJSONSchema "schema" }}`, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "JSON Schema validation failed")
+
 	// errors contain the template name
 	tr = NewRenderer(RenderOptions{})
 	err = tr.Render(ctx, "foo", `{{ bogus }}`, &bytes.Buffer{})
 	assert.ErrorContains(t, err, "template: foo:")
 }
 
+func TestRenderTemplate_MergeFunc(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.json": {Data: []byte(`{"apples": 2, "pears": 1}`)},
+		"b.json": {Data: []byte(`{"apples": 3}`)},
+	}
+	fsp := fsimpl.NewMux()
+	fsp.Add(datafs.MergeFS)
+	fsp.Add(datafs.WrappedFSProvider(fsys, "mem", ""))
+	ctx := datafs.ContextWithFSProvider(context.Background(), fsp)
+
+	mu, _ := url.Parse("merge:a|b")
+	au, _ := url.Parse("mem:///a.json")
+	bu, _ := url.Parse("mem:///b.json")
+
+	// sum the quantities, rather than overriding them
+	sum := func(docs []interface{}) (interface{}, error) {
+		out := map[string]interface{}{}
+		for _, doc := range docs {
+			for k, v := range doc.(map[string]interface{}) {
+				n, _ := out[k].(int)
+				out[k] = n + v.(int)
+			}
+		}
+
+		return out, nil
+	}
+
+	tr := NewRenderer(RenderOptions{
+		Datasources: map[string]DataSource{
+			"merged": {URL: mu},
+			"a":      {URL: au},
+			"b":      {URL: bu},
+		},
+		MergeFunc: sum,
+	})
+	out := &bytes.Buffer{}
+	err := tr.Render(ctx, "test", `{{ $m := ds "merged" }}{{ $m.apples }} {{ $m.pears }}`, out)
+	require.NoError(t, err)
+	assert.Equal(t, "5 1", out.String())
+
+	// errors are returned
+	tr = NewRenderer(RenderOptions{
+		Datasources: map[string]DataSource{
+			"merged": {URL: mu},
+			"a":      {URL: au},
+			"b":      {URL: bu},
+		},
+		MergeFunc: func([]interface{}) (interface{}, error) {
+			return nil, fmt.Errorf("no merging today")
+		},
+	})
+	err = tr.Render(ctx, "test", `{{ ds "merged" }}`, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "no merging today")
+}
+
+func TestRenderTemplate_MergeContext(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.json": {Data: []byte(`{"apples": 2}`)},
+	}
+	fsp := fsimpl.NewMux()
+	fsp.Add(datafs.WrappedFSProvider(fsys, "mem", ""))
+	ctx := datafs.ContextWithFSProvider(context.Background(), fsp)
+
+	au, _ := url.Parse("mem:///a.json")
+
+	// the template context is a named map type, and must still be mergeable
+	tr := NewRenderer(RenderOptions{
+		Context: map[string]DataSource{"a": {URL: au}},
+	})
+	out := &bytes.Buffer{}
+	err := tr.Render(ctx, "test", `{{ $m := coll.Merge . (dict "x" 1) }}{{ $m.a.apples }} {{ $m.x }}`, out)
+	require.NoError(t, err)
+	assert.Equal(t, "2 1", out.String())
+}
+
+func TestRenderTemplate_DatasourceStream(t *testing.T) {
+	fsys := fstest.MapFS{
+		"ok.ndjson":  {Data: []byte("{\"n\": 1}\n{\"n\": 2}\n{\"n\": 3}\n")},
+		"bad.ndjson": {Data: []byte("{\"n\": 1}\n{\"n\": 2}\n{\"n\": \n")},
+	}
+	fsp := fsimpl.NewMux()
+	fsp.Add(datafs.WrappedFSProvider(fsys, "mem", ""))
+	ctx := datafs.ContextWithFSProvider(context.Background(), fsp)
+
+	oku, _ := url.Parse("mem:///ok.ndjson")
+	badu, _ := url.Parse("mem:///bad.ndjson")
+
+	tr := NewRenderer(RenderOptions{
+		Datasources: map[string]DataSource{
+			"ok":  {URL: oku},
+			"bad": {URL: badu},
+		},
+	})
+
+	out := &bytes.Buffer{}
+	err := tr.Render(ctx, "test", `{{ range datasourceStream "ok" }}{{ .n }} {{ end }}`, out)
+	require.NoError(t, err)
+	assert.Equal(t, "1 2 3 ", out.String())
+
+	// stopping early is fine
+	out.Reset()
+	err = tr.Render(ctx, "test", `{{ range datasourceStream "ok" }}{{ .n }}{{ break }}{{ end }}`, out)
+	require.NoError(t, err)
+	assert.Equal(t, "1", out.String())
+
+	// errors part-way through the stream fail the render
+	out.Reset()
+	err = tr.Render(ctx, "test", `{{ range datasourceStream "bad" }}{{ .n }} {{ end }}`, out)
+	assert.ErrorContains(t, err, `datasourceStream "bad"`)
+	assert.Equal(t, "1 2 ", out.String())
+}
+
+func TestRenderTemplate_Policies(t *testing.T) {
+	fsys := fstest.MapFS{
+		"config.json": {Data: []byte(`{"replicas": 1, "image": "app:latest"}`)},
+		"good.json":   {Data: []byte(`{"replicas": 3, "image": "app:1.0"}`)},
+	}
+	fsp := fsimpl.NewMux()
+	fsp.Add(datafs.WrappedFSProvider(fsys, "mem", ""))
+	ctx := datafs.ContextWithFSProvider(context.Background(), fsp)
+
+	u, _ := url.Parse("mem:///config.json")
+
+	policies := map[string]string{
+		"deploy.rego": `package gomplate
+
+deny contains msg if {
+	input.config.replicas < 2
+	msg := "at least 2 replicas are required"
+}
+
+deny contains msg if {
+	endswith(input.config.image, ":latest")
+	msg := "images must not use the latest tag"
+}
+`,
+	}
+
+	tr := NewRenderer(RenderOptions{
+		Context:  map[string]DataSource{"config": {URL: u}},
+		Policies: policies,
+	})
+	out := &bytes.Buffer{}
+	err := tr.Render(ctx, "test", `{{ .config.replicas }}`, out)
+	require.EqualError(t, err, "policy check failed:\n"+
+		"  - at least 2 replicas are required\n"+
+		"  - images must not use the latest tag")
+	assert.Empty(t, out.String())
+
+	gu, _ := url.Parse("mem:///good.json")
+	tr = NewRenderer(RenderOptions{
+		Context:  map[string]DataSource{"config": {URL: gu}},
+		Policies: policies,
+	})
+	err = tr.Render(ctx, "test", `{{ .config.replicas }}`, out)
+	require.NoError(t, err)
+	assert.Equal(t, "3", out.String())
+
+	// the root context is given as the input directly
+	tr = NewRenderer(RenderOptions{
+		Context:  map[string]DataSource{".": {URL: gu}},
+		Policies: map[string]string{"root.rego": "package gomplate\n\ndeny contains \"no\" if input.replicas > 2\n"},
+	})
+	err = tr.Render(ctx, "test", `{{ .replicas }}`, &bytes.Buffer{})
+	require.EqualError(t, err, "policy check failed:\n  - no")
+}
+
+func TestRenderTemplate_Sprig(t *testing.T) {
+	fsys := fstest.MapFS{
+		"values.yaml": {Data: []byte("name: My_App\nlabels:\n  tier: web\n")},
+	}
+	fsp := fsimpl.NewMux()
+	fsp.Add(datafs.WrappedFSProvider(fsys, "mem", ""))
+	ctx := datafs.ContextWithFSProvider(context.Background(), fsp)
+
+	u, _ := url.Parse("mem:///values.yaml")
+
+	tpl := `{{- define "fullname" }}{{ .Values.name | lower | replace "_" "-" | trunc 63 }}{{ end -}}
+name: {{ include "fullname" . }}
+labels:
+  {{- toYaml .Values.labels | nindent 2 }}
+list: {{ list 1 2 3 | join "," }} {{ has 2 (list 1 2) }}`
+
+	tr := NewRenderer(RenderOptions{
+		Context: map[string]DataSource{"Values": {URL: u}},
+	})
+
+	// the Sprig functions are only available when enabled
+	err := tr.Render(ctx, "test", tpl, &bytes.Buffer{})
+	require.Error(t, err)
+
+	out := &bytes.Buffer{}
+	err = tr.Render(config.WithSprig(ctx), "test", tpl, out)
+	require.NoError(t, err)
+	assert.Equal(t, "name: my-app\nlabels:\n  tier: web\nlist: 1,2,3 true", out.String())
+}
+
+func TestRenderTemplate_FuncRules(t *testing.T) {
+	ctx := context.Background()
+
+	tr := NewRenderer(RenderOptions{
+		DenyFuncs:   []string{"env", "getenv", "file"},
+		FuncAliases: map[string]string{"up": "strings.ToUpper"},
+	})
+
+	out := &bytes.Buffer{}
+	err := tr.Render(ctx, "test", `{{ up "hello" }}`, out)
+	require.NoError(t, err)
+	assert.Equal(t, "HELLO", out.String())
+
+	err = tr.Render(ctx, "test", `{{ env.Getenv "HOME" }}`, &bytes.Buffer{})
+	require.ErrorContains(t, err, `function "env" not defined`)
+
+	// nested templates use the same functions
+	err = tr.Render(ctx, "test", `{{ tmpl.Inline "{{ file.Read \"foo\" }}" }}`, &bytes.Buffer{})
+	require.ErrorContains(t, err, `function "file" not defined`)
+
+	// aliases are denied along with their namespace
+	tr = NewRenderer(RenderOptions{DenyFuncs: []string{"env"}})
+	err = tr.Render(ctx, "test", `{{ getenv "HOME" }}`, &bytes.Buffer{})
+	require.ErrorContains(t, err, `function "getenv" not defined`)
+
+	// so is the environment in the context
+	err = tr.Render(ctx, "test", `{{ .Env.HOME }}`, &bytes.Buffer{})
+	require.ErrorContains(t, err, `map has no entry for key "Env"`)
+
+	tr = NewRenderer(RenderOptions{AllowFuncs: []string{"strings"}})
+	err = tr.Render(ctx, "test", `{{ .Env.HOME }}`, &bytes.Buffer{})
+	require.ErrorContains(t, err, `map has no entry for key "Env"`)
+
+	t.Setenv("FOO", "bar")
+	out.Reset()
+	tr = NewRenderer(RenderOptions{DenyFuncs: []string{"file"}})
+	err = tr.Render(ctx, "test", `{{ .Env.FOO }}`, out)
+	require.NoError(t, err)
+	assert.Equal(t, "bar", out.String())
+
+	tr = NewRenderer(RenderOptions{AllowFuncs: []string{"strings"}})
+	err = tr.Render(ctx, "test", `{{ strings.ToUpper "a" }}{{ toUpper "b" }}`, &bytes.Buffer{})
+	require.ErrorContains(t, err, `function "toUpper" not defined`)
+}
+
+func TestRenderTemplate_AllowWrite(t *testing.T) {
+	ctx := context.Background()
+	// files can only be written in the working directory
+	wd, _ := os.Getwd()
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+	})
+	dir := t.TempDir()
+	_ = os.Chdir(dir)
+
+	fname := filepath.Join(dir, "out.txt")
+	in := `{{ "hello" | file.Write "out.txt" }}`
+
+	tr := NewRenderer(RenderOptions{})
+	err := tr.Render(ctx, "test", in, &bytes.Buffer{})
+	require.ErrorContains(t, err, "file.Write is disabled")
+	assert.NoFileExists(t, fname)
+
+	tr = NewRenderer(RenderOptions{AllowWrite: true})
+	err = tr.Render(ctx, "test", in, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	b, err := os.ReadFile(fname)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(b))
+}
+
 //// examples
 
 func ExampleRenderer() {
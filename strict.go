@@ -0,0 +1,89 @@
+package gomplate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"text/template/parse"
+)
+
+// strictFuncName is the name of the function which is added to the end of
+// each action's pipeline in strict mode
+const strictFuncName = "_gomplate_strict"
+
+// strictValue fails when v is nil, which would otherwise be printed as
+// "<no value>". expr is the original pipeline, for the error message.
+func strictValue(expr string, v interface{}) (reflect.Value, error) {
+	if v == nil {
+		return reflect.Value{}, fmt.Errorf("%s has no value (strict mode)", expr)
+	}
+
+	return reflect.ValueOf(v), nil
+}
+
+// addStrictChecks rewrites the parse tree so that every action which
+// produces output pipes its value through strictValue. Actions which only
+// declare or assign variables are left alone, since they don't output
+// anything.
+func addStrictChecks(tree *parse.Tree) {
+	if tree == nil || tree.Root == nil {
+		return
+	}
+
+	addStrictChecksToNode(tree, tree.Root)
+}
+
+func addStrictChecksToNode(tree *parse.Tree, node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+
+		for _, c := range n.Nodes {
+			addStrictChecksToNode(tree, c)
+		}
+	case *parse.ActionNode:
+		if len(n.Pipe.Decl) > 0 || hasStrictCheck(n.Pipe) {
+			return
+		}
+
+		expr := n.Pipe.String()
+		pos := n.Pipe.Position()
+
+		n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{
+			NodeType: parse.NodeCommand,
+			Pos:      pos,
+			Args: []parse.Node{
+				parse.NewIdentifier(strictFuncName).SetTree(tree).SetPos(pos),
+				&parse.StringNode{NodeType: parse.NodeString, Pos: pos, Quoted: strconv.Quote(expr), Text: expr},
+			},
+		})
+	case *parse.IfNode:
+		addStrictChecksToNode(tree, n.List)
+		addStrictChecksToNode(tree, n.ElseList)
+	case *parse.RangeNode:
+		addStrictChecksToNode(tree, n.List)
+		addStrictChecksToNode(tree, n.ElseList)
+	case *parse.WithNode:
+		addStrictChecksToNode(tree, n.List)
+		addStrictChecksToNode(tree, n.ElseList)
+	}
+}
+
+// hasStrictCheck - whether the pipeline has already been rewritten, since
+// templates can be shared between template sets
+func hasStrictCheck(pipe *parse.PipeNode) bool {
+	if len(pipe.Cmds) == 0 {
+		return false
+	}
+
+	last := pipe.Cmds[len(pipe.Cmds)-1]
+	if len(last.Args) == 0 {
+		return false
+	}
+
+	id, ok := last.Args[0].(*parse.IdentifierNode)
+
+	return ok && id.Ident == strictFuncName
+}
@@ -0,0 +1,57 @@
+package gomplate
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrict(t *testing.T) {
+	t.Setenv("STRICT_TEST_VAR", "foo")
+
+	tr := newRenderer(RenderOptions{
+		Funcs:      CreateFuncs(context.Background()),
+		MissingKey: "zero",
+		Strict:     true,
+	})
+
+	// values which aren't nil are rendered as usual, including falsy ones
+	assert.Equal(t, "foo false 0  []",
+		testTemplate(t, tr, `{{ .Env.STRICT_TEST_VAR }} {{ false }} {{ 0 }} {{ "" }} {{ coll.Slice }}`))
+
+	// declarations and control structures don't output anything
+	assert.Equal(t, "ok",
+		testTemplate(t, tr, `{{ $x := index (dict) "a" }}{{ if not $x }}ok{{ end }}`))
+
+	testdata := []struct {
+		tmpl, errContains string
+	}{
+		{`{{ .Env.STRICT_TEST_UNSET }}`, `map has no entry for key "STRICT_TEST_UNSET"`},
+		{`{{ .missing }}`, `map has no entry for key "missing"`},
+		{`{{ $d := json "{\"a\": null}" }}{{ $d.a }}`, `$d.a has no value`},
+		{"line one\n{{ index (dict) \"a\" }}", `testtemplate:2:3`},
+		{`{{ define "T" }}{{ index (dict) "a" }}{{ end }}{{ template "T" }}`, `has no value`},
+		{`{{ range (coll.Slice 1) }}{{ index (dict) "a" }}{{ end }}`, `has no value`},
+		{`{{ with 1 }}{{ else }}{{ end }}{{ if true }}{{ index (dict) "a" }}{{ end }}`, `has no value`},
+	}
+
+	for _, d := range testdata {
+		t.Run(d.tmpl, func(t *testing.T) {
+			err := tr.Render(context.Background(), "testtemplate", d.tmpl, &bytes.Buffer{})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), d.errContains)
+		})
+	}
+}
+
+func TestStrictValue(t *testing.T) {
+	v, err := strictValue(".a", "foo")
+	require.NoError(t, err)
+	assert.Equal(t, "foo", v.Interface())
+
+	_, err = strictValue(".a", nil)
+	require.EqualError(t, err, ".a has no value (strict mode)")
+}
@@ -0,0 +1,78 @@
+package strings
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DotCase - convert the string to dot.case, like "foo.bar.baz"
+func DotCase(in string) string {
+	return strings.Join(lowerWords(in), ".")
+}
+
+// FlatCase - convert the string to flatcase, like "foobarbaz"
+func FlatCase(in string) string {
+	return strings.Join(lowerWords(in), "")
+}
+
+// TrainCase - convert the string to Train-Case, like "Foo-Bar-Baz"
+func TrainCase(in string) string {
+	words := splitWords(in)
+	for i, w := range words {
+		r := []rune(strings.ToLower(w))
+		r[0] = unicode.ToTitle(r[0])
+		words[i] = string(r)
+	}
+
+	return strings.Join(words, "-")
+}
+
+func lowerWords(in string) []string {
+	words := splitWords(in)
+	for i, w := range words {
+		words[i] = strings.ToLower(w)
+	}
+
+	return words
+}
+
+// splitWords splits the string into words, separated by any characters other
+// than letters and numbers, and by changes from lower- to upper-case (so
+// camelCase and PascalCase strings are split too). Acronyms are kept
+// together, so "HTTPServer" is split into "HTTP" and "Server".
+func splitWords(in string) []string {
+	words := []string{}
+	r := []rune(in)
+	start := -1
+
+	for i, c := range r {
+		if !unicode.IsLetter(c) && !unicode.IsNumber(c) {
+			if start >= 0 {
+				words = append(words, string(r[start:i]))
+				start = -1
+			}
+
+			continue
+		}
+
+		if start >= 0 && unicode.IsUpper(c) {
+			prev := r[i-1]
+			nextLower := i+1 < len(r) && unicode.IsLower(r[i+1])
+
+			if unicode.IsLower(prev) || unicode.IsNumber(prev) || (unicode.IsUpper(prev) && nextLower) {
+				words = append(words, string(r[start:i]))
+				start = i
+			}
+		}
+
+		if start < 0 {
+			start = i
+		}
+	}
+
+	if start >= 0 {
+		words = append(words, string(r[start:]))
+	}
+
+	return words
+}
@@ -0,0 +1,28 @@
+package strings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoreCaseFuncs(t *testing.T) {
+	t.Parallel()
+
+	testdata := []struct{ in, dot, train, flat string }{
+		{"  Foo bar ", "foo.bar", "Foo-Bar", "foobar"},
+		{"Hello, World!", "hello.world", "Hello-World", "helloworld"},
+		{"fooBarBaz", "foo.bar.baz", "Foo-Bar-Baz", "foobarbaz"},
+		{"HTTPServer_port", "http.server.port", "Http-Server-Port", "httpserverport"},
+		{"version2Beta", "version2.beta", "Version2-Beta", "version2beta"},
+		{"grüne | Straße", "grüne.straße", "Grüne-Straße", "grünestraße"},
+		{"already-kebab-case", "already.kebab.case", "Already-Kebab-Case", "alreadykebabcase"},
+		{"", "", "", ""},
+	}
+
+	for _, d := range testdata {
+		assert.Equal(t, d.dot, DotCase(d.in), d.in)
+		assert.Equal(t, d.train, TrainCase(d.in), d.in)
+		assert.Equal(t, d.flat, FlatCase(d.in), d.in)
+	}
+}
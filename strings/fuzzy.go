@@ -0,0 +1,46 @@
+package strings
+
+import "unicode/utf8"
+
+// Levenshtein - return the Levenshtein (edit) distance between two strings:
+// the number of single-character insertions, deletions, or substitutions
+// needed to change one into the other. Characters are compared as runes, so
+// multi-byte characters count as one.
+func Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	// only the previous row of the matrix is needed
+	row := make([]int, len(rb)+1)
+	for j := range row {
+		row[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		prev := row[0]
+		row[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			cur := min(row[j]+1, row[j-1]+1, prev+cost)
+			prev, row[j] = row[j], cur
+		}
+	}
+
+	return row[len(rb)]
+}
+
+// Similarity - return how similar two strings are, from 0 (completely
+// different) to 1 (identical), based on their Levenshtein distance relative to
+// the length of the longer string.
+func Similarity(a, b string) float64 {
+	n := max(utf8.RuneCountInString(a), utf8.RuneCountInString(b))
+	if n == 0 {
+		return 1
+	}
+
+	return 1 - float64(Levenshtein(a, b))/float64(n)
+}
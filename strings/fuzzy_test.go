@@ -0,0 +1,42 @@
+package strings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevenshtein(t *testing.T) {
+	t.Parallel()
+
+	testdata := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"abc", "abc", 0},
+		{"kitten", "sitting", 3},
+		{"flaw", "lawn", 2},
+		{"gumbo", "gambol", 2},
+		{"café", "cafe", 1},
+		{"日本語", "日本", 1},
+	}
+
+	for _, d := range testdata {
+		assert.Equal(t, d.expected, Levenshtein(d.a, d.b), "%q vs %q", d.a, d.b)
+		assert.Equal(t, d.expected, Levenshtein(d.b, d.a), "%q vs %q", d.b, d.a)
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	t.Parallel()
+
+	assert.InDelta(t, 1.0, Similarity("", ""), 0.0001)
+	assert.InDelta(t, 1.0, Similarity("abc", "abc"), 0.0001)
+	assert.InDelta(t, 0.0, Similarity("abc", "xyz"), 0.0001)
+	assert.InDelta(t, 0.0, Similarity("", "xyz"), 0.0001)
+	assert.InDelta(t, 0.5714, Similarity("kitten", "sitting"), 0.0001)
+	assert.InDelta(t, 0.75, Similarity("café", "cafe"), 0.0001)
+}
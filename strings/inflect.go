@@ -0,0 +1,35 @@
+package strings
+
+import (
+	"sync"
+
+	"github.com/gertd/go-pluralize"
+)
+
+// pluralizer is created on first use, since it compiles a large number of
+// regular expressions
+//
+//nolint:gochecknoglobals
+var pluralizer = sync.OnceValue(pluralize.NewClient)
+
+// Pluralize - return the plural form of an English word, like "boxes" for
+// "box", preserving the word's case.
+func Pluralize(word string) string {
+	return pluralizer().Plural(word)
+}
+
+// PluralizeCount - return the singular form of an English word if count is 1,
+// or the plural form otherwise.
+func PluralizeCount(count int, word string) string {
+	if count == 1 {
+		return Singularize(word)
+	}
+
+	return Pluralize(word)
+}
+
+// Singularize - return the singular form of an English word, like "box" for
+// "boxes", preserving the word's case.
+func Singularize(word string) string {
+	return pluralizer().Singular(word)
+}
@@ -0,0 +1,41 @@
+package strings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluralize(t *testing.T) {
+	t.Parallel()
+
+	testdata := []struct {
+		singular, plural string
+	}{
+		{"box", "boxes"},
+		{"person", "people"},
+		{"index", "indices"},
+		{"status", "statuses"},
+		{"sheep", "sheep"},
+		{"Category", "Categories"},
+		{"CHILD", "CHILDREN"},
+	}
+
+	for _, d := range testdata {
+		assert.Equal(t, d.plural, Pluralize(d.singular))
+		assert.Equal(t, d.singular, Singularize(d.plural))
+	}
+
+	assert.Equal(t, "boxes", Pluralize("boxes"))
+	assert.Equal(t, "box", Singularize("box"))
+}
+
+func TestPluralizeCount(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "boxes", PluralizeCount(0, "box"))
+	assert.Equal(t, "box", PluralizeCount(1, "box"))
+	assert.Equal(t, "box", PluralizeCount(1, "boxes"))
+	assert.Equal(t, "boxes", PluralizeCount(2, "box"))
+	assert.Equal(t, "boxes", PluralizeCount(-1, "box"))
+}
@@ -0,0 +1,56 @@
+package strings
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+)
+
+// MarkdownOpts defines the options to apply to the MarkdownToHTML function
+type MarkdownOpts struct {
+	// Unsafe allows raw HTML and potentially dangerous links (such as
+	// javascript: URLs) to be rendered. By default these are omitted.
+	Unsafe bool
+
+	// GFM enables the GitHub Flavored Markdown extensions: tables,
+	// strikethrough, autolinks, and task lists
+	GFM bool
+
+	// HardWraps renders newlines within paragraphs as <br> elements
+	HardWraps bool
+}
+
+// MarkdownToHTML - render CommonMark-compliant Markdown as HTML
+func MarkdownToHTML(in string, opts MarkdownOpts) (string, error) {
+	var exts []goldmark.Extender
+	if opts.GFM {
+		exts = append(exts, extension.GFM)
+	}
+
+	var rOpts []goldmark.Option
+	if opts.Unsafe {
+		rOpts = append(rOpts, goldmark.WithRendererOptions(html.WithUnsafe()))
+	}
+
+	if opts.HardWraps {
+		rOpts = append(rOpts, goldmark.WithRendererOptions(html.WithHardWraps()))
+	}
+
+	md := goldmark.New(append(rOpts,
+		goldmark.WithExtensions(exts...),
+		goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+	)...)
+
+	buf := &bytes.Buffer{}
+
+	err := md.Convert([]byte(in), buf)
+	if err != nil {
+		return "", fmt.Errorf("failed to render Markdown: %w", err)
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,67 @@
+package strings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdownToHTML(t *testing.T) {
+	t.Parallel()
+
+	testdata := []struct {
+		in       string
+		expected string
+		opts     MarkdownOpts
+	}{
+		{in: "", expected: ""},
+		{in: "# Hello", expected: "<h1 id=\"hello\">Hello</h1>\n"},
+		{
+			in:       "some *emphasis* and `code`",
+			expected: "<p>some <em>emphasis</em> and <code>code</code></p>\n",
+		},
+		{
+			in:       "- one\n- two\n",
+			expected: "<ul>\n<li>one</li>\n<li>two</li>\n</ul>\n",
+		},
+		{
+			in:       "hi <script>alert(1)</script>",
+			expected: "<p>hi <!-- raw HTML omitted -->alert(1)<!-- raw HTML omitted --></p>\n",
+		},
+		{
+			in:       "[click](javascript:alert(1))",
+			expected: "<p><a href=\"\">click</a></p>\n",
+		},
+		{
+			in:       "hi <b>there</b>",
+			expected: "<p>hi <b>there</b></p>\n",
+			opts:     MarkdownOpts{Unsafe: true},
+		},
+		{
+			in:       "~~old~~ new",
+			expected: "<p>~~old~~ new</p>\n",
+		},
+		{
+			in:       "~~old~~ new",
+			expected: "<p><del>old</del> new</p>\n",
+			opts:     MarkdownOpts{GFM: true},
+		},
+		{
+			in:       "| a | b |\n|---|---|\n| 1 | 2 |",
+			expected: "<table>\n<thead>\n<tr>\n<th>a</th>\n<th>b</th>\n</tr>\n</thead>\n<tbody>\n<tr>\n<td>1</td>\n<td>2</td>\n</tr>\n</tbody>\n</table>\n",
+			opts:     MarkdownOpts{GFM: true},
+		},
+		{
+			in:       "one\ntwo",
+			expected: "<p>one<br>\ntwo</p>\n",
+			opts:     MarkdownOpts{HardWraps: true},
+		},
+	}
+
+	for _, d := range testdata {
+		out, err := MarkdownToHTML(d.in, d.opts)
+		require.NoError(t, err)
+		assert.Equal(t, d.expected, out, d.in)
+	}
+}
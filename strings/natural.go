@@ -0,0 +1,93 @@
+package strings
+
+import (
+	"slices"
+	"strings"
+)
+
+// SortNatural - return a copy of the list of strings, sorted in "natural"
+// order (see [CompareNatural]), so "v1.10" sorts after "v1.9".
+func SortNatural(list []string) []string {
+	out := slices.Clone(list)
+	slices.SortStableFunc(out, CompareNatural)
+
+	return out
+}
+
+// CompareNatural - compare two strings in "natural" order, where runs of
+// digits are compared by their numeric values, so "file2" sorts before
+// "file10". The result is 0 if a == b, -1 if a < b, and +1 if a > b.
+func CompareNatural(a, b string) int {
+	for a != "" && b != "" {
+		ca, cb := a[0], b[0]
+
+		if isDigit(ca) && isDigit(cb) {
+			var da, db string
+			da, a = digitPrefix(a)
+			db, b = digitPrefix(b)
+
+			if c := compareDigits(da, db); c != 0 {
+				return c
+			}
+
+			continue
+		}
+
+		if ca != cb {
+			return strings.Compare(a[:1], b[:1])
+		}
+
+		a, b = a[1:], b[1:]
+	}
+
+	switch {
+	case a == "" && b == "":
+		return 0
+	case a == "":
+		return -1
+	default:
+		return 1
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// digitPrefix splits s into its leading run of digits and the rest
+func digitPrefix(s string) (string, string) {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+
+	return s[:i], s[i:]
+}
+
+// compareDigits compares two runs of digits by their numeric values, without
+// parsing them (so they can be arbitrarily long). When the values are equal,
+// the run with fewer leading zeros comes first.
+func compareDigits(a, b string) int {
+	ta, tb := strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+
+	if len(ta) != len(tb) {
+		if len(ta) < len(tb) {
+			return -1
+		}
+
+		return 1
+	}
+
+	if c := strings.Compare(ta, tb); c != 0 {
+		return c
+	}
+
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,48 @@
+package strings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareNatural(t *testing.T) {
+	t.Parallel()
+
+	testdata := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"", "a", -1},
+		{"a", "", 1},
+		{"abc", "abc", 0},
+		{"abc", "abd", -1},
+		{"file2", "file10", -1},
+		{"file10", "file2", 1},
+		{"file10", "file10", 0},
+		{"file010", "file10", 1},
+		{"file10a", "file10b", -1},
+		{"v1.2.10", "v1.2.9", 1},
+		{"a1", "a1b", -1},
+		{"1", "a", -1},
+		{"99999999999999999999999", "100000000000000000000000", -1},
+		{"x9y", "x09y", -1},
+	}
+
+	for _, d := range testdata {
+		assert.Equal(t, d.expected, CompareNatural(d.a, d.b), "%q vs %q", d.a, d.b)
+	}
+}
+
+func TestSortNatural(t *testing.T) {
+	t.Parallel()
+
+	in := []string{"v1.10.0", "v1.9.2", "v1.9.10", "v1.2.0", "v1.9.2-rc1"}
+	assert.Equal(t, []string{"v1.2.0", "v1.9.2", "v1.9.2-rc1", "v1.9.10", "v1.10.0"}, SortNatural(in))
+
+	// the input isn't modified
+	assert.Equal(t, "v1.10.0", in[0])
+
+	assert.Empty(t, SortNatural(nil))
+}
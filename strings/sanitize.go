@@ -0,0 +1,32 @@
+package strings
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// sanitizers are created on first use, and are safe for concurrent use
+//
+//nolint:gochecknoglobals
+var (
+	strictPolicy = sync.OnceValue(bluemonday.StrictPolicy)
+	ugcPolicy    = sync.OnceValue(bluemonday.UGCPolicy)
+)
+
+// SanitizeHTML - remove potentially unsafe elements and attributes (such as
+// scripts, styles, and event handlers) from an HTML fragment. With the "ugc"
+// policy, elements commonly used for formatting user-generated content (such
+// as links, lists, and tables) are kept. With the "strict" policy, all
+// elements are removed, leaving only the text.
+func SanitizeHTML(policy, in string) (string, error) {
+	switch policy {
+	case "ugc", "":
+		return ugcPolicy().Sanitize(in), nil
+	case "strict":
+		return strictPolicy().Sanitize(in), nil
+	default:
+		return "", fmt.Errorf("unknown policy %q: must be one of ugc or strict", policy)
+	}
+}
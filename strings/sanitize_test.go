@@ -0,0 +1,36 @@
+package strings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeHTML(t *testing.T) {
+	t.Parallel()
+
+	testdata := []struct {
+		policy   string
+		in       string
+		expected string
+	}{
+		{"", "", ""},
+		{"ugc", "plain text", "plain text"},
+		{"", `<p onclick="evil()">hi <b>there</b></p><script>alert(1)</script>`, "<p>hi <b>there</b></p>"},
+		{"ugc", `<a href="javascript:alert(1)">x</a> <a href="https://example.com">y</a>`, `x <a href="https://example.com" rel="nofollow">y</a>`},
+		{"ugc", `<img src="x.png" onerror="evil()">`, `<img src="x.png">`},
+		{"ugc", `<style>body{}</style><iframe src="https://example.com"></iframe>ok`, "ok"},
+		{"strict", `<p onclick="evil()">hi <b>there</b></p><script>alert(1)</script>`, "hi there"},
+		{"strict", `a &lt; b`, "a &lt; b"},
+	}
+
+	for _, d := range testdata {
+		out, err := SanitizeHTML(d.policy, d.in)
+		require.NoError(t, err)
+		assert.Equal(t, d.expected, out, d.in)
+	}
+
+	_, err := SanitizeHTML("bogus", "")
+	require.Error(t, err)
+}
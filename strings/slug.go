@@ -0,0 +1,60 @@
+package strings
+
+import (
+	"strings"
+
+	"github.com/gosimple/slug"
+	"github.com/gosimple/unidecode"
+)
+
+// SlugifyOpts defines the options to apply to the Slugify function
+type SlugifyOpts struct {
+	// Language used for language-specific substitutions, like "de" to
+	// transliterate "ä" as "ae" (defaults to "en")
+	Lang string
+
+	// Separator to insert between words (defaults to "-")
+	Separator string
+
+	// The maximum length of the slug - longer slugs are truncated after the
+	// last whole word that fits (defaults to 0, for no maximum)
+	MaxLength int
+}
+
+// Slugify - create a lower-case, URL- and filename-safe "slug" from the
+// string, transliterating any non-ASCII characters (so "Grüße, Welt!" becomes
+// "grusse-welt").
+func Slugify(in string, opts SlugifyOpts) string {
+	if opts.Lang == "" {
+		opts.Lang = "en"
+	}
+
+	s := slug.MakeLang(in, opts.Lang)
+
+	if opts.MaxLength > 0 && len(s) > opts.MaxLength {
+		s = truncateWords(s, opts.MaxLength)
+	}
+
+	if opts.Separator != "" && opts.Separator != "-" {
+		s = strings.ReplaceAll(s, "-", opts.Separator)
+	}
+
+	return s
+}
+
+// truncateWords truncates the slug to the last whole word within length, or
+// to length if the first word is longer
+func truncateWords(s string, length int) string {
+	i := strings.LastIndexByte(s[:length+1], '-')
+	if i <= 0 {
+		return strings.TrimRight(s[:length], "-_")
+	}
+
+	return s[:i]
+}
+
+// Transliterate - replace non-ASCII characters in the string with their
+// closest ASCII equivalents (so "Ünïcödé" becomes "Unicode").
+func Transliterate(in string) string {
+	return unidecode.Unidecode(in)
+}
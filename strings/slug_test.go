@@ -0,0 +1,43 @@
+package strings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugify(t *testing.T) {
+	t.Parallel()
+
+	testdata := []struct {
+		in       string
+		expected string
+		opts     SlugifyOpts
+	}{
+		{in: "Hello, world!", expected: "hello-world"},
+		{in: "  Grüße, Welt! ", expected: "grusse-welt"},
+		{in: "Grüße, Welt!", opts: SlugifyOpts{Lang: "de"}, expected: "gruesse-welt"},
+		{in: "Rock & Roll", expected: "rock-and-roll"},
+		{in: "Rock & Roll", opts: SlugifyOpts{Separator: "_"}, expected: "rock_and_roll"},
+		{in: "Rock & Roll", opts: SlugifyOpts{Separator: "."}, expected: "rock.and.roll"},
+		{in: "the quick brown fox", opts: SlugifyOpts{MaxLength: 15}, expected: "the-quick-brown"},
+		{in: "the quick brown fox", opts: SlugifyOpts{MaxLength: 14}, expected: "the-quick"},
+		{in: "the quick brown fox", opts: SlugifyOpts{MaxLength: 100}, expected: "the-quick-brown-fox"},
+		{in: "supercalifragilistic word", opts: SlugifyOpts{MaxLength: 5}, expected: "super"},
+		{in: "Ελληνικά", expected: "ellenika"},
+		{in: "", expected: ""},
+	}
+
+	for _, d := range testdata {
+		assert.Equal(t, d.expected, Slugify(d.in, d.opts), "%q %+v", d.in, d.opts)
+	}
+}
+
+func TestTransliterate(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Unicode", Transliterate("Ünïcödé"))
+	assert.Equal(t, "Zhong Wen ", Transliterate("中文"))
+	assert.Equal(t, "plain ascii", Transliterate("plain ascii"))
+	assert.Equal(t, "", Transliterate(""))
+}
@@ -0,0 +1,158 @@
+package strings
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Table - format the rows as a table of aligned text columns, with an
+// optional header row. When markdown is true, the table is formatted as a
+// Markdown (GitHub-flavoured) table instead, which always has a header row
+// (empty if none is given).
+//
+// Rows may have different numbers of cells - missing cells are left empty.
+// Newlines in cells are replaced with spaces. The result has no trailing
+// newline.
+func Table(header []string, rows [][]string, markdown bool) string {
+	if markdown {
+		return markdownTable(header, rows)
+	}
+
+	if len(header) > 0 {
+		rows = append([][]string{header}, rows...)
+	}
+
+	return alignColumns(normalizeCells(rows, false), 2)
+}
+
+// Columnize - format the lines as aligned columns, where each line's columns
+// are separated by delim. Columns in the output are separated by gap spaces.
+func Columnize(lines []string, delim string, gap int) string {
+	rows := make([][]string, len(lines))
+	for i, line := range lines {
+		cells := strings.Split(line, delim)
+		for j, c := range cells {
+			cells[j] = strings.TrimSpace(c)
+		}
+
+		rows[i] = cells
+	}
+
+	return alignColumns(rows, gap)
+}
+
+// alignColumns pads each cell to its column's width, separating columns with
+// gap spaces. The last cell in each row isn't padded, to avoid trailing spaces.
+func alignColumns(rows [][]string, gap int) string {
+	widths := columnWidths(rows)
+
+	sb := strings.Builder{}
+	for i, row := range rows {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+
+		line := strings.Builder{}
+		for j, cell := range row {
+			line.WriteString(cell)
+
+			if j < len(row)-1 {
+				line.WriteString(strings.Repeat(" ", widths[j]-utf8.RuneCountInString(cell)+gap))
+			}
+		}
+
+		sb.WriteString(strings.TrimRight(line.String(), " "))
+	}
+
+	return sb.String()
+}
+
+func markdownTable(header []string, rows [][]string) string {
+	all := normalizeCells(append([][]string{header}, rows...), true)
+	widths := columnWidths(all)
+
+	// Markdown needs at least 3 dashes in the delimiter row
+	for i, w := range widths {
+		widths[i] = max(w, 3)
+	}
+
+	sb := strings.Builder{}
+	for i, row := range all {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+
+		writeMarkdownRow(&sb, row, widths)
+
+		if i == 0 {
+			sb.WriteByte('\n')
+
+			dashes := make([]string, len(widths))
+			for j, w := range widths {
+				dashes[j] = strings.Repeat("-", w)
+			}
+
+			writeMarkdownRow(&sb, dashes, widths)
+		}
+	}
+
+	return sb.String()
+}
+
+func writeMarkdownRow(sb *strings.Builder, row []string, widths []int) {
+	sb.WriteByte('|')
+
+	for j, w := range widths {
+		cell := ""
+		if j < len(row) {
+			cell = row[j]
+		}
+
+		sb.WriteByte(' ')
+		sb.WriteString(cell)
+		sb.WriteString(strings.Repeat(" ", max(w-utf8.RuneCountInString(cell), 0)))
+		sb.WriteString(" |")
+	}
+}
+
+// columnWidths returns the width (in runes) of each column's widest cell
+func columnWidths(rows [][]string) []int {
+	widths := []int{}
+
+	for _, row := range rows {
+		for j, cell := range row {
+			w := utf8.RuneCountInString(cell)
+			if j >= len(widths) {
+				widths = append(widths, w)
+			} else {
+				widths[j] = max(widths[j], w)
+			}
+		}
+	}
+
+	return widths
+}
+
+// normalizeCells returns a copy of the rows with each cell normalized (see
+// tableCell), and with "|" characters escaped for Markdown tables
+func normalizeCells(rows [][]string, markdown bool) [][]string {
+	out := make([][]string, len(rows))
+	for i, row := range rows {
+		out[i] = make([]string, len(row))
+		for j, cell := range row {
+			cell = tableCell(cell)
+			if markdown {
+				cell = strings.ReplaceAll(cell, "|", `\|`)
+			}
+
+			out[i][j] = cell
+		}
+	}
+
+	return out
+}
+
+// tableCell normalizes a cell's content, so it fits on one line
+func tableCell(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "\r\n", " "), "\n", " ")
+}
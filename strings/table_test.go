@@ -0,0 +1,69 @@
+package strings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable(t *testing.T) {
+	t.Parallel()
+
+	header := []string{"NAME", "STATUS", "AGE"}
+	rows := [][]string{
+		{"web-1", "Running", "3d"},
+		{"database", "Pending", "12m"},
+		{"cache", "", "1h"},
+	}
+
+	assert.Equal(t, `NAME      STATUS   AGE
+web-1     Running  3d
+database  Pending  12m
+cache              1h`, Table(header, rows, false))
+
+	assert.Equal(t, `web-1     Running  3d
+database  Pending  12m
+cache              1h`, Table(nil, rows, false))
+
+	assert.Equal(t, `| NAME     | STATUS  | AGE |
+| -------- | ------- | --- |
+| web-1    | Running | 3d  |
+| database | Pending | 12m |
+| cache    |         | 1h  |`, Table(header, rows, true))
+
+	// ragged rows, multi-line and multi-byte cells, and pipes
+	rows = [][]string{
+		{"a|b", "line1\nline2"},
+		{"ü"},
+	}
+
+	assert.Equal(t, `a|b  line1 line2
+ü`, Table(nil, rows, false))
+
+	assert.Equal(t, `|      |             |
+| ---- | ----------- |
+| a\|b | line1 line2 |
+| ü    |             |`, Table(nil, rows, true))
+
+	assert.Equal(t, "", Table(nil, nil, false))
+}
+
+func TestColumnize(t *testing.T) {
+	t.Parallel()
+
+	lines := []string{
+		"Host | IP | Role",
+		"web01.example.com | 10.0.0.1 | web",
+		"db | 10.0.0.20 | database",
+	}
+
+	assert.Equal(t, `Host               IP         Role
+web01.example.com  10.0.0.1   web
+db                 10.0.0.20  database`, Columnize(lines, "|", 2))
+
+	assert.Equal(t, `a    b
+ccc  d`, Columnize([]string{"a,b", "ccc,d"}, ",", 2))
+
+	assert.Equal(t, `a   b
+ccc`, Columnize([]string{"a:b", "ccc"}, ":", 1))
+}
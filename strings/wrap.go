@@ -0,0 +1,113 @@
+package strings
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// WrapOpts defines the options to apply to the Wrap function
+type WrapOpts struct {
+	// Prefix to add to each line, like "# " for comments (defaults to none)
+	Prefix string
+
+	// The desired maximum line length in characters, including the prefix
+	// (defaults to 80)
+	Width int
+}
+
+// Wrap - wrap each line of the string at word boundaries, so that lines are at
+// most the given width. Unlike WordWrap, existing line breaks are kept, and
+// continuation lines keep the leading indentation of the line they were
+// wrapped from. Words longer than the width aren't broken up.
+func Wrap(in string, opts WrapOpts) string {
+	if opts.Width <= 0 {
+		opts.Width = 80
+	}
+
+	out := []string{}
+
+	for _, line := range strings.Split(in, "\n") {
+		rest := strings.TrimLeft(line, " \t")
+		lead := opts.Prefix + line[:len(line)-len(rest)]
+
+		words := strings.Fields(rest)
+		if len(words) == 0 {
+			out = append(out, strings.TrimRight(opts.Prefix, " \t"))
+			continue
+		}
+
+		cur := lead + words[0]
+		for _, w := range words[1:] {
+			if utf8.RuneCountInString(cur)+1+utf8.RuneCountInString(w) > opts.Width {
+				out = append(out, cur)
+				cur = lead + w
+
+				continue
+			}
+
+			cur += " " + w
+		}
+
+		out = append(out, cur)
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// Nindent - like Indent, but also adds a leading newline, and removes any
+// trailing newlines. This is useful for inserting multi-line blocks (like
+// YAML) at the end of a line in a template.
+func Nindent(width int, indent, s string) (string, error) {
+	out, err := Indent(width, indent, strings.TrimRight(s, "\n"))
+	if err != nil {
+		return "", err
+	}
+
+	return "\n" + out, nil
+}
+
+// Dedent - remove any leading whitespace common to all non-blank lines of the
+// string. Whitespace-only lines are emptied.
+func Dedent(s string) string {
+	lines := strings.Split(s, "\n")
+
+	common := ""
+	first := true
+
+	for _, line := range lines {
+		rest := strings.TrimLeft(line, " \t")
+		if rest == "" {
+			continue
+		}
+
+		lead := line[:len(line)-len(rest)]
+		if first {
+			common = lead
+			first = false
+
+			continue
+		}
+
+		common = commonPrefix(common, lead)
+	}
+
+	for i, line := range lines {
+		if strings.TrimLeft(line, " \t") == "" {
+			lines[i] = ""
+			continue
+		}
+
+		lines[i] = line[len(common):]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func commonPrefix(a, b string) string {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+
+	return a[:i]
+}
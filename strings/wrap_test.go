@@ -0,0 +1,60 @@
+package strings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrap(t *testing.T) {
+	t.Parallel()
+
+	in := "The quick brown fox jumps over the lazy dog.\n\n  - an indented list item which is long"
+
+	assert.Equal(t, `The quick brown
+fox jumps over the
+lazy dog.
+
+  - an indented
+  list item which
+  is long`, Wrap(in, WrapOpts{Width: 18}))
+
+	assert.Equal(t, `# The quick brown
+# fox jumps over
+# the lazy dog.
+#
+#   - an indented
+#   list item
+#   which is long`, Wrap(in, WrapOpts{Width: 18, Prefix: "# "}))
+
+	assert.Equal(t, "a\nverylongword\nb", Wrap("a verylongword b", WrapOpts{Width: 5}))
+	assert.Equal(t, "short line", Wrap("short   line", WrapOpts{}))
+	assert.Equal(t, "ünï cödé\nab", Wrap("ünï cödé ab", WrapOpts{Width: 8}))
+	assert.Equal(t, "", Wrap("", WrapOpts{}))
+}
+
+func TestNindent(t *testing.T) {
+	t.Parallel()
+
+	out, err := Nindent(2, " ", "foo: 1\nbar:\n  baz: 2\n")
+	require.NoError(t, err)
+	assert.Equal(t, "\n  foo: 1\n  bar:\n    baz: 2", out)
+
+	out, err = Nindent(1, "\t", "foo")
+	require.NoError(t, err)
+	assert.Equal(t, "\n\tfoo", out)
+
+	_, err = Nindent(0, " ", "foo")
+	require.Error(t, err)
+}
+
+func TestDedent(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "foo:\n  bar: 1\n\nbaz: 2\n", Dedent("    foo:\n      bar: 1\n  \n    baz: 2\n"))
+	assert.Equal(t, "a\n b", Dedent("\ta\n\t b"))
+	assert.Equal(t, "\ta\nb", Dedent(" \ta\n b"))
+	assert.Equal(t, "no indent\n  here", Dedent("no indent\n  here"))
+	assert.Equal(t, "", Dedent(""))
+}
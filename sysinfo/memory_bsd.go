@@ -0,0 +1,27 @@
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+
+package sysinfo
+
+import (
+	"fmt"
+	"runtime"
+
+	"golang.org/x/sys/unix"
+)
+
+func totalMemory() (uint64, error) {
+	name := "hw.physmem"
+	switch runtime.GOOS {
+	case "darwin":
+		name = "hw.memsize"
+	case "netbsd", "openbsd":
+		name = "hw.physmem64"
+	}
+
+	mem, err := unix.SysctlUint64(name)
+	if err != nil {
+		return 0, fmt.Errorf("sysctl %s: %w", name, err)
+	}
+
+	return mem, nil
+}
@@ -0,0 +1,17 @@
+package sysinfo
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+func totalMemory() (uint64, error) {
+	var info unix.Sysinfo_t
+	if err := unix.Sysinfo(&info); err != nil {
+		return 0, fmt.Errorf("sysinfo: %w", err)
+	}
+
+	//nolint:unconvert // Totalram's type varies by architecture
+	return uint64(info.Totalram) * uint64(info.Unit), nil
+}
@@ -0,0 +1,12 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd && !windows
+
+package sysinfo
+
+import (
+	"fmt"
+	"runtime"
+)
+
+func totalMemory() (uint64, error) {
+	return 0, fmt.Errorf("total memory is not supported on %s", runtime.GOOS)
+}
@@ -0,0 +1,207 @@
+// Package sysinfo provides information about the host system, such as its
+// hostname, platform, and network interfaces.
+package sysinfo
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// Hostname returns the host name reported by the kernel
+func Hostname() (string, error) {
+	return os.Hostname()
+}
+
+// FQDN returns the fully-qualified domain name of the host. When the
+// hostname is already qualified it's returned unchanged, otherwise the name
+// is resolved (with the hosts file or DNS) to find the canonical name. When
+// no qualified name can be found, the hostname is returned.
+func FQDN() (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", err
+	}
+
+	return fqdn(hostname), nil
+}
+
+func fqdn(hostname string) string {
+	if strings.Contains(hostname, ".") {
+		return hostname
+	}
+
+	if cname, err := net.LookupCNAME(hostname); err == nil {
+		cname = strings.TrimSuffix(cname, ".")
+		if strings.HasPrefix(cname, hostname+".") {
+			return cname
+		}
+	}
+
+	addrs, err := net.LookupHost(hostname)
+	if err != nil {
+		return hostname
+	}
+
+	for _, addr := range addrs {
+		names, err := net.LookupAddr(addr)
+		if err != nil {
+			continue
+		}
+
+		for _, name := range names {
+			name = strings.TrimSuffix(name, ".")
+			if strings.HasPrefix(name, hostname+".") {
+				return name
+			}
+		}
+	}
+
+	return hostname
+}
+
+// OS returns the operating system, as named by Go (e.g. "linux", "darwin",
+// "windows")
+func OS() string {
+	return runtime.GOOS
+}
+
+// Arch returns the CPU architecture, as named by Go (e.g. "amd64", "arm64")
+func Arch() string {
+	return runtime.GOARCH
+}
+
+// NumCPU returns the number of logical CPUs
+func NumCPU() int {
+	return runtime.NumCPU()
+}
+
+// TotalMemory returns the total amount of physical memory, in bytes
+func TotalMemory() (uint64, error) {
+	return totalMemory()
+}
+
+// Interface describes a network interface
+type Interface struct {
+	// Name is the interface's name (e.g. "eth0")
+	Name string
+	// IP is the interface's primary address
+	IP string
+	// MAC is the interface's hardware address, if any
+	MAC string
+	// MTU is the interface's maximum transmission unit
+	MTU int
+}
+
+// routeProbeAddrs are used to find the interface the default route goes
+// through. They're in networks reserved for documentation, and no traffic is
+// ever sent to them.
+//
+//nolint:gochecknoglobals
+var routeProbeAddrs = []string{"192.0.2.1:9", "[2001:db8::1]:9"}
+
+// PrimaryInterface returns the network interface that outbound traffic is
+// routed through by default. When there's no default route, the first
+// non-loopback interface that's up and has an address is returned instead.
+func PrimaryInterface() (*Interface, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network interfaces: %w", err)
+	}
+
+	for _, probe := range routeProbeAddrs {
+		ip := outboundIP(probe)
+		if ip == nil {
+			continue
+		}
+
+		for _, iface := range ifaces {
+			if hasAddr(iface, ip) {
+				return newInterface(iface, ip), nil
+			}
+		}
+	}
+
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+
+		if ip := firstAddr(iface); ip != nil {
+			return newInterface(iface, ip), nil
+		}
+	}
+
+	return nil, errors.New("no network interface found")
+}
+
+// outboundIP returns the local address used to reach addr, or nil when
+// there's no route. Connecting a UDP socket doesn't send any packets.
+func outboundIP(addr string) net.IP {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil
+	}
+	defer conn.Close()
+
+	if a, ok := conn.LocalAddr().(*net.UDPAddr); ok {
+		return a.IP
+	}
+
+	return nil
+}
+
+func ifaceIPs(iface net.Interface) []net.IP {
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil
+	}
+
+	ips := make([]net.IP, 0, len(addrs))
+	for _, a := range addrs {
+		if n, ok := a.(*net.IPNet); ok {
+			ips = append(ips, n.IP)
+		}
+	}
+
+	return ips
+}
+
+func hasAddr(iface net.Interface, ip net.IP) bool {
+	for _, a := range ifaceIPs(iface) {
+		if a.Equal(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// firstAddr returns the interface's first IPv4 address, or its first IPv6
+// address when it has no IPv4 address
+func firstAddr(iface net.Interface) net.IP {
+	var v6 net.IP
+	for _, ip := range ifaceIPs(iface) {
+		if ip.To4() != nil {
+			return ip
+		}
+
+		if v6 == nil && !ip.IsLinkLocalUnicast() {
+			v6 = ip
+		}
+	}
+
+	return v6
+}
+
+func newInterface(iface net.Interface, ip net.IP) *Interface {
+	return &Interface{
+		Name: iface.Name,
+		IP:   ip.String(),
+		MAC:  iface.HardwareAddr.String(),
+		MTU:  iface.MTU,
+	}
+}
@@ -0,0 +1,56 @@
+package sysinfo
+
+import (
+	"net"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostname(t *testing.T) {
+	expected, err := os.Hostname()
+	require.NoError(t, err)
+
+	h, err := Hostname()
+	require.NoError(t, err)
+	assert.Equal(t, expected, h)
+
+	f, err := FQDN()
+	require.NoError(t, err)
+	assert.Contains(t, f, h)
+}
+
+func TestFQDN(t *testing.T) {
+	assert.Equal(t, "host.example.com", fqdn("host.example.com"))
+	assert.Equal(t, "localhost", fqdn("localhost"))
+}
+
+func TestPlatform(t *testing.T) {
+	assert.Equal(t, runtime.GOOS, OS())
+	assert.Equal(t, runtime.GOARCH, Arch())
+	assert.Positive(t, NumCPU())
+}
+
+func TestTotalMemory(t *testing.T) {
+	mem, err := TotalMemory()
+	require.NoError(t, err)
+	assert.Greater(t, mem, uint64(64*1024*1024))
+}
+
+func TestPrimaryInterface(t *testing.T) {
+	iface, err := PrimaryInterface()
+	if err != nil {
+		t.Skipf("no network interface: %v", err)
+	}
+
+	assert.NotEmpty(t, iface.Name)
+	assert.NotNil(t, net.ParseIP(iface.IP), iface.IP)
+
+	ni, err := net.InterfaceByName(iface.Name)
+	require.NoError(t, err)
+	assert.Equal(t, ni.MTU, iface.MTU)
+	assert.True(t, hasAddr(*ni, net.ParseIP(iface.IP)))
+}
@@ -11,7 +11,9 @@ import (
 	"text/template"
 
 	"github.com/hack-pad/hackpadfs"
+	"github.com/hairyhenderson/gomplate/v4/internal/config"
 	"github.com/hairyhenderson/gomplate/v4/internal/datafs"
+	"github.com/hairyhenderson/gomplate/v4/internal/funcs"
 	"github.com/hairyhenderson/gomplate/v4/internal/iohelpers"
 	"github.com/hairyhenderson/gomplate/v4/tmpl"
 
@@ -22,11 +24,11 @@ import (
 // ignorefile name, like .gitignore
 const gomplateignore = ".gomplateignore"
 
-func addTmplFuncs(f template.FuncMap, root *template.Template, tctx interface{}, path string) {
+func addTmplFuncs(ctx context.Context, f template.FuncMap, root *template.Template, tctx interface{}, path string, read tmpl.SourceReader) {
 	t := tmpl.New(root, tctx, path)
-	tns := func() *tmpl.Template { return t }
-	f["tmpl"] = tns
-	f["tpl"] = t.Inline
+	t.SetSourceReader(read)
+	t.SetMaxDepth(config.MaxTemplateDepth(ctx))
+	addToMap(f, funcs.CreateTmplFuncs(ctx, t))
 }
 
 // copyFuncMap - copies the template.FuncMap into a new map so we can modify it
@@ -67,6 +69,10 @@ func gatherTemplates(ctx context.Context, cfg *Config, outFileNamer outputNamer)
 			Text:   cfg.Input,
 			Writer: target,
 		}}
+		if cfg.OutputFiles[0] == "-" {
+			templates[0].stdout = cfg.Stdout
+		}
+		applyDelims(cfg, &templates[0], "")
 	case cfg.InputDir != "":
 		// input dirs presume output dirs are set too
 		templates, err = walkDir(ctx, cfg, cfg.InputDir, outFileNamer, cfg.ExcludeGlob, cfg.ExcludeProcessingGlob, mode, modeOverride)
@@ -80,6 +86,7 @@ func gatherTemplates(ctx context.Context, cfg *Config, outFileNamer outputNamer)
 			if err != nil {
 				return nil, fmt.Errorf("fileToTemplate: %w", err)
 			}
+			applyDelims(cfg, &templates[i], f)
 		}
 	}
 
@@ -173,14 +180,18 @@ func walkDir(ctx context.Context, cfg *Config, dir string, outFileNamer outputNa
 		if err != nil {
 			return nil, fmt.Errorf("fileToTemplate: %w", err)
 		}
+		applyDelims(cfg, &tpl, file)
 
-		// Ensure file parent dirs - use separate fsys for output file
-		outfsys, err := datafs.FSysForPath(ctx, outFile)
-		if err != nil {
-			return nil, fmt.Errorf("fsysForPath: %w", err)
-		}
-		if err = hackpadfs.MkdirAll(outfsys, filepath.Dir(outFile), dirMode); err != nil {
-			return nil, fmt.Errorf("mkdirAll %q: %w", outFile, err)
+		// Ensure file parent dirs - use separate fsys for output file (unless
+		// nothing's being written, in dry-run mode)
+		if dryRunFromContext(ctx) == nil {
+			outfsys, err := datafs.FSysForPath(ctx, outFile)
+			if err != nil {
+				return nil, fmt.Errorf("fsysForPath: %w", err)
+			}
+			if err = hackpadfs.MkdirAll(outfsys, filepath.Dir(outFile), dirMode); err != nil {
+				return nil, fmt.Errorf("mkdirAll %q: %w", outFile, err)
+			}
 		}
 
 		templates = append(templates, tpl)
@@ -241,7 +252,7 @@ func getOutfileHandler(ctx context.Context, cfg *Config, outFile string, mode os
 	return target, nil
 }
 
-func copyFileToOutDir(ctx context.Context, cfg *Config, inFile, outFile string, mode os.FileMode, modeOverride bool) error {
+func copyFileToOutDir(ctx context.Context, cfg *Config, inFile, outFile string, mode os.FileMode, modeOverride bool) (err error) {
 	sourceStr, newmode, err := readInFile(ctx, inFile, mode)
 	if err != nil {
 		return err
@@ -254,7 +265,11 @@ func copyFileToOutDir(ctx context.Context, cfg *Config, inFile, outFile string,
 
 	wr, ok := outFH.(io.Closer)
 	if ok && wr != os.Stdout {
-		defer wr.Close()
+		defer func() {
+			if cerr := wr.Close(); cerr != nil && err == nil {
+				err = fmt.Errorf("failed to write %q: %w", outFile, cerr)
+			}
+		}()
 	}
 
 	_, err = outFH.Write([]byte(sourceStr))
@@ -277,10 +292,32 @@ func fileToTemplate(ctx context.Context, cfg *Config, inFile, outFile string, mo
 		Text:   source,
 		Writer: target,
 	}
+	if outFile == "-" {
+		tmpl.stdout = cfg.Stdout
+	}
 
 	return tmpl, nil
 }
 
+// writeOptions control how output files are written
+type writeOptions struct {
+	// atomic - write to a temporary file, and rename it into place
+	atomic bool
+	// fsync - sync output files to disk before closing them
+	fsync bool
+}
+
+type writeOptionsCtxKey struct{}
+
+func withWriteOptions(ctx context.Context, opts writeOptions) context.Context {
+	return context.WithValue(ctx, writeOptionsCtxKey{}, opts)
+}
+
+func writeOptionsFromContext(ctx context.Context) writeOptions {
+	opts, _ := ctx.Value(writeOptionsCtxKey{}).(writeOptions)
+	return opts
+}
+
 // openOutFile returns a writer for the given file, creating the file if it
 // doesn't exist yet, and creating the parent directories if necessary. Will
 // defer actual opening until the first non-empty write. If the file already
@@ -291,6 +328,12 @@ func fileToTemplate(ctx context.Context, cfg *Config, inFile, outFile string, mo
 //nolint:unparam
 func openOutFile(ctx context.Context, filename string, dirMode, mode os.FileMode, modeOverride bool, stdout io.Writer) (out io.Writer, err error) {
 	out = iohelpers.NewEmptySkipper(func() (io.Writer, error) {
+		if d := dryRunFromContext(ctx); d != nil {
+			if filename == "-" {
+				return iohelpers.NopCloser(io.Discard), nil
+			}
+			return d.open(filename), nil
+		}
 		if filename == "-" {
 			return iohelpers.NopCloser(stdout), nil
 		}
@@ -314,16 +357,27 @@ func createOutFile(ctx context.Context, filename string, dirMode, mode os.FileMo
 		}
 	}
 
+	opts := writeOptionsFromContext(ctx)
+
 	open := func() (out io.WriteCloser, err error) {
 		// Ensure file parent dirs
 		if err = hackpadfs.MkdirAll(fsys, filepath.Dir(filename), dirMode); err != nil {
 			return nil, fmt.Errorf("mkdirAll %q: %w", filename, err)
 		}
 
+		if opts.atomic {
+			return iohelpers.AtomicWriter(fsys, filename, mode, opts.fsync)
+		}
+
 		f, err := hackpadfs.OpenFile(fsys, filename, os.O_RDWR|os.O_CREATE|os.O_TRUNC, mode)
 		if err != nil {
 			return out, fmt.Errorf("failed to open output file '%s' for writing: %w", filename, err)
 		}
+
+		if opts.fsync {
+			return iohelpers.SyncCloser(f), nil
+		}
+
 		out = f.(io.WriteCloser)
 
 		return out, err
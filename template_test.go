@@ -7,9 +7,11 @@ import (
 	"io/fs"
 	"net/url"
 	"os"
+	"path/filepath"
 	"testing"
 	"testing/fstest"
 	"text/template"
+	"time"
 
 	"github.com/hack-pad/hackpadfs"
 	"github.com/hack-pad/hackpadfs/mem"
@@ -90,6 +92,7 @@ func TestGatherTemplates(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, templates, 1)
 	assert.Equal(t, "foo", templates[0].Text)
+	assert.Equal(t, buf, templates[0].stdout)
 
 	_, err = templates[0].Writer.Write([]byte("hello world"))
 	require.NoError(t, err)
@@ -101,6 +104,7 @@ func TestGatherTemplates(t *testing.T) {
 	}, nil)
 	require.NoError(t, err)
 	assert.Len(t, templates, 1)
+	assert.Nil(t, templates[0].stdout)
 
 	// out file is created only on demand
 	_, err = hackpadfs.Stat(fsys, "out")
@@ -178,6 +182,97 @@ func TestCreateOutFile(t *testing.T) {
 	assert.IsType(t, &fs.PathError{}, err)
 }
 
+func TestRun_UnchangedOutput(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out.txt")
+
+	render := func(in string) {
+		t.Helper()
+
+		err := Run(context.Background(), &Config{Input: in, OutputFiles: []string{out}})
+		require.NoError(t, err)
+	}
+
+	render("hello {{ 1 }}")
+
+	old := time.Now().Add(-time.Hour).Truncate(time.Second)
+	require.NoError(t, os.Chtimes(out, old, old))
+
+	// identical output isn't written
+	render("hello {{ 1 }}")
+
+	fi, err := os.Stat(out)
+	require.NoError(t, err)
+	assert.Equal(t, old, fi.ModTime())
+
+	render("hello {{ 2 }}")
+
+	fi, err = os.Stat(out)
+	require.NoError(t, err)
+	assert.NotEqual(t, old, fi.ModTime())
+
+	b, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, "hello 2", string(b))
+}
+
+func TestRun_Atomic(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out.txt")
+
+	require.NoError(t, os.WriteFile(out, []byte("old"), 0o600))
+
+	err := Run(context.Background(), &Config{
+		Input:       "new {{ 1 }}",
+		OutputFiles: []string{out},
+		Atomic:      true,
+		Fsync:       true,
+	})
+	require.NoError(t, err)
+
+	b, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, "new 1", string(b))
+
+	// output is discarded when rendering fails part-way
+	err = Run(context.Background(), &Config{
+		Input:       "partial {{ fail }}",
+		OutputFiles: []string{out},
+		Atomic:      true,
+	})
+	require.Error(t, err)
+
+	b, err = os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, "new 1", string(b))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	// without atomic writes, the output rendered before the failure is kept
+	err = Run(context.Background(), &Config{
+		Input:       "partial {{ fail }}",
+		OutputFiles: []string{out},
+	})
+	require.Error(t, err)
+
+	b, err = os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, "partial ", string(b))
+
+	newOut := filepath.Join(dir, "new.txt")
+	err = Run(context.Background(), &Config{
+		Input:       "partial {{ fail }}",
+		OutputFiles: []string{newOut},
+	})
+	require.Error(t, err)
+
+	b, err = os.ReadFile(newOut)
+	require.NoError(t, err)
+	assert.Equal(t, "partial ", string(b))
+}
+
 func TestParseNestedTemplates(t *testing.T) {
 	wd, _ := os.Getwd()
 	t.Cleanup(func() {
@@ -0,0 +1,58 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+// TCPReachable - whether a TCP connection can be opened to the address
+// ("host:port") within the timeout
+func TCPReachable(ctx context.Context, addr string, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	d := net.Dialer{}
+
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return false
+	}
+
+	_ = conn.Close()
+
+	return true
+}
+
+// HTTPStatus - make a GET request to the URL, and return the response's status
+// code. Redirects are followed. When no response is received within the
+// timeout (for example because the server is down), 0 is returned. An error
+// is only returned when the URL is invalid.
+func HTTPStatus(ctx context.Context, url string, timeout time.Duration) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("invalid URL %q: %w", url, err)
+	}
+
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return 0, fmt.Errorf("invalid URL %q: scheme must be http or https", url)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, nil
+	}
+
+	defer resp.Body.Close()
+
+	// drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,76 @@
+package test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTCPReachable(t *testing.T) {
+	t.Parallel()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	addr := l.Addr().String()
+
+	assert.True(t, TCPReachable(context.Background(), addr, time.Second))
+
+	l.Close()
+
+	assert.False(t, TCPReachable(context.Background(), addr, time.Second))
+	assert.False(t, TCPReachable(context.Background(), "bogus:address:here", time.Second))
+}
+
+func TestHTTPStatus(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			_, _ = w.Write([]byte("ok"))
+		case "/redirect":
+			http.Redirect(w, r, "/ok", http.StatusFound)
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	ctx := context.Background()
+
+	status, err := HTTPStatus(ctx, srv.URL+"/ok", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+
+	status, err = HTTPStatus(ctx, srv.URL+"/redirect", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+
+	status, err = HTTPStatus(ctx, srv.URL+"/missing", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status, err = HTTPStatus(ctx, srv.URL+"/slow", 10*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, 0, status)
+
+	srv.Close()
+
+	status, err = HTTPStatus(ctx, srv.URL+"/ok", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 0, status)
+
+	_, err = HTTPStatus(ctx, "ftp://example.com", time.Second)
+	require.Error(t, err)
+
+	_, err = HTTPStatus(ctx, "http://[::1", time.Second)
+	require.Error(t, err)
+}
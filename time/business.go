@@ -0,0 +1,38 @@
+package time
+
+import "time"
+
+// AddBusinessDays - add n business days (Monday to Friday, excluding the given
+// holidays) to t, keeping the time of day. When n is negative, business days
+// are subtracted. Only the holidays' dates are used - their times of day and
+// locations are ignored.
+//
+// When n is 0, t is returned unchanged, even when it isn't a business day.
+func AddBusinessDays(t time.Time, n int, holidays []time.Time) time.Time {
+	skip := make(map[string]bool, len(holidays))
+	for _, h := range holidays {
+		skip[h.Format(time.DateOnly)] = true
+	}
+
+	step := 1
+	if n < 0 {
+		step = -1
+		n = -n
+	}
+
+	for n > 0 {
+		t = t.AddDate(0, 0, step)
+
+		if isWeekday(t) && !skip[t.Format(time.DateOnly)] {
+			n--
+		}
+	}
+
+	return t
+}
+
+func isWeekday(t time.Time) bool {
+	wd := t.Weekday()
+
+	return wd != time.Saturday && wd != time.Sunday
+}
@@ -0,0 +1,49 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddBusinessDays(t *testing.T) {
+	t.Parallel()
+
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 9, 30, 0, 0, time.UTC)
+	}
+
+	// 2024-12-20 is a Friday
+	fri := date(2024, 12, 20)
+
+	testdata := []struct {
+		in       time.Time
+		n        int
+		holidays []time.Time
+		want     time.Time
+	}{
+		{fri, 0, nil, fri},
+		{date(2024, 12, 21), 0, nil, date(2024, 12, 21)},
+		{fri, 1, nil, date(2024, 12, 23)},
+		{fri, 5, nil, date(2024, 12, 27)},
+		{date(2024, 12, 21), 1, nil, date(2024, 12, 23)},
+		{date(2024, 12, 23), -1, nil, fri},
+		{date(2024, 12, 22), -1, nil, fri},
+		{fri, -10, nil, date(2024, 12, 6)},
+		{
+			fri, 5,
+			[]time.Time{
+				time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 12, 26, 0, 0, 0, 0, time.FixedZone("X", 3600)),
+				// weekends are already skipped
+				time.Date(2024, 12, 28, 0, 0, 0, 0, time.UTC),
+			},
+			date(2024, 12, 31),
+		},
+	}
+
+	for _, d := range testdata {
+		assert.Equal(t, d.want, AddBusinessDays(d.in, d.n, d.holidays), "%s + %d", d.in, d.n)
+	}
+}
@@ -0,0 +1,163 @@
+package time
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// cronParser parses standard 5-field cron expressions, with an optional
+// leading seconds field, as well as descriptors like "@daily" and "@every 1h"
+//
+//nolint:gochecknoglobals
+var cronParser = cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour |
+	cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// the bit set in a day-of-month or day-of-week field's mask when the field is
+// "*" or "?" - see github.com/robfig/cron/v3's spec.go
+const cronStarBit = 1 << 63
+
+// cronSearchYears is how far to search for a matching time, the same as the
+// cron library does when searching forwards
+const cronSearchYears = 5
+
+// CronNext - return the first time after t matching the cron expression.
+//
+// The expression is evaluated in the time zone given with a "CRON_TZ=" prefix
+// (e.g. "CRON_TZ=Europe/Paris 0 3 * * *"), or otherwise in t's location. The
+// result is in t's location.
+func CronNext(expr string, t time.Time) (time.Time, error) {
+	sched, err := cronParser.Parse(expr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+	}
+
+	next := sched.Next(t)
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("no time within %d years after %s matches cron expression %q", cronSearchYears, t, expr)
+	}
+
+	return next, nil
+}
+
+// CronPrev - return the last time before t matching the cron expression. See
+// [CronNext] for how time zones are handled. "@every" expressions aren't
+// supported, since they have no fixed times.
+func CronPrev(expr string, t time.Time) (time.Time, error) {
+	spec, _, err := parseCronSpec(expr)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	origLoc := t.Location()
+	t = cronIn(spec, t)
+
+	// start from the last whole second before t
+	t = t.Add(-time.Nanosecond).Truncate(time.Second)
+	limit := t.Year() - cronSearchYears
+
+	// this mirrors the cron library's search for the next time, working
+	// backwards through the fields from the most significant, and moving to
+	// the last second of the previous month/day/hour/minute when a field
+	// doesn't match
+	for t.Year() >= limit {
+		switch {
+		case 1<<uint(t.Month())&spec.Month == 0:
+			t = cronFloor(t, time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()))
+		case !cronDayMatches(spec, t):
+			t = cronFloor(t, time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()))
+		case 1<<uint(t.Hour())&spec.Hour == 0:
+			t = cronFloor(t, time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location()))
+		case 1<<uint(t.Minute())&spec.Minute == 0:
+			t = t.Truncate(time.Minute).Add(-time.Second)
+		case 1<<uint(t.Second())&spec.Second == 0:
+			t = t.Add(-time.Second)
+		default:
+			return t.In(origLoc), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("no time within %d years before %s matches cron expression %q", cronSearchYears, t, expr)
+}
+
+// CronMatches - return whether t matches the cron expression. See [CronNext]
+// for how time zones are handled. Unless the expression has a seconds field, t
+// matches when any second in its minute would. "@every" expressions aren't
+// supported, since they have no fixed times.
+func CronMatches(expr string, t time.Time) (bool, error) {
+	spec, withSeconds, err := parseCronSpec(expr)
+	if err != nil {
+		return false, err
+	}
+
+	t = cronIn(spec, t)
+
+	match := 1<<uint(t.Month())&spec.Month > 0 &&
+		cronDayMatches(spec, t) &&
+		1<<uint(t.Hour())&spec.Hour > 0 &&
+		1<<uint(t.Minute())&spec.Minute > 0
+
+	if withSeconds {
+		match = match && 1<<uint(t.Second())&spec.Second > 0
+	}
+
+	return match, nil
+}
+
+// parseCronSpec parses an expression with fixed times (i.e. not "@every"),
+// also returning whether it has a seconds field
+func parseCronSpec(expr string) (*cron.SpecSchedule, bool, error) {
+	sched, err := cronParser.Parse(expr)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+	}
+
+	spec, ok := sched.(*cron.SpecSchedule)
+	if !ok {
+		return nil, false, fmt.Errorf("unsupported cron expression %q: must have fixed times", expr)
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) > 0 && (strings.HasPrefix(fields[0], "CRON_TZ=") || strings.HasPrefix(fields[0], "TZ=")) {
+		fields = fields[1:]
+	}
+
+	return spec, len(fields) == 6, nil
+}
+
+// cronIn converts t to the expression's time zone, if it has one - like the
+// cron library, expressions without a time zone are evaluated in t's location
+func cronIn(spec *cron.SpecSchedule, t time.Time) time.Time {
+	if spec.Location == time.Local {
+		return t
+	}
+
+	return t.In(spec.Location)
+}
+
+// cronDayMatches reports whether t's day matches the day-of-month and
+// day-of-week fields - when both are restricted, matching either is enough
+func cronDayMatches(spec *cron.SpecSchedule, t time.Time) bool {
+	domMatch := 1<<uint(t.Day())&spec.Dom > 0
+	dowMatch := 1<<uint(t.Weekday())&spec.Dow > 0
+
+	if spec.Dom&cronStarBit > 0 || spec.Dow&cronStarBit > 0 {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
+
+// cronFloor returns the second before start, the start of the month/day/hour
+// containing t. When start is an ambiguous wall time (during a daylight saving
+// time transition) it may resolve to after t, so step back an hour to keep
+// moving backwards.
+func cronFloor(t, start time.Time) time.Time {
+	if start.After(t) {
+		start = start.Add(-time.Hour)
+	}
+
+	return start.Add(-time.Second)
+}
@@ -0,0 +1,142 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronNext(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2024, 5, 1, 12, 30, 15, 0, time.UTC)
+
+	testdata := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2024, 5, 1, 12, 31, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2024, 5, 2, 3, 0, 0, 0, time.UTC)},
+		{"30 * * * * *", time.Date(2024, 5, 1, 12, 30, 30, 0, time.UTC)},
+		{"@monthly", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 9 * * MON-FRI", time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)},
+		{"@every 1h", time.Date(2024, 5, 1, 13, 30, 15, 0, time.UTC)},
+		{"CRON_TZ=America/New_York 0 3 * * *", time.Date(2024, 5, 2, 7, 0, 0, 0, time.UTC)},
+	}
+
+	for _, d := range testdata {
+		got, err := CronNext(d.expr, from)
+		require.NoError(t, err, d.expr)
+		assert.Equal(t, d.want, got, d.expr)
+		assert.Equal(t, time.UTC, got.Location(), d.expr)
+	}
+
+	_, err := CronNext("bogus", from)
+	require.Error(t, err)
+
+	_, err = CronNext("0 0 30 2 *", from)
+	require.Error(t, err)
+}
+
+func TestCronPrev(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2024, 5, 1, 12, 30, 15, 0, time.UTC)
+
+	testdata := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC)},
+		{"30 * * * * *", time.Date(2024, 5, 1, 12, 29, 30, 0, time.UTC)},
+		{"@monthly", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 9 * * MON-FRI", time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * SAT", time.Date(2024, 4, 27, 9, 0, 0, 0, time.UTC)},
+		// day-of-month or day-of-week
+		{"0 0 15 * FRI", time.Date(2024, 4, 26, 0, 0, 0, 0, time.UTC)},
+		{"59 23 31 12 *", time.Date(2023, 12, 31, 23, 59, 0, 0, time.UTC)},
+		{"CRON_TZ=America/New_York 0 3 * * *", time.Date(2024, 5, 1, 7, 0, 0, 0, time.UTC)},
+	}
+
+	for _, d := range testdata {
+		got, err := CronPrev(d.expr, from)
+		require.NoError(t, err, d.expr)
+		assert.Equal(t, d.want, got, d.expr)
+		assert.Equal(t, time.UTC, got.Location(), d.expr)
+	}
+
+	// strictly before the given time
+	got, err := CronPrev("0 3 * * *", time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 4, 30, 3, 0, 0, 0, time.UTC), got)
+
+	got, err = CronPrev("0 3 * * *", time.Date(2024, 5, 1, 3, 0, 0, 1, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC), got)
+
+	// across daylight saving time transitions
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	got, err = CronPrev("30 1 * * *", time.Date(2024, 11, 3, 12, 0, 0, 0, ny))
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.Hour())
+	assert.Equal(t, 30, got.Minute())
+	assert.Equal(t, 3, got.Day())
+
+	got, err = CronPrev("0 * * * *", time.Date(2024, 3, 10, 3, 30, 0, 0, ny))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 10, 3, 0, 0, 0, ny), got)
+
+	got, err = CronPrev("0 * * * *", time.Date(2024, 3, 10, 3, 0, 0, 0, ny))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 10, 1, 0, 0, 0, ny), got)
+
+	_, err = CronPrev("bogus", from)
+	require.Error(t, err)
+
+	_, err = CronPrev("@every 1h", from)
+	require.Error(t, err)
+
+	_, err = CronPrev("0 0 30 2 *", from)
+	require.Error(t, err)
+}
+
+func TestCronMatches(t *testing.T) {
+	t.Parallel()
+
+	testdata := []struct {
+		expr string
+		t    time.Time
+		want bool
+	}{
+		{"* * * * *", time.Date(2024, 5, 1, 12, 30, 15, 0, time.UTC), true},
+		{"30 12 * * *", time.Date(2024, 5, 1, 12, 30, 15, 0, time.UTC), true},
+		{"31 12 * * *", time.Date(2024, 5, 1, 12, 30, 15, 0, time.UTC), false},
+		{"0 30 12 * * *", time.Date(2024, 5, 1, 12, 30, 15, 0, time.UTC), false},
+		{"15 30 12 * * *", time.Date(2024, 5, 1, 12, 30, 15, 0, time.UTC), true},
+		{"* 0-6 * * SAT,SUN", time.Date(2024, 5, 4, 2, 0, 0, 0, time.UTC), true},
+		{"* 0-6 * * SAT,SUN", time.Date(2024, 5, 6, 2, 0, 0, 0, time.UTC), false},
+		{"@daily", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), true},
+		{"CRON_TZ=Asia/Tokyo 0 9 * * *", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), true},
+		{"TZ=Asia/Tokyo 0 9 * * *", time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, d := range testdata {
+		got, err := CronMatches(d.expr, d.t)
+		require.NoError(t, err, d.expr)
+		assert.Equal(t, d.want, got, "%s at %s", d.expr, d.t)
+	}
+
+	_, err := CronMatches("bogus", time.Now())
+	require.Error(t, err)
+
+	_, err = CronMatches("@every 1h", time.Now())
+	require.Error(t, err)
+}
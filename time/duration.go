@@ -0,0 +1,145 @@
+package time
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Day and Week are the lengths of a day and a week, as used by
+// [ParseDuration] and [FormatDuration]. They ignore daylight saving time
+// transitions.
+const (
+	Day  = 24 * time.Hour
+	Week = 7 * Day
+)
+
+// ParseDuration - parse a duration string, like [time.ParseDuration], but also
+// supporting the units "d" (days) and "w" (weeks), such as "3d12h" or "1.5w".
+func ParseDuration(s string) (time.Duration, error) {
+	in := s
+
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+
+	// the days and weeks are summed separately, and the rest of the string is
+	// left to the time package
+	var extra time.Duration
+
+	rest := &strings.Builder{}
+
+	if s == "" {
+		return 0, fmt.Errorf("invalid duration %q", in)
+	}
+
+	for s != "" {
+		i := strings.IndexFunc(s, func(r rune) bool {
+			return (r < '0' || r > '9') && r != '.'
+		})
+		if i < 0 {
+			i = len(s)
+		}
+
+		if i == 0 {
+			return 0, fmt.Errorf("invalid duration %q", in)
+		}
+
+		num := s[:i]
+		s = s[i:]
+
+		j := strings.IndexFunc(s, func(r rune) bool {
+			return (r >= '0' && r <= '9') || r == '.'
+		})
+		if j < 0 {
+			j = len(s)
+		}
+
+		unit := s[:j]
+		s = s[j:]
+
+		switch unit {
+		case "d", "w":
+			f, err := strconv.ParseFloat(num, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", in)
+			}
+
+			u := Day
+			if unit == "w" {
+				u = Week
+			}
+
+			extra += time.Duration(f * float64(u))
+		default:
+			rest.WriteString(num)
+			rest.WriteString(unit)
+		}
+	}
+
+	var d time.Duration
+
+	if rest.Len() > 0 {
+		var err error
+
+		d, err = time.ParseDuration(rest.String())
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", in, err)
+		}
+	}
+
+	d += extra
+	if neg {
+		d = -d
+	}
+
+	return d, nil
+}
+
+// FormatDuration - format a duration using days, hours, minutes, and seconds,
+// omitting zero units, such as "3d12h" or "1h30m". Durations shorter than a
+// second are formatted as by [time.Duration.String], such as "250ms".
+func FormatDuration(d time.Duration) string {
+	if d < time.Second && d > -time.Second {
+		return d.String()
+	}
+
+	out := &strings.Builder{}
+
+	// avoid overflow when negating the minimum duration
+	u := uint64(d)
+	if d < 0 {
+		out.WriteByte('-')
+
+		u = -u
+	}
+
+	units := []struct {
+		suffix string
+		size   uint64
+	}{
+		{"d", uint64(Day)},
+		{"h", uint64(time.Hour)},
+		{"m", uint64(time.Minute)},
+	}
+
+	for _, unit := range units {
+		if n := u / unit.size; n > 0 {
+			out.WriteString(strconv.FormatUint(n, 10))
+			out.WriteString(unit.suffix)
+
+			u %= unit.size
+		}
+	}
+
+	if u > 0 {
+		secs := float64(u) / float64(time.Second)
+		out.WriteString(strconv.FormatFloat(secs, 'f', -1, 64))
+		out.WriteByte('s')
+	}
+
+	return out.String()
+}
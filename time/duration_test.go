@@ -0,0 +1,73 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDuration(t *testing.T) {
+	t.Parallel()
+
+	testdata := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"0", 0},
+		{"0s", 0},
+		{"1h30m", 90 * time.Minute},
+		{"-1.5h", -90 * time.Minute},
+		{"300ms", 300 * time.Millisecond},
+		{"3d12h", 84 * time.Hour},
+		{"1d", 24 * time.Hour},
+		{"+2w", 14 * 24 * time.Hour},
+		{"1.5d", 36 * time.Hour},
+		{"1w2d3h4m5s", (9*24+3)*time.Hour + 4*time.Minute + 5*time.Second},
+		{"-1d1µs", -(24*time.Hour + time.Microsecond)},
+	}
+
+	for _, d := range testdata {
+		got, err := ParseDuration(d.in)
+		require.NoError(t, err, d.in)
+		assert.Equal(t, d.want, got, d.in)
+	}
+
+	for _, in := range []string{"", "-", "d", "1", "1x", "1..2d", "1d2", "h1", "1d-2h"} {
+		_, err := ParseDuration(in)
+		require.Error(t, err, in)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	t.Parallel()
+
+	testdata := []struct {
+		in   time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{250 * time.Millisecond, "250ms"},
+		{-250 * time.Millisecond, "-250ms"},
+		{time.Second, "1s"},
+		{90 * time.Minute, "1h30m"},
+		{84 * time.Hour, "3d12h"},
+		{-84 * time.Hour, "-3d12h"},
+		{15 * 24 * time.Hour, "15d"},
+		{24*time.Hour + 1500*time.Millisecond, "1d1.5s"},
+		{time.Duration(1<<63 - 1), "106751d23h47m16.854775807s"},
+		{time.Duration(-1 << 63), "-106751d23h47m16.854775808s"},
+	}
+
+	for _, d := range testdata {
+		assert.Equal(t, d.want, FormatDuration(d.in), d.in.String())
+	}
+
+	// round-trip
+	for _, d := range testdata[:9] {
+		got, err := ParseDuration(FormatDuration(d.in))
+		require.NoError(t, err)
+		assert.Equal(t, d.in, got)
+	}
+}
@@ -0,0 +1,154 @@
+package time
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/ar"
+	"github.com/go-playground/locales/bg"
+	"github.com/go-playground/locales/ca"
+	"github.com/go-playground/locales/cs"
+	"github.com/go-playground/locales/da"
+	"github.com/go-playground/locales/de"
+	"github.com/go-playground/locales/el"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/es"
+	"github.com/go-playground/locales/et"
+	"github.com/go-playground/locales/fi"
+	"github.com/go-playground/locales/fr"
+	"github.com/go-playground/locales/he"
+	"github.com/go-playground/locales/hi"
+	"github.com/go-playground/locales/hr"
+	"github.com/go-playground/locales/hu"
+	"github.com/go-playground/locales/id"
+	"github.com/go-playground/locales/it"
+	"github.com/go-playground/locales/ja"
+	"github.com/go-playground/locales/ko"
+	"github.com/go-playground/locales/lt"
+	"github.com/go-playground/locales/lv"
+	"github.com/go-playground/locales/nb"
+	"github.com/go-playground/locales/nl"
+	"github.com/go-playground/locales/pl"
+	"github.com/go-playground/locales/pt"
+	"github.com/go-playground/locales/ro"
+	"github.com/go-playground/locales/ru"
+	"github.com/go-playground/locales/sk"
+	"github.com/go-playground/locales/sl"
+	"github.com/go-playground/locales/sr"
+	"github.com/go-playground/locales/sv"
+	"github.com/go-playground/locales/th"
+	"github.com/go-playground/locales/tr"
+	"github.com/go-playground/locales/uk"
+	"github.com/go-playground/locales/vi"
+	"github.com/go-playground/locales/zh"
+)
+
+// localeTranslators are the supported locales' languages, keyed by language
+// code. Regional variants have very few differences in month and weekday
+// names, so they're not distinguished.
+//
+//nolint:gochecknoglobals
+var localeTranslators = map[string]func() locales.Translator{
+	"ar": ar.New,
+	"bg": bg.New,
+	"ca": ca.New,
+	"cs": cs.New,
+	"da": da.New,
+	"de": de.New,
+	"el": el.New,
+	"en": en.New,
+	"es": es.New,
+	"et": et.New,
+	"fi": fi.New,
+	"fr": fr.New,
+	"he": he.New,
+	"hi": hi.New,
+	"hr": hr.New,
+	"hu": hu.New,
+	"id": id.New,
+	"it": it.New,
+	"ja": ja.New,
+	"ko": ko.New,
+	"lt": lt.New,
+	"lv": lv.New,
+	"nb": nb.New,
+	"no": nb.New,
+	"nl": nl.New,
+	"pl": pl.New,
+	"pt": pt.New,
+	"ro": ro.New,
+	"ru": ru.New,
+	"sk": sk.New,
+	"sl": sl.New,
+	"sr": sr.New,
+	"sv": sv.New,
+	"th": th.New,
+	"tr": tr.New,
+	"uk": uk.New,
+	"vi": vi.New,
+	"zh": zh.New,
+}
+
+// FormatLocale - format t according to the layout (see [time.Time.Format]),
+// with month and weekday names in the locale's language. The locale is a
+// language code, optionally with a region (e.g. "fr", "fr-CA", or "fr_CA").
+func FormatLocale(locale, layout string, t time.Time) (string, error) {
+	tr, err := localeTranslator(locale)
+	if err != nil {
+		return "", err
+	}
+
+	out := &strings.Builder{}
+	start := 0
+
+	// find the month and weekday names in the layout, the same way as
+	// time.Time.Format does, and format the rest of the layout as usual
+	for i := 0; i < len(layout); i++ {
+		var name string
+		var n int
+
+		rest := layout[i:]
+
+		switch {
+		case strings.HasPrefix(rest, "January"):
+			name, n = tr.MonthWide(t.Month()), len("January")
+		case strings.HasPrefix(rest, "Jan") && !startsWithLower(rest[3:]):
+			name, n = tr.MonthAbbreviated(t.Month()), len("Jan")
+		case strings.HasPrefix(rest, "Monday"):
+			name, n = tr.WeekdayWide(t.Weekday()), len("Monday")
+		case strings.HasPrefix(rest, "Mon") && !startsWithLower(rest[3:]):
+			name, n = tr.WeekdayAbbreviated(t.Weekday()), len("Mon")
+		default:
+			continue
+		}
+
+		out.WriteString(t.Format(layout[start:i]))
+		out.WriteString(name)
+
+		i += n - 1
+		start = i + 1
+	}
+
+	out.WriteString(t.Format(layout[start:]))
+
+	return out.String(), nil
+}
+
+func localeTranslator(locale string) (locales.Translator, error) {
+	lang, _, _ := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+
+	newTranslator, ok := localeTranslators[strings.ToLower(lang)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported locale %q", locale)
+	}
+
+	return newTranslator(), nil
+}
+
+// startsWithLower mirrors the time package - "Jan" and "Mon" are only
+// recognized in layouts when not followed by a lower-case letter
+func startsWithLower(s string) bool {
+	return len(s) > 0 && 'a' <= s[0] && s[0] <= 'z'
+}
@@ -0,0 +1,40 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatLocale(t *testing.T) {
+	t.Parallel()
+
+	in := time.Date(2024, 3, 4, 15, 4, 5, 0, time.UTC)
+
+	testdata := []struct {
+		locale, layout, want string
+	}{
+		{"en", "Monday, January 2, 2006", "Monday, March 4, 2024"},
+		{"fr", "Monday 2 January 2006", "lundi 4 mars 2024"},
+		{"fr-CA", "Mon 2 Jan", "lun. 4 mars"},
+		{"de_DE", "Monday, 2. January 2006 15:04", "Montag, 4. März 2024 15:04"},
+		{"es", "Mon Jan", "lun. mar."},
+		{"ja", "2006年January2日 (Mon)", "2024年3月4日 (月)"},
+		{"RU", "2 January", "4 марта"},
+		{"nl", "2006-01-02", "2024-03-04"},
+		// not followed by a lower-case letter, so not recognized
+		{"fr", "Monx Janx", "Monx Janx"},
+		{"de", "", ""},
+	}
+
+	for _, d := range testdata {
+		out, err := FormatLocale(d.locale, d.layout, in)
+		require.NoError(t, err, d.locale)
+		assert.Equal(t, d.want, out, "%s %q", d.locale, d.layout)
+	}
+
+	_, err := FormatLocale("xx", "Jan", in)
+	require.Error(t, err)
+}
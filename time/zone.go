@@ -0,0 +1,51 @@
+package time
+
+import (
+	"fmt"
+	"time"
+)
+
+// In - convert t to the named IANA time zone (e.g. "America/New_York"), or
+// "Local" or "UTC"
+func In(zone string, t time.Time) (time.Time, error) {
+	loc, err := loadLocation(zone)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return t.In(loc), nil
+}
+
+// ZoneInfo - details about the named IANA time zone at time t, as a map with
+// the keys "name", "abbreviation", "offset" (in seconds east of UTC),
+// "offsetString" (e.g. "+05:30"), and "isDST"
+func ZoneInfo(zone string, t time.Time) (map[string]interface{}, error) {
+	loc, err := loadLocation(zone)
+	if err != nil {
+		return nil, err
+	}
+
+	t = t.In(loc)
+	abbr, offset := t.Zone()
+
+	return map[string]interface{}{
+		"name":         loc.String(),
+		"abbreviation": abbr,
+		"offset":       offset,
+		"offsetString": t.Format("-07:00"),
+		"isDST":        t.IsDST(),
+	}, nil
+}
+
+func loadLocation(zone string) (*time.Location, error) {
+	if zone == "" {
+		return nil, fmt.Errorf("time zone name must not be empty")
+	}
+
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q: %w", zone, err)
+	}
+
+	return loc, nil
+}
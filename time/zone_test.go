@@ -0,0 +1,61 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIn(t *testing.T) {
+	t.Parallel()
+
+	in := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	out, err := In("Asia/Tokyo", in)
+	require.NoError(t, err)
+	assert.Equal(t, 21, out.Hour())
+	assert.Equal(t, "Asia/Tokyo", out.Location().String())
+	assert.True(t, in.Equal(out))
+
+	out, err = In("UTC", out)
+	require.NoError(t, err)
+	assert.Equal(t, in, out)
+
+	_, err = In("Nowhere/Special", in)
+	require.Error(t, err)
+
+	_, err = In("", in)
+	require.Error(t, err)
+}
+
+func TestZoneInfo(t *testing.T) {
+	t.Parallel()
+
+	summer := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	winter := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	info, err := ZoneInfo("America/New_York", summer)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"name":         "America/New_York",
+		"abbreviation": "EDT",
+		"offset":       -4 * 60 * 60,
+		"offsetString": "-04:00",
+		"isDST":        true,
+	}, info)
+
+	info, err = ZoneInfo("America/New_York", winter)
+	require.NoError(t, err)
+	assert.Equal(t, "EST", info["abbreviation"])
+	assert.Equal(t, false, info["isDST"])
+
+	info, err = ZoneInfo("Asia/Kolkata", winter)
+	require.NoError(t, err)
+	assert.Equal(t, 19800, info["offset"])
+	assert.Equal(t, "+05:30", info["offsetString"])
+
+	_, err = ZoneInfo("Nowhere/Special", winter)
+	require.Error(t, err)
+}
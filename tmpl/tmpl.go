@@ -3,21 +3,77 @@ package tmpl
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"path/filepath"
+	"strings"
 	"text/template"
 )
 
+// DefaultMaxDepth - the default maximum depth of nested template executions
+// (with Exec, Inline, or ExecFromDatasource), to catch infinite recursion
+const DefaultMaxDepth = 100
+
+// SourceReader - reads the contents of a datasource, given its alias or URL
+type SourceReader func(alias string) ([]byte, error)
+
 // Template -
 type Template struct {
 	root       *template.Template
 	defaultCtx interface{}
+	readSource SourceReader
 	path       string
+
+	// names of the templates currently being executed, outermost first
+	stack    []string
+	maxDepth int
 }
 
 // New -
 func New(root *template.Template, tctx interface{}, path string) *Template {
-	return &Template{root, tctx, path}
+	return &Template{root: root, defaultCtx: tctx, path: path}
+}
+
+// SetSourceReader - sets the function used by ExecFromDatasource to read
+// templates from datasources
+func (t *Template) SetSourceReader(read SourceReader) {
+	t.readSource = read
+}
+
+// SetMaxDepth - sets the maximum depth of nested template executions. Zero
+// or less means DefaultMaxDepth.
+func (t *Template) SetMaxDepth(n int) {
+	t.maxDepth = n
+}
+
+// DepthError - returned when the maximum depth of nested template executions
+// is exceeded, usually because of infinite recursion
+type DepthError struct {
+	// Chain - the names of the nested templates which were executing, ending
+	// with the template which couldn't be executed
+	Chain    []string
+	MaxDepth int
+}
+
+func (e *DepthError) Error() string {
+	chain := e.Chain
+
+	// when there's a cycle, only the templates in the cycle are interesting
+	last := chain[len(chain)-1]
+	for i := len(chain) - 2; i >= 0; i-- {
+		if chain[i] == last {
+			chain = chain[i:]
+			return fmt.Sprintf("maximum template depth (%d) exceeded, possibly due to infinite recursion: %s",
+				e.MaxDepth, strings.Join(chain, " -> "))
+		}
+	}
+
+	if len(chain) > 10 {
+		chain = append([]string{"..."}, chain[len(chain)-10:]...)
+	}
+
+	return fmt.Sprintf("maximum template depth (%d) exceeded: %s",
+		e.MaxDepth, strings.Join(chain, " -> "))
 }
 
 // Path - returns the path to the current template if it came from a file.
@@ -56,7 +112,7 @@ func (t *Template) inline(name, in string, ctx interface{}) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	return render(tmpl, ctx)
+	return t.render(tmpl, ctx)
 }
 
 // Exec - execute (render) a template - this is the built-in `template` action, except with output...
@@ -65,11 +121,65 @@ func (t *Template) Exec(name string, tmplcontext ...interface{}) (string, error)
 	if len(tmplcontext) == 1 {
 		ctx = tmplcontext[0]
 	}
+
 	tmpl := t.root.Lookup(name)
 	if tmpl == nil {
-		return "", fmt.Errorf(`template "%s" not defined`, name)
+		return "", fmt.Errorf(`template %q not defined%s`, name, t.root.DefinedTemplates())
 	}
-	return render(tmpl, ctx)
+	return t.render(tmpl, ctx)
+}
+
+// ExecFromDatasource - read a template from a datasource (by alias or URL), and
+// execute it. The template is parsed as part of the current template, so it
+// can use (and define) named templates.
+func (t *Template) ExecFromDatasource(alias string, tmplcontext ...interface{}) (string, error) {
+	ctx := t.defaultCtx
+	if len(tmplcontext) == 1 {
+		ctx = tmplcontext[0]
+	}
+
+	if t.readSource == nil {
+		return "", fmt.Errorf("can not read template %q: no datasource reader available", alias)
+	}
+
+	b, err := t.readSource(alias)
+	if err != nil {
+		return "", fmt.Errorf("read template %q: %w", alias, err)
+	}
+
+	tmpl, err := t.root.New(alias).Parse(string(b))
+	if err != nil {
+		return "", fmt.Errorf("parse template %q: %w", alias, err)
+	}
+
+	return t.render(tmpl, ctx)
+}
+
+// render executes the template, tracking the depth of nested executions so
+// that infinite recursion results in an error rather than a crash
+func (t *Template) render(tmpl *template.Template, ctx interface{}) (string, error) {
+	maxDepth := t.maxDepth
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxDepth
+	}
+
+	if len(t.stack) >= maxDepth {
+		chain := append(append([]string{}, t.stack...), tmpl.Name())
+		return "", &DepthError{Chain: chain, MaxDepth: maxDepth}
+	}
+
+	t.stack = append(t.stack, tmpl.Name())
+	defer func() { t.stack = t.stack[:len(t.stack)-1] }()
+
+	out, err := render(tmpl, ctx)
+
+	// avoid wrapping the error at every level of nesting
+	var depthErr *DepthError
+	if errors.As(err, &depthErr) {
+		return "", depthErr
+	}
+
+	return out, err
 }
 
 func render(tmpl *template.Template, ctx interface{}) (string, error) {
@@ -1,6 +1,7 @@
 package tmpl
 
 import (
+	"fmt"
 	"testing"
 	"text/template"
 
@@ -120,3 +121,115 @@ func TestPathDir(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "foo", p)
 }
+
+func TestExecFromDatasource(t *testing.T) {
+	sources := map[string]string{
+		"greeting": `hello, {{ . }}`,
+		"lib":      `{{ define "shout" }}{{ . | printf "%s!" }}{{ end }}library loaded`,
+		"bad":      `{{ oops`,
+	}
+
+	root := template.New("root")
+	tmpl := New(root, "world", "")
+
+	_, err := tmpl.ExecFromDatasource("greeting")
+	require.Error(t, err)
+
+	tmpl.SetSourceReader(func(alias string) ([]byte, error) {
+		s, ok := sources[alias]
+		if !ok {
+			return nil, fmt.Errorf("undefined datasource %q", alias)
+		}
+		return []byte(s), nil
+	})
+
+	out, err := tmpl.ExecFromDatasource("greeting")
+	require.NoError(t, err)
+	assert.Equal(t, "hello, world", out)
+
+	out, err = tmpl.ExecFromDatasource("greeting", "there")
+	require.NoError(t, err)
+	assert.Equal(t, "hello, there", out)
+
+	// templates defined in the datasource can be used afterwards
+	out, err = tmpl.ExecFromDatasource("lib")
+	require.NoError(t, err)
+	assert.Equal(t, "library loaded", out)
+
+	out, err = tmpl.Exec("shout", "hey")
+	require.NoError(t, err)
+	assert.Equal(t, "hey!", out)
+
+	_, err = tmpl.ExecFromDatasource("bad")
+	require.Error(t, err)
+
+	_, err = tmpl.ExecFromDatasource("missing")
+	require.Error(t, err)
+}
+
+func TestExecComputedName(t *testing.T) {
+	root := template.New("root")
+	template.Must(root.New("kind_web").Parse("web"))
+	tmpl := New(root, nil, "")
+
+	out, err := tmpl.Exec("kind_" + "web")
+	require.NoError(t, err)
+	assert.Equal(t, "web", out)
+
+	_, err = tmpl.Exec("kind_db")
+	assert.ErrorContains(t, err, `template "kind_db" not defined; defined templates are: `)
+	assert.ErrorContains(t, err, `"kind_web"`)
+}
+
+func TestExecMaxDepth(t *testing.T) {
+	root := template.New("root")
+	tmpl := New(root, nil, "")
+	root.Funcs(template.FuncMap{
+		"tmpl": func() *Template { return tmpl },
+		"tpl":  tmpl.Inline,
+	})
+
+	template.Must(root.New("a").Parse(`{{ tmpl.Exec "b" }}`))
+	template.Must(root.New("b").Parse(`{{ tmpl.Exec "a" }}`))
+	template.Must(root.New("count").Parse(
+		`{{ . }}{{ if . }} {{ tmpl.Exec "count" (slice . 1) }}{{ end }}`))
+
+	_, err := tmpl.Exec("a")
+	require.Error(t, err)
+
+	var depthErr *DepthError
+	require.ErrorAs(t, err, &depthErr)
+	assert.Equal(t, DefaultMaxDepth, depthErr.MaxDepth)
+	assert.Len(t, depthErr.Chain, DefaultMaxDepth+1)
+	assert.EqualError(t, err, "maximum template depth (100) exceeded, possibly due to infinite recursion: a -> b -> a")
+
+	// the stack is unwound after an error
+	assert.Empty(t, tmpl.stack)
+
+	out, err := tmpl.Exec("count", "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "abc bc c ", out)
+
+	tmpl.SetMaxDepth(3)
+	_, err = tmpl.Exec("count", "abc")
+	require.ErrorAs(t, err, &depthErr)
+	assert.Equal(t, 3, depthErr.MaxDepth)
+
+	// inline templates count too
+	template.Must(root.New("inline").Parse(`{{ tpl "{{ tmpl.Exec \"inline\" }}" }}`))
+	_, err = tmpl.Exec("inline")
+	require.ErrorAs(t, err, &depthErr)
+}
+
+func TestDepthError(t *testing.T) {
+	err := &DepthError{Chain: []string{"a", "b", "c", "b"}, MaxDepth: 3}
+	assert.EqualError(t, err, "maximum template depth (3) exceeded, possibly due to infinite recursion: b -> c -> b")
+
+	chain := []string{}
+	for i := 0; i < 15; i++ {
+		chain = append(chain, fmt.Sprintf("t%d", i))
+	}
+
+	err = &DepthError{Chain: chain, MaxDepth: 14}
+	assert.EqualError(t, err, "maximum template depth (14) exceeded: ... -> t5 -> t6 -> t7 -> t8 -> t9 -> t10 -> t11 -> t12 -> t13 -> t14")
+}